@@ -0,0 +1,76 @@
+// Code generated by mkops.go; DO NOT EDIT.
+
+package amd64
+
+import (
+	"cmd/compile/internal/ssa"
+	"cmd/compile/internal/ssagen"
+)
+
+// ssaGenSIMDValue generates code for v if it is a SIMD operation,
+// and reports whether it did so.
+func ssaGenSIMDValue(s *ssagen.State, v *ssa.Value) bool {
+	switch v.Op {
+	case ssa.OpAMD64VPABSB,
+		ssa.OpAMD64VPABSD,
+		ssa.OpAMD64VPABSQ,
+		ssa.OpAMD64VPABSW,
+		ssa.OpAMD64VSQRTPD,
+		ssa.OpAMD64VSQRTPS:
+		simdV11(s, v)
+	case ssa.OpAMD64VADDPD,
+		ssa.OpAMD64VADDPS,
+		ssa.OpAMD64VDIVPD,
+		ssa.OpAMD64VDIVPS,
+		ssa.OpAMD64VMAXPD,
+		ssa.OpAMD64VMAXPS,
+		ssa.OpAMD64VMINPD,
+		ssa.OpAMD64VMINPS,
+		ssa.OpAMD64VMULPD,
+		ssa.OpAMD64VMULPS,
+		ssa.OpAMD64VPADDB,
+		ssa.OpAMD64VPADDD,
+		ssa.OpAMD64VPADDQ,
+		ssa.OpAMD64VPADDW,
+		ssa.OpAMD64VPAND,
+		ssa.OpAMD64VPANDN,
+		ssa.OpAMD64VPCMPEQB,
+		ssa.OpAMD64VPCMPEQD,
+		ssa.OpAMD64VPCMPEQQ,
+		ssa.OpAMD64VPCMPEQW,
+		ssa.OpAMD64VPCMPGTB,
+		ssa.OpAMD64VPCMPGTD,
+		ssa.OpAMD64VPCMPGTQ,
+		ssa.OpAMD64VPCMPGTW,
+		ssa.OpAMD64VPMAXSB,
+		ssa.OpAMD64VPMAXSD,
+		ssa.OpAMD64VPMAXSQ,
+		ssa.OpAMD64VPMAXSW,
+		ssa.OpAMD64VPMAXUB,
+		ssa.OpAMD64VPMAXUD,
+		ssa.OpAMD64VPMAXUQ,
+		ssa.OpAMD64VPMAXUW,
+		ssa.OpAMD64VPMINSB,
+		ssa.OpAMD64VPMINSD,
+		ssa.OpAMD64VPMINSQ,
+		ssa.OpAMD64VPMINSW,
+		ssa.OpAMD64VPMINUB,
+		ssa.OpAMD64VPMINUD,
+		ssa.OpAMD64VPMINUQ,
+		ssa.OpAMD64VPMINUW,
+		ssa.OpAMD64VPMULLD,
+		ssa.OpAMD64VPMULLW,
+		ssa.OpAMD64VPOR,
+		ssa.OpAMD64VPSUBB,
+		ssa.OpAMD64VPSUBD,
+		ssa.OpAMD64VPSUBQ,
+		ssa.OpAMD64VPSUBW,
+		ssa.OpAMD64VPXOR,
+		ssa.OpAMD64VSUBPD,
+		ssa.OpAMD64VSUBPS:
+		simdV21(s, v)
+	default:
+		return false
+	}
+	return true
+}
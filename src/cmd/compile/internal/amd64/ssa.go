@@ -60,6 +60,9 @@ func loadByType(t *types.Type) obj.As {
 
 // storeByType returns the store instruction of the given type.
 func storeByType(t *types.Type) obj.As {
+	if t.IsSIMD() {
+		return x86.AVMOVDQU
+	}
 	width := t.Size()
 	if t.IsFloat() {
 		switch width {
@@ -87,6 +90,9 @@ func storeByType(t *types.Type) obj.As {
 
 // moveByType returns the reg->reg move instruction of the given type.
 func moveByType(t *types.Type) obj.As {
+	if t.IsSIMD() {
+		return x86.AVMOVDQU
+	}
 	if t.IsFloat() {
 		// Moving the whole sse2 register is faster
 		// than moving just the correct low portion of it.
@@ -1039,6 +1045,9 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		}
 		x := v.Args[0].Reg()
 		y := v.Reg()
+		if v.Type.IsSIMD() {
+			x, y = simdReg(v.Args[0]), simdReg(v)
+		}
 		if x != y {
 			opregreg(s, moveByType(v.Type), y, x)
 		}
@@ -1051,6 +1060,9 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		ssagen.AddrAuto(&p.From, v.Args[0])
 		p.To.Type = obj.TYPE_REG
 		p.To.Reg = v.Reg()
+		if v.Type.IsSIMD() {
+			p.To.Reg = simdReg(v)
+		}
 
 	case ssa.OpStoreReg:
 		if v.Type.IsFlags() {
@@ -1060,7 +1072,32 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		p := s.Prog(storeByType(v.Type))
 		p.From.Type = obj.TYPE_REG
 		p.From.Reg = v.Args[0].Reg()
+		if v.Type.IsSIMD() {
+			p.From.Reg = simdReg(v.Args[0])
+		}
 		ssagen.AddrAuto(&p.To, v)
+	case ssa.OpAMD64VMOVDQUload:
+		p := s.Prog(v.Op.Asm())
+		p.From.Type = obj.TYPE_MEM
+		p.From.Reg = v.Args[0].Reg()
+		ssagen.AddAux(&p.From, v)
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = simdReg(v)
+	case ssa.OpAMD64VMOVDQUstore:
+		p := s.Prog(v.Op.Asm())
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = simdReg(v.Args[1])
+		p.To.Type = obj.TYPE_MEM
+		p.To.Reg = v.Args[0].Reg()
+		ssagen.AddAux(&p.To, v)
+	case ssa.OpAMD64VPXORzero:
+		r := simdReg(v)
+		p := s.Prog(v.Op.Asm())
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = r
+		p.AddRestSourceReg(r)
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = r
 	case ssa.OpAMD64LoweredHasCPUFeature:
 		p := s.Prog(x86.AMOVBLZX)
 		p.From.Type = obj.TYPE_MEM
@@ -1405,10 +1442,52 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		p.To.Type = obj.TYPE_REG
 		p.To.Reg = v.Reg()
 	default:
-		v.Fatalf("genValue not implemented: %s", v.LongString())
+		if !ssaGenSIMDValue(s, v) {
+			v.Fatalf("genValue not implemented: %s", v.LongString())
+		}
 	}
 }
 
+// simdReg returns the register holding SIMD value v,
+// using the Y form of the register for 256-bit vectors.
+func simdReg(v *ssa.Value) int16 {
+	t := v.Type
+	if !t.IsSIMD() {
+		base.Fatalf("simdReg: not a SIMD type: %v", v.LongString())
+	}
+	switch t.Size() {
+	case 16:
+		return v.Reg()
+	case 32:
+		return v.Reg() + (x86.REG_Y0 - x86.REG_X0)
+	}
+	base.Fatalf("simdReg: bad SIMD width %d: %v", t.Size(), v.LongString())
+	return 0
+}
+
+// simdV11 generates code for a SIMD operation with one vector input.
+func simdV11(s *ssagen.State, v *ssa.Value) *obj.Prog {
+	p := s.Prog(v.Op.Asm())
+	p.From.Type = obj.TYPE_REG
+	p.From.Reg = simdReg(v.Args[0])
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = simdReg(v)
+	return p
+}
+
+// simdV21 generates code for a SIMD operation with two vector inputs.
+// The assembler takes the operands in reverse order, so the result is
+// v.Args[0] op v.Args[1].
+func simdV21(s *ssagen.State, v *ssa.Value) *obj.Prog {
+	p := s.Prog(v.Op.Asm())
+	p.From.Type = obj.TYPE_REG
+	p.From.Reg = simdReg(v.Args[1])
+	p.AddRestSourceReg(simdReg(v.Args[0]))
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = simdReg(v)
+	return p
+}
+
 var blockJump = [...]struct {
 	asm, invasm obj.As
 }{
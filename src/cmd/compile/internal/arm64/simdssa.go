@@ -0,0 +1,44 @@
+// Code generated by mkops.go; DO NOT EDIT.
+
+package arm64
+
+import (
+	"cmd/compile/internal/ssa"
+	"cmd/compile/internal/ssagen"
+	"cmd/internal/obj/arm64"
+)
+
+// ssaGenSIMDValue generates code for v if it is a SIMD operation,
+// and reports whether it did so.
+func ssaGenSIMDValue(s *ssagen.State, v *ssa.Value) bool {
+	switch v.Op {
+	case ssa.OpARM64VADD16B,
+		ssa.OpARM64VAND16B,
+		ssa.OpARM64VCMEQ16B,
+		ssa.OpARM64VEOR16B,
+		ssa.OpARM64VORR16B,
+		ssa.OpARM64VSUB16B,
+		ssa.OpARM64VUMAX16B,
+		ssa.OpARM64VUMIN16B:
+		simdV21(s, v, arm64.ARNG_16B)
+	case ssa.OpARM64VADD2D,
+		ssa.OpARM64VCMEQ2D,
+		ssa.OpARM64VSUB2D:
+		simdV21(s, v, arm64.ARNG_2D)
+	case ssa.OpARM64VADD4S,
+		ssa.OpARM64VCMEQ4S,
+		ssa.OpARM64VSUB4S,
+		ssa.OpARM64VUMAX4S,
+		ssa.OpARM64VUMIN4S:
+		simdV21(s, v, arm64.ARNG_4S)
+	case ssa.OpARM64VADD8H,
+		ssa.OpARM64VCMEQ8H,
+		ssa.OpARM64VSUB8H,
+		ssa.OpARM64VUMAX8H,
+		ssa.OpARM64VUMIN8H:
+		simdV21(s, v, arm64.ARNG_8H)
+	default:
+		return false
+	}
+	return true
+}
@@ -20,6 +20,9 @@ import (
 
 // loadByType returns the load instruction of the given type.
 func loadByType(t *types.Type) obj.As {
+	if t.IsSIMD() && t.Size() == 16 {
+		return arm64.AFMOVQ
+	}
 	if t.IsFloat() {
 		switch t.Size() {
 		case 4:
@@ -56,6 +59,9 @@ func loadByType(t *types.Type) obj.As {
 
 // storeByType returns the store instruction of the given type.
 func storeByType(t *types.Type) obj.As {
+	if t.IsSIMD() && t.Size() == 16 {
+		return arm64.AFMOVQ
+	}
 	if t.IsFloat() {
 		switch t.Size() {
 		case 4:
@@ -172,6 +178,14 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		if x == y {
 			return
 		}
+		if v.Type.IsSIMD() {
+			p := s.Prog(arm64.AVMOV)
+			p.From.Type = obj.TYPE_REG
+			p.From.Reg = simdRegArng(x, arm64.ARNG_16B)
+			p.To.Type = obj.TYPE_REG
+			p.To.Reg = simdRegArng(y, arm64.ARNG_16B)
+			return
+		}
 		as := arm64.AMOVD
 		if v.Type.IsFloat() {
 			switch v.Type.Size() {
@@ -1255,8 +1269,32 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		p.From.Offset = int64(x)
 		p.To.Type = obj.TYPE_REG
 		p.To.Reg = v.Reg()
+	case ssa.OpARM64FMOVQload:
+		p := s.Prog(v.Op.Asm())
+		p.From.Type = obj.TYPE_MEM
+		p.From.Reg = v.Args[0].Reg()
+		ssagen.AddAux(&p.From, v)
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = v.Reg()
+	case ssa.OpARM64FMOVQstore:
+		p := s.Prog(v.Op.Asm())
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = v.Args[1].Reg()
+		p.To.Type = obj.TYPE_MEM
+		p.To.Reg = v.Args[0].Reg()
+		ssagen.AddAux(&p.To, v)
+	case ssa.OpARM64VEORzero:
+		r := simdRegArng(v.Reg(), arm64.ARNG_16B)
+		p := s.Prog(v.Op.Asm())
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = r
+		p.Reg = r
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = r
 	default:
-		v.Fatalf("genValue not implemented: %s", v.LongString())
+		if !ssaGenSIMDValue(s, v) {
+			v.Fatalf("genValue not implemented: %s", v.LongString())
+		}
 	}
 }
 
@@ -1448,3 +1486,21 @@ func spillArgReg(pp *objw.Progs, p *obj.Prog, f *ssa.Func, t *types.Type, reg in
 	p.Pos = p.Pos.WithNotStmt()
 	return p
 }
+
+// simdRegArng returns the encoding of vector register reg
+// with the given arrangement, such as V1.S4.
+func simdRegArng(reg int16, arng int16) int16 {
+	return (reg-arm64.REG_F0)&31 + arm64.REG_ARNG + ((arng & 15) << 5)
+}
+
+// simdV21 generates code for an element-wise vector operation
+// with two inputs, computing v.Args[0] op v.Args[1].
+func simdV21(s *ssagen.State, v *ssa.Value, arng int16) *obj.Prog {
+	p := s.Prog(v.Op.Asm())
+	p.From.Type = obj.TYPE_REG
+	p.From.Reg = simdRegArng(v.Args[1].Reg(), arng)
+	p.Reg = simdRegArng(v.Args[0].Reg(), arng)
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = simdRegArng(v.Reg(), arng)
+	return p
+}
@@ -1156,7 +1156,7 @@ func init() {
 		//
 		// output[i] = input.
 		{name: "PSHUFBbroadcast", argLength: 1, reg: fp11, resultInArg0: true, asm: "PSHUFB"}, // PSHUFB with mask zero, (GOAMD64=v1)
-		{name: "VPBROADCASTB", argLength: 1, reg: gpfp, asm: "VPBROADCASTB"}, // Broadcast input byte from gp (GOAMD64=v3)
+		{name: "VPBROADCASTB", argLength: 1, reg: gpfp, asm: "VPBROADCASTB"},                  // Broadcast input byte from gp (GOAMD64=v3)
 
 		// Byte negate/zero/preserve (GOAMD64=v2).
 		//
@@ -1188,7 +1188,14 @@ func init() {
 		//
 		// output[i] = (input[i] >> 7) & 1
 		{name: "PMOVMSKB", argLength: 1, reg: fpgp, asm: "PMOVMSKB"},
+
+		// SIMD vector load, store, and zero (for GOEXPERIMENT=simd).
+		// The vector width (16 or 32 bytes) is the size of the value's type.
+		{name: "VMOVDQUload", argLength: 2, reg: fpload, asm: "VMOVDQU", aux: "SymOff", faultOnNilArg0: true, symEffect: "Read"},                // load a vector from arg0+auxint+aux. arg1=mem
+		{name: "VMOVDQUstore", argLength: 3, reg: fpstore, asm: "VMOVDQU", aux: "SymOff", typ: "Mem", faultOnNilArg0: true, symEffect: "Write"}, // store vector arg1 to arg0+auxint+aux. arg2=mem
+		{name: "VPXORzero", argLength: 0, reg: fp01, asm: "VPXOR", rematerializeable: true},                                                     // zero vector
 	}
+	AMD64ops = append(AMD64ops, simdAMD64Ops(fp11, fp21)...)
 
 	var AMD64blocks = []blockData{
 		{name: "EQ", controls: 1},
@@ -1219,6 +1226,7 @@ func init() {
 		name:               "AMD64",
 		pkg:                "cmd/internal/obj/x86",
 		genfile:            "../../amd64/ssa.go",
+		genSIMDfile:        "../../amd64/simdssa.go",
 		ops:                AMD64ops,
 		blocks:             AMD64blocks,
 		regnames:           regNamesAMD64,
@@ -1226,6 +1234,7 @@ func init() {
 		ParamFloatRegNames: "X0 X1 X2 X3 X4 X5 X6 X7 X8 X9 X10 X11 X12 X13 X14",
 		gpregmask:          gp,
 		fpregmask:          fp,
+		simdregmask:        fp,
 		specialregmask:     x15,
 		framepointerreg:    int8(num["BP"]),
 		linkreg:            -1, // not used
@@ -131,10 +131,13 @@ func init() {
 		gpspsbg    = gpspg | buildReg("SB")
 		fp         = buildReg("F0 F1 F2 F3 F4 F5 F6 F7 F8 F9 F10 F11 F12 F13 F14 F15 F16 F17 F18 F19 F20 F21 F22 F23 F24 F25 F26 F27 F28 F29 F30 F31")
 		callerSave = gp | fp | buildReg("g") // runtime.setg (and anything calling it) may clobber g
-		r0         = buildReg("R0")
-		r1         = buildReg("R1")
-		r2         = buildReg("R2")
-		r3         = buildReg("R3")
+		// SIMD vectors (for GOEXPERIMENT=simd) only live in V0-V15,
+		// which are the registers asyncPreempt saves in full.
+		vec = buildReg("F0 F1 F2 F3 F4 F5 F6 F7 F8 F9 F10 F11 F12 F13 F14 F15")
+		r0  = buildReg("R0")
+		r1  = buildReg("R1")
+		r2  = buildReg("R2")
+		r3  = buildReg("R3")
 	)
 	// Common regInfo
 	var (
@@ -174,6 +177,11 @@ func init() {
 		fpstore2       = regInfo{inputs: []regMask{gpspsbg, gpg, fp}}
 		readflags      = regInfo{inputs: nil, outputs: []regMask{gp}}
 		prefreg        = regInfo{inputs: []regMask{gpspsbg}}
+		v01            = regInfo{inputs: nil, outputs: []regMask{vec}}
+		v11            = regInfo{inputs: []regMask{vec}, outputs: []regMask{vec}}
+		v21            = regInfo{inputs: []regMask{vec, vec}, outputs: []regMask{vec}}
+		vload          = regInfo{inputs: []regMask{gpspsbg}, outputs: []regMask{vec}}
+		vstore         = regInfo{inputs: []regMask{gpspsbg, vec}}
 	)
 	ops := []opData{
 		// binary ops
@@ -755,7 +763,13 @@ func init() {
 
 		// Publication barrier
 		{name: "DMB", argLength: 1, aux: "Int64", asm: "DMB", hasSideEffects: true}, // Do data barrier. arg0=memory, aux=option.
+
+		// SIMD vector load, store, and zero (for GOEXPERIMENT=simd).
+		{name: "FMOVQload", argLength: 2, reg: vload, aux: "SymOff", asm: "FMOVQ", faultOnNilArg0: true, symEffect: "Read"},                // load 16 bytes from arg0 + auxInt + aux.  arg1=mem.
+		{name: "FMOVQstore", argLength: 3, reg: vstore, aux: "SymOff", asm: "FMOVQ", typ: "Mem", faultOnNilArg0: true, symEffect: "Write"}, // store 16 bytes of arg1 to arg0 + auxInt + aux.  arg2=mem.
+		{name: "VEORzero", argLength: 0, reg: v01, asm: "VEOR", rematerializeable: true},                                                   // zero vector
 	}
+	ops = append(ops, simdARM64Ops(v11, v21)...)
 
 	blocks := []blockData{
 		{name: "EQ", controls: 1},
@@ -794,6 +808,7 @@ func init() {
 		name:               "ARM64",
 		pkg:                "cmd/internal/obj/arm64",
 		genfile:            "../../arm64/ssa.go",
+		genSIMDfile:        "../../arm64/simdssa.go",
 		ops:                ops,
 		blocks:             blocks,
 		regnames:           regNamesARM64,
@@ -801,6 +816,7 @@ func init() {
 		ParamFloatRegNames: "F0 F1 F2 F3 F4 F5 F6 F7 F8 F9 F10 F11 F12 F13 F14 F15",
 		gpregmask:          gp,
 		fpregmask:          fp,
+		simdregmask:        vec,
 		framepointerreg:    -1, // not used
 		linkreg:            int8(num["R30"]),
 	})
@@ -530,6 +530,9 @@ var genericOps = []opData{
 	{name: "ArrayMake1", argLength: 1},                // Returns array with 1 element
 	{name: "ArraySelect", argLength: 1, aux: "Int64"}, // arg0=array, auxint=index. Returns a[i].
 
+	// SIMD vectors
+	{name: "ZeroSIMD"}, // Returns the zero value of a SIMD vector type.
+
 	// Spill&restore ops for the register allocator. These are
 	// semantically identical to OpCopy; they do not take/return
 	// stores like regular memory ops do. We can get away without memory
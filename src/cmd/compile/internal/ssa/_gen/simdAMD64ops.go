@@ -0,0 +1,64 @@
+// Code generated by mkops.go; DO NOT EDIT.
+
+package main
+
+func simdAMD64Ops(v11, v21 regInfo) []opData {
+	return []opData{
+		{name: "VADDPD", argLength: 2, reg: v21, asm: "VADDPD", commutative: true},
+		{name: "VADDPS", argLength: 2, reg: v21, asm: "VADDPS", commutative: true},
+		{name: "VDIVPD", argLength: 2, reg: v21, asm: "VDIVPD"},
+		{name: "VDIVPS", argLength: 2, reg: v21, asm: "VDIVPS"},
+		{name: "VMAXPD", argLength: 2, reg: v21, asm: "VMAXPD"},
+		{name: "VMAXPS", argLength: 2, reg: v21, asm: "VMAXPS"},
+		{name: "VMINPD", argLength: 2, reg: v21, asm: "VMINPD"},
+		{name: "VMINPS", argLength: 2, reg: v21, asm: "VMINPS"},
+		{name: "VMULPD", argLength: 2, reg: v21, asm: "VMULPD", commutative: true},
+		{name: "VMULPS", argLength: 2, reg: v21, asm: "VMULPS", commutative: true},
+		{name: "VPABSB", argLength: 1, reg: v11, asm: "VPABSB"},
+		{name: "VPABSD", argLength: 1, reg: v11, asm: "VPABSD"},
+		{name: "VPABSQ", argLength: 1, reg: v11, asm: "VPABSQ"},
+		{name: "VPABSW", argLength: 1, reg: v11, asm: "VPABSW"},
+		{name: "VPADDB", argLength: 2, reg: v21, asm: "VPADDB", commutative: true},
+		{name: "VPADDD", argLength: 2, reg: v21, asm: "VPADDD", commutative: true},
+		{name: "VPADDQ", argLength: 2, reg: v21, asm: "VPADDQ", commutative: true},
+		{name: "VPADDW", argLength: 2, reg: v21, asm: "VPADDW", commutative: true},
+		{name: "VPAND", argLength: 2, reg: v21, asm: "VPAND", commutative: true},
+		{name: "VPANDN", argLength: 2, reg: v21, asm: "VPANDN"},
+		{name: "VPCMPEQB", argLength: 2, reg: v21, asm: "VPCMPEQB", commutative: true},
+		{name: "VPCMPEQD", argLength: 2, reg: v21, asm: "VPCMPEQD", commutative: true},
+		{name: "VPCMPEQQ", argLength: 2, reg: v21, asm: "VPCMPEQQ", commutative: true},
+		{name: "VPCMPEQW", argLength: 2, reg: v21, asm: "VPCMPEQW", commutative: true},
+		{name: "VPCMPGTB", argLength: 2, reg: v21, asm: "VPCMPGTB"},
+		{name: "VPCMPGTD", argLength: 2, reg: v21, asm: "VPCMPGTD"},
+		{name: "VPCMPGTQ", argLength: 2, reg: v21, asm: "VPCMPGTQ"},
+		{name: "VPCMPGTW", argLength: 2, reg: v21, asm: "VPCMPGTW"},
+		{name: "VPMAXSB", argLength: 2, reg: v21, asm: "VPMAXSB"},
+		{name: "VPMAXSD", argLength: 2, reg: v21, asm: "VPMAXSD"},
+		{name: "VPMAXSQ", argLength: 2, reg: v21, asm: "VPMAXSQ"},
+		{name: "VPMAXSW", argLength: 2, reg: v21, asm: "VPMAXSW"},
+		{name: "VPMAXUB", argLength: 2, reg: v21, asm: "VPMAXUB"},
+		{name: "VPMAXUD", argLength: 2, reg: v21, asm: "VPMAXUD"},
+		{name: "VPMAXUQ", argLength: 2, reg: v21, asm: "VPMAXUQ"},
+		{name: "VPMAXUW", argLength: 2, reg: v21, asm: "VPMAXUW"},
+		{name: "VPMINSB", argLength: 2, reg: v21, asm: "VPMINSB"},
+		{name: "VPMINSD", argLength: 2, reg: v21, asm: "VPMINSD"},
+		{name: "VPMINSQ", argLength: 2, reg: v21, asm: "VPMINSQ"},
+		{name: "VPMINSW", argLength: 2, reg: v21, asm: "VPMINSW"},
+		{name: "VPMINUB", argLength: 2, reg: v21, asm: "VPMINUB"},
+		{name: "VPMINUD", argLength: 2, reg: v21, asm: "VPMINUD"},
+		{name: "VPMINUQ", argLength: 2, reg: v21, asm: "VPMINUQ"},
+		{name: "VPMINUW", argLength: 2, reg: v21, asm: "VPMINUW"},
+		{name: "VPMULLD", argLength: 2, reg: v21, asm: "VPMULLD", commutative: true},
+		{name: "VPMULLW", argLength: 2, reg: v21, asm: "VPMULLW", commutative: true},
+		{name: "VPOR", argLength: 2, reg: v21, asm: "VPOR", commutative: true},
+		{name: "VPSUBB", argLength: 2, reg: v21, asm: "VPSUBB"},
+		{name: "VPSUBD", argLength: 2, reg: v21, asm: "VPSUBD"},
+		{name: "VPSUBQ", argLength: 2, reg: v21, asm: "VPSUBQ"},
+		{name: "VPSUBW", argLength: 2, reg: v21, asm: "VPSUBW"},
+		{name: "VPXOR", argLength: 2, reg: v21, asm: "VPXOR", commutative: true},
+		{name: "VSQRTPD", argLength: 1, reg: v11, asm: "VSQRTPD"},
+		{name: "VSQRTPS", argLength: 1, reg: v11, asm: "VSQRTPS"},
+		{name: "VSUBPD", argLength: 2, reg: v21, asm: "VSUBPD"},
+		{name: "VSUBPS", argLength: 2, reg: v21, asm: "VSUBPS"},
+	}
+}
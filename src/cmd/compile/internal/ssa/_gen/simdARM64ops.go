@@ -0,0 +1,29 @@
+// Code generated by mkops.go; DO NOT EDIT.
+
+package main
+
+func simdARM64Ops(v11, v21 regInfo) []opData {
+	return []opData{
+		{name: "VADD16B", argLength: 2, reg: v21, asm: "VADD", commutative: true},
+		{name: "VADD2D", argLength: 2, reg: v21, asm: "VADD", commutative: true},
+		{name: "VADD4S", argLength: 2, reg: v21, asm: "VADD", commutative: true},
+		{name: "VADD8H", argLength: 2, reg: v21, asm: "VADD", commutative: true},
+		{name: "VAND16B", argLength: 2, reg: v21, asm: "VAND", commutative: true},
+		{name: "VCMEQ16B", argLength: 2, reg: v21, asm: "VCMEQ", commutative: true},
+		{name: "VCMEQ2D", argLength: 2, reg: v21, asm: "VCMEQ", commutative: true},
+		{name: "VCMEQ4S", argLength: 2, reg: v21, asm: "VCMEQ", commutative: true},
+		{name: "VCMEQ8H", argLength: 2, reg: v21, asm: "VCMEQ", commutative: true},
+		{name: "VEOR16B", argLength: 2, reg: v21, asm: "VEOR", commutative: true},
+		{name: "VORR16B", argLength: 2, reg: v21, asm: "VORR", commutative: true},
+		{name: "VSUB16B", argLength: 2, reg: v21, asm: "VSUB"},
+		{name: "VSUB2D", argLength: 2, reg: v21, asm: "VSUB"},
+		{name: "VSUB4S", argLength: 2, reg: v21, asm: "VSUB"},
+		{name: "VSUB8H", argLength: 2, reg: v21, asm: "VSUB"},
+		{name: "VUMAX16B", argLength: 2, reg: v21, asm: "VUMAX"},
+		{name: "VUMAX4S", argLength: 2, reg: v21, asm: "VUMAX"},
+		{name: "VUMAX8H", argLength: 2, reg: v21, asm: "VUMAX"},
+		{name: "VUMIN16B", argLength: 2, reg: v21, asm: "VUMIN"},
+		{name: "VUMIN4S", argLength: 2, reg: v21, asm: "VUMIN"},
+		{name: "VUMIN8H", argLength: 2, reg: v21, asm: "VUMIN"},
+	}
+}
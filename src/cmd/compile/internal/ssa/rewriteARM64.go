@@ -1170,6 +1170,8 @@ func rewriteValueARM64(v *Value) bool {
 	case OpZeroExt8to64:
 		v.Op = OpARM64MOVBUreg
 		return true
+	case OpZeroSIMD:
+		return rewriteValueARM64_OpZeroSIMD(v)
 	}
 	return false
 }
@@ -19626,6 +19628,20 @@ func rewriteValueARM64_OpLoad(v *Value) bool {
 		v.AddArg2(ptr, mem)
 		return true
 	}
+	// match: (Load <t> ptr mem)
+	// cond: t.IsSIMD()
+	// result: (FMOVQload ptr mem)
+	for {
+		t := v.Type
+		ptr := v_0
+		mem := v_1
+		if !(t.IsSIMD()) {
+			break
+		}
+		v.reset(OpARM64FMOVQload)
+		v.AddArg2(ptr, mem)
+		return true
+	}
 	return false
 }
 func rewriteValueARM64_OpLocalAddr(v *Value) bool {
@@ -23351,6 +23367,21 @@ func rewriteValueARM64_OpStore(v *Value) bool {
 		v.AddArg3(ptr, val, mem)
 		return true
 	}
+	// match: (Store {t} ptr val mem)
+	// cond: t.IsSIMD()
+	// result: (FMOVQstore ptr val mem)
+	for {
+		t := auxToType(v.Aux)
+		ptr := v_0
+		val := v_1
+		mem := v_2
+		if !(t.IsSIMD()) {
+			break
+		}
+		v.reset(OpARM64FMOVQstore)
+		v.AddArg3(ptr, val, mem)
+		return true
+	}
 	return false
 }
 func rewriteValueARM64_OpZero(v *Value) bool {
@@ -23766,6 +23797,14 @@ func rewriteValueARM64_OpZero(v *Value) bool {
 	}
 	return false
 }
+func rewriteValueARM64_OpZeroSIMD(v *Value) bool {
+	// match: (ZeroSIMD)
+	// result: (VEORzero)
+	for {
+		v.reset(OpARM64VEORzero)
+		return true
+	}
+}
 func rewriteBlockARM64(b *Block) bool {
 	typ := &b.Func.Config.Types
 	switch b.Kind {
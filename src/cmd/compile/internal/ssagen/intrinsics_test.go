@@ -1330,6 +1330,11 @@ func TestIntrinsics(t *testing.T) {
 
 	gotIntrinsics := make(map[testIntrinsicKey]struct{})
 	for ik, _ := range intrinsics {
+		if ik.pkg == simdPackage {
+			// The SIMD intrinsics are generated by simd/archsimd/mkops.go
+			// and only registered with GOEXPERIMENT=simd.
+			continue
+		}
 		gotIntrinsics[testIntrinsicKey{ik.arch.Name, ik.pkg, ik.fn}] = struct{}{}
 	}
 	for ik, _ := range gotIntrinsics {
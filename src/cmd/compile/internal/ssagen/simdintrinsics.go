@@ -0,0 +1,323 @@
+// Code generated by mkops.go; DO NOT EDIT.
+
+package ssagen
+
+import (
+	"cmd/compile/internal/ssa"
+	"cmd/internal/sys"
+)
+
+const simdPackage = "simd/archsimd"
+
+func simdIntrinsics(addF func(pkg, fn string, b intrinsicBuilder, archFamilies ...sys.ArchFamily)) {
+
+	/******** amd64 ********/
+	addF(simdPackage, "LoadInt8x16", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int8x16.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int8x16.Abs", simdOp1(ssa.OpAMD64VPABSB), sys.AMD64)
+	addF(simdPackage, "Int8x16.Add", simdOp2(ssa.OpAMD64VPADDB), sys.AMD64)
+	addF(simdPackage, "Int8x16.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int8x16.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int8x16.Equal", simdOp2(ssa.OpAMD64VPCMPEQB), sys.AMD64)
+	addF(simdPackage, "Int8x16.Greater", simdOp2(ssa.OpAMD64VPCMPGTB), sys.AMD64)
+	addF(simdPackage, "Int8x16.Max", simdOp2(ssa.OpAMD64VPMAXSB), sys.AMD64)
+	addF(simdPackage, "Int8x16.Min", simdOp2(ssa.OpAMD64VPMINSB), sys.AMD64)
+	addF(simdPackage, "Int8x16.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int8x16.Sub", simdOp2(ssa.OpAMD64VPSUBB), sys.AMD64)
+	addF(simdPackage, "Int8x16.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadInt16x8", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int16x8.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int16x8.Abs", simdOp1(ssa.OpAMD64VPABSW), sys.AMD64)
+	addF(simdPackage, "Int16x8.Add", simdOp2(ssa.OpAMD64VPADDW), sys.AMD64)
+	addF(simdPackage, "Int16x8.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int16x8.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int16x8.Equal", simdOp2(ssa.OpAMD64VPCMPEQW), sys.AMD64)
+	addF(simdPackage, "Int16x8.Greater", simdOp2(ssa.OpAMD64VPCMPGTW), sys.AMD64)
+	addF(simdPackage, "Int16x8.Max", simdOp2(ssa.OpAMD64VPMAXSW), sys.AMD64)
+	addF(simdPackage, "Int16x8.Min", simdOp2(ssa.OpAMD64VPMINSW), sys.AMD64)
+	addF(simdPackage, "Int16x8.Mul", simdOp2(ssa.OpAMD64VPMULLW), sys.AMD64)
+	addF(simdPackage, "Int16x8.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int16x8.Sub", simdOp2(ssa.OpAMD64VPSUBW), sys.AMD64)
+	addF(simdPackage, "Int16x8.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadInt32x4", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int32x4.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int32x4.Abs", simdOp1(ssa.OpAMD64VPABSD), sys.AMD64)
+	addF(simdPackage, "Int32x4.Add", simdOp2(ssa.OpAMD64VPADDD), sys.AMD64)
+	addF(simdPackage, "Int32x4.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int32x4.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int32x4.Equal", simdOp2(ssa.OpAMD64VPCMPEQD), sys.AMD64)
+	addF(simdPackage, "Int32x4.Greater", simdOp2(ssa.OpAMD64VPCMPGTD), sys.AMD64)
+	addF(simdPackage, "Int32x4.Max", simdOp2(ssa.OpAMD64VPMAXSD), sys.AMD64)
+	addF(simdPackage, "Int32x4.Min", simdOp2(ssa.OpAMD64VPMINSD), sys.AMD64)
+	addF(simdPackage, "Int32x4.Mul", simdOp2(ssa.OpAMD64VPMULLD), sys.AMD64)
+	addF(simdPackage, "Int32x4.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int32x4.Sub", simdOp2(ssa.OpAMD64VPSUBD), sys.AMD64)
+	addF(simdPackage, "Int32x4.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadInt64x2", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int64x2.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int64x2.Abs", simdOp1(ssa.OpAMD64VPABSQ), sys.AMD64)
+	addF(simdPackage, "Int64x2.Add", simdOp2(ssa.OpAMD64VPADDQ), sys.AMD64)
+	addF(simdPackage, "Int64x2.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int64x2.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int64x2.Equal", simdOp2(ssa.OpAMD64VPCMPEQQ), sys.AMD64)
+	addF(simdPackage, "Int64x2.Greater", simdOp2(ssa.OpAMD64VPCMPGTQ), sys.AMD64)
+	addF(simdPackage, "Int64x2.Max", simdOp2(ssa.OpAMD64VPMAXSQ), sys.AMD64)
+	addF(simdPackage, "Int64x2.Min", simdOp2(ssa.OpAMD64VPMINSQ), sys.AMD64)
+	addF(simdPackage, "Int64x2.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int64x2.Sub", simdOp2(ssa.OpAMD64VPSUBQ), sys.AMD64)
+	addF(simdPackage, "Int64x2.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint8x16", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint8x16.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint8x16.Add", simdOp2(ssa.OpAMD64VPADDB), sys.AMD64)
+	addF(simdPackage, "Uint8x16.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint8x16.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint8x16.Equal", simdOp2(ssa.OpAMD64VPCMPEQB), sys.AMD64)
+	addF(simdPackage, "Uint8x16.Max", simdOp2(ssa.OpAMD64VPMAXUB), sys.AMD64)
+	addF(simdPackage, "Uint8x16.Min", simdOp2(ssa.OpAMD64VPMINUB), sys.AMD64)
+	addF(simdPackage, "Uint8x16.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint8x16.Sub", simdOp2(ssa.OpAMD64VPSUBB), sys.AMD64)
+	addF(simdPackage, "Uint8x16.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint16x8", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint16x8.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint16x8.Add", simdOp2(ssa.OpAMD64VPADDW), sys.AMD64)
+	addF(simdPackage, "Uint16x8.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint16x8.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint16x8.Equal", simdOp2(ssa.OpAMD64VPCMPEQW), sys.AMD64)
+	addF(simdPackage, "Uint16x8.Max", simdOp2(ssa.OpAMD64VPMAXUW), sys.AMD64)
+	addF(simdPackage, "Uint16x8.Min", simdOp2(ssa.OpAMD64VPMINUW), sys.AMD64)
+	addF(simdPackage, "Uint16x8.Mul", simdOp2(ssa.OpAMD64VPMULLW), sys.AMD64)
+	addF(simdPackage, "Uint16x8.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint16x8.Sub", simdOp2(ssa.OpAMD64VPSUBW), sys.AMD64)
+	addF(simdPackage, "Uint16x8.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint32x4", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint32x4.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint32x4.Add", simdOp2(ssa.OpAMD64VPADDD), sys.AMD64)
+	addF(simdPackage, "Uint32x4.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint32x4.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint32x4.Equal", simdOp2(ssa.OpAMD64VPCMPEQD), sys.AMD64)
+	addF(simdPackage, "Uint32x4.Max", simdOp2(ssa.OpAMD64VPMAXUD), sys.AMD64)
+	addF(simdPackage, "Uint32x4.Min", simdOp2(ssa.OpAMD64VPMINUD), sys.AMD64)
+	addF(simdPackage, "Uint32x4.Mul", simdOp2(ssa.OpAMD64VPMULLD), sys.AMD64)
+	addF(simdPackage, "Uint32x4.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint32x4.Sub", simdOp2(ssa.OpAMD64VPSUBD), sys.AMD64)
+	addF(simdPackage, "Uint32x4.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint64x2", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint64x2.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint64x2.Add", simdOp2(ssa.OpAMD64VPADDQ), sys.AMD64)
+	addF(simdPackage, "Uint64x2.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint64x2.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint64x2.Equal", simdOp2(ssa.OpAMD64VPCMPEQQ), sys.AMD64)
+	addF(simdPackage, "Uint64x2.Max", simdOp2(ssa.OpAMD64VPMAXUQ), sys.AMD64)
+	addF(simdPackage, "Uint64x2.Min", simdOp2(ssa.OpAMD64VPMINUQ), sys.AMD64)
+	addF(simdPackage, "Uint64x2.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint64x2.Sub", simdOp2(ssa.OpAMD64VPSUBQ), sys.AMD64)
+	addF(simdPackage, "Uint64x2.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadFloat32x4", simdLoad, sys.AMD64)
+	addF(simdPackage, "Float32x4.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Float32x4.Add", simdOp2(ssa.OpAMD64VADDPS), sys.AMD64)
+	addF(simdPackage, "Float32x4.Div", simdOp2(ssa.OpAMD64VDIVPS), sys.AMD64)
+	addF(simdPackage, "Float32x4.Max", simdOp2(ssa.OpAMD64VMAXPS), sys.AMD64)
+	addF(simdPackage, "Float32x4.Min", simdOp2(ssa.OpAMD64VMINPS), sys.AMD64)
+	addF(simdPackage, "Float32x4.Mul", simdOp2(ssa.OpAMD64VMULPS), sys.AMD64)
+	addF(simdPackage, "Float32x4.Sqrt", simdOp1(ssa.OpAMD64VSQRTPS), sys.AMD64)
+	addF(simdPackage, "Float32x4.Sub", simdOp2(ssa.OpAMD64VSUBPS), sys.AMD64)
+	addF(simdPackage, "LoadFloat64x2", simdLoad, sys.AMD64)
+	addF(simdPackage, "Float64x2.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Float64x2.Add", simdOp2(ssa.OpAMD64VADDPD), sys.AMD64)
+	addF(simdPackage, "Float64x2.Div", simdOp2(ssa.OpAMD64VDIVPD), sys.AMD64)
+	addF(simdPackage, "Float64x2.Max", simdOp2(ssa.OpAMD64VMAXPD), sys.AMD64)
+	addF(simdPackage, "Float64x2.Min", simdOp2(ssa.OpAMD64VMINPD), sys.AMD64)
+	addF(simdPackage, "Float64x2.Mul", simdOp2(ssa.OpAMD64VMULPD), sys.AMD64)
+	addF(simdPackage, "Float64x2.Sqrt", simdOp1(ssa.OpAMD64VSQRTPD), sys.AMD64)
+	addF(simdPackage, "Float64x2.Sub", simdOp2(ssa.OpAMD64VSUBPD), sys.AMD64)
+	addF(simdPackage, "LoadInt8x32", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int8x32.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int8x32.Abs", simdOp1(ssa.OpAMD64VPABSB), sys.AMD64)
+	addF(simdPackage, "Int8x32.Add", simdOp2(ssa.OpAMD64VPADDB), sys.AMD64)
+	addF(simdPackage, "Int8x32.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int8x32.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int8x32.Equal", simdOp2(ssa.OpAMD64VPCMPEQB), sys.AMD64)
+	addF(simdPackage, "Int8x32.Greater", simdOp2(ssa.OpAMD64VPCMPGTB), sys.AMD64)
+	addF(simdPackage, "Int8x32.Max", simdOp2(ssa.OpAMD64VPMAXSB), sys.AMD64)
+	addF(simdPackage, "Int8x32.Min", simdOp2(ssa.OpAMD64VPMINSB), sys.AMD64)
+	addF(simdPackage, "Int8x32.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int8x32.Sub", simdOp2(ssa.OpAMD64VPSUBB), sys.AMD64)
+	addF(simdPackage, "Int8x32.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadInt16x16", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int16x16.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int16x16.Abs", simdOp1(ssa.OpAMD64VPABSW), sys.AMD64)
+	addF(simdPackage, "Int16x16.Add", simdOp2(ssa.OpAMD64VPADDW), sys.AMD64)
+	addF(simdPackage, "Int16x16.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int16x16.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int16x16.Equal", simdOp2(ssa.OpAMD64VPCMPEQW), sys.AMD64)
+	addF(simdPackage, "Int16x16.Greater", simdOp2(ssa.OpAMD64VPCMPGTW), sys.AMD64)
+	addF(simdPackage, "Int16x16.Max", simdOp2(ssa.OpAMD64VPMAXSW), sys.AMD64)
+	addF(simdPackage, "Int16x16.Min", simdOp2(ssa.OpAMD64VPMINSW), sys.AMD64)
+	addF(simdPackage, "Int16x16.Mul", simdOp2(ssa.OpAMD64VPMULLW), sys.AMD64)
+	addF(simdPackage, "Int16x16.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int16x16.Sub", simdOp2(ssa.OpAMD64VPSUBW), sys.AMD64)
+	addF(simdPackage, "Int16x16.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadInt32x8", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int32x8.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int32x8.Abs", simdOp1(ssa.OpAMD64VPABSD), sys.AMD64)
+	addF(simdPackage, "Int32x8.Add", simdOp2(ssa.OpAMD64VPADDD), sys.AMD64)
+	addF(simdPackage, "Int32x8.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int32x8.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int32x8.Equal", simdOp2(ssa.OpAMD64VPCMPEQD), sys.AMD64)
+	addF(simdPackage, "Int32x8.Greater", simdOp2(ssa.OpAMD64VPCMPGTD), sys.AMD64)
+	addF(simdPackage, "Int32x8.Max", simdOp2(ssa.OpAMD64VPMAXSD), sys.AMD64)
+	addF(simdPackage, "Int32x8.Min", simdOp2(ssa.OpAMD64VPMINSD), sys.AMD64)
+	addF(simdPackage, "Int32x8.Mul", simdOp2(ssa.OpAMD64VPMULLD), sys.AMD64)
+	addF(simdPackage, "Int32x8.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int32x8.Sub", simdOp2(ssa.OpAMD64VPSUBD), sys.AMD64)
+	addF(simdPackage, "Int32x8.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadInt64x4", simdLoad, sys.AMD64)
+	addF(simdPackage, "Int64x4.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Int64x4.Abs", simdOp1(ssa.OpAMD64VPABSQ), sys.AMD64)
+	addF(simdPackage, "Int64x4.Add", simdOp2(ssa.OpAMD64VPADDQ), sys.AMD64)
+	addF(simdPackage, "Int64x4.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Int64x4.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Int64x4.Equal", simdOp2(ssa.OpAMD64VPCMPEQQ), sys.AMD64)
+	addF(simdPackage, "Int64x4.Greater", simdOp2(ssa.OpAMD64VPCMPGTQ), sys.AMD64)
+	addF(simdPackage, "Int64x4.Max", simdOp2(ssa.OpAMD64VPMAXSQ), sys.AMD64)
+	addF(simdPackage, "Int64x4.Min", simdOp2(ssa.OpAMD64VPMINSQ), sys.AMD64)
+	addF(simdPackage, "Int64x4.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Int64x4.Sub", simdOp2(ssa.OpAMD64VPSUBQ), sys.AMD64)
+	addF(simdPackage, "Int64x4.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint8x32", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint8x32.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint8x32.Add", simdOp2(ssa.OpAMD64VPADDB), sys.AMD64)
+	addF(simdPackage, "Uint8x32.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint8x32.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint8x32.Equal", simdOp2(ssa.OpAMD64VPCMPEQB), sys.AMD64)
+	addF(simdPackage, "Uint8x32.Max", simdOp2(ssa.OpAMD64VPMAXUB), sys.AMD64)
+	addF(simdPackage, "Uint8x32.Min", simdOp2(ssa.OpAMD64VPMINUB), sys.AMD64)
+	addF(simdPackage, "Uint8x32.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint8x32.Sub", simdOp2(ssa.OpAMD64VPSUBB), sys.AMD64)
+	addF(simdPackage, "Uint8x32.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint16x16", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint16x16.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint16x16.Add", simdOp2(ssa.OpAMD64VPADDW), sys.AMD64)
+	addF(simdPackage, "Uint16x16.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint16x16.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint16x16.Equal", simdOp2(ssa.OpAMD64VPCMPEQW), sys.AMD64)
+	addF(simdPackage, "Uint16x16.Max", simdOp2(ssa.OpAMD64VPMAXUW), sys.AMD64)
+	addF(simdPackage, "Uint16x16.Min", simdOp2(ssa.OpAMD64VPMINUW), sys.AMD64)
+	addF(simdPackage, "Uint16x16.Mul", simdOp2(ssa.OpAMD64VPMULLW), sys.AMD64)
+	addF(simdPackage, "Uint16x16.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint16x16.Sub", simdOp2(ssa.OpAMD64VPSUBW), sys.AMD64)
+	addF(simdPackage, "Uint16x16.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint32x8", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint32x8.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint32x8.Add", simdOp2(ssa.OpAMD64VPADDD), sys.AMD64)
+	addF(simdPackage, "Uint32x8.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint32x8.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint32x8.Equal", simdOp2(ssa.OpAMD64VPCMPEQD), sys.AMD64)
+	addF(simdPackage, "Uint32x8.Max", simdOp2(ssa.OpAMD64VPMAXUD), sys.AMD64)
+	addF(simdPackage, "Uint32x8.Min", simdOp2(ssa.OpAMD64VPMINUD), sys.AMD64)
+	addF(simdPackage, "Uint32x8.Mul", simdOp2(ssa.OpAMD64VPMULLD), sys.AMD64)
+	addF(simdPackage, "Uint32x8.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint32x8.Sub", simdOp2(ssa.OpAMD64VPSUBD), sys.AMD64)
+	addF(simdPackage, "Uint32x8.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadUint64x4", simdLoad, sys.AMD64)
+	addF(simdPackage, "Uint64x4.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Uint64x4.Add", simdOp2(ssa.OpAMD64VPADDQ), sys.AMD64)
+	addF(simdPackage, "Uint64x4.And", simdOp2(ssa.OpAMD64VPAND), sys.AMD64)
+	addF(simdPackage, "Uint64x4.AndNot", simdOp2Swapped(ssa.OpAMD64VPANDN), sys.AMD64)
+	addF(simdPackage, "Uint64x4.Equal", simdOp2(ssa.OpAMD64VPCMPEQQ), sys.AMD64)
+	addF(simdPackage, "Uint64x4.Max", simdOp2(ssa.OpAMD64VPMAXUQ), sys.AMD64)
+	addF(simdPackage, "Uint64x4.Min", simdOp2(ssa.OpAMD64VPMINUQ), sys.AMD64)
+	addF(simdPackage, "Uint64x4.Or", simdOp2(ssa.OpAMD64VPOR), sys.AMD64)
+	addF(simdPackage, "Uint64x4.Sub", simdOp2(ssa.OpAMD64VPSUBQ), sys.AMD64)
+	addF(simdPackage, "Uint64x4.Xor", simdOp2(ssa.OpAMD64VPXOR), sys.AMD64)
+	addF(simdPackage, "LoadFloat32x8", simdLoad, sys.AMD64)
+	addF(simdPackage, "Float32x8.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Float32x8.Add", simdOp2(ssa.OpAMD64VADDPS), sys.AMD64)
+	addF(simdPackage, "Float32x8.Div", simdOp2(ssa.OpAMD64VDIVPS), sys.AMD64)
+	addF(simdPackage, "Float32x8.Max", simdOp2(ssa.OpAMD64VMAXPS), sys.AMD64)
+	addF(simdPackage, "Float32x8.Min", simdOp2(ssa.OpAMD64VMINPS), sys.AMD64)
+	addF(simdPackage, "Float32x8.Mul", simdOp2(ssa.OpAMD64VMULPS), sys.AMD64)
+	addF(simdPackage, "Float32x8.Sqrt", simdOp1(ssa.OpAMD64VSQRTPS), sys.AMD64)
+	addF(simdPackage, "Float32x8.Sub", simdOp2(ssa.OpAMD64VSUBPS), sys.AMD64)
+	addF(simdPackage, "LoadFloat64x4", simdLoad, sys.AMD64)
+	addF(simdPackage, "Float64x4.Store", simdStore, sys.AMD64)
+	addF(simdPackage, "Float64x4.Add", simdOp2(ssa.OpAMD64VADDPD), sys.AMD64)
+	addF(simdPackage, "Float64x4.Div", simdOp2(ssa.OpAMD64VDIVPD), sys.AMD64)
+	addF(simdPackage, "Float64x4.Max", simdOp2(ssa.OpAMD64VMAXPD), sys.AMD64)
+	addF(simdPackage, "Float64x4.Min", simdOp2(ssa.OpAMD64VMINPD), sys.AMD64)
+	addF(simdPackage, "Float64x4.Mul", simdOp2(ssa.OpAMD64VMULPD), sys.AMD64)
+	addF(simdPackage, "Float64x4.Sqrt", simdOp1(ssa.OpAMD64VSQRTPD), sys.AMD64)
+	addF(simdPackage, "Float64x4.Sub", simdOp2(ssa.OpAMD64VSUBPD), sys.AMD64)
+
+	/******** arm64 ********/
+	addF(simdPackage, "LoadInt8x16", simdLoad, sys.ARM64)
+	addF(simdPackage, "Int8x16.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Int8x16.Add", simdOp2(ssa.OpARM64VADD16B), sys.ARM64)
+	addF(simdPackage, "Int8x16.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Int8x16.Equal", simdOp2(ssa.OpARM64VCMEQ16B), sys.ARM64)
+	addF(simdPackage, "Int8x16.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Int8x16.Sub", simdOp2(ssa.OpARM64VSUB16B), sys.ARM64)
+	addF(simdPackage, "Int8x16.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+	addF(simdPackage, "LoadInt16x8", simdLoad, sys.ARM64)
+	addF(simdPackage, "Int16x8.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Int16x8.Add", simdOp2(ssa.OpARM64VADD8H), sys.ARM64)
+	addF(simdPackage, "Int16x8.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Int16x8.Equal", simdOp2(ssa.OpARM64VCMEQ8H), sys.ARM64)
+	addF(simdPackage, "Int16x8.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Int16x8.Sub", simdOp2(ssa.OpARM64VSUB8H), sys.ARM64)
+	addF(simdPackage, "Int16x8.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+	addF(simdPackage, "LoadInt32x4", simdLoad, sys.ARM64)
+	addF(simdPackage, "Int32x4.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Int32x4.Add", simdOp2(ssa.OpARM64VADD4S), sys.ARM64)
+	addF(simdPackage, "Int32x4.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Int32x4.Equal", simdOp2(ssa.OpARM64VCMEQ4S), sys.ARM64)
+	addF(simdPackage, "Int32x4.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Int32x4.Sub", simdOp2(ssa.OpARM64VSUB4S), sys.ARM64)
+	addF(simdPackage, "Int32x4.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+	addF(simdPackage, "LoadInt64x2", simdLoad, sys.ARM64)
+	addF(simdPackage, "Int64x2.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Int64x2.Add", simdOp2(ssa.OpARM64VADD2D), sys.ARM64)
+	addF(simdPackage, "Int64x2.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Int64x2.Equal", simdOp2(ssa.OpARM64VCMEQ2D), sys.ARM64)
+	addF(simdPackage, "Int64x2.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Int64x2.Sub", simdOp2(ssa.OpARM64VSUB2D), sys.ARM64)
+	addF(simdPackage, "Int64x2.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+	addF(simdPackage, "LoadUint8x16", simdLoad, sys.ARM64)
+	addF(simdPackage, "Uint8x16.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Uint8x16.Add", simdOp2(ssa.OpARM64VADD16B), sys.ARM64)
+	addF(simdPackage, "Uint8x16.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Uint8x16.Equal", simdOp2(ssa.OpARM64VCMEQ16B), sys.ARM64)
+	addF(simdPackage, "Uint8x16.Max", simdOp2(ssa.OpARM64VUMAX16B), sys.ARM64)
+	addF(simdPackage, "Uint8x16.Min", simdOp2(ssa.OpARM64VUMIN16B), sys.ARM64)
+	addF(simdPackage, "Uint8x16.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Uint8x16.Sub", simdOp2(ssa.OpARM64VSUB16B), sys.ARM64)
+	addF(simdPackage, "Uint8x16.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+	addF(simdPackage, "LoadUint16x8", simdLoad, sys.ARM64)
+	addF(simdPackage, "Uint16x8.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Uint16x8.Add", simdOp2(ssa.OpARM64VADD8H), sys.ARM64)
+	addF(simdPackage, "Uint16x8.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Uint16x8.Equal", simdOp2(ssa.OpARM64VCMEQ8H), sys.ARM64)
+	addF(simdPackage, "Uint16x8.Max", simdOp2(ssa.OpARM64VUMAX8H), sys.ARM64)
+	addF(simdPackage, "Uint16x8.Min", simdOp2(ssa.OpARM64VUMIN8H), sys.ARM64)
+	addF(simdPackage, "Uint16x8.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Uint16x8.Sub", simdOp2(ssa.OpARM64VSUB8H), sys.ARM64)
+	addF(simdPackage, "Uint16x8.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+	addF(simdPackage, "LoadUint32x4", simdLoad, sys.ARM64)
+	addF(simdPackage, "Uint32x4.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Uint32x4.Add", simdOp2(ssa.OpARM64VADD4S), sys.ARM64)
+	addF(simdPackage, "Uint32x4.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Uint32x4.Equal", simdOp2(ssa.OpARM64VCMEQ4S), sys.ARM64)
+	addF(simdPackage, "Uint32x4.Max", simdOp2(ssa.OpARM64VUMAX4S), sys.ARM64)
+	addF(simdPackage, "Uint32x4.Min", simdOp2(ssa.OpARM64VUMIN4S), sys.ARM64)
+	addF(simdPackage, "Uint32x4.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Uint32x4.Sub", simdOp2(ssa.OpARM64VSUB4S), sys.ARM64)
+	addF(simdPackage, "Uint32x4.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+	addF(simdPackage, "LoadUint64x2", simdLoad, sys.ARM64)
+	addF(simdPackage, "Uint64x2.Store", simdStore, sys.ARM64)
+	addF(simdPackage, "Uint64x2.Add", simdOp2(ssa.OpARM64VADD2D), sys.ARM64)
+	addF(simdPackage, "Uint64x2.And", simdOp2(ssa.OpARM64VAND16B), sys.ARM64)
+	addF(simdPackage, "Uint64x2.Equal", simdOp2(ssa.OpARM64VCMEQ2D), sys.ARM64)
+	addF(simdPackage, "Uint64x2.Or", simdOp2(ssa.OpARM64VORR16B), sys.ARM64)
+	addF(simdPackage, "Uint64x2.Sub", simdOp2(ssa.OpARM64VSUB2D), sys.ARM64)
+	addF(simdPackage, "Uint64x2.Xor", simdOp2(ssa.OpARM64VEOR16B), sys.ARM64)
+}
@@ -5,6 +5,7 @@
 package types
 
 import (
+	"internal/buildcfg"
 	"math"
 	"slices"
 
@@ -496,6 +497,26 @@ func CalcSize(t *Type) {
 	ResumeCheckSize()
 }
 
+// simdify marks st as a SIMD type. If isTag is set, st is one of the
+// zero-sized marker types (v128, v256) that package simd/archsimd embeds
+// as the first field of each vector type; otherwise st is a vector type.
+// A SIMD value lives in a single vector register (XMM or YMM on amd64,
+// a V register on arm64) and its fields are ignored by the compiler
+// except for the space they reserve. SIMD values are always passed
+// in memory under ABIInternal.
+func simdify(st *Type, isTag bool) {
+	st.align = 8
+	st.alg = ANOALG // not comparable with ==
+	st.intRegs = math.MaxUint8
+	st.floatRegs = math.MaxUint8
+	st.isSIMD = true
+	if isTag {
+		st.width = 0
+		st.isSIMDTag = true
+		st.ptrBytes = 0
+	}
+}
+
 // CalcStructSize calculates the size of t,
 // filling in t.width, t.align, t.intRegs, and t.floatRegs,
 // even if size calculation is otherwise disabled.
@@ -508,6 +529,11 @@ func CalcStructSize(t *Type) {
 		switch {
 		case sym.Name == "align64" && isAtomicStdPkg(sym.Pkg):
 			maxAlign = 8
+
+		case buildcfg.Experiment.SIMD && sym.Pkg.Path == "simd/archsimd" && (sym.Name == "v128" || sym.Name == "v256"):
+			// Without the experiment, no user-visible type is SIMD.
+			simdify(t, true)
+			return
 		}
 	}
 
@@ -584,6 +610,11 @@ func CalcStructSize(t *Type) {
 			break
 		}
 	}
+
+	if len(fields) >= 1 && fields[0].Type.isSIMDTag {
+		// A struct whose first field is a SIMD tag is a vector type.
+		simdify(t, false)
+	}
 }
 
 func (t *Type) widthCalculated() bool {
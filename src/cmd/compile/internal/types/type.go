@@ -204,6 +204,8 @@ type Type struct {
 	flags bitset8
 	alg   AlgKind // valid if Align > 0
 
+	isSIMDTag, isSIMD bool // isSIMDTag marks a SIMD tag type; isSIMD means t is (or has) a SIMD tag
+
 	// size of prefix of object that contains all pointers. valid if Align > 0.
 	// Note that for pointers, this is always PtrSize even if the element type
 	// is NotInHeap. See size.go:PtrDataSize for details.
@@ -1429,6 +1431,13 @@ func (t *Type) IsStruct() bool {
 	return t.kind == TSTRUCT
 }
 
+// IsSIMD reports whether t is a SIMD vector type, which the compiler
+// represents as a single value held in a vector register.
+// IsSIMD is only valid after t's size has been calculated.
+func (t *Type) IsSIMD() bool {
+	return t.isSIMD
+}
+
 func (t *Type) IsInterface() bool {
 	return t.kind == TINTER
 }
@@ -1691,6 +1700,9 @@ func (t *Type) SetUnderlying(underlying *Type) {
 	if underlying.HasShape() {
 		t.SetHasShape(true)
 	}
+	if underlying.isSIMD {
+		simdify(t, underlying.isSIMDTag)
+	}
 
 	// spec: "The declared type does not inherit any methods bound
 	// to the existing type, but the method set of an interface
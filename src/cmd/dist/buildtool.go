@@ -60,6 +60,7 @@ var bootstrapDirs = []string{
 	"cmd/link/internal/...",
 	"compress/flate",
 	"compress/zlib",
+	"compress/zstd",
 	"container/heap",
 	"debug/dwarf",
 	"debug/elf",
@@ -94,7 +95,6 @@ var bootstrapDirs = []string{
 	"internal/types/errors",
 	"internal/unsafeheader",
 	"internal/xcoff",
-	"internal/zstd",
 	"math/bits",
 	"sort",
 }
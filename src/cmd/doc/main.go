@@ -39,6 +39,10 @@
 // The -all flag causes doc to print all documentation for the package and
 // all its visible symbols. The argument must identify a package.
 //
+// The -http flag causes doc to serve HTML documentation for all the packages
+// it can find over HTTP, and to open a browser at the documentation for the
+// requested package or symbol.
+//
 // For complete documentation, run "go help doc".
 package main
 
@@ -66,6 +70,7 @@ var (
 	showCmd    bool   // -cmd flag
 	showSrc    bool   // -src flag
 	short      bool   // -short flag
+	serveHTTP  bool   // -http flag
 )
 
 // usage is a replacement usage function for the flags package.
@@ -107,6 +112,7 @@ func do(writer io.Writer, flagSet *flag.FlagSet, args []string) (err error) {
 	flagSet.BoolVar(&showCmd, "cmd", false, "show symbols with package docs even if package is a command")
 	flagSet.BoolVar(&showSrc, "src", false, "show source code for symbol")
 	flagSet.BoolVar(&short, "short", false, "one-line representation for each symbol")
+	flagSet.BoolVar(&serveHTTP, "http", false, "serve HTML documentation over HTTP and open it in a browser")
 	flagSet.Parse(args)
 	counter.Inc("doc/invocations")
 	counter.CountFlags("doc/flag:", *flag.CommandLine)
@@ -115,6 +121,9 @@ func do(writer io.Writer, flagSet *flag.FlagSet, args []string) (err error) {
 			return err
 		}
 	}
+	if serveHTTP {
+		return serveDocs(writer, flagSet.Args())
+	}
 	var paths []string
 	var symbol, method string
 	// Loop until something is printed.
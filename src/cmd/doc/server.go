@@ -0,0 +1,980 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/parser"
+	"go/printer"
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cmd/internal/browser"
+)
+
+// serveDocs implements the -http flag. It starts a web server that serves
+// HTML documentation for the packages known to dirs, and opens a browser
+// at the documentation for the package and symbol named by args.
+func serveDocs(w io.Writer, args []string) error {
+	dir, anchor, err := docTarget(args)
+	if err != nil {
+		return err
+	}
+	s := newDocServer()
+	page := "/"
+	if d, ok := s.byDir[dir]; ok {
+		page = "/pkg/" + d.importPath + anchor
+	}
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return err
+	}
+	url := "http://" + ln.Addr().String()
+	fmt.Fprintf(w, "Serving documentation at %s\n", url)
+	go func() {
+		if !browser.Open(url + page) {
+			fmt.Fprintf(w, "Open %s%s in a web browser.\n", url, page)
+		}
+	}()
+	return http.Serve(ln, s.handler(ln.Addr().String()))
+}
+
+// docTarget returns the directory of the package named by args and
+// the URL fragment for the symbol named by args, if any.
+// It follows the same rules for interpreting args as the text output.
+// If args is empty and the current directory does not contain a package,
+// docTarget returns an empty directory.
+func docTarget(args []string) (dir, anchor string, err error) {
+	if len(args) == 0 {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", "", err
+		}
+		if _, err := build.ImportDir(wd, build.ImportComment); err != nil {
+			return "", "", nil
+		}
+	}
+	var paths []string
+	var symbol, method string
+	dirs.Reset()
+	for i := 0; ; i++ {
+		buildPackage, userPath, sym, more := parseArgs(args)
+		if i > 0 && !more { // Ignore the "more" bit on the first iteration.
+			return "", "", failMessage(paths, symbol, method)
+		}
+		if buildPackage == nil {
+			return "", "", fmt.Errorf("no such package: %s", userPath)
+		}
+		if buildPackage.ImportPath == "builtin" {
+			unexported = true
+		}
+		symbol, method = parseSymbol(sym)
+		pkg := parsePackage(io.Discard, buildPackage, userPath)
+		paths = append(paths, pkg.prettyPath())
+		if symbol == "" {
+			return filepath.Clean(buildPackage.Dir), "", nil
+		}
+		if id, ok := symbolAnchor(pkg, symbol, method); ok {
+			return filepath.Clean(buildPackage.Dir), "#" + id, nil
+		}
+	}
+}
+
+// symbolAnchor returns the anchor of the documentation for symbol,
+// or for symbol.method if method is not empty, within the page for pkg.
+// It reports whether pkg contains such a symbol.
+func symbolAnchor(pkg *Package, symbol, method string) (id string, ok bool) {
+	defer func() {
+		// The lookups below report some failures with a PackageError panic.
+		if e := recover(); e != nil {
+			if _, isPkgErr := e.(PackageError); !isPkgErr {
+				panic(e)
+			}
+			id, ok = "", false
+		}
+	}()
+
+	if method == "" {
+		for _, value := range append(pkg.findValues(symbol, pkg.doc.Consts), pkg.findValues(symbol, pkg.doc.Vars)...) {
+			for _, name := range value.Names {
+				if match(symbol, name) {
+					return name, true
+				}
+			}
+		}
+		for _, fun := range pkg.findFuncs(symbol) {
+			return fun.Name, true
+		}
+		for _, typ := range pkg.findTypes(symbol) {
+			return typ.Name, true
+		}
+		return "", false
+	}
+
+	if !pkg.printMethodDoc(symbol, method) && !pkg.printFieldDoc(symbol, method) {
+		return "", false
+	}
+	// Methods have entries of their own;
+	// fields and interface methods are shown with their type.
+	types := pkg.findTypes(symbol)
+	for _, typ := range types {
+		for _, meth := range typ.Methods {
+			if match(method, meth.Name) {
+				return typ.Name + "." + meth.Name, true
+			}
+		}
+	}
+	return types[0].Name, true
+}
+
+// A docServer serves HTML documentation for the packages found by dirs.
+type docServer struct {
+	pkgs     []Dir          // package directories, sorted by import path
+	byPath   map[string]Dir // package directories by import path
+	byDir    map[string]Dir // package directories by file system directory
+	goroot   string         // $GOROOT/src
+	modCache string         // $GOMODCACHE, in module mode
+
+	symOnce sync.Once
+	syms    []docSymbol // package-level symbols, for search
+}
+
+// A docSymbol is a package-level symbol listed in search results.
+type docSymbol struct {
+	pkg     Dir
+	pkgName string
+	name    string // Name or Recv.Name
+}
+
+// newDocServer returns a docServer for all the package directories
+// found by dirs.
+func newDocServer() *docServer {
+	s := &docServer{
+		byPath: make(map[string]Dir),
+		byDir:  make(map[string]Dir),
+		goroot: filepath.Join(buildCtx.GOROOT, "src"),
+	}
+	dirs.Reset()
+	for {
+		d, ok := dirs.Next()
+		if !ok {
+			break
+		}
+		d.dir = filepath.Clean(d.dir)
+		if _, dup := s.byPath[d.importPath]; dup || d.importPath == "" {
+			continue
+		}
+		s.byPath[d.importPath] = d
+		if _, dup := s.byDir[d.dir]; !dup {
+			s.byDir[d.dir] = d
+		}
+		s.pkgs = append(s.pkgs, d)
+	}
+	dirs.Reset()
+	slices.SortFunc(s.pkgs, func(a, b Dir) int { return strings.Compare(a.importPath, b.importPath) })
+
+	if usingModules {
+		if out, err := exec.Command(goCmd(), "env", "GOMODCACHE").Output(); err == nil {
+			s.modCache = string(bytes.TrimSpace(out))
+		}
+	}
+	return s
+}
+
+// handler returns the HTTP handler for the documentation server
+// listening on the loopback address addr.
+func (s *docServer) handler(addr string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.serveIndex)
+	mux.HandleFunc("GET /search", s.serveSearch)
+	mux.HandleFunc("GET /pkg/{path...}", s.servePackage)
+	mux.HandleFunc("GET /src/{path...}", s.serveSource)
+	return loopbackHost(addr, mux)
+}
+
+// loopbackHost returns a handler that passes requests to h only if
+// their Host is the loopback address addr, either as is or with its
+// host replaced by localhost, and rejects all other requests.
+// Otherwise a web page from another site could use DNS rebinding
+// to read the documentation and source code that the server exposes.
+func loopbackHost(addr string, h http.Handler) http.Handler {
+	_, port, _ := net.SplitHostPort(addr)
+	hosts := []string{addr, net.JoinHostPort("localhost", port)}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !slices.ContainsFunc(hosts, func(host string) bool { return strings.EqualFold(r.Host, host) }) {
+			http.Error(w, "invalid Host header", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Sections of the package index, in display order.
+const (
+	sectionModule = iota
+	sectionStd
+	sectionDeps
+)
+
+var sectionTitles = [...]string{
+	sectionModule: "Current module and workspace",
+	sectionStd:    "Standard library",
+	sectionDeps:   "Dependencies",
+}
+
+// section returns the section of the package index that lists d.
+func (s *docServer) section(d Dir) int {
+	switch {
+	case inDir(d.dir, s.goroot):
+		return sectionStd
+	case s.modCache != "" && inDir(d.dir, s.modCache):
+		return sectionDeps
+	}
+	return sectionModule
+}
+
+// inDir reports whether dir is root or a directory within it.
+func inDir(dir, root string) bool {
+	return dir == root || strings.HasPrefix(dir, root+string(filepath.Separator))
+}
+
+func (s *docServer) serveIndex(w http.ResponseWriter, r *http.Request) {
+	type section struct {
+		Title string
+		Pkgs  []string
+	}
+	sections := make([]section, len(sectionTitles))
+	for i, title := range sectionTitles {
+		sections[i].Title = title
+	}
+	for _, d := range s.pkgs {
+		i := s.section(d)
+		sections[i].Pkgs = append(sections[i].Pkgs, d.importPath)
+	}
+	s.render(w, indexTemplate, "Packages", sections)
+}
+
+func (s *docServer) serveSearch(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.FormValue("q"))
+	if q == "" {
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+	if _, ok := s.byPath[q]; ok {
+		http.Redirect(w, r, "/pkg/"+q, http.StatusFound)
+		return
+	}
+
+	const maxResults = 100
+	type result struct {
+		Path string // URL path
+		Text string
+	}
+	var pkgs, syms []result
+	lq := strings.ToLower(q)
+	for _, d := range s.sorted(s.pkgs) {
+		if len(pkgs) < maxResults && strings.Contains(strings.ToLower(d.importPath), lq) {
+			pkgs = append(pkgs, result{"/pkg/" + d.importPath, d.importPath})
+		}
+	}
+
+	// A query of the form "pkg.Sym" or "pkg.Type.Method" restricts
+	// the symbols to packages named pkg.
+	qpkg, qsym, _ := strings.Cut(q, ".")
+	s.symOnce.Do(s.indexSymbols)
+	for _, sym := range s.syms {
+		if len(syms) >= maxResults {
+			break
+		}
+		_, method, isMethod := strings.Cut(sym.name, ".")
+		if strings.EqualFold(sym.name, q) || isMethod && strings.EqualFold(method, q) ||
+			qsym != "" && strings.EqualFold(sym.pkgName, qpkg) && strings.EqualFold(sym.name, qsym) {
+			syms = append(syms, result{"/pkg/" + sym.pkg.importPath + "#" + sym.name, sym.pkg.importPath + "." + sym.name})
+		}
+	}
+
+	s.render(w, searchTemplate, "Search results for "+q, struct {
+		Query      string
+		Symbols    []result
+		Packages   []result
+		MaxResults int
+	}{q, syms, pkgs, maxResults})
+}
+
+// sorted returns pkgs sorted by index section, then import path.
+func (s *docServer) sorted(pkgs []Dir) []Dir {
+	pkgs = slices.Clone(pkgs)
+	slices.SortStableFunc(pkgs, func(a, b Dir) int { return s.section(a) - s.section(b) })
+	return pkgs
+}
+
+// indexSymbols records the package-level symbols of every package
+// for use in search. It ignores build constraints.
+func (s *docServer) indexSymbols() {
+	for _, d := range s.sorted(s.pkgs) {
+		entries, err := os.ReadDir(d.dir)
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		add := func(pkgName, name string) {
+			if !seen[name] {
+				seen[name] = true
+				s.syms = append(s.syms, docSymbol{d, pkgName, name})
+			}
+		}
+		fset := token.NewFileSet()
+		for _, e := range entries {
+			name := e.Name()
+			if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			f, err := parser.ParseFile(fset, filepath.Join(d.dir, name), nil, parser.SkipObjectResolution)
+			if err != nil || f.Name.Name == "main" {
+				continue
+			}
+			pkgName := f.Name.Name
+			for _, decl := range f.Decls {
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					if !token.IsExported(decl.Name.Name) {
+						continue
+					}
+					if decl.Recv == nil {
+						add(pkgName, decl.Name.Name)
+					} else if recv := recvTypeName(decl.Recv.List[0].Type); token.IsExported(recv) {
+						add(pkgName, recv+"."+decl.Name.Name)
+					}
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						switch spec := spec.(type) {
+						case *ast.TypeSpec:
+							if token.IsExported(spec.Name.Name) {
+								add(pkgName, spec.Name.Name)
+							}
+						case *ast.ValueSpec:
+							for _, id := range spec.Names {
+								if token.IsExported(id.Name) {
+									add(pkgName, id.Name)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// recvTypeName returns the name of the base type of a method receiver.
+func recvTypeName(x ast.Expr) string {
+	for {
+		switch t := x.(type) {
+		case *ast.StarExpr:
+			x = t.X
+		case *ast.ParenExpr:
+			x = t.X
+		case *ast.IndexExpr:
+			x = t.X
+		case *ast.IndexListExpr:
+			x = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// A docPackage is a package prepared for display as HTML.
+type docPackage struct {
+	dir      Dir
+	build    *build.Package
+	fset     *token.FileSet
+	doc      *doc.Package
+	files    map[string]*ast.File // by file name
+	topLevel map[string]bool      // names of the package-level symbols shown
+}
+
+// loadPackage parses the package in d.
+func loadPackage(d Dir) (*docPackage, error) {
+	bp, err := buildCtx.ImportDir(d.dir, build.ImportComment)
+	if err != nil {
+		return nil, err
+	}
+	p := &docPackage{
+		dir:      d,
+		build:    bp,
+		fset:     token.NewFileSet(),
+		files:    make(map[string]*ast.File),
+		topLevel: make(map[string]bool),
+	}
+	var files []*ast.File
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		filename := filepath.Join(bp.Dir, name)
+		f, err := parser.ParseFile(p.fset, filename, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		p.files[filename] = f
+		files = append(files, f)
+	}
+	var mode doc.Mode
+	if unexported || d.importPath == "builtin" {
+		mode |= doc.AllDecls
+	}
+	p.doc, err = doc.NewFromFiles(p.fset, files, d.importPath, mode)
+	if err != nil {
+		return nil, err
+	}
+	if p.isCommand() {
+		// As with the text output, show only the package documentation
+		// of a command unless -cmd is set.
+		p.doc.Consts, p.doc.Vars, p.doc.Funcs, p.doc.Types = nil, nil, nil, nil
+	}
+
+	for _, v := range slices.Concat(p.doc.Consts, p.doc.Vars) {
+		for _, name := range v.Names {
+			p.topLevel[name] = true
+		}
+	}
+	for _, f := range p.doc.Funcs {
+		p.topLevel[f.Name] = true
+	}
+	for _, t := range p.doc.Types {
+		p.topLevel[t.Name] = true
+		for _, v := range slices.Concat(t.Consts, t.Vars) {
+			for _, name := range v.Names {
+				p.topLevel[name] = true
+			}
+		}
+		for _, f := range t.Funcs {
+			p.topLevel[f.Name] = true
+		}
+	}
+	return p, nil
+}
+
+// isCommand reports whether p is a command whose symbols should be hidden.
+func (p *docPackage) isCommand() bool {
+	return p.doc.Name == "main" && !showCmd
+}
+
+// A docItem is the documentation for a single declaration.
+type docItem struct {
+	IDs  []string // anchors for the declared names
+	Name string   // heading, for functions, types and methods
+	Src  string   // URL of the declaration's source
+	Decl template.HTML
+	Doc  template.HTML
+}
+
+// A typeItem is the documentation for a type and its associated declarations.
+type typeItem struct {
+	docItem
+	Consts, Vars, Funcs, Methods []docItem
+}
+
+func (s *docServer) servePackage(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.byPath[strings.TrimSuffix(r.PathValue("path"), "/")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	p, err := loadPackage(d)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	page := struct {
+		ImportPath string
+		Name       string
+		Command    bool
+		Doc        template.HTML
+		Consts     []docItem
+		Vars       []docItem
+		Funcs      []docItem
+		Types      []typeItem
+		Bugs       []template.HTML
+		Files      []string
+		Subdirs    []string
+	}{
+		ImportPath: d.importPath,
+		Name:       p.doc.Name,
+		Command:    p.doc.Name == "main",
+		Doc:        p.docHTML(p.doc.Doc),
+		Consts:     p.valueItems(p.doc.Consts),
+		Vars:       p.valueItems(p.doc.Vars),
+		Funcs:      p.funcItems(p.doc.Funcs, ""),
+	}
+	if page.Command {
+		page.Name = path.Base(d.importPath)
+	}
+	for _, t := range p.doc.Types {
+		page.Types = append(page.Types, typeItem{
+			docItem: docItem{
+				IDs:  []string{t.Name},
+				Name: t.Name,
+				Src:  p.srcURL(t.Decl.Pos()),
+				Decl: p.declHTML(t.Decl),
+				Doc:  p.docHTML(t.Doc),
+			},
+			Consts:  p.valueItems(t.Consts),
+			Vars:    p.valueItems(t.Vars),
+			Funcs:   p.funcItems(t.Funcs, ""),
+			Methods: p.funcItems(t.Methods, t.Name),
+		})
+	}
+	for _, bug := range p.doc.Notes["BUG"] {
+		page.Bugs = append(page.Bugs, p.docHTML(bug.Body))
+	}
+	page.Files = append(page.Files, p.build.GoFiles...)
+	page.Files = append(page.Files, p.build.CgoFiles...)
+	slices.Sort(page.Files)
+	i, _ := slices.BinarySearchFunc(s.pkgs, d.importPath+"/", func(d Dir, prefix string) int {
+		return strings.Compare(d.importPath, prefix)
+	})
+	for _, sub := range s.pkgs[i:] {
+		if !strings.HasPrefix(sub.importPath, d.importPath+"/") {
+			break
+		}
+		page.Subdirs = append(page.Subdirs, sub.importPath)
+	}
+
+	title := "Package " + page.Name
+	if page.Command {
+		title = "Command " + page.Name
+	}
+	s.render(w, packageTemplate, title, page)
+}
+
+// valueItems returns the documentation for a list of const or var groups.
+func (p *docPackage) valueItems(values []*doc.Value) []docItem {
+	var items []docItem
+	for _, v := range values {
+		items = append(items, docItem{
+			IDs:  v.Names,
+			Src:  p.srcURL(v.Decl.Pos()),
+			Decl: p.declHTML(v.Decl),
+			Doc:  p.docHTML(v.Doc),
+		})
+	}
+	return items
+}
+
+// funcItems returns the documentation for a list of functions,
+// or of methods if recv is not empty.
+func (p *docPackage) funcItems(funcs []*doc.Func, recv string) []docItem {
+	var items []docItem
+	for _, f := range funcs {
+		id := f.Name
+		name := "func " + f.Name
+		if recv != "" {
+			id = recv + "." + f.Name
+			name = "func (" + f.Recv + ") " + f.Name
+		}
+		items = append(items, docItem{
+			IDs:  []string{id},
+			Name: name,
+			Src:  p.srcURL(f.Decl.Pos()),
+			Decl: p.declHTML(f.Decl),
+			Doc:  p.docHTML(f.Doc),
+		})
+	}
+	return items
+}
+
+// docHTML returns the HTML for a doc comment.
+// Doc links refer to the pages served by the documentation server.
+func (p *docPackage) docHTML(text string) template.HTML {
+	pr := p.doc.Printer()
+	pr.DocLinkBaseURL = "/pkg"
+	pr.HeadingLevel = 3
+	return template.HTML(pr.HTML(p.doc.Parser().Parse(text)))
+}
+
+// srcURL returns the URL of the source line containing pos.
+func (p *docPackage) srcURL(pos token.Pos) string {
+	position := p.fset.Position(pos)
+	return fmt.Sprintf("/src/%s/%s#L%d", p.dir.importPath, filepath.Base(position.Filename), position.Line)
+}
+
+// declHTML returns the HTML for the declaration node, with identifiers
+// that refer to documented symbols linked to their documentation.
+func (p *docPackage) declHTML(node ast.Node) template.HTML {
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces, Tabwidth: 4}
+	if err := cfg.Fprint(&buf, p.fset, node); err != nil {
+		return template.HTML(template.HTMLEscapeString(err.Error()))
+	}
+	src := buf.Bytes()
+	links := p.identLinks(node)
+
+	// The printer emits identifiers in the same order as ast.Inspect
+	// visits them, so the identifiers scanned from src correspond
+	// one-to-one with links. If that ever fails to hold,
+	// stop adding links rather than add wrong ones.
+	var out bytes.Buffer
+	var sc scanner.Scanner
+	file := token.NewFileSet().AddFile("", -1, len(src))
+	sc.Init(file, src, nil, scanner.ScanComments)
+	last, i := 0, 0
+	for {
+		pos, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.IDENT {
+			continue
+		}
+		off := file.Offset(pos)
+		template.HTMLEscape(&out, src[last:off])
+		last = off + len(lit)
+		if i < len(links) && links[i].name != lit {
+			i = len(links)
+		}
+		if i < len(links) && links[i].url != "" {
+			fmt.Fprintf(&out, `<a href="%s">%s</a>`, template.HTMLEscapeString(links[i].url), template.HTMLEscapeString(lit))
+		} else {
+			template.HTMLEscape(&out, []byte(lit))
+		}
+		i++
+	}
+	template.HTMLEscape(&out, src[last:])
+	return template.HTML(out.String())
+}
+
+// An identLink is an identifier in a declaration and the URL
+// of the documentation it refers to, if any.
+type identLink struct {
+	name string
+	url  string
+}
+
+// identLinks returns the identifiers in node in ast.Inspect order,
+// along with the documentation URLs of the ones that refer to
+// package-level symbols, imported packages or predeclared identifiers.
+func (p *docPackage) identLinks(node ast.Node) []identLink {
+	imports := make(map[string]string) // import name → import path
+	if f := p.files[p.fset.Position(node.Pos()).Filename]; f != nil {
+		for _, spec := range f.Imports {
+			if importPath, err := strconv.Unquote(spec.Path.Value); err == nil {
+				imports[importName(spec, importPath)] = importPath
+			}
+		}
+	}
+
+	noLink := make(map[*ast.Ident]bool) // declared names and field selectors
+	local := make(map[string]bool)      // type parameters
+	qualified := make(map[*ast.Ident]string)
+	declareFields := func(list *ast.FieldList, isTypeParams bool) {
+		if list == nil {
+			return
+		}
+		for _, field := range list.List {
+			for _, name := range field.Names {
+				noLink[name] = true
+				if isTypeParams {
+					local[name.Name] = true
+				}
+			}
+		}
+	}
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			noLink[n.Name] = true
+			declareFields(n.Recv, false)
+			if n.Recv != nil && len(n.Recv.List) > 0 {
+				// Receiver type parameters, as in func (l *List[T]) Len().
+				ast.Inspect(n.Recv.List[0].Type, func(n ast.Node) bool {
+					var indices []ast.Expr
+					switch n := n.(type) {
+					case *ast.IndexExpr:
+						indices = []ast.Expr{n.Index}
+					case *ast.IndexListExpr:
+						indices = n.Indices
+					}
+					for _, x := range indices {
+						if id, ok := x.(*ast.Ident); ok {
+							noLink[id] = true
+							local[id.Name] = true
+						}
+					}
+					return true
+				})
+			}
+		case *ast.FuncType:
+			declareFields(n.TypeParams, true)
+			declareFields(n.Params, false)
+			declareFields(n.Results, false)
+		case *ast.TypeSpec:
+			noLink[n.Name] = true
+			declareFields(n.TypeParams, true)
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				noLink[name] = true
+			}
+		case *ast.StructType:
+			declareFields(n.Fields, false)
+		case *ast.InterfaceType:
+			declareFields(n.Methods, false)
+		case *ast.KeyValueExpr:
+			if id, ok := n.Key.(*ast.Ident); ok {
+				noLink[id] = true
+			}
+		case *ast.SelectorExpr:
+			if id, ok := n.X.(*ast.Ident); ok && imports[id.Name] != "" {
+				qualified[id] = "/pkg/" + imports[id.Name]
+				qualified[n.Sel] = "/pkg/" + imports[id.Name] + "#" + n.Sel.Name
+			} else {
+				noLink[n.Sel] = true
+			}
+		}
+		return true
+	})
+
+	var links []identLink
+	ast.Inspect(node, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		link := identLink{name: id.Name}
+		switch {
+		case qualified[id] != "":
+			link.url = qualified[id]
+		case noLink[id], local[id.Name]:
+		case p.topLevel[id.Name]:
+			link.url = "#" + id.Name
+		case doc.IsPredeclared(id.Name):
+			link.url = "/pkg/builtin#" + id.Name
+		}
+		links = append(links, link)
+		return true
+	})
+	return links
+}
+
+// importName returns the name by which a file refers to the package
+// imported by spec. Without loading the package, it assumes that the
+// package name is the last element of its import path, ignoring any
+// major version suffix, "go-" prefix, and anything after a '.' or '-'.
+func importName(spec *ast.ImportSpec, importPath string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexAny(name, ".-"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func (s *docServer) serveSource(w http.ResponseWriter, r *http.Request) {
+	importPath, file := path.Split(r.PathValue("path"))
+	d, ok := s.byPath[strings.TrimSuffix(importPath, "/")]
+	if !ok || !strings.HasSuffix(file, ".go") || filepath.Base(file) != file {
+		http.NotFound(w, r)
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(d.dir, file))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	s.render(w, sourceTemplate, path.Join(d.importPath, file), struct {
+		ImportPath string
+		File       string
+		Lines      []string
+	}{d.importPath, file, strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")})
+}
+
+// render writes the page with the given title and body template,
+// executed with data, to w.
+func (s *docServer) render(w http.ResponseWriter, body *template.Template, title string, data any) {
+	var buf bytes.Buffer
+	err := body.Execute(&buf, struct {
+		Title string
+		Data  any
+	}{title, data})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+func newTemplate(body string) *template.Template {
+	funcs := template.FuncMap{
+		"inc": func(i int) int { return i + 1 },
+	}
+	t := template.Must(template.New("page").Funcs(funcs).Parse(pageTemplate))
+	return template.Must(t.New("body").Parse(body))
+}
+
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} - Go Documentation</title>
+<style>
+body { font-family: sans-serif; margin: 0; line-height: 1.4; color: #202224; }
+header { background: #e0ebf5; padding: 0.5em 1em; display: flex; gap: 1em; align-items: center; }
+header a { font-weight: bold; color: #202224; text-decoration: none; }
+main { padding: 0 1em 2em; max-width: 60em; }
+a { color: #007d9c; }
+pre { background: #f6f8fa; padding: 0.6em; overflow-x: auto; }
+pre a { color: inherit; }
+h2 { border-bottom: 1px solid #ccc; }
+h3 .src, h4 .src { font-size: smaller; font-weight: normal; }
+.index ul { list-style: none; padding-left: 1em; margin: 0; }
+.lines { padding: 0; }
+.lines span { display: block; }
+.lines span:target { background: #ffffa0; }
+.ln { display: inline-block; width: 5ch; text-align: right; margin-right: 1ch; color: #999; text-decoration: none; }
+</style>
+</head>
+<body>
+<header>
+<a href="/">Go Documentation</a>
+<form action="/search"><input type="search" name="q" placeholder="Search packages or symbols" size="40"></form>
+</header>
+<main>
+{{template "body" .}}
+</main>
+</body>
+</html>
+`
+
+var indexTemplate = newTemplate(`
+<h1>Packages</h1>
+{{range .Data}}{{if .Pkgs}}
+<h2>{{.Title}}</h2>
+<ul>
+{{range .Pkgs}}<li><a href="/pkg/{{.}}">{{.}}</a></li>
+{{end}}</ul>
+{{end}}{{end}}
+`)
+
+var searchTemplate = newTemplate(`
+<h1>{{.Title}}</h1>
+{{with .Data}}
+{{if .Symbols}}
+<h2>Symbols</h2>
+<ul>
+{{range .Symbols}}<li><a href="{{.Path}}">{{.Text}}</a></li>
+{{end}}</ul>
+{{end}}
+{{if .Packages}}
+<h2>Packages</h2>
+<ul>
+{{range .Packages}}<li><a href="{{.Path}}">{{.Text}}</a></li>
+{{end}}</ul>
+{{end}}
+{{if or (eq (len .Symbols) .MaxResults) (eq (len .Packages) .MaxResults)}}<p>Only the first {{.MaxResults}} results are shown.</p>{{end}}
+{{if not (or .Symbols .Packages)}}<p>No packages or symbols match “{{.Query}}”.</p>{{end}}
+{{end}}
+`)
+
+var packageTemplate = newTemplate(`
+<h1>{{.Title}}</h1>
+{{with .Data}}
+{{if not .Command}}<pre>import "{{.ImportPath}}"</pre>{{end}}
+<section id="pkg-overview">
+{{.Doc}}
+</section>
+{{if or .Consts .Vars .Funcs .Types}}
+<section id="pkg-index" class="index">
+<h2>Index</h2>
+<ul>
+{{if .Consts}}<li><a href="#pkg-constants">Constants</a></li>{{end}}
+{{if .Vars}}<li><a href="#pkg-variables">Variables</a></li>{{end}}
+{{range .Funcs}}<li><a href="#{{index .IDs 0}}">{{.Name}}</a></li>
+{{end}}
+{{range .Types}}<li><a href="#{{index .IDs 0}}">type {{.Name}}</a>
+<ul>
+{{range .Funcs}}<li><a href="#{{index .IDs 0}}">{{.Name}}</a></li>
+{{end}}
+{{range .Methods}}<li><a href="#{{index .IDs 0}}">{{.Name}}</a></li>
+{{end}}
+</ul></li>
+{{end}}
+{{if .Bugs}}<li><a href="#pkg-note-BUG">Bugs</a></li>{{end}}
+</ul>
+</section>
+{{end}}
+{{if .Consts}}<h2 id="pkg-constants">Constants</h2>{{range .Consts}}{{template "value" .}}{{end}}{{end}}
+{{if .Vars}}<h2 id="pkg-variables">Variables</h2>{{range .Vars}}{{template "value" .}}{{end}}{{end}}
+{{if .Funcs}}<h2 id="pkg-functions">Functions</h2>{{range .Funcs}}{{template "func" .}}{{end}}{{end}}
+{{if .Types}}<h2 id="pkg-types">Types</h2>{{range .Types}}
+<h3 id="{{index .IDs 0}}">type {{.Name}} <a class="src" href="{{.Src}}">source</a></h3>
+<pre>{{.Decl}}</pre>
+{{.Doc}}
+{{range .Consts}}{{template "value" .}}{{end}}
+{{range .Vars}}{{template "value" .}}{{end}}
+{{range .Funcs}}{{template "func" .}}{{end}}
+{{range .Methods}}{{template "func" .}}{{end}}
+{{end}}{{end}}
+{{if .Bugs}}<h2 id="pkg-note-BUG">Bugs</h2><ul>{{range .Bugs}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .Files}}
+<h2 id="pkg-files">Source Files</h2>
+<ul>
+{{range .Files}}<li><a href="/src/{{$.Data.ImportPath}}/{{.}}">{{.}}</a></li>
+{{end}}</ul>
+{{end}}
+{{if .Subdirs}}
+<h2 id="pkg-subdirectories">Directories</h2>
+<ul>
+{{range .Subdirs}}<li><a href="/pkg/{{.}}">{{.}}</a></li>
+{{end}}</ul>
+{{end}}
+{{end}}
+{{define "value"}}
+<div>{{range .IDs}}<span id="{{.}}"></span>{{end}}
+<pre>{{.Decl}}</pre>
+<a class="src" href="{{.Src}}">source</a>
+{{.Doc}}
+</div>
+{{end}}
+{{define "func"}}
+<h4 id="{{index .IDs 0}}">{{.Name}} <a class="src" href="{{.Src}}">source</a></h4>
+<pre>{{.Decl}}</pre>
+{{.Doc}}
+{{end}}
+`)
+
+var sourceTemplate = newTemplate(`
+<h1>{{.Title}}</h1>
+{{with .Data}}
+<p>Package <a href="/pkg/{{.ImportPath}}">{{.ImportPath}}</a></p>
+<pre class="lines">{{range $i, $line := .Lines}}<span id="L{{inc $i}}"><a class="ln" href="#L{{inc $i}}">{{inc $i}}</a>{{$line}}</span>{{end}}</pre>
+{{end}}
+`)
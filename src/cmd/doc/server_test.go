@@ -0,0 +1,152 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocTarget(t *testing.T) {
+	maybeSkip(t)
+	testdataDir, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		args   []string
+		anchor string
+	}{
+		{[]string{p}, ""},
+		{[]string{p, "ExportedFunc"}, "#ExportedFunc"},
+		{[]string{p, "exportedtype"}, "#ExportedType"},
+		{[]string{p, "ExportedType.ExportedMethod"}, "#ExportedType.ExportedMethod"},
+		{[]string{p, "ExportedType.ExportedField"}, "#ExportedType"},
+		{[]string{p, "ConstTwo"}, "#ConstTwo"},
+	}
+	for _, tt := range tests {
+		dir, anchor, err := docTarget(tt.args)
+		if err != nil {
+			t.Errorf("docTarget(%q): %v", tt.args, err)
+			continue
+		}
+		if dir != testdataDir || anchor != tt.anchor {
+			t.Errorf("docTarget(%q) = %q, %q; want %q, %q", tt.args, dir, anchor, testdataDir, tt.anchor)
+		}
+	}
+	if _, _, err := docTarget([]string{p, "NoSuchSymbol"}); err == nil {
+		t.Errorf("docTarget(%q) succeeded, want error", []string{p, "NoSuchSymbol"})
+	}
+}
+
+func TestDocServer(t *testing.T) {
+	maybeSkip(t)
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config.Handler = newDocServer().handler(ts.Listener.Addr().String())
+	ts.Start()
+	defer ts.Close()
+
+	tests := []struct {
+		path   string
+		status int
+		want   []string
+		reject []string
+	}{
+		{
+			path:   "/",
+			status: http.StatusOK,
+			want:   []string{`<a href="/pkg/testdata">testdata</a>`, `<a href="/pkg/fmt">fmt</a>`},
+		},
+		{
+			path:   "/pkg/testdata",
+			status: http.StatusOK,
+			want: []string{
+				`<h1>Package pkg</h1>`,
+				`<h4 id="ExportedFunc">func ExportedFunc <a class="src" href="/src/testdata/pkg.go#L59">source</a></h4>`,
+				`<h3 id="ExportedType">type ExportedType`,
+				`id="ExportedType.ExportedMethod"`,
+				`<span id="ExportedConstant"></span>`,
+				`func ReturnExported() <a href="#ExportedType">ExportedType</a>`,
+				`<a href="/pkg/testdata/nested">testdata/nested</a>`,
+				`<a href="/src/testdata/pkg.go">pkg.go</a>`,
+			},
+			reject: []string{`internalFunc`, `unexportedMethod`},
+		},
+		{
+			path:   "/src/testdata/pkg.go",
+			status: http.StatusOK,
+			want:   []string{`<span id="L59"><a class="ln" href="#L59">59</a>func ExportedFunc(a int) bool {</span>`},
+		},
+		{
+			path:   "/search?q=ExportedMethod",
+			status: http.StatusOK,
+			want:   []string{`<a href="/pkg/testdata#ExportedType.ExportedMethod">testdata.ExportedType.ExportedMethod</a>`},
+		},
+		{
+			path:   "/search?q=pkg.ExportedFunc",
+			status: http.StatusOK,
+			want:   []string{`<a href="/pkg/testdata#ExportedFunc">testdata.ExportedFunc</a>`},
+		},
+		{path: "/pkg/no/such/package", status: http.StatusNotFound},
+		{path: "/src/testdata/nested", status: http.StatusNotFound},
+		{path: "/src/testdata/missing.go", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(ts.URL + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.status {
+			t.Errorf("GET %s: status %d, want %d", tt.path, resp.StatusCode, tt.status)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(body), want) {
+				t.Errorf("GET %s: missing %s", tt.path, want)
+			}
+		}
+		for _, reject := range tt.reject {
+			if strings.Contains(string(body), reject) {
+				t.Errorf("GET %s: unexpected %s", tt.path, reject)
+			}
+		}
+	}
+}
+
+func TestLoopbackHost(t *testing.T) {
+	h := loopbackHost("127.0.0.1:6060", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range []struct {
+		host   string
+		status int
+	}{
+		{"127.0.0.1:6060", http.StatusOK},
+		{"localhost:6060", http.StatusOK},
+		{"LocalHost:6060", http.StatusOK},
+		{"localhost", http.StatusForbidden},
+		{"127.0.0.1", http.StatusForbidden},
+		{"localhost:6061", http.StatusForbidden},
+		{"127.0.0.2:6060", http.StatusForbidden},
+		{"example.com:6060", http.StatusForbidden},
+		{"localhost.example.com:6060", http.StatusForbidden},
+		{"", http.StatusForbidden},
+	} {
+		r := httptest.NewRequest("GET", "/pkg/fmt", nil)
+		r.Host = tt.host
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("GET with Host %q: status %d, want %d", tt.host, w.Code, tt.status)
+		}
+	}
+}
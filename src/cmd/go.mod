@@ -6,9 +6,9 @@ require (
 	github.com/google/pprof v0.0.0-20241101162523-b92577c0c142
 	golang.org/x/arch v0.12.0
 	golang.org/x/build v0.0.0-20241119201203-2f2bd003cf4c
-	golang.org/x/mod v0.22.0
-	golang.org/x/sync v0.9.1-0.20241113011828-913fb63af28f
-	golang.org/x/sys v0.27.1-0.20241118193836-0a57dbcf35b2
+	golang.org/x/mod v0.25.0
+	golang.org/x/sync v0.11.0
+	golang.org/x/sys v0.30.0
 	golang.org/x/telemetry v0.0.0-20241108154256-525ce2e96f55
 	golang.org/x/term v0.26.0
 	golang.org/x/tools v0.27.1-0.20241122193402-68caf84fca7f
@@ -16,6 +16,6 @@ require (
 
 require (
 	github.com/ianlancetaylor/demangle v0.0.0-20240912202439-0a2b6291aafd // indirect
-	golang.org/x/text v0.20.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
 	rsc.io/markdown v0.0.0-20240306144322-0bf8f97ee8ef // indirect
 )
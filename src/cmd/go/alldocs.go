@@ -446,6 +446,13 @@
 //		Treat a command (package main) like a regular package.
 //		Otherwise package main's exported symbols are hidden
 //		when showing the package's top-level documentation.
+//	-http
+//		Serve HTML documentation over HTTP, and open it in a web
+//		browser at the documentation for the requested package or
+//		symbol. The server presents the packages of the standard
+//		library, the current module or workspace, and its
+//		dependencies, with links between them, links to their
+//		source code, and search. It runs until interrupted.
 //	-short
 //		One-line representation for each symbol.
 //	-src
@@ -1263,9 +1270,13 @@
 // The -tool=path and -droptool=path flags add and drop a tool declaration
 // for the given path.
 //
+// The -ignore=path and -dropignore=path flags add and drop an ignore
+// declaration for the given directory path.
+//
 // The -godebug, -dropgodebug, -require, -droprequire, -exclude, -dropexclude,
-// -replace, -dropreplace, -retract, -dropretract, -tool, and -droptool editing
-// flags may be repeated, and the changes are applied in the order given.
+// -replace, -dropreplace, -retract, -dropretract, -tool, -droptool, -ignore,
+// and -dropignore editing flags may be repeated, and the changes are applied
+// in the order given.
 //
 // The -print flag prints the final go.mod in its text format instead of
 // writing it back to go.mod.
@@ -1287,6 +1298,8 @@
 //		Exclude   []Module
 //		Replace   []Replace
 //		Retract   []Retract
+//		Tool      []Tool
+//		Ignore    []Ignore
 //	}
 //
 //	type ModPath struct {
@@ -1320,6 +1333,10 @@
 //		Path string
 //	}
 //
+//	type Ignore struct {
+//		Path string
+//	}
+//
 // Retract entries representing a single version (not an interval) will have
 // the "Low" and "High" fields set to the same value.
 //
@@ -1895,7 +1912,8 @@
 // The rule for a match in the cache is that the run involves the same
 // test binary and the flags on the command line come entirely from a
 // restricted set of 'cacheable' test flags, defined as -benchtime, -cpu,
-// -list, -parallel, -run, -short, -timeout, -failfast, -fullpath and -v.
+// -list, -parallel, -run, -shard, -short, -testtimeout, -timeout, -failfast,
+// -fullpath and -v.
 // If a run of go test has any test or non-test flags outside this set,
 // the result is not cached. To disable test caching, use any test flag
 // or argument other than the cacheable flags. The idiomatic way to disable
@@ -1934,6 +1952,21 @@
 //	    If file ends in a slash or names an existing directory,
 //	    the test is written to pkg.test in that directory.
 //
+//	-retry n
+//	    If a package's test binary runs to completion but some of its
+//	    top-level tests or examples fail, rerun the test binary up to n
+//	    more times, each time running only the tests that failed in the
+//	    previous run, selected by -run with their exact names. Each rerun
+//	    is announced by a "=== RETRY name" line for each test it runs.
+//	    A test that fails and then passes on a rerun is reported as flaky
+//	    by a "=== FLAKY name" line, and the package passes if all its
+//	    failed tests pass on a rerun; test results that needed a rerun
+//	    are not cached. With -json, these lines are reported as events
+//	    with the "retry" and "flaky" actions. Tests are not rerun if the
+//	    test binary panics, times out, or otherwise exits abnormally,
+//	    or if a benchmark fails. The -retry flag cannot be combined
+//	    with -failfast, -fuzz, or the profiling flags.
+//
 // The test binary also accepts flags that control execution of the test; these
 // flags are also accessible by 'go test'. See 'go help testflag' for details.
 //
@@ -1998,12 +2031,34 @@
 // For a list of checkers and their flags, see 'go tool vet help'.
 // For details of a specific checker such as 'printf', see 'go tool vet help printf'.
 //
+// A file named govet.cfg in the root directory of a main module configures
+// the analyzers run on the packages of that module, both by go vet and by
+// the vet step of go test. Each line of the file holds a directive:
+//
+//	enable analyzer...        run the analyzers in addition to the defaults
+//	disable analyzer...       do not run the analyzers
+//	flag analyzer.flag=value  set an analyzer flag
+//
+// A '#' starts a comment that runs to the end of the line. For example:
+//
+//	# Report impossible nil checks and unintended shadowing.
+//	enable nilness shadow
+//	flag shadow.strict=true
+//	flag printf.funcs=Logf,Warnf
+//
+// Analyzers enabled in govet.cfg also run during go test, in addition to
+// its high-confidence subset of analyzers, and analyzers disabled in it are
+// removed from that subset. Flags given on the command line take precedence
+// over the settings of govet.cfg. In particular, naming analyzers on the
+// command line selects exactly those analyzers.
+//
 // The -vettool=prog flag selects a different analysis tool with alternative
-// or additional checks.
-// For example, the 'shadow' analyzer can be built and run using these commands:
+// or additional checks. The settings of govet.cfg do not apply to it.
+// For example, the 'fieldalignment' analyzer can be built and run using
+// these commands:
 //
-//	go install golang.org/x/tools/go/analysis/passes/shadow/cmd/shadow@latest
-//	go vet -vettool=$(which shadow)
+//	go install golang.org/x/tools/go/analysis/passes/fieldalignment/cmd/fieldalignment@latest
+//	go vet -vettool=$(which fieldalignment)
 //
 // The build flags supported by go vet are those that control package resolution
 // and execution, such as -C, -n, -x, -v, -tags, and -toolexec.
@@ -3134,6 +3189,13 @@
 // Directory and file names that begin with "." or "_" are ignored
 // by the go tool, as are directories named "testdata".
 //
+// In module mode, patterns containing "..." and the "all" pattern also skip
+// any directories listed in ignore directives in the main module's go.mod
+// file. An ignore path beginning with "./", such as "./node_modules", names
+// a directory relative to the module root; any other path, such as
+// "node_modules", names every directory with that name anywhere in the module.
+// Ignored directories are still included in the module's zip file.
+//
 // # Configuration for downloading non-public code
 //
 // The go command defaults to downloading modules from the public Go module
@@ -3188,6 +3250,10 @@
 // The following flags are recognized by the 'go test' command and
 // control the execution of any test:
 //
+//	-artifacts
+//	    Save test artifacts in the directory specified by -outputdir.
+//	    See 'go doc testing.T.ArtifactDir'.
+//
 //	-bench regexp
 //	    Run only those benchmarks matching a regular expression.
 //	    By default, no benchmarks are run.
@@ -3315,6 +3381,21 @@
 //	    the Go tree can run a sanity check but not spend time running
 //	    exhaustive tests.
 //
+//	-shard i/n
+//	    Run only the tests, examples, benchmarks, and fuzz tests in shard i
+//	    of n, where 1 <= i <= n. Each top-level test is assigned to exactly
+//	    one shard by a hash of its package import path and its name, so
+//	    running every shard from 1/n to n/n, for example on n separate
+//	    machines, runs each test exactly once. The assignment is
+//	    deterministic and does not depend on which other tests exist,
+//	    so adding a test does not move other tests between shards.
+//	    Subtests always run in the same shard as their top-level test.
+//	    A package none of whose tests are in the shard is not built or
+//	    run, and is reported as having no tests in the shard, unless -c,
+//	    -o, or a profiling flag requires its test binary.
+//	    When -json is set, the final event for each package records the
+//	    shard in its Shard field.
+//
 //	-shuffle off,on,N
 //	    Randomize the execution order of tests and benchmarks.
 //	    It is off by default. If -shuffle is set to on, then it will seed
@@ -3330,6 +3411,15 @@
 //	    part of a test's identifier must match the corresponding element in
 //	    the sequence, if any.
 //
+//	-testtimeout d
+//	    If a top-level test runs longer than duration d, fail the test,
+//	    print the stack traces of its goroutines, and cancel the
+//	    context returned by its Context method. Unlike -timeout, this
+//	    does not stop the test binary: if the test returns once its
+//	    context is canceled, the remaining tests continue to run.
+//	    If d is 0 (the default), the timeout is disabled.
+//	    A test can change its own timeout with the SetTimeout method.
+//
 //	-timeout d
 //	    If a test binary runs longer than duration d, panic.
 //	    If d is 0, the timeout is disabled.
@@ -3394,8 +3484,9 @@
 //	    contended mutex.
 //
 //	-outputdir directory
-//	    Place output files from profiling in the specified directory,
-//	    by default the directory in which "go test" is running.
+//	    Place output files from profiling and test artifacts in the
+//	    specified directory, by default the directory in which "go test"
+//	    is running.
 //
 //	-trace trace.out
 //	    Write an execution trace to the specified file before exiting.
@@ -114,6 +114,13 @@ Flags:
 		Treat a command (package main) like a regular package.
 		Otherwise package main's exported symbols are hidden
 		when showing the package's top-level documentation.
+	-http
+		Serve HTML documentation over HTTP, and open it in a web
+		browser at the documentation for the requested package or
+		symbol. The server presents the packages of the standard
+		library, the current module or workspace, and its
+		dependencies, with links between them, links to their
+		source code, and search. It runs until interrupted.
 	-short
 		One-line representation for each symbol.
 	-src
@@ -119,6 +119,13 @@ in those files and ignoring any other files in the directory.
 
 Directory and file names that begin with "." or "_" are ignored
 by the go tool, as are directories named "testdata".
+
+In module mode, patterns containing "..." and the "all" pattern also skip
+any directories listed in ignore directives in the main module's go.mod
+file. An ignore path beginning with "./", such as "./node_modules", names
+a directory relative to the module root; any other path, such as
+"node_modules", names every directory with that name anywhere in the module.
+Ignored directories are still included in the module's zip file.
 	`,
 }
 
@@ -651,6 +651,22 @@ func loadTestFuncs(ptest *Package) (*testFuncs, error) {
 	return t, err
 }
 
+// TestShardNames returns the import path and names that the testing
+// package uses to assign the tests, benchmarks, fuzz tests, and examples
+// of p to shards of the -test.shard flag.
+func TestShardNames(p *Package) (importPath string, names []string, err error) {
+	t, err := loadTestFuncs(p)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, list := range [][]testFunc{t.Tests, t.Benchmarks, t.FuzzTargets, t.Examples} {
+		for _, f := range list {
+			names = append(names, f.Name)
+		}
+	}
+	return t.ImportPath(), names, nil
+}
+
 // formatTestmain returns the content of the _testmain.go file for t.
 func formatTestmain(t *testFuncs) ([]byte, error) {
 	var buf bytes.Buffer
@@ -691,6 +707,14 @@ func (t *testFuncs) ImportPath() string {
 	return pkg
 }
 
+func (t *testFuncs) ModulePath() string {
+	m := t.Package.Module
+	if m == nil {
+		return ""
+	}
+	return m.Path
+}
+
 // Covered returns a string describing which packages are being tested for coverage.
 // If the covered package is the same as the tested package, it returns the empty string.
 // Otherwise it is a comma-separated human-readable list of packages beginning with
@@ -871,6 +895,7 @@ var examples = []testing.InternalExample{
 }
 
 func init() {
+	testdeps.ModulePath = {{.ModulePath | printf "%q"}}
 	testdeps.ImportPath = {{.ImportPath | printf "%q"}}
 }
 
@@ -991,6 +1016,7 @@ func init() {
 	testdeps.CoverMarkProfileEmittedFunc = cfile.MarkProfileEmitted
 
 {{end}}
+	testdeps.ModulePath = {{.ModulePath | printf "%q"}}
 	testdeps.ImportPath = {{.ImportPath | printf "%q"}}
 }
 
@@ -213,6 +213,16 @@ func (mms *MainModuleSet) ModFile(m module.Version) *modfile.File {
 	return mms.modFiles[m]
 }
 
+// IgnorePatterns returns the directories excluded from package pattern
+// matching by the ignore directives in the go.mod file of main module m.
+// It returns nil if m has no ignore directives.
+func (mms *MainModuleSet) IgnorePatterns(m module.Version) *search.IgnorePatterns {
+	if mms == nil {
+		return nil
+	}
+	return search.ModFileIgnorePatterns(mms.modFiles[m])
+}
+
 func (mms *MainModuleSet) WorkFile() *modfile.WorkFile {
 	return mms.workFile
 }
@@ -75,7 +75,7 @@ func matchPackages(ctx context.Context, m *search.Match, tags map[string]bool, f
 
 	q := par.NewQueue(runtime.GOMAXPROCS(0))
 
-	walkPkgs := func(root, importPathRoot string, prune pruning) {
+	walkPkgs := func(root, importPathRoot string, prune pruning, ignore *search.IgnorePatterns) {
 		_, span := trace.StartSpan(ctx, "walkPkgs "+root)
 		defer span.Done()
 
@@ -102,6 +102,11 @@ func matchPackages(ctx context.Context, m *search.Match, tags map[string]bool, f
 				_, elem = filepath.Split(pkgDir)
 				if strings.HasPrefix(elem, ".") || strings.HasPrefix(elem, "_") || elem == "testdata" {
 					want = false
+				} else if d.IsDir() && ignore.ShouldIgnore(pkgDir[len(root):]) {
+					if cfg.BuildX {
+						fmt.Fprintf(os.Stderr, "# ignoring directory %s\n", pkgDir)
+					}
+					want = false
 				}
 			}
 
@@ -157,20 +162,20 @@ func matchPackages(ctx context.Context, m *search.Match, tags map[string]bool, f
 	}()
 
 	if filter == includeStd {
-		walkPkgs(cfg.GOROOTsrc, "", pruneGoMod)
+		walkPkgs(cfg.GOROOTsrc, "", pruneGoMod, nil)
 		if treeCanMatch("cmd") {
-			walkPkgs(filepath.Join(cfg.GOROOTsrc, "cmd"), "cmd", pruneGoMod)
+			walkPkgs(filepath.Join(cfg.GOROOTsrc, "cmd"), "cmd", pruneGoMod, nil)
 		}
 	}
 
 	if cfg.BuildMod == "vendor" {
 		for _, mod := range MainModules.Versions() {
 			if modRoot := MainModules.ModRoot(mod); modRoot != "" {
-				walkPkgs(modRoot, MainModules.PathPrefix(mod), pruneGoMod|pruneVendor)
+				walkPkgs(modRoot, MainModules.PathPrefix(mod), pruneGoMod|pruneVendor, MainModules.IgnorePatterns(mod))
 			}
 		}
 		if HasModRoot() {
-			walkPkgs(VendorDir(), "", pruneVendor)
+			walkPkgs(VendorDir(), "", pruneVendor, nil)
 		}
 		return
 	}
@@ -183,6 +188,7 @@ func matchPackages(ctx context.Context, m *search.Match, tags map[string]bool, f
 		var (
 			root, modPrefix string
 			isLocal         bool
+			ignore          *search.IgnorePatterns
 		)
 		if MainModules.Contains(mod.Path) {
 			if MainModules.ModRoot(mod) == "" {
@@ -191,6 +197,7 @@ func matchPackages(ctx context.Context, m *search.Match, tags map[string]bool, f
 			root = MainModules.ModRoot(mod)
 			modPrefix = MainModules.PathPrefix(mod)
 			isLocal = true
+			ignore = MainModules.IgnorePatterns(mod)
 		} else {
 			var err error
 			root, isLocal, err = fetch(ctx, mod)
@@ -201,7 +208,7 @@ func matchPackages(ctx context.Context, m *search.Match, tags map[string]bool, f
 			modPrefix = mod.Path
 		}
 		if mi, err := modindex.GetModule(root); err == nil {
-			walkFromIndex(mi, modPrefix, isMatch, treeCanMatch, tags, have, addPkg)
+			walkFromIndex(mi, modPrefix, isMatch, treeCanMatch, tags, have, addPkg, ignore)
 			continue
 		} else if !errors.Is(err, modindex.ErrNotIndexed) {
 			m.AddError(err)
@@ -211,14 +218,15 @@ func matchPackages(ctx context.Context, m *search.Match, tags map[string]bool, f
 		if isLocal {
 			prune |= pruneGoMod
 		}
-		walkPkgs(root, modPrefix, prune)
+		walkPkgs(root, modPrefix, prune, ignore)
 	}
 }
 
 // walkFromIndex matches packages in a module using the module index. modroot
 // is the module's root directory on disk, index is the modindex.Module for the
-// module, and importPathRoot is the module's path prefix.
-func walkFromIndex(index *modindex.Module, importPathRoot string, isMatch, treeCanMatch func(string) bool, tags, have map[string]bool, addPkg func(string)) {
+// module, and importPathRoot is the module's path prefix. Directories matched
+// by ignore are skipped.
+func walkFromIndex(index *modindex.Module, importPathRoot string, isMatch, treeCanMatch func(string) bool, tags, have map[string]bool, addPkg func(string), ignore *search.IgnorePatterns) {
 	index.Walk(func(reldir string) {
 		// Avoid .foo, _foo, and testdata subdirectory trees.
 		p := reldir
@@ -240,6 +248,9 @@ func walkFromIndex(index *modindex.Module, importPathRoot string, isMatch, treeC
 			}
 			p = rest
 		}
+		if ignore.ShouldIgnore(reldir) {
+			return
+		}
 
 		// Don't use GOROOT/src.
 		if reldir == "" && importPathRoot == "" {
@@ -17,6 +17,8 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+
+	"golang.org/x/mod/modfile"
 )
 
 // A Match represents the result of matching a single package pattern.
@@ -253,19 +255,21 @@ func (m *Match) MatchDirs(modRoots []string) {
 	// We need to preserve the ./ for pattern matching
 	// and in the returned import paths.
 
-	if len(modRoots) > 1 {
+	var modRoot string
+	if len(modRoots) > 0 {
 		abs, err := filepath.Abs(dir)
 		if err != nil {
 			m.AddError(err)
 			return
 		}
 		var found bool
-		for _, modRoot := range modRoots {
-			if modRoot != "" && str.HasFilePathPrefix(abs, modRoot) {
+		for _, mr := range modRoots {
+			if mr != "" && str.HasFilePathPrefix(abs, mr) {
 				found = true
+				modRoot = mr
 			}
 		}
-		if !found {
+		if !found && len(modRoots) > 1 {
 			plural := ""
 			if len(modRoots) > 1 {
 				plural = "s"
@@ -274,6 +278,8 @@ func (m *Match) MatchDirs(modRoots []string) {
 		}
 	}
 
+	ignore := readIgnorePatterns(modRoot)
+
 	// If dir is actually a symlink to a directory,
 	// we want to follow it (see https://go.dev/issue/50807).
 	// Add a trailing separator to force that to happen.
@@ -306,6 +312,15 @@ func (m *Match) MatchDirs(modRoots []string) {
 			return filepath.SkipDir
 		}
 
+		if ignore != nil {
+			if abs, err := filepath.Abs(path); err == nil && ignore.ShouldIgnore(InDir(abs, modRoot)) {
+				if cfg.BuildX {
+					fmt.Fprintf(os.Stderr, "# ignoring directory %s\n", abs)
+				}
+				return filepath.SkipDir
+			}
+		}
+
 		if !top && cfg.ModulesEnabled {
 			// Ignore other modules found in subdirectories.
 			if info, err := fsys.Stat(filepath.Join(path, "go.mod")); err == nil && !info.IsDir() {
@@ -342,6 +357,78 @@ func (m *Match) MatchDirs(modRoots []string) {
 	}
 }
 
+// IgnorePatterns is the set of directories excluded from package pattern
+// matching by the ignore directives in a go.mod file.
+type IgnorePatterns struct {
+	rooted []string // patterns beginning with "./", matched at the module root
+	any    []string // other patterns, matched at any depth
+}
+
+// ModFileIgnorePatterns returns the IgnorePatterns for the ignore
+// directives in f. It returns nil if f has no ignore directives.
+func ModFileIgnorePatterns(f *modfile.File) *IgnorePatterns {
+	if f == nil || len(f.Ignore) == 0 {
+		return nil
+	}
+	p := new(IgnorePatterns)
+	for _, i := range f.Ignore {
+		rel, rooted := strings.CutPrefix(filepath.ToSlash(i.Path), "./")
+		rel = path.Clean("/" + rel)
+		if rel == "/" {
+			continue
+		}
+		rel += "/"
+		if rooted {
+			p.rooted = append(p.rooted, rel)
+		} else {
+			p.any = append(p.any, rel)
+		}
+	}
+	return p
+}
+
+// ShouldIgnore reports whether the directory dir, relative to the
+// module root, should be excluded from package pattern matching.
+//
+// An ignore pattern "./x" excludes the directory x at the module root and
+// everything beneath it. An ignore pattern "x" excludes every directory
+// named x (or, for a multi-element pattern, ending in that sequence of
+// elements) anywhere in the module, along with everything beneath it.
+func (p *IgnorePatterns) ShouldIgnore(dir string) bool {
+	if p == nil || dir == "" || dir == "." {
+		return false
+	}
+	dir = path.Clean("/"+filepath.ToSlash(dir)) + "/"
+	for _, pattern := range p.rooted {
+		if strings.HasPrefix(dir, pattern) {
+			return true
+		}
+	}
+	for _, pattern := range p.any {
+		if strings.Contains(dir, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// readIgnorePatterns returns the ignore patterns listed in the go.mod file
+// in modRoot, or nil if modRoot is empty or its go.mod cannot be read.
+func readIgnorePatterns(modRoot string) *IgnorePatterns {
+	if modRoot == "" {
+		return nil
+	}
+	data, err := fsys.ReadFile(filepath.Join(modRoot, "go.mod"))
+	if err != nil {
+		return nil
+	}
+	f, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return nil
+	}
+	return ModFileIgnorePatterns(f)
+}
+
 // WarnUnmatched warns about patterns that didn't match any packages.
 func WarnUnmatched(matches []*Match) {
 	for _, m := range matches {
@@ -9,6 +9,7 @@ package test
 // passFlagToTest contains the flags that should be forwarded to
 // the test binary with the prefix "test.".
 var passFlagToTest = map[string]bool{
+	"artifacts":            true,
 	"bench":                true,
 	"benchmem":             true,
 	"benchtime":            true,
@@ -31,9 +32,11 @@ var passFlagToTest = map[string]bool{
 	"outputdir":            true,
 	"parallel":             true,
 	"run":                  true,
+	"shard":                true,
 	"short":                true,
 	"shuffle":              true,
 	"skip":                 true,
+	"testtimeout":          true,
 	"timeout":              true,
 	"trace":                true,
 	"v":                    true,
@@ -61,8 +64,10 @@ var passAnalyzersToVet = map[string]bool{
 	"lostcancel":       true,
 	"methods":          true,
 	"nilfunc":          true,
+	"nilness":          true,
 	"printf":           true,
 	"rangeloops":       true,
+	"shadow":           true,
 	"shift":            true,
 	"sigchanyzer":      true,
 	"slog":             true,
@@ -77,4 +82,5 @@ var passAnalyzersToVet = map[string]bool{
 	"unreachable":      true,
 	"unsafeptr":        true,
 	"unusedresult":     true,
+	"unusedwrite":      true,
 }
@@ -127,7 +127,8 @@ elapsed time in the summary line.
 The rule for a match in the cache is that the run involves the same
 test binary and the flags on the command line come entirely from a
 restricted set of 'cacheable' test flags, defined as -benchtime, -cpu,
--list, -parallel, -run, -short, -timeout, -failfast, -fullpath and -v.
+-list, -parallel, -run, -shard, -short, -testtimeout, -timeout, -failfast,
+-fullpath and -v.
 If a run of go test has any test or non-test flags outside this set,
 the result is not cached. To disable test caching, use any test flag
 or argument other than the cacheable flags. The idiomatic way to disable
@@ -166,6 +167,21 @@ In addition to the build flags, the flags handled by 'go test' itself are:
 	    If file ends in a slash or names an existing directory,
 	    the test is written to pkg.test in that directory.
 
+	-retry n
+	    If a package's test binary runs to completion but some of its
+	    top-level tests or examples fail, rerun the test binary up to n
+	    more times, each time running only the tests that failed in the
+	    previous run, selected by -run with their exact names. Each rerun
+	    is announced by a "=== RETRY name" line for each test it runs.
+	    A test that fails and then passes on a rerun is reported as flaky
+	    by a "=== FLAKY name" line, and the package passes if all its
+	    failed tests pass on a rerun; test results that needed a rerun
+	    are not cached. With -json, these lines are reported as events
+	    with the "retry" and "flaky" actions. Tests are not rerun if the
+	    test binary panics, times out, or otherwise exits abnormally,
+	    or if a benchmark fails. The -retry flag cannot be combined
+	    with -failfast, -fuzz, or the profiling flags.
+
 The test binary also accepts flags that control execution of the test; these
 flags are also accessible by 'go test'. See 'go help testflag' for details.
 
@@ -191,6 +207,10 @@ options of pprof control how the information is presented.
 The following flags are recognized by the 'go test' command and
 control the execution of any test:
 
+	-artifacts
+	    Save test artifacts in the directory specified by -outputdir.
+	    See 'go doc testing.T.ArtifactDir'.
+
 	-bench regexp
 	    Run only those benchmarks matching a regular expression.
 	    By default, no benchmarks are run.
@@ -318,6 +338,21 @@ control the execution of any test:
 	    the Go tree can run a sanity check but not spend time running
 	    exhaustive tests.
 
+	-shard i/n
+	    Run only the tests, examples, benchmarks, and fuzz tests in shard i
+	    of n, where 1 <= i <= n. Each top-level test is assigned to exactly
+	    one shard by a hash of its package import path and its name, so
+	    running every shard from 1/n to n/n, for example on n separate
+	    machines, runs each test exactly once. The assignment is
+	    deterministic and does not depend on which other tests exist,
+	    so adding a test does not move other tests between shards.
+	    Subtests always run in the same shard as their top-level test.
+	    A package none of whose tests are in the shard is not built or
+	    run, and is reported as having no tests in the shard, unless -c,
+	    -o, or a profiling flag requires its test binary.
+	    When -json is set, the final event for each package records the
+	    shard in its Shard field.
+
 	-shuffle off,on,N
 	    Randomize the execution order of tests and benchmarks.
 	    It is off by default. If -shuffle is set to on, then it will seed
@@ -333,6 +368,15 @@ control the execution of any test:
 	    part of a test's identifier must match the corresponding element in
 	    the sequence, if any.
 
+	-testtimeout d
+	    If a top-level test runs longer than duration d, fail the test,
+	    print the stack traces of its goroutines, and cancel the
+	    context returned by its Context method. Unlike -timeout, this
+	    does not stop the test binary: if the test returns once its
+	    context is canceled, the remaining tests continue to run.
+	    If d is 0 (the default), the timeout is disabled.
+	    A test can change its own timeout with the SetTimeout method.
+
 	-timeout d
 	    If a test binary runs longer than duration d, panic.
 	    If d is 0, the timeout is disabled.
@@ -397,8 +441,9 @@ profile the tests during execution:
 	    contended mutex.
 
 	-outputdir directory
-	    Place output files from profiling in the specified directory,
-	    by default the directory in which "go test" is running.
+	    Place output files from profiling and test artifacts in the
+	    specified directory, by default the directory in which "go test"
+	    is running.
 
 	-trace trace.out
 	    Write an execution trace to the specified file before exiting.
@@ -539,6 +584,7 @@ See the documentation of the testing package for more information.
 }
 
 var (
+	testArtifacts    bool                              // -artifacts flag
 	testBench        string                            // -bench flag
 	testC            bool                              // -c flag
 	testCoverPkgs    []*load.Package                   // -coverpkg flag
@@ -549,6 +595,8 @@ var (
 	testList         string                            // -list flag
 	testO            string                            // -o flag
 	testOutputDir    outputdirFlag                     // -outputdir flag
+	testRetry        int                               // -retry flag
+	testShard        shardFlag                         // -shard flag
 	testShuffle      shuffleFlag                       // -shuffle flag
 	testTimeout      time.Duration                     // -timeout flag
 	testV            testVFlag                         // -v flag
@@ -710,6 +758,21 @@ func runTest(ctx context.Context, cmd *base.Command, args []string) {
 		base.Fatalf("no packages to test")
 	}
 
+	if testRetry < 0 {
+		base.Fatalf("-retry must be non-negative")
+	}
+	if testRetry > 0 {
+		if testFailFast {
+			base.Fatalf("cannot use -retry flag with -failfast flag")
+		}
+		if testFuzz != "" {
+			base.Fatalf("cannot use -retry flag with -fuzz flag")
+		}
+		if profileFlag := testProfile(); profileFlag != "" {
+			base.Fatalf("cannot use %s flag with -retry flag", profileFlag)
+		}
+	}
+
 	if testFuzz != "" {
 		if !platform.FuzzSupported(cfg.Goos, cfg.Goarch) {
 			base.Fatalf("-fuzz flag is not supported on %s/%s", cfg.Goos, cfg.Goarch)
@@ -1005,6 +1068,7 @@ func runTest(ctx context.Context, cmd *base.Command, args []string) {
 			var stdout io.Writer = os.Stdout
 			if testJSON {
 				json := test2json.NewConverter(stdout, p.ImportPath, test2json.Timestamp)
+				json.SetShard(testShard.String())
 				defer func() {
 					json.Exited(err)
 					json.Close()
@@ -1059,6 +1123,23 @@ func runTest(ctx context.Context, cmd *base.Command, args []string) {
 	b.Do(ctx, root)
 }
 
+// shardHasTests reports whether any of the tests, benchmarks, fuzz tests,
+// or examples of p are in the -shard shard.
+// If the test files cannot be parsed, it reports true,
+// leaving the error to be reported when building the test binary.
+func shardHasTests(p *load.Package) bool {
+	importPath, names, err := load.TestShardNames(p)
+	if err != nil {
+		return true
+	}
+	for _, name := range names {
+		if testShard.contains(importPath, name) {
+			return true
+		}
+	}
+	return false
+}
+
 var windowsBadWords = []string{
 	"install",
 	"patch",
@@ -1110,6 +1191,26 @@ func builderTest(b *work.Builder, ctx context.Context, pkgOpts load.PackageOpts,
 		return build, run, print, nil, nil
 	}
 
+	if testShard.n > 0 && !testC && !testNeedBinary() && !shardHasTests(p) {
+		// None of the package's tests are in the shard,
+		// so there is no need to build or run the test binary.
+		build := &work.Action{Mode: "nop"}
+		run := &work.Action{
+			Mode:    "test run",
+			Actor:   &runTestActor{notInShard: true},
+			Deps:    []*work.Action{build},
+			Package: p,
+		}
+		print := &work.Action{
+			Mode:       "test print",
+			Actor:      work.ActorFunc(builderPrintTest),
+			Deps:       []*work.Action{run},
+			Package:    p,
+			IgnoreFail: true, // print even if test failed
+		}
+		return build, run, print, nil, nil
+	}
+
 	// Build Package structs describing:
 	//	pmain - pkg.test binary
 	//	ptest - package + test files
@@ -1342,6 +1443,10 @@ type runTestActor struct {
 	// sequencing of json start messages, to preserve test order
 	prev <-chan struct{} // wait to start until prev is closed
 	next chan<- struct{} // close next once the next test can start.
+
+	// notInShard reports that none of the package's tests are in the
+	// -shard shard, so there is no test binary to run.
+	notInShard bool
 }
 
 // runCache is the cache for running a single test.
@@ -1413,6 +1518,7 @@ func (r *runTestActor) Act(b *work.Builder, ctx context.Context, a *work.Action)
 	var json *test2json.Converter
 	if testJSON {
 		json = test2json.NewConverter(lockedStdout{}, a.Package.ImportPath, test2json.Timestamp)
+		json.SetShard(testShard.String())
 		defer func() {
 			json.Exited(err)
 			json.Close()
@@ -1457,6 +1563,14 @@ func (r *runTestActor) Act(b *work.Builder, ctx context.Context, a *work.Action)
 		return a.Objdir + "_cover_.out"
 	}
 
+	if r.notInShard {
+		fmt.Fprintf(stdout, "?   \t%s\t[no tests in shard %s]\n", a.Package.ImportPath, testShard.String())
+		if stdout == &buf {
+			a.TestOutput = &buf
+		}
+		return nil
+	}
+
 	if p := a.Package; len(p.TestGoFiles)+len(p.XTestGoFiles) == 0 {
 		reportNoTestFiles := true
 		if cfg.BuildCover && cfg.Experiment.CoverageRedesign && p.Internal.Cover.GenMeta {
@@ -1572,11 +1686,6 @@ func (r *runTestActor) Act(b *work.Builder, ctx context.Context, a *work.Action)
 		}
 	}
 
-	// Normally, the test will terminate itself when the timeout expires,
-	// but add a last-ditch deadline to detect and stop wedged binaries.
-	ctx, cancel := context.WithTimeout(ctx, testKillTimeout)
-	defer cancel()
-
 	// Now we're ready to actually run the command.
 	//
 	// If the -o flag is set, or if at some point we change cmd/go to start
@@ -1590,61 +1699,118 @@ func (r *runTestActor) Act(b *work.Builder, ctx context.Context, a *work.Action)
 
 	var (
 		cmd            *exec.Cmd
-		t0             time.Time
 		cancelKilled   = false
 		cancelSignaled = false
+		attemptOut     bytes.Buffer // output of the latest run, for -retry
 	)
-	for {
-		cmd = exec.CommandContext(ctx, args[0], args[1:]...)
-		cmd.Dir = a.Package.Dir
+	run := func(args []string) error {
+		// Normally, the test will terminate itself when the timeout expires,
+		// but add a last-ditch deadline to detect and stop wedged binaries.
+		ctx, cancel := context.WithTimeout(ctx, testKillTimeout)
+		defer cancel()
+
+		attemptOut.Reset()
+		for {
+			cmd = exec.CommandContext(ctx, args[0], args[1:]...)
+			cmd.Dir = a.Package.Dir
+
+			env := slices.Clip(cfg.OrigEnv)
+			env = base.AppendPATH(env)
+			env = base.AppendPWD(env, cmd.Dir)
+			cmd.Env = env
+			if addToEnv != "" {
+				cmd.Env = append(cmd.Env, addToEnv)
+			}
 
-		env := slices.Clip(cfg.OrigEnv)
-		env = base.AppendPATH(env)
-		env = base.AppendPWD(env, cmd.Dir)
-		cmd.Env = env
-		if addToEnv != "" {
-			cmd.Env = append(cmd.Env, addToEnv)
-		}
+			cmd.Stdout = stdout
+			if testRetry > 0 {
+				cmd.Stdout = io.MultiWriter(stdout, &attemptOut)
+			}
+			cmd.Stderr = cmd.Stdout
 
-		cmd.Stdout = stdout
-		cmd.Stderr = stdout
+			cmd.Cancel = func() error {
+				if base.SignalTrace == nil {
+					err := cmd.Process.Kill()
+					if err == nil {
+						cancelKilled = true
+					}
+					return err
+				}
 
-		cmd.Cancel = func() error {
-			if base.SignalTrace == nil {
-				err := cmd.Process.Kill()
+				// Send a quit signal in the hope that the program will print
+				// a stack trace and exit.
+				err := cmd.Process.Signal(base.SignalTrace)
 				if err == nil {
-					cancelKilled = true
+					cancelSignaled = true
 				}
 				return err
 			}
+			cmd.WaitDelay = testWaitDelay
+
+			base.StartSigHandlers()
+			err := cmd.Run()
 
-			// Send a quit signal in the hope that the program will print
-			// a stack trace and exit.
-			err := cmd.Process.Signal(base.SignalTrace)
-			if err == nil {
-				cancelSignaled = true
+			if !base.IsETXTBSY(err) {
+				// We didn't hit the race in #22315, so there is no reason to retry the
+				// command.
+				return err
 			}
-			return err
 		}
-		cmd.WaitDelay = testWaitDelay
+	}
 
-		base.StartSigHandlers()
-		t0 = time.Now()
-		err = cmd.Run()
+	// The framing lines for -retry use the same ^V marker as the test
+	// binary's own, so that test2json recognizes them.
+	prefix := ""
+	if testJSON || testV.json {
+		prefix = "\x16"
+	}
+
+	t0 := time.Now()
+	err = run(args)
+	mergeCoverProfile(stdout, a.Objdir+"_cover_.out")
 
-		if !base.IsETXTBSY(err) {
-			// We didn't hit the race in #22315, so there is no reason to retry the
-			// command.
+	// If some top-level tests failed, rerun just those tests,
+	// up to testRetry more times, recording the ones that pass as flaky.
+	var flaky []string
+	for retry := 1; retry <= testRetry && !cancelKilled && !cancelSignaled; retry++ {
+		failed, ok := retryableTests(err, attemptOut.Bytes())
+		if !ok {
 			break
 		}
+		for _, name := range failed {
+			fmt.Fprintf(stdout, "%s=== RETRY %s\n", prefix, name)
+		}
+		rargs := retryArgs(args, failed)
+		if cfg.BuildX {
+			sh.ShowCmd("", "%s", strings.Join(rargs, " "))
+		}
+		if testCoverProfile != "" {
+			// Don't merge the previous run's profile again
+			// if the rerun fails to write one.
+			os.Remove(coverProfTempFile(a))
+		}
+		err = run(rargs)
+		mergeCoverProfile(stdout, a.Objdir+"_cover_.out")
+
+		var stillFailed []string
+		if err != nil {
+			if stillFailed, ok = retryableTests(err, attemptOut.Bytes()); !ok {
+				// The rerun did not finish normally,
+				// so we do not know which tests passed.
+				break
+			}
+		}
+		for _, name := range failed {
+			if !slices.Contains(stillFailed, name) {
+				flaky = append(flaky, name)
+			}
+		}
 	}
 
 	out := buf.Bytes()
 	a.TestOutput = &buf
 	t := fmt.Sprintf("%.3fs", time.Since(t0).Seconds())
 
-	mergeCoverProfile(cmd.Stdout, a.Objdir+"_cover_.out")
-
 	if err == nil {
 		norun := ""
 		if !testShowPass() && !testJSON {
@@ -1662,10 +1828,18 @@ func (r *runTestActor) Act(b *work.Builder, ctx context.Context, a *work.Action)
 		if len(out) > 0 && !bytes.HasSuffix(out, []byte("\n")) {
 			// Ensure that the output ends with a newline before the "ok"
 			// line we're about to print (https://golang.org/issue/49317).
-			cmd.Stdout.Write([]byte("\n"))
+			stdout.Write([]byte("\n"))
+		}
+		// Format the summary before reporting flaky tests:
+		// out may share its storage with the (reset) buf.
+		summary := fmt.Sprintf("ok  \t%s\t%s%s%s\n", a.Package.ImportPath, t, coveragePercentage(out), norun)
+		reportFlaky(stdout, prefix, flaky)
+		io.WriteString(stdout, summary)
+		if len(flaky) == 0 {
+			// Don't cache a result that needed retries,
+			// so that the next run tries the flaky tests again.
+			r.c.saveOutput(a)
 		}
-		fmt.Fprintf(cmd.Stdout, "ok  \t%s\t%s%s%s\n", a.Package.ImportPath, t, coveragePercentage(out), norun)
-		r.c.saveOutput(a)
 	} else {
 		if testFailFast {
 			testShouldFailFast.Store(true)
@@ -1673,21 +1847,21 @@ func (r *runTestActor) Act(b *work.Builder, ctx context.Context, a *work.Action)
 
 		base.SetExitStatus(1)
 		if cancelSignaled {
-			fmt.Fprintf(cmd.Stdout, "*** Test killed with %v: ran too long (%v).\n", base.SignalTrace, testKillTimeout)
+			fmt.Fprintf(stdout, "*** Test killed with %v: ran too long (%v).\n", base.SignalTrace, testKillTimeout)
 		} else if cancelKilled {
-			fmt.Fprintf(cmd.Stdout, "*** Test killed: ran too long (%v).\n", testKillTimeout)
+			fmt.Fprintf(stdout, "*** Test killed: ran too long (%v).\n", testKillTimeout)
 		} else if errors.Is(err, exec.ErrWaitDelay) {
-			fmt.Fprintf(cmd.Stdout, "*** Test I/O incomplete %v after exiting.\n", cmd.WaitDelay)
+			fmt.Fprintf(stdout, "*** Test I/O incomplete %v after exiting.\n", cmd.WaitDelay)
 		}
 		var ee *exec.ExitError
 		if len(out) == 0 || !errors.As(err, &ee) || !ee.Exited() {
 			// If there was no test output, print the exit status so that the reason
 			// for failure is clear.
-			fmt.Fprintf(cmd.Stdout, "%s\n", err)
+			fmt.Fprintf(stdout, "%s\n", err)
 		} else if !bytes.HasSuffix(out, []byte("\n")) {
 			// Otherwise, ensure that the output ends with a newline before the FAIL
 			// line we're about to print (https://golang.org/issue/49317).
-			cmd.Stdout.Write([]byte("\n"))
+			stdout.Write([]byte("\n"))
 		}
 
 		// NOTE(golang.org/issue/37555): test2json reports that a test passes
@@ -1698,19 +1872,73 @@ func (r *runTestActor) Act(b *work.Builder, ctx context.Context, a *work.Action)
 		// not a pipe.
 		// TODO(golang.org/issue/29062): tests that exit with status 0 without
 		// printing a final result should fail.
-		prefix := ""
-		if testJSON || testV.json {
-			prefix = "\x16"
-		}
-		fmt.Fprintf(cmd.Stdout, "%sFAIL\t%s\t%s\n", prefix, a.Package.ImportPath, t)
+		reportFlaky(stdout, prefix, flaky)
+		fmt.Fprintf(stdout, "%sFAIL\t%s\t%s\n", prefix, a.Package.ImportPath, t)
 	}
 
-	if cmd.Stdout != &buf {
-		buf.Reset() // cmd.Stdout was going to os.Stdout already
+	if stdout != &buf {
+		buf.Reset() // stdout was going to os.Stdout already
 	}
 	return nil
 }
 
+// retryableTests returns the names of the failed top-level tests
+// in the output of a test binary that exited with err.
+// It reports whether those tests can be retried: the binary must
+// have run to completion and failed only tests or examples,
+// since a benchmark cannot be rerun by name with -test.run.
+func retryableTests(err error, out []byte) (names []string, ok bool) {
+	// A binary that ran to completion with test failures prints a final
+	// FAIL line and exits with status 1. Each failed top-level test has
+	// an unindented report line.
+	var ee *exec.ExitError
+	if !errors.As(err, &ee) || ee.ExitCode() != 1 || !regexp.MustCompile(`(?m)^\x16?FAIL\r?$`).Match(out) {
+		return nil, false
+	}
+	for _, m := range regexp.MustCompile(`(?m)^\x16?--- FAIL: (\S+) \(`).FindAllSubmatch(out, -1) {
+		name := string(m[1])
+		if strings.HasPrefix(name, "Benchmark") {
+			return nil, false
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, len(names) > 0
+}
+
+// retryArgs returns the test binary command line args, modified to run
+// exactly the named tests and no benchmarks.
+func retryArgs(args, names []string) []string {
+	var quoted []string
+	for _, name := range names {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	run := "-test.run=^(?:" + strings.Join(quoted, "|") + ")$"
+
+	var out []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-test.run=") || strings.HasPrefix(arg, "-test.bench=") {
+			continue
+		}
+		if arg == "-test.paniconexit0" {
+			// Insert next to a flag that always precedes testArgs,
+			// so that it comes before any non-flag arguments there.
+			out = append(out, run)
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+// reportFlaky prints a framing line for each test that failed
+// but then passed when rerun with -retry.
+func reportFlaky(w io.Writer, prefix string, flaky []string) {
+	for _, name := range flaky {
+		fmt.Fprintf(w, "%s=== FLAKY %s\n", prefix, name)
+	}
+}
+
 // tryCache is called just before the link attempt,
 // to see if the test result is cached and therefore the link is unneeded.
 // It reports whether the result can be satisfied from cache.
@@ -1754,7 +1982,9 @@ func (c *runCache) tryCacheWithID(b *work.Builder, a *work.Action, id string) bo
 			"-test.list",
 			"-test.parallel",
 			"-test.run",
+			"-test.shard",
 			"-test.short",
+			"-test.testtimeout",
 			"-test.timeout",
 			"-test.failfast",
 			"-test.v",
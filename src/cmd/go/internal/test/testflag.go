@@ -12,6 +12,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"internal/godebug"
 	"os"
 	"path/filepath"
@@ -38,12 +39,14 @@ func init() {
 	work.AddCoverFlags(CmdTest, &testCoverProfile)
 	cf.Var((*base.StringsFlag)(&work.ExecCmd), "exec", "")
 	cf.BoolVar(&testJSON, "json", false, "")
+	cf.IntVar(&testRetry, "retry", 0, "")
 	cf.Var(&testVet, "vet", "")
 
 	// Register flags to be forwarded to the test binary. We retain variables for
 	// some of them so that cmd/go knows what to do with the test output, or knows
 	// to build the test in a way that supports the use of the flag.
 
+	cf.BoolVar(&testArtifacts, "artifacts", false, "")
 	cf.StringVar(&testBench, "bench", "", "")
 	cf.Bool("benchmem", false, "")
 	cf.String("benchtime", "", "")
@@ -65,12 +68,14 @@ func init() {
 	cf.String("run", "", "")
 	cf.Bool("short", false, "")
 	cf.String("skip", "", "")
+	cf.Duration("testtimeout", 0, "")
 	cf.DurationVar(&testTimeout, "timeout", 10*time.Minute, "") // known to cmd/dist
 	cf.String("fuzztime", "", "")
 	cf.String("fuzzminimizetime", "", "")
 	cf.StringVar(&testTrace, "trace", "", "")
 	cf.Var(&testV, "v", "")
 	cf.Var(&testShuffle, "shuffle", "")
+	cf.Var(&testShard, "shard", "")
 
 	for name, ok := range passFlagToTest {
 		if ok {
@@ -215,6 +220,47 @@ func (f *shuffleFlag) Set(value string) error {
 	return nil
 }
 
+// shardFlag implements the -shard flag.
+type shardFlag struct {
+	index, n int // index is 1-based; n == 0 means sharding is off
+}
+
+func (f *shardFlag) String() string {
+	if f.n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", f.index, f.n)
+}
+
+func (f *shardFlag) Set(value string) error {
+	is, ns, ok := strings.Cut(value, "/")
+	index, err1 := strconv.Atoi(is)
+	n, err2 := strconv.Atoi(ns)
+	if !ok || err1 != nil || err2 != nil || n < 1 || index < 1 || index > n {
+		return fmt.Errorf("-shard argument must be i/n with 1 <= i <= n")
+	}
+	*f = shardFlag{index: index, n: n}
+	return nil
+}
+
+// contains reports whether the top-level test, example, benchmark,
+// or fuzz test with the given name in the package with the given
+// import path belongs to the shard.
+// It must match the assignment made by inShard in package testing.
+func (f *shardFlag) contains(importPath, name string) bool {
+	h := fnv.New64a()
+	h.Write([]byte(importPath))
+	h.Write([]byte{0})
+	h.Write([]byte(name))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return int(x%uint64(f.n)) == f.index-1
+}
+
 // testFlags processes the command line, grabbing -x and -c, rewriting known flags
 // to have "test" before them, and reading the command line for the test binary.
 // Unfortunately for us, we need to do our own flag processing because go test
@@ -392,7 +438,8 @@ func testFlags(args []string) (packageNames, passToTest []string) {
 	// directory, but 'go test' defaults it to the working directory of the 'go'
 	// command. Set it explicitly if it is needed due to some other flag that
 	// requests output.
-	if testProfile() != "" && !outputDirSet {
+	needOutputDir := testProfile() != "" || testArtifacts
+	if needOutputDir && !outputDirSet {
 		injectedFlags = append(injectedFlags, "-test.outputdir="+testOutputDir.getAbs())
 	}
 
@@ -35,12 +35,34 @@ For more about specifying packages, see 'go help packages'.
 For a list of checkers and their flags, see 'go tool vet help'.
 For details of a specific checker such as 'printf', see 'go tool vet help printf'.
 
+A file named govet.cfg in the root directory of a main module configures
+the analyzers run on the packages of that module, both by go vet and by
+the vet step of go test. Each line of the file holds a directive:
+
+  enable analyzer...        run the analyzers in addition to the defaults
+  disable analyzer...       do not run the analyzers
+  flag analyzer.flag=value  set an analyzer flag
+
+A '#' starts a comment that runs to the end of the line. For example:
+
+  # Report impossible nil checks and unintended shadowing.
+  enable nilness shadow
+  flag shadow.strict=true
+  flag printf.funcs=Logf,Warnf
+
+Analyzers enabled in govet.cfg also run during go test, in addition to
+its high-confidence subset of analyzers, and analyzers disabled in it are
+removed from that subset. Flags given on the command line take precedence
+over the settings of govet.cfg. In particular, naming analyzers on the
+command line selects exactly those analyzers.
+
 The -vettool=prog flag selects a different analysis tool with alternative
-or additional checks.
-For example, the 'shadow' analyzer can be built and run using these commands:
+or additional checks. The settings of govet.cfg do not apply to it.
+For example, the 'fieldalignment' analyzer can be built and run using
+these commands:
 
-  go install golang.org/x/tools/go/analysis/passes/shadow/cmd/shadow@latest
-  go vet -vettool=$(which shadow)
+  go install golang.org/x/tools/go/analysis/passes/fieldalignment/cmd/fieldalignment@latest
+  go vet -vettool=$(which fieldalignment)
 
 The build flags supported by go vet are those that control package resolution
 and execution, such as -C, -n, -x, -v, -tags, and -toolexec.
@@ -1150,6 +1150,11 @@ type vetConfig struct {
 	VetxOutput    string            // write vetx data to this output file
 	GoVersion     string            // Go version for package
 
+	// EnabledAnalyzers lists the analyzers enabled by the module's
+	// govet.cfg file. The vet command runs them in addition to its
+	// default analyzers.
+	EnabledAnalyzers []string
+
 	SucceedOnTypecheckFailure bool // awful hack; see #18395 and below
 }
 
@@ -1308,6 +1313,18 @@ func (b *Builder) vet(ctx context.Context, a *Action) error {
 		}
 	}
 
+	// Apply the configuration of the module being vetted, if any.
+	// Its flags are meaningless to an alternate vet tool.
+	if p := a.Package; VetTool == "" && !p.Goroot && p.Module != nil && p.Module.Main && p.Module.Dir != "" {
+		mc, err := loadModuleVetConfig(p.Module.Dir)
+		if err != nil {
+			return err
+		}
+		vetFlags = mc.vetFlags(vetFlags, cfg.CmdName == "test" && !VetExplicit)
+		vcfg.EnabledAnalyzers = mc.enable
+	}
+	fmt.Fprintf(h, "vetenable %q\n", vcfg.EnabledAnalyzers)
+
 	// Note: We could decide that vet should compute export data for
 	// all analyses, in which case we don't need to include the flags here.
 	// But that would mean that if an analysis causes problems like
@@ -0,0 +1,171 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package work
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/fsys"
+	"cmd/internal/par"
+)
+
+// ModuleVetFile is the name of the file in a module's root directory
+// that configures the analyzers run by 'go vet' and 'go test'.
+// See 'go help vet' for its format.
+const ModuleVetFile = "govet.cfg"
+
+// A moduleVetConfig is the parsed content of a module's govet.cfg file.
+type moduleVetConfig struct {
+	enable  []string // analyzers to run in addition to the default ones
+	disable []string // analyzers not to run
+	flags   []string // analyzer flags, in the form "analyzer.flag=value"
+}
+
+var moduleVetConfigCache par.ErrCache[string, *moduleVetConfig]
+
+// loadModuleVetConfig returns the vet configuration of the module rooted
+// at dir. It returns an empty configuration if the module has no
+// govet.cfg file.
+func loadModuleVetConfig(dir string) (*moduleVetConfig, error) {
+	return moduleVetConfigCache.Do(dir, func() (*moduleVetConfig, error) {
+		file := filepath.Join(dir, ModuleVetFile)
+		data, err := fsys.ReadFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			return new(moduleVetConfig), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parseModuleVetConfig(file, data, vetToolFlags)
+	})
+}
+
+// vetToolFlags returns the flags of the vet tool, mapped to whether each
+// is a boolean flag. The boolean flags of the vet tool enable analyzers.
+var vetToolFlags = sync.OnceValues(func() (map[string]bool, error) {
+	out, err := exec.Command(base.Tool("vet"), "-flags").Output()
+	if err != nil {
+		return nil, fmt.Errorf("running vet -flags: %v", err)
+	}
+	var flags []struct {
+		Name string
+		Bool bool
+	}
+	if err := json.Unmarshal(out, &flags); err != nil {
+		return nil, fmt.Errorf("parsing output of vet -flags: %v", err)
+	}
+	m := make(map[string]bool)
+	for _, f := range flags {
+		m[f.Name] = f.Bool
+	}
+	return m, nil
+})
+
+// parseModuleVetConfig parses the content of the govet.cfg file named file,
+// checking the analyzers and flags it names against those returned by
+// toolFlags.
+//
+// Each line holds a directive followed by its arguments. The directives are
+//
+//	enable analyzer...
+//	disable analyzer...
+//	flag analyzer.flag=value
+//
+// The value of a flag may not contain spaces.
+// A '#' starts a comment that runs to the end of the line.
+func parseModuleVetConfig(file string, data []byte, toolFlags func() (map[string]bool, error)) (*moduleVetConfig, error) {
+	known, err := toolFlags()
+	if err != nil {
+		return nil, err
+	}
+	mc := new(moduleVetConfig)
+	state := make(map[string]string) // analyzer name -> directive that named it
+	for i, line := range strings.Split(string(data), "\n") {
+		errorf := func(format string, args ...any) error {
+			return fmt.Errorf("%s:%d: %s", base.ShortPath(file), i+1, fmt.Sprintf(format, args...))
+		}
+		if j := strings.Index(line, "#"); j >= 0 {
+			line = line[:j]
+		}
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+		switch verb := args[0]; verb {
+		case "enable", "disable":
+			if len(args) == 1 {
+				return nil, errorf("%s requires at least one analyzer name", verb)
+			}
+			for _, name := range args[1:] {
+				if isBool, ok := known[name]; !ok || !isBool || strings.Contains(name, ".") {
+					return nil, errorf("unknown analyzer %q", name)
+				}
+				if prev, ok := state[name]; ok {
+					return nil, errorf("analyzer %s already named by %s directive", name, prev)
+				}
+				state[name] = verb
+				if verb == "enable" {
+					mc.enable = append(mc.enable, name)
+				} else {
+					mc.disable = append(mc.disable, name)
+				}
+			}
+		case "flag":
+			if len(args) != 2 {
+				return nil, errorf("usage: flag analyzer.flag=value")
+			}
+			name, _, ok := strings.Cut(args[1], "=")
+			if !ok || !strings.Contains(name, ".") {
+				return nil, errorf("usage: flag analyzer.flag=value")
+			}
+			if _, ok := known[name]; !ok {
+				return nil, errorf("unknown analyzer flag %q", name)
+			}
+			mc.flags = append(mc.flags, args[1])
+		default:
+			return nil, errorf("unknown directive %q", args[0])
+		}
+	}
+	return mc, nil
+}
+
+// vetFlags returns the flags to pass to the vet tool for a package of the
+// module, given the flags that would be passed otherwise.
+// If testDefaults is set, vetFlags is the list of analyzers that
+// 'go test' enables by default, which the configuration amends.
+// Otherwise, the configuration's settings precede vetFlags, so that
+// explicit command-line flags take precedence over them.
+func (mc *moduleVetConfig) vetFlags(vetFlags []string, testDefaults bool) []string {
+	var out []string
+	for _, f := range mc.flags {
+		out = append(out, "-"+f)
+	}
+	if testDefaults {
+		for _, f := range vetFlags {
+			if !slices.Contains(mc.disable, strings.TrimPrefix(f, "-")) {
+				out = append(out, f)
+			}
+		}
+		for _, name := range mc.enable {
+			if !slices.Contains(vetFlags, "-"+name) {
+				out = append(out, "-"+name)
+			}
+		}
+		return out
+	}
+	for _, name := range mc.disable {
+		out = append(out, "-"+name+"=false")
+	}
+	return append(out, vetFlags...)
+}
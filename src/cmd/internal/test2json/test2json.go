@@ -36,6 +36,10 @@ type event struct {
 	Elapsed     *float64   `json:",omitempty"`
 	Output      *textBytes `json:",omitempty"`
 	FailedBuild string     `json:",omitempty"`
+	Key         string     `json:",omitempty"`
+	Value       string     `json:",omitempty"`
+	Path        string     `json:",omitempty"`
+	Shard       string     `json:",omitempty"`
 }
 
 // textBytes is a hack to get JSON to emit a []byte as a string
@@ -64,6 +68,9 @@ type Converter struct {
 	// failedBuild is set to the package ID of the cause of a build failure,
 	// if that's what caused this test to fail.
 	failedBuild string
+
+	// shard is the "i/n" shard selected by go test -shard, if any.
+	shard string
 }
 
 // inBuffer and outBuffer are the input and output buffer sizes.
@@ -152,6 +159,13 @@ func (c *Converter) SetFailedBuild(pkgID string) {
 	c.failedBuild = pkgID
 }
 
+// SetShard sets the shard, in the form "i/n", that the test binary was
+// asked to run. This will be reported in the final package-level event's
+// Shard field.
+func (c *Converter) SetShard(shard string) {
+	c.shard = shard
+}
+
 const marker = byte(0x16) // ^V
 
 var (
@@ -177,6 +191,10 @@ var (
 		[]byte("=== PASS  "),
 		[]byte("=== FAIL  "),
 		[]byte("=== SKIP  "),
+		[]byte("=== ATTR  "),
+		[]byte("=== ARTIFACTS "),
+		[]byte("=== RETRY "),
+		[]byte("=== FLAKY "),
 	}
 
 	reports = [][]byte{
@@ -190,6 +208,7 @@ var (
 
 	skipLinePrefix = []byte("?   \t")
 	skipLineSuffix = []byte("\t[no test files]")
+	skipShardInfix = []byte("\t[no tests in shard ")
 )
 
 // handleInputLine handles a single whole test output line.
@@ -239,9 +258,10 @@ func (c *Converter) handleInputLine(line []byte) {
 		return
 	}
 
-	// Special case for entirely skipped test binary: "?   \tpkgname\t[no test files]\n" is only line.
+	// Special case for entirely skipped test binary: "?   \tpkgname\t[no test files]\n"
+	// or "?   \tpkgname\t[no tests in shard i/n]\n" is only line.
 	// Report it as plain output but remember to say skip in the final summary.
-	if bytes.HasPrefix(line, skipLinePrefix) && bytes.HasSuffix(trim, skipLineSuffix) && len(c.report) == 0 {
+	if bytes.HasPrefix(line, skipLinePrefix) && (bytes.HasSuffix(trim, skipLineSuffix) || bytes.Contains(trim, skipShardInfix)) && len(c.report) == 0 {
 		c.result = "skip"
 	}
 
@@ -294,15 +314,18 @@ func (c *Converter) handleInputLine(line []byte) {
 	}
 
 	// Parse out action and test name.
-	i := 0
+	var action, name string
 	if actionColon {
-		i = bytes.IndexByte(line, ':') + 1
-	}
-	if i == 0 {
-		i = len(updates[0])
+		// "--- ACTION: Name"
+		i := bytes.IndexByte(line, ':') + 1
+		action = strings.TrimSpace(string(line[4 : i-1]))
+		name = string(line[i:])
+	} else {
+		// "=== ACTION Name"
+		action, name, _ = strings.Cut(string(line[4:]), " ")
 	}
-	action := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(string(line[4:i])), ":"))
-	name := strings.TrimSpace(string(line[i:]))
+	action = strings.ToLower(action)
+	name = strings.TrimSpace(name)
 
 	e := &event{Action: action}
 	if line[0] == '-' { // PASS or FAIL report
@@ -333,6 +356,16 @@ func (c *Converter) handleInputLine(line []byte) {
 		c.output.write(origLine)
 		return
 	}
+	switch action {
+	case "artifacts":
+		// "=== ARTIFACTS Name Path"
+		name, e.Path, _ = strings.Cut(name, " ")
+	case "attr":
+		// "=== ATTR  Name Key Value"
+		var rest string
+		name, rest, _ = strings.Cut(name, " ")
+		e.Key, e.Value, _ = strings.Cut(rest, " ")
+	}
 	// === update.
 	// Finish any pending PASS/FAIL reports.
 	c.needMarker = sawMarker
@@ -355,6 +388,11 @@ func (c *Converter) handleInputLine(line []byte) {
 	if action != "pause" {
 		c.output.write(origLine)
 	}
+	if action == "retry" || action == "flaky" {
+		// go test prints these lines between runs of the test binary,
+		// so the output that follows does not belong to the named test.
+		c.testName = ""
+	}
 
 	return
 }
@@ -384,6 +422,7 @@ func (c *Converter) Close() error {
 		if c.result == "fail" {
 			e.FailedBuild = c.failedBuild
 		}
+		e.Shard = c.shard
 		c.writeEvent(e)
 	}
 	return nil
@@ -42,6 +42,10 @@
 //		Elapsed     float64 // seconds
 //		Output      string
 //		FailedBuild string
+//		Key         string
+//		Value       string
+//		Path        string
+//		Shard       string
 //	}
 //
 // The Time field holds the time the event happened.
@@ -49,15 +53,19 @@
 //
 // The Action field is one of a fixed set of action descriptions:
 //
-//	start  - the test binary is about to be executed
-//	run    - the test has started running
-//	pause  - the test has been paused
-//	cont   - the test has continued running
-//	pass   - the test passed
-//	bench  - the benchmark printed log output but did not fail
-//	fail   - the test or benchmark failed
-//	output - the test printed output
-//	skip   - the test was skipped or the package contained no tests
+//	start     - the test binary is about to be executed
+//	run       - the test has started running
+//	pause     - the test has been paused
+//	cont      - the test has continued running
+//	pass      - the test passed
+//	bench     - the benchmark printed log output but did not fail
+//	fail      - the test or benchmark failed
+//	output    - the test printed output
+//	skip      - the test was skipped or the package contained no tests
+//	attr      - the test reported an attribute with T.Attr
+//	artifacts - the test created an artifact directory with T.ArtifactDir
+//	retry     - go test -retry is rerunning the test after it failed
+//	flaky     - the test failed but then passed when rerun by go test -retry
 //
 // Every JSON stream begins with a "start" event.
 //
@@ -85,6 +93,19 @@
 // failed to build. This matches the ImportPath field of the "go list" output,
 // as well as the BuildEvent.ImportPath field as emitted by "go build -json".
 //
+// The Key and Value fields are set for Action == "attr".
+// They hold the key and value of an attribute reported by the test
+// using testing.T.Attr.
+//
+// The Path field is set for Action == "artifacts".
+// It holds the absolute path of the directory returned by
+// testing.T.ArtifactDir, which is only reported when the test is run
+// with the -artifacts flag.
+//
+// The Shard field is set on the final package-level "pass", "fail", or
+// "skip" event when the test was run with "go test -shard=i/n".
+// It holds the shard, in the form "i/n", that the run covered.
+//
 // When a benchmark runs, it typically produces a single line of output
 // giving timing results. That line is reported in an event with Action == "output"
 // and no Test field. If a benchmark logs output or reports a failure
@@ -60,14 +60,24 @@ To list the available checks, run "go tool vet help":
 	unsafeptr        check for invalid conversions of uintptr to unsafe.Pointer
 	unusedresult     check for unused results of calls to some functions
 
+The following checks are also available, but are not performed by default:
+
+	nilness          check for redundant or impossible nil comparisons
+	shadow           check for possible unintended shadowing of variables
+	unusedwrite      checks for unused writes
+
 For details and flags of a particular check, such as printf, run "go tool vet help printf".
 
-By default, all checks are performed.
+By default, all checks in the first list are performed.
 If any flags are explicitly set to true, only those tests are run.
 Conversely, if any flag is explicitly set to false, only those tests are disabled.
 Thus -printf=true runs the printf check,
 and -printf=false runs all checks except the printf check.
 
+When run by the go command, the govet.cfg file of the module being vetted
+may enable additional checks, disable checks, and set their flags.
+See "go help vet" for details.
+
 For information on writing a new check, see golang.org/x/tools/go/analysis.
 
 Core flags:
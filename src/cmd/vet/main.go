@@ -7,8 +7,13 @@ package main
 import (
 	"cmd/internal/objabi"
 	"cmd/internal/telemetry/counter"
+	"encoding/json"
 	"flag"
+	"os"
+	"slices"
+	"strings"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/unitchecker"
 
 	"golang.org/x/tools/go/analysis/passes/appends"
@@ -29,7 +34,9 @@ import (
 	"golang.org/x/tools/go/analysis/passes/loopclosure"
 	"golang.org/x/tools/go/analysis/passes/lostcancel"
 	"golang.org/x/tools/go/analysis/passes/nilfunc"
+	"golang.org/x/tools/go/analysis/passes/nilness"
 	"golang.org/x/tools/go/analysis/passes/printf"
+	"golang.org/x/tools/go/analysis/passes/shadow"
 	"golang.org/x/tools/go/analysis/passes/shift"
 	"golang.org/x/tools/go/analysis/passes/sigchanyzer"
 	"golang.org/x/tools/go/analysis/passes/slog"
@@ -44,6 +51,7 @@ import (
 	"golang.org/x/tools/go/analysis/passes/unreachable"
 	"golang.org/x/tools/go/analysis/passes/unsafeptr"
 	"golang.org/x/tools/go/analysis/passes/unusedresult"
+	"golang.org/x/tools/go/analysis/passes/unusedwrite"
 )
 
 func main() {
@@ -51,43 +59,89 @@ func main() {
 	objabi.AddVersionFlag()
 
 	counter.Inc("vet/invocations")
-	unitchecker.Main(
-		appends.Analyzer,
-		asmdecl.Analyzer,
-		assign.Analyzer,
-		atomic.Analyzer,
-		bools.Analyzer,
-		buildtag.Analyzer,
-		cgocall.Analyzer,
-		composite.Analyzer,
-		copylock.Analyzer,
-		defers.Analyzer,
-		directive.Analyzer,
-		errorsas.Analyzer,
-		framepointer.Analyzer,
-		httpresponse.Analyzer,
-		ifaceassert.Analyzer,
-		loopclosure.Analyzer,
-		lostcancel.Analyzer,
-		nilfunc.Analyzer,
-		printf.Analyzer,
-		shift.Analyzer,
-		sigchanyzer.Analyzer,
-		slog.Analyzer,
-		stdmethods.Analyzer,
-		stdversion.Analyzer,
-		stringintconv.Analyzer,
-		structtag.Analyzer,
-		tests.Analyzer,
-		testinggoroutine.Analyzer,
-		timeformat.Analyzer,
-		unmarshal.Analyzer,
-		unreachable.Analyzer,
-		unsafeptr.Analyzer,
-		unusedresult.Analyzer,
-	)
+
+	// Optional analyzers are disabled unless the configuration of the
+	// module being vetted enables them. Disable them ahead of the other
+	// flags, so that they can still be enabled on the command line.
+	args := os.Args[1:]
+	enabled := enabledAnalyzers(args)
+	var disable []string
+	for _, a := range optionalAnalyzers {
+		if !slices.Contains(enabled, a.Name) {
+			disable = append(disable, "-"+a.Name+"=false")
+		}
+	}
+	os.Args = append(append(os.Args[:1:1], disable...), args...)
+
+	unitchecker.Main(append(defaultAnalyzers, optionalAnalyzers...)...)
 
 	// It's possible that unitchecker will exit early. In
 	// those cases the flags won't be counted.
 	counter.CountFlags("vet/flag:", *flag.CommandLine)
 }
+
+// defaultAnalyzers are the analyzers run by default.
+var defaultAnalyzers = []*analysis.Analyzer{
+	appends.Analyzer,
+	asmdecl.Analyzer,
+	assign.Analyzer,
+	atomic.Analyzer,
+	bools.Analyzer,
+	buildtag.Analyzer,
+	cgocall.Analyzer,
+	composite.Analyzer,
+	copylock.Analyzer,
+	defers.Analyzer,
+	directive.Analyzer,
+	errorsas.Analyzer,
+	framepointer.Analyzer,
+	httpresponse.Analyzer,
+	ifaceassert.Analyzer,
+	loopclosure.Analyzer,
+	lostcancel.Analyzer,
+	nilfunc.Analyzer,
+	printf.Analyzer,
+	shift.Analyzer,
+	sigchanyzer.Analyzer,
+	slog.Analyzer,
+	stdmethods.Analyzer,
+	stdversion.Analyzer,
+	stringintconv.Analyzer,
+	structtag.Analyzer,
+	tests.Analyzer,
+	testinggoroutine.Analyzer,
+	timeformat.Analyzer,
+	unmarshal.Analyzer,
+	unreachable.Analyzer,
+	unsafeptr.Analyzer,
+	unusedresult.Analyzer,
+}
+
+// optionalAnalyzers are the analyzers that are run only when enabled,
+// either explicitly on the command line or by the govet.cfg file of the
+// module being vetted.
+var optionalAnalyzers = []*analysis.Analyzer{
+	nilness.Analyzer,
+	shadow.Analyzer,
+	unusedwrite.Analyzer,
+}
+
+// enabledAnalyzers returns the names of the analyzers enabled by the
+// module's govet.cfg file, as recorded by the go command in the JSON
+// configuration file passed as the last argument.
+func enabledAnalyzers(args []string) []string {
+	if len(args) == 0 || !strings.HasSuffix(args[len(args)-1], ".cfg") {
+		return nil
+	}
+	data, err := os.ReadFile(args[len(args)-1])
+	if err != nil {
+		return nil // unitchecker reports the error
+	}
+	var cfg struct {
+		EnabledAnalyzers []string
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil // unitchecker reports the error
+	}
+	return cfg.EnabledAnalyzers
+}
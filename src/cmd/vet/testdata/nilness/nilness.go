@@ -0,0 +1,12 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nilness
+
+func Deref(p *int) int {
+	if p == nil {
+		return *p // ERROR "nil dereference in load"
+	}
+	return 0
+}
@@ -196,6 +196,20 @@ func errchk(c *exec.Cmd, files []string, t *testing.T) {
 	}
 }
 
+// TestOptional verifies that the optional analyzers run only when enabled.
+func TestOptional(t *testing.T) {
+	t.Parallel()
+
+	cmd := vetCmd(t, "-printf=false", "nilness")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("vet failed: %v\n%s", err, output)
+	}
+
+	cmd = vetCmd(t, "-nilness", "nilness")
+	errchk(cmd, []string{filepath.Join("testdata", "nilness", "nilness.go")}, t)
+}
+
 // TestTags verifies that the -tags argument controls which files to check.
 func TestTags(t *testing.T) {
 	t.Parallel()
@@ -128,3 +128,57 @@ func (rbr *reverseBitReader) fetch(b uint8) bool {
 func (rbr *reverseBitReader) makeError(msg string) error {
 	return rbr.r.makeError(int(rbr.off), msg)
 }
+
+// bitWriter writes a bit stream going forward.
+// The bits of each value are written starting with the least
+// significant bit. A stream written by bitWriter and ended with
+// closeStream is read by a reverseBitReader, which returns the
+// values in the opposite order. A stream ended by flush is read
+// by a bitReader.
+type bitWriter struct {
+	out  []byte // bytes written so far
+	bits uint64 // bits waiting to be written
+	cnt  uint32 // number of valid bits in the bits field
+}
+
+// reset discards any bits written so far, and starts
+// appending a new stream to out.
+func (bw *bitWriter) reset(out []byte) {
+	bw.out = out
+	bw.bits = 0
+	bw.cnt = 0
+}
+
+// addBits writes the low b bits of v. b must be at most 32.
+func (bw *bitWriter) addBits(v uint32, b uint8) {
+	bw.bits |= (uint64(v) & (1<<b - 1)) << bw.cnt
+	bw.cnt += uint32(b)
+	if bw.cnt >= 32 {
+		bw.out = append(bw.out, byte(bw.bits), byte(bw.bits>>8), byte(bw.bits>>16), byte(bw.bits>>24))
+		bw.bits >>= 32
+		bw.cnt -= 32
+	}
+}
+
+// flush writes out any pending bits, padding the last byte with zeroes.
+// It returns the bytes written.
+func (bw *bitWriter) flush() []byte {
+	for bw.cnt > 0 {
+		bw.out = append(bw.out, byte(bw.bits))
+		bw.bits >>= 8
+		if bw.cnt < 8 {
+			bw.cnt = 0
+		} else {
+			bw.cnt -= 8
+		}
+	}
+	return bw.out
+}
+
+// closeStream ends a stream to be read by a reverseBitReader,
+// by writing a single 1 bit, and flushes it.
+// It returns the bytes written.
+func (bw *bitWriter) closeStream() []byte {
+	bw.addBits(1, 1)
+	return bw.flush()
+}
@@ -399,7 +399,25 @@ func (r *Reader) copyFromWindow(rbr *reverseBitReader, offset, match uint32) err
 		lenWindow := r.window.len()
 		copy := offset - lenBlock
 		if copy > lenWindow {
-			return rbr.makeError("offset past window")
+			// The offset refers to the dictionary content,
+			// which is only permitted while the amount of
+			// data in the frame is no more than the window size.
+			// RFC 5.
+			if !r.useDict || r.frameDecompressed+uint64(lenBlock) > uint64(r.window.size) {
+				return rbr.makeError("offset past window")
+			}
+			dictCopy := copy - lenWindow
+			content := r.dict.content
+			if dictCopy > uint32(len(content)) {
+				return rbr.makeError("offset past dictionary")
+			}
+			dictOffset := uint32(len(content)) - dictCopy
+			if dictCopy > match {
+				dictCopy = match
+			}
+			r.buffer = append(r.buffer, content[dictOffset:dictOffset+dictCopy]...)
+			match -= dictCopy
+			copy = lenWindow
 		}
 		windowOffset := lenWindow - copy
 		if copy > match {
@@ -0,0 +1,320 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math/bits"
+)
+
+// maxBlockSize is the largest amount of data in a block.
+// RFC 3.1.1.2.3.
+const maxBlockSize = 128 << 10
+
+// sequence is a single sequence to encode in a compressed block.
+// RFC 3.1.1.3.2.
+type sequence struct {
+	litLen   uint32 // number of literals
+	matchLen uint32 // match length, at least 3
+	offset   uint32 // offset value: 1 to 3 for a repeated offset, else offset + 3
+}
+
+// blockEncoder holds the state used to compress a single block.
+type blockEncoder struct {
+	lits []byte
+	seqs []sequence
+
+	// Sequence codes, indexed by seqCode.
+	codes [3][]uint8
+
+	fse  [3]fseEncoder
+	huff huffEncoder
+
+	// Scratch buffer for Huffman streams.
+	streams []byte
+}
+
+// reset prepares to collect the literals and sequences of a new block.
+func (be *blockEncoder) reset() {
+	be.lits = be.lits[:0]
+	be.seqs = be.seqs[:0]
+}
+
+// appendBlock appends the contents of a Compressed_Block
+// for the literals and sequences collected in be. RFC 3.1.1.3.
+func (be *blockEncoder) appendBlock(dst []byte) []byte {
+	dst = be.appendLiterals(dst)
+	return be.appendSequences(dst)
+}
+
+// appendLiteralsHeader appends a literals section header for a
+// Raw_Literals_Block or an RLE_Literals_Block. RFC 3.1.1.3.1.1.
+func appendLiteralsHeader(dst []byte, typ byte, size int) []byte {
+	switch {
+	case size < 1<<5:
+		return append(dst, typ|byte(size)<<3)
+	case size < 1<<12:
+		return append(dst, typ|1<<2|byte(size)<<4, byte(size>>4))
+	default:
+		return append(dst, typ|3<<2|byte(size)<<4, byte(size>>4), byte(size>>12))
+	}
+}
+
+// appendLiterals appends the literals section. RFC 3.1.1.3.1.
+func (be *blockEncoder) appendLiterals(dst []byte) []byte {
+	lits := be.lits
+
+	// Compressing a few literals is not worth the cost
+	// of describing the Huffman table.
+	if len(lits) < 64 {
+		dst = appendLiteralsHeader(dst, 0, len(lits))
+		return append(dst, lits...)
+	}
+
+	var counts [256]uint32
+	for _, b := range lits {
+		counts[b]++
+	}
+	if counts[lits[0]] == uint32(len(lits)) {
+		dst = appendLiteralsHeader(dst, 1, len(lits))
+		return append(dst, lits[0])
+	}
+
+	be.huff.build(&counts)
+
+	// The streams follow the table description.
+	start := len(dst)
+	out, ok := be.huff.appendTable(be.streams[:0])
+	if !ok {
+		dst = appendLiteralsHeader(dst, 0, len(lits))
+		return append(dst, lits...)
+	}
+
+	// Use a single stream for a small number of literals,
+	// otherwise four streams. RFC 3.1.1.3.1.6.
+	streams := 1
+	if len(lits) >= 256 {
+		streams = 4
+	}
+	if streams == 1 {
+		out = be.huff.appendStream(out, lits)
+	} else {
+		jump := len(out)
+		out = append(out, 0, 0, 0, 0, 0, 0)
+		size := (len(lits) + 3) / 4
+		for i := 0; i < 4; i++ {
+			streamStart := len(out)
+			chunk := lits[min(i*size, len(lits)):min((i+1)*size, len(lits))]
+			out = be.huff.appendStream(out, chunk)
+			if i < 3 {
+				n := len(out) - streamStart
+				if n > 0xffff {
+					ok = false
+					break
+				}
+				out[jump+2*i] = byte(n)
+				out[jump+2*i+1] = byte(n >> 8)
+			}
+		}
+	}
+	be.streams = out
+
+	// Only use the Huffman code if it saves enough space.
+	if !ok || len(out) >= len(lits)-(len(lits)>>6+2) {
+		dst = appendLiteralsHeader(dst[:start], 0, len(lits))
+		return append(dst, lits...)
+	}
+
+	regen, comp := len(lits), len(out)
+	switch {
+	case streams == 1:
+		dst = append(dst, 2|byte(regen)<<4, byte(regen>>4)&0x3f|byte(comp)<<6, byte(comp>>2))
+	case regen < 1<<10 && comp < 1<<10:
+		dst = append(dst, 2|1<<2|byte(regen)<<4, byte(regen>>4)&0x3f|byte(comp)<<6, byte(comp>>2))
+	case regen < 1<<14 && comp < 1<<14:
+		dst = append(dst, 2|2<<2|byte(regen)<<4, byte(regen>>4), byte(regen>>12)&3|byte(comp)<<2, byte(comp>>6))
+	default:
+		dst = append(dst, 2|3<<2|byte(regen)<<4, byte(regen>>4), byte(regen>>12)&0x3f|byte(comp)<<6, byte(comp>>2), byte(comp>>10))
+	}
+	return append(dst, out...)
+}
+
+// literalLengthCode returns the literal length code for litLen.
+// RFC 3.1.1.3.2.1.1.
+func literalLengthCode(litLen uint32) uint8 {
+	switch {
+	case litLen < 16:
+		return uint8(litLen)
+	case litLen < 24:
+		return uint8(16 + (litLen-16)/2)
+	case litLen < 32:
+		return uint8(20 + (litLen-24)/4)
+	case litLen < 48:
+		return uint8(22 + (litLen-32)/8)
+	case litLen < 64:
+		return 24
+	default:
+		return uint8(bits.Len32(litLen) + 18)
+	}
+}
+
+// matchLengthCode returns the match length code for matchLen.
+// RFC 3.1.1.3.2.1.1.
+func matchLengthCode(matchLen uint32) uint8 {
+	v := matchLen - 3
+	switch {
+	case v < 32:
+		return uint8(v)
+	case v < 40:
+		return uint8(32 + (v-32)/2)
+	case v < 48:
+		return uint8(36 + (v-40)/4)
+	case v < 64:
+		return uint8(38 + (v-48)/8)
+	case v < 96:
+		return uint8(40 + (v-64)/16)
+	case v < 128:
+		return 42
+	default:
+		return uint8(bits.Len32(v) + 35)
+	}
+}
+
+// offsetCode returns the offset code for the offset value off.
+// RFC 3.1.1.3.2.1.1.
+func offsetCode(off uint32) uint8 {
+	return uint8(bits.Len32(off) - 1)
+}
+
+// seqCodeEncodeInfo is the information used to pick an FSE table
+// for a kind of sequence code.
+var seqCodeEncodeInfo = [3]struct {
+	predefined     []int16
+	predefinedBits int
+}{
+	seqLiteral: {literalPredefinedDistribution, 6},
+	seqOffset:  {offsetPredefinedDistribution, 5},
+	seqMatch:   {matchPredefinedDistribution, 6},
+}
+
+// appendSequences appends the sequences section. RFC 3.1.1.3.2.
+func (be *blockEncoder) appendSequences(dst []byte) []byte {
+	seqs := be.seqs
+	n := len(seqs)
+	switch {
+	case n < 128:
+		dst = append(dst, byte(n))
+	case n < 0x7f00:
+		dst = append(dst, byte(n>>8)+128, byte(n))
+	default:
+		dst = append(dst, 255, byte(n-0x7f00), byte((n-0x7f00)>>8))
+	}
+	if n == 0 {
+		return dst
+	}
+
+	for kind := range be.codes {
+		if cap(be.codes[kind]) < n {
+			be.codes[kind] = make([]uint8, n)
+		}
+		be.codes[kind] = be.codes[kind][:n]
+	}
+	llCodes := be.codes[seqLiteral]
+	ofCodes := be.codes[seqOffset]
+	mlCodes := be.codes[seqMatch]
+	for i, s := range seqs {
+		llCodes[i] = literalLengthCode(s.litLen)
+		ofCodes[i] = offsetCode(s.offset)
+		mlCodes[i] = matchLengthCode(s.matchLen)
+	}
+
+	// Write the Symbol_Compression_Modes, then the tables.
+	modesOff := len(dst)
+	dst = append(dst, 0)
+	var modes byte
+	for _, kind := range []seqCode{seqLiteral, seqOffset, seqMatch} {
+		var mode byte
+		mode, dst = be.chooseTable(dst, kind)
+		modes |= mode << (6 - 2*kind)
+	}
+	dst[modesOff] = modes
+
+	// Write the bitstream. The decoder reads the sequences
+	// in order, and reads the bitstream backward,
+	// so start with the last sequence.
+	var bw bitWriter
+	bw.reset(dst)
+	var llState, ofState, mlState fseState
+	last := n - 1
+	llState.init(&be.fse[seqLiteral], llCodes[last])
+	ofState.init(&be.fse[seqOffset], ofCodes[last])
+	mlState.init(&be.fse[seqMatch], mlCodes[last])
+	be.addExtraBits(&bw, seqs[last], llCodes[last], ofCodes[last], mlCodes[last])
+	for i := last - 1; i >= 0; i-- {
+		ofState.encode(&bw, ofCodes[i])
+		mlState.encode(&bw, mlCodes[i])
+		llState.encode(&bw, llCodes[i])
+		be.addExtraBits(&bw, seqs[i], llCodes[i], ofCodes[i], mlCodes[i])
+	}
+	mlState.flush(&bw)
+	ofState.flush(&bw)
+	llState.flush(&bw)
+	return bw.closeStream()
+}
+
+// addExtraBits writes the extra bits of a sequence
+// that follow the codes.
+func (be *blockEncoder) addExtraBits(bw *bitWriter, s sequence, llCode, ofCode, mlCode uint8) {
+	if llCode >= literalLengthOffset {
+		lb := literalLengthBase[llCode-literalLengthOffset]
+		bw.addBits(s.litLen-lb&0xffffff, uint8(lb>>24))
+	}
+	if mlCode >= matchLengthOffset {
+		mb := matchLengthBase[mlCode-matchLengthOffset]
+		bw.addBits(s.matchLen-mb&0xffffff, uint8(mb>>24))
+	}
+	bw.addBits(s.offset-1<<ofCode, ofCode)
+}
+
+// chooseTable picks the Compression_Mode for a kind of sequence code,
+// sets up the FSE encoder for it, and appends any table description.
+// RFC 3.1.1.3.2.2.
+func (be *blockEncoder) chooseTable(dst []byte, kind seqCode) (byte, []byte) {
+	codes := be.codes[kind]
+	info := &seqCodeInfo[kind]
+	einfo := &seqCodeEncodeInfo[kind]
+	enc := &be.fse[kind]
+
+	var counts [53]uint32
+	maxSym := 0
+	for _, c := range codes {
+		counts[c]++
+		maxSym = max(maxSym, int(c))
+	}
+	if counts[codes[0]] == uint32(len(codes)) {
+		// RLE_Mode.
+		enc.buildRLE()
+		return 1, append(dst, codes[0])
+	}
+
+	predefCost, predefOK := fseCost(counts[:maxSym+1], einfo.predefined, einfo.predefinedBits)
+
+	tableBits := fseTableBits(len(codes), maxSym, info.maxBits)
+	var norm [53]int16
+	normalizeCounts(counts[:maxSym+1], uint32(len(codes)), tableBits, norm[:maxSym+1])
+	cost, _ := fseCost(counts[:maxSym+1], norm[:maxSym+1], tableBits)
+	start := len(dst)
+	dst = appendFSETable(dst, norm[:maxSym+1], tableBits)
+	cost += float64(8 * (len(dst) - start))
+
+	if predefOK && predefCost <= cost {
+		// Predefined_Mode.
+		enc.build(einfo.predefined, uint8(einfo.predefinedBits))
+		return 0, dst[:start]
+	}
+
+	// FSE_Compressed_Mode.
+	enc.build(norm[:maxSym+1], uint8(tableBits))
+	return 2, dst
+}
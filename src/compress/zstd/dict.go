@@ -0,0 +1,98 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// dictMagic is the magic number at the start of a dictionary. RFC 5.
+const dictMagic = 0xec30a437
+
+// dictionary is a parsed zstd dictionary. RFC 5.
+//
+// A dictionary that does not start with dictMagic is treated as
+// raw content, with an ID of 0 and no entropy tables.
+type dictionary struct {
+	id      uint32
+	content []byte
+
+	// Entropy tables to use for the first block of a frame.
+	// These are only set for a dictionary in the zstd format.
+	huffmanTable     []uint16
+	huffmanTableBits int
+	seqTables        [3][]fseBaselineEntry
+	seqTableBits     [3]uint8
+
+	// The initial repeated offsets.
+	repeatedOffsets [3]uint32
+}
+
+// parseDict parses a dictionary.
+func parseDict(data []byte) (*dictionary, error) {
+	d := &dictionary{
+		repeatedOffsets: [3]uint32{1, 4, 8},
+	}
+	if len(data) < 8 || binary.LittleEndian.Uint32(data) != dictMagic {
+		d.content = data
+		return d, nil
+	}
+
+	d.id = binary.LittleEndian.Uint32(data[4:])
+
+	// Read the entropy tables using the decompressor's methods.
+	// Errors report offsets within the dictionary.
+	r := new(Reader)
+	off := 8
+
+	d.huffmanTable = make([]uint16, 1<<maxHuffmanBits)
+	tableBits, off, err := r.readHuff(block(data), off, d.huffmanTable)
+	if err != nil {
+		return nil, dictError(err)
+	}
+	d.huffmanTableBits = tableBits
+
+	// The FSE tables are stored in the order
+	// offsets, match lengths, literal lengths.
+	for _, kind := range []seqCode{seqOffset, seqMatch, seqLiteral} {
+		info := &seqCodeInfo[kind]
+		fseTable := make([]fseEntry, 1<<info.maxBits)
+		tableBits, roff, err := r.readFSE(block(data), off, info.maxSym, info.maxBits, fseTable)
+		if err != nil {
+			return nil, dictError(err)
+		}
+		baseline := make([]fseBaselineEntry, 1<<tableBits)
+		if err := info.toBaseline(r, off, fseTable[:1<<tableBits], baseline); err != nil {
+			return nil, dictError(err)
+		}
+		d.seqTables[kind] = baseline
+		d.seqTableBits[kind] = uint8(tableBits)
+		off = roff
+	}
+
+	if len(data)-off < 12 {
+		return nil, dictError(errors.New("missing repeat offsets"))
+	}
+	d.content = data[off+12:]
+	for i := range d.repeatedOffsets {
+		ro := binary.LittleEndian.Uint32(data[off+4*i:])
+		if ro == 0 || ro > uint32(len(d.content)) {
+			return nil, dictError(fmt.Errorf("invalid repeat offset %d", ro))
+		}
+		d.repeatedOffsets[i] = ro
+	}
+
+	return d, nil
+}
+
+// dictError wraps an error found while parsing a dictionary.
+func dictError(err error) error {
+	if ze, ok := err.(*zstdError); ok {
+		return fmt.Errorf("zstd: invalid dictionary at %d: %v", ze.offset, ze.err)
+	}
+	return fmt.Errorf("zstd: invalid dictionary: %v", err)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd_test
+
+import (
+	"bytes"
+	"compress/zstd"
+	"io"
+	"log"
+	"os"
+)
+
+func Example_writerReader() {
+	var buf bytes.Buffer
+	zw := zstd.NewWriter(&buf)
+	if _, err := zw.Write([]byte("A long time ago in a galaxy far, far away...")); err != nil {
+		log.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	zr := zstd.NewReader(&buf)
+	if _, err := io.Copy(os.Stdout, zr); err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// A long time ago in a galaxy far, far away...
+}
+
+func Example_dictionary() {
+	// A dictionary holds content that is common to many small inputs.
+	// It may also be a dictionary in the zstd format,
+	// as produced by the zstd --train command.
+	dict := []byte(`{"name": "", "email": "@example.com", "admin": false}`)
+
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriterDict(&buf, zstd.BestCompression, dict)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := zw.Write([]byte(`{"name": "gopher", "email": "gopher@example.com", "admin": false}`)); err != nil {
+		log.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	// The same dictionary is needed to decompress the data.
+	zr, err := zstd.NewReaderDict(&buf, dict)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := io.Copy(os.Stdout, zr); err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// {"name": "gopher", "email": "gopher@example.com", "admin": false}
+}
@@ -0,0 +1,327 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math"
+	"math/bits"
+)
+
+// literalPredefinedDistribution is the predefined distribution table
+// for literal lengths. RFC 3.1.1.3.2.2.1.
+var literalPredefinedDistribution = []int16{
+	4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+	-1, -1, -1, -1,
+}
+
+// offsetPredefinedDistribution is the predefined distribution table
+// for offsets. RFC 3.1.1.3.2.2.3.
+var offsetPredefinedDistribution = []int16{
+	1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+}
+
+// matchPredefinedDistribution is the predefined distribution table
+// for match lengths. RFC 3.1.1.3.2.2.2.
+var matchPredefinedDistribution = []int16{
+	1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+	-1, -1, -1, -1, -1,
+}
+
+// minFSEBits is the smallest accuracy log that can be described
+// in an FSE table header. RFC 4.1.1.
+const minFSEBits = 5
+
+// maxFSEBits is the largest accuracy log used by any FSE table.
+const maxFSEBits = 9
+
+// fseSymbolTransform is the information needed to encode one symbol
+// using an FSE table.
+type fseSymbolTransform struct {
+	// deltaBits is used to compute the number of bits to write
+	// for the current state before moving to a state for this symbol.
+	deltaBits uint32
+	// deltaState is added to the shifted state to find the
+	// index into the state table.
+	deltaState int32
+}
+
+// fseEncoder is an FSE table used for encoding.
+// It is the inverse of the table built by buildFSE:
+// encoding a sequence of symbols in reverse order produces
+// a bit stream that the decoding table reads in forward order.
+type fseEncoder struct {
+	tableBits  uint8
+	rle        bool                    // only one symbol, no bits needed
+	states     []uint16                // next states, grouped by symbol
+	transforms [256]fseSymbolTransform // indexed by symbol
+}
+
+// build sets up e to encode using the normalized probabilities in norm,
+// as they would be read by readFSE. The probabilities must add up to
+// 1<<tableBits, counting -1 as 1.
+func (e *fseEncoder) build(norm []int16, tableBits uint8) {
+	tableSize := 1 << tableBits
+	mask := tableSize - 1
+	highThreshold := tableSize - 1
+
+	e.tableBits = tableBits
+	e.rle = false
+	if cap(e.states) < tableSize {
+		e.states = make([]uint16, tableSize)
+	}
+	e.states = e.states[:tableSize]
+
+	// Spread the symbols over the table exactly as buildFSE does.
+	var (
+		cumul  [257]int
+		symbol [1 << maxFSEBits]uint8
+	)
+	for i, n := range norm {
+		if n == -1 {
+			cumul[i+1] = cumul[i] + 1
+			symbol[highThreshold] = uint8(i)
+			highThreshold--
+		} else {
+			cumul[i+1] = cumul[i] + int(n)
+		}
+	}
+
+	pos := 0
+	step := (tableSize >> 1) + (tableSize >> 3) + 3
+	for i, n := range norm {
+		for j := 0; j < int(n); j++ {
+			symbol[pos] = uint8(i)
+			pos = (pos + step) & mask
+			for pos > highThreshold {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+
+	// State tableSize+i decodes as entry i of the decoding table.
+	// The states for a symbol are stored in the order in which
+	// buildFSE assigns them increasing next state values.
+	for i := 0; i < tableSize; i++ {
+		s := symbol[i]
+		e.states[cumul[s]] = uint16(tableSize + i)
+		cumul[s]++
+	}
+
+	total := int32(0)
+	for i, n := range norm {
+		var t fseSymbolTransform
+		switch n {
+		case 0:
+			// Not used for encoding.
+		case -1, 1:
+			t.deltaBits = uint32(tableBits)<<16 - uint32(tableSize)
+			t.deltaState = total - 1
+			total++
+		default:
+			maxBitsOut := uint32(tableBits) - uint32(bits.Len16(uint16(n-1))-1)
+			minStatePlus := uint32(n) << maxBitsOut
+			t.deltaBits = maxBitsOut<<16 - minStatePlus
+			t.deltaState = total - int32(n)
+			total += int32(n)
+		}
+		e.transforms[i] = t
+	}
+}
+
+// buildRLE sets up e for a table with only one symbol,
+// as used by RLE_Mode for sequence codes.
+func (e *fseEncoder) buildRLE() {
+	e.tableBits = 0
+	e.rle = true
+}
+
+// fseState is the state of an FSE encoder.
+type fseState struct {
+	enc   *fseEncoder
+	state uint32
+}
+
+// init starts encoding with sym, which will be the last symbol
+// that the decoder reads. This chooses the smallest state for sym,
+// which is always one that needs at least one bit to move to the
+// next state. Reading the Huffman weights relies on that.
+func (s *fseState) init(enc *fseEncoder, sym uint8) {
+	s.enc = enc
+	if enc.rle {
+		s.state = 0
+		return
+	}
+	t := &enc.transforms[sym]
+	nbBits := (t.deltaBits + 1<<15) >> 16
+	v := nbBits<<16 - t.deltaBits
+	s.state = uint32(enc.states[int32(v>>nbBits)+t.deltaState])
+}
+
+// encode adds sym, which the decoder reads before the symbols
+// already encoded.
+func (s *fseState) encode(bw *bitWriter, sym uint8) {
+	if s.enc.rle {
+		return
+	}
+	t := &s.enc.transforms[sym]
+	nbBits := (s.state + t.deltaBits) >> 16
+	bw.addBits(s.state, uint8(nbBits))
+	s.state = uint32(s.enc.states[int32(s.state>>nbBits)+t.deltaState])
+}
+
+// flush writes the final state, which the decoder reads as its
+// initial state.
+func (s *fseState) flush(bw *bitWriter) {
+	bw.addBits(s.state, s.enc.tableBits)
+}
+
+// fseTableBits picks the accuracy log to use for an FSE table
+// describing total symbols no larger than maxSym,
+// using at most maxBits bits.
+func fseTableBits(total int, maxSym int, maxBits int) int {
+	tableBits := maxBits
+	// There is no point to a table much larger than the input.
+	if b := bits.Len(uint(total-1)) - 3; b < tableBits {
+		tableBits = b
+	}
+	// The table needs room for each symbol.
+	minBits := min(bits.Len(uint(total)), bits.Len(uint(maxSym))+1)
+	if minBits > tableBits {
+		tableBits = minBits
+	}
+	return max(minFSEBits, min(tableBits, maxBits))
+}
+
+// normalizeCounts converts symbol counts, which must add up to total,
+// into a distribution of probabilities adding up to 1<<tableBits.
+// Every symbol that appears gets a probability of at least 1.
+// The result is stored in norm, which must have len(counts) entries.
+func normalizeCounts(counts []uint32, total uint32, tableBits int, norm []int16) {
+	tableSize := 1 << tableBits
+	var rem [256]uint64
+	sum := 0
+	for i, c := range counts {
+		if c == 0 {
+			norm[i] = 0
+			rem[i] = 0
+			continue
+		}
+		scaled := uint64(c) << tableBits
+		n := int(scaled / uint64(total))
+		rem[i] = scaled % uint64(total)
+		if n == 0 {
+			n = 1
+			rem[i] = 0
+		}
+		norm[i] = int16(n)
+		sum += n
+	}
+
+	// Rounding down leaves some probability to hand out.
+	// Give it to the symbols that lost the most.
+	for ; sum < tableSize; sum++ {
+		best := -1
+		for i, c := range counts {
+			if c != 0 && (best < 0 || rem[i] > rem[best]) {
+				best = i
+			}
+		}
+		norm[best]++
+		rem[best] = 0
+	}
+
+	// Raising rare symbols to 1 may have used too much.
+	// Take it from the most probable symbols.
+	for ; sum > tableSize; sum-- {
+		best := -1
+		for i, n := range norm {
+			if n > 1 && (best < 0 || n > norm[best]) {
+				best = i
+			}
+		}
+		norm[best]--
+	}
+}
+
+// fseCost estimates the number of bits required to encode
+// symbols with the given counts using the normalized distribution norm.
+// It reports false if some symbol can't be encoded.
+func fseCost(counts []uint32, norm []int16, tableBits int) (float64, bool) {
+	cost := 0.0
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if i >= len(norm) || norm[i] == 0 {
+			return 0, false
+		}
+		n := norm[i]
+		if n < 0 {
+			n = 1
+		}
+		cost += float64(c) * (float64(tableBits) - math.Log2(float64(n)))
+	}
+	return cost, true
+}
+
+// appendFSETable appends the description of the normalized
+// distribution norm, as read by readFSE. RFC 4.1.1.
+func appendFSETable(dst []byte, norm []int16, tableBits int) []byte {
+	var bw bitWriter
+	bw.reset(dst)
+	bw.addBits(uint32(tableBits-minFSEBits), 4)
+
+	tableSize := 1 << tableBits
+	remaining := tableSize + 1
+	threshold := tableSize
+	bitsNeeded := tableBits + 1
+
+	prev0 := false
+	sym := 0
+	for sym < len(norm) && remaining > 1 {
+		if prev0 {
+			// Write the number of following zero probabilities
+			// as 2-bit repeat flags.
+			start := sym
+			for sym < len(norm) && norm[sym] == 0 {
+				sym++
+			}
+			for sym >= start+3 {
+				bw.addBits(3, 2)
+				start += 3
+			}
+			bw.addBits(uint32(sym-start), 2)
+		}
+
+		count := int(norm[sym])
+		sym++
+		max := (2*threshold - 1) - remaining
+		if count < 0 {
+			remaining += count
+		} else {
+			remaining -= count
+		}
+		count++
+		if count >= threshold {
+			count += max
+		}
+		if count < max {
+			bw.addBits(uint32(count), uint8(bitsNeeded-1))
+		} else {
+			bw.addBits(uint32(count), uint8(bitsNeeded))
+		}
+		prev0 = count == 1
+
+		for remaining < threshold {
+			bitsNeeded--
+			threshold >>= 1
+		}
+	}
+	return bw.flush()
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 )
 
@@ -138,3 +139,55 @@ func FuzzReverse(f *testing.F) {
 		}
 	})
 }
+
+// Fuzz test to verify that we can decompress what we compress,
+// at every compression level.
+func FuzzRoundTrip(f *testing.F) {
+	for _, test := range tests {
+		f.Add([]byte(test.uncompressed), uint8(DefaultCompression))
+	}
+	f.Add(bytes.Repeat([]byte("abcdefghijklmnop"), 256), uint8(BestSpeed))
+	f.Add(bytes.Repeat([]byte("abcdefghijklmnop"), 256), uint8(BestCompression))
+	f.Add(dictSample(0), uint8(BestCompression))
+
+	// Add the contents of the decoder test files.
+	files, err := filepath.Glob("testdata/*.zst")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for i, file := range files {
+		compressed, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		data, err := io.ReadAll(NewReader(bytes.NewReader(compressed)))
+		if err != nil {
+			f.Fatalf("%s: %v", file, err)
+		}
+		f.Add(data, uint8(i))
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte, level uint8) {
+		level = BestSpeed + level%(BestCompression-BestSpeed+1)
+		var compressed bytes.Buffer
+		w, err := NewWriterLevel(&compressed, int(level))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(b); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := NewReader(bytes.NewReader(compressed.Bytes()))
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, b) {
+			showDiffs(t, got, b)
+		}
+	})
+}
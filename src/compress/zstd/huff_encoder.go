@@ -0,0 +1,219 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"sort"
+)
+
+// huffEncoder is a Huffman code used to compress literals.
+type huffEncoder struct {
+	tableBits int        // longest code length
+	maxSym    int        // largest symbol with a code
+	lengths   [256]uint8 // code length of each symbol, 0 if not used
+	codes     [256]uint16
+}
+
+// build computes a Huffman code for the byte counts in counts,
+// with codes no longer than maxHuffmanBits.
+// There must be at least two different symbols.
+func (he *huffEncoder) build(counts *[256]uint32) {
+	// Sort the symbols that appear by increasing count.
+	var syms [256]uint16
+	n := 0
+	for i, c := range counts {
+		if c != 0 {
+			syms[n] = uint16(i)
+			n++
+			he.maxSym = i
+		}
+	}
+	sorted := syms[:n]
+	sort.Slice(sorted, func(i, j int) bool {
+		ci, cj := counts[sorted[i]], counts[sorted[j]]
+		if ci != cj {
+			return ci < cj
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	// Build the tree using two queues: the leaves in sorted order,
+	// and the internal nodes in the order they are created,
+	// which is also sorted by count.
+	// Nodes 0 to n-1 are leaves, the rest are internal nodes.
+	var (
+		weight [511]uint32
+		parent [511]uint16
+		depth  [511]uint8
+	)
+	for i, s := range sorted {
+		weight[i] = counts[s]
+	}
+	leaf, node, next := 0, n, n
+	pop := func() int {
+		if leaf < n && (node >= next || weight[leaf] <= weight[node]) {
+			leaf++
+			return leaf - 1
+		}
+		node++
+		return node - 1
+	}
+	for next < 2*n-1 {
+		a := pop()
+		b := pop()
+		weight[next] = weight[a] + weight[b]
+		parent[a] = uint16(next)
+		parent[b] = uint16(next)
+		next++
+	}
+	depth[2*n-2] = 0
+	for i := 2*n - 3; i >= 0; i-- {
+		depth[i] = depth[parent[i]] + 1
+	}
+
+	// Limit the code lengths to maxHuffmanBits.
+	// Measure the code space used in units of 1<<-maxHuffmanBits;
+	// a complete code uses exactly 1<<maxHuffmanBits units.
+	const full = 1 << maxHuffmanBits
+	clear(he.lengths[:])
+	used := 0
+	for i, s := range sorted {
+		l := min(depth[i], maxHuffmanBits)
+		he.lengths[s] = l
+		used += 1 << (maxHuffmanBits - l)
+	}
+	// If clamping overflowed the code space,
+	// lengthen the codes of the least frequent symbols.
+	for used > full {
+		for _, s := range sorted {
+			if used <= full {
+				break
+			}
+			if l := he.lengths[s]; l < maxHuffmanBits {
+				used -= 1 << (maxHuffmanBits - l - 1)
+				he.lengths[s]++
+			}
+		}
+	}
+	// Use any remaining code space
+	// to shorten the codes of the most frequent symbols.
+	for used < full {
+		for i := n - 1; i >= 0; i-- {
+			s := sorted[i]
+			for l := he.lengths[s]; l > 1 && used+1<<(maxHuffmanBits-l) <= full; l-- {
+				used += 1 << (maxHuffmanBits - l)
+				he.lengths[s]--
+			}
+		}
+	}
+
+	he.tableBits = 0
+	for _, s := range sorted {
+		he.tableBits = max(he.tableBits, int(he.lengths[s]))
+	}
+
+	// Assign codes the way readHuff builds its table:
+	// by increasing weight, and within a weight by symbol.
+	var start [maxHuffmanBits + 2]uint32
+	for s := 0; s <= he.maxSym; s++ {
+		if w := he.weight(s); w > 0 {
+			start[w+1] += 1 << (w - 1)
+		}
+	}
+	for w := 2; w < len(start); w++ {
+		start[w] += start[w-1]
+	}
+	for s := 0; s <= he.maxSym; s++ {
+		if w := he.weight(s); w > 0 {
+			he.codes[s] = uint16(start[w] >> (w - 1))
+			start[w] += 1 << (w - 1)
+		}
+	}
+}
+
+// weight returns the Huffman weight of sym. RFC 4.2.1.
+func (he *huffEncoder) weight(sym int) int {
+	if he.lengths[sym] == 0 {
+		return 0
+	}
+	return he.tableBits + 1 - int(he.lengths[sym])
+}
+
+// appendTable appends the Huffman tree description, as read by readHuff.
+// It reports false if the description can't be represented.
+// RFC 4.2.1.
+func (he *huffEncoder) appendTable(dst []byte) ([]byte, bool) {
+	// The weight of the last symbol is implied.
+	count := he.maxSym
+	var weights [255]uint8
+	var weightCounts [maxHuffmanBits + 1]uint32
+	distinct := 0
+	for s := 0; s < count; s++ {
+		w := he.weight(s)
+		weights[s] = uint8(w)
+		if weightCounts[w] == 0 {
+			distinct++
+		}
+		weightCounts[w]++
+	}
+
+	// Try compressing the weights with FSE. RFC 4.2.1.2.
+	if count >= 2 && distinct > 1 {
+		start := len(dst)
+		dst = append(dst, 0)
+		tableBits := fseTableBits(count, maxHuffmanBits, 6)
+		var norm [maxHuffmanBits + 1]int16
+		normalizeCounts(weightCounts[:], uint32(count), tableBits, norm[:])
+		dst = appendFSETable(dst, norm[:], tableBits)
+
+		var enc fseEncoder
+		enc.build(norm[:], uint8(tableBits))
+
+		// There are two interleaved states.
+		// The decoder reads even weights with the first state
+		// and odd weights with the second.
+		var states [2]fseState
+		states[(count-1)&1].init(&enc, weights[count-1])
+		states[(count-2)&1].init(&enc, weights[count-2])
+		var bw bitWriter
+		bw.reset(dst)
+		for i := count - 3; i >= 0; i-- {
+			states[i&1].encode(&bw, weights[i])
+		}
+		states[1].flush(&bw)
+		states[0].flush(&bw)
+		dst = bw.closeStream()
+
+		size := len(dst) - start - 1
+		if size < 128 && (count > 128 || size < (count+1)/2) {
+			dst[start] = byte(size)
+			return dst, true
+		}
+		dst = dst[:start]
+	}
+
+	// Write the weights directly, 4 bits each.
+	if count > 128 {
+		return dst, false
+	}
+	dst = append(dst, byte(127+count))
+	for i := 0; i < count; i += 2 {
+		dst = append(dst, weights[i]<<4|weights[i+1])
+	}
+	return dst, true
+}
+
+// appendStream appends a single Huffman encoded stream of src.
+// The decoder reads the stream backward,
+// so the last symbol is written first.
+func (he *huffEncoder) appendStream(dst, src []byte) []byte {
+	var bw bitWriter
+	bw.reset(dst)
+	for i := len(src) - 1; i >= 0; i-- {
+		b := src[i]
+		bw.addBits(uint32(he.codes[b]), he.lengths[b])
+	}
+	return bw.closeStream()
+}
@@ -0,0 +1,395 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// levelParams are the parameters used to find matches
+// at a compression level.
+type levelParams struct {
+	windowLog uint8 // log of the window size
+	hashLog   uint8 // log of the hash table size
+	chainLog  uint8 // log of the hash chain size, 0 for the fast strategy
+	minMatch  uint8 // number of bytes hashed, 4 to 8
+	depth     int   // number of hash chain entries to check
+	lazy      int   // number of following positions to check for a better match
+	target    int   // stop searching when a match is this long
+}
+
+// levels holds the parameters for each compression level,
+// starting at BestSpeed.
+var levels = [...]levelParams{
+	{windowLog: 19, hashLog: 16, minMatch: 6},
+	{windowLog: 20, hashLog: 17, minMatch: 6},
+	{windowLog: 21, hashLog: 17, chainLog: 16, minMatch: 5, depth: 4, lazy: 1, target: 32},
+	{windowLog: 21, hashLog: 18, chainLog: 17, minMatch: 5, depth: 8, lazy: 1, target: 64},
+	{windowLog: 21, hashLog: 18, chainLog: 18, minMatch: 4, depth: 16, lazy: 1, target: 96},
+	{windowLog: 22, hashLog: 19, chainLog: 19, minMatch: 4, depth: 32, lazy: 2, target: 128},
+	{windowLog: 22, hashLog: 19, chainLog: 19, minMatch: 4, depth: 64, lazy: 2, target: 192},
+	{windowLog: 22, hashLog: 19, chainLog: 20, minMatch: 4, depth: 128, lazy: 2, target: 256},
+	{windowLog: 22, hashLog: 19, chainLog: 20, minMatch: 4, depth: 256, lazy: 2, target: 512},
+}
+
+// matcher finds matches in the data to compress,
+// and records them as sequences in a blockEncoder.
+type matcher struct {
+	p *levelParams
+
+	// hist holds the dictionary content, the data already
+	// compressed that is still within the window,
+	// and the data to compress next.
+	hist []byte
+
+	// table maps a hash of the bytes at a position in hist
+	// to the most recent position with that hash.
+	table []int32
+
+	// chain maps a position in hist, modulo the chain size,
+	// to the previous position with the same hash.
+	chain []int32
+
+	// long maps a hash of the 8 bytes at a position in hist
+	// to the most recent position with that hash.
+	// It finds matches that are too far back for the chain.
+	// It is only used with a hash chain.
+	long []int32
+
+	// The next position in hist to add to table and chain.
+	nextInsert int
+
+	// The current repeated offsets. RFC 3.1.1.5.
+	rep [3]uint32
+}
+
+// init allocates the tables for p.
+func (m *matcher) init(p *levelParams) {
+	m.p = p
+	m.table = make([]int32, 1<<p.hashLog)
+	if p.chainLog > 0 {
+		m.chain = make([]int32, 1<<p.chainLog)
+		m.long = make([]int32, 1<<p.hashLog)
+	}
+}
+
+// reset clears the matcher to start a new frame.
+func (m *matcher) reset(dict *dictionary) {
+	m.hist = m.hist[:0]
+	clearTable(m.table)
+	clearTable(m.chain)
+	clearTable(m.long)
+	m.nextInsert = 0
+	m.rep = [3]uint32{1, 4, 8}
+	if dict != nil {
+		m.hist = append(m.hist, dict.content...)
+		m.rep = dict.repeatedOffsets
+		if m.p.chainLog == 0 {
+			for i := 0; i+8 <= len(m.hist); i++ {
+				m.table[m.hash(i)] = int32(i)
+			}
+		}
+	}
+}
+
+// shift discards the first n bytes of hist.
+// If there is a hash chain, n must be a multiple of its size.
+func (m *matcher) shift(n int) {
+	copy(m.hist, m.hist[n:])
+	m.hist = m.hist[:len(m.hist)-n]
+	shiftTable(m.table, n)
+	shiftTable(m.chain, n)
+	shiftTable(m.long, n)
+	m.nextInsert = max(m.nextInsert-n, 0)
+}
+
+// clearTable sets all the entries of table to -1,
+// meaning no position.
+func clearTable(table []int32) {
+	if len(table) == 0 {
+		return
+	}
+	table[0] = -1
+	for n := 1; n < len(table); n *= 2 {
+		copy(table[n:], table[:n])
+	}
+}
+
+// shiftTable adjusts positions in a table after a shift of n bytes.
+// Discarded positions become negative.
+func shiftTable(table []int32, n int) {
+	for i, v := range table {
+		if int(v) < n {
+			table[i] = -1
+		} else {
+			table[i] = v - int32(n)
+		}
+	}
+}
+
+// hash returns the hash table index for the bytes at hist[i:].
+// There must be at least 8 bytes available.
+func (m *matcher) hash(i int) uint32 {
+	const prime = 0xcf1bbcdcb7a56463
+	u := binary.LittleEndian.Uint64(m.hist[i:])
+	return uint32(((u << (64 - 8*m.p.minMatch)) * prime) >> (64 - m.p.hashLog))
+}
+
+// hashLong returns the long table index for the 8 bytes at hist[i:].
+func (m *matcher) hashLong(i int) uint32 {
+	const prime = 0x9fb21c651e98df25
+	u := binary.LittleEndian.Uint64(m.hist[i:])
+	return uint32((u * prime) >> (64 - m.p.hashLog))
+}
+
+// matchLen returns the number of bytes that match
+// at hist[a:] and hist[b:end], where a < b.
+func (m *matcher) matchLen(a, b, end int) int {
+	n := 0
+	for b+n+8 <= end {
+		x := binary.LittleEndian.Uint64(m.hist[a+n:]) ^ binary.LittleEndian.Uint64(m.hist[b+n:])
+		if x != 0 {
+			return n + bits.TrailingZeros64(x)/8
+		}
+		n += 8
+	}
+	for b+n < end && m.hist[a+n] == m.hist[b+n] {
+		n++
+	}
+	return n
+}
+
+// addSequence records literals from hist[litStart:i]
+// followed by a match of length matchLen at offset off.
+func (m *matcher) addSequence(be *blockEncoder, litStart, i, off, matchLen int) {
+	be.lits = append(be.lits, m.hist[litStart:i]...)
+	litLen := uint32(i - litStart)
+	be.seqs = append(be.seqs, sequence{
+		litLen:   litLen,
+		matchLen: uint32(matchLen),
+		offset:   m.offsetValue(uint32(off), litLen),
+	})
+}
+
+// offsetValue returns the offset value used to encode off,
+// and updates the repeated offsets the way the decoder will.
+// RFC 3.1.1.5.
+func (m *matcher) offsetValue(off, litLen uint32) uint32 {
+	r := &m.rep
+	if litLen > 0 {
+		switch off {
+		case r[0]:
+			return 1
+		case r[1]:
+			r[1] = r[0]
+			r[0] = off
+			return 2
+		case r[2]:
+			r[2] = r[1]
+			r[1] = r[0]
+			r[0] = off
+			return 3
+		}
+	} else {
+		switch off {
+		case r[1]:
+			r[1] = r[0]
+			r[0] = off
+			return 1
+		case r[2]:
+			r[2] = r[1]
+			r[1] = r[0]
+			r[0] = off
+			return 2
+		case r[0] - 1:
+			r[2] = r[1]
+			r[1] = r[0]
+			r[0] = off
+			return 3
+		}
+	}
+	r[2] = r[1]
+	r[1] = r[0]
+	r[0] = off
+	return off + 3
+}
+
+// compress finds matches for hist[start:end], recording the
+// sequences and literals in be. Matches may refer back to
+// positions no earlier than low, and no more than window bytes back
+// unless they refer to the dictionary content.
+func (m *matcher) compress(be *blockEncoder, start, end, low, window int, dictOK bool) {
+	// Hashing reads 8 bytes, so don't look for matches
+	// starting in the last few bytes.
+	limit := end - 8
+	anchor := start
+	if start < limit {
+		if m.p.chainLog == 0 {
+			anchor = m.compressFast(be, start, limit, end, low, window, dictOK)
+		} else {
+			anchor = m.compressChain(be, start, limit, end, low, window, dictOK)
+		}
+	}
+	be.lits = append(be.lits, m.hist[anchor:end]...)
+}
+
+// compressFast uses a single hash table to find matches,
+// checking one candidate at each position.
+// It returns the start of the trailing literals.
+func (m *matcher) compressFast(be *blockEncoder, start, limit, end, low, window int, dictOK bool) int {
+	hist := m.hist
+	anchor := start
+	for i := start; i < limit; {
+		minPos := low
+		if !dictOK {
+			minPos = max(low, i-window)
+		}
+
+		h := m.hash(i)
+		cand := int(m.table[h])
+		m.table[h] = int32(i)
+
+		cur := binary.LittleEndian.Uint32(hist[i:])
+		off := 0
+		if r := int(m.rep[0]); i > anchor && i-r >= minPos && binary.LittleEndian.Uint32(hist[i-r:]) == cur {
+			off = r
+		} else if cand >= minPos && cand < i && binary.LittleEndian.Uint32(hist[cand:]) == cur {
+			off = i - cand
+		} else {
+			// Skip ahead faster in data that doesn't compress.
+			i += 1 + (i-anchor)>>6
+			continue
+		}
+
+		matchLen := 4 + m.matchLen(i-off+4, i+4, end)
+		for i > anchor && i-off > minPos && hist[i-1] == hist[i-off-1] {
+			i--
+			matchLen++
+		}
+		m.addSequence(be, anchor, i, off, matchLen)
+		i += matchLen
+		anchor = i
+
+		// Remember a position near the end of the match.
+		if i-2 < limit {
+			m.table[m.hash(i-2)] = int32(i - 2)
+		}
+	}
+	return anchor
+}
+
+// compressChain uses hash chains to find matches,
+// with optional lazy matching.
+// It returns the start of the trailing literals.
+func (m *matcher) compressChain(be *blockEncoder, start, limit, end, low, window int, dictOK bool) int {
+	hist := m.hist
+	anchor := start
+	for i := start; i < limit; {
+		matchLen, off, gain := m.bestMatch(i, limit, end, low, window, dictOK, i > anchor)
+		if matchLen == 0 {
+			i++
+			continue
+		}
+
+		// Check whether a match starting at a following
+		// position would be better, allowing for the cost
+		// of another literal.
+		for step := 0; step < m.p.lazy && i+1 < limit; step++ {
+			matchLen2, off2, gain2 := m.bestMatch(i+1, limit, end, low, window, dictOK, true)
+			if matchLen2 == 0 || gain2 <= gain+4 {
+				break
+			}
+			i++
+			matchLen, off, gain = matchLen2, off2, gain2
+		}
+
+		minPos := low
+		if !dictOK {
+			minPos = max(low, i-window)
+		}
+		for i > anchor && i-off > minPos && hist[i-1] == hist[i-off-1] {
+			i--
+			matchLen++
+		}
+		m.addSequence(be, anchor, i, off, matchLen)
+		i += matchLen
+		anchor = i
+	}
+	return anchor
+}
+
+// matchGain estimates the benefit of a match, in quarter bits,
+// for comparing matches.
+func matchGain(matchLen, off int) int {
+	return 4*matchLen - bits.Len(uint(off))
+}
+
+// insert adds the positions up to i to the hash table and chain.
+func (m *matcher) insert(i, limit int) {
+	mask := len(m.chain) - 1
+	for p := m.nextInsert; p < i && p < limit; p++ {
+		h := m.hash(p)
+		m.chain[p&mask] = m.table[h]
+		m.table[h] = int32(p)
+		m.long[m.hashLong(p)] = int32(p)
+	}
+	m.nextInsert = max(m.nextInsert, min(i, limit))
+}
+
+// bestMatch returns the length and offset of the best match at hist[i:],
+// checking the repeated offsets and the hash chain,
+// along with an estimate of its gain for comparing matches.
+// It returns a zero length if there is no match of at least 4 bytes.
+// The first repeated offset can only be used cheaply if there are
+// literals before the match, as reported by haveLits.
+func (m *matcher) bestMatch(i, limit, end, low, window int, dictOK, haveLits bool) (matchLen, off, gain int) {
+	hist := m.hist
+	minPos := low
+	if !dictOK {
+		minPos = max(low, i-window)
+	}
+
+	// A repeated offset costs almost nothing to encode.
+	for k, r := range m.rep {
+		if k == 0 && !haveLits {
+			continue
+		}
+		p := i - int(r)
+		if p < minPos {
+			continue
+		}
+		if l := m.matchLen(p, i, end); l >= 4 && 4*l-1 > gain {
+			matchLen, off, gain = l, int(r), 4*l-1
+		}
+	}
+
+	// Check the long table before it is updated with i.
+	if cand := int(m.long[m.hashLong(i)]); cand >= minPos && cand < i {
+		l := m.matchLen(cand, i, end)
+		if g := matchGain(l, i-cand); l >= 8 && g > gain {
+			matchLen, off, gain = l, i-cand, g
+		}
+	}
+
+	m.insert(i, limit)
+	cand := int(m.table[m.hash(i)])
+	mask := len(m.chain) - 1
+	chainLow := max(minPos, i-len(m.chain))
+	for depth := m.p.depth; depth > 0 && cand >= chainLow && cand < i; depth-- {
+		// Only check the whole match if it could be longer.
+		if matchLen < end-i && hist[cand+matchLen] == hist[i+matchLen] {
+			l := m.matchLen(cand, i, end)
+			if g := matchGain(l, i-cand); l >= int(m.p.minMatch) && g > gain {
+				matchLen, off, gain = l, i-cand, g
+			}
+		}
+		if matchLen >= m.p.target || i+matchLen >= end {
+			break
+		}
+		cand = int(m.chain[cand&mask])
+	}
+	return matchLen, off, gain
+}
@@ -0,0 +1,365 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// These constants are the compression levels accepted by
+// [NewWriterLevel] and [NewWriterDict].
+// Any level from BestSpeed to BestCompression may be used.
+const (
+	BestSpeed          = 1
+	DefaultCompression = 3
+	BestCompression    = 9
+)
+
+// frameMagic is the magic number at the start of a frame. RFC 3.1.1.
+const frameMagic = 0xfd2fb528
+
+var errWriterClosed = errors.New("zstd: write to closed Writer")
+
+// A Writer is an [io.WriteCloser].
+// Writes to a Writer are compressed and written to the underlying writer.
+//
+// Each Writer writes a single zstd frame, which includes a checksum
+// of the uncompressed data. Data is compressed in blocks of up to
+// 128 KiB, so the output is not complete until [Writer.Close] is called.
+type Writer struct {
+	w     io.Writer
+	level int
+	dict  *dictionary
+	err   error
+
+	// Whether the frame header has been written.
+	wroteHeader bool
+
+	// The start of the data not yet compressed, in m.hist.
+	pos int
+
+	// The start of the frame's data, in m.hist.
+	// This is negative once the start of the frame has been discarded.
+	frameStart int
+
+	m  matcher
+	be blockEncoder
+
+	// The compressed block being written.
+	out []byte
+
+	checksum xxhash64
+}
+
+// NewWriter returns a new [Writer] that compresses data
+// at the default compression level.
+//
+// It is the caller's responsibility to call Close on the Writer when done.
+// Writes may be buffered and not flushed until Close.
+func NewWriter(w io.Writer) *Writer {
+	z, _ := NewWriterLevel(w, DefaultCompression)
+	return z
+}
+
+// NewWriterLevel is like [NewWriter] but specifies the compression level
+// instead of assuming [DefaultCompression].
+//
+// The compression level can be any integer value between
+// [BestSpeed] and [BestCompression] inclusive.
+// The error returned will be nil if the level is valid.
+func NewWriterLevel(w io.Writer, level int) (*Writer, error) {
+	return NewWriterDict(w, level, nil)
+}
+
+// NewWriterDict is like [NewWriterLevel] but compresses using a dictionary.
+// The dictionary may be in the zstd dictionary format, as produced by
+// the zstd --train command, or it may be raw content.
+// The compressed data can only be decompressed by a [Reader]
+// using the same dictionary, as returned by [NewReaderDict].
+// A dictionary in the zstd format has an ID, which is recorded in
+// the compressed data.
+//
+// The Writer retains dict; the caller must not modify it.
+func NewWriterDict(w io.Writer, level int, dict []byte) (*Writer, error) {
+	if level < BestSpeed || level > BestCompression {
+		return nil, fmt.Errorf("zstd: invalid compression level: %d", level)
+	}
+	z := &Writer{
+		level: level,
+	}
+	if dict != nil {
+		d, err := parseDict(dict)
+		if err != nil {
+			return nil, err
+		}
+		z.dict = d
+	}
+	z.m.init(&levels[level-BestSpeed])
+	z.Reset(w)
+	return z, nil
+}
+
+// Reset discards the Writer z's state and makes it equivalent to the
+// result of its original state from [NewWriter], [NewWriterLevel],
+// or [NewWriterDict], but writing to w instead.
+// This permits reusing a Writer rather than allocating a new one.
+func (z *Writer) Reset(w io.Writer) {
+	z.w = w
+	z.err = nil
+	z.wroteHeader = false
+	z.m.reset(z.dict)
+	z.pos = len(z.m.hist)
+	z.frameStart = z.pos
+	z.checksum.reset()
+}
+
+// windowSize returns the window size that the frame header declares.
+func (z *Writer) windowSize() int {
+	return 1 << z.m.p.windowLog
+}
+
+// Write writes a compressed form of p to the underlying [io.Writer].
+// The compressed bytes are not necessarily flushed until
+// the Writer is flushed or closed.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	n := 0
+	for len(p) > 0 {
+		if len(z.m.hist)-z.pos == maxBlockSize {
+			if err := z.writeBlock(false); err != nil {
+				return n, err
+			}
+		}
+		if z.pos == len(z.m.hist) {
+			z.makeRoom()
+		}
+		c := min(len(p), maxBlockSize-(len(z.m.hist)-z.pos))
+		z.m.hist = append(z.m.hist, p[:c]...)
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// makeRoom makes sure that there is room in the history buffer for
+// another block, by discarding data that is outside the window.
+// It is only called when all the data has been compressed.
+func (z *Writer) makeRoom() {
+	if len(z.m.hist)+maxBlockSize <= cap(z.m.hist) {
+		return
+	}
+	// Keep at least a window's worth of data.
+	// The hash chain relies on discarding a multiple of its size.
+	window := z.windowSize()
+	n := len(z.m.hist) - window
+	if chain := len(z.m.chain); chain > 0 {
+		n &^= chain - 1
+	}
+	if n > 0 {
+		z.m.shift(n)
+		z.pos -= n
+		z.frameStart -= n
+	}
+	if len(z.m.hist)+maxBlockSize > cap(z.m.hist) {
+		// Grow geometrically, so that small inputs
+		// don't need a buffer the size of the window.
+		size := max(min(2*cap(z.m.hist), 2*window+maxBlockSize), len(z.m.hist)+maxBlockSize)
+		z.m.hist = append(make([]byte, 0, size), z.m.hist...)
+	}
+}
+
+// Flush compresses any pending data and writes it to the
+// underlying [io.Writer]. After Flush, a [Reader] can
+// decompress all the data written so far.
+// Flush does not close the frame, so the output is not a complete
+// zstd stream until [Writer.Close] is called.
+func (z *Writer) Flush() error {
+	if z.err != nil {
+		if z.err == errWriterClosed {
+			return nil
+		}
+		return z.err
+	}
+	if len(z.m.hist) > z.pos {
+		return z.writeBlock(false)
+	}
+	if !z.wroteHeader {
+		z.out = z.appendHeader(z.out[:0], false)
+		return z.writeOut()
+	}
+	return nil
+}
+
+// Close compresses any pending data, and finishes the frame
+// by writing the checksum. It does not close the underlying [io.Writer].
+func (z *Writer) Close() error {
+	if z.err != nil {
+		if z.err == errWriterClosed {
+			return nil
+		}
+		return z.err
+	}
+	if err := z.writeBlock(true); err != nil {
+		return err
+	}
+	z.err = errWriterClosed
+	return nil
+}
+
+// writeOut writes z.out to the underlying writer.
+func (z *Writer) writeOut() error {
+	if _, err := z.w.Write(z.out); err != nil {
+		z.err = err
+		return err
+	}
+	return nil
+}
+
+// appendHeader appends the frame header. RFC 3.1.1.1.
+// If final is true, all the data in the frame is in the first block,
+// and the header records its size.
+func (z *Writer) appendHeader(dst []byte, final bool) []byte {
+	z.wroteHeader = true
+
+	// Frame_Header_Descriptor, with Content_Checksum_Flag set.
+	descriptor := byte(1 << 2)
+
+	dictID := uint32(0)
+	dictIDSize := 0
+	if z.dict != nil {
+		dictID = z.dict.id
+	}
+	switch {
+	case dictID == 0:
+	case dictID < 1<<8:
+		dictIDSize = 1
+		descriptor |= 1
+	case dictID < 1<<16:
+		dictIDSize = 2
+		descriptor |= 2
+	default:
+		dictIDSize = 4
+		descriptor |= 3
+	}
+
+	// If we know the size of the data, we can use a single segment,
+	// and skip the Window_Descriptor.
+	size := len(z.m.hist) - z.pos
+	if final {
+		descriptor |= 1 << 5
+		switch {
+		case size < 256:
+		case size < 65536+256:
+			descriptor |= 1 << 6
+		default:
+			descriptor |= 2 << 6
+		}
+	}
+
+	dst = binary.LittleEndian.AppendUint32(dst, frameMagic)
+	dst = append(dst, descriptor)
+	if !final {
+		// Window_Descriptor, with a zero Mantissa.
+		dst = append(dst, (z.m.p.windowLog-10)<<3)
+	}
+	for i := 0; i < dictIDSize; i++ {
+		dst = append(dst, byte(dictID>>(8*i)))
+	}
+	if final {
+		switch descriptor >> 6 {
+		case 0:
+			dst = append(dst, byte(size))
+		case 1:
+			dst = binary.LittleEndian.AppendUint16(dst, uint16(size-256))
+		case 2:
+			dst = binary.LittleEndian.AppendUint32(dst, uint32(size))
+		}
+	}
+	return dst
+}
+
+// writeBlock compresses the pending data as a block,
+// and writes it to the underlying writer.
+// If last is true this is the last block of the frame,
+// and is followed by the checksum.
+func (z *Writer) writeBlock(last bool) error {
+	src := z.m.hist[z.pos:]
+
+	z.out = z.out[:0]
+	if !z.wroteHeader {
+		z.out = z.appendHeader(z.out, last)
+	}
+
+	// Reserve space for the Block_Header. RFC 3.1.1.2.
+	hdr := len(z.out)
+	z.out = append(z.out, 0, 0, 0)
+
+	var blockType uint32
+	if len(src) > 1 && allSame(src) {
+		// RLE_Block.
+		blockType = 1
+		z.out = append(z.out, src[0])
+	} else {
+		// Try a Compressed_Block, falling back to a Raw_Block.
+		// Matches may refer back into the dictionary as long as
+		// the frame is no larger than the window.
+		window := z.windowSize()
+		end := len(z.m.hist)
+		dictOK := end-z.frameStart <= window
+		low := 0
+		if !dictOK {
+			low = max(z.frameStart, 0)
+		}
+
+		savedRep := z.m.rep
+		z.be.reset()
+		z.m.compress(&z.be, z.pos, end, low, window, dictOK)
+		start := len(z.out)
+		z.out = z.be.appendBlock(z.out)
+		if len(z.out)-start < len(src) {
+			blockType = 2
+		} else {
+			// The decoder won't see the sequences,
+			// so it won't update the repeated offsets.
+			z.m.rep = savedRep
+			z.out = append(z.out[:start], src...)
+		}
+	}
+
+	size := uint32(len(z.out) - hdr - 3)
+	if blockType == 1 {
+		size = uint32(len(src))
+	}
+	header := size<<3 | blockType<<1
+	if last {
+		header |= 1
+	}
+	z.out[hdr] = byte(header)
+	z.out[hdr+1] = byte(header >> 8)
+	z.out[hdr+2] = byte(header >> 16)
+
+	z.checksum.update(src)
+	if last {
+		z.out = binary.LittleEndian.AppendUint32(z.out, uint32(z.checksum.digest()))
+	}
+	z.pos = len(z.m.hist)
+
+	return z.writeOut()
+}
+
+// allSame reports whether all the bytes in b are the same.
+func allSame(b []byte) bool {
+	for _, c := range b[1:] {
+		if c != b[0] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,388 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// writerInputs returns some inputs to compress.
+func writerInputs(t testing.TB) map[string][]byte {
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 300000)
+	r.Read(random)
+
+	// Text-like data drawn from a small alphabet,
+	// which compresses well with Huffman but has few matches.
+	skewed := make([]byte, 200000)
+	for i := range skewed {
+		skewed[i] = "aaaabbbcccdde "[r.Intn(14)]
+	}
+
+	inputs := map[string][]byte{
+		"empty":    nil,
+		"byte":     []byte("x"),
+		"hello":    []byte("hello, world\n"),
+		"zeros":    make([]byte, 400000),
+		"random":   random,
+		"skewed":   skewed,
+		"repeated": bytes.Repeat([]byte("abcdefghijklmnop"), 20000),
+	}
+	for _, test := range tests {
+		if test.uncompressed != "" {
+			inputs[test.name] = []byte(test.uncompressed)
+		}
+	}
+	if !testing.Short() {
+		inputs["big"] = bigData(t)
+	}
+	return inputs
+}
+
+// compress returns data compressed at level with dict.
+// It writes the data in chunks of size chunk, or all at once if chunk is 0.
+func compress(t testing.TB, data []byte, level int, dict []byte, chunk int) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriterDict(&buf, level, dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(data) > 0 {
+		n := len(data)
+		if chunk > 0 {
+			n = min(n, chunk)
+		}
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// decompress returns the result of decompressing data with dict.
+func decompress(t testing.TB, data, dict []byte) []byte {
+	r, err := NewReaderDict(bytes.NewReader(data), dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	for name, data := range writerInputs(t) {
+		for level := BestSpeed; level <= BestCompression; level++ {
+			t.Run(fmt.Sprintf("%s/level%d", name, level), func(t *testing.T) {
+				compressed := compress(t, data, level, nil, 0)
+				t.Logf("compressed %d bytes to %d", len(data), len(compressed))
+				got := decompress(t, compressed, nil)
+				if !bytes.Equal(got, data) {
+					showDiffs(t, got, data)
+				}
+			})
+		}
+	}
+}
+
+func TestWriterChunks(t *testing.T) {
+	data := bigData(t)
+	if testing.Short() {
+		data = data[:len(data)/10]
+	}
+	for _, chunk := range []int{1000, 65536, 200000} {
+		t.Run(fmt.Sprint(chunk), func(t *testing.T) {
+			compressed := compress(t, data, DefaultCompression, nil, chunk)
+			got := decompress(t, compressed, nil)
+			if !bytes.Equal(got, data) {
+				showDiffs(t, got, data)
+			}
+		})
+	}
+}
+
+func TestWriterCompresses(t *testing.T) {
+	data := bigData(t)[:1<<20]
+	prev := len(data)
+	for _, level := range []int{BestSpeed, DefaultCompression, BestCompression} {
+		n := len(compress(t, data, level, nil, 0))
+		t.Logf("level %d: compressed %d bytes to %d", level, len(data), n)
+		if n > prev {
+			t.Errorf("level %d: compressed size %d larger than %d at previous level", level, n, prev)
+		}
+		prev = n
+	}
+	if prev > len(data)/3 {
+		t.Errorf("compressed %d bytes to %d, want at most %d", len(data), prev, len(data)/3)
+	}
+}
+
+func TestWriterFlush(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() == 0 {
+		t.Error("Flush of empty Writer wrote nothing")
+	}
+
+	var want []byte
+	for i := 0; i < 10; i++ {
+		line := fmt.Sprintf("line %d of the flush test\n", i)
+		want = append(want, line...)
+		if _, err := io.WriteString(w, line); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Everything written so far must be readable,
+		// although the frame is incomplete.
+		r := NewReader(bytes.NewReader(buf.Bytes()))
+		got := make([]byte, len(want))
+		if _, err := io.ReadFull(r, got); err != nil {
+			t.Fatalf("after Flush %d: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("after Flush %d: got %q, want %q", i, got, want)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := decompress(t, buf.Bytes(), nil); !bytes.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWriterReset(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	w := NewWriter(&buf1)
+	io.WriteString(w, "first frame")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	if _, err := io.WriteString(w, "more"); err == nil {
+		t.Error("Write after Close succeeded")
+	}
+
+	w.Reset(&buf2)
+	io.WriteString(w, "second frame")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := decompress(t, buf1.Bytes(), nil); string(got) != "first frame" {
+		t.Errorf("first frame: got %q", got)
+	}
+	if got := decompress(t, buf2.Bytes(), nil); string(got) != "second frame" {
+		t.Errorf("second frame: got %q", got)
+	}
+}
+
+func TestWriterLevel(t *testing.T) {
+	for _, level := range []int{-1, 0, BestCompression + 1} {
+		if _, err := NewWriterLevel(io.Discard, level); err == nil {
+			t.Errorf("NewWriterLevel(%d) succeeded", level)
+		}
+	}
+}
+
+// Test that the zstd command can decompress what we compress.
+func TestWriterZstd(t *testing.T) {
+	zstd := findZstd(t)
+	for name, data := range writerInputs(t) {
+		for _, level := range []int{BestSpeed, DefaultCompression, BestCompression} {
+			t.Run(fmt.Sprintf("%s/level%d", name, level), func(t *testing.T) {
+				compressed := compress(t, data, level, nil, 0)
+				cmd := exec.Command(zstd, "-d")
+				cmd.Stdin = bytes.NewReader(compressed)
+				var out bytes.Buffer
+				cmd.Stdout = &out
+				cmd.Stderr = os.Stderr
+				if err := cmd.Run(); err != nil {
+					t.Fatalf("zstd -d failed: %v", err)
+				}
+				if !bytes.Equal(out.Bytes(), data) {
+					showDiffs(t, out.Bytes(), data)
+				}
+			})
+		}
+	}
+}
+
+// testDictFile is a dictionary in the zstd format, trained on
+// lines of generated text, as produced by dictSample.
+// See testdata/README.
+const testDictFile = "testdata/lines.dict"
+
+// dictSample returns a sample of the data that testDictFile
+// was trained on.
+func dictSample(i int) []byte {
+	var b strings.Builder
+	for j := 0; j < 20; j++ {
+		fmt.Fprintf(&b, "%d: the quick brown fox jumps over the lazy dog %d times\n", i, (i*7+j*13)%97)
+	}
+	return []byte(b.String())
+}
+
+func TestWriterDict(t *testing.T) {
+	formatted, err := os.ReadFile(testDictFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dicts := map[string][]byte{
+		"raw":       []byte(strings.Repeat("the quick brown fox jumps over the lazy dog\n", 10)),
+		"formatted": formatted,
+	}
+	for name, dict := range dicts {
+		for level := BestSpeed; level <= BestCompression; level++ {
+			t.Run(fmt.Sprintf("%s/level%d", name, level), func(t *testing.T) {
+				data := dictSample(1000 + level)
+				plain := compress(t, data, level, nil, 0)
+				compressed := compress(t, data, level, dict, 0)
+				t.Logf("compressed %d bytes to %d, %d without dictionary", len(data), len(compressed), len(plain))
+				if len(compressed) >= len(plain) {
+					t.Errorf("dictionary did not help: got %d bytes, %d without dictionary", len(compressed), len(plain))
+				}
+				got := decompress(t, compressed, dict)
+				if !bytes.Equal(got, data) {
+					showDiffs(t, got, data)
+				}
+			})
+		}
+	}
+}
+
+// Test that a large input compressed with a dictionary
+// doesn't refer to the dictionary beyond the window.
+func TestWriterDictLarge(t *testing.T) {
+	dict, err := os.ReadFile(testDictFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data []byte
+	for i := 0; len(data) < 5<<20; i++ {
+		data = append(data, dictSample(i)...)
+	}
+	for _, level := range []int{BestSpeed, BestCompression} {
+		compressed := compress(t, data, level, dict, 100000)
+		got := decompress(t, compressed, dict)
+		if !bytes.Equal(got, data) {
+			showDiffs(t, got, data)
+		}
+	}
+}
+
+// Test that we can decompress what the zstd command compresses
+// with a dictionary, and that it can decompress ours.
+func TestDictZstd(t *testing.T) {
+	zstd := findZstd(t)
+	dict := testDictFile
+	data := dictSample(12345)
+
+	cmd := exec.Command(zstd, "-z", "-D", dict)
+	cmd.Stdin = bytes.NewReader(data)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("zstd -z failed: %v", err)
+	}
+	dictData, err := os.ReadFile(dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := decompress(t, out.Bytes(), dictData); !bytes.Equal(got, data) {
+		showDiffs(t, got, data)
+	}
+
+	for level := BestSpeed; level <= BestCompression; level++ {
+		compressed := compress(t, data, level, dictData, 0)
+		cmd := exec.Command(zstd, "-d", "-D", dict)
+		cmd.Stdin = bytes.NewReader(compressed)
+		out.Reset()
+		cmd.Stdout = &out
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("level %d: zstd -d failed: %v", level, err)
+		}
+		if !bytes.Equal(out.Bytes(), data) {
+			showDiffs(t, out.Bytes(), data)
+		}
+	}
+}
+
+func TestReaderDictMismatch(t *testing.T) {
+	dict, err := os.ReadFile(testDictFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed := compress(t, dictSample(1), DefaultCompression, dict, 0)
+
+	// Without the dictionary.
+	if _, err := io.ReadAll(NewReader(bytes.NewReader(compressed))); err == nil {
+		t.Error("decompressing without dictionary succeeded")
+	}
+
+	// With a different dictionary ID.
+	other := bytes.Clone(dict)
+	other[4]++
+	r, err := NewReaderDict(bytes.NewReader(compressed), other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(r); err == nil || !strings.Contains(err.Error(), "dictionary ID") {
+		t.Errorf("got error %v, want dictionary ID mismatch", err)
+	}
+}
+
+func TestDictBad(t *testing.T) {
+	dict, err := os.ReadFile(testDictFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{9, 20, 100} {
+		if _, err := NewReaderDict(bytes.NewReader(nil), dict[:n]); err == nil {
+			t.Errorf("truncated dictionary of %d bytes accepted", n)
+		}
+		if _, err := NewWriterDict(io.Discard, DefaultCompression, dict[:n]); err == nil {
+			t.Errorf("truncated dictionary of %d bytes accepted by NewWriterDict", n)
+		}
+	}
+}
+
+func BenchmarkWriter(b *testing.B) {
+	data := bigData(b)[:4<<20]
+	for _, level := range []int{BestSpeed, DefaultCompression, BestCompression} {
+		b.Run(fmt.Sprintf("level%d", level), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			w, _ := NewWriterLevel(io.Discard, level)
+			for i := 0; i < b.N; i++ {
+				w.Reset(io.Discard)
+				w.Write(data)
+				w.Close()
+			}
+		})
+	}
+}
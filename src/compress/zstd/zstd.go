@@ -2,8 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package zstd provides a decompressor for zstd streams,
-// described in RFC 8878. It does not support dictionaries.
+// Package zstd implements reading and writing of zstd compressed data,
+// as described in RFC 8878.
+//
+// Both the [Reader] and the [Writer] support dictionaries,
+// either in the format produced by the zstd --train command
+// or as raw content.
 package zstd
 
 import (
@@ -49,6 +53,16 @@ type Reader struct {
 	// Current read offset in buffer.
 	off int
 
+	// The dictionary, if any.
+	dict *dictionary
+
+	// Whether the current frame uses the dictionary.
+	useDict bool
+
+	// The number of bytes decompressed so far in the current frame,
+	// not counting buffer.
+	frameDecompressed uint64
+
 	// The current repeated offsets.
 	repeatedOffset1 uint32
 	repeatedOffset2 uint32
@@ -91,8 +105,28 @@ func NewReader(input io.Reader) *Reader {
 	return r
 }
 
+// NewReaderDict is like [NewReader] but uses a dictionary.
+// The dictionary may be in the zstd dictionary format, as produced by
+// the zstd --train command, or it may be raw content.
+// The dictionary is used for each frame that names its ID,
+// or that does not name any dictionary.
+// A frame that names a different dictionary is an error.
+//
+// The Reader retains dict; the caller must not modify it.
+func NewReaderDict(input io.Reader, dict []byte) (*Reader, error) {
+	d, err := parseDict(dict)
+	if err != nil {
+		return nil, err
+	}
+	r := new(Reader)
+	r.dict = d
+	r.Reset(input)
+	return r, nil
+}
+
 // Reset discards the current state and starts reading a new stream from r.
 // This permits reusing a Reader rather than allocating a new one.
+// The Reader continues to use the same dictionary, if any.
 func (r *Reader) Reset(input io.Reader) {
 	r.r = input
 
@@ -106,6 +140,9 @@ func (r *Reader) Reset(input io.Reader) {
 	r.blockOffset = 0
 	r.buffer = r.buffer[:0]
 	r.off = 0
+	// dict
+	r.useDict = false
+	r.frameDecompressed = 0
 	// repeatedOffset1
 	// repeatedOffset2
 	// repeatedOffset3
@@ -255,15 +292,19 @@ retry:
 	}
 
 	// Dictionary_ID. RFC 3.1.1.1.3.
+	var dictionaryID uint32
 	if dictionaryIdSize != 0 {
-		dictionaryId := r.scratch[windowDescriptorSize : windowDescriptorSize+dictionaryIdSize]
-		// Allow only zero Dictionary ID.
-		for _, b := range dictionaryId {
-			if b != 0 {
-				return r.makeError(relativeOffset, "dictionaries are not supported")
-			}
+		dictionaryIDBytes := r.scratch[windowDescriptorSize : windowDescriptorSize+dictionaryIdSize]
+		for i, b := range dictionaryIDBytes {
+			dictionaryID |= uint32(b) << (8 * i)
 		}
 	}
+	// A zero Dictionary ID means that the decoder must
+	// know which dictionary to use, if any.
+	r.useDict = r.dict != nil
+	if dictionaryID != 0 && (r.dict == nil || r.dict.id != dictionaryID) {
+		return r.wrapError(relativeOffset, fmt.Errorf("unknown dictionary ID %d", dictionaryID))
+	}
 
 	// Frame_Content_Size. RFC 3.1.1.1.4.
 	r.frameSizeUnknown = false
@@ -304,6 +345,7 @@ retry:
 	r.blockOffset += int64(relativeOffset)
 
 	// Prepare to read blocks from the frame.
+	r.frameDecompressed = 0
 	r.repeatedOffset1 = 1
 	r.repeatedOffset2 = 4
 	r.repeatedOffset3 = 8
@@ -313,9 +355,35 @@ retry:
 	r.seqTables[1] = nil
 	r.seqTables[2] = nil
 
+	if r.useDict {
+		r.applyDict()
+	}
+
 	return nil
 }
 
+// applyDict sets up the state at the start of a frame
+// from the dictionary. RFC 5.
+func (r *Reader) applyDict() {
+	d := r.dict
+	r.repeatedOffset1 = d.repeatedOffsets[0]
+	r.repeatedOffset2 = d.repeatedOffsets[1]
+	r.repeatedOffset3 = d.repeatedOffsets[2]
+	if d.huffmanTableBits > 0 {
+		// Reading a new Huffman table overwrites r.huffmanTable,
+		// so copy the table rather than sharing it.
+		if len(r.huffmanTable) < 1<<maxHuffmanBits {
+			r.huffmanTable = make([]uint16, 1<<maxHuffmanBits)
+		}
+		copy(r.huffmanTable, d.huffmanTable)
+		r.huffmanTableBits = d.huffmanTableBits
+	}
+	// The sequence tables are never modified in place,
+	// so they can be shared.
+	r.seqTables = d.seqTables
+	r.seqTableBits = d.seqTableBits
+}
+
 // skipFrame skips a skippable frame. RFC 3.1.2.
 func (r *Reader) skipFrame() error {
 	relativeOffset := 0
@@ -432,6 +500,8 @@ func (r *Reader) readBlock() error {
 		r.checksum.update(r.buffer)
 	}
 
+	r.frameDecompressed += uint64(len(r.buffer))
+
 	if !lastBlock {
 		r.window.save(r.buffer)
 	} else {
@@ -13,9 +13,15 @@
 // ordering for the Less method, so Push adds items while Pop removes the
 // highest-priority item from the queue. The Examples include such an
 // implementation; the file example_pq_test.go has the complete source.
+//
+// Package [container/heap/v2] provides a heap type with a type parameter
+// for its elements, which does not require implementing [Interface].
 package heap
 
-import "sort"
+import (
+	"container/heap/internal/sift"
+	"sort"
+)
 
 // The Interface type describes the requirements
 // for a type using the routines in this package.
@@ -39,18 +45,14 @@ type Interface interface {
 // and may be called whenever the heap invariants may have been invalidated.
 // The complexity is O(n) where n = h.Len().
 func Init(h Interface) {
-	// heapify
-	n := h.Len()
-	for i := n/2 - 1; i >= 0; i-- {
-		down(h, i, n)
-	}
+	sift.Init(h, h.Len())
 }
 
 // Push pushes the element x onto the heap.
 // The complexity is O(log n) where n = h.Len().
 func Push(h Interface, x any) {
 	h.Push(x)
-	up(h, h.Len()-1)
+	sift.Up(h, h.Len()-1)
 }
 
 // Pop removes and returns the minimum element (according to Less) from the heap.
@@ -59,7 +61,7 @@ func Push(h Interface, x any) {
 func Pop(h Interface) any {
 	n := h.Len() - 1
 	h.Swap(0, n)
-	down(h, 0, n)
+	sift.Down(h, 0, n)
 	return h.Pop()
 }
 
@@ -69,8 +71,8 @@ func Remove(h Interface, i int) any {
 	n := h.Len() - 1
 	if n != i {
 		h.Swap(i, n)
-		if !down(h, i, n) {
-			up(h, i)
+		if !sift.Down(h, i, n) {
+			sift.Up(h, i)
 		}
 	}
 	return h.Pop()
@@ -81,38 +83,7 @@ func Remove(h Interface, i int) any {
 // but less expensive than, calling [Remove](h, i) followed by a Push of the new value.
 // The complexity is O(log n) where n = h.Len().
 func Fix(h Interface, i int) {
-	if !down(h, i, h.Len()) {
-		up(h, i)
-	}
-}
-
-func up(h Interface, j int) {
-	for {
-		i := (j - 1) / 2 // parent
-		if i == j || !h.Less(j, i) {
-			break
-		}
-		h.Swap(i, j)
-		j = i
-	}
-}
-
-func down(h Interface, i0, n int) bool {
-	i := i0
-	for {
-		j1 := 2*i + 1
-		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
-			break
-		}
-		j := j1 // left child
-		if j2 := j1 + 1; j2 < n && h.Less(j2, j1) {
-			j = j2 // = 2*i + 2  // right child
-		}
-		if !h.Less(j, i) {
-			break
-		}
-		h.Swap(i, j)
-		i = j
+	if !sift.Down(h, i, h.Len()) {
+		sift.Up(h, i)
 	}
-	return i > i0
 }
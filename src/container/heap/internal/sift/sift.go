@@ -0,0 +1,57 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sift implements the heap algorithms shared by
+// container/heap and container/heap/v2.
+package sift
+
+// Interface is implemented by a min-heap whose elements are
+// identified by their index.
+type Interface interface {
+	Less(i, j int) bool
+	Swap(i, j int)
+}
+
+// Init establishes the heap invariants for the n elements of h.
+func Init[H Interface](h H, n int) {
+	for i := n/2 - 1; i >= 0; i-- {
+		Down(h, i, n)
+	}
+}
+
+// Up moves the element at index j towards the root
+// until it is not less than its parent.
+func Up[H Interface](h H, j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		j = i
+	}
+}
+
+// Down moves the element at index i0 away from the root, among the first
+// n elements, until neither of its children is less than it.
+// It reports whether the element moved.
+func Down[H Interface](h H, i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.Less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		i = j
+	}
+	return i > i0
+}
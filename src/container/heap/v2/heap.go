@@ -0,0 +1,188 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package heap provides a min-heap data structure, [Heap],
+// whose elements are values of a single type.
+//
+// It is a type-parameterized version of [container/heap]:
+// instead of implementing an interface whose Push and Pop methods
+// take and return values of type any, a Heap is created with a
+// function that compares two elements, and stores its elements
+// in a slice that it manages itself.
+//
+// A heap is a common way to implement a priority queue. To build a
+// priority queue, create a Heap whose less function reports whether
+// one element has a higher priority than another, so Push adds items
+// while Pop removes the highest-priority item from the queue.
+package heap
+
+import (
+	"container/heap/internal/sift"
+	"iter"
+)
+
+// A Heap is a min-heap backed by a slice.
+// The minimum element, according to the less function
+// passed to [New], is at index 0.
+//
+// The elements of a Heap are identified by their index in the
+// backing slice, which changes as elements are added and removed.
+// Use [Heap.SetIndex] to be told the index of each element,
+// for use with [Heap.Fix] and [Heap.Remove].
+type Heap[T any] struct {
+	s        []T
+	less     func(a, b T) bool
+	setIndex func(T, int)
+}
+
+// New returns a new empty heap whose elements are ordered by less,
+// which reports whether a must be removed from the heap before b.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// SetIndex arranges for f to be called with an element and its index
+// whenever the element is added to the heap or moves within it.
+// When an element is removed from the heap, f is called with
+// the element and -1.
+//
+// SetIndex must be called before any elements are added to the heap.
+func (h *Heap[T]) SetIndex(f func(T, int)) {
+	h.setIndex = f
+}
+
+// Init replaces the contents of h with the elements of s,
+// and establishes the heap invariants.
+// The heap takes ownership of s, which the caller must not use afterwards.
+// The complexity is O(n) where n = len(s).
+func (h *Heap[T]) Init(s []T) {
+	h.s = s
+	if h.setIndex != nil {
+		for i, v := range s {
+			h.setIndex(v, i)
+		}
+	}
+	sift.Init(h.ops(), len(s))
+}
+
+// Len returns the number of elements in h.
+func (h *Heap[T]) Len() int {
+	return len(h.s)
+}
+
+// Push adds v to h.
+// The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) Push(v T) {
+	h.s = append(h.s, v)
+	n := len(h.s) - 1
+	if h.setIndex != nil {
+		h.setIndex(v, n)
+	}
+	sift.Up(h.ops(), n)
+}
+
+// Min returns the minimum element of h without removing it.
+// Min panics if h is empty.
+func (h *Heap[T]) Min() T {
+	return h.s[0]
+}
+
+// Pop removes and returns the minimum element of h.
+// Pop panics if h is empty.
+// The complexity is O(log n) where n = h.Len().
+// Pop is equivalent to [Heap.Remove](0).
+func (h *Heap[T]) Pop() T {
+	n := len(h.s) - 1
+	h.swap(0, n)
+	sift.Down(h.ops(), 0, n)
+	return h.removeLast()
+}
+
+// Remove removes and returns the element at index i of h.
+// The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) Remove(i int) T {
+	n := len(h.s) - 1
+	if n != i {
+		h.swap(i, n)
+		if !sift.Down(h.ops(), i, n) {
+			sift.Up(h.ops(), i)
+		}
+	}
+	return h.removeLast()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling [Heap.Remove](i) followed by a Push of the new value.
+// The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) Fix(i int) {
+	if !sift.Down(h.ops(), i, len(h.s)) {
+		sift.Up(h.ops(), i)
+	}
+}
+
+// Clear removes all elements from h.
+func (h *Heap[T]) Clear() {
+	if h.setIndex != nil {
+		for _, v := range h.s {
+			h.setIndex(v, -1)
+		}
+	}
+	clear(h.s)
+	h.s = h.s[:0]
+}
+
+// All returns an iterator over the elements of h, in an unspecified order.
+// The heap must not be modified during iteration.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range h.s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the elements of h and yields them
+// in order, smallest first. If iteration stops early, the elements not yet
+// yielded remain in h.
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(h.s) > 0 {
+			if !yield(h.Pop()) {
+				return
+			}
+		}
+	}
+}
+
+// removeLast removes and returns the last element of the backing slice.
+func (h *Heap[T]) removeLast() T {
+	n := len(h.s) - 1
+	v := h.s[n]
+	var zero T
+	h.s[n] = zero // don't stop the GC from reclaiming the element
+	h.s = h.s[:n]
+	if h.setIndex != nil {
+		h.setIndex(v, -1)
+	}
+	return v
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.s[i], h.s[j] = h.s[j], h.s[i]
+	if h.setIndex != nil {
+		h.setIndex(h.s[i], i)
+		h.setIndex(h.s[j], j)
+	}
+}
+
+// ops implements [sift.Interface] for a Heap.
+type ops[T any] Heap[T]
+
+func (h *Heap[T]) ops() *ops[T] { return (*ops[T])(h) }
+
+func (o *ops[T]) Less(i, j int) bool { return o.less(o.s[i], o.s[j]) }
+func (o *ops[T]) Swap(i, j int)      { (*Heap[T])(o).swap(i, j) }
@@ -0,0 +1,255 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func newIntHeap() *Heap[int] {
+	return New(func(a, b int) bool { return a < b })
+}
+
+func (h *Heap[T]) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.Len()
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	if j1 < n {
+		if h.less(h.s[j1], h.s[i]) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.s[i], j1, h.s[j1])
+			return
+		}
+		h.verify(t, j1)
+	}
+	if j2 < n {
+		if h.less(h.s[j2], h.s[i]) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.s[i], j1, h.s[j2])
+			return
+		}
+		h.verify(t, j2)
+	}
+}
+
+func TestInit0(t *testing.T) {
+	h := newIntHeap()
+	h.Init(make([]int, 20)) // all elements are the same
+	h.verify(t, 0)
+
+	for i := 1; h.Len() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != 0 {
+			t.Errorf("%d.th pop got %d; want %d", i, x, 0)
+		}
+	}
+}
+
+func TestInit1(t *testing.T) {
+	h := newIntHeap()
+	var s []int
+	for i := 20; i > 0; i-- {
+		s = append(s, i) // all elements are different
+	}
+	h.Init(s)
+	h.verify(t, 0)
+
+	for i := 1; h.Len() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != i {
+			t.Errorf("%d.th pop got %d; want %d", i, x, i)
+		}
+	}
+}
+
+func Test(t *testing.T) {
+	h := newIntHeap()
+	h.verify(t, 0)
+
+	var s []int
+	for i := 20; i > 10; i-- {
+		s = append(s, i)
+	}
+	h.Init(s)
+	h.verify(t, 0)
+
+	for i := 10; i > 0; i-- {
+		h.Push(i)
+		h.verify(t, 0)
+	}
+	if h.Min() != 1 {
+		t.Errorf("Min() = %d; want 1", h.Min())
+	}
+
+	for i := 1; h.Len() > 0; i++ {
+		x := h.Pop()
+		if i < 20 {
+			h.Push(20 + i)
+		}
+		h.verify(t, 0)
+		if x != i {
+			t.Errorf("%d.th pop got %d; want %d", i, x, i)
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	const N = 10
+	h := newIntHeap()
+	for i := 0; i < N; i++ {
+		h.Push(i)
+	}
+	h.verify(t, 0)
+
+	m := make(map[int]bool)
+	for h.Len() > 0 {
+		m[h.Remove((h.Len()-1)/2)] = true
+		h.verify(t, 0)
+	}
+
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+	for i := 0; i < len(m); i++ {
+		if !m[i] {
+			t.Errorf("m[%d] doesn't exist", i)
+		}
+	}
+}
+
+func TestFix(t *testing.T) {
+	h := newIntHeap()
+	h.verify(t, 0)
+
+	for i := 200; i > 0; i -= 10 {
+		h.Push(i)
+	}
+	h.verify(t, 0)
+
+	if h.s[0] != 10 {
+		t.Fatalf("Expected head to be 10, was %d", h.s[0])
+	}
+	h.s[0] = 210
+	h.Fix(0)
+	h.verify(t, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.Len())
+		if i&1 == 0 {
+			h.s[elem] *= 2
+		} else {
+			h.s[elem] /= 2
+		}
+		h.Fix(elem)
+		h.verify(t, 0)
+	}
+}
+
+type indexed struct {
+	value int
+	index int
+}
+
+func TestSetIndex(t *testing.T) {
+	h := New(func(a, b *indexed) bool { return a.value < b.value })
+	h.SetIndex(func(x *indexed, i int) { x.index = i })
+	check := func() {
+		t.Helper()
+		for i, x := range h.s {
+			if x.index != i {
+				t.Fatalf("element %d has index %d", i, x.index)
+			}
+		}
+	}
+
+	var items []*indexed
+	for i := range 20 {
+		items = append(items, &indexed{value: rand.Intn(100)})
+		if i < 10 {
+			h.Push(items[i])
+			check()
+		}
+	}
+	h.Init(slices.Clone(items))
+	check()
+
+	for _, x := range items[:5] {
+		x.value = rand.Intn(100)
+		h.Fix(x.index)
+		h.verify(t, 0)
+		check()
+	}
+	removed := items[3]
+	if got := h.Remove(removed.index); got != removed {
+		t.Errorf("Remove returned %v, want %v", got, removed)
+	}
+	if removed.index != -1 {
+		t.Errorf("removed element has index %d, want -1", removed.index)
+	}
+	check()
+	x := h.Pop()
+	if x.index != -1 {
+		t.Errorf("popped element has index %d, want -1", x.index)
+	}
+	check()
+	h.Clear()
+	if h.Len() != 0 {
+		t.Errorf("Len after Clear = %d, want 0", h.Len())
+	}
+	for _, x := range items {
+		if x.index != -1 {
+			t.Errorf("element %v still has an index after Clear", x)
+		}
+	}
+}
+
+func TestAllAndDrain(t *testing.T) {
+	h := newIntHeap()
+	want := rand.Perm(50)
+	for _, v := range want {
+		h.Push(v)
+	}
+	got := slices.Sorted(h.All())
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("All = %v, want %v", got, want)
+	}
+
+	var drained []int
+	for v := range h.Drain() {
+		drained = append(drained, v)
+		if len(drained) == 10 {
+			break
+		}
+	}
+	if !slices.Equal(drained, want[:10]) {
+		t.Errorf("first 10 values of Drain = %v, want %v", drained, want[:10])
+	}
+	if h.Len() != 40 {
+		t.Errorf("Len after stopping Drain = %d, want 40", h.Len())
+	}
+	drained = slices.AppendSeq(drained, h.Drain())
+	if !slices.Equal(drained, want) || h.Len() != 0 {
+		t.Errorf("Drain = %v, want %v", drained, want)
+	}
+}
+
+func BenchmarkDup(b *testing.B) {
+	const n = 10000
+	h := New(func(a, b int) bool { return cmp.Less(a, b) })
+	h.Init(make([]int, 0, n))
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			h.Push(0) // all elements are the same
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
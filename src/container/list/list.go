@@ -9,6 +9,9 @@
 //	for e := l.Front(); e != nil; e = e.Next() {
 //		// do something with e.Value
 //	}
+//
+// Package [container/list/v2] provides the same list type with a type
+// parameter for the element values, avoiding the need for type assertions.
 package list
 
 // Element is an element of a linked list.
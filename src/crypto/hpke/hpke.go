@@ -0,0 +1,289 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hpke implements Hybrid Public Key Encryption (HPKE) as defined in
+// [RFC 9180].
+//
+// An HPKE ciphersuite is the combination of a [KEM], a [KDF], and an [AEAD].
+// The supported KEMs are DHKEM over X25519, P-256, P-384, and P-521 (see
+// [DHKEM]), and the post-quantum ML-KEM-768 and MLKEM768-X25519 (a.k.a.
+// X-Wing) KEMs from draft-ietf-hpke-pq (see [MLKEM768] and [MLKEM768X25519]).
+//
+// The base mode is implemented by [NewSender] and [NewRecipient], and the
+// pre-shared key mode by [NewSenderWithPSK] and [NewRecipientWithPSK]. The
+// authenticated modes are not supported.
+//
+// [RFC 9180]: https://www.rfc-editor.org/rfc/rfc9180.html
+package hpke
+
+import (
+	"crypto/cipher"
+	"errors"
+	"internal/byteorder"
+)
+
+// Modes from RFC 9180, Section 5.
+const (
+	modeBase = 0x00
+	modePSK  = 0x01
+)
+
+type context struct {
+	suiteID []byte
+
+	export func(string, uint16) ([]byte, error)
+
+	aead      cipher.AEAD
+	baseNonce []byte
+	// seqNum starts at zero and is incremented for each Seal/Open call.
+	// 64 bits are enough not to overflow for 500 years at 1ns per operation.
+	seqNum uint64
+}
+
+// Sender is a sending HPKE context. It is instantiated with a specific KEM
+// encapsulation key (i.e. the public key), and it is stateful, incrementing the
+// nonce counter for each [Sender.Seal] call.
+type Sender struct {
+	*context
+}
+
+// Recipient is a receiving HPKE context. It is instantiated with a specific KEM
+// decapsulation key (i.e. the secret key), and it is stateful, incrementing the
+// nonce counter for each successful [Recipient.Open] call.
+type Recipient struct {
+	*context
+}
+
+// checkPSK implements VerifyPSKInputs from RFC 9180, Section 5.1.
+func checkPSK(psk, pskID []byte) error {
+	if len(pskID) == 0 {
+		return errors.New("hpke: empty PSK ID")
+	}
+	// RFC 9180, Section 5.1.2 requires the PSK to have at least 32 bytes of
+	// entropy. We can't check that, but we can reject PSKs that are too short
+	// to possibly meet the requirement.
+	if len(psk) < 32 {
+		return errors.New("hpke: PSK shorter than 32 bytes")
+	}
+	return nil
+}
+
+// newContext implements KeySchedule from RFC 9180, Section 5.1. psk and
+// pskID must be empty in the base mode.
+func newContext(mode byte, sharedSecret []byte, kemID uint16, kdf KDF, aead AEAD, info, psk, pskID []byte) (*context, error) {
+	sid := suiteID(kemID, kdf.ID(), aead.ID())
+
+	pskIDHash, err := kdf.labeledExtract(sid, nil, "psk_id_hash", pskID)
+	if err != nil {
+		return nil, err
+	}
+	infoHash, err := kdf.labeledExtract(sid, nil, "info_hash", info)
+	if err != nil {
+		return nil, err
+	}
+	ksContext := append([]byte{mode}, pskIDHash...)
+	ksContext = append(ksContext, infoHash...)
+
+	secret, err := kdf.labeledExtract(sid, sharedSecret, "secret", psk)
+	if err != nil {
+		return nil, err
+	}
+	key, err := kdf.labeledExpand(sid, secret, "key", ksContext, uint16(aead.keySize()))
+	if err != nil {
+		return nil, err
+	}
+	a, err := aead.aead(key)
+	if err != nil {
+		return nil, err
+	}
+	baseNonce, err := kdf.labeledExpand(sid, secret, "base_nonce", ksContext, uint16(aead.nonceSize()))
+	if err != nil {
+		return nil, err
+	}
+	expSecret, err := kdf.labeledExpand(sid, secret, "exp", ksContext, uint16(kdf.size()))
+	if err != nil {
+		return nil, err
+	}
+	export := func(exporterContext string, length uint16) ([]byte, error) {
+		return kdf.labeledExpand(sid, expSecret, "sec", []byte(exporterContext), length)
+	}
+
+	return &context{
+		aead:      a,
+		suiteID:   sid,
+		export:    export,
+		baseNonce: baseNonce,
+	}, nil
+}
+
+// NewSender returns a sending HPKE context for the provided KEM encapsulation
+// key (i.e. the public key), and using the ciphersuite defined by the
+// combination of KEM, KDF, and AEAD.
+//
+// The info parameter is additional public information that must match between
+// sender and recipient.
+//
+// The returned enc ciphertext can be used to instantiate a matching receiving
+// HPKE context with the corresponding KEM decapsulation key.
+func NewSender(pk PublicKey, kdf KDF, aead AEAD, info []byte) (enc []byte, s *Sender, err error) {
+	return newSender(modeBase, pk, kdf, aead, info, nil, nil)
+}
+
+// NewSenderWithPSK is like [NewSender], but it uses the PSK mode of HPKE,
+// additionally authenticating the sender as a holder of the pre-shared key psk
+// identified by pskID.
+//
+// psk must be at least 32 bytes long and have at least 32 bytes of entropy,
+// and pskID must not be empty. Both must match between sender and recipient.
+func NewSenderWithPSK(pk PublicKey, kdf KDF, aead AEAD, info, psk, pskID []byte) (enc []byte, s *Sender, err error) {
+	if err := checkPSK(psk, pskID); err != nil {
+		return nil, nil, err
+	}
+	return newSender(modePSK, pk, kdf, aead, info, psk, pskID)
+}
+
+func newSender(mode byte, pk PublicKey, kdf KDF, aead AEAD, info, psk, pskID []byte) ([]byte, *Sender, error) {
+	sharedSecret, encapsulatedKey, err := pk.encap()
+	if err != nil {
+		return nil, nil, err
+	}
+	context, err := newContext(mode, sharedSecret, pk.KEM().ID(), kdf, aead, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encapsulatedKey, &Sender{context}, nil
+}
+
+// NewRecipient returns a receiving HPKE context for the provided KEM
+// decapsulation key (i.e. the secret key), and using the ciphersuite defined by
+// the combination of KEM, KDF, and AEAD.
+//
+// The enc parameter must have been produced by a matching sending HPKE context
+// with the corresponding KEM encapsulation key. The info parameter is
+// additional public information that must match between sender and recipient.
+func NewRecipient(enc []byte, k PrivateKey, kdf KDF, aead AEAD, info []byte) (*Recipient, error) {
+	return newRecipient(modeBase, enc, k, kdf, aead, info, nil, nil)
+}
+
+// NewRecipientWithPSK is like [NewRecipient], but it uses the PSK mode of
+// HPKE. The enc parameter must have been produced by [NewSenderWithPSK] with
+// the same psk and pskID.
+//
+// psk must be at least 32 bytes long and pskID must not be empty.
+func NewRecipientWithPSK(enc []byte, k PrivateKey, kdf KDF, aead AEAD, info, psk, pskID []byte) (*Recipient, error) {
+	if err := checkPSK(psk, pskID); err != nil {
+		return nil, err
+	}
+	return newRecipient(modePSK, enc, k, kdf, aead, info, psk, pskID)
+}
+
+func newRecipient(mode byte, enc []byte, k PrivateKey, kdf KDF, aead AEAD, info, psk, pskID []byte) (*Recipient, error) {
+	sharedSecret, err := k.decap(enc)
+	if err != nil {
+		return nil, err
+	}
+	context, err := newContext(mode, sharedSecret, k.KEM().ID(), kdf, aead, info, psk, pskID)
+	if err != nil {
+		return nil, err
+	}
+	return &Recipient{context}, nil
+}
+
+// Seal encrypts the provided plaintext, optionally binding to the additional
+// public data aad.
+//
+// Seal uses incrementing counters for each call, and Open on the receiving side
+// must be called in the same order as Seal.
+func (s *Sender) Seal(aad, plaintext []byte) ([]byte, error) {
+	if s.aead == nil {
+		return nil, errors.New("hpke: export-only instantiation")
+	}
+	ciphertext := s.aead.Seal(nil, s.nextNonce(), plaintext, aad)
+	s.seqNum++
+	return ciphertext, nil
+}
+
+// Seal instantiates a single-use HPKE sending HPKE context like [NewSender],
+// and then encrypts the provided plaintext like [Sender.Seal] (with no aad).
+// Seal returns the concatenation of the encapsulated key and the ciphertext.
+func Seal(pk PublicKey, kdf KDF, aead AEAD, info, plaintext []byte) ([]byte, error) {
+	enc, s, err := NewSender(pk, kdf, aead, info)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := s.Seal(nil, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return append(enc, ct...), nil
+}
+
+// Export produces a secret value derived from the shared key between sender and
+// recipient. length must be at most 65,535.
+func (s *Sender) Export(exporterContext string, length int) ([]byte, error) {
+	if length < 0 || length > 0xFFFF {
+		return nil, errors.New("hpke: invalid length")
+	}
+	return s.export(exporterContext, uint16(length))
+}
+
+// Open decrypts the provided ciphertext, optionally binding to the additional
+// public data aad, or returns an error if decryption fails.
+//
+// Open uses incrementing counters for each successful call, and must be called
+// in the same order as Seal on the sending side.
+func (r *Recipient) Open(aad, ciphertext []byte) ([]byte, error) {
+	if r.aead == nil {
+		return nil, errors.New("hpke: export-only instantiation")
+	}
+	plaintext, err := r.aead.Open(nil, r.nextNonce(), ciphertext, aad)
+	if err != nil {
+		return nil, err
+	}
+	r.seqNum++
+	return plaintext, nil
+}
+
+// Open instantiates a single-use HPKE receiving HPKE context like [NewRecipient],
+// and then decrypts the provided ciphertext like [Recipient.Open] (with no aad).
+// ciphertext must be the concatenation of the encapsulated key and the actual ciphertext.
+func Open(k PrivateKey, kdf KDF, aead AEAD, info, ciphertext []byte) ([]byte, error) {
+	encSize := k.KEM().encSize()
+	if len(ciphertext) < encSize {
+		return nil, errors.New("hpke: ciphertext too short")
+	}
+	enc, ciphertext := ciphertext[:encSize], ciphertext[encSize:]
+	r, err := NewRecipient(enc, k, kdf, aead, info)
+	if err != nil {
+		return nil, err
+	}
+	return r.Open(nil, ciphertext)
+}
+
+// Export produces a secret value derived from the shared key between sender and
+// recipient. length must be at most 65,535.
+func (r *Recipient) Export(exporterContext string, length int) ([]byte, error) {
+	if length < 0 || length > 0xFFFF {
+		return nil, errors.New("hpke: invalid length")
+	}
+	return r.export(exporterContext, uint16(length))
+}
+
+func (ctx *context) nextNonce() []byte {
+	nonce := make([]byte, ctx.aead.NonceSize())
+	byteorder.BEPutUint64(nonce[len(nonce)-8:], ctx.seqNum)
+	for i := range ctx.baseNonce {
+		nonce[i] ^= ctx.baseNonce[i]
+	}
+	return nonce
+}
+
+func suiteID(kemID, kdfID, aeadID uint16) []byte {
+	suiteID := make([]byte, 0, 4+2+2+2)
+	suiteID = append(suiteID, []byte("HPKE")...)
+	suiteID = byteorder.BEAppendUint16(suiteID, kemID)
+	suiteID = byteorder.BEAppendUint16(suiteID, kdfID)
+	suiteID = byteorder.BEAppendUint16(suiteID, aeadID)
+	return suiteID
+}
@@ -0,0 +1,350 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha3"
+	"errors"
+	"internal/byteorder"
+)
+
+// testingOnlyEncapsulate is only used during testing, to provide a fixed
+// ML-KEM encapsulation when checking the draft-ietf-hpke-pq vectors.
+var testingOnlyEncapsulate func() (ss, ct []byte)
+
+type hybridKEM struct {
+	id    uint16
+	label string
+	curve ecdh.Curve
+
+	curveSeedSize  int
+	curvePointSize int
+}
+
+var mlkem768X25519 = &hybridKEM{
+	id: 0x647a,
+	label: /**/ `\./` +
+		/*   */ `/^\`,
+	curve: ecdh.X25519(),
+
+	curveSeedSize:  32,
+	curvePointSize: 32,
+}
+
+// MLKEM768X25519 returns a KEM implementing MLKEM768-X25519 (a.k.a. X-Wing)
+// from draft-ietf-hpke-pq.
+func MLKEM768X25519() KEM {
+	return mlkem768X25519
+}
+
+func (kem *hybridKEM) ID() uint16 {
+	return kem.id
+}
+
+func (kem *hybridKEM) encSize() int {
+	return mlkem.CiphertextSize768 + kem.curvePointSize
+}
+
+func (kem *hybridKEM) sharedSecret(ssPQ, ssT, ctT, ekT []byte) []byte {
+	h := sha3.New256()
+	h.Write(ssPQ)
+	h.Write(ssT)
+	h.Write(ctT)
+	h.Write(ekT)
+	h.Write([]byte(kem.label))
+	return h.Sum(nil)
+}
+
+type hybridPublicKey struct {
+	kem *hybridKEM
+	t   *ecdh.PublicKey
+	pq  *mlkem.EncapsulationKey768
+}
+
+// NewHybridPublicKey returns a PublicKey implementing MLKEM768-X25519 (a.k.a.
+// X-Wing) from draft-ietf-hpke-pq. t must be an [ecdh.X25519] public key.
+//
+// This function is meant for applications that already have instantiated
+// crypto/ecdh and crypto/mlkem public keys. Otherwise, applications should use
+// the [KEM.NewPublicKey] method of [MLKEM768X25519].
+func NewHybridPublicKey(pq *mlkem.EncapsulationKey768, t *ecdh.PublicKey) (PublicKey, error) {
+	if t.Curve() != ecdh.X25519() {
+		return nil, errors.New("hpke: unsupported curve")
+	}
+	return &hybridPublicKey{mlkem768X25519, t, pq}, nil
+}
+
+func (kem *hybridKEM) NewPublicKey(data []byte) (PublicKey, error) {
+	if len(data) != mlkem.EncapsulationKeySize768+kem.curvePointSize {
+		return nil, errors.New("hpke: invalid public key size")
+	}
+	pq, err := mlkem.NewEncapsulationKey768(data[:mlkem.EncapsulationKeySize768])
+	if err != nil {
+		return nil, err
+	}
+	k, err := kem.curve.NewPublicKey(data[mlkem.EncapsulationKeySize768:])
+	if err != nil {
+		return nil, err
+	}
+	return NewHybridPublicKey(pq, k)
+}
+
+func (pk *hybridPublicKey) KEM() KEM {
+	return pk.kem
+}
+
+func (pk *hybridPublicKey) Bytes() []byte {
+	return append(pk.pq.Bytes(), pk.t.Bytes()...)
+}
+
+func (pk *hybridPublicKey) encap() (sharedSecret []byte, encapPub []byte, err error) {
+	skE, err := pk.t.Curve().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if testingOnlyGenerateKey != nil {
+		skE = testingOnlyGenerateKey()
+	}
+	ssT, err := skE.ECDH(pk.t)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctT := skE.PublicKey().Bytes()
+
+	ctPQ, ssPQ := pk.pq.Encapsulate()
+	if testingOnlyEncapsulate != nil {
+		ssPQ, ctPQ = testingOnlyEncapsulate()
+	}
+
+	ss := pk.kem.sharedSecret(ssPQ, ssT, ctT, pk.t.Bytes())
+	ct := append(ctPQ, ctT...)
+	return ss, ct, nil
+}
+
+type hybridPrivateKey struct {
+	kem  *hybridKEM
+	seed []byte // can be nil
+	t    *ecdh.PrivateKey
+	pq   *mlkem.DecapsulationKey768
+}
+
+// NewHybridPrivateKey returns a PrivateKey implementing MLKEM768-X25519
+// (a.k.a. X-Wing) from draft-ietf-hpke-pq. t must be an [ecdh.X25519] private
+// key.
+//
+// This function is meant for applications that already have instantiated
+// crypto/ecdh and crypto/mlkem private keys. Otherwise, applications should
+// use the [KEM.NewPrivateKey] method of [MLKEM768X25519].
+//
+// The [PrivateKey.Bytes] method of the returned key returns an error, since
+// the seed the two keys were derived from is not known.
+func NewHybridPrivateKey(pq *mlkem.DecapsulationKey768, t *ecdh.PrivateKey) (PrivateKey, error) {
+	return newHybridPrivateKey(pq, t, nil)
+}
+
+func (kem *hybridKEM) GenerateKey() (PrivateKey, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return kem.NewPrivateKey(seed)
+}
+
+func (kem *hybridKEM) NewPrivateKey(priv []byte) (PrivateKey, error) {
+	if len(priv) != 32 {
+		return nil, errors.New("hpke: invalid hybrid KEM secret length")
+	}
+
+	s := sha3.NewSHAKE256()
+	s.Write(priv)
+
+	seedPQ := make([]byte, mlkem.SeedSize)
+	s.Read(seedPQ)
+	pq, err := mlkem.NewDecapsulationKey768(seedPQ)
+	if err != nil {
+		return nil, err
+	}
+
+	seedT := make([]byte, kem.curveSeedSize)
+	for {
+		s.Read(seedT)
+		k, err := kem.curve.NewPrivateKey(seedT)
+		if err != nil {
+			continue
+		}
+		return newHybridPrivateKey(pq, k, priv)
+	}
+}
+
+func newHybridPrivateKey(pq *mlkem.DecapsulationKey768, t *ecdh.PrivateKey, seed []byte) (PrivateKey, error) {
+	if t.Curve() != ecdh.X25519() {
+		return nil, errors.New("hpke: unsupported curve")
+	}
+	return &hybridPrivateKey{mlkem768X25519, bytes.Clone(seed), t, pq}, nil
+}
+
+func (kem *hybridKEM) DeriveKeyPair(ikm []byte) (PrivateKey, error) {
+	suiteID := byteorder.BEAppendUint16([]byte("KEM"), kem.id)
+	dk := shake256LabeledDerive(suiteID, ikm, "DeriveKeyPair", nil, 32)
+	return kem.NewPrivateKey(dk)
+}
+
+func (k *hybridPrivateKey) KEM() KEM {
+	return k.kem
+}
+
+func (k *hybridPrivateKey) Bytes() ([]byte, error) {
+	if k.seed == nil {
+		return nil, errors.New("hpke: private key seed not available")
+	}
+	return bytes.Clone(k.seed), nil
+}
+
+func (k *hybridPrivateKey) PublicKey() PublicKey {
+	return &hybridPublicKey{
+		kem: k.kem,
+		t:   k.t.PublicKey(),
+		pq:  k.pq.EncapsulationKey(),
+	}
+}
+
+func (k *hybridPrivateKey) decap(enc []byte) ([]byte, error) {
+	if len(enc) != mlkem.CiphertextSize768+k.kem.curvePointSize {
+		return nil, errors.New("hpke: invalid encapsulated key size")
+	}
+	ctPQ, ctT := enc[:mlkem.CiphertextSize768], enc[mlkem.CiphertextSize768:]
+	ssPQ, err := k.pq.Decapsulate(ctPQ)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.t.Curve().NewPublicKey(ctT)
+	if err != nil {
+		return nil, err
+	}
+	ssT, err := k.t.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+	ss := k.kem.sharedSecret(ssPQ, ssT, ctT, k.t.PublicKey().Bytes())
+	return ss, nil
+}
+
+type mlkemKEM struct {
+	id uint16
+}
+
+var mlkem768 = &mlkemKEM{id: 0x0041}
+
+// MLKEM768 returns a KEM implementing ML-KEM-768 from draft-ietf-hpke-pq.
+func MLKEM768() KEM {
+	return mlkem768
+}
+
+func (kem *mlkemKEM) ID() uint16 {
+	return kem.id
+}
+
+func (kem *mlkemKEM) encSize() int {
+	return mlkem.CiphertextSize768
+}
+
+type mlkemPublicKey struct {
+	kem *mlkemKEM
+	pq  *mlkem.EncapsulationKey768
+}
+
+// NewMLKEMPublicKey returns a PublicKey implementing ML-KEM-768 from
+// draft-ietf-hpke-pq.
+//
+// This function is meant for applications that already have an instantiated
+// crypto/mlkem public key. Otherwise, applications should use the
+// [KEM.NewPublicKey] method of [MLKEM768].
+func NewMLKEMPublicKey(pub *mlkem.EncapsulationKey768) (PublicKey, error) {
+	return &mlkemPublicKey{mlkem768, pub}, nil
+}
+
+func (kem *mlkemKEM) NewPublicKey(data []byte) (PublicKey, error) {
+	pq, err := mlkem.NewEncapsulationKey768(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewMLKEMPublicKey(pq)
+}
+
+func (pk *mlkemPublicKey) KEM() KEM {
+	return pk.kem
+}
+
+func (pk *mlkemPublicKey) Bytes() []byte {
+	return pk.pq.Bytes()
+}
+
+func (pk *mlkemPublicKey) encap() (sharedSecret []byte, encapPub []byte, err error) {
+	ct, ss := pk.pq.Encapsulate()
+	if testingOnlyEncapsulate != nil {
+		ss, ct = testingOnlyEncapsulate()
+	}
+	return ss, ct, nil
+}
+
+type mlkemPrivateKey struct {
+	kem *mlkemKEM
+	pq  *mlkem.DecapsulationKey768
+}
+
+// NewMLKEMPrivateKey returns a PrivateKey implementing ML-KEM-768 from
+// draft-ietf-hpke-pq.
+//
+// This function is meant for applications that already have an instantiated
+// crypto/mlkem private key. Otherwise, applications should use the
+// [KEM.NewPrivateKey] method of [MLKEM768].
+func NewMLKEMPrivateKey(priv *mlkem.DecapsulationKey768) (PrivateKey, error) {
+	return &mlkemPrivateKey{mlkem768, priv}, nil
+}
+
+func (kem *mlkemKEM) GenerateKey() (PrivateKey, error) {
+	pq, err := mlkem.GenerateKey768()
+	if err != nil {
+		return nil, err
+	}
+	return NewMLKEMPrivateKey(pq)
+}
+
+func (kem *mlkemKEM) NewPrivateKey(priv []byte) (PrivateKey, error) {
+	pq, err := mlkem.NewDecapsulationKey768(priv)
+	if err != nil {
+		return nil, err
+	}
+	return NewMLKEMPrivateKey(pq)
+}
+
+func (kem *mlkemKEM) DeriveKeyPair(ikm []byte) (PrivateKey, error) {
+	suiteID := byteorder.BEAppendUint16([]byte("KEM"), kem.id)
+	dk := shake256LabeledDerive(suiteID, ikm, "DeriveKeyPair", nil, mlkem.SeedSize)
+	return kem.NewPrivateKey(dk)
+}
+
+func (k *mlkemPrivateKey) KEM() KEM {
+	return k.kem
+}
+
+func (k *mlkemPrivateKey) Bytes() ([]byte, error) {
+	return k.pq.Bytes(), nil
+}
+
+func (k *mlkemPrivateKey) PublicKey() PublicKey {
+	return &mlkemPublicKey{
+		kem: k.kem,
+		pq:  k.pq.EncapsulationKey(),
+	}
+}
+
+func (k *mlkemPrivateKey) decap(enc []byte) ([]byte, error) {
+	return k.pq.Decapsulate(enc)
+}
@@ -24,6 +24,10 @@ func LEUint64(b []byte) uint64 {
 	return byteorder.LEUint64(b)
 }
 
+func LEPutUint16(b []byte, v uint16) {
+	byteorder.LEPutUint16(b, v)
+}
+
 func BEPutUint16(b []byte, v uint16) {
 	byteorder.BEPutUint16(b, v)
 }
@@ -23,6 +23,7 @@ import (
 	"crypto/internal/fips140/ed25519"
 	_ "crypto/internal/fips140/hkdf"
 	_ "crypto/internal/fips140/hmac"
+	"crypto/internal/fips140/mldsa"
 	"crypto/internal/fips140/mlkem"
 	"crypto/internal/fips140/rsa"
 	"crypto/internal/fips140/sha256"
@@ -73,6 +74,8 @@ func findAllCASTs(t *testing.T) map[string]struct{} {
 // TestConditionals causes the conditional CASTs and PCTs to be invoked.
 func TestConditionals(t *testing.T) {
 	mlkem.GenerateKey768()
+	kMLDSA := mldsa.GenerateKey44()
+	mldsa.SignDeterministic(kMLDSA, make([]byte, 32), "")
 	k, err := ecdh.GenerateKey(ecdh.P256(), rand.Reader)
 	if err != nil {
 		t.Fatal(err)
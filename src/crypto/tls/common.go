@@ -237,6 +237,11 @@ type ConnectionState struct {
 	Version uint16
 
 	// HandshakeComplete is true if the handshake has concluded.
+	//
+	// On a server that accepted TLS 1.3 early data, [Conn.Handshake] returns
+	// before the client's Finished message is verified, and HandshakeComplete
+	// stays false until [Conn.Read] has read the early data and the rest of the
+	// client's flight. Until then, the client is not authenticated.
 	HandshakeComplete bool
 
 	// DidResume is true if this connection was successfully resumed from a
@@ -299,6 +304,11 @@ type ConnectionState struct {
 	// client side.
 	ECHAccepted bool
 
+	// EarlyDataAccepted indicates if TLS 1.3 early data (0-RTT) was offered by
+	// the client and accepted by the server. See [Conn.SetEarlyData] and
+	// [Config.MaxEarlyData].
+	EarlyDataAccepted bool
+
 	// ekm is a closure exposed via ExportKeyingMaterial.
 	ekm func(label string, context []byte, length int) ([]byte, error)
 
@@ -479,6 +489,24 @@ func (c *ClientHelloInfo) Context() context.Context {
 	return c.ctx
 }
 
+// EarlyDataInfo contains information about a client's attempt to send TLS 1.3
+// early data (0-RTT), which is used by [Config.AcceptEarlyData].
+type EarlyDataInfo struct {
+	// ClientHello is the ClientHello offering early data.
+	ClientHello *ClientHelloInfo
+
+	// Session is the session being resumed.
+	Session *SessionState
+
+	// Identity is the PSK identity (the session ticket) selected by the server.
+	Identity []byte
+
+	// Binder is the PSK binder from the ClientHello. It is computed over the
+	// whole ClientHello, including its random value, so a replayed ClientHello
+	// carries the same Binder as the original. See RFC 8446, Section 8.2.
+	Binder []byte
+}
+
 // CertificateRequestInfo contains information from a server's
 // CertificateRequest message, which is used to demand a certificate and proof
 // of control from a client.
@@ -753,6 +781,38 @@ type Config struct {
 	// depending on the protocol version.
 	WrapSession func(ConnectionState, *SessionState) ([]byte, error)
 
+	// MaxEarlyData is the maximum number of bytes of TLS 1.3 early data (0-RTT)
+	// that a server accepts when resuming a session, and that it advertises in
+	// the session tickets it issues. It is ignored by clients and by QUIC
+	// connections, which use [QUICSessionTicketOptions.EarlyData] instead.
+	//
+	// If MaxEarlyData is zero, or AcceptEarlyData is nil, the server issues
+	// tickets that can't be used for early data, and rejects early data that
+	// clients send anyway.
+	//
+	// Early data is not protected against replays: data read with [Conn.Read]
+	// before the client's EndOfEarlyData message, while [Conn.InEarlyData]
+	// reports true, may have been replayed by an attacker. See AcceptEarlyData.
+	MaxEarlyData uint32
+
+	// AcceptEarlyData, if not nil, is called on the server when a client
+	// offers TLS 1.3 early data that would otherwise be accepted, including on
+	// QUIC connections. If it returns false, the early data is rejected and the
+	// handshake continues as a regular resumption.
+	//
+	// An attacker can replay early data, causing the server to process it
+	// multiple times. Any data read before the client's EndOfEarlyData message,
+	// while [Conn.InEarlyData] reports true, may have been replayed, even if
+	// AcceptEarlyData returned true. Servers that act on early data in a
+	// non-idempotent way should use AcceptEarlyData to implement one of the
+	// anti-replay mechanisms of RFC 8446, Section 8, for example by accepting
+	// each [EarlyDataInfo.Binder] only once.
+	//
+	// If AcceptEarlyData is nil, early data is always rejected on TCP
+	// connections, and always accepted on QUIC connections, where
+	// [QUICSessionTicketOptions.EarlyData] controls whether tickets allow it.
+	AcceptEarlyData func(*EarlyDataInfo) bool
+
 	// MinVersion contains the minimum TLS version that is acceptable.
 	//
 	// By default, TLS 1.2 is currently used as the minimum. TLS 1.0 is the
@@ -949,6 +1009,8 @@ func (c *Config) Clone() *Config {
 		ClientSessionCache:                  c.ClientSessionCache,
 		UnwrapSession:                       c.UnwrapSession,
 		WrapSession:                         c.WrapSession,
+		MaxEarlyData:                        c.MaxEarlyData,
+		AcceptEarlyData:                     c.AcceptEarlyData,
 		MinVersion:                          c.MinVersion,
 		MaxVersion:                          c.MaxVersion,
 		CurvePreferences:                    c.CurvePreferences,
@@ -1500,6 +1562,7 @@ func (c *Config) BuildNameToCertificate() {
 
 const (
 	keyLogLabelTLS12           = "CLIENT_RANDOM"
+	keyLogLabelClientEarly     = "CLIENT_EARLY_TRAFFIC_SECRET"
 	keyLogLabelClientHandshake = "CLIENT_HANDSHAKE_TRAFFIC_SECRET"
 	keyLogLabelServerHandshake = "SERVER_HANDSHAKE_TRAFFIC_SECRET"
 	keyLogLabelClientTraffic   = "CLIENT_TRAFFIC_SECRET_0"
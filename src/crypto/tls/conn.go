@@ -36,6 +36,12 @@ type Conn struct {
 	// application data (i.e. is not currently processing a handshake).
 	// isHandshakeComplete is true implies handshakeErr == nil.
 	isHandshakeComplete atomic.Bool
+	// isEarlyDataHandshake is true if a server that accepted TLS 1.3 early
+	// data returned from the handshake before the client's Finished message
+	// was verified. It is reset once isHandshakeComplete is set. Meanwhile,
+	// the server can write application data and read early data, but the
+	// client is not authenticated.
+	isEarlyDataHandshake atomic.Bool
 	// constant after handshake; protected by handshakeMutex
 	handshakeMutex sync.Mutex
 	handshakeErr   error   // error resulting from handshake
@@ -73,6 +79,24 @@ type Conn struct {
 	resumptionSecret []byte
 	echAccepted      bool
 
+	// earlyData is the TLS 1.3 early data to send as a client, set by
+	// SetEarlyData. earlyDataAccepted is true if the server accepted it.
+	earlyData         []byte
+	earlyDataAccepted bool
+	// earlyDataHandshake is the server handshake waiting for the client's
+	// EndOfEarlyData message while inEarlyData is true. It is completed by
+	// handlePostHandshakeMessage.
+	earlyDataHandshake *serverHandshakeStateTLS13
+	// inEarlyData is true while the server reads early data, until the
+	// client's EndOfEarlyData message.
+	inEarlyData atomic.Bool
+	// earlyDataLeft is the number of bytes of early data the server can still
+	// read if inEarlyData is true, or skip if skipEarlyData is true.
+	earlyDataLeft int
+	// skipEarlyData is true if the server rejected early data and is ignoring
+	// records that fail to decrypt. See RFC 8446, Section 4.2.10.
+	skipEarlyData bool
+
 	// ticketKeys is the set of active session ticket keys for this
 	// connection. The first one is used to encrypt new tickets and
 	// all are tried to decrypt tickets.
@@ -613,7 +637,9 @@ func (c *Conn) readRecordOrCCS(expectChangeCipherSpec bool) error {
 	if c.in.err != nil {
 		return c.in.err
 	}
-	handshakeComplete := c.isHandshakeComplete.Load()
+	// Early data is application data, but it is read before the handshake
+	// is complete.
+	handshakeComplete := c.isHandshakeComplete.Load() || c.inEarlyData.Load()
 
 	// This function modifies c.rawInput, which owns the c.input memory.
 	if c.input.Len() != 0 {
@@ -686,10 +712,20 @@ func (c *Conn) readRecordOrCCS(expectChangeCipherSpec bool) error {
 
 	// Process message.
 	record := c.rawInput.Next(recordHeaderLen + n)
+	if c.skipEarlyData && c.in.cipher == nil && typ == recordTypeApplicationData {
+		// Early data sent before a HelloRetryRequest, see skipEarlyDataRecord.
+		return c.skipEarlyDataRecord(expectChangeCipherSpec, n)
+	}
 	data, typ, err := c.in.decrypt(record)
 	if err != nil {
+		if c.skipEarlyData && c.in.cipher != nil {
+			return c.skipEarlyDataRecord(expectChangeCipherSpec, n)
+		}
 		return c.in.setErrorLocked(c.sendAlert(err.(alert)))
 	}
+	if c.skipEarlyData && typ != recordTypeChangeCipherSpec {
+		c.skipEarlyData = false
+	}
 	if len(data) > maxPlaintext {
 		return c.in.setErrorLocked(c.sendAlert(alertRecordOverflow))
 	}
@@ -768,6 +804,13 @@ func (c *Conn) readRecordOrCCS(expectChangeCipherSpec bool) error {
 		if len(data) == 0 {
 			return c.retryReadRecord(expectChangeCipherSpec)
 		}
+		if c.inEarlyData.Load() {
+			c.earlyDataLeft -= len(data)
+			if c.earlyDataLeft < 0 {
+				c.sendAlert(alertUnexpectedMessage)
+				return c.in.setErrorLocked(errors.New("tls: client sent too much early data"))
+			}
+		}
 		// Note that data is owned by c.rawInput, following the Next call above,
 		// to avoid copying the plaintext. This is safe because c.rawInput is
 		// not read from or written to until c.input is drained.
@@ -794,6 +837,21 @@ func (c *Conn) retryReadRecord(expectChangeCipherSpec bool) error {
 	return c.readRecordOrCCS(expectChangeCipherSpec)
 }
 
+// skipEarlyDataRecord drops a record of n bytes carrying early data that the
+// server rejected, and recurs into readRecordOrCCS. The client can send up to
+// the max_early_data_size advertised in the ticket, which is assumed to be the
+// server's current MaxEarlyData. See RFC 8446, Section 4.2.10.
+func (c *Conn) skipEarlyDataRecord(expectChangeCipherSpec bool, n int) error {
+	// Don't count the inner content type and the AEAD tag, but make sure every
+	// record consumes some of the allowance.
+	c.earlyDataLeft -= max(n-1-16, 1)
+	if c.earlyDataLeft < 0 {
+		c.sendAlert(alertUnexpectedMessage)
+		return c.in.setErrorLocked(errors.New("tls: client sent too much rejected early data"))
+	}
+	return c.readRecordOrCCS(expectChangeCipherSpec)
+}
+
 // atLeastReader reads from R, stopping with EOF once at least N bytes have been
 // read. It is different from an io.LimitedReader in that it doesn't cut short
 // the last Read call, and in that it considers an early EOF an error.
@@ -1217,7 +1275,9 @@ func (c *Conn) Write(b []byte) (int, error) {
 		return 0, err
 	}
 
-	if !c.isHandshakeComplete.Load() {
+	// A server that accepted early data can send application data before
+	// the client's Finished message. See RFC 8446, Section 4.6.
+	if !c.isHandshakeComplete.Load() && !c.isEarlyDataHandshake.Load() {
 		return 0, alertInternalError
 	}
 
@@ -1301,6 +1361,14 @@ func (c *Conn) handlePostHandshakeMessage() error {
 		return c.handleRenegotiation()
 	}
 
+	if hs := c.earlyDataHandshake; hs != nil {
+		c.earlyDataHandshake = nil
+		if err := hs.readEndOfEarlyData(); err != nil {
+			return c.in.setErrorLocked(err)
+		}
+		return nil
+	}
+
 	msg, err := c.readHandshake(nil)
 	if err != nil {
 		return err
@@ -1362,6 +1430,44 @@ func (c *Conn) handleKeyUpdate(keyUpdate *keyUpdateMsg) error {
 	return nil
 }
 
+// SetEarlyData sets data to be sent by a client as TLS 1.3 early data (0-RTT)
+// right after the ClientHello. It must be called before the handshake.
+//
+// The data is sent only when resuming a TLS 1.3 session from
+// [Config.ClientSessionCache] whose server advertised that it accepts at least
+// len(data) bytes of early data, and only if [Config.EncryptedClientHelloConfigList]
+// is not set. After the handshake, [ConnectionState.EarlyDataAccepted] reports
+// whether the server accepted the data. If it didn't, the data was not
+// processed, and the application is responsible for sending it again with
+// [Conn.Write] if necessary.
+//
+// Early data is not forward secret, and can be replayed by an attacker to the
+// server, so it should only carry idempotent requests.
+func (c *Conn) SetEarlyData(data []byte) error {
+	if !c.isClient {
+		return errors.New("tls: SetEarlyData called on a server connection")
+	}
+
+	c.handshakeMutex.Lock()
+	defer c.handshakeMutex.Unlock()
+
+	if c.handshakes > 0 || c.handshakeErr != nil || c.isHandshakeComplete.Load() {
+		return errors.New("tls: SetEarlyData called after the handshake")
+	}
+	c.earlyData = bytes.Clone(data)
+	return nil
+}
+
+// InEarlyData reports whether a server is reading TLS 1.3 early data (0-RTT)
+// from the client. While InEarlyData returns true, the data returned by
+// [Conn.Read] was sent by the client before the handshake completed, so it may
+// have been replayed by an attacker, and the client's Finished message has not
+// been verified yet. A single Read call never returns both early data and data
+// sent after the handshake.
+func (c *Conn) InEarlyData() bool {
+	return c.inEarlyData.Load()
+}
+
 // Read reads data from the connection.
 //
 // As Read calls [Conn.Handshake], in order to prevent indefinite blocking a deadline
@@ -1435,7 +1541,7 @@ func (c *Conn) Close() error {
 	}
 
 	var alertErr error
-	if c.isHandshakeComplete.Load() {
+	if c.isHandshakeComplete.Load() || c.isEarlyDataHandshake.Load() {
 		if err := c.closeNotify(); err != nil {
 			alertErr = fmt.Errorf("tls: failed to send closeNotify alert (but connection was closed anyway): %w", err)
 		}
@@ -1453,7 +1559,7 @@ var errEarlyCloseWrite = errors.New("tls: CloseWrite called before handshake com
 // called once the handshake has completed and does not call CloseWrite on the
 // underlying connection. Most callers should just use [Conn.Close].
 func (c *Conn) CloseWrite() error {
-	if !c.isHandshakeComplete.Load() {
+	if !c.isHandshakeComplete.Load() && !c.isEarlyDataHandshake.Load() {
 		return errEarlyCloseWrite
 	}
 
@@ -1488,6 +1594,11 @@ func (c *Conn) closeNotify() error {
 // in certificates sent by either the TLS server or client is limited to 8192
 // bits. This limit can be overridden by setting tlsmaxrsasize in the GODEBUG
 // environment variable (e.g. GODEBUG=tlsmaxrsasize=4096).
+//
+// A server that accepts TLS 1.3 early data returns from Handshake before
+// the client has finished the handshake, while [Conn.InEarlyData] reports
+// true. Until then, the client is not authenticated, and
+// [ConnectionState.HandshakeComplete] is false.
 func (c *Conn) Handshake() error {
 	return c.HandshakeContext(context.Background())
 }
@@ -1511,8 +1622,9 @@ func (c *Conn) HandshakeContext(ctx context.Context) error {
 func (c *Conn) handshakeContext(ctx context.Context) (ret error) {
 	// Fast sync/atomic-based exit if there is no handshake in flight and the
 	// last one succeeded without an error. Avoids the expensive context setup
-	// and mutex for most Read and Write calls.
-	if c.isHandshakeComplete.Load() {
+	// and mutex for most Read and Write calls. A server that accepted early
+	// data completes the handshake in Read, see handlePostHandshakeMessage.
+	if c.isHandshakeComplete.Load() || c.isEarlyDataHandshake.Load() {
 		return nil
 	}
 
@@ -1558,7 +1670,7 @@ func (c *Conn) handshakeContext(ctx context.Context) (ret error) {
 	if err := c.handshakeErr; err != nil {
 		return err
 	}
-	if c.isHandshakeComplete.Load() {
+	if c.isHandshakeComplete.Load() || c.isEarlyDataHandshake.Load() {
 		return nil
 	}
 
@@ -1574,10 +1686,10 @@ func (c *Conn) handshakeContext(ctx context.Context) (ret error) {
 		c.flush()
 	}
 
-	if c.handshakeErr == nil && !c.isHandshakeComplete.Load() {
+	if c.handshakeErr == nil && !c.isHandshakeComplete.Load() && !c.isEarlyDataHandshake.Load() {
 		c.handshakeErr = errors.New("tls: internal error: handshake should have had a result")
 	}
-	if c.handshakeErr != nil && c.isHandshakeComplete.Load() {
+	if c.handshakeErr != nil && (c.isHandshakeComplete.Load() || c.isEarlyDataHandshake.Load()) {
 		panic("tls: internal error: handshake returned an error but is marked successful")
 	}
 
@@ -1654,6 +1766,7 @@ func (c *Conn) connectionStateLocked() ConnectionState {
 		state.ekm = c.ekm
 	}
 	state.ECHAccepted = c.echAccepted
+	state.EarlyDataAccepted = c.earlyDataAccepted
 	return state
 }
 
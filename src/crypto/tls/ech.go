@@ -6,7 +6,8 @@ package tls
 
 import (
 	"bytes"
-	"crypto/internal/hpke"
+	"crypto/ecdh"
+	"crypto/hpke"
 	"errors"
 	"fmt"
 	"slices"
@@ -15,16 +16,32 @@ import (
 	"golang.org/x/crypto/cryptobyte"
 )
 
-// sortedSupportedAEADs is just a sorted version of hpke.SupportedAEADS.
+// echKEM and echKDF are the only HPKE KEM and KDF we support for ECH.
+var (
+	echKEM = hpke.DHKEM(ecdh.X25519())
+	echKDF = hpke.HKDFSHA256()
+)
+
+// sortedSupportedAEADs is the sorted list of HPKE AEADs we support for ECH.
 // We need this so that when we insert them into ECHConfigs the ordering
 // is stable.
-var sortedSupportedAEADs []uint16
+var sortedSupportedAEADs = []uint16{
+	hpke.AES128GCM().ID(),
+	hpke.AES256GCM().ID(),
+	hpke.ChaCha20Poly1305().ID(),
+}
 
-func init() {
-	for aeadID := range hpke.SupportedAEADs {
-		sortedSupportedAEADs = append(sortedSupportedAEADs, aeadID)
+// echSuite returns the HPKE KDF and AEAD for the ECH cipher suite cs, or an
+// error if it is not supported.
+func echSuite(cs echCipher) (hpke.KDF, hpke.AEAD, error) {
+	if cs.KDFID != echKDF.ID() || !slices.Contains(sortedSupportedAEADs, cs.AEADID) {
+		return nil, nil, errors.New("tls: unsupported ECH cipher suite")
+	}
+	aead, err := hpke.NewAEAD(cs.AEADID)
+	if err != nil {
+		return nil, nil, err
 	}
-	slices.Sort(sortedSupportedAEADs)
+	return echKDF, aead, nil
 }
 
 type echCipher struct {
@@ -153,15 +170,12 @@ func parseECHConfigList(data []byte) ([]echConfig, error) {
 
 func pickECHConfig(list []echConfig) *echConfig {
 	for _, ec := range list {
-		if _, ok := hpke.SupportedKEMs[ec.KemID]; !ok {
+		if ec.KemID != echKEM.ID() {
 			continue
 		}
 		var validSCS bool
 		for _, cs := range ec.SymmetricCipherSuite {
-			if _, ok := hpke.SupportedAEADs[cs.AEADID]; !ok {
-				continue
-			}
-			if _, ok := hpke.SupportedKDFs[cs.KDFID]; !ok {
+			if _, _, err := echSuite(cs); err != nil {
 				continue
 			}
 			validSCS = true
@@ -195,10 +209,7 @@ func pickECHCipherSuite(suites []echCipher) (echCipher, error) {
 		// NOTE: all of the supported AEADs and KDFs are fine, rather than
 		// imposing some sort of preference here, we just pick the first valid
 		// suite.
-		if _, ok := hpke.SupportedAEADs[s.AEADID]; !ok {
-			continue
-		}
-		if _, ok := hpke.SupportedKDFs[s.KDFID]; !ok {
+		if _, _, err := echSuite(s); err != nil {
 			continue
 		}
 		return s, nil
@@ -388,7 +399,7 @@ func decodeInnerClientHello(outer *clientHelloMsg, encoded []byte) (*clientHello
 	return inner, nil
 }
 
-func decryptECHPayload(context *hpke.Receipient, hello, payload []byte) ([]byte, error) {
+func decryptECHPayload(context *hpke.Recipient, hello, payload []byte) ([]byte, error) {
 	outerAAD := bytes.Replace(hello[4:], payload, make([]byte, len(payload)), 1)
 	return context.Open(outerAAD, payload)
 }
@@ -570,13 +581,22 @@ func (c *Conn) processECHClientHello(outer *clientHelloMsg) (*clientHelloMsg, *e
 		if skip {
 			continue
 		}
-		echPriv, err := hpke.ParseHPKEPrivateKey(config.KemID, echKey.PrivateKey)
+		if config.KemID != echKEM.ID() {
+			c.sendAlert(alertInternalError)
+			return nil, nil, errors.New("tls: invalid EncryptedClientHelloKeys PrivateKey: unsupported KEM id")
+		}
+		echPriv, err := echKEM.NewPrivateKey(echKey.PrivateKey)
 		if err != nil {
 			c.sendAlert(alertInternalError)
 			return nil, nil, fmt.Errorf("tls: invalid EncryptedClientHelloKeys PrivateKey: %s", err)
 		}
+		kdf, aead, err := echSuite(echCiphersuite)
+		if err != nil {
+			// attempt next trial decryption
+			continue
+		}
 		info := append([]byte("tls ech\x00"), echKey.Config...)
-		hpkeContext, err := hpke.SetupReceipient(hpke.DHKEM_X25519_HKDF_SHA256, echCiphersuite.KDFID, echCiphersuite.AEADID, echPriv, info, encap)
+		hpkeContext, err := hpke.NewRecipient(encap, echPriv, kdf, aead, info)
 		if err != nil {
 			// attempt next trial decryption
 			continue
@@ -10,9 +10,9 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/hpke"
 	"crypto/internal/fips140/mlkem"
 	"crypto/internal/fips140/tls13"
-	"crypto/internal/hpke"
 	"crypto/rsa"
 	"crypto/subtle"
 	"crypto/tls/internal/fips140tls"
@@ -232,7 +232,7 @@ func (c *Conn) makeClientHello() (*clientHelloMsg, *keySharePrivateKeys, *echCli
 		hello.secureRenegotiationSupported = false
 		hello.extendedMasterSecret = false
 
-		echPK, err := hpke.ParseHPKEPublicKey(ech.config.KemID, ech.config.PublicKey)
+		echPK, err := echKEM.NewPublicKey(ech.config.PublicKey)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -240,9 +240,13 @@ func (c *Conn) makeClientHello() (*clientHelloMsg, *keySharePrivateKeys, *echCli
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		kdf, aead, err := echSuite(suite)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 		ech.kdfID, ech.aeadID = suite.KDFID, suite.AEADID
 		info := append([]byte("tls ech\x00"), ech.config.raw...)
-		ech.encapsulatedKey, ech.hpkeContext, err = hpke.SetupSender(ech.config.KemID, suite.KDFID, suite.AEADID, echPK, info)
+		ech.encapsulatedKey, ech.hpkeContext, err = hpke.NewSender(echPK, kdf, aead, info)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -332,7 +336,11 @@ func (c *Conn) clientHandshake(ctx context.Context) (err error) {
 			return err
 		}
 		earlyTrafficSecret := earlySecret.ClientEarlyTrafficSecret(transcript)
-		c.quicSetWriteSecret(QUICEncryptionLevelEarly, suite.id, earlyTrafficSecret)
+		if c.quic != nil {
+			c.quicSetWriteSecret(QUICEncryptionLevelEarly, suite.id, earlyTrafficSecret)
+		} else if err := c.writeEarlyData(suite, hello.random, earlyTrafficSecret); err != nil {
+			return err
+		}
 	}
 
 	// serverHelloMsg is not included in the transcript
@@ -351,6 +359,12 @@ func (c *Conn) clientHandshake(ctx context.Context) (err error) {
 		return err
 	}
 
+	if hello.earlyData && c.quic == nil && c.vers != VersionTLS13 {
+		// Don't send an alert, as the record layer is already using the early
+		// traffic keys, which the server would not understand.
+		return errors.New("tls: server selected TLS 1.2 or lower after early data was sent")
+	}
+
 	// If we are negotiating a protocol version that's lower than what we
 	// support, check for the server downgrade canaries.
 	// See RFC 8446, Section 4.1.3.
@@ -374,6 +388,8 @@ func (c *Conn) clientHandshake(ctx context.Context) (err error) {
 			earlySecret:  earlySecret,
 			binderKey:    binderKey,
 			echContext:   ech,
+			// writeEarlyData sent the compatibility ChangeCipherSpec.
+			sentDummyCCS: hello.earlyData && c.quic == nil,
 		}
 		return hs.handshake()
 	}
@@ -504,6 +520,14 @@ func (c *Conn) loadSession(hello *clientHelloMsg) (
 				}
 			}
 		}
+	} else if len(c.earlyData) > 0 && session.EarlyData &&
+		uint64(len(c.earlyData)) <= uint64(session.maxEarlyData) &&
+		c.config.EncryptedClientHelloConfigList == nil &&
+		mutualCipherSuiteTLS13(hello.cipherSuites, session.cipherSuite) != nil &&
+		(session.alpnProtocol == "" || slices.Contains(hello.alpnProtocols, session.alpnProtocol)) {
+		// Like for QUIC, the cipher suite has to match exactly, and the server
+		// will only accept early data if it selects the same ALPN protocol.
+		hello.earlyData = true
 	}
 
 	// Set the pre_shared_key extension. See RFC 8446, Section 4.2.11.1.
@@ -526,6 +550,33 @@ func (c *Conn) loadSession(hello *clientHelloMsg) (
 	return
 }
 
+// writeEarlyData sends c.earlyData as TLS 1.3 early data following the
+// ClientHello, preceded by the compatibility ChangeCipherSpec record. The
+// record layer is left using the early traffic keys. See RFC 8446, Section 4.2.10.
+func (c *Conn) writeEarlyData(suite *cipherSuiteTLS13, clientRandom, earlyTrafficSecret []byte) error {
+	if err := c.config.writeKeyLog(keyLogLabelClientEarly, clientRandom, earlyTrafficSecret); err != nil {
+		c.sendAlert(alertInternalError)
+		return err
+	}
+
+	c.out.Lock()
+	defer c.out.Unlock()
+
+	// The version is not negotiated yet, but early data is only offered when
+	// resuming a TLS 1.3 session, so its records are TLS 1.3 records.
+	c.vers, c.out.version = VersionTLS13, VersionTLS13
+	defer func() { c.vers = 0 }()
+
+	if _, err := c.writeRecordLocked(recordTypeChangeCipherSpec, []byte{1}); err != nil {
+		return c.out.setErrorLocked(err)
+	}
+	c.out.setTrafficSecret(suite, QUICEncryptionLevelEarly, earlyTrafficSecret)
+	if _, err := c.writeRecordLocked(recordTypeApplicationData, c.earlyData); err != nil {
+		return c.out.setErrorLocked(err)
+	}
+	return nil
+}
+
 func (c *Conn) pickTLSVersion(serverHello *serverHelloMsg) error {
 	peerVersion := serverHello.vers
 	if serverHello.supportedVersion != 0 {
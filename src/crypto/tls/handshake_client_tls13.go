@@ -39,6 +39,10 @@ type clientHandshakeStateTLS13 struct {
 	masterSecret  *tls13.MasterSecret
 	trafficSecret []byte // client_application_traffic_secret_0
 
+	// clientHandshakeSecret is client_handshake_traffic_secret, if it can't be
+	// used until the end of early data.
+	clientHandshakeSecret []byte
+
 	echContext *echClientContext
 }
 
@@ -143,6 +147,9 @@ func (hs *clientHandshakeStateTLS13) handshake() error {
 	if err := hs.readServerFinished(); err != nil {
 		return err
 	}
+	if err := hs.sendEndOfEarlyData(); err != nil {
+		return err
+	}
 	if err := hs.sendClientCertificate(); err != nil {
 		return err
 	}
@@ -340,6 +347,20 @@ func (hs *clientHandshakeStateTLS13) processHelloRetryRequest() error {
 		hello.keyShares = []keyShare{{group: curveID, data: key.PublicKey().Bytes()}}
 	}
 
+	// Early data is not allowed in the second ClientHello, and the extension
+	// must be removed before computing the new binders.
+	if hello.earlyData {
+		hello.earlyData = false
+		if c.quic != nil {
+			c.quicRejectedEarlyData()
+		} else {
+			// The second ClientHello is sent in plaintext.
+			c.out.cipher = nil
+			c.out.level = QUICEncryptionLevelInitial
+			c.out.trafficSecret = nil
+		}
+	}
+
 	if len(hello.pskIdentities) > 0 {
 		pskSuite := cipherSuiteTLS13ByID(hs.session.cipherSuite)
 		if pskSuite == nil {
@@ -367,11 +388,6 @@ func (hs *clientHandshakeStateTLS13) processHelloRetryRequest() error {
 		}
 	}
 
-	if hello.earlyData {
-		hello.earlyData = false
-		c.quicRejectedEarlyData()
-	}
-
 	if isInnerHello {
 		// Any extensions which have changed in hello, but are mirrored in the
 		// outer hello and compressed, need to be copied to the outer hello, so
@@ -518,7 +534,13 @@ func (hs *clientHandshakeStateTLS13) establishHandshakeKeys() error {
 	handshakeSecret := earlySecret.HandshakeSecret(sharedKey)
 
 	clientSecret := handshakeSecret.ClientHandshakeTrafficSecret(hs.transcript)
-	c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, clientSecret)
+	if hs.hello.earlyData && c.quic == nil {
+		// Keep using the early traffic keys until the server either rejects
+		// early data or receives our EndOfEarlyData.
+		hs.clientHandshakeSecret = clientSecret
+	} else {
+		c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, clientSecret)
+	}
 	serverSecret := handshakeSecret.ServerHandshakeTrafficSecret(hs.transcript)
 	c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, serverSecret)
 
@@ -589,7 +611,11 @@ func (hs *clientHandshakeStateTLS13) readServerParameters() error {
 		return errors.New("tls: server sent an unexpected early_data extension")
 	}
 	if hs.hello.earlyData && !encryptedExtensions.earlyData {
-		c.quicRejectedEarlyData()
+		if c.quic != nil {
+			c.quicRejectedEarlyData()
+		} else {
+			c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, hs.clientHandshakeSecret)
+		}
 	}
 	if encryptedExtensions.earlyData {
 		if hs.session.cipherSuite != c.cipherSuite {
@@ -600,6 +626,7 @@ func (hs *clientHandshakeStateTLS13) readServerParameters() error {
 			c.sendAlert(alertHandshakeFailure)
 			return errors.New("tls: server accepted 0-RTT with the wrong ALPN")
 		}
+		c.earlyDataAccepted = true
 	}
 	if hs.echContext != nil && !hs.echContext.echRejected && encryptedExtensions.echRetryConfigs != nil {
 		c.sendAlert(alertUnsupportedExtension)
@@ -749,6 +776,24 @@ func (hs *clientHandshakeStateTLS13) readServerFinished() error {
 	return nil
 }
 
+// sendEndOfEarlyData signals the end of the early data accepted by the server,
+// and switches to the handshake traffic keys. See RFC 8446, Section 4.5.
+func (hs *clientHandshakeStateTLS13) sendEndOfEarlyData() error {
+	c := hs.c
+
+	// QUIC doesn't use EndOfEarlyData. See RFC 9001, Section 8.3.
+	if !c.earlyDataAccepted || c.quic != nil {
+		return nil
+	}
+
+	if _, err := c.writeHandshakeRecord(&endOfEarlyDataMsg{}, hs.transcript); err != nil {
+		return err
+	}
+	c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, hs.clientHandshakeSecret)
+
+	return nil
+}
+
 func (hs *clientHandshakeStateTLS13) sendClientCertificate() error {
 	c := hs.c
 
@@ -888,7 +933,12 @@ func (c *Conn) handleNewSessionTicket(msg *newSessionTicketMsgTLS13) error {
 	session.secret = psk
 	session.useBy = uint64(c.config.time().Add(lifetime).Unix())
 	session.ageAdd = msg.ageAdd
-	session.EarlyData = c.quic != nil && msg.maxEarlyData == 0xffffffff // RFC 9001, Section 4.6.1
+	if c.quic != nil {
+		session.EarlyData = msg.maxEarlyData == 0xffffffff // RFC 9001, Section 4.6.1
+	} else {
+		session.EarlyData = msg.maxEarlyData != 0
+	}
+	session.maxEarlyData = msg.maxEarlyData
 	session.ticket = msg.label
 	if c.quic != nil && c.quic.enableSessionEvents {
 		c.quicStoreSession(session)
@@ -112,6 +112,11 @@ func TestMarshalUnmarshal(t *testing.T) {
 					// data is optional and the length of the
 					// Finished varies across versions.
 					for j := 0; j < len(marshaled); j++ {
+						if ss, ok := m1.(*SessionState); ok && ss.maxEarlyData != 0 && j == len(marshaled)-4 {
+							// The trailing max_early_data of a client session
+							// is optional, for sessions encoded before 0-RTT.
+							continue
+						}
 						if m.unmarshal(marshaled[0:j]) {
 							t.Errorf("#%d unmarshaled a prefix of length %d of %#v", i, j, m1)
 							break
@@ -428,6 +433,9 @@ func (*SessionState) Generate(rand *rand.Rand, size int) reflect.Value {
 		if isTLS13 {
 			s.useBy = uint64(rand.Int63())
 			s.ageAdd = uint32(rand.Int63() & math.MaxUint32)
+			if s.EarlyData {
+				s.maxEarlyData = uint32(rand.Int63() & math.MaxUint32)
+			}
 		}
 	}
 	return reflect.ValueOf(s)
@@ -9,10 +9,10 @@ import (
 	"context"
 	"crypto"
 	"crypto/hmac"
+	"crypto/hpke"
 	"crypto/internal/fips140/hkdf"
 	"crypto/internal/fips140/mlkem"
 	"crypto/internal/fips140/tls13"
-	"crypto/internal/hpke"
 	"crypto/rsa"
 	"crypto/tls/internal/fips140tls"
 	"errors"
@@ -30,7 +30,7 @@ import (
 const maxClientPSKIdentities = 5
 
 type echServerContext struct {
-	hpkeContext *hpke.Receipient
+	hpkeContext *hpke.Recipient
 	configID    uint8
 	ciphersuite echCipher
 	transcript  hash.Hash
@@ -60,6 +60,10 @@ type serverHandshakeStateTLS13 struct {
 	transcript      hash.Hash
 	clientFinished  []byte
 	echContext      *echServerContext
+
+	// clientHandshakeSecret is client_handshake_traffic_secret, if it can't be
+	// used until the end of early data.
+	clientHandshakeSecret []byte
 }
 
 func (hs *serverHandshakeStateTLS13) handshake() error {
@@ -72,6 +76,10 @@ func (hs *serverHandshakeStateTLS13) handshake() error {
 	if err := hs.checkForResumption(); err != nil {
 		return err
 	}
+	if hs.clientHello.earlyData && !hs.earlyData && c.quic == nil {
+		c.skipEarlyData = true
+		c.earlyDataLeft = int(c.config.MaxEarlyData)
+	}
 	if err := hs.pickCertificate(); err != nil {
 		return err
 	}
@@ -94,6 +102,15 @@ func (hs *serverHandshakeStateTLS13) handshake() error {
 	if err := hs.readClientCertificate(); err != nil {
 		return err
 	}
+	if hs.earlyData && c.quic == nil {
+		// The early data, and then the client's second flight, are read by
+		// Conn.Read, which calls readEndOfEarlyData.
+		c.earlyDataHandshake = hs
+		c.earlyDataLeft = int(c.config.MaxEarlyData)
+		c.inEarlyData.Store(true)
+		c.isEarlyDataHandshake.Store(true)
+		return nil
+	}
 	if err := hs.readClientFinished(); err != nil {
 		return err
 	}
@@ -156,7 +173,7 @@ func (hs *serverHandshakeStateTLS13) processClientHello() error {
 		return errors.New("tls: initial handshake had non-empty renegotiation extension")
 	}
 
-	if hs.clientHello.earlyData && c.quic != nil {
+	if hs.clientHello.earlyData && (c.quic != nil || c.config.MaxEarlyData > 0) {
 		if len(hs.clientHello.pskIdentities) == 0 {
 			c.sendAlert(alertIllegalParameter)
 			return errors.New("tls: early_data without pre_shared_key")
@@ -164,10 +181,11 @@ func (hs *serverHandshakeStateTLS13) processClientHello() error {
 	} else if hs.clientHello.earlyData {
 		// See RFC 8446, Section 4.2.10 for the complicated behavior required
 		// here. The scenario is that a different server at our address offered
-		// to accept early data in the past, which we can't handle. For now, all
-		// 0-RTT enabled session tickets need to expire before a Go server can
-		// replace a server or join a pool. That's the same requirement that
-		// applies to mixing or replacing with any TLS 1.2 server.
+		// to accept early data in the past, which we can't handle unless
+		// MaxEarlyData is set. For now, all 0-RTT enabled session tickets need
+		// to expire before a Go server without MaxEarlyData can replace a
+		// server or join a pool. That's the same requirement that applies to
+		// mixing or replacing with any TLS 1.2 server.
 		c.sendAlert(alertUnsupportedExtension)
 		return errors.New("tls: client sent unexpected early data")
 	}
@@ -438,17 +456,33 @@ func (hs *serverHandshakeStateTLS13) checkForResumption() error {
 			return errors.New("tls: invalid PSK binder")
 		}
 
-		if c.quic != nil && hs.clientHello.earlyData && i == 0 &&
+		if (c.quic != nil || c.config.MaxEarlyData > 0) && hs.clientHello.earlyData && i == 0 &&
 			sessionState.EarlyData && sessionState.cipherSuite == hs.suite.id &&
-			sessionState.alpnProtocol == c.clientProtocol {
+			sessionState.alpnProtocol == c.clientProtocol &&
+			c.acceptEarlyData(&EarlyDataInfo{
+				ClientHello: clientHelloInfo(hs.ctx, c, hs.clientHello),
+				Session:     sessionState,
+				Identity:    identity.label,
+				Binder:      hs.clientHello.pskBinders[i],
+			}) {
 			hs.earlyData = true
+			c.earlyDataAccepted = true
 
 			transcript := hs.suite.hash.New()
 			if err := transcriptMsg(hs.clientHello, transcript); err != nil {
 				return err
 			}
 			earlyTrafficSecret := hs.earlySecret.ClientEarlyTrafficSecret(transcript)
-			c.quicSetReadSecret(QUICEncryptionLevelEarly, hs.suite.id, earlyTrafficSecret)
+			if c.quic != nil {
+				c.quicSetReadSecret(QUICEncryptionLevelEarly, hs.suite.id, earlyTrafficSecret)
+			} else {
+				c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelEarly, earlyTrafficSecret)
+				err := c.config.writeKeyLog(keyLogLabelClientEarly, hs.clientHello.random, earlyTrafficSecret)
+				if err != nil {
+					c.sendAlert(alertInternalError)
+					return err
+				}
+			}
 		}
 
 		c.didResume = true
@@ -589,6 +623,12 @@ func (hs *serverHandshakeStateTLS13) doHelloRetryRequest(selectedGroup CurveID)
 		return nil, err
 	}
 
+	if hs.clientHello.earlyData && c.quic == nil {
+		// Skip the early data that the client sent after the first ClientHello.
+		c.skipEarlyData = true
+		c.earlyDataLeft = int(c.config.MaxEarlyData)
+	}
+
 	// clientHelloMsg is not included in the transcript.
 	msg, err := c.readHandshake(nil)
 	if err != nil {
@@ -763,7 +803,12 @@ func (hs *serverHandshakeStateTLS13) sendServerParameters() error {
 	hs.handshakeSecret = earlySecret.HandshakeSecret(hs.sharedKey)
 
 	clientSecret := hs.handshakeSecret.ClientHandshakeTrafficSecret(hs.transcript)
-	c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, clientSecret)
+	if hs.earlyData && c.quic == nil {
+		// Keep using the early traffic keys until EndOfEarlyData.
+		hs.clientHandshakeSecret = clientSecret
+	} else {
+		c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, clientSecret)
+	}
 	serverSecret := hs.handshakeSecret.ServerHandshakeTrafficSecret(hs.transcript)
 	c.out.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, serverSecret)
 
@@ -795,8 +840,8 @@ func (hs *serverHandshakeStateTLS13) sendServerParameters() error {
 			return err
 		}
 		encryptedExtensions.quicTransportParameters = p
-		encryptedExtensions.earlyData = hs.earlyData
 	}
+	encryptedExtensions.earlyData = hs.earlyData
 
 	// If client sent ECH extension, but we didn't accept it,
 	// send retry configs, if available.
@@ -928,8 +973,9 @@ func (hs *serverHandshakeStateTLS13) sendServerFinished() error {
 
 	// If we did not request client certificates, at this point we can
 	// precompute the client finished and roll the transcript forward to send
-	// session tickets in our first flight.
-	if !hs.requestClientCert() {
+	// session tickets in our first flight, unless the client's EndOfEarlyData
+	// still needs to be added to the transcript.
+	if !hs.requestClientCert() && (!hs.earlyData || c.quic != nil) {
 		if err := hs.sendSessionTickets(); err != nil {
 			return err
 		}
@@ -973,7 +1019,17 @@ func (hs *serverHandshakeStateTLS13) sendSessionTickets() error {
 	if !hs.shouldSendSessionTickets() {
 		return nil
 	}
-	return c.sendSessionTicket(false, nil)
+	return c.sendSessionTicket(c.config.MaxEarlyData > 0 && c.config.AcceptEarlyData != nil, nil)
+}
+
+// acceptEarlyData reports whether the server accepts the early data
+// described by info. Early data can be replayed, so on TCP connections
+// it is accepted only if Config.AcceptEarlyData allows it.
+func (c *Conn) acceptEarlyData(info *EarlyDataInfo) bool {
+	if c.config.AcceptEarlyData == nil {
+		return c.quic != nil
+	}
+	return c.config.AcceptEarlyData(info)
 }
 
 func (c *Conn) sendSessionTicket(earlyData bool, extra [][]byte) error {
@@ -1012,17 +1068,19 @@ func (c *Conn) sendSessionTicket(earlyData bool, extra [][]byte) error {
 	m.lifetime = uint32(maxSessionTicketLifetime / time.Second)
 
 	// ticket_age_add is a random 32-bit value. See RFC 8446, section 4.6.1
-	// The value is not stored anywhere; we never need to check the ticket age
-	// because 0-RTT is not supported.
+	// The value is not stored anywhere; we never check the ticket age, and
+	// leave protection against 0-RTT replays to Config.AcceptEarlyData.
 	ageAdd := make([]byte, 4)
 	if _, err := c.config.rand().Read(ageAdd); err != nil {
 		return err
 	}
 	m.ageAdd = byteorder.LEUint32(ageAdd)
 
-	if earlyData {
+	if earlyData && c.quic != nil {
 		// RFC 9001, Section 4.6.1
 		m.maxEarlyData = 0xffffffff
+	} else if earlyData {
+		m.maxEarlyData = c.config.MaxEarlyData
 	}
 
 	if _, err := c.writeHandshakeRecord(m, nil); err != nil {
@@ -1121,6 +1179,45 @@ func (hs *serverHandshakeStateTLS13) readClientCertificate() error {
 	return nil
 }
 
+// readEndOfEarlyData is called by Conn.Read when a handshake message follows
+// the accepted early data. It reads the client's EndOfEarlyData, and then
+// completes the handshake. See RFC 8446, Section 4.5.
+func (hs *serverHandshakeStateTLS13) readEndOfEarlyData() error {
+	c := hs.c
+
+	msg, err := c.readHandshake(hs.transcript)
+	if err != nil {
+		return err
+	}
+
+	endOfEarlyData, ok := msg.(*endOfEarlyDataMsg)
+	if !ok {
+		c.sendAlert(alertUnexpectedMessage)
+		return unexpectedMessageError(endOfEarlyData, msg)
+	}
+
+	// The next message must be in a record protected by the handshake keys.
+	if c.hand.Len() != 0 {
+		c.sendAlert(alertUnexpectedMessage)
+		return errors.New("tls: handshake message not aligned with the end of early data")
+	}
+
+	c.in.setTrafficSecret(hs.suite, QUICEncryptionLevelHandshake, hs.clientHandshakeSecret)
+	c.inEarlyData.Store(false)
+
+	if err := hs.sendSessionTickets(); err != nil {
+		return err
+	}
+	if err := hs.readClientFinished(); err != nil {
+		return err
+	}
+
+	c.isHandshakeComplete.Store(true)
+	c.isEarlyDataHandshake.Store(false)
+
+	return nil
+}
+
 func (hs *serverHandshakeStateTLS13) readClientFinished() error {
 	c := hs.c
 
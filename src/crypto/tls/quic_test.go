@@ -557,6 +557,64 @@ func TestQUICEarlyData(t *testing.T) {
 	if cliSecret.suite != srvSecret.suite || !bytes.Equal(cliSecret.secret, srvSecret.secret) {
 		t.Errorf("client early data secret does not match server")
 	}
+	if !cli2.conn.ConnectionState().EarlyDataAccepted {
+		t.Errorf("client ConnectionState.EarlyDataAccepted = false, want true")
+	}
+	if !srv2.conn.ConnectionState().EarlyDataAccepted {
+		t.Errorf("server ConnectionState.EarlyDataAccepted = false, want true")
+	}
+}
+
+func TestQUICAcceptEarlyData(t *testing.T) {
+	clientConfig := &QUICConfig{TLSConfig: testConfig.Clone()}
+	clientConfig.TLSConfig.MinVersion = VersionTLS13
+	clientConfig.TLSConfig.ClientSessionCache = NewLRUClientSessionCache(1)
+	clientConfig.TLSConfig.ServerName = "example.go.dev"
+	clientConfig.TLSConfig.NextProtos = []string{"h3"}
+
+	serverConfig := &QUICConfig{TLSConfig: testConfig.Clone()}
+	serverConfig.TLSConfig.MinVersion = VersionTLS13
+	serverConfig.TLSConfig.NextProtos = []string{"h3"}
+
+	cli := newTestQUICClient(t, clientConfig)
+	cli.conn.SetTransportParameters(nil)
+	srv := newTestQUICServer(t, serverConfig)
+	srv.conn.SetTransportParameters(nil)
+	srv.ticketOpts.EarlyData = true
+	if err := runTestQUICConnection(context.Background(), cli, srv, nil); err != nil {
+		t.Fatalf("error during first connection handshake: %v", err)
+	}
+
+	var info *EarlyDataInfo
+	serverConfig.TLSConfig.AcceptEarlyData = func(i *EarlyDataInfo) bool {
+		info = i
+		return false
+	}
+	cli2 := newTestQUICClient(t, clientConfig)
+	cli2.conn.SetTransportParameters(nil)
+	srv2 := newTestQUICServer(t, serverConfig)
+	srv2.conn.SetTransportParameters(nil)
+	if err := runTestQUICConnection(context.Background(), cli2, srv2, nil); err != nil {
+		t.Fatalf("error during second connection handshake: %v", err)
+	}
+	if !cli2.conn.ConnectionState().DidResume {
+		t.Errorf("second connection did not use session resumption")
+	}
+	if info == nil {
+		t.Fatalf("AcceptEarlyData was not called")
+	}
+	if info.Session == nil || !info.Session.EarlyData || len(info.Identity) == 0 || len(info.Binder) == 0 {
+		t.Errorf("AcceptEarlyData called with incomplete EarlyDataInfo: %+v", info)
+	}
+	if !cli2.earlyDataRejected {
+		t.Errorf("client did not receive QUICEarlyDataRejected")
+	}
+	if _, srvEarlyData := srv2.readSecret[QUICEncryptionLevelEarly]; srvEarlyData {
+		t.Errorf("server received early data read secret")
+	}
+	if cli2.conn.ConnectionState().EarlyDataAccepted || srv2.conn.ConnectionState().EarlyDataAccepted {
+		t.Errorf("ConnectionState.EarlyDataAccepted = true, want false")
+	}
 }
 
 func TestQUICEarlyDataDeclined(t *testing.T) {
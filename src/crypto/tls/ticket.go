@@ -52,6 +52,7 @@ type SessionState struct {
 	//                   case VersionTLS13: struct {
 	//                       uint64 use_by;
 	//                       uint32 age_add;
+	//                       uint32 max_early_data; /* omitted if zero */
 	//                   };
 	//               };
 	//           };
@@ -72,9 +73,9 @@ type SessionState struct {
 	// with an id and version prefix).
 	Extra [][]byte
 
-	// EarlyData indicates whether the ticket can be used for 0-RTT in a QUIC
-	// connection. The application may set this to false if it is true to
-	// decline to offer 0-RTT even if supported.
+	// EarlyData indicates whether the ticket can be used for 0-RTT. The
+	// application may set this to false if it is true to decline to offer 0-RTT
+	// even if supported.
 	EarlyData bool
 
 	version     uint16
@@ -94,9 +95,10 @@ type SessionState struct {
 	alpnProtocol      string // only set if EarlyData is true
 
 	// Client-side TLS 1.3-only fields.
-	useBy  uint64 // seconds since UNIX epoch
-	ageAdd uint32
-	ticket []byte
+	useBy        uint64 // seconds since UNIX epoch
+	ageAdd       uint32
+	maxEarlyData uint32 // max_early_data_size of the NewSessionTicket
+	ticket       []byte
 }
 
 // Bytes encodes the session, including any private fields, so that it can be
@@ -165,6 +167,11 @@ func (s *SessionState) Bytes() ([]byte, error) {
 		if s.version >= VersionTLS13 {
 			addUint64(&b, s.useBy)
 			b.AddUint32(s.ageAdd)
+			// max_early_data is omitted if zero, which is also the
+			// encoding of sessions from before 0-RTT was supported.
+			if s.maxEarlyData != 0 {
+				b.AddUint32(s.maxEarlyData)
+			}
 		}
 	}
 	return b.Bytes()
@@ -282,7 +289,13 @@ func ParseSessionState(data []byte) (*SessionState, error) {
 		}
 		return ss, nil
 	}
-	if !s.ReadUint64(&ss.useBy) || !s.ReadUint32(&ss.ageAdd) || !s.Empty() {
+	if !s.ReadUint64(&ss.useBy) || !s.ReadUint32(&ss.ageAdd) {
+		return nil, errors.New("tls: invalid session encoding")
+	}
+	if !s.Empty() && (!s.ReadUint32(&ss.maxEarlyData) || ss.maxEarlyData == 0) {
+		return nil, errors.New("tls: invalid session encoding")
+	}
+	if !s.Empty() {
 		return nil, errors.New("tls: invalid session encoding")
 	}
 	return ss, nil
@@ -4,5 +4,52 @@
 
 package tls
 
+import (
+	"bytes"
+	"testing"
+)
+
 var _ = &Config{WrapSession: (&Config{}).EncryptTicket}
 var _ = &Config{UnwrapSession: (&Config{}).DecryptTicket}
+
+func TestParseSessionStateWithoutMaxEarlyData(t *testing.T) {
+	ss := &SessionState{
+		version:          VersionTLS13,
+		cipherSuite:      TLS_AES_128_GCM_SHA256,
+		secret:           []byte("secret"),
+		peerCertificates: sessionTestCerts,
+		isClient:         true,
+		useBy:            1234,
+		ageAdd:           5678,
+		maxEarlyData:     16384,
+		EarlyData:        true,
+	}
+	b, err := ss.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseSessionState(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.maxEarlyData != ss.maxEarlyData {
+		t.Errorf("maxEarlyData = %d, want %d", got.maxEarlyData, ss.maxEarlyData)
+	}
+
+	// Sessions encoded before 0-RTT was supported end after age_add.
+	old := b[:len(b)-4]
+	got, err = ParseSessionState(old)
+	if err != nil {
+		t.Fatalf("parsing session without max_early_data: %v", err)
+	}
+	if got.useBy != ss.useBy || got.ageAdd != ss.ageAdd || got.maxEarlyData != 0 {
+		t.Errorf("got useBy %d, ageAdd %d, maxEarlyData %d; want %d, %d, 0",
+			got.useBy, got.ageAdd, got.maxEarlyData, ss.useBy, ss.ageAdd)
+	}
+	ss.maxEarlyData = 0
+	if b, err := ss.Bytes(); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(b, old) {
+		t.Errorf("session without max_early_data encoded as %x, want %x", b, old)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"crypto/ecdh"
 	"crypto/ecdsa"
 	"crypto/elliptic"
-	"crypto/internal/hpke"
 	"crypto/rand"
 	"crypto/tls/internal/fips140tls"
 	"crypto/x509"
@@ -811,7 +810,7 @@ func TestWarningAlertFlood(t *testing.T) {
 }
 
 func TestCloneFuncFields(t *testing.T) {
-	const expectedCount = 9
+	const expectedCount = 10
 	called := 0
 
 	c1 := Config{
@@ -851,6 +850,10 @@ func TestCloneFuncFields(t *testing.T) {
 			called |= 1 << 8
 			return nil
 		},
+		AcceptEarlyData: func(*EarlyDataInfo) bool {
+			called |= 1 << 9
+			return true
+		},
 	}
 
 	c2 := c1.Clone()
@@ -864,6 +867,7 @@ func TestCloneFuncFields(t *testing.T) {
 	c2.UnwrapSession(nil, ConnectionState{})
 	c2.WrapSession(ConnectionState{}, nil)
 	c2.EncryptedClientHelloRejectionVerify(ConnectionState{})
+	c2.AcceptEarlyData(nil)
 
 	if called != (1<<expectedCount)-1 {
 		t.Fatalf("expected %d calls but saw calls %b", expectedCount, called)
@@ -882,7 +886,7 @@ func TestCloneNonFuncFields(t *testing.T) {
 		switch fn := typ.Field(i).Name; fn {
 		case "Rand":
 			f.Set(reflect.ValueOf(io.Reader(os.Stdin)))
-		case "Time", "GetCertificate", "GetConfigForClient", "VerifyPeerCertificate", "VerifyConnection", "GetClientCertificate", "WrapSession", "UnwrapSession", "EncryptedClientHelloRejectionVerify":
+		case "Time", "GetCertificate", "GetConfigForClient", "VerifyPeerCertificate", "VerifyConnection", "GetClientCertificate", "WrapSession", "UnwrapSession", "EncryptedClientHelloRejectionVerify", "AcceptEarlyData":
 			// DeepEqual can't compare functions. If you add a
 			// function field to this list, you must also change
 			// TestCloneFuncFields to ensure that the func field is
@@ -907,6 +911,8 @@ func TestCloneNonFuncFields(t *testing.T) {
 			f.Set(reflect.ValueOf(VerifyClientCertIfGiven))
 		case "InsecureSkipVerify", "SessionTicketsDisabled", "DynamicRecordSizingDisabled", "PreferServerCipherSuites":
 			f.Set(reflect.ValueOf(true))
+		case "MaxEarlyData":
+			f.Set(reflect.ValueOf(uint32(16384)))
 		case "MinVersion", "MaxVersion":
 			f.Set(reflect.ValueOf(uint16(VersionTLS12)))
 		case "SessionTicketKey":
@@ -2172,13 +2178,13 @@ func TestECH(t *testing.T) {
 		builder.AddUint16(extensionEncryptedClientHello)
 		builder.AddUint16LengthPrefixed(func(builder *cryptobyte.Builder) {
 			builder.AddUint8(id)
-			builder.AddUint16(hpke.DHKEM_X25519_HKDF_SHA256) // The only DHKEM we support
+			builder.AddUint16(echKEM.ID()) // The only DHKEM we support
 			builder.AddUint16LengthPrefixed(func(builder *cryptobyte.Builder) {
 				builder.AddBytes(pubKey)
 			})
 			builder.AddUint16LengthPrefixed(func(builder *cryptobyte.Builder) {
 				for _, aeadID := range sortedSupportedAEADs {
-					builder.AddUint16(hpke.KDF_HKDF_SHA256) // The only KDF we support
+					builder.AddUint16(echKDF.ID()) // The only KDF we support
 					builder.AddUint16(aeadID)
 				}
 			})
@@ -2251,3 +2257,162 @@ func TestECH(t *testing.T) {
 		t.Fatal("unexpected certificate")
 	}
 }
+
+func TestEarlyData(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		testEarlyData(t, "early", nil, true)
+	})
+	t.Run("NoAcceptEarlyData", func(t *testing.T) {
+		testEarlyData(t, "early", func(config *Config) {
+			config.AcceptEarlyData = nil
+		}, false)
+	})
+	t.Run("AcceptEarlyData", func(t *testing.T) {
+		var info *EarlyDataInfo
+		testEarlyData(t, "early", func(config *Config) {
+			config.AcceptEarlyData = func(i *EarlyDataInfo) bool {
+				info = i
+				return false
+			}
+		}, false)
+		if info == nil {
+			t.Fatal("AcceptEarlyData was not called")
+		}
+		if info.ClientHello.ServerName != "example.golang" {
+			t.Errorf("EarlyDataInfo.ClientHello.ServerName = %q, want %q", info.ClientHello.ServerName, "example.golang")
+		}
+		if info.Session == nil || !info.Session.EarlyData || len(info.Identity) == 0 || len(info.Binder) == 0 {
+			t.Errorf("AcceptEarlyData called with incomplete EarlyDataInfo: %+v", info)
+		}
+	})
+	t.Run("HelloRetryRequest", func(t *testing.T) {
+		testEarlyData(t, "early", func(config *Config) {
+			config.CurvePreferences = []CurveID{CurveP256}
+		}, false)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		testEarlyData(t, strings.Repeat("a", 17), nil, false)
+	})
+}
+
+// testEarlyData establishes a connection to get a session ticket, and then
+// resumes it sending earlyData, after applying configureServer to the server
+// config. If the early data is expected to be accepted, it checks that a third
+// connection can use early data with the ticket issued by the second one.
+func testEarlyData(t *testing.T, earlyData string, configureServer func(*Config), wantAccepted bool) {
+	clientConfig := testConfig.Clone()
+	clientConfig.MinVersion = VersionTLS13
+	clientConfig.ClientSessionCache = NewLRUClientSessionCache(1)
+	clientConfig.ServerName = "example.golang"
+	clientConfig.NextProtos = []string{"h2"}
+
+	serverConfig := testConfig.Clone()
+	serverConfig.MinVersion = VersionTLS13
+	serverConfig.NextProtos = []string{"h2"}
+	serverConfig.MaxEarlyData = 16
+	serverConfig.AcceptEarlyData = func(*EarlyDataInfo) bool { return true }
+
+	if err := testEarlyDataConn(t, clientConfig, serverConfig, "", false, false); err != nil {
+		t.Fatalf("first connection: %v", err)
+	}
+	if configureServer != nil {
+		configureServer(serverConfig)
+	}
+	if err := testEarlyDataConn(t, clientConfig, serverConfig, earlyData, true, wantAccepted); err != nil {
+		t.Fatalf("second connection: %v", err)
+	}
+	if wantAccepted {
+		if err := testEarlyDataConn(t, clientConfig, serverConfig, earlyData, true, true); err != nil {
+			t.Fatalf("third connection: %v", err)
+		}
+	}
+}
+
+func testEarlyDataConn(t *testing.T, clientConfig, serverConfig *Config, earlyData string, wantResume, wantAccepted bool) error {
+	c, s := localPipe(t)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- func() error {
+			cli := Client(c, clientConfig)
+			defer cli.Close()
+			if err := cli.SetEarlyData([]byte(earlyData)); err != nil {
+				return err
+			}
+			if err := cli.Handshake(); err != nil {
+				return fmt.Errorf("client handshake: %v", err)
+			}
+			if err := cli.SetEarlyData(nil); err == nil {
+				return errors.New("SetEarlyData after the handshake succeeded")
+			}
+			if got := cli.ConnectionState().EarlyDataAccepted; got != wantAccepted {
+				return fmt.Errorf("client EarlyDataAccepted = %v, want %v", got, wantAccepted)
+			}
+			if _, err := io.WriteString(cli, "late"); err != nil {
+				return err
+			}
+			// Reading the reply also processes the session tickets.
+			buf := make([]byte, len("reply"))
+			if _, err := io.ReadFull(cli, buf); err != nil {
+				return fmt.Errorf("client read: %v", err)
+			}
+			return nil
+		}()
+	}()
+
+	srv := Server(s, serverConfig)
+	defer srv.Close()
+	if err := srv.Handshake(); err != nil {
+		return fmt.Errorf("server handshake: %v", err)
+	}
+	state := srv.ConnectionState()
+	if state.DidResume != wantResume {
+		return fmt.Errorf("DidResume = %v, want %v", state.DidResume, wantResume)
+	}
+	if state.EarlyDataAccepted != wantAccepted {
+		return fmt.Errorf("server EarlyDataAccepted = %v, want %v", state.EarlyDataAccepted, wantAccepted)
+	}
+	if wantAccepted {
+		if !srv.InEarlyData() {
+			return errors.New("InEarlyData = false before reading early data")
+		}
+		if state.HandshakeComplete || srv.isHandshakeComplete.Load() {
+			return errors.New("HandshakeComplete = true while reading early data")
+		}
+		// The server can reply before the client's Finished message.
+		if _, err := io.WriteString(srv, "reply"); err != nil {
+			return fmt.Errorf("server write during early data: %v", err)
+		}
+		buf := make([]byte, len(earlyData))
+		if _, err := io.ReadFull(srv, buf); err != nil {
+			return fmt.Errorf("server read of early data: %v", err)
+		}
+		if string(buf) != earlyData {
+			return fmt.Errorf("server read early data %q, want %q", buf, earlyData)
+		}
+		if !srv.InEarlyData() {
+			return errors.New("InEarlyData = false after reading early data")
+		}
+		if srv.ConnectionState().HandshakeComplete || srv.isHandshakeComplete.Load() {
+			return errors.New("HandshakeComplete = true after reading early data")
+		}
+	}
+	buf := make([]byte, len("late"))
+	if _, err := io.ReadFull(srv, buf); err != nil {
+		return fmt.Errorf("server read: %v", err)
+	}
+	if string(buf) != "late" {
+		return fmt.Errorf("server read %q, want %q", buf, "late")
+	}
+	if srv.InEarlyData() {
+		return errors.New("InEarlyData = true after the handshake")
+	}
+	if !srv.ConnectionState().HandshakeComplete || srv.isEarlyDataHandshake.Load() {
+		return errors.New("HandshakeComplete = false after the handshake")
+	}
+	if !wantAccepted {
+		if _, err := io.WriteString(srv, "reply"); err != nil {
+			return err
+		}
+	}
+	return <-errc
+}
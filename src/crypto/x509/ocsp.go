@@ -0,0 +1,664 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// OCSPStatus is the revocation status of a certificate, as reported by an
+// OCSP responder.
+type OCSPStatus int
+
+const (
+	// OCSPGood indicates that the certificate is not revoked.
+	OCSPGood OCSPStatus = iota
+	// OCSPRevoked indicates that the certificate has been revoked.
+	OCSPRevoked
+	// OCSPUnknown indicates that the responder doesn't know about the
+	// certificate.
+	OCSPUnknown
+)
+
+var (
+	oidOCSPBasic = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+	oidSHA1      = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+)
+
+// ocspHashes are the hash functions supported in OCSP CertIDs.
+var ocspHashes = []struct {
+	hash crypto.Hash
+	oid  asn1.ObjectIdentifier
+}{
+	{crypto.SHA1, oidSHA1},
+	{crypto.SHA256, oidSHA256},
+	{crypto.SHA384, oidSHA384},
+	{crypto.SHA512, oidSHA512},
+}
+
+func ocspHashOID(h crypto.Hash) asn1.ObjectIdentifier {
+	for _, details := range ocspHashes {
+		if details.hash == h {
+			return details.oid
+		}
+	}
+	return nil
+}
+
+// OCSPRequest represents an OCSP request for the status of a single
+// certificate, as specified by RFC 6960.
+type OCSPRequest struct {
+	// HashAlgorithm is the hash function used to compute IssuerNameHash and
+	// IssuerKeyHash.
+	HashAlgorithm crypto.Hash
+	// IssuerNameHash is the hash of the DER-encoded subject of the issuer of
+	// the certificate.
+	IssuerNameHash []byte
+	// IssuerKeyHash is the hash of the subject public key of the issuer of
+	// the certificate.
+	IssuerKeyHash []byte
+	// SerialNumber is the serial number of the certificate.
+	SerialNumber *big.Int
+}
+
+// OCSPResponse represents an OCSP response for the status of a single
+// certificate, as specified by RFC 6960.
+type OCSPResponse struct {
+	// Raw contains the complete ASN.1 DER content of the OCSPResponse.
+	Raw []byte
+	// RawTBSResponseData contains just the signed tbsResponseData portion of
+	// the ASN.1 DER.
+	RawTBSResponseData []byte
+
+	// Status is the revocation status of the certificate.
+	Status OCSPStatus
+	// SerialNumber is the serial number of the certificate.
+	SerialNumber *big.Int
+	// HashAlgorithm is the hash function used to compute IssuerNameHash and
+	// IssuerKeyHash. When creating a response, crypto.SHA1 is used if zero.
+	HashAlgorithm crypto.Hash
+	// IssuerNameHash and IssuerKeyHash identify the issuer of the certificate,
+	// like the fields of [OCSPRequest]. They are ignored when creating a
+	// response.
+	IssuerNameHash []byte
+	IssuerKeyHash  []byte
+
+	// ProducedAt is the time at which the response was signed. When
+	// creating a response, the current time is used if zero.
+	ProducedAt time.Time
+	// ThisUpdate is the time at which the status is known to be correct.
+	ThisUpdate time.Time
+	// NextUpdate is the time at or before which newer information will be
+	// available. It is zero if the responder didn't provide it.
+	NextUpdate time.Time
+
+	// RevokedAt is the time at which the certificate was revoked. It is only
+	// set if Status is OCSPRevoked.
+	RevokedAt time.Time
+	// ReasonCode is the reason for revocation, using the integer enum
+	// values specified in RFC 5280 Section 5.3.1. It is only set if Status
+	// is OCSPRevoked, and is zero if the responder didn't provide a reason.
+	ReasonCode int
+
+	// RawResponderName and ResponderKeyHash identify the responder, by its
+	// DER-encoded subject or the SHA-1 hash of its subject public key. Only
+	// one of them is set. They are ignored when creating a response.
+	RawResponderName []byte
+	ResponderKeyHash []byte
+
+	// Certificate is the delegated responder certificate, issued by the
+	// issuer of the certificate to sign OCSP responses on its behalf. It is
+	// nil if the response doesn't include any certificate. When creating a
+	// response, if not nil it is included in the response and the response
+	// is signed with its key rather than the issuer's.
+	Certificate *Certificate
+
+	Signature          []byte
+	SignatureAlgorithm SignatureAlgorithm
+
+	// Extensions contains raw response extensions. When parsing responses,
+	// this can be used to extract extensions that are not parsed by this
+	// package. When marshaling responses, the Extensions field is ignored,
+	// see ExtraExtensions.
+	Extensions []pkix.Extension
+
+	// ExtraExtensions contains any additional response extensions to add
+	// directly to the response.
+	ExtraExtensions []pkix.Extension
+}
+
+// ocspIssuerHashes returns the hashes of the subject and the subject public
+// key of issuer, used in OCSP CertIDs.
+func ocspIssuerHashes(issuer *Certificate, hash crypto.Hash) (nameHash, keyHash []byte, err error) {
+	if !hash.Available() {
+		return nil, nil, fmt.Errorf("x509: OCSP hash function %v is not available", hash)
+	}
+	publicKey, err := subjectPublicKeyBytes(issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+	subject, err := subjectBytes(issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h := hash.New()
+	h.Write(subject)
+	nameHash = h.Sum(nil)
+	h.Reset()
+	h.Write(publicKey)
+	keyHash = h.Sum(nil)
+	return nameHash, keyHash, nil
+}
+
+// subjectPublicKeyBytes returns the contents of the subjectPublicKey BIT
+// STRING of cert.
+func subjectPublicKeyBytes(cert *Certificate) ([]byte, error) {
+	spki := cryptobyte.String(cert.RawSubjectPublicKeyInfo)
+	var publicKey asn1.BitString
+	if !spki.ReadASN1(&spki, cryptobyte_asn1.SEQUENCE) ||
+		!spki.SkipASN1(cryptobyte_asn1.SEQUENCE) ||
+		!spki.ReadASN1BitString(&publicKey) {
+		return nil, errors.New("x509: malformed subject public key info")
+	}
+	return publicKey.RightAlign(), nil
+}
+
+func parseOCSPCertID(der *cryptobyte.String) (hash crypto.Hash, nameHash, keyHash []byte, serial *big.Int, err error) {
+	var certID, hashAISeq cryptobyte.String
+	if !der.ReadASN1(&certID, cryptobyte_asn1.SEQUENCE) ||
+		!certID.ReadASN1(&hashAISeq, cryptobyte_asn1.SEQUENCE) {
+		return 0, nil, nil, nil, errors.New("x509: malformed OCSP CertID")
+	}
+	hashAI, err := parseAI(hashAISeq)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	for _, details := range ocspHashes {
+		if hashAI.Algorithm.Equal(details.oid) {
+			hash = details.hash
+			break
+		}
+	}
+	if hash == 0 {
+		return 0, nil, nil, nil, fmt.Errorf("x509: unsupported OCSP CertID hash algorithm %v", hashAI.Algorithm)
+	}
+	serial = new(big.Int)
+	if !certID.ReadASN1Bytes(&nameHash, cryptobyte_asn1.OCTET_STRING) ||
+		!certID.ReadASN1Bytes(&keyHash, cryptobyte_asn1.OCTET_STRING) ||
+		!certID.ReadASN1Integer(serial) {
+		return 0, nil, nil, nil, errors.New("x509: malformed OCSP CertID")
+	}
+	return hash, nameHash, keyHash, serial, nil
+}
+
+func addOCSPCertID(b *cryptobyte.Builder, hashOID asn1.ObjectIdentifier, nameHash, keyHash []byte, serial *big.Int) {
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1ObjectIdentifier(hashOID)
+			b.AddASN1NULL()
+		})
+		b.AddASN1OctetString(nameHash)
+		b.AddASN1OctetString(keyHash)
+		b.AddASN1BigInt(serial)
+	})
+}
+
+// ParseOCSPRequest parses an OCSP request in ASN.1 DER form. Only the first
+// certificate of requests for multiple certificates is returned. Request
+// signatures and extensions are ignored.
+func ParseOCSPRequest(der []byte) (*OCSPRequest, error) {
+	input := cryptobyte.String(der)
+	var tbs, requests, request cryptobyte.String
+	if !input.ReadASN1(&input, cryptobyte_asn1.SEQUENCE) ||
+		!input.ReadASN1(&tbs, cryptobyte_asn1.SEQUENCE) ||
+		!tbs.SkipOptionalASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) ||
+		!tbs.SkipOptionalASN1(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()) ||
+		!tbs.ReadASN1(&requests, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP request")
+	}
+	if !requests.ReadASN1(&request, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: OCSP request contains no certificates")
+	}
+
+	req := &OCSPRequest{}
+	var err error
+	req.HashAlgorithm, req.IssuerNameHash, req.IssuerKeyHash, req.SerialNumber, err = parseOCSPCertID(&request)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// CreateOCSPRequest returns a DER-encoded OCSP request for the status of
+// cert, which must have been issued by issuer. The hash function used to
+// identify the issuer is crypto.SHA1 if hash is zero, which is the only one
+// that all responders are required to support.
+func CreateOCSPRequest(cert, issuer *Certificate, hash crypto.Hash) ([]byte, error) {
+	if hash == 0 {
+		hash = crypto.SHA1
+	}
+	hashOID := ocspHashOID(hash)
+	if hashOID == nil {
+		return nil, fmt.Errorf("x509: unsupported OCSP hash function %v", hash)
+	}
+	nameHash, keyHash, err := ocspIssuerHashes(issuer, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	b := cryptobyte.NewBuilder(nil)
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // OCSPRequest
+		b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // TBSRequest
+			b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // requestList
+				b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // Request
+					addOCSPCertID(b, hashOID, nameHash, keyHash, cert.SerialNumber)
+				})
+			})
+		})
+	})
+	return b.Bytes()
+}
+
+// ParseOCSPResponse parses an OCSP response in ASN.1 DER form, such as a
+// response stapled to a TLS handshake and exposed by
+// [crypto/tls.ConnectionState.OCSPResponse].
+//
+// Only basic OCSP responses for a single certificate are supported. If the
+// responder returned an error status instead of a response, an error is
+// returned. The signature on the response is not checked, see
+// [OCSPResponse.CheckSignatureFrom].
+func ParseOCSPResponse(der []byte) (*OCSPResponse, error) {
+	resp := &OCSPResponse{}
+
+	input := cryptobyte.String(der)
+	if !input.ReadASN1Element(&input, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP response")
+	}
+	resp.Raw = input
+	var status int
+	if !input.ReadASN1(&input, cryptobyte_asn1.SEQUENCE) ||
+		!input.ReadASN1Enum(&status) {
+		return nil, errors.New("x509: malformed OCSP response")
+	}
+	if status != 0 {
+		return nil, fmt.Errorf("x509: OCSP responder returned error status %d", status)
+	}
+
+	var responseBytes, basic cryptobyte.String
+	var responseType asn1.ObjectIdentifier
+	if !input.ReadASN1(&responseBytes, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) ||
+		!responseBytes.ReadASN1(&responseBytes, cryptobyte_asn1.SEQUENCE) ||
+		!responseBytes.ReadASN1ObjectIdentifier(&responseType) {
+		return nil, errors.New("x509: malformed OCSP response")
+	}
+	if !responseType.Equal(oidOCSPBasic) {
+		return nil, fmt.Errorf("x509: unsupported OCSP response type %v", responseType)
+	}
+	if !responseBytes.ReadASN1(&basic, cryptobyte_asn1.OCTET_STRING) ||
+		!basic.ReadASN1(&basic, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed basic OCSP response")
+	}
+
+	var tbs cryptobyte.String
+	if !basic.ReadASN1Element(&tbs, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP response data")
+	}
+	resp.RawTBSResponseData = tbs
+	if !tbs.ReadASN1(&tbs, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP response data")
+	}
+
+	var sigAISeq cryptobyte.String
+	if !basic.ReadASN1(&sigAISeq, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed signature algorithm identifier")
+	}
+	sigAI, err := parseAI(sigAISeq)
+	if err != nil {
+		return nil, err
+	}
+	resp.SignatureAlgorithm = getSignatureAlgorithmFromAI(sigAI)
+
+	var signature asn1.BitString
+	if !basic.ReadASN1BitString(&signature) {
+		return nil, errors.New("x509: malformed signature")
+	}
+	resp.Signature = signature.RightAlign()
+
+	var certs cryptobyte.String
+	var present bool
+	if !basic.ReadOptionalASN1(&certs, &present, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, errors.New("x509: malformed OCSP response certificates")
+	}
+	if present {
+		if !certs.ReadASN1(&certs, cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed OCSP response certificates")
+		}
+		if !certs.Empty() {
+			var certDER cryptobyte.String
+			if !certs.ReadASN1Element(&certDER, cryptobyte_asn1.SEQUENCE) {
+				return nil, errors.New("x509: malformed OCSP response certificates")
+			}
+			resp.Certificate, err = ParseCertificate(certDER)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var version int
+	if !tbs.ReadOptionalASN1Integer(&version, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), 0) {
+		return nil, errors.New("x509: malformed OCSP response version")
+	}
+	if version != 0 {
+		return nil, fmt.Errorf("x509: unsupported OCSP response version: %d", version)
+	}
+
+	var responderID cryptobyte.String
+	switch {
+	case tbs.PeekASN1Tag(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()):
+		if !tbs.ReadASN1(&responderID, cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()) ||
+			!responderID.ReadASN1Element(&responderID, cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed OCSP responder ID")
+		}
+		resp.RawResponderName = responderID
+	case tbs.PeekASN1Tag(cryptobyte_asn1.Tag(2).Constructed().ContextSpecific()):
+		if !tbs.ReadASN1(&responderID, cryptobyte_asn1.Tag(2).Constructed().ContextSpecific()) ||
+			!responderID.ReadASN1Bytes(&resp.ResponderKeyHash, cryptobyte_asn1.OCTET_STRING) {
+			return nil, errors.New("x509: malformed OCSP responder ID")
+		}
+	default:
+		return nil, errors.New("x509: malformed OCSP responder ID")
+	}
+
+	if !tbs.ReadASN1GeneralizedTime(&resp.ProducedAt) {
+		return nil, errors.New("x509: malformed OCSP response producedAt")
+	}
+
+	var responses, single cryptobyte.String
+	if !tbs.ReadASN1(&responses, cryptobyte_asn1.SEQUENCE) ||
+		!responses.ReadASN1(&single, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP single response")
+	}
+	if !responses.Empty() {
+		return nil, errors.New("x509: OCSP responses for multiple certificates are not supported")
+	}
+
+	resp.HashAlgorithm, resp.IssuerNameHash, resp.IssuerKeyHash, resp.SerialNumber, err = parseOCSPCertID(&single)
+	if err != nil {
+		return nil, err
+	}
+
+	var certStatus cryptobyte.String
+	var certStatusTag cryptobyte_asn1.Tag
+	if !single.ReadAnyASN1(&certStatus, &certStatusTag) {
+		return nil, errors.New("x509: malformed OCSP certificate status")
+	}
+	switch certStatusTag {
+	case cryptobyte_asn1.Tag(0).ContextSpecific():
+		resp.Status = OCSPGood
+	case cryptobyte_asn1.Tag(1).Constructed().ContextSpecific():
+		resp.Status = OCSPRevoked
+		if !certStatus.ReadASN1GeneralizedTime(&resp.RevokedAt) {
+			return nil, errors.New("x509: malformed OCSP revocation time")
+		}
+		var reason cryptobyte.String
+		if !certStatus.ReadOptionalASN1(&reason, &present, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) {
+			return nil, errors.New("x509: malformed OCSP revocation reason")
+		}
+		if present && !reason.ReadASN1Enum(&resp.ReasonCode) {
+			return nil, errors.New("x509: malformed OCSP revocation reason")
+		}
+	case cryptobyte_asn1.Tag(2).ContextSpecific():
+		resp.Status = OCSPUnknown
+	default:
+		return nil, errors.New("x509: malformed OCSP certificate status")
+	}
+
+	if !single.ReadASN1GeneralizedTime(&resp.ThisUpdate) {
+		return nil, errors.New("x509: malformed OCSP response thisUpdate")
+	}
+	var nextUpdate cryptobyte.String
+	if !single.ReadOptionalASN1(&nextUpdate, &present, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, errors.New("x509: malformed OCSP response nextUpdate")
+	}
+	if present && !nextUpdate.ReadASN1GeneralizedTime(&resp.NextUpdate) {
+		return nil, errors.New("x509: malformed OCSP response nextUpdate")
+	}
+
+	var extensions cryptobyte.String
+	if !tbs.ReadOptionalASN1(&extensions, &present, cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()) {
+		return nil, errors.New("x509: malformed extensions")
+	}
+	if present {
+		if !extensions.ReadASN1(&extensions, cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed extensions")
+		}
+		for !extensions.Empty() {
+			var extension cryptobyte.String
+			if !extensions.ReadASN1(&extension, cryptobyte_asn1.SEQUENCE) {
+				return nil, errors.New("x509: malformed extension")
+			}
+			ext, err := parseExtension(extension)
+			if err != nil {
+				return nil, err
+			}
+			resp.Extensions = append(resp.Extensions, ext)
+		}
+	}
+
+	return resp, nil
+}
+
+// CheckSignatureFrom verifies that the signature on resp is a valid signature
+// from issuer, or from a delegated responder certificate in resp.Certificate
+// that was issued by issuer and has the [ExtKeyUsageOCSPSigning] extended key
+// usage.
+func (resp *OCSPResponse) CheckSignatureFrom(issuer *Certificate) error {
+	signer := issuer
+	if resp.Certificate != nil && !bytes.Equal(resp.Certificate.Raw, issuer.Raw) {
+		if !slices.Contains(resp.Certificate.ExtKeyUsage, ExtKeyUsageOCSPSigning) {
+			return errors.New("x509: OCSP responder certificate is not authorized to sign OCSP responses")
+		}
+		if err := resp.Certificate.CheckSignatureFrom(issuer); err != nil {
+			return err
+		}
+		signer = resp.Certificate
+	}
+
+	if signer.PublicKeyAlgorithm == UnknownPublicKeyAlgorithm {
+		return ErrUnsupportedAlgorithm
+	}
+
+	return signer.CheckSignature(resp.SignatureAlgorithm, resp.RawTBSResponseData, resp.Signature)
+}
+
+// isFor reports whether resp is a response for the status of cert, which was
+// issued by issuer.
+func (resp *OCSPResponse) isFor(cert, issuer *Certificate) bool {
+	if resp.SerialNumber == nil || resp.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		return false
+	}
+	nameHash, keyHash, err := ocspIssuerHashes(issuer, resp.HashAlgorithm)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(nameHash, resp.IssuerNameHash) && bytes.Equal(keyHash, resp.IssuerKeyHash)
+}
+
+// CreateOCSPResponse creates a new OCSP response for the status of the
+// certificate with serial number template.SerialNumber, issued by issuer.
+//
+// The response is signed with priv. If template.Certificate is nil, priv
+// must be the private key of issuer. Otherwise, template.Certificate is a
+// delegated responder certificate issued by issuer and included in the
+// response, and priv must be its private key.
+//
+// The following members of template are used: Status, SerialNumber,
+// HashAlgorithm, ProducedAt, ThisUpdate, NextUpdate, RevokedAt, ReasonCode,
+// Certificate, SignatureAlgorithm, and ExtraExtensions.
+func CreateOCSPResponse(rand io.Reader, template *OCSPResponse, issuer *Certificate, priv crypto.Signer) ([]byte, error) {
+	if template == nil {
+		return nil, errors.New("x509: template can not be nil")
+	}
+	if issuer == nil {
+		return nil, errors.New("x509: issuer can not be nil")
+	}
+	if template.SerialNumber == nil {
+		return nil, errors.New("x509: template contains nil SerialNumber field")
+	}
+	if template.ThisUpdate.IsZero() {
+		return nil, errors.New("x509: template contains zero ThisUpdate field")
+	}
+	if !template.NextUpdate.IsZero() && template.NextUpdate.Before(template.ThisUpdate) {
+		return nil, errors.New("x509: template.ThisUpdate is after template.NextUpdate")
+	}
+	if template.Status == OCSPRevoked && template.RevokedAt.IsZero() {
+		return nil, errors.New("x509: template contains zero RevokedAt field")
+	}
+
+	hash := template.HashAlgorithm
+	if hash == 0 {
+		hash = crypto.SHA1
+	}
+	hashOID := ocspHashOID(hash)
+	if hashOID == nil {
+		return nil, fmt.Errorf("x509: unsupported OCSP hash function %v", hash)
+	}
+	nameHash, keyHash, err := ocspIssuerHashes(issuer, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := issuer
+	if template.Certificate != nil {
+		if len(template.Certificate.Raw) == 0 {
+			return nil, errNotParsed
+		}
+		signer = template.Certificate
+	}
+	type privateKey interface {
+		Equal(crypto.PublicKey) bool
+	}
+	if privPub, ok := priv.Public().(privateKey); !ok {
+		return nil, errors.New("x509: internal error: supported public key does not implement Equal")
+	} else if !privPub.Equal(signer.PublicKey) {
+		return nil, errors.New("x509: provided PrivateKey doesn't match the responder's PublicKey")
+	}
+	// The responder ID is always computed with SHA-1. See RFC 6960,
+	// Section 4.2.1.
+	signerPublicKey, err := subjectPublicKeyBytes(signer)
+	if err != nil {
+		return nil, err
+	}
+	responderKeyHash := sha1.Sum(signerPublicKey)
+
+	signatureAlgorithm, algorithmIdentifier, err := signingParamsForKey(priv, template.SignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	producedAt := template.ProducedAt
+	if producedAt.IsZero() {
+		producedAt = time.Now()
+	}
+
+	b := cryptobyte.NewBuilder(nil)
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // ResponseData
+		b.AddASN1(cryptobyte_asn1.Tag(2).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+			b.AddASN1OctetString(responderKeyHash[:])
+		})
+		b.AddASN1GeneralizedTime(producedAt.UTC())
+		b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // responses
+			b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // SingleResponse
+				addOCSPCertID(b, hashOID, nameHash, keyHash, template.SerialNumber)
+				switch template.Status {
+				case OCSPGood:
+					b.AddASN1(cryptobyte_asn1.Tag(0).ContextSpecific(), func(b *cryptobyte.Builder) {})
+				case OCSPRevoked:
+					b.AddASN1(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+						b.AddASN1GeneralizedTime(template.RevokedAt.UTC())
+						if template.ReasonCode != 0 {
+							b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+								b.AddASN1Enum(int64(template.ReasonCode))
+							})
+						}
+					})
+				case OCSPUnknown:
+					b.AddASN1(cryptobyte_asn1.Tag(2).ContextSpecific(), func(b *cryptobyte.Builder) {})
+				default:
+					b.SetError(errors.New("x509: template contains unknown Status"))
+				}
+				b.AddASN1GeneralizedTime(template.ThisUpdate.UTC())
+				if !template.NextUpdate.IsZero() {
+					b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+						b.AddASN1GeneralizedTime(template.NextUpdate.UTC())
+					})
+				}
+			})
+		})
+		if len(template.ExtraExtensions) > 0 {
+			b.AddASN1(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+				b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					for _, ext := range template.ExtraExtensions {
+						b.MarshalASN1(ext)
+					}
+				})
+			})
+		}
+	})
+	tbs, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signTBS(tbs, priv, signatureAlgorithm, rand)
+	if err != nil {
+		return nil, err
+	}
+
+	b = cryptobyte.NewBuilder(nil)
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // BasicOCSPResponse
+		b.AddBytes(tbs)
+		b.MarshalASN1(algorithmIdentifier)
+		b.AddASN1BitString(signature)
+		if template.Certificate != nil {
+			b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+				b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					b.AddBytes(template.Certificate.Raw)
+				})
+			})
+		}
+	})
+	basic, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	b = cryptobyte.NewBuilder(nil)
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // OCSPResponse
+		b.AddASN1Enum(0) // successful
+		b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+			b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // ResponseBytes
+				b.AddASN1ObjectIdentifier(oidOCSPBasic)
+				b.AddASN1OctetString(basic)
+			})
+		})
+	})
+	return b.Bytes()
+}
@@ -0,0 +1,376 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func generateOCSPTestPKI(t *testing.T) (issuer, leaf *Certificate, issuerKey crypto.Signer) {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer = genCertEdge(t, "issuer", k, func(c *Certificate) {
+		c.KeyUsage |= KeyUsageCRLSign
+	}, rootCertificate, nil, nil)
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf = genCertEdge(t, "leaf", leafKey, nil, leafCertificate, issuer, k)
+	return issuer, leaf, k
+}
+
+func TestOCSPRequest(t *testing.T) {
+	issuer, leaf, _ := generateOCSPTestPKI(t)
+	for _, h := range []crypto.Hash{0, crypto.SHA1, crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		der, err := CreateOCSPRequest(leaf, issuer, h)
+		if err != nil {
+			t.Fatalf("CreateOCSPRequest(%v): %v", h, err)
+		}
+		req, err := ParseOCSPRequest(der)
+		if err != nil {
+			t.Fatalf("ParseOCSPRequest(%v): %v", h, err)
+		}
+		if h == 0 {
+			h = crypto.SHA1
+		}
+		if req.HashAlgorithm != h {
+			t.Errorf("HashAlgorithm = %v, want %v", req.HashAlgorithm, h)
+		}
+		if req.SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+			t.Errorf("SerialNumber = %v, want %v", req.SerialNumber, leaf.SerialNumber)
+		}
+		nameHash, keyHash, err := ocspIssuerHashes(issuer, h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(req.IssuerNameHash, nameHash) || !bytes.Equal(req.IssuerKeyHash, keyHash) {
+			t.Errorf("issuer hashes don't match")
+		}
+	}
+
+	if _, err := CreateOCSPRequest(leaf, issuer, crypto.MD5); err == nil {
+		t.Error("CreateOCSPRequest with MD5 succeeded")
+	}
+}
+
+func TestOCSPResponse(t *testing.T) {
+	issuer, leaf, issuerKey := generateOCSPTestPKI(t)
+	now := time.Now().Truncate(time.Second).UTC()
+
+	responderKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	responder := genCertEdge(t, "responder", responderKey, func(c *Certificate) {
+		c.ExtKeyUsage = []ExtKeyUsage{ExtKeyUsageOCSPSigning}
+	}, leafCertificate, issuer, issuerKey)
+
+	tests := []struct {
+		name     string
+		template *OCSPResponse
+		key      crypto.Signer
+	}{
+		{
+			name: "good",
+			template: &OCSPResponse{
+				Status:       OCSPGood,
+				SerialNumber: leaf.SerialNumber,
+				ProducedAt:   now,
+				ThisUpdate:   now.Add(-time.Hour),
+				NextUpdate:   now.Add(time.Hour),
+			},
+			key: issuerKey,
+		},
+		{
+			name: "revoked",
+			template: &OCSPResponse{
+				Status:        OCSPRevoked,
+				SerialNumber:  leaf.SerialNumber,
+				HashAlgorithm: crypto.SHA256,
+				ProducedAt:    now,
+				ThisUpdate:    now.Add(-time.Hour),
+				RevokedAt:     now.Add(-2 * time.Hour),
+				ReasonCode:    1,
+			},
+			key: issuerKey,
+		},
+		{
+			name: "unknown",
+			template: &OCSPResponse{
+				Status:       OCSPUnknown,
+				SerialNumber: leaf.SerialNumber,
+				ProducedAt:   now,
+				ThisUpdate:   now,
+				ExtraExtensions: []pkix.Extension{
+					{Id: asn1.ObjectIdentifier{1, 2, 3}, Value: []byte{5, 0}},
+				},
+			},
+			key: issuerKey,
+		},
+		{
+			name: "delegated",
+			template: &OCSPResponse{
+				Status:       OCSPGood,
+				SerialNumber: leaf.SerialNumber,
+				ProducedAt:   now,
+				ThisUpdate:   now,
+				Certificate:  responder,
+			},
+			key: responderKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			der, err := CreateOCSPResponse(rand.Reader, tt.template, issuer, tt.key)
+			if err != nil {
+				t.Fatalf("CreateOCSPResponse: %v", err)
+			}
+			resp, err := ParseOCSPResponse(der)
+			if err != nil {
+				t.Fatalf("ParseOCSPResponse: %v", err)
+			}
+			if !bytes.Equal(resp.Raw, der) {
+				t.Errorf("Raw doesn't match the response")
+			}
+			if err := resp.CheckSignatureFrom(issuer); err != nil {
+				t.Errorf("CheckSignatureFrom: %v", err)
+			}
+			if !resp.isFor(leaf, issuer) {
+				t.Errorf("response doesn't match the certificate")
+			}
+
+			want := tt.template
+			if resp.Status != want.Status {
+				t.Errorf("Status = %v, want %v", resp.Status, want.Status)
+			}
+			if !resp.ProducedAt.Equal(want.ProducedAt) || !resp.ThisUpdate.Equal(want.ThisUpdate) ||
+				!resp.NextUpdate.Equal(want.NextUpdate) || !resp.RevokedAt.Equal(want.RevokedAt) {
+				t.Errorf("times don't match: got %v %v %v %v", resp.ProducedAt, resp.ThisUpdate, resp.NextUpdate, resp.RevokedAt)
+			}
+			if resp.ReasonCode != want.ReasonCode {
+				t.Errorf("ReasonCode = %d, want %d", resp.ReasonCode, want.ReasonCode)
+			}
+			if want.HashAlgorithm != 0 && resp.HashAlgorithm != want.HashAlgorithm {
+				t.Errorf("HashAlgorithm = %v, want %v", resp.HashAlgorithm, want.HashAlgorithm)
+			}
+			if !reflect.DeepEqual(resp.Extensions, want.ExtraExtensions) {
+				t.Errorf("Extensions = %v, want %v", resp.Extensions, want.ExtraExtensions)
+			}
+			if want.Certificate != nil && (resp.Certificate == nil || !resp.Certificate.Equal(want.Certificate)) {
+				t.Errorf("Certificate doesn't match the delegated responder")
+			}
+			if len(resp.ResponderKeyHash) == 0 {
+				t.Errorf("missing ResponderKeyHash")
+			}
+
+			otherIssuer, otherLeaf, _ := generateOCSPTestPKI(t)
+			if err := resp.CheckSignatureFrom(otherIssuer); err == nil {
+				t.Errorf("CheckSignatureFrom succeeded for the wrong issuer")
+			}
+			if resp.isFor(otherLeaf, otherIssuer) {
+				t.Errorf("response matches the wrong certificate")
+			}
+		})
+	}
+}
+
+func TestOCSPResponseUnauthorizedResponder(t *testing.T) {
+	issuer, leaf, issuerKey := generateOCSPTestPKI(t)
+	responderKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	responder := genCertEdge(t, "responder", responderKey, nil, leafCertificate, issuer, issuerKey)
+	der, err := CreateOCSPResponse(rand.Reader, &OCSPResponse{
+		Status:       OCSPGood,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   time.Now(),
+		Certificate:  responder,
+	}, issuer, responderKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := ParseOCSPResponse(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resp.CheckSignatureFrom(issuer); err == nil {
+		t.Error("CheckSignatureFrom succeeded for a responder without the OCSPSigning EKU")
+	}
+}
+
+func TestCreateOCSPResponseErrors(t *testing.T) {
+	issuer, leaf, issuerKey := generateOCSPTestPKI(t)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	tests := []struct {
+		name     string
+		template *OCSPResponse
+		key      crypto.Signer
+	}{
+		{"nil template", nil, issuerKey},
+		{"nil serial", &OCSPResponse{ThisUpdate: now}, issuerKey},
+		{"zero ThisUpdate", &OCSPResponse{SerialNumber: leaf.SerialNumber}, issuerKey},
+		{"NextUpdate before ThisUpdate", &OCSPResponse{SerialNumber: leaf.SerialNumber, ThisUpdate: now, NextUpdate: now.Add(-time.Hour)}, issuerKey},
+		{"revoked without RevokedAt", &OCSPResponse{Status: OCSPRevoked, SerialNumber: leaf.SerialNumber, ThisUpdate: now}, issuerKey},
+		{"unknown status", &OCSPResponse{Status: 42, SerialNumber: leaf.SerialNumber, ThisUpdate: now}, issuerKey},
+		{"unsupported hash", &OCSPResponse{HashAlgorithm: crypto.MD5, SerialNumber: leaf.SerialNumber, ThisUpdate: now}, issuerKey},
+		{"wrong key", &OCSPResponse{SerialNumber: leaf.SerialNumber, ThisUpdate: now}, otherKey},
+	}
+	for _, tt := range tests {
+		if _, err := CreateOCSPResponse(rand.Reader, tt.template, issuer, tt.key); err == nil {
+			t.Errorf("%s: CreateOCSPResponse succeeded", tt.name)
+		}
+	}
+}
+
+func TestParseOCSPResponseErrorStatus(t *testing.T) {
+	// OCSPResponse with responseStatus tryLater (3).
+	der := []byte{0x30, 0x03, 0x0a, 0x01, 0x03}
+	if _, err := ParseOCSPResponse(der); err == nil {
+		t.Error("ParseOCSPResponse succeeded for an error status")
+	}
+}
+
+func TestVerifyRevocation(t *testing.T) {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := genCertEdge(t, "root", rootKey, func(c *Certificate) {
+		c.KeyUsage |= KeyUsageCRLSign
+	}, rootCertificate, nil, nil)
+	interKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inter := genCertEdge(t, "intermediate", interKey, func(c *Certificate) {
+		c.KeyUsage |= KeyUsageCRLSign
+	}, intermediateCertificate, root, rootKey)
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := genCertEdge(t, "leaf", leafKey, nil, leafCertificate, inter, interKey)
+
+	now := time.Now()
+	crl := func(issuer *Certificate, key crypto.Signer, thisUpdate time.Time, revoked ...*big.Int) *RevocationList {
+		t.Helper()
+		tmpl := &RevocationList{
+			Number:     big.NewInt(1),
+			ThisUpdate: thisUpdate,
+			NextUpdate: thisUpdate.Add(time.Hour),
+		}
+		for _, serial := range revoked {
+			tmpl.RevokedCertificateEntries = append(tmpl.RevokedCertificateEntries, RevocationListEntry{
+				SerialNumber:   serial,
+				RevocationTime: thisUpdate,
+			})
+		}
+		der, err := CreateRevocationList(rand.Reader, tmpl, issuer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rl, err := ParseRevocationList(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rl
+	}
+	ocsp := func(cert, issuer *Certificate, key crypto.Signer, status OCSPStatus) *OCSPResponse {
+		t.Helper()
+		der, err := CreateOCSPResponse(rand.Reader, &OCSPResponse{
+			Status:       status,
+			SerialNumber: cert.SerialNumber,
+			ThisUpdate:   now.Add(-time.Minute),
+			NextUpdate:   now.Add(time.Hour),
+			RevokedAt:    now.Add(-time.Hour),
+		}, issuer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := ParseOCSPResponse(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name    string
+		crls    []*RevocationList
+		ocsp    []*OCSPResponse
+		require bool
+		reason  InvalidReason // -1 for success
+	}{
+		{"no revocation info", nil, nil, false, -1},
+		{"no revocation info required", nil, nil, true, RevocationStatusUnknown},
+		{"leaf revoked by CRL", []*RevocationList{crl(inter, interKey, now.Add(-time.Minute), leaf.SerialNumber)}, nil, false, Revoked},
+		{"intermediate revoked by CRL", []*RevocationList{crl(root, rootKey, now.Add(-time.Minute), inter.SerialNumber)}, nil, false, Revoked},
+		{"CRL from wrong issuer", []*RevocationList{crl(root, rootKey, now.Add(-time.Minute), leaf.SerialNumber)}, nil, false, -1},
+		{"expired CRL", []*RevocationList{crl(inter, interKey, now.Add(-2*time.Hour), leaf.SerialNumber)}, nil, false, -1},
+		{"CRLs not revoked required", []*RevocationList{
+			crl(inter, interKey, now.Add(-time.Minute)),
+			crl(root, rootKey, now.Add(-time.Minute)),
+		}, nil, true, -1},
+		{"CRL for leaf only required", []*RevocationList{crl(inter, interKey, now.Add(-time.Minute))}, nil, true, RevocationStatusUnknown},
+		{"leaf revoked by OCSP", nil, []*OCSPResponse{ocsp(leaf, inter, interKey, OCSPRevoked)}, false, Revoked},
+		{"OCSP from wrong issuer", nil, []*OCSPResponse{ocsp(leaf, root, rootKey, OCSPRevoked)}, false, -1},
+		{"OCSP good required", nil, []*OCSPResponse{
+			ocsp(leaf, inter, interKey, OCSPGood),
+			ocsp(inter, root, rootKey, OCSPGood),
+		}, true, -1},
+		{"OCSP unknown required", nil, []*OCSPResponse{
+			ocsp(leaf, inter, interKey, OCSPUnknown),
+			ocsp(inter, root, rootKey, OCSPGood),
+		}, true, RevocationStatusUnknown},
+		{"OCSP and CRL required", []*RevocationList{crl(root, rootKey, now.Add(-time.Minute))},
+			[]*OCSPResponse{ocsp(leaf, inter, interKey, OCSPGood)}, true, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roots := NewCertPool()
+			roots.AddCert(root)
+			intermediates := NewCertPool()
+			intermediates.AddCert(inter)
+			_, err := leaf.Verify(VerifyOptions{
+				Roots:                   roots,
+				Intermediates:           intermediates,
+				CRLs:                    tt.crls,
+				OCSPResponses:           tt.ocsp,
+				RequireRevocationStatus: tt.require,
+			})
+			if tt.reason == -1 {
+				if err != nil {
+					t.Fatalf("Verify failed: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Verify succeeded unexpectedly")
+			}
+			if cie, ok := err.(CertificateInvalidError); !ok || cie.Reason != tt.reason {
+				t.Fatalf("Verify returned %v, want reason %d", err, tt.reason)
+			}
+		})
+	}
+}
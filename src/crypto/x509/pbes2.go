@@ -0,0 +1,493 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	cryptorand "crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PKCS8Cipher is a content encryption algorithm for encrypted PKCS #8 private
+// keys. See [MarshalEncryptedPKCS8PrivateKey].
+type PKCS8Cipher int
+
+// Possible values for the Cipher field of [PKCS8EncryptionOptions].
+const (
+	_ PKCS8Cipher = iota
+	PKCS8AES128CBC
+	PKCS8AES192CBC
+	PKCS8AES256CBC
+	PKCS8AES128GCM
+	PKCS8AES192GCM
+	PKCS8AES256GCM
+)
+
+// PKCS8EncryptionOptions configures the encryption performed by
+// [MarshalEncryptedPKCS8PrivateKey].
+type PKCS8EncryptionOptions struct {
+	// Cipher is the content encryption algorithm. If zero, PKCS8AES256CBC
+	// is used, which is the most widely supported.
+	Cipher PKCS8Cipher
+
+	// PBKDF2Hash is the hash function of the HMAC pseudorandom function used
+	// by PBKDF2. Only SHA-1, SHA-224, SHA-256, SHA-384, and SHA-512 are
+	// supported. If zero, crypto.SHA256 is used.
+	PBKDF2Hash crypto.Hash
+
+	// PBKDF2Iterations is the PBKDF2 iteration count. If zero, 600,000
+	// iterations are used. The default might change in the future.
+	// It must not exceed 10,000,000.
+	PBKDF2Iterations int
+
+	// ScryptN, ScryptR, and ScryptP are the scrypt CPU/memory cost, block
+	// size, and parallelization parameters. If ScryptN is not zero, scrypt
+	// (RFC 7914) is used as the key derivation function instead of PBKDF2,
+	// and ScryptN must be a power of two greater than one. If ScryptR or
+	// ScryptP are zero, 8 and 1 are used, respectively. The parameters are
+	// subject to the limits described in [ParseEncryptedPKCS8PrivateKey].
+	ScryptN, ScryptR, ScryptP int
+}
+
+const defaultPBKDF2Iterations = 600000
+
+// maxPBKDF2Iterations limits the PBKDF2 iteration count,
+// which is read from untrusted keys.
+const maxPBKDF2Iterations = 10000000
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidAES128GCM = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 6}
+	oidAES192GCM = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 26}
+	oidAES256GCM = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 46}
+)
+
+// pbes2Cipher holds a content encryption algorithm supported by PBES2.
+type pbes2Cipher struct {
+	cipher  PKCS8Cipher
+	oid     asn1.ObjectIdentifier
+	keySize int
+	gcm     bool
+}
+
+var pbes2Ciphers = [...]pbes2Cipher{
+	{PKCS8AES128CBC, oidAES128CBC, 16, false},
+	{PKCS8AES192CBC, oidAES192CBC, 24, false},
+	{PKCS8AES256CBC, oidAES256CBC, 32, false},
+	{PKCS8AES128GCM, oidAES128GCM, 16, true},
+	{PKCS8AES192GCM, oidAES192GCM, 24, true},
+	{PKCS8AES256GCM, oidAES256GCM, 32, true},
+}
+
+var pbkdf2PRFs = []struct {
+	hash crypto.Hash
+	oid  asn1.ObjectIdentifier
+}{
+	{crypto.SHA1, oidHMACWithSHA1},
+	{crypto.SHA224, oidHMACWithSHA224},
+	{crypto.SHA256, oidHMACWithSHA256},
+	{crypto.SHA384, oidHMACWithSHA384},
+	{crypto.SHA512, oidHMACWithSHA512},
+}
+
+// encryptedPrivateKeyInfo reflects an ASN.1 EncryptedPrivateKeyInfo.
+// See RFC 5958, Section 3.
+type encryptedPrivateKeyInfo struct {
+	Algo          pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params reflects the ASN.1 PBES2-params structure. See RFC 8018,
+// Appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params reflects the ASN.1 PBKDF2-params structure, with the salt
+// restricted to the specified OCTET STRING choice. See RFC 8018,
+// Appendix A.2.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// scryptParams reflects the ASN.1 scrypt-params structure. See RFC 7914,
+// Section 7.1.
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// gcmParams reflects the ASN.1 GCMParameters structure. See RFC 5084,
+// Section 3.2.
+type gcmParams struct {
+	Nonce  []byte
+	ICVLen int `asn1:"optional,default:12"`
+}
+
+// ParseEncryptedPKCS8PrivateKey decrypts and parses a private key in
+// encrypted PKCS #8, ASN.1 DER form, as produced by OpenSSL by default.
+//
+// The key must be encrypted with PBES2 (RFC 8018) using PBKDF2 or scrypt as
+// the key derivation function and AES-CBC or AES-GCM as the encryption
+// scheme. The decrypted key is parsed with [ParsePKCS8PrivateKey] and
+// returned as one of the types it supports.
+//
+// If an incorrect password is detected, [IncorrectPasswordError] is returned.
+// Note that the key derivation parameters are read from der, so decrypting
+// untrusted inputs can be expensive. Keys with more than 10,000,000 PBKDF2
+// iterations, or scrypt parameters that require more than 1 GiB of memory,
+// N greater than 2^20, r greater than 32, or p greater than 16, are rejected.
+//
+// This kind of key is commonly encoded in PEM blocks of type
+// "ENCRYPTED PRIVATE KEY".
+func ParseEncryptedPKCS8PrivateKey(der, password []byte) (key any, err error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		if _, err := asn1.Unmarshal(der, &pkcs8{}); err == nil {
+			return nil, errors.New("x509: failed to parse encrypted private key (use ParsePKCS8PrivateKey instead for this key format)")
+		}
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after encrypted PKCS#8 private key")
+	}
+	if !info.Algo.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("x509: unsupported PKCS#8 encryption algorithm %v", info.Algo.Algorithm)
+	}
+
+	var params pbes2Params
+	if rest, err := asn1.Unmarshal(info.Algo.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.New("x509: failed to parse PBES2 parameters: " + err.Error())
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after PBES2 parameters")
+	}
+
+	var c *pbes2Cipher
+	for i := range pbes2Ciphers {
+		if params.EncryptionScheme.Algorithm.Equal(pbes2Ciphers[i].oid) {
+			c = &pbes2Ciphers[i]
+			break
+		}
+	}
+	if c == nil {
+		return nil, fmt.Errorf("x509: unsupported PBES2 encryption scheme %v", params.EncryptionScheme.Algorithm)
+	}
+
+	derivedKey, err := pbes2DeriveKey(params.KeyDerivationFunc, password, c.keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var plaintext []byte
+	encParams := params.EncryptionScheme.Parameters.FullBytes
+	if c.gcm {
+		var gp gcmParams
+		if rest, err := asn1.Unmarshal(encParams, &gp); err != nil {
+			return nil, errors.New("x509: failed to parse AES-GCM parameters: " + err.Error())
+		} else if len(rest) != 0 {
+			return nil, errors.New("x509: trailing data after AES-GCM parameters")
+		}
+		if len(gp.Nonce) != 12 || gp.ICVLen < 12 || gp.ICVLen > 16 {
+			return nil, errors.New("x509: unsupported AES-GCM parameters")
+		}
+		aead, err := cipher.NewGCMWithTagSize(block, gp.ICVLen)
+		if err != nil {
+			return nil, err
+		}
+		plaintext, err = aead.Open(nil, gp.Nonce, info.EncryptedData, nil)
+		if err != nil {
+			return nil, IncorrectPasswordError
+		}
+	} else {
+		var iv []byte
+		if rest, err := asn1.Unmarshal(encParams, &iv); err != nil {
+			return nil, errors.New("x509: failed to parse AES-CBC parameters: " + err.Error())
+		} else if len(rest) != 0 {
+			return nil, errors.New("x509: trailing data after AES-CBC parameters")
+		}
+		if len(iv) != aes.BlockSize {
+			return nil, errors.New("x509: invalid AES-CBC IV length")
+		}
+		data := info.EncryptedData
+		if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+			return nil, errors.New("x509: encrypted PKCS#8 private key is not a multiple of the block size")
+		}
+		plaintext = make([]byte, len(data))
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, data)
+
+		// Remove the PKCS #7 padding. A wrong password is usually detected
+		// here, but might also result in valid padding by chance.
+		last := int(plaintext[len(plaintext)-1])
+		if last == 0 || last > aes.BlockSize {
+			return nil, IncorrectPasswordError
+		}
+		for _, b := range plaintext[len(plaintext)-last:] {
+			if int(b) != last {
+				return nil, IncorrectPasswordError
+			}
+		}
+		plaintext = plaintext[:len(plaintext)-last]
+	}
+
+	// Garbage that doesn't even parse as a PKCS #8 structure is the result
+	// of decrypting with the wrong key, not of a malformed private key.
+	if rest, err := asn1.Unmarshal(plaintext, &pkcs8{}); err != nil || len(rest) != 0 {
+		return nil, IncorrectPasswordError
+	}
+	return ParsePKCS8PrivateKey(plaintext)
+}
+
+// pbes2DeriveKey derives a key of the given size from password according to
+// the PBES2 key derivation function kdf.
+func pbes2DeriveKey(kdf pkix.AlgorithmIdentifier, password []byte, keySize int) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if rest, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.New("x509: failed to parse PBKDF2 parameters: " + err.Error())
+		} else if len(rest) != 0 {
+			return nil, errors.New("x509: trailing data after PBKDF2 parameters")
+		}
+		if params.IterationCount <= 0 {
+			return nil, errors.New("x509: invalid PBKDF2 iteration count")
+		}
+		if params.IterationCount > maxPBKDF2Iterations {
+			return nil, errors.New("x509: PBKDF2 iteration count is too large")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, errors.New("x509: PBKDF2 key length does not match the encryption scheme")
+		}
+		h := crypto.SHA1
+		if len(params.PRF.Algorithm) != 0 {
+			h = 0
+			for _, prf := range pbkdf2PRFs {
+				if params.PRF.Algorithm.Equal(prf.oid) {
+					h = prf.hash
+					break
+				}
+			}
+			if h == 0 {
+				return nil, fmt.Errorf("x509: unsupported PBKDF2 pseudorandom function %v", params.PRF.Algorithm)
+			}
+		}
+		if !h.Available() {
+			return nil, fmt.Errorf("x509: PBKDF2 pseudorandom function hash %v is not available", h)
+		}
+		return pbkdf2.Key(h.New, string(password), params.Salt, params.IterationCount, keySize)
+
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if rest, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.New("x509: failed to parse scrypt parameters: " + err.Error())
+		} else if len(rest) != 0 {
+			return nil, errors.New("x509: trailing data after scrypt parameters")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, errors.New("x509: scrypt key length does not match the encryption scheme")
+		}
+		return scryptKey(password, params.Salt, params.CostParameter, params.BlockSize, params.ParallelizationParameter, keySize)
+
+	default:
+		return nil, fmt.Errorf("x509: unsupported PBES2 key derivation function %v", kdf.Algorithm)
+	}
+}
+
+// MarshalEncryptedPKCS8PrivateKey converts a private key to PKCS #8, ASN.1 DER
+// form, and encrypts it with password according to PBES2 (RFC 8018).
+//
+// The following key types are currently supported: *[rsa.PrivateKey],
+// *[ecdsa.PrivateKey], [ed25519.PrivateKey] (not a pointer), *[mldsa.PrivateKey],
+// and *[ecdh.PrivateKey]. See [MarshalPKCS8PrivateKey] for details.
+//
+// The salt and the initialization vector or nonce are read from rand, which
+// must be a cryptographically secure random source. If rand is nil,
+// [crypto/rand.Reader] is used. If opts is nil, AES-256-CBC with a key
+// derived by PBKDF2 with HMAC-SHA-256 is used.
+//
+// This kind of key is commonly encoded in PEM blocks of type
+// "ENCRYPTED PRIVATE KEY".
+func MarshalEncryptedPKCS8PrivateKey(rand io.Reader, key any, password []byte, opts *PKCS8EncryptionOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &PKCS8EncryptionOptions{}
+	}
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	cipherType := opts.Cipher
+	if cipherType == 0 {
+		cipherType = PKCS8AES256CBC
+	}
+	var c *pbes2Cipher
+	for i := range pbes2Ciphers {
+		if pbes2Ciphers[i].cipher == cipherType {
+			c = &pbes2Ciphers[i]
+			break
+		}
+	}
+	if c == nil {
+		return nil, errors.New("x509: unknown PKCS#8 encryption cipher")
+	}
+
+	plaintext, err := MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand, salt); err != nil {
+		return nil, errors.New("x509: cannot generate salt: " + err.Error())
+	}
+
+	var kdf pkix.AlgorithmIdentifier
+	var derivedKey []byte
+	if opts.ScryptN != 0 {
+		r, p := opts.ScryptR, opts.ScryptP
+		if r == 0 {
+			r = 8
+		}
+		if p == 0 {
+			p = 1
+		}
+		derivedKey, err = scryptKey(password, salt, opts.ScryptN, r, p, c.keySize)
+		if err != nil {
+			return nil, err
+		}
+		kdf.Algorithm = oidScrypt
+		kdf.Parameters.FullBytes, err = asn1.Marshal(scryptParams{
+			Salt:                     salt,
+			CostParameter:            opts.ScryptN,
+			BlockSize:                r,
+			ParallelizationParameter: p,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		h := opts.PBKDF2Hash
+		if h == 0 {
+			h = crypto.SHA256
+		}
+		var prf pkix.AlgorithmIdentifier
+		for _, candidate := range pbkdf2PRFs {
+			if candidate.hash == h {
+				prf.Algorithm = candidate.oid
+				prf.Parameters = asn1.NullRawValue
+				break
+			}
+		}
+		if prf.Algorithm == nil {
+			return nil, fmt.Errorf("x509: unsupported PBKDF2 hash function %v", h)
+		}
+		if !h.Available() {
+			return nil, fmt.Errorf("x509: PBKDF2 hash function %v is not available", h)
+		}
+		if h == crypto.SHA1 {
+			// hmacWithSHA1 is the DEFAULT value, which DER requires to be omitted.
+			prf = pkix.AlgorithmIdentifier{}
+		}
+		iter := opts.PBKDF2Iterations
+		if iter == 0 {
+			iter = defaultPBKDF2Iterations
+		}
+		if iter < 0 || iter > maxPBKDF2Iterations {
+			return nil, errors.New("x509: invalid PBKDF2 iteration count")
+		}
+		derivedKey, err = pbkdf2.Key(h.New, string(password), salt, iter, c.keySize)
+		if err != nil {
+			return nil, err
+		}
+		kdf.Algorithm = oidPBKDF2
+		kdf.Parameters.FullBytes, err = asn1.Marshal(pbkdf2Params{
+			Salt:           salt,
+			IterationCount: iter,
+			PRF:            prf,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := pkix.AlgorithmIdentifier{Algorithm: c.oid}
+	var encrypted []byte
+	if c.gcm {
+		nonce := make([]byte, 12)
+		if _, err := io.ReadFull(rand, nonce); err != nil {
+			return nil, errors.New("x509: cannot generate nonce: " + err.Error())
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		encrypted = aead.Seal(nil, nonce, plaintext, nil)
+		enc.Parameters.FullBytes, err = asn1.Marshal(gcmParams{Nonce: nonce, ICVLen: aead.Overhead()})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		iv := make([]byte, aes.BlockSize)
+		if _, err := io.ReadFull(rand, iv); err != nil {
+			return nil, errors.New("x509: cannot generate IV: " + err.Error())
+		}
+		// Add PKCS #7 padding. See RFC 8018, Section 6.1.1.
+		pad := aes.BlockSize - len(plaintext)%aes.BlockSize
+		encrypted = append(plaintext, bytes.Repeat([]byte{byte(pad)}, pad)...)
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+		enc.Parameters.FullBytes, err = asn1.Marshal(iv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: kdf,
+		EncryptionScheme:  enc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBES2,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		EncryptedData: encrypted,
+	})
+}
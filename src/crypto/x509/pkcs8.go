@@ -8,6 +8,7 @@ import (
 	"crypto/ecdh"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/mldsa"
 	"crypto/rsa"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -28,8 +29,10 @@ type pkcs8 struct {
 // ParsePKCS8PrivateKey parses an unencrypted private key in PKCS #8, ASN.1 DER form.
 //
 // It returns a *[rsa.PrivateKey], an *[ecdsa.PrivateKey], an [ed25519.PrivateKey] (not
-// a pointer), or an *[ecdh.PrivateKey] (for X25519). More types might be supported
-// in the future.
+// a pointer), a *[mldsa.PrivateKey], or an *[ecdh.PrivateKey] (for X25519). More
+// types might be supported in the future.
+//
+// ML-DSA private keys are only supported in the seed-only encoding of RFC 9881.
 //
 // This kind of key is commonly encoded in PEM blocks of type "PRIVATE KEY".
 func ParsePKCS8PrivateKey(der []byte) (key any, err error) {
@@ -76,6 +79,36 @@ func ParsePKCS8PrivateKey(der []byte) (key any, err error) {
 		}
 		return ed25519.NewKeyFromSeed(curvePrivateKey), nil
 
+	case privKey.Algo.Algorithm.Equal(oidPublicKeyMLDSA44),
+		privKey.Algo.Algorithm.Equal(oidPublicKeyMLDSA65),
+		privKey.Algo.Algorithm.Equal(oidPublicKeyMLDSA87):
+		if l := len(privKey.Algo.Parameters.FullBytes); l != 0 {
+			return nil, errors.New("x509: invalid ML-DSA private key parameters")
+		}
+		if l := len(privKey.PrivateKey); l == 0 {
+			return nil, fmt.Errorf("x509: invalid ML-DSA private key length: %d", l)
+		}
+		switch privKey.PrivateKey[0] {
+		case 0x80: // IMPLICIT [0] OCTET STRING (seed)
+		case 0x04: // OCTET STRING (expandedKey)
+			return nil, errors.New("x509: semi-expanded ML-DSA private keys without seed are not supported")
+		case 0x30: // SEQUENCE (both)
+			return nil, errors.New(`x509: ML-DSA private keys with both seed and expanded key are not supported, use e.g. "openssl pkey -provparam ml-dsa.output_formats=seed-only" to convert to a seed-only key`)
+		default:
+			return nil, fmt.Errorf("x509: invalid ML-DSA private key: invalid ASN.1 tag %02x", privKey.PrivateKey[0])
+		}
+		if l := len(privKey.PrivateKey); l != 2+mldsa.PrivateKeySize {
+			return nil, fmt.Errorf("x509: invalid ML-DSA private key length: %d", l)
+		}
+		if privKey.PrivateKey[1] != mldsa.PrivateKeySize {
+			return nil, errors.New("x509: invalid ML-DSA private key ASN.1 encoding")
+		}
+		params, ok := mldsaParametersFromOID(privKey.Algo.Algorithm)
+		if !ok {
+			return nil, errors.New("x509: unknown ML-DSA parameters")
+		}
+		return mldsa.NewPrivateKey(params, privKey.PrivateKey[2:])
+
 	case privKey.Algo.Algorithm.Equal(oidPublicKeyX25519):
 		if l := len(privKey.Algo.Parameters.FullBytes); l != 0 {
 			return nil, errors.New("x509: invalid X25519 private key parameters")
@@ -94,8 +127,10 @@ func ParsePKCS8PrivateKey(der []byte) (key any, err error) {
 // MarshalPKCS8PrivateKey converts a private key to PKCS #8, ASN.1 DER form.
 //
 // The following key types are currently supported: *[rsa.PrivateKey],
-// *[ecdsa.PrivateKey], [ed25519.PrivateKey] (not a pointer), and *[ecdh.PrivateKey].
-// Unsupported key types result in an error.
+// *[ecdsa.PrivateKey], [ed25519.PrivateKey] (not a pointer), *[mldsa.PrivateKey],
+// and *[ecdh.PrivateKey]. Unsupported key types result in an error.
+//
+// ML-DSA private keys are encoded in the seed-only format of RFC 9881.
 //
 // This kind of key is commonly encoded in PEM blocks of type "PRIVATE KEY".
 func MarshalPKCS8PrivateKey(key any) ([]byte, error) {
@@ -138,6 +173,16 @@ func MarshalPKCS8PrivateKey(key any) ([]byte, error) {
 		}
 		privKey.PrivateKey = curvePrivateKey
 
+	case *mldsa.PrivateKey:
+		oid, ok := oidFromMLDSAParameters(k.PublicKey().Parameters())
+		if !ok {
+			return nil, errors.New("x509: unknown ML-DSA parameters while marshaling to PKCS#8")
+		}
+		privKey.Algo = pkix.AlgorithmIdentifier{
+			Algorithm: oid,
+		}
+		privKey.PrivateKey = append([]byte{0x80, mldsa.PrivateKeySize}, k.Bytes()...)
+
 	case *ecdh.PrivateKey:
 		if k.Curve() == ecdh.X25519() {
 			privKey.Algo = pkix.AlgorithmIdentifier{
@@ -0,0 +1,136 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Limits on the scrypt parameters, which are read from untrusted keys.
+// They bound the memory used by scryptKey, 128*N*r bytes, to 1 GiB, and
+// admit the commonly recommended parameters (N up to 2^20, r = 8, p = 1).
+const (
+	maxScryptN   = 1 << 20
+	maxScryptR   = 32
+	maxScryptP   = 16
+	maxScryptMem = 1 << 30
+)
+
+// scryptKey derives a key from the password, salt, and cost parameters as
+// specified in RFC 7914. N must be a power of two greater than one.
+func scryptKey(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
+	if N <= 1 || N&(N-1) != 0 {
+		return nil, errors.New("x509: scrypt N must be a power of two greater than one")
+	}
+	if r <= 0 || p <= 0 {
+		return nil, errors.New("x509: invalid scrypt parameters")
+	}
+	if N > maxScryptN || r > maxScryptR || p > maxScryptP || 128*N*r > maxScryptMem {
+		return nil, errors.New("x509: scrypt parameters are too large")
+	}
+
+	b, err := pbkdf2.Key(sha256.New, string(password), salt, 1, p*128*r)
+	if err != nil {
+		return nil, err
+	}
+	xy := make([]uint32, 64*r)
+	v := make([]uint32, 32*N*r)
+	for i := 0; i < p; i++ {
+		scryptROMix(b[i*128*r:], r, N, v, xy)
+	}
+	return pbkdf2.Key(sha256.New, string(password), b, 1, keyLen)
+}
+
+// scryptROMix implements the scryptROMix function of RFC 7914, Section 5,
+// operating in place on the first 128*r bytes of b.
+func scryptROMix(b []byte, r, N int, v, xy []uint32) {
+	var tmp [16]uint32
+	R := 32 * r
+	x := xy[:R]
+	y := xy[R:]
+
+	for i := range x {
+		x[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+	for i := 0; i < N; i += 2 {
+		copy(v[i*R:], x)
+		scryptBlockMix(&tmp, x, y, r)
+		copy(v[(i+1)*R:], y)
+		scryptBlockMix(&tmp, y, x, r)
+	}
+	for i := 0; i < N; i += 2 {
+		j := int(integerify(x, r) & uint64(N-1))
+		blockXOR(x, v[j*R:j*R+R])
+		scryptBlockMix(&tmp, x, y, r)
+
+		j = int(integerify(y, r) & uint64(N-1))
+		blockXOR(y, v[j*R:j*R+R])
+		scryptBlockMix(&tmp, y, x, r)
+	}
+	for i, w := range x {
+		binary.LittleEndian.PutUint32(b[4*i:], w)
+	}
+}
+
+// scryptBlockMix implements the scryptBlockMix function of RFC 7914,
+// Section 4, reading 2*r 64-byte blocks from in and writing them to out.
+func scryptBlockMix(tmp *[16]uint32, in, out []uint32, r int) {
+	copy(tmp[:], in[(2*r-1)*16:])
+	for i := 0; i < 2*r; i += 2 {
+		salsaXOR(tmp, in[i*16:], out[i*8:])
+		salsaXOR(tmp, in[i*16+16:], out[i*8+r*16:])
+	}
+}
+
+// integerify returns the little-endian integer encoded by the first eight
+// bytes of the last 64-byte block of b.
+func integerify(b []uint32, r int) uint64 {
+	j := (2*r - 1) * 16
+	return uint64(b[j]) | uint64(b[j+1])<<32
+}
+
+func blockXOR(dst, src []uint32) {
+	for i, w := range src {
+		dst[i] ^= w
+	}
+}
+
+// salsaXOR XORs tmp with the 16 words of in, applies the Salsa20/8 core to
+// the result, and stores it in both tmp and out.
+func salsaXOR(tmp *[16]uint32, in, out []uint32) {
+	var w [16]uint32
+	for i := range w {
+		w[i] = tmp[i] ^ in[i]
+	}
+	x := w
+	for i := 0; i < 8; i += 2 {
+		// Column round.
+		salsaQuarterRound(&x, 0, 4, 8, 12)
+		salsaQuarterRound(&x, 5, 9, 13, 1)
+		salsaQuarterRound(&x, 10, 14, 2, 6)
+		salsaQuarterRound(&x, 15, 3, 7, 11)
+		// Row round.
+		salsaQuarterRound(&x, 0, 1, 2, 3)
+		salsaQuarterRound(&x, 5, 6, 7, 4)
+		salsaQuarterRound(&x, 10, 11, 8, 9)
+		salsaQuarterRound(&x, 15, 12, 13, 14)
+	}
+	for i := range x {
+		x[i] += w[i]
+	}
+	*tmp = x
+	copy(out[:16], x[:])
+}
+
+func salsaQuarterRound(x *[16]uint32, a, b, c, d int) {
+	x[b] ^= bits.RotateLeft32(x[a]+x[d], 7)
+	x[c] ^= bits.RotateLeft32(x[b]+x[a], 9)
+	x[d] ^= bits.RotateLeft32(x[c]+x[b], 13)
+	x[a] ^= bits.RotateLeft32(x[d]+x[c], 18)
+}
@@ -60,6 +60,13 @@ const (
 	CANotAuthorizedForExtKeyUsage
 	// NoValidChains results when there are no valid chains to return.
 	NoValidChains
+	// Revoked results when a certificate has been revoked, according to a
+	// CRL or OCSP response given in the VerifyOptions.
+	Revoked
+	// RevocationStatusUnknown results when VerifyOptions.RequireRevocationStatus
+	// is set and the revocation status of a certificate can't be determined
+	// from the CRLs and OCSP responses given in the VerifyOptions.
+	RevocationStatusUnknown
 )
 
 // CertificateInvalidError results when an odd error occurs. Users of this
@@ -90,6 +97,10 @@ func (e CertificateInvalidError) Error() string {
 		return "x509: issuer has name constraints but leaf doesn't have a SAN extension"
 	case UnconstrainedName:
 		return "x509: issuer has name constraints but leaf contains unknown or unconstrained name: " + e.Detail
+	case Revoked:
+		return "x509: certificate has been revoked: " + e.Detail
+	case RevocationStatusUnknown:
+		return "x509: revocation status of certificate is unknown"
 	case NoValidChains:
 		s := "x509: no valid chains built"
 		if e.Detail != "" {
@@ -217,6 +228,29 @@ type VerifyOptions struct {
 	// field implies any valid policy is acceptable.
 	CertificatePolicies []OID
 
+	// CRLs is an optional list of certificate revocation lists used to check
+	// the revocation status of every certificate in a chain except the root.
+	// A CRL is only used for certificates issued by its issuer, if it is
+	// correctly signed by that issuer, and if CurrentTime is between its
+	// ThisUpdate and NextUpdate. Delta CRLs and indirect CRLs are not
+	// supported.
+	CRLs []*RevocationList
+
+	// OCSPResponses is an optional list of OCSP responses used to check the
+	// revocation status of every certificate in a chain except the root, such
+	// as stapled responses from [crypto/tls.ConnectionState.OCSPResponse]
+	// parsed with [ParseOCSPResponse]. A response is only used if it is for
+	// the certificate, if [OCSPResponse.CheckSignatureFrom] succeeds for the
+	// certificate's issuer, and if CurrentTime is between its ThisUpdate and
+	// NextUpdate.
+	OCSPResponses []*OCSPResponse
+
+	// RequireRevocationStatus, if true, causes chains to be rejected unless
+	// a valid CRL or good OCSP response establishes the revocation status of
+	// every certificate in the chain except the root. Otherwise, chains are
+	// only rejected if a certificate is known to be revoked.
+	RequireRevocationStatus bool
+
 	// The following policy fields are unexported, because we do not expect
 	// users to actually need to use them, but are useful for testing the
 	// policy validation code.
@@ -773,7 +807,8 @@ func (c *Certificate) isValid(certType int, currentChain []*Certificate, opts *V
 //
 // Certificates other than c in the returned chains should not be modified.
 //
-// WARNING: this function doesn't do any revocation checking.
+// Revocation checking is only performed against the CRLs and OCSP responses
+// provided in opts, which are never fetched from the network.
 func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err error) {
 	// Platform-specific verification needs the ASN.1 contents so
 	// this makes the behavior consistent across platforms.
@@ -796,15 +831,22 @@ func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err e
 		// i.e. if SetFallbackRoots was called with x509usefallbackroots=1.
 		systemPool := systemRootsPool()
 		if opts.Roots == nil && (systemPool == nil || systemPool.systemPool) {
-			return c.systemVerify(&opts)
+			platformChains, err := c.systemVerify(&opts)
+			if err != nil {
+				return nil, err
+			}
+			return filterRevokedChains(platformChains, &opts)
 		}
 		if opts.Roots != nil && opts.Roots.systemPool {
 			platformChains, err := c.systemVerify(&opts)
 			// If the platform verifier succeeded, or there are no additional
 			// roots, return the platform verifier result. Otherwise, continue
 			// with the Go verifier.
-			if err == nil || opts.Roots.len() == 0 {
-				return platformChains, err
+			if err == nil {
+				return filterRevokedChains(platformChains, &opts)
+			}
+			if opts.Roots.len() == 0 {
+				return nil, err
 			}
 		}
 	}
@@ -842,31 +884,46 @@ func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err e
 		opts.KeyUsages = []ExtKeyUsage{ExtKeyUsageServerAuth}
 	}
 
+	anyKeyUsage := false
 	for _, eku := range opts.KeyUsages {
 		if eku == ExtKeyUsageAny {
 			// If any key usage is acceptable, no need to check the chain for
 			// key usages.
-			return candidateChains, nil
+			anyKeyUsage = true
+			break
 		}
 	}
 
 	chains = make([][]*Certificate, 0, len(candidateChains))
-	var incompatibleKeyUsageChains, invalidPoliciesChains int
+	var incompatibleKeyUsageChains, invalidPoliciesChains, revokedChains int
+	var revocationErr error
 	for _, candidate := range candidateChains {
-		if !checkChainForKeyUsage(candidate, opts.KeyUsages) {
-			incompatibleKeyUsageChains++
-			continue
+		if !anyKeyUsage {
+			if !checkChainForKeyUsage(candidate, opts.KeyUsages) {
+				incompatibleKeyUsageChains++
+				continue
+			}
+			if !policiesValid(candidate, opts) {
+				invalidPoliciesChains++
+				continue
+			}
 		}
-		if !policiesValid(candidate, opts) {
-			invalidPoliciesChains++
+		if err := checkChainRevocation(candidate, &opts); err != nil {
+			if revocationErr == nil {
+				revocationErr = err
+			}
+			revokedChains++
 			continue
 		}
 		chains = append(chains, candidate)
 	}
 	if len(chains) == 0 {
+		if revokedChains > 0 && incompatibleKeyUsageChains == 0 && invalidPoliciesChains == 0 {
+			return nil, revocationErr
+		}
 		var details []string
 		if incompatibleKeyUsageChains > 0 {
-			if invalidPoliciesChains == 0 {
+			if invalidPoliciesChains == 0 && revokedChains == 0 {
 				return nil, CertificateInvalidError{c, IncompatibleUsage, ""}
 			}
 			details = append(details, fmt.Sprintf("%d chains with incompatible key usage", incompatibleKeyUsageChains))
@@ -874,6 +931,9 @@ func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err e
 		if invalidPoliciesChains > 0 {
 			details = append(details, fmt.Sprintf("%d chains with invalid policies", invalidPoliciesChains))
 		}
+		if revokedChains > 0 {
+			details = append(details, fmt.Sprintf("%d chains with revoked certificates or unknown revocation status", revokedChains))
+		}
 		err = CertificateInvalidError{c, NoValidChains, strings.Join(details, ", ")}
 		return nil, err
 	}
@@ -881,6 +941,84 @@ func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err e
 	return chains, nil
 }
 
+// checkChainRevocation checks the revocation status of every certificate in
+// chain except the root against opts.CRLs and opts.OCSPResponses.
+func checkChainRevocation(chain []*Certificate, opts *VerifyOptions) error {
+	if len(opts.CRLs) == 0 && len(opts.OCSPResponses) == 0 && !opts.RequireRevocationStatus {
+		return nil
+	}
+	now := opts.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		known := false
+
+		for _, crl := range opts.CRLs {
+			if !bytes.Equal(crl.RawIssuer, cert.RawIssuer) ||
+				now.Before(crl.ThisUpdate) ||
+				!crl.NextUpdate.IsZero() && now.After(crl.NextUpdate) {
+				continue
+			}
+			if crl.CheckSignatureFrom(issuer) != nil {
+				continue
+			}
+			known = true
+			for _, entry := range crl.RevokedCertificateEntries {
+				if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+					return CertificateInvalidError{cert, Revoked, "listed in CRL, revoked at " + entry.RevocationTime.Format(time.RFC3339)}
+				}
+			}
+		}
+
+		for _, resp := range opts.OCSPResponses {
+			if now.Before(resp.ThisUpdate) ||
+				!resp.NextUpdate.IsZero() && now.After(resp.NextUpdate) {
+				continue
+			}
+			if resp.Certificate != nil && (now.Before(resp.Certificate.NotBefore) || now.After(resp.Certificate.NotAfter)) {
+				continue
+			}
+			if !resp.isFor(cert, issuer) || resp.CheckSignatureFrom(issuer) != nil {
+				continue
+			}
+			switch resp.Status {
+			case OCSPRevoked:
+				return CertificateInvalidError{cert, Revoked, "OCSP response, revoked at " + resp.RevokedAt.Format(time.RFC3339)}
+			case OCSPGood:
+				known = true
+			}
+		}
+
+		if !known && opts.RequireRevocationStatus {
+			return CertificateInvalidError{cert, RevocationStatusUnknown, ""}
+		}
+	}
+	return nil
+}
+
+// filterRevokedChains removes the chains that fail checkChainRevocation. If
+// no chains are left, it returns the first revocation error.
+func filterRevokedChains(chains [][]*Certificate, opts *VerifyOptions) ([][]*Certificate, error) {
+	var valid [][]*Certificate
+	var firstErr error
+	for _, chain := range chains {
+		if err := checkChainRevocation(chain, opts); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		valid = append(valid, chain)
+	}
+	if len(valid) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return valid, nil
+}
+
 func appendToFreshChain(chain []*Certificate, cert *Certificate) []*Certificate {
 	n := make([]*Certificate, len(chain)+1)
 	copy(n, chain)
@@ -0,0 +1,33 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package internal contains declarations shared by the
+// encoding/json, encoding/json/v2, and encoding/json/jsontext packages.
+package internal
+
+import "errors"
+
+// NotForPublicUse is a marker type that an API is for internal use only.
+// It does not perfectly prevent usage of that API, but helps to restrict usage.
+// Anything with this marker is not covered by the Go compatibility agreement.
+type NotForPublicUse struct{}
+
+// AllowInternalUse is passed from "json" to "jsontext" to authenticate
+// that the caller can have access to internal functionality.
+var AllowInternalUse NotForPublicUse
+
+// Sentinel errors produced by "json/v2" and recognized by "json"
+// to reconstruct the historical error types of the v1 API.
+var (
+	ErrCycle            = errors.New("encountered a cycle")
+	ErrNonNilReference  = errors.New("value must be passed as a non-nil pointer reference")
+	ErrNonFiniteNumber  = errors.New("unsupported non-finite number")
+	ErrNilInterface     = errors.New("cannot derive concrete type for nil interface with finite type set")
+	ErrMismatchedLength = errors.New("mismatching array length")
+)
+
+// NewMarshalerError constructs an error wrapping err as returned by
+// a MarshalJSON or MarshalText method (as named by funcName) of val.
+// It is injected by "json" when operating under legacy error semantics.
+var NewMarshalerError func(val any, err error, funcName string) error
@@ -0,0 +1,88 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsonopts_test
+
+import (
+	"testing"
+
+	"encoding/json/internal/jsonflags"
+	. "encoding/json/internal/jsonopts"
+)
+
+func makeFlags(f ...jsonflags.Bools) (fs jsonflags.Flags) {
+	for _, f := range f {
+		fs.Set(f)
+	}
+	return fs
+}
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		in           Options
+		excludeCoder bool
+		want         *Struct
+	}{{
+		in:   jsonflags.AllowInvalidUTF8 | 1,
+		want: &Struct{Flags: makeFlags(jsonflags.AllowInvalidUTF8 | 1)},
+	}, {
+		in: jsonflags.Multiline | 0,
+		want: &Struct{
+			Flags: makeFlags(jsonflags.AllowInvalidUTF8|1, jsonflags.Multiline|0)},
+	}, {
+		in: Indent("\t"),
+		want: &Struct{
+			Flags:       makeFlags(jsonflags.AllowInvalidUTF8|1, jsonflags.Multiline|jsonflags.Indent|1),
+			CoderValues: CoderValues{Indent: "\t"},
+		},
+	}, {
+		in:           &Struct{Flags: makeFlags(jsonflags.Multiline|0, jsonflags.Deterministic|1)},
+		excludeCoder: true,
+		want: &Struct{
+			Flags:       makeFlags(jsonflags.AllowInvalidUTF8|1, jsonflags.Multiline|jsonflags.Indent|1, jsonflags.Deterministic|1),
+			CoderValues: CoderValues{Indent: "\t"},
+		},
+	}, {
+		in:   &DefaultOptionsV1,
+		want: &DefaultOptionsV1, // v1 fully replaces before (including Indent)
+	}, {
+		in:   &DefaultOptionsV2,
+		want: &DefaultOptionsV2, // v2 fully replaces before (including Indent)
+	}}
+	got := new(Struct)
+	for i, tt := range tests {
+		if tt.excludeCoder {
+			got.JoinWithoutCoderOptions(tt.in)
+		} else {
+			got.Join(tt.in)
+		}
+		if *got != *tt.want {
+			t.Fatalf("%d: Join:\n\tgot:  %+v\n\twant: %+v", i, got, tt.want)
+		}
+	}
+}
+
+func TestGetOption(t *testing.T) {
+	opts := &Struct{}
+	opts.Join(jsonflags.AllowDuplicateNames|1, Indent("  "))
+	if v, ok := GetOption(opts, func(v bool) Options {
+		if v {
+			return jsonflags.AllowDuplicateNames | 1
+		}
+		return jsonflags.AllowDuplicateNames | 0
+	}); !v || !ok {
+		t.Errorf("GetOption(AllowDuplicateNames) = (%v, %v), want (true, true)", v, ok)
+	}
+	if v, ok := GetOption(opts, func(v bool) Options {
+		if v {
+			return jsonflags.Deterministic | 1
+		}
+		return jsonflags.Deterministic | 0
+	}); v || ok {
+		t.Errorf("GetOption(Deterministic) = (%v, %v), want (false, false)", v, ok)
+	}
+	if v, ok := GetOption(opts, func(v string) Options { return Indent(v) }); v != "  " || !ok {
+		t.Errorf("GetOption(Indent) = (%q, %v), want (%q, true)", v, ok, "  ")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsonwire
+
+import (
+	"io"
+	"math"
+	"testing"
+
+	"encoding/json/internal/jsonflags"
+)
+
+func TestConsumeString(t *testing.T) {
+	tests := []struct {
+		in            string
+		validateUTF8  bool
+		wantN         int
+		wantVerbatim  bool
+		wantCanonical bool
+		wantErr       bool
+	}{
+		{in: `""`, wantN: 2, wantVerbatim: true, wantCanonical: true},
+		{in: `"hello" `, wantN: 7, wantVerbatim: true, wantCanonical: true},
+		{in: `"\u0041"`, wantN: 8},
+		{in: `"\n"`, wantN: 4, wantCanonical: true},
+		{in: `"\/"`, wantN: 4},
+		{in: `"\ud83d\ude00"`, wantN: 14},
+		{in: `"abc`, wantN: 4, wantErr: true},
+		{in: `"\x"`, wantN: 1, wantErr: true},
+		{in: "\"\x00\"", wantN: 1, wantErr: true},
+		{in: "\"\xff\"", validateUTF8: true, wantN: 1, wantErr: true},
+		{in: "\"\xff\"", wantN: 3},
+	}
+	for _, tt := range tests {
+		var flags ValueFlags
+		n, err := ConsumeString(&flags, []byte(tt.in), tt.validateUTF8)
+		if n != tt.wantN || (err != nil) != tt.wantErr {
+			t.Errorf("ConsumeString(%q) = (%d, %v), want (%d, error=%v)", tt.in, n, err, tt.wantN, tt.wantErr)
+		}
+		if err == nil {
+			if flags.IsVerbatim() != tt.wantVerbatim {
+				t.Errorf("ConsumeString(%q).IsVerbatim() = %v, want %v", tt.in, flags.IsVerbatim(), tt.wantVerbatim)
+			}
+			if flags.IsCanonical() != tt.wantCanonical {
+				t.Errorf("ConsumeString(%q).IsCanonical() = %v, want %v", tt.in, flags.IsCanonical(), tt.wantCanonical)
+			}
+		}
+	}
+}
+
+func TestAppendQuoteUnquote(t *testing.T) {
+	tests := []struct {
+		in       string
+		flags    jsonflags.Bools
+		want     string
+		wantErr  error
+		unquoted string // if different from in
+	}{
+		{in: "", want: `""`},
+		{in: "hello", want: `"hello"`},
+		{in: "\"\\\b\f\n\r\t", want: `"\"\\\b\f\n\r\t"`},
+		{in: "\x00\x1f\x7f", want: `"\u0000\u001f` + "\x7f" + `"`},
+		{in: "<>&", want: `"<>&"`},
+		{in: "<>&", flags: jsonflags.EscapeForHTML | 1, want: `"\u003c\u003e\u0026"`},
+		{in: "\u2028\u2029", flags: jsonflags.EscapeForJS | 1, want: `"\u2028\u2029"`},
+		{in: "\b\f", flags: jsonflags.EscapeWithLegacySemantics | 1, want: `"\b\f"`},
+		{in: "a\xffb", want: "\"a\uFFFDb\"", wantErr: ErrInvalidUTF8},
+		{in: "a\xffb", flags: jsonflags.AllowInvalidUTF8 | 1, want: "\"a\uFFFDb\"", unquoted: "a\uFFFDb"},
+		{in: "a\xffb", flags: jsonflags.AllowInvalidUTF8 | jsonflags.EscapeWithLegacySemantics | 1, want: `"a\ufffdb"`, unquoted: "a\uFFFDb"},
+	}
+	for _, tt := range tests {
+		var flags jsonflags.Flags
+		flags.Set(tt.flags)
+		got, err := AppendQuote(nil, tt.in, &flags)
+		if string(got) != tt.want || err != tt.wantErr {
+			t.Errorf("AppendQuote(%q) = (%s, %v), want (%s, %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		want := tt.in
+		if tt.unquoted != "" {
+			want = tt.unquoted
+		}
+		if got, err := AppendUnquote(nil, got); string(got) != want || err != nil {
+			t.Errorf("AppendUnquote(AppendQuote(%q)) = (%q, %v), want (%q, nil)", tt.in, got, err, want)
+		}
+	}
+}
+
+func TestConsumeNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantN   int
+		wantErr error
+	}{
+		{in: "0", wantN: 1},
+		{in: "-0.5e+10,", wantN: 8},
+		{in: "123]", wantN: 3},
+		{in: "01", wantN: 1},
+		{in: "-", wantN: 1, wantErr: io.ErrUnexpectedEOF},
+		{in: "1.", wantN: 1, wantErr: io.ErrUnexpectedEOF},
+		{in: "1e", wantN: 1, wantErr: io.ErrUnexpectedEOF},
+		{in: "-x", wantN: 1},
+		{in: "1.x", wantN: 2},
+	}
+	for _, tt := range tests {
+		n, err := ConsumeNumber([]byte(tt.in))
+		if n != tt.wantN {
+			t.Errorf("ConsumeNumber(%q) = %d, want %d", tt.in, n, tt.wantN)
+		}
+		if tt.wantErr != nil && err != tt.wantErr {
+			t.Errorf("ConsumeNumber(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseNumbers(t *testing.T) {
+	if v, ok := ParseUint([]byte("18446744073709551615")); !ok || v != math.MaxUint64 {
+		t.Errorf("ParseUint(MaxUint64) = (%d, %v)", v, ok)
+	}
+	if v, ok := ParseUint([]byte("18446744073709551616")); ok || v != math.MaxUint64 {
+		t.Errorf("ParseUint(MaxUint64+1) = (%d, %v), want (MaxUint64, false)", v, ok)
+	}
+	if v, ok := ParseFloat([]byte("1e1000"), 64); ok || v != math.MaxFloat64 {
+		t.Errorf("ParseFloat(1e1000) = (%v, %v), want (MaxFloat64, false)", v, ok)
+	}
+	if v, ok := ParseFloat([]byte("-1e100"), 32); ok || v != -math.MaxFloat32 {
+		t.Errorf("ParseFloat(-1e100, 32) = (%v, %v), want (-MaxFloat32, false)", v, ok)
+	}
+}
+
+func TestAppendFloat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		bits int
+		want string
+	}{
+		{0, 64, "0"},
+		{math.Copysign(0, -1), 64, "-0"},
+		{1e20, 64, "100000000000000000000"},
+		{1e21, 64, "1e+21"},
+		{1e-6, 64, "0.000001"},
+		{1e-7, 64, "1e-7"},
+		{0.1, 32, "0.1"},
+	}
+	for _, tt := range tests {
+		if got := string(AppendFloat(nil, tt.in, tt.bits)); got != tt.want {
+			t.Errorf("AppendFloat(%v, %d) = %s, want %s", tt.in, tt.bits, got, tt.want)
+		}
+	}
+}
+
+func TestTruncatePointer(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"/a/b", 100, "/a/b"},
+		{"/aaaaaaaaaa/bbbbbbbbbb/cccccccccc", 16, "/aaaaaaa…/…/…cccccccc"},
+		{"/abcdefghijklmnopqrstuvwxyz", 10, "/abcd…vwxyz"},
+	}
+	for _, tt := range tests {
+		if got := TruncatePointer(tt.in, tt.n); got != tt.want {
+			t.Errorf("TruncatePointer(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
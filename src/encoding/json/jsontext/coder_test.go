@@ -0,0 +1,329 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// readers returns a set of readers that all produce in,
+// but with different chunking behavior.
+func readers(in string) map[string]io.Reader {
+	return map[string]io.Reader{
+		"Whole":   strings.NewReader(in),
+		"OneByte": iotest.OneByteReader(strings.NewReader(in)),
+		"Half":    iotest.HalfReader(strings.NewReader(in)),
+	}
+}
+
+func TestDecoderReadToken(t *testing.T) {
+	const in = ` {"name":"value", "array":[null, false,true,3.14159,-1e10,"xé"], "object":{"k":"v"}} 123 "abc" [] `
+	type tokenInfo struct {
+		kind    Kind
+		str     string
+		pointer Pointer
+		offset  int64
+	}
+	want := []tokenInfo{
+		{'{', "{", "", 2},
+		{'"', "name", "/name", 8},
+		{'"', "value", "/name", 16},
+		{'"', "array", "/array", 25},
+		{'[', "[", "/array", 27},
+		{'n', "null", "/array/0", 31},
+		{'f', "false", "/array/1", 38},
+		{'t', "true", "/array/2", 43},
+		{'0', "3.14159", "/array/3", 51},
+		{'0', "-1e10", "/array/4", 57},
+		{'"', "xé", "/array/5", 63},
+		{']', "]", "/array", 64},
+		{'"', "object", "/object", 74},
+		{'{', "{", "/object", 76},
+		{'"', "k", "/object/k", 79},
+		{'"', "v", "/object/k", 83},
+		{'}', "}", "/object", 84},
+		{'}', "}", "", 85},
+		{'0', "123", "", 89},
+		{'"', "abc", "", 95},
+		{'[', "[", "", 97},
+		{']', "]", "", 98},
+	}
+	for name, r := range readers(in) {
+		t.Run(name, func(t *testing.T) {
+			d := NewDecoder(r)
+			var got []tokenInfo
+			for {
+				tok, err := d.ReadToken()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadToken error: %v", err)
+				}
+				got = append(got, tokenInfo{tok.Kind(), tok.String(), d.StackPointer(), d.InputOffset()})
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d tokens, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("token %d = %+v, want %+v", i, got[i], want[i])
+				}
+			}
+			if d.StackDepth() != 0 {
+				t.Errorf("StackDepth = %d, want 0", d.StackDepth())
+			}
+		})
+	}
+}
+
+func TestDecoderReadValue(t *testing.T) {
+	const in = ` {"a" : [1, 2.5, {"b":null}]}	"x" 1e3 true [ ] `
+	want := []string{`{"a" : [1, 2.5, {"b":null}]}`, `"x"`, `1e3`, `true`, `[ ]`}
+	for name, r := range readers(in) {
+		t.Run(name, func(t *testing.T) {
+			d := NewDecoder(r)
+			var got []string
+			for {
+				v, err := d.ReadValue()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadValue error: %v", err)
+				}
+				got = append(got, string(v))
+			}
+			if strings.Join(got, "|") != strings.Join(want, "|") {
+				t.Errorf("ReadValue:\n\tgot  %q\n\twant %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDecoderMixed(t *testing.T) {
+	d := NewDecoder(strings.NewReader(`{"skip":{"x":[1,2,3]},"keep":[true,"v"]}`))
+	mustToken := func(want Kind) Token {
+		t.Helper()
+		tok, err := d.ReadToken()
+		if err != nil {
+			t.Fatalf("ReadToken error: %v", err)
+		}
+		if tok.Kind() != want {
+			t.Fatalf("ReadToken kind = %v, want %v", tok.Kind(), want)
+		}
+		return tok
+	}
+	mustToken('{')
+	if tok := mustToken('"'); tok.String() != "skip" {
+		t.Fatalf("name = %q, want %q", tok.String(), "skip")
+	}
+	if k := d.PeekKind(); k != '{' {
+		t.Fatalf("PeekKind = %v, want '{'", k)
+	}
+	if err := d.SkipValue(); err != nil {
+		t.Fatalf("SkipValue error: %v", err)
+	}
+	mustToken('"')
+	v, err := d.ReadValue()
+	if err != nil {
+		t.Fatalf("ReadValue error: %v", err)
+	}
+	if string(v) != `[true,"v"]` {
+		t.Fatalf("ReadValue = %s, want %s", v, `[true,"v"]`)
+	}
+	if tok := mustToken('}'); tok.Kind() != '}' {
+		t.Fatal("unreachable")
+	}
+	if _, err := d.ReadToken(); err != io.EOF {
+		t.Fatalf("ReadToken error = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		in      string
+		opts    []Options
+		wantErr string
+	}{
+		{in: `{"a":1,"a":2}`, wantErr: `jsontext: duplicate object member name "a"`},
+		{in: `{"a":1,"a":2}`, opts: []Options{AllowDuplicateNames(true)}},
+		{in: `[1,2`, wantErr: `unexpected EOF`},
+		{in: `{"a" 1}`, wantErr: `jsontext: invalid character '1' after object name (expecting ':') within "/a" after offset 5`},
+		{in: `[1 2]`, wantErr: `jsontext: invalid character '2' after array element (expecting ',' or ']')`},
+		{in: `[true:]`, wantErr: `jsontext: invalid character ':' after array element (expecting ',' or ']')`},
+		{in: `[1,]`, wantErr: `jsontext: invalid character ']' at start of value`},
+		{in: `{"a",1}`, wantErr: `jsontext: invalid character ',' after object name (expecting ':')`},
+		{in: `1 :`, wantErr: `jsontext: invalid character ':' at start of value after offset 2`},
+		{in: `{1:2}`, wantErr: `after offset 1`},
+		{in: `"\x"`, wantErr: "jsontext: invalid escape sequence `\\x` in string after offset 1"},
+		{in: "\"\xff\"", wantErr: `jsontext: invalid UTF-8 after offset 1`},
+		{in: "\"\xff\"", opts: []Options{AllowInvalidUTF8(true)}},
+		{in: `tru`, wantErr: `jsontext: unexpected EOF after offset 3`},
+		{in: `[}`, wantErr: `within "/0" after offset 1`},
+		{in: `]`, wantErr: `jsontext: `},
+		{in: `nul`, wantErr: `unexpected EOF`},
+		{in: `nulL`, wantErr: `invalid character 'L' in literal null (expecting 'l')`},
+	}
+	for _, tt := range tests {
+		for _, read := range []string{"ReadToken", "ReadValue"} {
+			d := NewDecoder(strings.NewReader(tt.in), tt.opts...)
+			var err error
+			for err == nil {
+				if read == "ReadToken" {
+					_, err = d.ReadToken()
+				} else {
+					_, err = d.ReadValue()
+				}
+			}
+			switch {
+			case tt.wantErr == "" && err != io.EOF:
+				t.Errorf("%s(%q) error = %v, want io.EOF", read, tt.in, err)
+			case tt.wantErr != "" && (err == io.EOF || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("%s(%q) error = %v, want %q", read, tt.in, err, tt.wantErr)
+			case tt.wantErr != "":
+				var serr *SyntacticError
+				if !errors.As(err, &serr) {
+					t.Errorf("%s(%q) error = %T, want *SyntacticError", read, tt.in, err)
+				}
+			}
+		}
+	}
+}
+
+func TestDecoderIOError(t *testing.T) {
+	wantErr := errors.New("some error")
+	d := NewDecoder(io.MultiReader(strings.NewReader(`[1,`), iotest.ErrReader(wantErr)))
+	var err error
+	for err == nil {
+		_, err = d.ReadToken()
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("ReadToken error = %v, want %v", err, wantErr)
+	}
+	var serr *SyntacticError
+	if errors.As(err, &serr) {
+		t.Errorf("ReadToken error = %v, want I/O error", err)
+	}
+}
+
+func TestEncoderWriteToken(t *testing.T) {
+	tests := []struct {
+		opts []Options
+		want string
+	}{{
+		want: `{"name":"value","array":[null,false,true,1.5,-3,7,"<>&"],"object":{}}` + "\n" + `"top"` + "\n",
+	}, {
+		opts: []Options{Multiline(true), WithIndent("  ")},
+		want: "{\n  \"name\": \"value\",\n  \"array\": [\n    null,\n    false,\n    true,\n    1.5,\n    -3,\n    7,\n    \"<>&\"\n  ],\n  \"object\": {}\n}\n\"top\"\n",
+	}, {
+		opts: []Options{SpaceAfterColon(true), SpaceAfterComma(true), EscapeForHTML(true)},
+		want: `{"name": "value", "array": [null, false, true, 1.5, -3, 7, "\u003c\u003e\u0026"], "object": {}}` + "\n" + `"top"` + "\n",
+	}}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		e := NewEncoder(&buf, tt.opts...)
+		for _, tok := range []Token{
+			BeginObject, String("name"), String("value"),
+			String("array"), BeginArray, Null, False, True, Float(1.5), Int(-3), Uint(7), String("<>&"), EndArray,
+			String("object"), BeginObject, EndObject,
+			EndObject, String("top"),
+		} {
+			if err := e.WriteToken(tok); err != nil {
+				t.Fatalf("WriteToken(%v) error: %v", tok, err)
+			}
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("output mismatch:\ngot:\n%s\nwant:\n%s", got, tt.want)
+		}
+		if got := e.OutputOffset(); got != int64(buf.Len()) {
+			t.Errorf("OutputOffset = %d, want %d", got, buf.Len())
+		}
+	}
+}
+
+func TestEncoderWriteValue(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	if err := e.WriteToken(BeginObject); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WriteValue(Value(` "k" `)); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WriteValue(Value(`[ 1 , { "x" : "A" } ]`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WriteToken(EndObject); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `{"k":[1,{"x":"A"}]}`+"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokens  []any // either Token or Value
+		wantErr string
+	}{
+		{"NonStringName", []any{BeginObject, Int(1)}, "object member name must be a string"},
+		{"NonStringNameValue", []any{BeginObject, Value(`1`)}, "object member name must be a string"},
+		{"DuplicateName", []any{BeginObject, String("a"), Null, String("a")}, `duplicate object member name "a"`},
+		{"MismatchDelim", []any{BeginArray, EndObject}, "mismatching structural token"},
+		{"InvalidValue", []any{Value(`{"a"}`)}, "invalid character '}' after object name (expecting ':')"},
+		{"TrailingValue", []any{Value(`1 2`)}, "after top-level value"},
+		{"InvalidUTF8", []any{String("\xff")}, "invalid UTF-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEncoder(io.Discard)
+			var err error
+			for _, x := range tt.tokens {
+				switch x := x.(type) {
+				case Token:
+					err = e.WriteToken(x)
+				case Value:
+					err = e.WriteValue(x)
+				}
+				if err != nil {
+					break
+				}
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	const in = `{"a":[1,-2.5e-7,"\u2028😀",{"":null}],"b":{"c":true,"d":false}}`
+	d := NewDecoder(iotest.OneByteReader(strings.NewReader(in)))
+	var buf bytes.Buffer
+	e := NewEncoder(&buf, EscapeForJS(true))
+	for {
+		tok, err := d.ReadToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := e.WriteToken(tok); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `{"a":[1,-2.5e-7,"\u2028😀",{"":null}],"b":{"c":true,"d":false}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
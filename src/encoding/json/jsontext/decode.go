@@ -0,0 +1,555 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"bytes"
+	"io"
+	"slices"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+)
+
+// Decoder is a streaming decoder for raw JSON tokens and values.
+// It is used to read a stream of top-level JSON values,
+// each separated by optional whitespace characters.
+//
+// [Decoder.ReadToken] and [Decoder.ReadValue] calls may be interleaved.
+// For example, the following JSON value:
+//
+//	{"name":"value","array":[null,false,true,3.14159],"object":{"k":"v"}}
+//
+// can be parsed with the following calls (ignoring errors for brevity):
+//
+//	d.ReadToken() // {
+//	d.ReadToken() // "name"
+//	d.ReadToken() // "value"
+//	d.ReadValue() // "array"
+//	d.ReadToken() // [
+//	d.ReadToken() // null
+//	d.ReadToken() // false
+//	d.ReadValue() // true
+//	d.ReadToken() // 3.14159
+//	d.ReadToken() // ]
+//	d.ReadValue() // "object"
+//	d.ReadValue() // {"k":"v"}
+//	d.ReadToken() // }
+//
+// The above is one of many possible sequence of calls and
+// may not represent the most sensible method to call for any given token/value.
+// For example, it is probably more common to call [Decoder.ReadToken] to obtain a
+// string token for object names.
+type Decoder struct {
+	s decoderState
+}
+
+// decoderState is the low-level state of Decoder.
+// It has exported fields and method for use by the "json" package.
+type decoderState struct {
+	state
+	decodeBuffer
+	jsonopts.Struct
+}
+
+// decodeBuffer is a buffer split into 4 segments:
+//
+//   - buf[0:prevEnd]         // already read portion of the buffer
+//   - buf[prevStart:prevEnd] // previously read value
+//   - buf[prevEnd:len(buf)]  // unread portion of the buffer
+//   - buf[len(buf):cap(buf)] // unused portion of the buffer
+//
+// Invariants:
+//
+//	0 <= prevStart <= prevEnd <= len(buf) <= cap(buf)
+type decodeBuffer struct {
+	buf       []byte
+	prevStart int
+	prevEnd   int
+
+	// baseOffset is added to prevStart and prevEnd to obtain
+	// the absolute offset relative to the start of io.Reader stream.
+	baseOffset int64
+
+	rd io.Reader
+}
+
+// minReadSize is the minimum amount of space in the buffer
+// that fetch ensures is available before reading.
+const minReadSize = 4 << 10
+
+// NewDecoder constructs a new streaming decoder reading from r.
+func NewDecoder(r io.Reader, opts ...Options) *Decoder {
+	d := new(Decoder)
+	d.Reset(r, opts...)
+	return d
+}
+
+// Reset resets a decoder such that it is reading afresh from r and
+// configured with the provided options. Reset must not be called on an
+// a Decoder passed to the [encoding/json/v2.UnmarshalerFrom.UnmarshalJSONFrom] method
+// or the [encoding/json/v2.UnmarshalFromFunc] function.
+func (d *Decoder) Reset(r io.Reader, opts ...Options) {
+	switch {
+	case d == nil:
+		panic("jsontext: invalid nil Decoder")
+	case r == nil:
+		panic("jsontext: invalid nil io.Reader")
+	case d.s.Flags.Get(jsonflags.WithinArshalCall):
+		panic("jsontext: cannot reset Decoder passed to json.UnmarshalerFrom")
+	}
+	d.s.reset(nil, r, opts...)
+}
+
+func (d *decoderState) reset(b []byte, r io.Reader, opts ...Options) {
+	d.state.reset()
+	d.decodeBuffer = decodeBuffer{buf: b, rd: r}
+	opts2 := jsonopts.Struct{} // avoid mutating d.Struct in case it is part of opts
+	opts2.Join(opts...)
+	d.Struct = opts2
+}
+
+// Options returns the options used to construct the decoder and
+// may additionally contain semantic options passed to a
+// [encoding/json/v2.UnmarshalDecode] call.
+//
+// If operating within
+// a [encoding/json/v2.UnmarshalerFrom.UnmarshalJSONFrom] method call or
+// a [encoding/json/v2.UnmarshalFromFunc] function call,
+// then the returned options are only valid within the call.
+func (d *Decoder) Options() Options {
+	return &d.s.Struct
+}
+
+func (d *decodeBuffer) previousOffsetStart() int64 {
+	return d.baseOffset + int64(d.prevStart)
+}
+
+func (d *decodeBuffer) previousOffsetEnd() int64 {
+	return d.baseOffset + int64(d.prevEnd)
+}
+
+func (d *decodeBuffer) previousBuffer() []byte {
+	return d.buf[d.prevStart:d.prevEnd]
+}
+
+// UnreadBuffer returns the data remaining in the buffer
+// that has not yet been consumed by the Decoder.
+func (d *decodeBuffer) UnreadBuffer() []byte {
+	return d.buf[d.prevEnd:len(d.buf)]
+}
+
+// fetch reads at least one more byte into the buffer.
+// It may discard the portion of the buffer that has already been read,
+// which invalidates any raw Token or Value previously returned,
+// but never discards the unread portion.
+// It returns io.EOF if no more data is available.
+func (d *decodeBuffer) fetch() error {
+	if d.rd == nil {
+		return io.EOF
+	}
+
+	// Discard the already read portion of the buffer.
+	if d.prevEnd > 0 {
+		n := copy(d.buf, d.buf[d.prevEnd:])
+		d.baseOffset += int64(d.prevEnd)
+		d.buf = d.buf[:n]
+		d.prevStart, d.prevEnd = 0, 0
+	}
+
+	// Ensure that there is sufficient space to read into.
+	if cap(d.buf)-len(d.buf) < minReadSize {
+		d.buf = slices.Grow(d.buf, max(len(d.buf), minReadSize))
+	}
+
+	for {
+		n, err := d.rd.Read(d.buf[len(d.buf):cap(d.buf)])
+		d.buf = d.buf[:len(d.buf)+n]
+		switch {
+		case n > 0:
+			return nil // ignore any error since it will occur again on the next read
+		case err == io.EOF:
+			return io.EOF
+		case err != nil:
+			return &ioError{action: "read", err: err}
+		}
+	}
+}
+
+// setPrevious records buf[prevEnd+pos:prevEnd+pos+n] as the most
+// recently read token or value.
+func (d *decodeBuffer) setPrevious(pos, n int) {
+	d.prevStart = d.prevEnd + pos
+	d.prevEnd = d.prevStart + n
+}
+
+// skipToNext consumes any leading whitespace and an optional delimiter,
+// returning the position of the next token relative to the unread buffer
+// and the delimiter encountered (if any).
+func (d *decoderState) skipToNext() (pos int, delim byte, err error) {
+	if pos, err = d.skipWhitespace(0); err != nil {
+		return pos, 0, err
+	}
+	if c := d.buf[d.prevEnd+pos]; c == ',' || c == ':' {
+		delim = c
+		if pos, err = d.skipWhitespace(pos + 1); err != nil {
+			return pos, delim, err
+		}
+	}
+	return pos, delim, nil
+}
+
+// skipWhitespace consumes whitespace starting at pos (relative to the
+// unread buffer) until a non-whitespace character is available.
+func (d *decoderState) skipWhitespace(pos int) (int, error) {
+	for {
+		b := d.UnreadBuffer()
+		pos += jsonwire.ConsumeWhitespace(b[pos:])
+		if pos < len(b) {
+			return pos, nil
+		}
+		if err := d.fetch(); err != nil {
+			return pos, err
+		}
+	}
+}
+
+// checkEOF converts an error encountered while skipping to the next token
+// into the appropriate error to return.
+// A clean io.EOF is only reported at the top-level between values.
+func (d *decoderState) checkEOF(err error, pos int, delim byte) error {
+	if err == io.EOF && delim != 0 && d.needDelim('n') != delim {
+		// Report a misplaced delimiter rather than the truncated input.
+		pos, err = d.checkDelim(d.UnreadBuffer(), pos, delim, 'n')
+	} else if err == io.EOF && (d.depth() > 0 || delim != 0) {
+		err = io.ErrUnexpectedEOF
+	}
+	return wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, pos, +1)
+}
+
+// checkDelim reports an error if delim is not the delimiter that must precede
+// the token starting at b[pos], along with the position of the offending character.
+func (d *decoderState) checkDelim(b []byte, pos int, delim byte, next Kind) (int, error) {
+	want := d.needDelim(next)
+	if want == delim {
+		return pos, nil
+	}
+	where := "at start of value"
+	if delim != 0 {
+		if want == 0 && d.needDelim('n') == delim {
+			// A valid delimiter is followed by the end of an object or array.
+			return pos, jsonwire.NewInvalidCharacterError(b[pos:], where)
+		}
+		want = d.needDelim('n')
+		pos = bytes.LastIndexByte(b[:pos], delim)
+	}
+	switch want {
+	case ':':
+		where = "after object name (expecting ':')"
+	case ',':
+		if d.last().kind == '{' {
+			where = "after object value (expecting ',' or '}')"
+		} else {
+			where = "after array element (expecting ',' or ']')"
+		}
+	}
+	return pos, jsonwire.NewInvalidCharacterError(b[pos:], where)
+}
+
+// PeekKind retrieves the next token kind, but does not advance the read offset.
+//
+// It returns 0 if an error occurs. Any such error is cached until
+// the next read call and it is the caller's responsibility to eventually
+// follow up a PeekKind call with a read call.
+func (d *Decoder) PeekKind() Kind {
+	return d.s.PeekKind()
+}
+func (d *decoderState) PeekKind() Kind {
+	pos, delim, err := d.skipToNext()
+	if err != nil {
+		return invalidKind
+	}
+	next := Kind(d.buf[d.prevEnd+pos]).normalize()
+	if _, err := d.checkDelim(d.UnreadBuffer(), pos, delim, next); err != nil {
+		return invalidKind
+	}
+	switch next {
+	case 'n', 'f', 't', '"', '0', '{', '}', '[', ']':
+		return next
+	default:
+		return invalidKind
+	}
+}
+
+// SkipValue is semantically equivalent to calling [Decoder.ReadValue] and discarding
+// the result except that memory is not wasted trying to hold the entire result.
+func (d *Decoder) SkipValue() error {
+	return d.s.SkipValue()
+}
+func (d *decoderState) SkipValue() error {
+	switch d.PeekKind() {
+	case '{', '[':
+		// Read tokens one at a time to avoid holding the entire value in memory.
+		depth := d.depth()
+		for {
+			if _, err := d.ReadToken(); err != nil {
+				return err
+			}
+			if d.depth() == depth {
+				return nil
+			}
+		}
+	default:
+		_, err := d.ReadValue()
+		return err
+	}
+}
+
+// ReadToken reads the next [Token], advancing the read offset.
+// The returned token is only valid until the next Peek, Read, or Skip call.
+// It returns [io.EOF] if there are no more tokens.
+func (d *Decoder) ReadToken() (Token, error) {
+	return d.s.ReadToken()
+}
+func (d *decoderState) ReadToken() (Token, error) {
+	pos, delim, err := d.skipToNext()
+	if err != nil {
+		return Token{}, d.checkEOF(err, pos, delim)
+	}
+	b := d.UnreadBuffer()
+	next := Kind(b[pos]).normalize()
+	if pos, err := d.checkDelim(b, pos, delim, next); err != nil {
+		return Token{}, wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, pos, +1)
+	}
+	if d.needObjectName() && next != '"' && next != '}' {
+		return Token{}, wrapSyntacticError(&d.state, d.previousOffsetEnd(), ErrNonStringName, pos, +1)
+	}
+
+	var n int
+	var tok Token
+	switch next {
+	case 'n', 'f', 't':
+		lit, t := "null", Null
+		switch next {
+		case 'f':
+			lit, t = "false", False
+		case 't':
+			lit, t = "true", True
+		}
+		if n, err = d.consume(pos, func(b []byte) (int, error) {
+			return jsonwire.ConsumeLiteral(b, lit)
+		}); err != nil {
+			break
+		}
+		err = d.appendLiteral()
+		tok = t
+	case '"':
+		var flags jsonwire.ValueFlags
+		validateUTF8 := !d.Flags.Get(jsonflags.AllowInvalidUTF8)
+		if n, err = d.consume(pos, func(b []byte) (int, error) {
+			flags = 0
+			return jsonwire.ConsumeString(&flags, b, validateUTF8)
+		}); err != nil {
+			break
+		}
+		b = d.UnreadBuffer()
+		err = d.appendString(!d.Flags.Get(jsonflags.AllowDuplicateNames), func() []byte {
+			return jsonwire.UnquoteMayCopy(b[pos:pos+n], flags.IsVerbatim())
+		})
+	case '0':
+		if n, err = d.consumeNumber(pos); err != nil {
+			break
+		}
+		err = d.appendNumber()
+	case '{':
+		if err = d.pushObject(); err == nil {
+			n, tok = 1, BeginObject
+		}
+	case '}':
+		if err = d.popObject(); err == nil {
+			n, tok = 1, EndObject
+		}
+	case '[':
+		if err = d.pushArray(); err == nil {
+			n, tok = 1, BeginArray
+		}
+	case ']':
+		if err = d.popArray(); err == nil {
+			n, tok = 1, EndArray
+		}
+	default:
+		err = jsonwire.NewInvalidCharacterError(b[pos:], "at start of value")
+	}
+	if err != nil {
+		return Token{}, wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, pos+n, +1)
+	}
+	d.setPrevious(pos, n)
+	if tok.raw == nil {
+		tok = Token{raw: &d.decodeBuffer, num: uint64(d.previousOffsetStart())}
+	}
+	return tok, nil
+}
+
+// consume calls fn on the unread buffer starting at pos,
+// fetching more data whenever fn reports io.ErrUnexpectedEOF.
+func (d *decoderState) consume(pos int, fn func([]byte) (int, error)) (int, error) {
+	for {
+		n, err := fn(d.UnreadBuffer()[pos:])
+		if err != io.ErrUnexpectedEOF {
+			return n, err
+		}
+		switch ferr := d.fetch(); {
+		case ferr == io.EOF:
+			return n, io.ErrUnexpectedEOF
+		case ferr != nil:
+			return n, ferr
+		}
+	}
+}
+
+// consumeNumber is like consume for a JSON number,
+// which is not self-terminating and so may need more data
+// even if the number appears complete.
+func (d *decoderState) consumeNumber(pos int) (int, error) {
+	for {
+		b := d.UnreadBuffer()[pos:]
+		n, err := jsonwire.ConsumeNumber(b)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return n, err
+		}
+		if err == nil && n < len(b) {
+			return n, nil
+		}
+		switch ferr := d.fetch(); {
+		case ferr == io.EOF:
+			if err == nil {
+				return n, nil // the number is terminated by the end of input
+			}
+			return n, io.ErrUnexpectedEOF
+		case ferr != nil:
+			return n, ferr
+		}
+	}
+}
+
+// ReadValue returns the next raw JSON value, advancing the read offset.
+// The value is stripped of any leading or trailing whitespace and
+// contains the exact bytes of the input, which may contain invalid UTF-8
+// if [AllowInvalidUTF8] is specified.
+//
+// The returned value is only valid until the next Peek, Read, or Skip call and
+// may not be mutated while the Decoder remains in use.
+// If the decoder is currently at the end token for an object or array,
+// then it reports a [SyntacticError] and the internal state remains unchanged.
+// It returns [io.EOF] if there are no more values.
+func (d *Decoder) ReadValue() (Value, error) {
+	return d.s.ReadValue()
+}
+func (d *decoderState) ReadValue() (Value, error) {
+	pos, delim, err := d.skipToNext()
+	if err != nil {
+		return nil, d.checkEOF(err, pos, delim)
+	}
+	b := d.UnreadBuffer()
+	next := Kind(b[pos]).normalize()
+	switch {
+	case next == '}' || next == ']':
+		err = jsonwire.NewInvalidCharacterError(b[pos:], "at start of value")
+	case d.needObjectName() && next != '"':
+		err = ErrNonStringName
+	default:
+		var errPos int
+		if errPos, err = d.checkDelim(b, pos, delim, next); err != nil {
+			return nil, wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, errPos, +1)
+		}
+	}
+	if err != nil {
+		return nil, wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, pos, +1)
+	}
+
+	// Consume the entire value, fetching more data as necessary.
+	f := valueFormatter{opts: &d.Struct}
+	var end int
+	for {
+		b = d.UnreadBuffer()
+		f.path = f.path[:0]
+		end, err = f.formatValue(b, pos, d.depth())
+		incomplete := err == io.ErrUnexpectedEOF || (err == nil && next == '0' && end == len(b))
+		if !incomplete {
+			break
+		}
+		if ferr := d.fetch(); ferr != nil {
+			if ferr != io.EOF {
+				err = ferr
+			}
+			break
+		}
+	}
+	if err != nil {
+		if _, ok := err.(*ioError); !ok {
+			err = f.newError(end-pos, err)
+		}
+		return nil, wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, pos, +1)
+	}
+
+	b = d.UnreadBuffer()
+	if err := d.appendValue(next, !d.Flags.Get(jsonflags.AllowDuplicateNames), func() []byte {
+		name, _ := jsonwire.AppendUnquote(nil, b[pos:end])
+		return name
+	}); err != nil {
+		return nil, wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, pos, +1)
+	}
+	d.setPrevious(pos, end-pos)
+	return d.previousBuffer(), nil
+}
+
+// InputOffset returns the current input byte offset. It gives the location
+// of the next byte immediately after the most recently returned token or value.
+// The number of bytes actually read from the underlying [io.Reader] may be more
+// than this offset due to internal buffering effects.
+func (d *Decoder) InputOffset() int64 {
+	return d.s.previousOffsetEnd()
+}
+
+// UnreadBuffer returns the data remaining in the unread buffer,
+// which may contain zero or more bytes.
+// The returned buffer must not be mutated while Decoder continues to be used.
+// The buffer contents are valid until the next Peek, Read, or Skip call.
+func (d *Decoder) UnreadBuffer() []byte {
+	return d.s.UnreadBuffer()
+}
+
+// StackDepth returns the depth of the state machine for read JSON data.
+// Each level on the stack represents a nested JSON object or array.
+// It is incremented whenever an [BeginObject] or [BeginArray] token is encountered
+// and decremented whenever an [EndObject] or [EndArray] token is encountered.
+// The depth is zero-indexed, where zero represents the top-level JSON value.
+func (d *Decoder) StackDepth() int {
+	// NOTE: Keep in sync with Encoder.StackDepth.
+	return d.s.depth()
+}
+
+// StackIndex returns information about the specified stack level.
+// It must be a number between 0 and [Decoder.StackDepth], inclusive.
+// For each level, it reports the kind:
+//
+//   - 0 for a level of zero,
+//   - '{' for a level representing a JSON object, and
+//   - '[' for a level representing a JSON array.
+//
+// It also reports the length of that JSON object or array.
+// Each name and value in a JSON object is counted separately,
+// so the effective number of members would be half the length.
+// A complete JSON object must have an even length.
+func (d *Decoder) StackIndex(i int) (Kind, int64) {
+	// NOTE: Keep in sync with Encoder.StackIndex.
+	return d.s.stackIndex(i)
+}
+
+// StackPointer returns a JSON Pointer (RFC 6901) to the most recently read value.
+func (d *Decoder) StackPointer() Pointer {
+	return Pointer(d.s.appendStackPointer(nil, -1))
+}
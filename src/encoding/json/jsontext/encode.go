@@ -0,0 +1,378 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"io"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+)
+
+// Encoder is a streaming encoder from raw JSON tokens and values.
+// It is used to write a stream of top-level JSON values,
+// each terminated with a newline character.
+//
+// [Encoder.WriteToken] and [Encoder.WriteValue] calls may be interleaved.
+// For example, the following JSON value:
+//
+//	{"name":"value","array":[null,false,true,3.14159],"object":{"k":"v"}}
+//
+// can be composed with the following calls (ignoring errors for brevity):
+//
+//	e.WriteToken(BeginObject)        // {
+//	e.WriteToken(String("name"))     // "name"
+//	e.WriteToken(String("value"))    // "value"
+//	e.WriteValue(Value(`"array"`))   // "array"
+//	e.WriteToken(BeginArray)         // [
+//	e.WriteToken(Null)               // null
+//	e.WriteToken(False)              // false
+//	e.WriteValue(Value("true"))      // true
+//	e.WriteToken(Float(3.14159))     // 3.14159
+//	e.WriteToken(EndArray)           // ]
+//	e.WriteValue(Value(`"object"`))  // "object"
+//	e.WriteValue(Value(`{"k":"v"}`)) // {"k":"v"}
+//	e.WriteToken(EndObject)          // }
+//
+// The above is one of many possible sequence of calls and
+// may not represent the most sensible method to call for any given token/value.
+// For example, it is probably more common to call [Encoder.WriteToken] with a string
+// for object names.
+type Encoder struct {
+	s encoderState
+}
+
+// encoderState is the low-level state of Encoder.
+// It has exported fields and method for use by the "json" package.
+type encoderState struct {
+	state
+	encodeBuffer
+	jsonopts.Struct
+
+	// SeenPointers is a map of pointers seen so far.
+	// It is used by the "json" package to detect cycles
+	// when marshaling deeply nested values.
+	SeenPointers map[any]struct{}
+}
+
+// encodeBuffer is a buffer split into 2 segments:
+//
+//   - buf[0:len(buf)]        // written (but unflushed) portion of the buffer
+//   - buf[len(buf):cap(buf)] // unused portion of the buffer
+type encodeBuffer struct {
+	Buf []byte
+
+	// baseOffset is added to len(buf) to obtain the absolute offset
+	// relative to the start of io.Writer stream.
+	baseOffset int64
+
+	wr io.Writer
+}
+
+// flushThreshold is the size of buffered output at which
+// the Encoder writes to the underlying io.Writer,
+// even if it has not completed writing the current top-level value.
+const flushThreshold = 64 << 10
+
+// NewEncoder constructs a new streaming encoder writing to w
+// configured with the provided options.
+// It flushes the internal buffer when the buffer is sufficiently full or
+// when a top-level value has been written.
+func NewEncoder(w io.Writer, opts ...Options) *Encoder {
+	e := new(Encoder)
+	e.Reset(w, opts...)
+	return e
+}
+
+// Reset resets an encoder such that it is writing afresh to w and
+// configured with the provided options. Reset must not be called on
+// a Encoder passed to the [encoding/json/v2.MarshalerTo.MarshalJSONTo] method
+// or the [encoding/json/v2.MarshalToFunc] function.
+func (e *Encoder) Reset(w io.Writer, opts ...Options) {
+	switch {
+	case e == nil:
+		panic("jsontext: invalid nil Encoder")
+	case w == nil:
+		panic("jsontext: invalid nil io.Writer")
+	case e.s.Flags.Get(jsonflags.WithinArshalCall):
+		panic("jsontext: cannot reset Encoder passed to json.MarshalerTo")
+	}
+	e.s.reset(nil, w, opts...)
+}
+
+func (e *encoderState) reset(b []byte, w io.Writer, opts ...Options) {
+	e.state.reset()
+	e.encodeBuffer = encodeBuffer{Buf: b, wr: w}
+	opts2 := jsonopts.Struct{} // avoid mutating e.Struct in case it is part of opts
+	opts2.Join(opts...)
+	e.Struct = opts2
+	normalizeFormatOptions(&e.Struct)
+	e.SeenPointers = nil
+}
+
+// normalizeFormatOptions applies the defaults implied by Multiline.
+func normalizeFormatOptions(o *jsonopts.Struct) {
+	if o.Flags.Get(jsonflags.Multiline) {
+		if !o.Flags.Has(jsonflags.SpaceAfterColon) {
+			o.Flags.Set(jsonflags.SpaceAfterColon | 1)
+		}
+		if !o.Flags.Has(jsonflags.SpaceAfterComma) {
+			o.Flags.Set(jsonflags.SpaceAfterComma | 0)
+		}
+		if !o.Flags.Has(jsonflags.Indent) {
+			o.Flags.Set(jsonflags.Indent | 1)
+			o.Indent = "\t"
+		}
+	}
+}
+
+// Options returns the options used to construct the encoder and
+// may additionally contain semantic options passed to a
+// [encoding/json/v2.MarshalEncode] call.
+//
+// If operating within
+// a [encoding/json/v2.MarshalerTo.MarshalJSONTo] method call or
+// a [encoding/json/v2.MarshalToFunc] function call,
+// then the returned options are only valid within the call.
+func (e *Encoder) Options() Options {
+	return &e.s.Struct
+}
+
+// NeedFlush determines whether to flush at this point.
+func (e *encoderState) NeedFlush() bool {
+	// NOTE: This function is carefully written to be inlinable.
+	return e.wr != nil && (e.depth() == 0 || len(e.Buf) > flushThreshold)
+}
+
+// Flush flushes the buffer to the underlying io.Writer.
+func (e *encoderState) Flush() error {
+	if e.wr == nil || len(e.Buf) == 0 {
+		return nil
+	}
+	n, err := e.wr.Write(e.Buf)
+	e.baseOffset += int64(n)
+	if err != nil {
+		// In the event of an error, preserve the unflushed portion.
+		e.Buf = e.Buf[:copy(e.Buf, e.Buf[n:])]
+		return &ioError{action: "write", err: err}
+	}
+	e.Buf = e.Buf[:0]
+	return nil
+}
+
+// previousOffsetEnd returns the offset immediately after the end
+// of the most recently written token or value.
+func (e *encodeBuffer) previousOffsetEnd() int64 {
+	return e.baseOffset + int64(len(e.Buf))
+}
+
+// appendDelimAndIndent appends any delimiter and whitespace needed
+// before a token of the next kind.
+func (e *encoderState) appendDelimAndIndent(b []byte, next Kind) []byte {
+	multiline := e.Flags.Get(jsonflags.Multiline)
+	last := e.last()
+	if next == '}' || next == ']' {
+		if multiline && last.length > 0 && last.kind != 0 {
+			b = appendIndent(b, &e.Struct, e.depth()-1)
+		}
+		return b
+	}
+	switch e.needDelim(next) {
+	case ':':
+		b = append(b, ':')
+		if e.Flags.Get(jsonflags.SpaceAfterColon) {
+			b = append(b, ' ')
+		}
+		return b
+	case ',':
+		b = append(b, ',')
+		if !multiline && e.Flags.Get(jsonflags.SpaceAfterComma) {
+			b = append(b, ' ')
+		}
+	}
+	if multiline && last.kind != 0 {
+		b = appendIndent(b, &e.Struct, e.depth())
+	}
+	return b
+}
+
+// appendTopLevelNewline appends a newline after a completed top-level value.
+func (e *encoderState) appendTopLevelNewline() {
+	if e.depth() == 0 && !e.Flags.Get(jsonflags.OmitTopLevelNewline) {
+		e.Buf = append(e.Buf, '\n')
+	}
+}
+
+// WriteToken writes the next token and advances the internal write offset.
+//
+// The provided token kind must be consistent with the JSON grammar.
+// For example, it is an error to provide a number when the encoder
+// is expecting an object name (which is always a string), or
+// to provide an end object delimiter when the encoder is finishing an array.
+// If the provided token is invalid, then it reports a [SyntacticError] and
+// the internal state remains unchanged. The offset reported
+// in [SyntacticError] will be relative to the [Encoder.OutputOffset].
+func (e *Encoder) WriteToken(t Token) error {
+	return e.s.WriteToken(t)
+}
+func (e *encoderState) WriteToken(t Token) error {
+	k := t.Kind()
+	b := e.Buf // use local variable to avoid mutating e in case of error
+
+	// Append any delimiters or optional whitespace.
+	b = e.appendDelimAndIndent(b, k)
+
+	var err error
+	switch k {
+	case 'n':
+		b = append(b, "null"...)
+		err = e.appendLiteral()
+	case 'f':
+		b = append(b, "false"...)
+		err = e.appendLiteral()
+	case 't':
+		b = append(b, "true"...)
+		err = e.appendLiteral()
+	case '"':
+		pos := len(b)
+		if b, err = t.appendString(b, &e.Flags); err != nil {
+			break
+		}
+		err = e.appendString(!e.Flags.Get(jsonflags.AllowDuplicateNames), func() []byte {
+			if t.raw == nil {
+				return []byte(t.str)
+			}
+			name, _ := jsonwire.AppendUnquote(nil, b[pos:])
+			return name
+		})
+	case '0':
+		if b, err = t.appendNumber(b, &e.Flags); err != nil {
+			break
+		}
+		err = e.appendNumber()
+	case '{':
+		b = append(b, '{')
+		err = e.pushObject()
+	case '}':
+		if err = e.popObject(); err != nil {
+			break
+		}
+		b = append(b, '}')
+	case '[':
+		b = append(b, '[')
+		err = e.pushArray()
+	case ']':
+		if err = e.popArray(); err != nil {
+			break
+		}
+		b = append(b, ']')
+	default:
+		err = errInvalidToken
+	}
+	if err != nil {
+		return wrapSyntacticError(&e.state, e.previousOffsetEnd(), err, 0, +1)
+	}
+
+	// Finish off the buffer and store it back into e.
+	e.Buf = b
+	e.appendTopLevelNewline()
+	if e.NeedFlush() {
+		return e.Flush()
+	}
+	return nil
+}
+
+// WriteValue writes the next raw value and advances the internal write offset.
+// The Encoder does not simply copy the provided value verbatim, but
+// parses it to ensure that it is syntactically valid and reformats it
+// according to how the Encoder is configured to format whitespace and strings.
+// If [AllowInvalidUTF8] is specified, then any invalid UTF-8 is mangled
+// as the Unicode replacement character, U+FFFD.
+//
+// The provided value kind must be consistent with the JSON grammar
+// (see examples on [Encoder.WriteToken]). If the provided value is invalid,
+// then it reports a [SyntacticError] and the internal state remains unchanged.
+// The offset reported in [SyntacticError] will be relative to the
+// [Encoder.OutputOffset] plus the offset into v of any encountered syntax error.
+func (e *Encoder) WriteValue(v Value) error {
+	return e.s.WriteValue(v)
+}
+func (e *encoderState) WriteValue(v Value) error {
+	k := v.Kind()
+	b := e.Buf // use local variable to avoid mutating e in case of error
+
+	// Append any delimiters or optional whitespace.
+	b = e.appendDelimAndIndent(b, k)
+
+	var err error
+	switch {
+	case k == '}' || k == ']':
+		err = jsonwire.NewInvalidCharacterError(v[jsonwire.ConsumeWhitespace(v):], "at start of value")
+	case e.needObjectName() && k != '"' && k != invalidKind:
+		err = ErrNonStringName
+	default:
+		pos := len(b)
+		f := valueFormatter{opts: &e.Struct, emit: true, dst: b}
+		if b, err = f.formatTopLevel(v, e.depth()); err != nil {
+			return wrapSyntacticError(&e.state, e.previousOffsetEnd(), err, 0, +1)
+		}
+		err = e.appendValue(k, !e.Flags.Get(jsonflags.AllowDuplicateNames), func() []byte {
+			name, _ := jsonwire.AppendUnquote(nil, b[pos:])
+			return name
+		})
+	}
+	if err != nil {
+		return wrapSyntacticError(&e.state, e.previousOffsetEnd(), err, 0, +1)
+	}
+
+	// Finish off the buffer and store it back into e.
+	e.Buf = b
+	e.appendTopLevelNewline()
+	if e.NeedFlush() {
+		return e.Flush()
+	}
+	return nil
+}
+
+// OutputOffset returns the current output byte offset. It gives the location
+// of the next byte immediately after the most recently written token or value.
+// The number of bytes actually written to the underlying [io.Writer] may be less
+// than this offset due to internal buffering effects.
+func (e *Encoder) OutputOffset() int64 {
+	return e.s.previousOffsetEnd()
+}
+
+// StackDepth returns the depth of the state machine for written JSON data.
+// Each level on the stack represents a nested JSON object or array.
+// It is incremented whenever an [BeginObject] or [BeginArray] token is encountered
+// and decremented whenever an [EndObject] or [EndArray] token is encountered.
+// The depth is zero-indexed, where zero represents the top-level JSON value.
+func (e *Encoder) StackDepth() int {
+	// NOTE: Keep in sync with Decoder.StackDepth.
+	return e.s.depth()
+}
+
+// StackIndex returns information about the specified stack level.
+// It must be a number between 0 and [Encoder.StackDepth], inclusive.
+// For each level, it reports the kind:
+//
+//   - 0 for a level of zero,
+//   - '{' for a level representing a JSON object, and
+//   - '[' for a level representing a JSON array.
+//
+// It also reports the length of that JSON object or array.
+// Each name and value in a JSON object is counted separately,
+// so the effective number of members would be half the length.
+// A complete JSON object must have an even length.
+func (e *Encoder) StackIndex(i int) (Kind, int64) {
+	// NOTE: Keep in sync with Decoder.StackIndex.
+	return e.s.stackIndex(i)
+}
+
+// StackPointer returns a JSON Pointer (RFC 6901) to the most recently written value.
+func (e *Encoder) StackPointer() Pointer {
+	return Pointer(e.s.appendStackPointer(nil, -1))
+}
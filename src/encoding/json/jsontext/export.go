@@ -0,0 +1,135 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"io"
+	"sync"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+)
+
+// Internal is for internal use only.
+// This is exempt from the Go compatibility agreement.
+var Internal exporter
+
+type exporter struct{}
+
+// Export exposes internal functionality from "jsontext" to "json".
+// This cannot be dynamically called by other packages since
+// they cannot obtain a reference to the internal.AllowInternalUse value.
+func (exporter) Export(p *internal.NotForPublicUse) export {
+	if p != &internal.AllowInternalUse {
+		panic("unauthorized call to Export")
+	}
+	return export{}
+}
+
+// The export type exposes functionality to packages with visibility to
+// the internal.AllowInternalUse variable. The "json" packages use this
+// to access the low-level state of the Encoder and Decoder types.
+type export struct{}
+
+// Encoder returns a pointer to the underlying encoderState.
+func (export) Encoder(e *Encoder) *encoderState { return &e.s }
+
+// Decoder returns a pointer to the underlying decoderState.
+func (export) Decoder(d *Decoder) *decoderState { return &d.s }
+
+var (
+	encoderPool = sync.Pool{New: func() any { return new(Encoder) }}
+	decoderPool = sync.Pool{New: func() any { return new(Decoder) }}
+)
+
+// GetBufferedEncoder returns an Encoder that writes to an internal buffer,
+// which can be retrieved with Encoder(e).Buf.
+func (export) GetBufferedEncoder(o ...Options) *Encoder {
+	e := encoderPool.Get().(*Encoder)
+	e.s.reset(e.s.Buf[:0], nil, o...)
+	return e
+}
+
+// PutBufferedEncoder returns e to the pool.
+// The buffer must no longer be referenced by the caller.
+func (export) PutBufferedEncoder(e *Encoder) {
+	// Avoid pinning arbitrarily large amounts of memory.
+	if cap(e.s.Buf) > 64<<10 {
+		e.s.Buf = nil
+	}
+	e.s.Struct = jsonopts.Struct{}
+	e.s.SeenPointers = nil
+	encoderPool.Put(e)
+}
+
+// GetStreamingEncoder returns an Encoder that writes to w.
+func (export) GetStreamingEncoder(w io.Writer, o ...Options) *Encoder {
+	e := encoderPool.Get().(*Encoder)
+	e.s.reset(e.s.Buf[:0], w, o...)
+	return e
+}
+
+// PutStreamingEncoder returns e to the pool.
+func (x export) PutStreamingEncoder(e *Encoder) {
+	e.s.wr = nil
+	x.PutBufferedEncoder(e)
+}
+
+// GetBufferedDecoder returns a Decoder that reads from b.
+func (export) GetBufferedDecoder(b []byte, o ...Options) *Decoder {
+	d := decoderPool.Get().(*Decoder)
+	d.s.reset(b, nil, o...)
+	return d
+}
+
+// PutBufferedDecoder returns d to the pool.
+func (export) PutBufferedDecoder(d *Decoder) {
+	d.s.buf = nil
+	d.s.Struct = jsonopts.Struct{}
+	decoderPool.Put(d)
+}
+
+// GetStreamingDecoder returns a Decoder that reads from r.
+func (export) GetStreamingDecoder(r io.Reader, o ...Options) *Decoder {
+	d := decoderPool.Get().(*Decoder)
+	d.s.reset(nil, r, o...)
+	return d
+}
+
+// PutStreamingDecoder returns d to the pool.
+func (x export) PutStreamingDecoder(d *Decoder) {
+	d.s.rd = nil
+	x.PutBufferedDecoder(d)
+}
+
+// NeedObjectName reports whether the next token or value written to
+// or read from the underlying state must be a JSON object name.
+func (s *state) NeedObjectName() bool {
+	return s.needObjectName()
+}
+
+// CheckEOF verifies that the input has no more data,
+// reporting a SyntacticError if any value remains.
+func (d *decoderState) CheckEOF() error {
+	pos, err := d.skipWhitespace(0)
+	switch err {
+	case nil:
+		b := d.UnreadBuffer()
+		err = jsonwire.NewInvalidCharacterError(b[pos:], "after top-level value")
+		return wrapSyntacticError(&d.state, d.previousOffsetEnd(), err, pos, +1)
+	case io.EOF:
+		return nil
+	default:
+		return err
+	}
+}
+
+// IsIOError reports whether err is an I/O error
+// produced by the underlying io.Reader or io.Writer.
+func (export) IsIOError(err error) bool {
+	_, ok := err.(*ioError)
+	return ok
+}
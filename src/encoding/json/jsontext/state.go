@@ -0,0 +1,324 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// maxNestingDepth is the maximum depth of nested JSON objects and arrays
+// that an Encoder or Decoder permits.
+const maxNestingDepth = 10000
+
+var (
+	errMissingValue  = errors.New("missing value after object name")
+	errMismatchDelim = errors.New("mismatching structural token for object or array")
+	errMaxDepth      = errors.New("exceeded max depth")
+)
+
+// stackEntry is the state for a single JSON object or array
+// (or the top-level stream of values for the entry at the bottom of the stack).
+type stackEntry struct {
+	kind Kind // either '{', '[', or zero for the top-level
+
+	// length is the number of tokens read or written within this container.
+	// For a JSON object, both names and values are counted.
+	length int64
+
+	// name is the unquoted name of the most recent object member.
+	name []byte
+
+	// names is the set of all unquoted object member names seen so far.
+	// It is only populated when duplicate names are being rejected.
+	names map[string]struct{}
+}
+
+// state tracks the grammar and the object names of a JSON stream
+// for either an Encoder or Decoder.
+type state struct {
+	stack []stackEntry // stack[0] is always the top-level
+}
+
+func (s *state) reset() {
+	for i := range s.stack {
+		s.stack[i] = stackEntry{} // allow the garbage collector to reclaim names
+	}
+	s.stack = append(s.stack[:0], stackEntry{})
+}
+
+// last returns the innermost container.
+func (s *state) last() *stackEntry {
+	return &s.stack[len(s.stack)-1]
+}
+
+// depth is the current nested depth of JSON objects and arrays.
+// It is zero at the top-level.
+func (s *state) depth() int {
+	return len(s.stack) - 1
+}
+
+// needObjectName reports whether the next token must be an object name.
+func (s *state) needObjectName() bool {
+	e := s.last()
+	return e.kind == '{' && e.length%2 == 0
+}
+
+// needObjectValue reports whether the next token must be an object value.
+func (s *state) needObjectValue() bool {
+	e := s.last()
+	return e.kind == '{' && e.length%2 == 1
+}
+
+// needDelim reports the delimiter (either ',' or ':')
+// that must precede a token of the next kind, or zero if none is needed.
+func (s *state) needDelim(next Kind) byte {
+	e := s.last()
+	switch {
+	case next == '}' || next == ']':
+		return 0
+	case e.kind == '{' && e.length%2 == 1:
+		return ':'
+	case e.kind != 0 && e.length > 0:
+		return ','
+	default:
+		return 0
+	}
+}
+
+// appendLiteral, appendString, and appendNumber update the state
+// for a JSON literal, string, or number that is about to be processed.
+func (s *state) appendLiteral() error {
+	if s.needObjectName() {
+		return ErrNonStringName
+	}
+	s.last().length++
+	return nil
+}
+
+func (s *state) appendNumber() error {
+	return s.appendLiteral()
+}
+
+// appendString updates the state for a JSON string,
+// where name is the unquoted string if this is an object name.
+// The name function is only called if the string is an object name.
+func (s *state) appendString(rejectDuplicates bool, name func() []byte) error {
+	e := s.last()
+	if e.kind == '{' && e.length%2 == 0 {
+		n := name()
+		if rejectDuplicates {
+			if _, ok := e.names[string(n)]; ok {
+				e.name = append(e.name[:0], n...) // reported by wrapSyntacticError
+				return ErrDuplicateName
+			}
+			if e.names == nil {
+				e.names = make(map[string]struct{})
+			}
+			e.names[string(n)] = struct{}{}
+		}
+		e.name = append(e.name[:0], n...)
+	}
+	e.length++
+	return nil
+}
+
+// appendValue updates the state for a JSON value of the given kind
+// as a single unit (e.g., via WriteValue or ReadValue).
+func (s *state) appendValue(k Kind, rejectDuplicates bool, name func() []byte) error {
+	if k == '"' {
+		return s.appendString(rejectDuplicates, name)
+	}
+	return s.appendLiteral()
+}
+
+// pushObject and pushArray update the state for the start of an object or array.
+func (s *state) pushObject() error {
+	return s.push('{')
+}
+
+func (s *state) pushArray() error {
+	return s.push('[')
+}
+
+func (s *state) push(k Kind) error {
+	if s.needObjectName() {
+		return ErrNonStringName
+	}
+	if len(s.stack) > maxNestingDepth {
+		return errMaxDepth
+	}
+	s.last().length++
+	if len(s.stack) < cap(s.stack) {
+		s.stack = s.stack[:len(s.stack)+1]
+		e := s.last()
+		e.kind, e.length, e.name = k, 0, e.name[:0]
+		clear(e.names)
+	} else {
+		s.stack = append(s.stack, stackEntry{kind: k})
+	}
+	return nil
+}
+
+// popObject and popArray update the state for the end of an object or array.
+func (s *state) popObject() error {
+	switch e := s.last(); {
+	case e.kind != '{':
+		return errMismatchDelim
+	case e.length%2 == 1:
+		return errMissingValue
+	}
+	s.stack = s.stack[:len(s.stack)-1]
+	return nil
+}
+
+func (s *state) popArray() error {
+	if s.last().kind != '[' {
+		return errMismatchDelim
+	}
+	s.stack = s.stack[:len(s.stack)-1]
+	return nil
+}
+
+// appendStackPointer appends a JSON Pointer (RFC 6901) to the current value.
+//
+// If where is -1, the pointer refers to the most recently processed value
+// (i.e., the last value processed within each container).
+// If where is +1, the pointer refers to the next value to be processed
+// within the innermost container.
+func (s *state) appendStackPointer(b []byte, where int) []byte {
+	for i := 1; i < len(s.stack); i++ {
+		e := &s.stack[i]
+		innermost := i == len(s.stack)-1
+		switch e.kind {
+		case '{':
+			// Within an object, the current value is only well-defined
+			// after the name for it has been processed.
+			if e.length == 0 || (innermost && where > 0 && e.length%2 == 0) {
+				continue
+			}
+			b = appendEscapePointerName(append(b, '/'), e.name)
+		case '[':
+			n := e.length - 1
+			if innermost && where > 0 {
+				n = e.length
+			}
+			if n < 0 {
+				continue
+			}
+			b = strconv.AppendInt(append(b, '/'), n, 10)
+		}
+	}
+	return b
+}
+
+// stackIndex returns information about the specified stack level.
+func (s *state) stackIndex(i int) (Kind, int64) {
+	if i < 0 || i >= len(s.stack) {
+		return 0, 0
+	}
+	e := &s.stack[i]
+	if i == 0 {
+		return 0, e.length
+	}
+	return e.kind, e.length
+}
+
+// Pointer is a JSON Pointer (RFC 6901) that references a particular JSON value
+// relative to the root of the top-level JSON value.
+//
+// A Pointer is a slash-separated list of tokens, where each token is
+// either a JSON object name or an index to a JSON array element
+// encoded as a base-10 integer value.
+// It is impossible to distinguish between an array index and an object name
+// (that happens to be a base-10 encoded integer) without also knowing
+// the structure of the top-level JSON value that the pointer refers to.
+//
+// There is exactly one representation of a pointer to a particular value,
+// so comparability of Pointer values is equivalent to checking whether
+// they both point to the exact same value.
+type Pointer string
+
+// IsValid reports whether p is a valid JSON Pointer according to RFC 6901.
+// Note that the concatenation of two valid pointers produces a valid pointer.
+func (p Pointer) IsValid() bool {
+	for i, r := range p {
+		switch {
+		case r == '~' && (i+1 == len(p) || (p[i+1] != '0' && p[i+1] != '1')):
+			return false // invalid escape
+		case r == '\uFFFD' && !strings.HasPrefix(string(p[i:]), "\uFFFD"):
+			return false // invalid UTF-8
+		}
+	}
+	return len(p) == 0 || p[0] == '/'
+}
+
+// Contains reports whether the JSON value that p points to
+// is equal to or contains the JSON value that pc points to.
+func (p Pointer) Contains(pc Pointer) bool {
+	// Invariant: len(p) <= len(pc) if p.Contains(pc)
+	suffix, ok := strings.CutPrefix(string(pc), string(p))
+	return ok && (suffix == "" || suffix[0] == '/')
+}
+
+// Parent strips off the last token and returns the remaining pointer.
+// The parent of an empty p is an empty string.
+func (p Pointer) Parent() Pointer {
+	return p[:max(strings.LastIndexByte(string(p), '/'), 0)]
+}
+
+// LastToken returns the last token in the pointer.
+// The last token of an empty p is an empty string.
+func (p Pointer) LastToken() string {
+	last := p[max(strings.LastIndexByte(string(p), '/'), 0):]
+	return unescapePointerToken(strings.TrimPrefix(string(last), "/"))
+}
+
+// AppendToken appends a token to the end of p and returns the full pointer.
+func (p Pointer) AppendToken(tok string) Pointer {
+	return Pointer(appendEscapePointerName([]byte(p+"/"), tok))
+}
+
+// Tokens returns an iterator over the reference tokens in the JSON pointer,
+// starting from the first token until the last token (unless stopped early).
+func (p Pointer) Tokens() func(yield func(string) bool) {
+	return func(yield func(string) bool) {
+		for len(p) > 0 {
+			p = Pointer(strings.TrimPrefix(string(p), "/"))
+			i := min(uint(strings.IndexByte(string(p), '/')), uint(len(p)))
+			if !yield(unescapePointerToken(string(p)[:i])) {
+				return
+			}
+			p = p[i:]
+		}
+	}
+}
+
+func unescapePointerToken(token string) string {
+	if strings.Contains(token, "~") {
+		// Per RFC 6901, section 3, unescape '~' and '/' characters.
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+	}
+	return token
+}
+
+// appendEscapePointerName appends the escaped name to b
+// according to RFC 6901, section 3.
+func appendEscapePointerName[Bytes ~[]byte | ~string](b []byte, name Bytes) []byte {
+	for _, r := range string(name) {
+		// Per RFC 6901, section 3, escape '~' and '/' characters.
+		switch r {
+		case '~':
+			b = append(b, "~0"...)
+		case '/':
+			b = append(b, "~1"...)
+		default:
+			b = append(b, string(r)...)
+		}
+	}
+	return b
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPointer(t *testing.T) {
+	tests := []struct {
+		in         Pointer
+		wantValid  bool
+		wantParent Pointer
+		wantLast   string
+		wantTokens []string
+	}{
+		{"", true, "", "", nil},
+		{"/", true, "", "", []string{""}},
+		{"/a/b~1c/~0d", true, "/a/b~1c", "~d", []string{"a", "b/c", "~d"}},
+		{"/0/1", true, "/0", "1", []string{"0", "1"}},
+		{"a", false, "", "", nil},
+		{"/~2", false, "", "", nil},
+		{"/\xff", false, "", "", nil},
+	}
+	for _, tt := range tests {
+		if got := tt.in.IsValid(); got != tt.wantValid {
+			t.Errorf("Pointer(%q).IsValid() = %v, want %v", tt.in, got, tt.wantValid)
+		}
+		if !tt.wantValid {
+			continue
+		}
+		if got := tt.in.Parent(); got != tt.wantParent {
+			t.Errorf("Pointer(%q).Parent() = %q, want %q", tt.in, got, tt.wantParent)
+		}
+		if got := tt.in.LastToken(); got != tt.wantLast {
+			t.Errorf("Pointer(%q).LastToken() = %q, want %q", tt.in, got, tt.wantLast)
+		}
+		var got []string
+		for tok := range tt.in.Tokens() {
+			got = append(got, tok)
+		}
+		if !slices.Equal(got, tt.wantTokens) {
+			t.Errorf("Pointer(%q).Tokens() = %q, want %q", tt.in, got, tt.wantTokens)
+		}
+		var p Pointer
+		for _, tok := range tt.wantTokens {
+			p = p.AppendToken(tok)
+		}
+		if p != tt.in {
+			t.Errorf("AppendToken roundtrip = %q, want %q", p, tt.in)
+		}
+	}
+
+	if !Pointer("/a/b").Contains("/a/b/c") || Pointer("/a/b").Contains("/a/bc") || !Pointer("").Contains("/x") {
+		t.Errorf("Pointer.Contains reported wrong result")
+	}
+}
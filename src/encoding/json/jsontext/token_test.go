@@ -0,0 +1,126 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTokenAccessors(t *testing.T) {
+	type want struct {
+		kind   Kind
+		str    string
+		float  float64
+		int    int64
+		uint   uint64
+		isBool bool
+		bool   bool
+	}
+	tests := []struct {
+		in   Token
+		want want
+	}{
+		{Token{}, want{kind: 0, str: "<invalid jsontext.Token>"}},
+		{Null, want{kind: 'n', str: "null"}},
+		{False, want{kind: 'f', str: "false", isBool: true, bool: false}},
+		{True, want{kind: 't', str: "true", isBool: true, bool: true}},
+		{Bool(true), want{kind: 't', str: "true", isBool: true, bool: true}},
+		{BeginObject, want{kind: '{', str: "{"}},
+		{EndArray, want{kind: ']', str: "]"}},
+		{String(""), want{kind: '"', str: ""}},
+		{String("hello"), want{kind: '"', str: "hello"}},
+		{Float(0), want{kind: '0', str: "0"}},
+		{Float(-1.5), want{kind: '0', str: "-1.5", float: -1.5, int: -1, uint: 0}},
+		{Float(math.NaN()), want{kind: '"', str: "NaN"}},
+		{Float(math.Inf(+1)), want{kind: '"', str: "Infinity"}},
+		{Int(math.MinInt64), want{kind: '0', str: "-9223372036854775808", float: math.MinInt64, int: math.MinInt64}},
+		{Uint(math.MaxUint64), want{kind: '0', str: "18446744073709551615", float: math.MaxUint64, int: math.MaxInt64, uint: math.MaxUint64}},
+	}
+	for _, tt := range tests {
+		if got := tt.in.Kind(); got != tt.want.kind {
+			t.Errorf("%v.Kind() = %v, want %v", tt.in, got, tt.want.kind)
+		}
+		if got := tt.in.String(); got != tt.want.str {
+			t.Errorf("Token.String() = %q, want %q", got, tt.want.str)
+		}
+		if tt.want.isBool {
+			if got := tt.in.Bool(); got != tt.want.bool {
+				t.Errorf("%v.Bool() = %v, want %v", tt.in, got, tt.want.bool)
+			}
+		}
+		if tt.want.kind == '0' {
+			if got := tt.in.Float(); got != tt.want.float {
+				t.Errorf("%v.Float() = %v, want %v", tt.in, got, tt.want.float)
+			}
+			if got := tt.in.Int(); got != tt.want.int {
+				t.Errorf("%v.Int() = %v, want %v", tt.in, got, tt.want.int)
+			}
+			if got := tt.in.Uint(); got != tt.want.uint {
+				t.Errorf("%v.Uint() = %v, want %v", tt.in, got, tt.want.uint)
+			}
+		}
+	}
+}
+
+func TestTokenRawNumbers(t *testing.T) {
+	d := NewDecoder(strings.NewReader(`[1e400, -1e400, 123.9, -0.5, 99999999999999999999]`))
+	if _, err := d.ReadToken(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		float float64
+		int   int64
+		uint  uint64
+	}{
+		{math.MaxFloat64, math.MaxInt64, math.MaxUint64},
+		{-math.MaxFloat64, math.MinInt64, 0},
+		{123.9, 123, 123},
+		{-0.5, 0, 0},
+		{1e20, math.MaxInt64, math.MaxUint64},
+	}
+	for _, tt := range tests {
+		tok, err := d.ReadToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tok.Float(); got != tt.float {
+			t.Errorf("%v.Float() = %v, want %v", tok, got, tt.float)
+		}
+		if got := tok.Int(); got != tt.int {
+			t.Errorf("%v.Int() = %v, want %v", tok, got, tt.int)
+		}
+		if got := tok.Uint(); got != tt.uint {
+			t.Errorf("%v.Uint() = %v, want %v", tok, got, tt.uint)
+		}
+	}
+}
+
+func TestTokenClone(t *testing.T) {
+	d := NewDecoder(strings.NewReader(`["a","b"]`))
+	if _, err := d.ReadToken(); err != nil {
+		t.Fatal(err)
+	}
+	tok, err := d.ReadToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone := tok.Clone()
+	if _, err := d.ReadToken(); err != nil {
+		t.Fatal(err)
+	}
+	if got := clone.String(); got != "a" {
+		t.Errorf("Clone().String() = %q, want %q", got, "a")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("stale Token did not panic")
+			}
+		}()
+		_ = tok.String()
+	}()
+}
@@ -0,0 +1,476 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+)
+
+// NOTE: Value is analogous to v1 json.RawMessage.
+
+// Value represents a single raw JSON value, which may be one of the following:
+//   - a JSON literal (i.e., null, true, or false)
+//   - a JSON string (e.g., "hello, world!")
+//   - a JSON number (e.g., 123.456)
+//   - an entire JSON object (e.g., {"fizz":"buzz"} )
+//   - an entire JSON array (e.g., [1,2,3] )
+//
+// Value can represent entire array or object values, while [Token] cannot.
+// Value may contain leading and/or trailing whitespace.
+type Value []byte
+
+// Clone returns a copy of v.
+func (v Value) Clone() Value {
+	return bytes.Clone(v)
+}
+
+// String returns the string formatting of v.
+func (v Value) String() string {
+	if v == nil {
+		return "null"
+	}
+	return string(v)
+}
+
+// IsValid reports whether the raw JSON value is syntactically valid
+// according to the specified options.
+//
+// By default (if no options are specified), it validates according to RFC 7493.
+// It verifies whether the input is properly encoded as UTF-8,
+// that escape sequences within strings decode to valid Unicode codepoints, and
+// that all names in each object are unique.
+// It does not verify whether numbers are representable within the limits
+// of any common numeric type (e.g., float64, int64, or uint64).
+//
+// Relevant options include:
+//   - [AllowDuplicateNames]
+//   - [AllowInvalidUTF8]
+//
+// All other options are ignored.
+func (v Value) IsValid(opts ...Options) bool {
+	var o jsonopts.Struct
+	o.Join(opts...)
+	f := valueFormatter{opts: &o}
+	_, err := f.formatTopLevel(v, 0)
+	return err == nil
+}
+
+// Format formats the raw JSON value in place.
+//
+// By default (if no options are specified), it validates according to RFC 7493
+// and produces the minimal JSON representation, where
+// all whitespace is elided and JSON strings use the shortest encoding.
+//
+// Relevant options include:
+//   - [AllowDuplicateNames]
+//   - [AllowInvalidUTF8]
+//   - [EscapeForHTML]
+//   - [EscapeForJS]
+//   - [Multiline]
+//   - [SpaceAfterColon]
+//   - [SpaceAfterComma]
+//   - [WithIndent]
+//   - [WithIndentPrefix]
+//
+// All other options are ignored.
+//
+// It is guaranteed to succeed if the value is valid according to the same options.
+// If the value is already formatted, then the buffer is not mutated.
+func (v *Value) Format(opts ...Options) error {
+	var o jsonopts.Struct
+	o.Join(opts...)
+	return v.format(&o)
+}
+
+// Compact removes all whitespace from the raw JSON value.
+//
+// It does not reformat JSON strings or numbers to use any other representation.
+// To maximize the set of JSON values that can be formatted,
+// this permits values with duplicate names and invalid UTF-8.
+//
+// Compact is equivalent to calling [Value.Format] with the following options:
+//   - [AllowDuplicateNames](true)
+//   - [AllowInvalidUTF8](true)
+//   - [Multiline](false)
+//
+// Any options specified by the caller are applied after the initial set
+// and may deliberately override prior options.
+func (v *Value) Compact(opts ...Options) error {
+	var o jsonopts.Struct
+	o.Join(AllowDuplicateNames(true), AllowInvalidUTF8(true), Multiline(false))
+	o.Flags.Set(jsonflags.PreserveRawStrings | 1)
+	o.Join(opts...)
+	return v.format(&o)
+}
+
+// Indent reformats the whitespace in the raw JSON value so that each element
+// in a JSON object or array begins on a indented line according to the nesting.
+//
+// It does not reformat JSON strings or numbers to use any other representation.
+// To maximize the set of JSON values that can be formatted,
+// this permits values with duplicate names and invalid UTF-8.
+//
+// Indent is equivalent to calling [Value.Format] with the following options:
+//   - [AllowDuplicateNames](true)
+//   - [AllowInvalidUTF8](true)
+//   - [Multiline](true)
+//
+// Any options specified by the caller are applied after the initial set
+// and may deliberately override prior options.
+func (v *Value) Indent(opts ...Options) error {
+	var o jsonopts.Struct
+	o.Join(AllowDuplicateNames(true), AllowInvalidUTF8(true), Multiline(true))
+	o.Flags.Set(jsonflags.PreserveRawStrings | 1)
+	o.Join(opts...)
+	return v.format(&o)
+}
+
+func (v *Value) format(o *jsonopts.Struct) error {
+	normalizeFormatOptions(o)
+	f := valueFormatter{opts: o, emit: true}
+	b, err := f.formatTopLevel(*v, 0)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(b, *v) {
+		*v = append((*v)[:0], b...)
+	}
+	return nil
+}
+
+// MarshalJSON returns v as the JSON encoding of v.
+// It returns the stored value as the raw JSON output without any validation.
+// If v is nil, then this returns a JSON null.
+func (v Value) MarshalJSON() ([]byte, error) {
+	// NOTE: This matches the behavior of v1 json.RawMessage.MarshalJSON.
+	if v == nil {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+// UnmarshalJSON sets v as the JSON encoding of b.
+// It stores a copy of the provided raw JSON input without any validation.
+func (v *Value) UnmarshalJSON(b []byte) error {
+	// NOTE: This matches the behavior of v1 json.RawMessage.UnmarshalJSON.
+	if v == nil {
+		return errNilValue
+	}
+	*v = append((*v)[:0], b...)
+	return nil
+}
+
+// Kind returns the starting token kind.
+// For a valid value, this will never include '}' or ']'.
+func (v Value) Kind() Kind {
+	if v := v[jsonwire.ConsumeWhitespace(v):]; len(v) > 0 {
+		return Kind(v[0]).normalize()
+	}
+	return invalidKind
+}
+
+// valueFormatter validates and optionally reformats a single JSON value.
+type valueFormatter struct {
+	opts *jsonopts.Struct
+	emit bool   // whether to append the formatted output to dst
+	dst  []byte // formatted output
+
+	// path is a stack of the current location within the value,
+	// which is used to construct a JSON pointer for error reporting.
+	path []pathEntry
+}
+
+type pathEntry struct {
+	isObject bool
+	name     []byte // raw (quoted) name of the current object member
+	index    int64  // index of the current array element
+}
+
+// formatTopLevel formats src, which must contain exactly one JSON value
+// with optional surrounding whitespace, as if it were located at the given depth.
+// The returned error is a *SyntacticError relative to the start of src.
+func (f *valueFormatter) formatTopLevel(src []byte, depth int) ([]byte, error) {
+	n := jsonwire.ConsumeWhitespace(src)
+	n, err := f.formatValue(src, n, depth)
+	if err == nil {
+		n += jsonwire.ConsumeWhitespace(src[n:])
+		if n < len(src) {
+			err = jsonwire.NewInvalidCharacterError(src[n:], "after top-level value")
+		}
+	}
+	if err != nil {
+		return nil, f.newError(n, err)
+	}
+	return f.dst, nil
+}
+
+// newError wraps err as a *SyntacticError at offset n
+// with a JSON pointer relative to the start of the value.
+func (f *valueFormatter) newError(n int, err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return &SyntacticError{ByteOffset: int64(n), JSONPointer: Pointer(f.appendPointer(nil)), Err: err}
+}
+
+// appendPointer appends the relative JSON pointer of the current location.
+func (f *valueFormatter) appendPointer(b []byte) []byte {
+	for _, e := range f.path {
+		switch {
+		case e.isObject && e.name != nil:
+			name, _ := jsonwire.AppendUnquote(nil, e.name)
+			b = appendEscapePointerName(append(b, '/'), name)
+		case !e.isObject:
+			b = strconv.AppendInt(append(b, '/'), e.index, 10)
+		}
+	}
+	return b
+}
+
+func (f *valueFormatter) appendIndent(depth int) {
+	if f.emit && f.opts.Flags.Get(jsonflags.Multiline) {
+		f.dst = appendIndent(f.dst, f.opts, depth)
+	}
+}
+
+// appendIndent appends a newline and the indentation for the given depth.
+func appendIndent(b []byte, o *jsonopts.Struct, depth int) []byte {
+	b = append(b, '\n')
+	b = append(b, o.IndentPrefix...)
+	for range depth {
+		b = append(b, o.Indent...)
+	}
+	return b
+}
+
+// formatValue formats the JSON value starting at src[n:]
+// and returns the offset immediately after it.
+// On error, it returns the offset of where the error occurred.
+func (f *valueFormatter) formatValue(src []byte, n, depth int) (int, error) {
+	if n >= len(src) {
+		return n, io.ErrUnexpectedEOF
+	}
+	switch k := Kind(src[n]).normalize(); k {
+	case 'n', 'f', 't':
+		lit := "null"
+		switch k {
+		case 'f':
+			lit = "false"
+		case 't':
+			lit = "true"
+		}
+		m, err := jsonwire.ConsumeLiteral(src[n:], lit)
+		if err != nil {
+			return n + m, err
+		}
+		if f.emit {
+			f.dst = append(f.dst, lit...)
+		}
+		return n + m, nil
+	case '"':
+		return f.formatString(src, n)
+	case '0':
+		m, err := jsonwire.ConsumeNumber(src[n:])
+		if err != nil {
+			return n + m, err
+		}
+		if f.emit {
+			f.dst = append(f.dst, src[n:n+m]...)
+		}
+		return n + m, nil
+	case '{':
+		return f.formatObject(src, n, depth)
+	case '[':
+		return f.formatArray(src, n, depth)
+	default:
+		return n, jsonwire.NewInvalidCharacterError(src[n:], "at start of value")
+	}
+}
+
+func (f *valueFormatter) formatString(src []byte, n int) (int, error) {
+	var m int
+	var err error
+	if f.emit {
+		f.dst, m, err = jsonwire.ReformatString(f.dst, src[n:], &f.opts.Flags)
+	} else {
+		var vf jsonwire.ValueFlags
+		m, err = jsonwire.ConsumeString(&vf, src[n:], !f.opts.Flags.Get(jsonflags.AllowInvalidUTF8))
+	}
+	return n + m, err
+}
+
+func (f *valueFormatter) formatObject(src []byte, n, depth int) (int, error) {
+	if depth >= maxNestingDepth {
+		return n, errMaxDepth
+	}
+	n++ // consume '{'
+	if f.emit {
+		f.dst = append(f.dst, '{')
+	}
+	n += jsonwire.ConsumeWhitespace(src[n:])
+	if n >= len(src) {
+		return n, io.ErrUnexpectedEOF
+	}
+	if src[n] == '}' {
+		if f.emit {
+			f.dst = append(f.dst, '}')
+		}
+		return n + 1, nil
+	}
+
+	rejectDuplicates := !f.opts.Flags.Get(jsonflags.AllowDuplicateNames)
+	validateUTF8 := !f.opts.Flags.Get(jsonflags.AllowInvalidUTF8)
+	var names map[string]struct{}
+	f.path = append(f.path, pathEntry{isObject: true})
+	entry := &f.path[len(f.path)-1]
+	for {
+		f.appendIndent(depth + 1)
+
+		// Consume the object name.
+		entry.name = nil
+		if n >= len(src) {
+			return n, io.ErrUnexpectedEOF
+		}
+		if src[n] != '"' {
+			return n, jsonwire.NewInvalidCharacterError(src[n:], `at start of string (expecting '"')`)
+		}
+		var vf jsonwire.ValueFlags
+		m, err := jsonwire.ConsumeString(&vf, src[n:], validateUTF8)
+		if err != nil {
+			return n + m, err
+		}
+		name := src[n : n+m]
+		entry.name = name
+		if rejectDuplicates {
+			unquoted := jsonwire.UnquoteMayCopy(name, vf.IsVerbatim())
+			if _, ok := names[string(unquoted)]; ok {
+				return n, ErrDuplicateName
+			}
+			if names == nil {
+				names = make(map[string]struct{})
+			}
+			names[string(unquoted)] = struct{}{}
+		}
+		if f.emit {
+			f.dst, _, _ = jsonwire.ReformatString(f.dst, name, &f.opts.Flags)
+		}
+		n += m
+
+		// Consume the colon.
+		n += jsonwire.ConsumeWhitespace(src[n:])
+		if n >= len(src) {
+			return n, io.ErrUnexpectedEOF
+		}
+		if src[n] != ':' {
+			return n, jsonwire.NewInvalidCharacterError(src[n:], "after object name (expecting ':')")
+		}
+		n++
+		if f.emit {
+			f.dst = append(f.dst, ':')
+			if f.opts.Flags.Get(jsonflags.SpaceAfterColon) {
+				f.dst = append(f.dst, ' ')
+			}
+		}
+
+		// Consume the object value.
+		n += jsonwire.ConsumeWhitespace(src[n:])
+		n, err = f.formatValue(src, n, depth+1)
+		if err != nil {
+			return n, err
+		}
+		entry = &f.path[len(f.path)-1] // path may have been reallocated
+
+		// Consume the comma or end of object.
+		n += jsonwire.ConsumeWhitespace(src[n:])
+		if n >= len(src) {
+			return n, io.ErrUnexpectedEOF
+		}
+		switch src[n] {
+		case ',':
+			n++
+			n += jsonwire.ConsumeWhitespace(src[n:])
+			if f.emit {
+				f.dst = append(f.dst, ',')
+				if !f.opts.Flags.Get(jsonflags.Multiline) && f.opts.Flags.Get(jsonflags.SpaceAfterComma) {
+					f.dst = append(f.dst, ' ')
+				}
+			}
+		case '}':
+			f.appendIndent(depth)
+			if f.emit {
+				f.dst = append(f.dst, '}')
+			}
+			f.path = f.path[:len(f.path)-1]
+			return n + 1, nil
+		default:
+			return n, jsonwire.NewInvalidCharacterError(src[n:], "after object value (expecting ',' or '}')")
+		}
+	}
+}
+
+func (f *valueFormatter) formatArray(src []byte, n, depth int) (int, error) {
+	if depth >= maxNestingDepth {
+		return n, errMaxDepth
+	}
+	n++ // consume '['
+	if f.emit {
+		f.dst = append(f.dst, '[')
+	}
+	n += jsonwire.ConsumeWhitespace(src[n:])
+	if n >= len(src) {
+		return n, io.ErrUnexpectedEOF
+	}
+	if src[n] == ']' {
+		if f.emit {
+			f.dst = append(f.dst, ']')
+		}
+		return n + 1, nil
+	}
+
+	f.path = append(f.path, pathEntry{})
+	for i := int64(0); ; i++ {
+		f.path[len(f.path)-1].index = i
+		f.appendIndent(depth + 1)
+
+		// Consume the array element.
+		var err error
+		n, err = f.formatValue(src, n, depth+1)
+		if err != nil {
+			return n, err
+		}
+
+		// Consume the comma or end of array.
+		n += jsonwire.ConsumeWhitespace(src[n:])
+		if n >= len(src) {
+			return n, io.ErrUnexpectedEOF
+		}
+		switch src[n] {
+		case ',':
+			n++
+			n += jsonwire.ConsumeWhitespace(src[n:])
+			if f.emit {
+				f.dst = append(f.dst, ',')
+				if !f.opts.Flags.Get(jsonflags.Multiline) && f.opts.Flags.Get(jsonflags.SpaceAfterComma) {
+					f.dst = append(f.dst, ' ')
+				}
+			}
+		case ']':
+			f.appendIndent(depth)
+			if f.emit {
+				f.dst = append(f.dst, ']')
+			}
+			f.path = f.path[:len(f.path)-1]
+			return n + 1, nil
+		default:
+			return n, jsonwire.NewInvalidCharacterError(src[n:], "after array element (expecting ',' or ']')")
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValueMethods(t *testing.T) {
+	tests := []struct {
+		in          string
+		wantValid   bool
+		wantKind    Kind
+		wantCompact string
+		wantIndent  string
+	}{
+		{in: ``, wantKind: 0},
+		{in: `  null `, wantValid: true, wantKind: 'n', wantCompact: `null`, wantIndent: `null`},
+		{in: `"\u0041" `, wantValid: true, wantKind: '"', wantCompact: `"\u0041"`, wantIndent: `"\u0041"`},
+		{in: ` -1.5e3`, wantValid: true, wantKind: '0', wantCompact: `-1.5e3`, wantIndent: `-1.5e3`},
+		{in: `{}`, wantValid: true, wantKind: '{', wantCompact: `{}`, wantIndent: `{}`},
+		{in: `[ ]`, wantValid: true, wantKind: '[', wantCompact: `[]`, wantIndent: `[]`},
+		{
+			in:          ` { "a" : [ 1 , 2 , { "b" : null } ] , "c" : "é" } `,
+			wantValid:   true,
+			wantKind:    '{',
+			wantCompact: `{"a":[1,2,{"b":null}],"c":"é"}`,
+			wantIndent:  "{\n\t\"a\": [\n\t\t1,\n\t\t2,\n\t\t{\n\t\t\t\"b\": null\n\t\t}\n\t],\n\t\"c\": \"é\"\n}",
+		},
+		{in: `{"a":1,"a":2}`, wantKind: '{', wantCompact: `{"a":1,"a":2}`, wantIndent: "{\n\t\"a\": 1,\n\t\"a\": 2\n}"},
+		{in: `[1,]`, wantKind: '['},
+		{in: `1 2`, wantKind: '0'},
+		{in: "\"\xff\"", wantKind: '"', wantCompact: "\"\xff\"", wantIndent: "\"\xff\""},
+	}
+	for _, tt := range tests {
+		v := Value(tt.in)
+		if got := v.IsValid(); got != tt.wantValid {
+			t.Errorf("Value(%q).IsValid() = %v, want %v", tt.in, got, tt.wantValid)
+		}
+		if got := v.Kind(); got != tt.wantKind {
+			t.Errorf("Value(%q).Kind() = %v, want %v", tt.in, got, tt.wantKind)
+		}
+
+		// Compact and Indent permit duplicate names and invalid UTF-8.
+		formatOK := tt.wantCompact != ""
+		v = Value(tt.in)
+		err := v.Compact()
+		if formatOK {
+			if err != nil {
+				t.Errorf("Value(%q).Compact() error: %v", tt.in, err)
+			} else if string(v) != tt.wantCompact {
+				t.Errorf("Value(%q).Compact() = %q, want %q", tt.in, v, tt.wantCompact)
+			}
+		} else if err == nil {
+			t.Errorf("Value(%q).Compact() error is nil, want non-nil", tt.in)
+		}
+
+		v = Value(tt.in)
+		err = v.Indent()
+		if formatOK {
+			if err != nil {
+				t.Errorf("Value(%q).Indent() error: %v", tt.in, err)
+			} else if string(v) != tt.wantIndent {
+				t.Errorf("Value(%q).Indent() = %q, want %q", tt.in, v, tt.wantIndent)
+			}
+		} else if err == nil {
+			t.Errorf("Value(%q).Indent() error is nil, want non-nil", tt.in)
+		}
+	}
+}
+
+func TestValueFormatOptions(t *testing.T) {
+	v := Value(`{"a":"<>","b":[1,2]}`)
+	if err := v.Format(EscapeForHTML(true), SpaceAfterComma(true)); err != nil {
+		t.Fatalf("Format error: %v", err)
+	}
+	if got, want := string(v), `{"a":"\u003c\u003e", "b":[1, 2]}`; got != want {
+		t.Errorf("Format = %s, want %s", got, want)
+	}
+
+	v = Value(`{"a":1,"a":2}`)
+	if err := v.Format(AllowDuplicateNames(true)); err != nil {
+		t.Errorf("Format(AllowDuplicateNames(true)) error: %v", err)
+	}
+	v = Value(`[1,{"a":1,"a":2}]`)
+	err := v.Format()
+	if err == nil || !strings.Contains(err.Error(), `duplicate object member name "a" within "/1"`) {
+		t.Errorf("Format error = %v, want duplicate name error", err)
+	}
+}
@@ -0,0 +1,1658 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+// optimizeCommon specifies whether to use optimizations targeted for certain
+// common patterns, rather than using the slower, but more general logic.
+// All tests should pass regardless of whether this is true or not.
+const optimizeCommon = true
+
+var (
+	// Most natural Go type that correspond with each JSON type.
+	anyType     = reflect.TypeFor[any]()     // JSON value
+	boolType    = reflect.TypeFor[bool]()    // JSON bool
+	stringType  = reflect.TypeFor[string]()  // JSON string
+	float64Type = reflect.TypeFor[float64]() // JSON number
+)
+
+// startDetectingCyclesAfter is the depth at which marshaling begins
+// checking for pointer cycles. Most values are never this deep,
+// so the cost of tracking visited pointers is avoided in the common case.
+const startDetectingCyclesAfter = 1000
+
+type seenPointer struct {
+	ptr any // an unsafe.Pointer obtained from reflect.Value.UnsafePointer
+	len int // the length of a slice; otherwise zero
+	typ reflect.Type
+}
+
+// visitPointer records that v was visited by the encoder,
+// reporting an error if v was already visited (i.e., there is a cycle).
+func visitPointer(m *map[any]struct{}, v reflect.Value) error {
+	p := seenPointer{v.UnsafePointer(), 0, v.Type()}
+	if v.Kind() == reflect.Slice {
+		p.len = v.Len()
+	}
+	if _, ok := (*m)[p]; ok {
+		return internal.ErrCycle
+	}
+	if *m == nil {
+		*m = make(map[any]struct{})
+	}
+	(*m)[p] = struct{}{}
+	return nil
+}
+
+// leavePointer removes the record of v visited by visitPointer.
+func leavePointer(m *map[any]struct{}, v reflect.Value) {
+	p := seenPointer{v.UnsafePointer(), 0, v.Type()}
+	if v.Kind() == reflect.Slice {
+		p.len = v.Len()
+	}
+	delete(*m, p)
+}
+
+// checkCycle reports an error if marshaling va would revisit a value
+// already being marshaled further up the stack. The returned function
+// must be called once marshaling va is complete.
+func checkCycle(enc *jsontext.Encoder, va addressableValue) (leave func(), err error) {
+	if enc.StackDepth() <= startDetectingCyclesAfter {
+		return func() {}, nil
+	}
+	xe := export.Encoder(enc)
+	if err := visitPointer(&xe.SeenPointers, va.Value); err != nil {
+		return nil, newMarshalError(enc, va.Type(), err)
+	}
+	return func() { leavePointer(&xe.SeenPointers, va.Value) }, nil
+}
+
+func makeDefaultArshaler(t reflect.Type) *arshaler {
+	switch t.Kind() {
+	case reflect.Bool:
+		return makeBoolArshaler(t)
+	case reflect.String:
+		return makeStringArshaler(t)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return makeIntArshaler(t)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return makeUintArshaler(t)
+	case reflect.Float32, reflect.Float64:
+		return makeFloatArshaler(t)
+	case reflect.Map:
+		return makeMapArshaler(t)
+	case reflect.Struct:
+		return makeStructArshaler(t)
+	case reflect.Slice:
+		fncs := makeSliceArshaler(t)
+		if t.Elem().Kind() == reflect.Uint8 && !implementsAny(t.Elem(), allMethodTypes...) {
+			return makeBytesArshaler(t, fncs)
+		}
+		return fncs
+	case reflect.Array:
+		fncs := makeArrayArshaler(t)
+		if t.Elem().Kind() == reflect.Uint8 && !implementsAny(t.Elem(), allMethodTypes...) {
+			return makeBytesArshaler(t, fncs)
+		}
+		return fncs
+	case reflect.Pointer:
+		return makePointerArshaler(t)
+	case reflect.Interface:
+		return makeInterfaceArshaler(t)
+	default:
+		return makeInvalidArshaler(t)
+	}
+}
+
+// newInvalidFormatError reports that the format flag in o
+// is not supported for values of type t.
+func newInvalidFormatError(c coder, t reflect.Type, o *jsonopts.Struct) error {
+	err := fmt.Errorf("invalid format flag %q", o.Format)
+	switch c := c.(type) {
+	case *jsontext.Encoder:
+		return newMarshalError(c, t, err)
+	case *jsontext.Decoder:
+		return newUnmarshalErrorBefore(c, t, err)
+	}
+	return err
+}
+
+// unquote returns the unescaped form of the JSON string val.
+// The result may alias val if val contains no escape sequences.
+func unquote(val jsontext.Value) []byte {
+	if bytes.IndexByte(val, '\\') < 0 && utf8.Valid(val) {
+		return val[len(`"`) : len(val)-len(`"`)]
+	}
+	b, _ := jsonwire.AppendUnquote(nil, val)
+	return b
+}
+
+func makeBoolArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if hasFormat(enc, mo) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		return enc.WriteToken(jsontext.Bool(va.Bool()))
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		val, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		switch k := val.Kind(); k {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetBool(false)
+			}
+			return nil
+		case 't', 'f':
+			va.SetBool(k == 't')
+			return nil
+		}
+		return newUnmarshalError(dec, 0, val, t, nil)
+	}
+	return &fncs
+}
+
+func makeStringArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if hasFormat(enc, mo) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		return enc.WriteToken(jsontext.String(va.String()))
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		val, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		switch val.Kind() {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetString("")
+			}
+			return nil
+		case '"':
+			va.SetString(string(unquote(val)))
+			return nil
+		}
+		return newUnmarshalError(dec, 0, val, t, nil)
+	}
+	return &fncs
+}
+
+// stringifyNumber reports whether a number should be encoded
+// within a JSON string.
+func stringifyNumber(enc *jsontext.Encoder, mo *jsonopts.Struct) bool {
+	return mo.Flags.Get(jsonflags.StringifyNumbers) || export.Encoder(enc).NeedObjectName()
+}
+
+// readNumber reads the next JSON value from dec as a number,
+// unquoting it if necessary. It returns the raw JSON value as read
+// along with the number itself, which is nil for a JSON null.
+func readNumber(dec *jsontext.Decoder, t reflect.Type, uo *jsonopts.Struct) (val, num jsontext.Value, err error) {
+	stringify := uo.Flags.Get(jsonflags.StringifyNumbers)
+	val, err = dec.ReadValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch k := val.Kind(); k {
+	case 'n':
+		return val, nil, nil
+	case '"':
+		if stringify {
+			num = unquote(val)
+			if n, err := jsonwire.ConsumeNumber(num); n == len(num) && err == nil {
+				return val, num, nil
+			}
+			return val, nil, newUnmarshalError(dec, k, val, t, strconv.ErrSyntax)
+		}
+	case '0':
+		if !stringify {
+			return val, val, nil
+		}
+	}
+	return val, nil, newUnmarshalError(dec, 0, val, t, nil)
+}
+
+func makeIntArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	bits := t.Bits()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if hasFormat(enc, mo) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		if stringifyNumber(enc, mo) {
+			return enc.WriteToken(jsontext.String(strconv.FormatInt(va.Int(), 10)))
+		}
+		return enc.WriteToken(jsontext.Int(va.Int()))
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		val, num, err := readNumber(dec, t, uo)
+		if err != nil {
+			return err
+		}
+		if num == nil {
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetInt(0)
+			}
+			return nil
+		}
+		neg := num[0] == '-'
+		if neg {
+			num = num[len("-"):]
+		}
+		n, ok := jsonwire.ParseUint(num)
+		maxInt := uint64(1) << (bits - 1)
+		overflow := (neg && n > maxInt) || (!neg && n > maxInt-1)
+		if !ok {
+			if n != math.MaxUint64 {
+				return newUnmarshalError(dec, 0, val, t, strconv.ErrSyntax)
+			}
+			overflow = true
+		}
+		if overflow {
+			return newUnmarshalError(dec, 0, val, t, strconv.ErrRange)
+		}
+		if neg {
+			va.SetInt(int64(-n))
+		} else {
+			va.SetInt(int64(+n))
+		}
+		return nil
+	}
+	return &fncs
+}
+
+func makeUintArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	bits := t.Bits()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if hasFormat(enc, mo) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		if stringifyNumber(enc, mo) {
+			return enc.WriteToken(jsontext.String(strconv.FormatUint(va.Uint(), 10)))
+		}
+		return enc.WriteToken(jsontext.Uint(va.Uint()))
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		val, num, err := readNumber(dec, t, uo)
+		if err != nil {
+			return err
+		}
+		if num == nil {
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetUint(0)
+			}
+			return nil
+		}
+		n, ok := jsonwire.ParseUint(num)
+		overflow := n > math.MaxUint64>>(64-bits)
+		if !ok {
+			if n != math.MaxUint64 {
+				return newUnmarshalError(dec, 0, val, t, strconv.ErrSyntax)
+			}
+			overflow = true
+		}
+		if overflow {
+			return newUnmarshalError(dec, 0, val, t, strconv.ErrRange)
+		}
+		va.SetUint(n)
+		return nil
+	}
+	return &fncs
+}
+
+func makeFloatArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	bits := t.Bits()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		var allowNonFinite bool
+		if hasFormat(enc, mo) {
+			if mo.Format != "nonfinite" {
+				return newInvalidFormatError(enc, t, mo)
+			}
+			allowNonFinite = true
+		}
+		fv := va.Float()
+		if math.IsNaN(fv) || math.IsInf(fv, 0) {
+			if !allowNonFinite {
+				err := fmt.Errorf("%w: %s", internal.ErrNonFiniteNumber, strconv.FormatFloat(fv, 'g', -1, bits))
+				return newMarshalError(enc, t, err)
+			}
+			return enc.WriteToken(jsontext.Float(fv))
+		}
+		b := jsonwire.AppendFloat(nil, fv, bits)
+		if stringifyNumber(enc, mo) {
+			return enc.WriteToken(jsontext.String(string(b)))
+		}
+		return enc.WriteValue(b)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		var allowNonFinite bool
+		if hasFormat(dec, uo) {
+			if uo.Format != "nonfinite" {
+				return newInvalidFormatError(dec, t, uo)
+			}
+			allowNonFinite = true
+		}
+		if allowNonFinite && dec.PeekKind() == '"' {
+			val, err := dec.ReadValue()
+			if err != nil {
+				return err
+			}
+			switch string(unquote(val)) {
+			case "NaN":
+				va.SetFloat(math.NaN())
+			case "Infinity":
+				va.SetFloat(math.Inf(+1))
+			case "-Infinity":
+				va.SetFloat(math.Inf(-1))
+			default:
+				if !uo.Flags.Get(jsonflags.StringifyNumbers) {
+					return newUnmarshalError(dec, 0, val, t, nil)
+				}
+				num := unquote(val)
+				fv, ok := jsonwire.ParseFloat(num, bits)
+				if n, err := jsonwire.ConsumeNumber(num); n != len(num) || err != nil || !ok {
+					return newUnmarshalError(dec, 0, val, t, strconv.ErrSyntax)
+				}
+				va.SetFloat(fv)
+			}
+			return nil
+		}
+		val, num, err := readNumber(dec, t, uo)
+		if err != nil {
+			return err
+		}
+		if num == nil {
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetFloat(0)
+			}
+			return nil
+		}
+		fv, ok := jsonwire.ParseFloat(num, bits)
+		va.SetFloat(fv)
+		if !ok {
+			return newUnmarshalError(dec, 0, val, t, strconv.ErrRange)
+		}
+		return nil
+	}
+	return &fncs
+}
+
+func makeBytesArshaler(t reflect.Type, fncs *arshaler) *arshaler {
+	// NOTE: This handles both []~byte and [N]~byte.
+	// The v2 default is to treat a []namedByte as equivalent to []T
+	// since being able to convert []namedByte to []byte relies on
+	// dubious Go reflection behavior (see https://go.dev/issue/24746).
+	isNamedByte := t.Elem().PkgPath() != ""
+	marshalArray := fncs.marshal
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if !mo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) && isNamedByte {
+			return marshalArray(enc, va, mo) // treat as []T or [N]T
+		}
+		appendEncode := appendEncodeBase64
+		if hasFormat(enc, mo) {
+			switch mo.Format {
+			case "base64":
+				appendEncode = appendEncodeBase64
+			case "base64url":
+				appendEncode = appendEncodeBase64URL
+			case "base32":
+				appendEncode = appendEncodeBase32
+			case "base32hex":
+				appendEncode = appendEncodeBase32Hex
+			case "base16", "hex":
+				appendEncode = appendEncodeBase16
+			case "array":
+				mo.Format = ""
+				return marshalArray(enc, va, mo)
+			default:
+				return newInvalidFormatError(enc, t, mo)
+			}
+		} else if mo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) && va.Kind() == reflect.Array {
+			return marshalArray(enc, va, mo)
+		}
+		if mo.Flags.Get(jsonflags.FormatNilSliceAsNull) && va.Kind() == reflect.Slice && va.IsNil() {
+			return enc.WriteToken(jsontext.Null)
+		}
+		return enc.WriteToken(jsontext.String(string(appendEncode(nil, va.Bytes()))))
+	}
+	unmarshalArray := fncs.unmarshal
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if !uo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) && isNamedByte {
+			return unmarshalArray(dec, va, uo) // treat as []T or [N]T
+		}
+		decode, decodedLen, encodedLen := base64.StdEncoding.Decode, base64.StdEncoding.DecodedLen, base64.StdEncoding.EncodedLen
+		if hasFormat(dec, uo) {
+			switch uo.Format {
+			case "base64":
+			case "base64url":
+				decode, decodedLen, encodedLen = base64.URLEncoding.Decode, base64.URLEncoding.DecodedLen, base64.URLEncoding.EncodedLen
+			case "base32":
+				decode, decodedLen, encodedLen = base32.StdEncoding.Decode, base32.StdEncoding.DecodedLen, base32.StdEncoding.EncodedLen
+			case "base32hex":
+				decode, decodedLen, encodedLen = base32.HexEncoding.Decode, base32.HexEncoding.DecodedLen, base32.HexEncoding.EncodedLen
+			case "base16", "hex":
+				decode, decodedLen, encodedLen = decodeBase16, hex.DecodedLen, hex.EncodedLen
+			case "array":
+				uo.Format = ""
+				return unmarshalArray(dec, va, uo)
+			default:
+				return newInvalidFormatError(dec, t, uo)
+			}
+		} else if uo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) && va.Kind() == reflect.Array {
+			return unmarshalArray(dec, va, uo)
+		}
+		val, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		switch k := val.Kind(); k {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) || va.Kind() != reflect.Array {
+				va.SetZero()
+			}
+			return nil
+		case '"':
+			b := unquote(val)
+			if !uo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) && bytes.ContainsAny(b, "\r\n") {
+				return newUnmarshalError(dec, k, val, t, errors.New("illegal character in encoded data"))
+			}
+
+			dst := make([]byte, decodedLen(len(b)))
+			n2, err := decode(dst, b)
+			if err == nil && len(b) != encodedLen(n2) && !uo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) {
+				// TODO(https://go.dev/issue/53845): RFC 4648, section 3.3,
+				// specifies that non-alphabet characters must be rejected.
+				// Unfortunately, the "base32" and "base64" packages allow
+				// '\r' and '\n' characters by default.
+				err = errors.New("illegal character in encoded data")
+			}
+			if err != nil {
+				return newUnmarshalError(dec, k, val, t, err)
+			}
+			if va.Kind() == reflect.Array {
+				if n2 != va.Len() {
+					err := fmt.Errorf("decoded length of %d mismatches array length of %d", n2, va.Len())
+					return newUnmarshalError(dec, k, val, t, err)
+				}
+				copy(va.Bytes(), dst[:n2])
+			} else {
+				va.SetBytes(dst[:n2])
+			}
+			return nil
+		}
+		return newUnmarshalError(dec, 0, val, t, nil)
+	}
+	return fncs
+}
+
+func appendEncodeBase16(dst, src []byte) []byte    { return hex.AppendEncode(dst, src) }
+func appendEncodeBase32(dst, src []byte) []byte    { return base32.StdEncoding.AppendEncode(dst, src) }
+func appendEncodeBase32Hex(dst, src []byte) []byte { return base32.HexEncoding.AppendEncode(dst, src) }
+func appendEncodeBase64(dst, src []byte) []byte    { return base64.StdEncoding.AppendEncode(dst, src) }
+func appendEncodeBase64URL(dst, src []byte) []byte { return base64.URLEncoding.AppendEncode(dst, src) }
+
+func decodeBase16(dst, src []byte) (int, error) { return hex.Decode(dst, src) }
+
+func makeMapArshaler(t reflect.Type) *arshaler {
+	// NOTE: A Go map is encoded as a JSON object in the order of
+	// the underlying Go map iteration, unless Deterministic is specified.
+	var fncs arshaler
+	var (
+		once    sync.Once
+		keyFncs *arshaler
+	)
+	init := func() {
+		keyFncs = lookupArshaler(t.Key())
+	}
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		emitNull := mo.Flags.Get(jsonflags.FormatNilMapAsNull)
+		if hasFormat(enc, mo) {
+			switch mo.Format {
+			case "emitnull":
+				emitNull = true
+			case "emitempty":
+				emitNull = false
+			default:
+				return newInvalidFormatError(enc, t, mo)
+			}
+		}
+
+		// Handle empty maps.
+		n := va.Len()
+		if n == 0 {
+			if emitNull && va.IsNil() {
+				return enc.WriteToken(jsontext.Null)
+			}
+			if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+				return err
+			}
+			return enc.WriteToken(jsontext.EndObject)
+		}
+
+		leave, err := checkCycle(enc, va)
+		if err != nil {
+			return err
+		}
+		defer leave()
+
+		once.Do(init)
+		// Historically, v1 ignored any text methods on a Go map key
+		// of a string kind and always used the string value directly.
+		hasCustomKey := marshalersOf(mo).has(t.Key())
+		directKey := t.Key().Kind() == reflect.String &&
+			((optimizeCommon && !keyFncs.nonDefault && !hasCustomKey) || mo.Flags.Get(jsonflags.CallMethodsWithLegacySemantics))
+		keyName := func(k addressableValue) (string, error) {
+			if directKey {
+				return k.String(), nil
+			}
+			return marshalMapKey(enc, t, k, mo)
+		}
+
+		if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+			return err
+		}
+		k := newAddressableValue(t.Key())
+		v := newAddressableValue(t.Elem())
+		if !mo.Flags.Get(jsonflags.Deterministic) || n <= 1 {
+			for iter := va.MapRange(); iter.Next(); {
+				k.SetIterKey(iter)
+				name, err := keyName(k)
+				if err != nil {
+					return err
+				}
+				if err := enc.WriteToken(jsontext.String(name)); err != nil {
+					return err
+				}
+				v.SetIterValue(iter)
+				if err := marshalValue(enc, v, mo); err != nil {
+					return err
+				}
+			}
+		} else {
+			type member struct {
+				name string
+				val  addressableValue
+			}
+			members := make([]member, 0, n)
+			for iter := va.MapRange(); iter.Next(); {
+				k.SetIterKey(iter)
+				name, err := keyName(k)
+				if err != nil {
+					return err
+				}
+				v := newAddressableValue(t.Elem())
+				v.SetIterValue(iter)
+				members = append(members, member{name, v})
+			}
+			slices.SortFunc(members, func(x, y member) int {
+				return strings.Compare(x.name, y.name)
+			})
+			for _, m := range members {
+				if err := enc.WriteToken(jsontext.String(m.name)); err != nil {
+					return err
+				}
+				if err := marshalValue(enc, m.val, mo); err != nil {
+					return err
+				}
+			}
+		}
+		return enc.WriteToken(jsontext.EndObject)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			switch uo.Format {
+			case "emitnull", "emitempty":
+				// ignore marshal-specific formatting flags
+			default:
+				return newInvalidFormatError(dec, t, uo)
+			}
+		}
+		switch k := dec.PeekKind(); k {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			va.SetZero()
+			return nil
+		case '{':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			once.Do(init)
+			hasCustomKey := unmarshalersOf(uo).has(t.Key())
+			directKey := optimizeCommon && t.Key().Kind() == reflect.String && !keyFncs.nonDefault && !hasCustomKey
+			if va.IsNil() {
+				va.Set(reflect.MakeMap(t))
+			}
+
+			var errUnmarshal error
+			k := newAddressableValue(t.Key())
+			v := newAddressableValue(t.Elem())
+			for dec.PeekKind() != '}' {
+				// Process the object member name.
+				k.SetZero()
+				if directKey {
+					val, err := dec.ReadValue()
+					if err != nil {
+						return err
+					}
+					k.SetString(string(unquote(val)))
+				} else {
+					val, err := dec.ReadValue()
+					if err != nil {
+						return err
+					}
+					if err := unmarshalMapKey(dec, t, k, val, uo); err != nil {
+						if isFatalError(err, uo.Flags) {
+							return err
+						}
+						errUnmarshal = cmp.Or(errUnmarshal, err)
+						if err := dec.SkipValue(); err != nil {
+							return err
+						}
+						continue
+					}
+				}
+
+				// Process the object member value.
+				// Historically, v1 always unmarshaled into a fresh zero value,
+				// while v2 merges into any existing value.
+				if v2 := va.MapIndex(k.Value); v2.IsValid() && !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					v.Set(v2)
+				} else {
+					v.SetZero()
+				}
+				if err := unmarshalValue(dec, v, uo); err != nil {
+					if isFatalError(err, uo.Flags) {
+						va.SetMapIndex(k.Value, v.Value)
+						return err
+					}
+					errUnmarshal = cmp.Or(errUnmarshal, err)
+				}
+				va.SetMapIndex(k.Value, v.Value)
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorBefore(dec, t, nil)
+	}
+	return &fncs
+}
+
+// marshalMapKey marshals the Go map key k as a JSON string,
+// returning the unquoted string value.
+func marshalMapKey(enc *jsontext.Encoder, t reflect.Type, k addressableValue, mo *jsonopts.Struct) (string, error) {
+	if k.Kind() == reflect.Pointer && k.IsNil() && mo.Flags.Get(jsonflags.CallMethodsWithLegacySemantics) &&
+		k.Type().Implements(textMarshalerType) {
+		return "", nil // historically, v1 encoded a nil text marshaler key as ""
+	}
+	mo2 := *mo
+	mo2.Flags.Set(jsonflags.StringifyNumbers | jsonflags.OmitTopLevelNewline | 1)
+	mo2.Format = ""
+	be := export.GetBufferedEncoder(&mo2)
+	defer export.PutBufferedEncoder(be)
+	if err := marshalValue(be, k, &mo2); err != nil {
+		if serr, ok := err.(*SemanticError); ok && serr.GoType == k.Type() && serr.JSONPointer == "" {
+			serr.ByteOffset = enc.OutputOffset()
+			serr.JSONPointer = nextPointer(enc.StackPointer(), enc.StackDepth(), enc.StackIndex)
+		}
+		return "", err
+	}
+	b := export.Encoder(be).Buf
+	if jsontext.Value(b).Kind() != '"' {
+		return "", newMarshalError(enc, t, nil)
+	}
+	return string(unquote(b)), nil
+}
+
+// unmarshalMapKey unmarshals the quoted JSON object name val into the Go map key k.
+func unmarshalMapKey(dec *jsontext.Decoder, t reflect.Type, k addressableValue, val jsontext.Value, uo *jsonopts.Struct) error {
+	uo2 := *uo
+	uo2.Flags.Set(jsonflags.StringifyNumbers | 1)
+	uo2.Format = ""
+	kt := k.Type()
+	switch {
+	case kt.Kind() == reflect.Bool || kt.Kind() == reflect.Struct || kt.Kind() == reflect.Array ||
+		kt.Kind() == reflect.Slice || kt.Kind() == reflect.Map:
+		// Types that cannot be represented by a JSON string
+		// unless they implement a text or JSON method.
+		if !implementsAny(kt, allUnmarshalerTypes...) {
+			if !unmarshalersOf(uo).has(kt) {
+				return newUnmarshalError(dec, '"', val, t, nil)
+			}
+		}
+	}
+	bd := export.GetBufferedDecoder(val, &uo2)
+	defer export.PutBufferedDecoder(bd)
+	if err := unmarshalValue(bd, k, &uo2); err != nil {
+		if serr, ok := err.(*SemanticError); ok {
+			serr.ByteOffset = dec.InputOffset() - int64(len(val))
+			serr.JSONPointer = dec.StackPointer()
+		}
+		return err
+	}
+	return nil
+}
+
+func makeStructArshaler(t reflect.Type) *arshaler {
+	// NOTE: The logic below disables namespaces for tracking duplicate names
+	// and does the tracking locally with an efficient bit-set based on which
+	// Go struct fields were seen.
+	var fncs arshaler
+	var (
+		once    sync.Once
+		fields  structFields
+		errInit *SemanticError
+	)
+	init := func() {
+		fields, errInit = makeStructFields(t)
+	}
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if hasFormat(enc, mo) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		once.Do(init)
+		if errInit != nil && !mo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+			return newMarshalError(enc, errInit.GoType, errInit.Err)
+		}
+		if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+			return err
+		}
+		prevFormat, prevFormatDepth := mo.Format, mo.FormatDepth
+		defer func() { mo.Format, mo.FormatDepth = prevFormat, prevFormatDepth }()
+		for i := range fields.flattened {
+			f := &fields.flattened[i]
+			v, ok := va.fieldByIndex(f.index, false)
+			if !ok {
+				continue // nil embedded pointer
+			}
+
+			// OmitZero skips the field if the Go value is zero,
+			// which we can determine up front without calling the marshaler.
+			if (f.omitzero || mo.Flags.Get(jsonflags.OmitZeroStructFields)) &&
+				((f.isZero == nil && v.IsZero()) || (f.isZero != nil && f.isZero(v))) {
+				continue
+			}
+
+			// OmitEmpty skips the field if the marshaled JSON value is empty.
+			if f.omitempty {
+				if mo.Flags.Get(jsonflags.OmitEmptyWithLegacyDefinition) {
+					if isLegacyEmpty(v) {
+						continue
+					}
+				} else if optimizeCommon && f.isEmpty != nil && !lookupArshaler(f.typ).nonDefault {
+					if f.isEmpty(v) {
+						continue
+					}
+				} else if empty, err := marshalsEmpty(v, mo); err != nil {
+					return err
+				} else if empty {
+					continue
+				}
+			}
+
+			// Write the object member name.
+			if err := enc.WriteToken(jsontext.String(f.name)); err != nil {
+				return err
+			}
+
+			// Write the object member value.
+			mo.Format, mo.FormatDepth = "", 0
+			if f.format != "" {
+				mo.Format, mo.FormatDepth = f.format, enc.StackDepth()
+			}
+			var err error
+			switch {
+			case f.string && mo.Flags.Get(jsonflags.StringifyWithLegacySemantics):
+				err = marshalLegacyStringify(enc, v, mo)
+			case f.string:
+				prevFlags := mo.Flags
+				mo.Flags.Set(jsonflags.StringifyNumbers | 1)
+				err = marshalValue(enc, v, mo)
+				mo.Flags = prevFlags
+			default:
+				err = marshalValue(enc, v, mo)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		mo.Format, mo.FormatDepth = "", 0
+
+		if fields.inlinedFallback != nil && !mo.Flags.Get(jsonflags.DiscardUnknownMembers) {
+			if err := marshalInlinedFallbackAll(enc, va, mo, fields.inlinedFallback); err != nil {
+				return err
+			}
+		}
+		return enc.WriteToken(jsontext.EndObject)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		switch k := dec.PeekKind(); k {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetZero()
+			}
+			return nil
+		case '{':
+			once.Do(init)
+			if errInit != nil && !uo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+				return newUnmarshalErrorBefore(dec, errInit.GoType, errInit.Err)
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			prevFormat, prevFormatDepth := uo.Format, uo.FormatDepth
+			defer func() { uo.Format, uo.FormatDepth = prevFormat, prevFormatDepth }()
+
+			var errUnmarshal error
+			for dec.PeekKind() != '}' {
+				// Process the object member name.
+				val, err := dec.ReadValue()
+				if err != nil {
+					return err
+				}
+				name := unquote(val)
+				f := fields.byActualName[string(name)]
+				if f == nil {
+					for _, f2 := range fields.lookupByFoldedName(name) {
+						if f2.matchFoldedName(name, uo.Flags.Get(jsonflags.MatchCaseInsensitiveNames), uo.Flags.Get(jsonflags.MatchCaseSensitiveDelimiter)) {
+							f = f2
+							break
+						}
+					}
+				}
+				if f == nil {
+					if uo.Flags.Get(jsonflags.RejectUnknownMembers) && (fields.inlinedFallback == nil || fields.inlinedFallback.unknown) {
+						err := &SemanticError{action: "unmarshal", GoType: t, Err: ErrUnknownName,
+							ByteOffset:  dec.InputOffset() - int64(len(val)),
+							JSONPointer: dec.StackPointer(),
+						}
+						if isFatalError(err, uo.Flags) {
+							return err
+						}
+						errUnmarshal = cmp.Or[error](errUnmarshal, err)
+						if err := dec.SkipValue(); err != nil {
+							return err
+						}
+						continue
+					}
+					if fields.inlinedFallback == nil {
+						if err := dec.SkipValue(); err != nil {
+							return err
+						}
+						continue
+					}
+					if err := unmarshalInlinedFallbackNext(dec, va, uo, fields.inlinedFallback, val.Clone(), name); err != nil {
+						if isFatalError(err, uo.Flags) {
+							return err
+						}
+						errUnmarshal = cmp.Or(errUnmarshal, err)
+					}
+					continue
+				}
+
+				// Process the object member value.
+				v, ok := va.fieldByIndex(f.index, true)
+				if !ok {
+					err := newUnmarshalErrorBefore(dec, t, fmt.Errorf("cannot set embedded pointer to unexported struct type %v", indirectType(t.FieldByIndex(f.index[:len(f.index)-1]).Type)))
+					if isFatalError(err, uo.Flags) {
+						return err
+					}
+					errUnmarshal = cmp.Or(errUnmarshal, err)
+					continue
+				}
+				uo.Format, uo.FormatDepth = "", 0
+				if f.format != "" {
+					uo.Format, uo.FormatDepth = f.format, dec.StackDepth()
+				}
+				switch {
+				case f.string && uo.Flags.Get(jsonflags.StringifyWithLegacySemantics):
+					err = unmarshalLegacyStringify(dec, v, uo)
+				case f.string:
+					prevFlags := uo.Flags
+					uo.Flags.Set(jsonflags.StringifyNumbers | 1)
+					err = unmarshalValue(dec, v, uo)
+					uo.Flags = prevFlags
+				default:
+					err = unmarshalValue(dec, v, uo)
+				}
+				if err != nil {
+					if isFatalError(err, uo.Flags) {
+						return err
+					}
+					errUnmarshal = cmp.Or(errUnmarshal, err)
+				}
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorBefore(dec, t, nil)
+	}
+	return &fncs
+}
+
+// isLegacyEmpty reports whether va is empty according to the v1 definition
+// of the `omitempty` tag option.
+func isLegacyEmpty(va addressableValue) bool {
+	switch va.Kind() {
+	case reflect.Bool:
+		return !va.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return va.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return va.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return va.Float() == 0
+	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
+		return va.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return va.IsNil()
+	}
+	return false
+}
+
+// marshalsEmpty reports whether va marshals as an empty JSON value,
+// which is either a JSON null, empty string, empty object, or empty array.
+func marshalsEmpty(va addressableValue, mo *jsonopts.Struct) (bool, error) {
+	mo2 := *mo
+	mo2.Flags.Set(jsonflags.OmitTopLevelNewline | 1)
+	mo2.Flags.Set(jsonflags.Multiline | 0)
+	mo2.Format = ""
+	be := export.GetBufferedEncoder(&mo2)
+	defer export.PutBufferedEncoder(be)
+	if err := marshalValue(be, va, &mo2); err != nil {
+		return false, err
+	}
+	switch string(export.Encoder(be).Buf) {
+	case "null", `""`, "{}", "[]":
+		return true, nil
+	}
+	return false, nil
+}
+
+// canLegacyStringify reports whether t is a type that the v1 `string`
+// tag option applies to, which is a bool, number, or string kind,
+// or a pointer to one.
+func canLegacyStringify(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer && t.Name() == "" {
+		t = t.Elem()
+	}
+	if lookupArshaler(t).nonDefault {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// marshalLegacyStringify marshals va as usual, but encodes the resulting
+// JSON value within a JSON string as done by the v1 `string` tag option.
+func marshalLegacyStringify(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+	if !canLegacyStringify(va.Type()) {
+		return marshalValue(enc, va, mo)
+	}
+	mo2 := *mo
+	mo2.Flags.Set(jsonflags.OmitTopLevelNewline | 1)
+	mo2.Flags.Set(jsonflags.Multiline | 0)
+	be := export.GetBufferedEncoder(&mo2)
+	defer export.PutBufferedEncoder(be)
+	if err := marshalValue(be, va, &mo2); err != nil {
+		return err
+	}
+	b := export.Encoder(be).Buf
+	if string(b) == "null" {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.String(string(b)))
+}
+
+// unmarshalLegacyStringify unmarshals a JSON value encoded within
+// a JSON string as done by the v1 `string` tag option.
+func unmarshalLegacyStringify(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+	t := va.Type()
+	if !canLegacyStringify(t) {
+		return unmarshalValue(dec, va, uo)
+	}
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	switch val.Kind() {
+	case 'n':
+		// Historically, v1 cleared pointers for a JSON null
+		// (whether quoted or not), but left other values unchanged.
+		if t.Kind() == reflect.Pointer {
+			va.SetZero()
+		}
+		return nil
+	case '"':
+		b := unquote(val)
+		if string(b) == "null" {
+			if t.Kind() == reflect.Pointer {
+				va.SetZero()
+			}
+			return nil
+		}
+		bd := export.GetBufferedDecoder(b, uo)
+		defer export.PutBufferedDecoder(bd)
+		err := unmarshalValue(bd, va, uo)
+		if err == nil {
+			err = export.Decoder(bd).CheckEOF()
+		}
+		if err != nil {
+			return newUnmarshalError(dec, 0, val, t, fmt.Errorf("invalid use of ,string struct tag, trying to unmarshal %s into %v", val, t))
+		}
+		return nil
+	}
+	return newUnmarshalError(dec, 0, val, t, fmt.Errorf("invalid use of ,string struct tag, trying to unmarshal unquoted value into %v", t))
+}
+
+// marshalInlinedFallbackAll marshals all the members stored in the
+// inlined fallback field f of the Go struct value va.
+func marshalInlinedFallbackAll(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct, f *structField) error {
+	v, ok := va.fieldByIndex(f.index, false)
+	if !ok {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = addressableValue{v.Elem(), false} // dereferenced pointer is always addressable
+	}
+
+	if v.Type() == jsontextValueType {
+		b := v.Interface().(jsontext.Value)
+		if len(b) == 0 { // TODO: Should this be nil? What if it were all whitespace?
+			return nil
+		}
+		dec := export.GetBufferedDecoder(b)
+		defer export.PutBufferedDecoder(dec)
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return newMarshalError(enc, v.Type(), err)
+		}
+		if tok.Kind() != '{' {
+			return newMarshalError(enc, v.Type(), errors.New("inlined raw value must be a JSON object"))
+		}
+		for dec.PeekKind() != '}' {
+			// Parse the JSON object name.
+			name, err := dec.ReadToken()
+			if err != nil {
+				return newMarshalError(enc, v.Type(), err)
+			}
+			if err := enc.WriteToken(name); err != nil {
+				return err
+			}
+
+			// Parse the JSON object value.
+			val, err := dec.ReadValue()
+			if err != nil {
+				return newMarshalError(enc, v.Type(), err)
+			}
+			if err := enc.WriteValue(val); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return newMarshalError(enc, v.Type(), err)
+		}
+		if err := export.Decoder(dec).CheckEOF(); err != nil {
+			return newMarshalError(enc, v.Type(), err)
+		}
+		return nil
+	}
+
+	n := v.Len()
+	if n == 0 {
+		return nil
+	}
+	names := make([]string, 0, n)
+	for iter := v.MapRange(); iter.Next(); {
+		names = append(names, iter.Key().String())
+	}
+	if mo.Flags.Get(jsonflags.Deterministic) {
+		slices.Sort(names)
+	}
+	mk := newAddressableValue(v.Type().Key())
+	mv := newAddressableValue(v.Type().Elem())
+	for _, name := range names {
+		mk.SetString(name)
+		if err := enc.WriteToken(jsontext.String(name)); err != nil {
+			return err
+		}
+		mv.Set(v.MapIndex(mk.Value))
+		if err := marshalValue(enc, mv, mo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalInlinedFallbackNext unmarshals the next JSON object member value
+// into the inlined fallback field f of the Go struct value va.
+// The quoted JSON object name has already been read as quotedName.
+func unmarshalInlinedFallbackNext(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct, f *structField, quotedName, name []byte) error {
+	v, ok := va.fieldByIndex(f.index, true)
+	if !ok {
+		return newUnmarshalErrorBefore(dec, va.Type(), errors.New("cannot set embedded pointer to unexported struct type"))
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = addressableValue{v.Elem(), false} // dereferenced pointer is always addressable
+	}
+
+	if v.Type() == jsontextValueType {
+		b := v.Addr().Interface().(*jsontext.Value)
+		if len(*b) == 0 {
+			*b = append(*b, '{')
+		} else {
+			*b = jsonwire.TrimSuffixWhitespace(*b)
+			if jsonwire.HasSuffixByte(*b, '}') {
+				// TODO: When merging into an object for the first time,
+				// should we verify that it is valid?
+				*b = jsonwire.TrimSuffixByte(*b, '}')
+				*b = jsonwire.TrimSuffixWhitespace(*b)
+				if !jsonwire.HasSuffixByte(*b, ',') && !jsonwire.HasSuffixByte(*b, '{') {
+					*b = append(*b, ',')
+				}
+			} else {
+				return newUnmarshalErrorBefore(dec, v.Type(), errors.New("inlined raw value must be a JSON object"))
+			}
+		}
+		*b = append(*b, quotedName...)
+		*b = append(*b, ':')
+		val, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		*b = append(*b, val...)
+		*b = append(*b, '}')
+		return nil
+	}
+
+	mk := reflect.New(v.Type().Key()).Elem()
+	mk.SetString(string(name))
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	mv := newAddressableValue(v.Type().Elem())
+	if v2 := v.MapIndex(mk); v2.IsValid() {
+		mv.Set(v2)
+	}
+	err := unmarshalValue(dec, mv, uo)
+	v.SetMapIndex(mk, mv.Value)
+	return err
+}
+
+func makeSliceArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		emitNull := mo.Flags.Get(jsonflags.FormatNilSliceAsNull)
+		if hasFormat(enc, mo) {
+			switch mo.Format {
+			case "emitnull":
+				emitNull = true
+			case "emitempty":
+				emitNull = false
+			default:
+				return newInvalidFormatError(enc, t, mo)
+			}
+		}
+
+		// Handle empty slices.
+		n := va.Len()
+		if n == 0 {
+			if emitNull && va.IsNil() {
+				return enc.WriteToken(jsontext.Null)
+			}
+			if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+				return err
+			}
+			return enc.WriteToken(jsontext.EndArray)
+		}
+
+		leave, err := checkCycle(enc, va)
+		if err != nil {
+			return err
+		}
+		defer leave()
+
+		if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+			return err
+		}
+		for i := range n {
+			v := addressableValue{va.Index(i), false} // indexed slice element is always addressable
+			if err := marshalValue(enc, v, mo); err != nil {
+				return err
+			}
+		}
+		return enc.WriteToken(jsontext.EndArray)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			switch uo.Format {
+			case "emitnull", "emitempty":
+				// ignore marshal-specific formatting flags
+			default:
+				return newInvalidFormatError(dec, t, uo)
+			}
+		}
+		switch k := dec.PeekKind(); k {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			va.SetZero()
+			return nil
+		case '[':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			mustZero := !uo.Flags.Get(jsonflags.MergeWithLegacySemantics)
+			cap := va.Cap()
+			if cap > 0 {
+				va.SetLen(cap)
+			}
+			var i int
+			var errUnmarshal error
+			for dec.PeekKind() != ']' {
+				if i == cap {
+					va.Value.Grow(1)
+					cap = va.Cap()
+					va.SetLen(cap)
+				}
+				v := addressableValue{va.Index(i), false} // indexed slice element is always addressable
+				i++
+				if mustZero {
+					v.SetZero()
+				}
+				if err := unmarshalValue(dec, v, uo); err != nil {
+					if isFatalError(err, uo.Flags) {
+						va.SetLen(i)
+						return err
+					}
+					errUnmarshal = cmp.Or(errUnmarshal, err)
+				}
+			}
+			if i == 0 {
+				va.Set(reflect.MakeSlice(va.Type(), 0, 0))
+			} else {
+				va.SetLen(i)
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorBefore(dec, t, nil)
+	}
+	return &fncs
+}
+
+func makeArrayArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	n := t.Len()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if hasFormat(enc, mo) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+			return err
+		}
+		for i := range n {
+			v := addressableValue{va.Index(i), va.forcedAddr} // indexed array element is addressable if array is addressable
+			if err := marshalValue(enc, v, mo); err != nil {
+				return err
+			}
+		}
+		return enc.WriteToken(jsontext.EndArray)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		switch k := dec.PeekKind(); k {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetZero()
+			}
+			return nil
+		case '[':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			mustZero := !uo.Flags.Get(jsonflags.MergeWithLegacySemantics)
+			anyLength := uo.Flags.Get(jsonflags.UnmarshalArrayFromAnyLength)
+			var i int
+			var errUnmarshal error
+			for dec.PeekKind() != ']' {
+				if i >= n {
+					if err := dec.SkipValue(); err != nil {
+						return err
+					}
+					i++
+					continue
+				}
+				v := addressableValue{va.Index(i), va.forcedAddr} // indexed array element is addressable if array is addressable
+				i++
+				if mustZero {
+					v.SetZero()
+				}
+				if err := unmarshalValue(dec, v, uo); err != nil {
+					if isFatalError(err, uo.Flags) {
+						return err
+					}
+					errUnmarshal = cmp.Or(errUnmarshal, err)
+				}
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if i != n && !anyLength {
+				err := fmt.Errorf("%w: got %d, want %d", internal.ErrMismatchedLength, i, n)
+				return newUnmarshalError(dec, '[', nil, t, err)
+			}
+			for ; i < n; i++ {
+				va.Index(i).SetZero()
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorBefore(dec, t, nil)
+	}
+	return &fncs
+}
+
+func makePointerArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		// NOTE: Struct.Format is forwarded to underlying marshal.
+		if va.IsNil() {
+			return enc.WriteToken(jsontext.Null)
+		}
+		leave, err := checkCycle(enc, va)
+		if err != nil {
+			return err
+		}
+		defer leave()
+		v := addressableValue{va.Elem(), false} // dereferenced pointer is always addressable
+		return marshalValue(enc, v, mo)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		// NOTE: Struct.Format is forwarded to underlying unmarshal.
+		if dec.PeekKind() == 'n' {
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			va.SetZero()
+			return nil
+		}
+		if va.IsNil() {
+			va.Set(reflect.New(t.Elem()))
+		}
+		v := addressableValue{va.Elem(), false} // dereferenced pointer is always addressable
+		return unmarshalValue(dec, v, uo)
+	}
+	return &fncs
+}
+
+func makeInterfaceArshaler(t reflect.Type) *arshaler {
+	// NOTE: Values retrieved from an interface are not addressable,
+	// so we shallow copy the values to make them addressable and
+	// store them back into the interface afterwards.
+	var fncs arshaler
+	whichMarshaler := t
+	if !slices.Contains(allMarshalerTypes, t) {
+		whichMarshaler = nil
+	}
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		if hasFormat(enc, mo) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		if va.IsNil() {
+			return enc.WriteToken(jsontext.Null)
+		}
+		if whichMarshaler != nil && mo.Flags.Get(jsonflags.CallMethodsWithLegacySemantics) &&
+			va.Elem().Kind() == reflect.Pointer && va.Elem().IsNil() {
+			// Historically, v1 called marshal methods on a nil pointer
+			// stored within an interface of the marshaler type itself.
+			// The pointer marshaler never calls methods on a nil receiver,
+			// so wrap the value in a struct that promotes the method
+			// onto a value receiver.
+			var w any
+			switch m := va.Elem().Interface(); whichMarshaler {
+			case jsonMarshalerToType:
+				w = struct{ MarshalerTo }{m.(MarshalerTo)}
+			case jsonMarshalerType:
+				w = struct{ Marshaler }{m.(Marshaler)}
+			case textAppenderType:
+				w = struct{ encoding.TextAppender }{m.(encoding.TextAppender)}
+			case textMarshalerType:
+				w = struct{ encoding.TextMarshaler }{m.(encoding.TextMarshaler)}
+			}
+			v := newAddressableValue(reflect.TypeOf(w))
+			v.Set(reflect.ValueOf(w))
+			return marshalValue(enc, v, mo)
+		}
+		v := newAddressableValue(va.Elem().Type())
+		v.Set(va.Elem())
+		return marshalValue(enc, v, mo)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		if hasFormat(dec, uo) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		if dec.PeekKind() == 'n' {
+			// Historically, v1 unmarshaled a JSON null into an interface
+			// containing a non-nil pointer to a pointer by setting
+			// the inner pointer to nil rather than clearing the interface.
+			if uo.Flags.Get(jsonflags.MergeWithLegacySemantics) && !va.IsNil() {
+				if e := va.Elem(); e.Kind() == reflect.Pointer && !e.IsNil() && e.Elem().Kind() == reflect.Pointer {
+					if _, err := dec.ReadToken(); err != nil {
+						return err
+					}
+					e.Elem().SetZero()
+					return nil
+				}
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			va.SetZero()
+			return nil
+		}
+
+		// Unmarshal into the existing concrete value if possible.
+		// Historically, v1 only did so if the value was a non-nil pointer,
+		// otherwise it discarded the existing value.
+		if !va.IsNil() {
+			e := va.Elem()
+			if isSelfReference(e) {
+				// Historically, v1 stopped following an interface value
+				// containing a pointer to itself and replaced it instead.
+				va.SetZero()
+			} else if (e.Kind() == reflect.Pointer && !e.IsNil()) || !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				v := newAddressableValue(e.Type())
+				v.Set(e)
+				err := unmarshalValue(dec, v, uo)
+				va.Set(v.Value)
+				return err
+			}
+		}
+
+		if t.NumMethod() > 0 {
+			if uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				return newUnmarshalErrorBefore(dec, t, nil)
+			}
+			return newUnmarshalErrorBefore(dec, t, internal.ErrNilInterface)
+		}
+
+		// Unmarshal into the most natural Go type for the JSON value.
+		var v addressableValue
+		switch dec.PeekKind() {
+		case 'f', 't':
+			v = newAddressableValue(boolType)
+		case '"':
+			v = newAddressableValue(stringType)
+		case '0':
+			v = newAddressableValue(float64Type)
+		case '{':
+			return unmarshalAnyObject(dec, va, uo)
+		case '[':
+			return unmarshalAnyArray(dec, va, uo)
+		default:
+			_, err := dec.ReadValue()
+			return err
+		}
+		err := unmarshalValue(dec, v, uo)
+		va.Set(v.Value)
+		return err
+	}
+	return &fncs
+}
+
+// isSelfReference reports whether v is a pointer to an interface value
+// that itself contains v, which would otherwise be followed forever.
+func isSelfReference(v reflect.Value) bool {
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return false
+	}
+	e := v.Elem()
+	return e.Kind() == reflect.Interface && !e.IsNil() && e.Elem().Equal(v)
+}
+
+// unmarshalAnyObject unmarshals a JSON object into a new map[string]any
+// stored in the interface value va.
+func unmarshalAnyObject(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+	if _, err := dec.ReadToken(); err != nil {
+		return err
+	}
+	m := make(map[string]any)
+	va.Set(reflect.ValueOf(m))
+	var errUnmarshal error
+	for dec.PeekKind() != '}' {
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		name := tok.String()
+		v := newAddressableValue(anyType)
+		if prev, ok := m[name]; ok && !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+			v.Set(reflect.ValueOf(&prev).Elem())
+		}
+		err = unmarshalValue(dec, v, uo)
+		m[name] = v.Interface()
+		if err != nil {
+			if isFatalError(err, uo.Flags) {
+				return err
+			}
+			errUnmarshal = cmp.Or(errUnmarshal, err)
+		}
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		return err
+	}
+	return errUnmarshal
+}
+
+// unmarshalAnyArray unmarshals a JSON array into a new []any
+// stored in the interface value va.
+func unmarshalAnyArray(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+	if _, err := dec.ReadToken(); err != nil {
+		return err
+	}
+	s := []any{}
+	var errUnmarshal error
+	for dec.PeekKind() != ']' {
+		v := newAddressableValue(anyType)
+		err := unmarshalValue(dec, v, uo)
+		s = append(s, v.Interface())
+		if err != nil {
+			if isFatalError(err, uo.Flags) {
+				va.Set(reflect.ValueOf(s))
+				return err
+			}
+			errUnmarshal = cmp.Or(errUnmarshal, err)
+		}
+	}
+	va.Set(reflect.ValueOf(s))
+	if _, err := dec.ReadToken(); err != nil {
+		return err
+	}
+	return errUnmarshal
+}
+
+func makeInvalidArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		return newMarshalError(enc, t, nil)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		return newUnmarshalErrorBefore(dec, t, nil)
+	}
+	return &fncs
+}
@@ -0,0 +1,349 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+var errNonStringValue = errors.New("JSON value must be string type")
+
+// Interfaces for custom serialization.
+var (
+	jsonMarshalerType       = reflect.TypeFor[Marshaler]()
+	jsonMarshalerToType     = reflect.TypeFor[MarshalerTo]()
+	jsonUnmarshalerType     = reflect.TypeFor[Unmarshaler]()
+	jsonUnmarshalerFromType = reflect.TypeFor[UnmarshalerFrom]()
+	textAppenderType        = reflect.TypeFor[encoding.TextAppender]()
+	textMarshalerType       = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType     = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+	allMarshalerTypes   = []reflect.Type{jsonMarshalerToType, jsonMarshalerType, textAppenderType, textMarshalerType}
+	allUnmarshalerTypes = []reflect.Type{jsonUnmarshalerFromType, jsonUnmarshalerType, textUnmarshalerType}
+	allMethodTypes      = append(allMarshalerTypes, allUnmarshalerTypes...)
+)
+
+// Marshaler is implemented by types that can marshal themselves.
+// It is recommended that types implement [MarshalerTo] unless the implementation
+// is trying to avoid directly depending on the "jsontext" package.
+//
+// Implementations should return a buffer that is safe
+// for the caller to retain and potentially mutate.
+type Marshaler interface {
+	MarshalJSON() ([]byte, error)
+}
+
+// MarshalerTo is implemented by types that can marshal themselves.
+// It is recommended that types implement MarshalerTo instead of [Marshaler]
+// since it is both more performant and more flexible.
+// If a type implements both Marshaler and MarshalerTo,
+// then MarshalerTo takes precedence. In such a case, both implementations
+// should aim to have equivalent behavior for the default marshal options.
+//
+// The implementation must write only one JSON value to the Encoder and
+// must not retain the pointer to [jsontext.Encoder].
+type MarshalerTo interface {
+	MarshalJSONTo(*jsontext.Encoder) error
+
+	// TODO: Should users call the MarshalEncode function or
+	// should/can they call this method directly? Does it matter?
+}
+
+// Unmarshaler is implemented by types that can unmarshal themselves.
+// It is recommended that types implement [UnmarshalerFrom] unless the implementation
+// is trying to avoid directly depending on the "jsontext" package.
+//
+// The input can be assumed to be a valid encoding of a JSON value
+// if called from unmarshal functionality in this package.
+// UnmarshalJSON must copy the JSON data if it is retained after returning.
+// It is recommended that UnmarshalJSON implement merge semantics when
+// unmarshaling into a pre-populated value.
+type Unmarshaler interface {
+	UnmarshalJSON([]byte) error
+}
+
+// UnmarshalerFrom is implemented by types that can unmarshal themselves.
+// It is recommended that types implement UnmarshalerFrom instead of [Unmarshaler]
+// since it is both more performant and more flexible.
+// If a type implements both Unmarshaler and UnmarshalerFrom,
+// then UnmarshalerFrom takes precedence. In such a case, both implementations
+// should aim to have equivalent behavior for the default unmarshal options.
+//
+// The implementation must read only one JSON value from the Decoder.
+// It is recommended that UnmarshalJSONFrom implement merge semantics when
+// unmarshaling into a pre-populated value.
+//
+// Implementations must not retain the pointer to [jsontext.Decoder].
+type UnmarshalerFrom interface {
+	UnmarshalJSONFrom(*jsontext.Decoder) error
+}
+
+// makeMethodArshaler wraps fncs with marshal and unmarshal functions
+// that call the custom serialization methods implemented by t or *t,
+// in order of precedence.
+func makeMethodArshaler(fncs *arshaler, t reflect.Type) *arshaler {
+	// Avoid injecting method arshaler on the pointer or interface version
+	// to avoid ever calling the method on a nil pointer or interface receiver.
+	// Let it be injected on the value receiver (which is always addressable).
+	if t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface {
+		return fncs
+	}
+
+	if needAddr, ok := implements(t, textMarshalerType); ok {
+		fncs.nonDefault = true
+		prevMarshal := fncs.marshal
+		fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+			if !canCallMethod(va, needAddr, mo) {
+				return prevMarshal(enc, va, mo)
+			}
+			marshaler := va.Addr().Interface().(encoding.TextMarshaler)
+			b, err := marshaler.MarshalText()
+			if err != nil {
+				return wrapMethodError(enc, "marshal", t, marshaler, "MarshalText", err, mo.Flags)
+			}
+			if err := enc.WriteToken(jsontext.String(string(b))); err != nil {
+				return wrapTextError(enc, t, marshaler, err, mo.Flags)
+			}
+			return nil
+		}
+	}
+
+	if needAddr, ok := implements(t, textAppenderType); ok {
+		fncs.nonDefault = true
+		prevMarshal := fncs.marshal
+		fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+			if !canCallMethod(va, needAddr, mo) {
+				return prevMarshal(enc, va, mo)
+			}
+			appender := va.Addr().Interface().(encoding.TextAppender)
+			b, err := appender.AppendText(nil)
+			if err != nil {
+				return wrapMethodError(enc, "marshal", t, appender, "AppendText", err, mo.Flags)
+			}
+			if err := enc.WriteToken(jsontext.String(string(b))); err != nil {
+				return wrapTextError(enc, t, appender, err, mo.Flags)
+			}
+			return nil
+		}
+	}
+
+	if needAddr, ok := implements(t, jsonMarshalerType); ok {
+		fncs.nonDefault = true
+		prevMarshal := fncs.marshal
+		fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+			if !canCallMethod(va, needAddr, mo) {
+				return prevMarshal(enc, va, mo)
+			}
+			marshaler := va.Addr().Interface().(Marshaler)
+			val, err := marshaler.MarshalJSON()
+			if err != nil {
+				return wrapMethodError(enc, "marshal", t, marshaler, "MarshalJSON", err, mo.Flags)
+			}
+			if err := enc.WriteValue(val); err != nil {
+				return wrapMethodError(enc, "marshal", t, marshaler, "MarshalJSON", err, mo.Flags)
+			}
+			return nil
+		}
+	}
+
+	if needAddr, ok := implements(t, jsonMarshalerToType); ok {
+		fncs.nonDefault = true
+		prevMarshal := fncs.marshal
+		fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+			if !canCallMethod(va, needAddr, mo) {
+				return prevMarshal(enc, va, mo)
+			}
+			marshaler := va.Addr().Interface().(MarshalerTo)
+			return callMarshalTo(enc, t, marshaler, "MarshalJSONTo", mo, false, func() error {
+				return marshaler.MarshalJSONTo(enc)
+			})
+		}
+	}
+
+	if _, ok := implements(t, textUnmarshalerType); ok {
+		fncs.nonDefault = true
+		fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+			val, err := dec.ReadValue()
+			if err != nil {
+				return err
+			}
+			switch val.Kind() {
+			case 'n':
+				if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					va.SetZero()
+				}
+				return nil
+			case '"':
+				s, err := jsonwire.AppendUnquote(nil, val)
+				if err != nil {
+					return newUnmarshalError(dec, 0, val, t, err)
+				}
+				unmarshaler := va.Addr().Interface().(encoding.TextUnmarshaler)
+				if err := unmarshaler.UnmarshalText(s); err != nil {
+					return wrapMethodError(dec, "unmarshal", t, unmarshaler, "UnmarshalText", err, uo.Flags)
+				}
+				return nil
+			default:
+				return newUnmarshalError(dec, 0, val, t, errNonStringValue)
+			}
+		}
+	}
+
+	if _, ok := implements(t, jsonUnmarshalerType); ok {
+		fncs.nonDefault = true
+		fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+			val, err := dec.ReadValue()
+			if err != nil {
+				return err
+			}
+			unmarshaler := va.Addr().Interface().(Unmarshaler)
+			if err := unmarshaler.UnmarshalJSON(val.Clone()); err != nil {
+				return wrapMethodError(dec, "unmarshal", t, unmarshaler, "UnmarshalJSON", err, uo.Flags)
+			}
+			return nil
+		}
+	}
+
+	if _, ok := implements(t, jsonUnmarshalerFromType); ok {
+		fncs.nonDefault = true
+		fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+			unmarshaler := va.Addr().Interface().(UnmarshalerFrom)
+			return callUnmarshalFrom(dec, t, unmarshaler, "UnmarshalJSONFrom", uo, false, func() error {
+				return unmarshaler.UnmarshalJSONFrom(dec)
+			})
+		}
+	}
+
+	return fncs
+}
+
+// implements reports whether t or *t implements ifaceType,
+// and whether the address of the value must be taken.
+func implements(t, ifaceType reflect.Type) (needAddr, ok bool) {
+	switch {
+	case t.Implements(ifaceType):
+		return false, true
+	case reflect.PointerTo(t).Implements(ifaceType):
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// implementsAny reports whether t or *t implements any of the provided types.
+func implementsAny(t reflect.Type, ifaceTypes ...reflect.Type) bool {
+	for _, ifaceType := range ifaceTypes {
+		if _, ok := implements(t, ifaceType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// canCallMethod reports whether a marshal method can be called on va.
+// Historically, v1 would only call methods declared on the pointer receiver
+// if the value was addressable in the original Go value.
+// Unmarshal methods are always callable since the destination
+// of unmarshaling is always addressable.
+func canCallMethod(va addressableValue, needAddr bool, o *jsonopts.Struct) bool {
+	return !needAddr || !va.forcedAddr || !o.Flags.Get(jsonflags.CallMethodsWithLegacySemantics)
+}
+
+// wrapTextError wraps an error from encoding the output of a text method.
+func wrapTextError(enc *jsontext.Encoder, t reflect.Type, val any, err error, flags jsonflags.Flags) error {
+	if export.IsIOError(err) {
+		return err
+	}
+	return wrapMethodError(enc, "marshal", t, val, "MarshalText", err, flags)
+}
+
+// callMarshalTo calls fn, which is expected to write exactly one JSON value
+// to enc, while preventing the Encoder from being reset by the callee.
+// If maySkip is true, then fn may return [SkipFunc] provided that
+// it did not write anything to enc.
+func callMarshalTo(enc *jsontext.Encoder, t reflect.Type, val any, funcName string, mo *jsonopts.Struct, maySkip bool, fn func() error) error {
+	xe := export.Encoder(enc)
+	prevDepth, prevLength := enc.StackDepth(), stackLength(enc)
+	prevFlags := xe.Flags
+	xe.Flags.Set(jsonflags.WithinArshalCall | 1)
+	err := fn()
+	xe.Flags = prevFlags
+	currDepth, currLength := enc.StackDepth(), stackLength(enc)
+	switch {
+	case err == SkipFunc && maySkip:
+		if prevDepth != currDepth || prevLength != currLength {
+			err = errSkipMutation
+			break
+		}
+		return SkipFunc
+	case err == SkipFunc:
+		err = errSkipFunc
+	case err == nil && (prevDepth != currDepth || prevLength+1 != currLength):
+		err = errors.New("must write exactly one JSON value")
+	}
+	if err != nil {
+		if export.IsIOError(err) {
+			return err
+		}
+		if serr, ok := err.(*SemanticError); ok {
+			return newSemanticErrorWithPosition(enc, t, prevDepth, prevLength, serr)
+		}
+		return wrapMethodError(enc, "marshal", t, val, funcName, err, mo.Flags)
+	}
+	return nil
+}
+
+// callUnmarshalFrom calls fn, which is expected to read exactly one JSON value
+// from dec, while preventing the Decoder from being reset by the callee.
+// If maySkip is true, then fn may return [SkipFunc] provided that
+// it did not read anything from dec.
+func callUnmarshalFrom(dec *jsontext.Decoder, t reflect.Type, val any, funcName string, uo *jsonopts.Struct, maySkip bool, fn func() error) error {
+	xd := export.Decoder(dec)
+	prevDepth, prevLength := dec.StackDepth(), stackLength(dec)
+	prevFlags := xd.Flags
+	xd.Flags.Set(jsonflags.WithinArshalCall | 1)
+	err := fn()
+	xd.Flags = prevFlags
+	currDepth, currLength := dec.StackDepth(), stackLength(dec)
+	switch {
+	case err == SkipFunc && maySkip:
+		if prevDepth != currDepth || prevLength != currLength {
+			err = errSkipMutation
+			break
+		}
+		return SkipFunc
+	case err == SkipFunc:
+		err = errSkipFunc
+	case err == nil && (prevDepth != currDepth || prevLength+1 != currLength):
+		err = errors.New("must read exactly one JSON value")
+	}
+	if err != nil {
+		if export.IsIOError(err) {
+			return err
+		}
+		if _, ok := err.(*jsontext.SyntacticError); ok {
+			return err
+		}
+		if serr, ok := err.(*SemanticError); ok {
+			return newSemanticErrorWithPosition(dec, t, prevDepth, prevLength, serr)
+		}
+		return wrapMethodError(dec, "unmarshal", t, val, funcName, err, uo.Flags)
+	}
+	return nil
+}
+
+// isZeroer is implemented by types that can report whether they are zero.
+type isZeroer interface {
+	IsZero() bool
+}
+
+var isZeroerType = reflect.TypeFor[isZeroer]()
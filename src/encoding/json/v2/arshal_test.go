@@ -0,0 +1,510 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"encoding/json/internal"
+	"encoding/json/jsontext"
+)
+
+type (
+	structAll struct {
+		Bool   bool
+		String string
+		Bytes  []byte
+		Int    int64
+		Uint   uint64
+		Float  float64
+		Map    map[string]string
+		Slice  []string
+		Array  [1]string
+		Ptr    *structAll
+		Iface  any
+	}
+	structOmitZero struct {
+		Bool  bool              `json:",omitzero"`
+		Int   int               `json:",omitzero"`
+		Time  time.Time         `json:",omitzero"`
+		Map   map[string]string `json:",omitzero"`
+		Zeros zeroer            `json:",omitzero"`
+	}
+	structOmitEmpty struct {
+		String string            `json:",omitempty"`
+		Int    int               `json:",omitempty"`
+		Map    map[string]string `json:",omitempty"`
+		Slice  []int             `json:",omitempty"`
+		Ptr    *int              `json:",omitempty"`
+		Struct struct{}          `json:",omitempty"`
+	}
+	structStringified struct {
+		Int   int            `json:",string"`
+		Float float64        `json:",string"`
+		Map   map[string]int `json:",string"`
+	}
+	structFormats struct {
+		Base16    []byte        `json:",format:base16"`
+		Base32    []byte        `json:",format:base32"`
+		Base64URL []byte        `json:",format:base64url"`
+		Array     []byte        `json:",format:array"`
+		NilMap    map[int]int   `json:",format:emitnull"`
+		NilSlice  []int         `json:",format:emitnull"`
+		NaN       float64       `json:",format:nonfinite"`
+		Sec       time.Duration `json:",format:sec"`
+		Nano      time.Duration `json:",format:nano"`
+		Date      time.Time     `json:",format:DateOnly"`
+		Milli     time.Time     `json:",format:unixmilli"`
+	}
+	structInlined struct {
+		A string
+		structInlinedEmbedded
+		Unknown map[string]any `json:",unknown"`
+	}
+	structInlinedEmbedded struct {
+		B string
+	}
+	structCaseIgnore struct {
+		FooBar string `json:",case:ignore"`
+		Strict string `json:"strict,case:strict"`
+	}
+	structNoneExported struct {
+		unexported string
+	}
+	structMalformedTag struct {
+		Malformed string `json:"\""`
+	}
+
+	zeroer struct{ Zero bool }
+
+	namedString     string
+	textMarshaler   struct{ s string }
+	jsonMarshaler   struct{ s string }
+	jsonMarshalerTo struct{ s string }
+)
+
+func (z zeroer) IsZero() bool { return z.Zero }
+
+func (t textMarshaler) MarshalText() ([]byte, error) { return []byte("text:" + t.s), nil }
+func (t *textMarshaler) UnmarshalText(b []byte) error {
+	s, ok := strings.CutPrefix(string(b), "text:")
+	if !ok {
+		return errors.New("missing prefix")
+	}
+	t.s = s
+	return nil
+}
+
+func (j jsonMarshaler) MarshalJSON() ([]byte, error) { return []byte(`["json",` + j.s + `]`), nil }
+func (j *jsonMarshaler) UnmarshalJSON(b []byte) error {
+	j.s = string(b)
+	return nil
+}
+
+func (j jsonMarshalerTo) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String("to:" + j.s))
+}
+func (j *jsonMarshalerTo) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+	j.s = strings.TrimPrefix(tok.String(), "to:")
+	return nil
+}
+
+func addr[T any](v T) *T { return &v }
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Options
+		in      any
+		want    string
+		wantErr error
+	}{
+		{name: "Nil", in: nil, want: `null`},
+		{name: "Bool", in: true, want: `true`},
+		{name: "String", in: "hello ", want: `"hello "`},
+		{name: "String/InvalidUTF8", in: "\xff", wantErr: errors.New("invalid UTF-8")},
+		{name: "NamedString", in: namedString("x"), want: `"x"`},
+		{name: "Int", in: int8(-128), want: `-128`},
+		{name: "Int/Stringified", opts: []Options{StringifyNumbers(true)}, in: int64(math.MinInt64), want: `"-9223372036854775808"`},
+		{name: "Uint", in: uint64(math.MaxUint64), want: `18446744073709551615`},
+		{name: "Float32", in: float32(3.14), want: `3.14`},
+		{name: "Float64", in: 1e21, want: `1e+21`},
+		{name: "Float64/NaN", in: math.NaN(), wantErr: internal.ErrNonFiniteNumber},
+		{name: "Bytes", in: []byte("hello"), want: `"aGVsbG8="`},
+		{name: "Bytes/Nil", in: []byte(nil), want: `""`},
+		{name: "Bytes/NilAsNull", opts: []Options{FormatNilSliceAsNull(true)}, in: []byte(nil), want: `null`},
+		{name: "Bytes/Array", in: [3]byte{1, 2, 3}, want: `"AQID"`},
+		{name: "Slice/Nil", in: []int(nil), want: `[]`},
+		{name: "Slice/NilAsNull", opts: []Options{FormatNilSliceAsNull(true)}, in: []int(nil), want: `null`},
+		{name: "Map/Nil", in: map[string]int(nil), want: `{}`},
+		{name: "Map/NilAsNull", opts: []Options{FormatNilMapAsNull(true)}, in: map[string]int(nil), want: `null`},
+		{name: "Map/Deterministic", opts: []Options{Deterministic(true)}, in: map[string]int{"c": 3, "a": 1, "b": 2}, want: `{"a":1,"b":2,"c":3}`},
+		{name: "Map/IntKeys", opts: []Options{Deterministic(true)}, in: map[int]bool{10: true, -1: false}, want: `{"-1":false,"10":true}`},
+		{name: "Map/TextKeys", in: map[textMarshaler]int{{"k"}: 1}, want: `{"text:k":1}`},
+		{name: "Map/InvalidKey", in: map[bool]int{true: 1}, wantErr: &SemanticError{}},
+		{name: "Pointer/Nil", in: (*int)(nil), want: `null`},
+		{name: "Pointer", in: addr(addr(5)), want: `5`},
+		{name: "Interface/Nil", in: []any{nil}, want: `[null]`},
+		{name: "Interface", in: []any{1, "a", true}, want: `[1,"a",true]`},
+		{
+			name: "Struct/All",
+			in:   structAll{Bool: true, String: "s", Bytes: []byte{0}, Int: -1, Uint: 1, Float: 1.5, Map: map[string]string{"k": "v"}, Slice: []string{"x"}, Array: [1]string{"y"}, Ptr: &structAll{}, Iface: 2},
+			want: `{"Bool":true,"String":"s","Bytes":"AA==","Int":-1,"Uint":1,"Float":1.5,"Map":{"k":"v"},"Slice":["x"],"Array":["y"],"Ptr":{"Bool":false,"String":"","Bytes":"","Int":0,"Uint":0,"Float":0,"Map":{},"Slice":[],"Array":[""],"Ptr":null,"Iface":null},"Iface":2}`,
+		},
+		{name: "Struct/OmitZero/Zero", in: structOmitZero{Zeros: zeroer{true}}, want: `{}`},
+		{name: "Struct/OmitZero/NonZero", in: structOmitZero{Bool: true, Map: map[string]string{}}, want: `{"Bool":true,"Map":{},"Zeros":{"Zero":false}}`},
+		{name: "Struct/OmitZeroStructFields", opts: []Options{OmitZeroStructFields(true)}, in: structAll{Int: 1}, want: `{"Int":1}`},
+		{name: "Struct/OmitEmpty/Empty", in: structOmitEmpty{Map: map[string]string{}, Slice: []int{}}, want: `{"Int":0}`},
+		{name: "Struct/OmitEmpty/NonEmpty", in: structOmitEmpty{String: "s", Ptr: addr(0)}, want: `{"String":"s","Int":0,"Ptr":0}`},
+		{name: "Struct/Stringified", in: structStringified{Int: 1, Float: 2.5, Map: map[string]int{"k": 3}}, want: `{"Int":"1","Float":"2.5","Map":{"k":"3"}}`},
+		{
+			name: "Struct/Formats",
+			in: structFormats{
+				Base16: []byte{0xde, 0xad}, Base32: []byte("a"), Base64URL: []byte{0xff, 0xfe}, Array: []byte{1, 2},
+				NaN: math.Inf(-1), Sec: 1500 * time.Millisecond, Nano: 2, Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Milli: time.UnixMilli(1234),
+			},
+			want: `{"Base16":"dead","Base32":"ME======","Base64URL":"__4=","Array":[1,2],"NilMap":null,"NilSlice":null,"NaN":"-Infinity","Sec":1.5,"Nano":2,"Date":"2024-01-02","Milli":1234}`,
+		},
+		{name: "Struct/Inlined", in: structInlined{A: "a", structInlinedEmbedded: structInlinedEmbedded{B: "b"}, Unknown: map[string]any{"c": 1}}, want: `{"A":"a","B":"b","c":1}`},
+		{name: "Struct/Inlined/Discard", opts: []Options{DiscardUnknownMembers(true)}, in: structInlined{Unknown: map[string]any{"c": 1}}, want: `{"A":"","B":""}`},
+		{name: "Struct/NoneExported", in: structNoneExported{}, wantErr: errNoExportedFields},
+		{name: "Struct/MalformedTag", in: structMalformedTag{}, wantErr: &SemanticError{}},
+		{name: "Methods/Text", in: textMarshaler{"x"}, want: `"text:x"`},
+		{name: "Methods/JSON", in: jsonMarshaler{"1"}, want: `["json",1]`},
+		{name: "Methods/JSONTo", in: []jsonMarshalerTo{{"a"}}, want: `["to:a"]`},
+		{name: "Time", in: time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC), want: `"2024-05-06T07:08:09.00000001Z"`},
+		{name: "Time/InvalidYear", in: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), wantErr: &SemanticError{}},
+		{name: "Duration", in: 90 * time.Second, want: `"1m30s"`},
+		{name: "Chan", in: make(chan int), wantErr: &SemanticError{}},
+		{name: "Func", in: func() {}, wantErr: &SemanticError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in, tt.opts...)
+			if !matchError(err, tt.wantErr) {
+				t.Fatalf("Marshal error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && string(got) != tt.want {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Options
+		in      string
+		inVal   any
+		want    any
+		wantErr error
+	}{
+		{name: "Bool", in: `true`, inVal: new(bool), want: addr(true)},
+		{name: "Bool/Null", in: `null`, inVal: addr(true), want: addr(false)},
+		{name: "Bool/Invalid", in: `"true"`, inVal: new(bool), want: new(bool), wantErr: &SemanticError{}},
+		{name: "String", in: `"aé\n"`, inVal: new(string), want: addr("aé\n")},
+		{name: "Int", in: `-128`, inVal: new(int8), want: addr(int8(-128))},
+		{name: "Int/Overflow", in: `128`, inVal: new(int8), want: new(int8), wantErr: &SemanticError{}},
+		{name: "Int/Fraction", in: `1.5`, inVal: new(int), want: new(int), wantErr: &SemanticError{}},
+		{name: "Int/Stringified", opts: []Options{StringifyNumbers(true)}, in: `"12"`, inVal: new(int), want: addr(12)},
+		{name: "Int/StringifiedRejectsNumber", opts: []Options{StringifyNumbers(true)}, in: `12`, inVal: new(int), want: new(int), wantErr: &SemanticError{}},
+		{name: "Uint/Negative", in: `-1`, inVal: new(uint), want: new(uint), wantErr: &SemanticError{}},
+		{name: "Float", in: `1e3`, inVal: new(float64), want: addr(1e3)},
+		{name: "Float32/Overflow", in: `1e100`, inVal: new(float32), want: addr(float32(math.MaxFloat32)), wantErr: &SemanticError{}},
+		{name: "Bytes", in: `"aGVsbG8="`, inVal: new([]byte), want: addr([]byte("hello"))},
+		{name: "Bytes/Invalid", in: `"!"`, inVal: new([]byte), want: new([]byte), wantErr: &SemanticError{}},
+		{name: "Bytes/Array", in: `"AQID"`, inVal: new([3]byte), want: addr([3]byte{1, 2, 3})},
+		{name: "Bytes/ArrayWrongLength", in: `"AQI="`, inVal: new([3]byte), want: new([3]byte), wantErr: &SemanticError{}},
+		{name: "Slice", in: `[1,2,3]`, inVal: addr([]int{9}), want: addr([]int{1, 2, 3})},
+		{name: "Slice/Empty", in: `[]`, inVal: new([]int), want: addr([]int{})},
+		{name: "Slice/Null", in: `null`, inVal: addr([]int{1}), want: new([]int)},
+		{name: "Array", in: `[1,2]`, inVal: new([2]int), want: addr([2]int{1, 2})},
+		{name: "Array/Short", in: `[1]`, inVal: new([2]int), want: addr([2]int{1, 0}), wantErr: internal.ErrMismatchedLength},
+		{name: "Array/Long", in: `[1,2,3]`, inVal: new([2]int), want: addr([2]int{1, 2}), wantErr: internal.ErrMismatchedLength},
+		{name: "Map", in: `{"a":1,"b":2}`, inVal: new(map[string]int), want: addr(map[string]int{"a": 1, "b": 2})},
+		{name: "Map/Merge", in: `{"b":2}`, inVal: addr(map[string]int{"a": 1}), want: addr(map[string]int{"a": 1, "b": 2})},
+		{name: "Map/IntKeys", in: `{"-1":1,"2":2}`, inVal: new(map[int]int), want: addr(map[int]int{-1: 1, 2: 2})},
+		{name: "Map/InvalidIntKey", in: `{"x":1}`, inVal: new(map[int]int), want: addr(map[int]int{}), wantErr: &SemanticError{}},
+		{name: "Map/TextKeys", in: `{"text:k":1}`, inVal: new(map[textMarshaler]int), want: addr(map[textMarshaler]int{{"k"}: 1})},
+		{name: "Map/DuplicateName", in: `{"a":1,"a":2}`, inVal: new(map[string]int), want: addr(map[string]int{"a": 1}), wantErr: jsontext.ErrDuplicateName},
+		{name: "Pointer", in: `5`, inVal: new(*int), want: addr(addr(5))},
+		{name: "Pointer/Null", in: `null`, inVal: addr(addr(5)), want: new(*int)},
+		{
+			name:  "Interface/Any",
+			in:    `{"a":[1,"s",true,null,{}]}`,
+			inVal: new(any),
+			want:  addr(any(map[string]any{"a": []any{1.0, "s", true, nil, map[string]any{}}})),
+		},
+		{name: "Interface/Existing", in: `{"b":2}`, inVal: addr(any(map[string]any{"a": 1.0})), want: addr(any(map[string]any{"a": 1.0, "b": 2.0}))},
+		{name: "Interface/NonEmptyNil", in: `1`, inVal: new(fmt.Stringer), want: new(fmt.Stringer), wantErr: internal.ErrNilInterface},
+		{
+			name:  "Struct/All",
+			in:    `{"Bool":true,"String":"s","Bytes":"AA==","Int":-1,"Uint":1,"Float":1.5,"Map":{"k":"v"},"Slice":["x"],"Array":["y"],"Ptr":{},"Iface":2}`,
+			inVal: new(structAll),
+			want:  &structAll{Bool: true, String: "s", Bytes: []byte{0}, Int: -1, Uint: 1, Float: 1.5, Map: map[string]string{"k": "v"}, Slice: []string{"x"}, Array: [1]string{"y"}, Ptr: &structAll{}, Iface: 2.0},
+		},
+		{name: "Struct/CaseSensitive", in: `{"bool":true}`, inVal: new(structAll), want: new(structAll)},
+		{name: "Struct/CaseInsensitive", opts: []Options{MatchCaseInsensitiveNames(true)}, in: `{"bool":true}`, inVal: new(structAll), want: &structAll{Bool: true}},
+		{name: "Struct/CaseIgnoreTag", in: `{"foo_bar":"x","STRICT":"y"}`, inVal: new(structCaseIgnore), want: &structCaseIgnore{FooBar: "x"}},
+		{name: "Struct/CaseStrictTag", opts: []Options{MatchCaseInsensitiveNames(true)}, in: `{"STRICT":"y"}`, inVal: new(structCaseIgnore), want: new(structCaseIgnore)},
+		{name: "Struct/UnknownIgnored", in: `{"Nope":1}`, inVal: new(structAll), want: new(structAll)},
+		{name: "Struct/UnknownRejected", opts: []Options{RejectUnknownMembers(true)}, in: `{"Nope":1}`, inVal: new(structAll), want: new(structAll), wantErr: ErrUnknownName},
+		{name: "Struct/Inlined", in: `{"A":"a","B":"b","c":1}`, inVal: new(structInlined), want: &structInlined{A: "a", structInlinedEmbedded: structInlinedEmbedded{B: "b"}, Unknown: map[string]any{"c": 1.0}}},
+		{name: "Struct/Stringified", in: `{"Int":"1","Float":"2.5","Map":{"k":"3"}}`, inVal: new(structStringified), want: &structStringified{Int: 1, Float: 2.5, Map: map[string]int{"k": 3}}},
+		{
+			name:  "Struct/Formats",
+			in:    `{"Base16":"dead","Base32":"ME======","Base64URL":"__4=","Array":[1,2],"NaN":"NaN","Sec":1.5,"Nano":2,"Date":"2024-01-02","Milli":1234}`,
+			inVal: new(structFormats),
+			want: &structFormats{
+				Base16: []byte{0xde, 0xad}, Base32: []byte("a"), Base64URL: []byte{0xff, 0xfe}, Array: []byte{1, 2},
+				NaN: math.NaN(), Sec: 1500 * time.Millisecond, Nano: 2, Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Milli: time.UnixMilli(1234).UTC(),
+			},
+		},
+		{name: "Struct/Null", in: `null`, inVal: &structAll{Int: 1}, want: new(structAll)},
+		{name: "Struct/WrongKind", in: `[]`, inVal: new(structAll), want: new(structAll), wantErr: &SemanticError{}},
+		{name: "Struct/NoneExported", in: `{}`, inVal: new(structNoneExported), want: new(structNoneExported), wantErr: errNoExportedFields},
+		{name: "Methods/Text", in: `"text:x"`, inVal: new(textMarshaler), want: &textMarshaler{"x"}},
+		{name: "Methods/TextError", in: `"x"`, inVal: new(textMarshaler), want: new(textMarshaler), wantErr: &SemanticError{}},
+		{name: "Methods/JSON", in: ` [1] `, inVal: new(jsonMarshaler), want: &jsonMarshaler{"[1]"}},
+		{name: "Methods/JSONFrom", in: `["to:a"]`, inVal: new([]jsonMarshalerTo), want: &[]jsonMarshalerTo{{"a"}}},
+		{name: "Time", in: `"2024-05-06T07:08:09.00000001Z"`, inVal: new(time.Time), want: addr(time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC))},
+		{name: "Time/Invalid", in: `"2024-05-06"`, inVal: new(time.Time), want: new(time.Time), wantErr: &SemanticError{}},
+		{name: "Duration", in: `"1m30s"`, inVal: new(time.Duration), want: addr(90 * time.Second)},
+		{name: "TrailingData", in: `1 2`, inVal: new(int), want: addr(1), wantErr: &jsontext.SyntacticError{}},
+		{name: "Truncated", in: `[1,`, inVal: new([]int), want: addr([]int{1, 0}), wantErr: io.ErrUnexpectedEOF},
+		{name: "NonPointer", in: `1`, inVal: 1, want: 1, wantErr: internal.ErrNonNilReference},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.in), tt.inVal, tt.opts...)
+			if !matchError(err, tt.wantErr) {
+				t.Fatalf("Unmarshal error = %v, want %v", err, tt.wantErr)
+			}
+			if !equalNaN(tt.inVal, tt.want) {
+				t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", tt.inVal, tt.want)
+			}
+		})
+	}
+}
+
+// matchError reports whether got matches want, which may be nil,
+// an error value to match with errors.Is, a zero-value error type to
+// match with errors.As, or an error whose message is a substring of got.
+func matchError(got, want error) bool {
+	switch want := want.(type) {
+	case nil:
+		return got == nil
+	case *SemanticError:
+		return errors.As(got, &want)
+	case *jsontext.SyntacticError:
+		return errors.As(got, &want)
+	}
+	if got == nil {
+		return false
+	}
+	return errors.Is(got, want) || strings.Contains(got.Error(), want.Error())
+}
+
+// equalNaN is like reflect.DeepEqual, but treats all NaN floats
+// within a struct as equal.
+func equalNaN(x, y any) bool {
+	if x, ok := x.(*structFormats); ok {
+		if y, ok := y.(*structFormats); ok && math.IsNaN(x.NaN) && math.IsNaN(y.NaN) {
+			x2, y2 := *x, *y
+			x2.NaN, y2.NaN = 0, 0
+			return reflect.DeepEqual(x2, y2)
+		}
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func TestMarshalFuncs(t *testing.T) {
+	m := JoinMarshalers(
+		MarshalToFunc(func(enc *jsontext.Encoder, v int) error {
+			if v%2 == 0 {
+				return SkipFunc
+			}
+			return enc.WriteToken(jsontext.String(fmt.Sprintf("odd:%d", v)))
+		}),
+		MarshalFunc(func(v int) ([]byte, error) {
+			return []byte(fmt.Sprintf(`"even:%d"`, v)), nil
+		}),
+		MarshalFunc(func(v fmt.Stringer) ([]byte, error) {
+			return []byte(`"stringer"`), nil
+		}),
+	)
+	got, err := Marshal([]any{1, 2, time.Second, "s"}, WithMarshalers(m))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `["odd:1","even:2","stringer","s"]`; string(got) != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+
+	// Skipping after writing is an error.
+	m = MarshalToFunc(func(enc *jsontext.Encoder, v int) error {
+		enc.WriteToken(jsontext.Null)
+		return SkipFunc
+	})
+	if _, err := Marshal(1, WithMarshalers(m)); !errors.Is(err, errSkipMutation) {
+		t.Errorf("Marshal error = %v, want %v", err, errSkipMutation)
+	}
+
+	// Only the To variant may skip.
+	m = MarshalFunc(func(int) ([]byte, error) { return nil, SkipFunc })
+	if _, err := Marshal(1, WithMarshalers(m)); !errors.Is(err, errSkipFunc) {
+		t.Errorf("Marshal error = %v, want %v", err, errSkipFunc)
+	}
+
+	// Writing more than one value is an error.
+	m = MarshalToFunc(func(enc *jsontext.Encoder, v int) error {
+		enc.WriteToken(jsontext.Null)
+		return enc.WriteToken(jsontext.Null)
+	})
+	if _, err := Marshal([]int{1}, WithMarshalers(m)); err == nil {
+		t.Errorf("Marshal error = nil, want non-nil")
+	}
+}
+
+func TestUnmarshalFuncs(t *testing.T) {
+	u := JoinUnmarshalers(
+		UnmarshalFromFunc(func(dec *jsontext.Decoder, v *string) error {
+			if dec.PeekKind() != '0' {
+				return SkipFunc
+			}
+			val, err := dec.ReadValue()
+			*v = "number:" + string(val)
+			return err
+		}),
+		UnmarshalFunc(func(b []byte, v *bool) error {
+			*v = string(b) == `"yes"`
+			return nil
+		}),
+	)
+	var got struct {
+		A, B string
+		C    bool
+	}
+	if err := Unmarshal([]byte(`{"A":1,"B":"b","C":"yes"}`), &got, WithUnmarshalers(u)); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if got.A != "number:1" || got.B != "b" || !got.C {
+		t.Errorf("Unmarshal = %+v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("UnmarshalFunc with non-pointer type did not panic")
+		}
+	}()
+	UnmarshalFunc(func([]byte, int) error { return nil })
+}
+
+func TestMarshalWriteRead(t *testing.T) {
+	var buf bytes.Buffer
+	if err := MarshalWrite(&buf, map[string]int{"a": 1}); err != nil {
+		t.Fatalf("MarshalWrite error: %v", err)
+	}
+	if got, want := buf.String(), `{"a":1}`; got != want {
+		t.Errorf("MarshalWrite = %q, want %q", got, want)
+	}
+	var got map[string]int
+	if err := UnmarshalRead(&buf, &got); err != nil {
+		t.Fatalf("UnmarshalRead error: %v", err)
+	}
+	if got["a"] != 1 {
+		t.Errorf("UnmarshalRead = %v", got)
+	}
+}
+
+func TestMarshalEncodeDecode(t *testing.T) {
+	var buf bytes.Buffer
+	enc := jsontext.NewEncoder(&buf)
+	for i := range 3 {
+		if err := MarshalEncode(enc, []int{i}); err != nil {
+			t.Fatalf("MarshalEncode error: %v", err)
+		}
+	}
+	if got, want := buf.String(), "[0]\n[1]\n[2]\n"; got != want {
+		t.Errorf("MarshalEncode = %q, want %q", got, want)
+	}
+
+	dec := jsontext.NewDecoder(&buf)
+	for i := range 3 {
+		var got []int
+		if err := UnmarshalDecode(dec, &got); err != nil {
+			t.Fatalf("UnmarshalDecode error: %v", err)
+		}
+		if len(got) != 1 || got[0] != i {
+			t.Errorf("UnmarshalDecode = %v, want [%d]", got, i)
+		}
+	}
+	var got []int
+	if err := UnmarshalDecode(dec, &got); err != io.EOF {
+		t.Errorf("UnmarshalDecode error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestSemanticErrorPosition(t *testing.T) {
+	var v struct {
+		A []struct{ B int }
+	}
+	err := Unmarshal([]byte(`{"A":[{"B":1},{"B":"x"}]}`), &v)
+	var serr *SemanticError
+	if !errors.As(err, &serr) {
+		t.Fatalf("Unmarshal error = %v, want SemanticError", err)
+	}
+	if serr.JSONPointer != "/A/1/B" {
+		t.Errorf("JSONPointer = %q, want %q", serr.JSONPointer, "/A/1/B")
+	}
+	if serr.ByteOffset != 19 {
+		t.Errorf("ByteOffset = %d, want 19", serr.ByteOffset)
+	}
+	if serr.GoType != reflect.TypeFor[int]() {
+		t.Errorf("GoType = %v, want int", serr.GoType)
+	}
+	if want := `json: cannot unmarshal JSON string "x" into Go int within "/A/1/B"`; err.Error() != want {
+		t.Errorf("Error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestMarshalCycle(t *testing.T) {
+	type node struct{ Next *node }
+	n := new(node)
+	n.Next = n
+	_, err := Marshal(n)
+	if !errors.Is(err, internal.ErrCycle) {
+		t.Errorf("Marshal error = %v, want %v", err, internal.ErrCycle)
+	}
+}
+
+func TestGetOption(t *testing.T) {
+	opts := JoinOptions(Deterministic(true), jsontext.AllowDuplicateNames(true), Deterministic(false))
+	if v, ok := GetOption(opts, Deterministic); v || !ok {
+		t.Errorf("GetOption(Deterministic) = (%v, %v), want (false, true)", v, ok)
+	}
+	if v, ok := GetOption(opts, StringifyNumbers); v || ok {
+		t.Errorf("GetOption(StringifyNumbers) = (%v, %v), want (false, false)", v, ok)
+	}
+	m := MarshalFunc(func(int) ([]byte, error) { return nil, nil })
+	if v, ok := GetOption(WithMarshalers(m), WithMarshalers); v != m || !ok {
+		t.Errorf("GetOption(WithMarshalers) = (%p, %v), want (%p, true)", v, ok, m)
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+// ErrUnknownName indicates that a JSON object member could not be
+// unmarshaled because the name is not known to the target Go struct.
+// This error is directly wrapped within a [SemanticError] when produced.
+//
+// The name of an unknown JSON object member can be extracted as:
+//
+//	err := ...
+//	var serr *json.SemanticError
+//	if errors.As(err, &serr) && serr.Err == json.ErrUnknownName {
+//		ptr := serr.JSONPointer // JSON pointer to unknown name
+//		name := ptr.LastToken() // unknown name itself
+//		...
+//	}
+//
+// This error is only returned if [RejectUnknownMembers] is true.
+var ErrUnknownName = errors.New("unknown object member name")
+
+const errorPrefix = "json: "
+
+// isFatalError reports whether this error must terminate arshaling.
+// All errors are considered fatal unless operating under
+// [jsonflags.ReportErrorsWithLegacySemantics] in which case only
+// syntactic errors and I/O errors are considered fatal.
+func isFatalError(err error, flags jsonflags.Flags) bool {
+	if !flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+		return true
+	}
+	_, isSyntactic := err.(*jsontext.SyntacticError)
+	return isSyntactic || export.IsIOError(err) || err == io.ErrUnexpectedEOF
+}
+
+// SemanticError describes an error determining the meaning
+// of JSON data as Go data, or vice versa.
+//
+// If a [Marshaler], [MarshalerTo], [Unmarshaler], or [UnmarshalerFrom] method
+// returns a SemanticError when called by the [json] package,
+// then the ByteOffset, JSONPointer, and GoType fields are automatically
+// populated by the calling context if they are the zero value.
+//
+// The contents of this error as produced by this package may change over time.
+type SemanticError struct {
+	requireKeyedLiterals
+	nonComparable
+
+	action string // either "marshal" or "unmarshal"
+
+	// ByteOffset indicates that an error occurred after this byte offset.
+	ByteOffset int64
+	// JSONPointer indicates that an error occurred within this JSON value
+	// as indicated using the JSON Pointer notation (see RFC 6901).
+	JSONPointer jsontext.Pointer
+
+	// JSONKind is the JSON kind that could not be handled.
+	JSONKind jsontext.Kind // may be zero if unknown
+	// JSONValue is the JSON number or string that could not be unmarshaled.
+	// It is not populated during marshaling.
+	JSONValue jsontext.Value // may be nil if irrelevant or unknown
+	// GoType is the Go type that could not be handled.
+	GoType reflect.Type // may be nil if unknown
+
+	// Err is the underlying error.
+	Err error // may be nil
+}
+
+// newMarshalError constructs a SemanticError for a Go value of type t
+// that could not be marshaled at the current position of e.
+func newMarshalError(e *jsontext.Encoder, t reflect.Type, err error) error {
+	return &SemanticError{action: "marshal", GoType: t, Err: err,
+		ByteOffset:  e.OutputOffset(),
+		JSONPointer: nextPointer(e.StackPointer(), e.StackDepth(), e.StackIndex),
+	}
+}
+
+// newUnmarshalError constructs a SemanticError for a JSON value of kind k
+// that could not be unmarshaled into a Go value of type t.
+// The value must have already been consumed from d.
+func newUnmarshalError(d *jsontext.Decoder, k jsontext.Kind, val jsontext.Value, t reflect.Type, err error) error {
+	if k == 0 && len(val) > 0 {
+		k = val.Kind()
+	}
+	if k != '"' && k != '0' {
+		val = nil // only strings and numbers are recorded
+	}
+	return &SemanticError{action: "unmarshal", GoType: t, Err: err,
+		ByteOffset:  d.InputOffset() - int64(len(val)),
+		JSONPointer: d.StackPointer(),
+		JSONKind:    k,
+		JSONValue:   val.Clone(),
+	}
+}
+
+// newUnmarshalErrorBefore constructs a SemanticError for a Go value of type t
+// that cannot be unmarshaled into, before the next value in d is consumed.
+// The next value is skipped if operating under legacy error semantics
+// so that unmarshaling may continue.
+func newUnmarshalErrorBefore(d *jsontext.Decoder, t reflect.Type, err error) error {
+	k := d.PeekKind()
+	if k == 0 {
+		// An invalid kind means that reading the next token fails,
+		// so report the underlying syntactic or I/O error instead.
+		if _, err2 := d.ReadToken(); err2 != nil {
+			return err2
+		}
+	}
+	if export.Decoder(d).Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+		if err2 := d.SkipValue(); err2 != nil {
+			return err2
+		}
+		return newUnmarshalError(d, k, nil, t, err)
+	}
+	return &SemanticError{action: "unmarshal", GoType: t, Err: err,
+		ByteOffset:  d.InputOffset(),
+		JSONPointer: nextPointer(d.StackPointer(), d.StackDepth(), d.StackIndex),
+		JSONKind:    k,
+	}
+}
+
+// nextPointer approximates the JSON pointer to the next value
+// given the pointer to the most recently processed value.
+func nextPointer(p jsontext.Pointer, depth int, index func(int) (jsontext.Kind, int64)) jsontext.Pointer {
+	switch k, n := index(depth); k {
+	case '[':
+		if n > 0 {
+			p = p.Parent()
+		}
+		return p.AppendToken(strconv.FormatInt(n, 10))
+	case '{':
+		if n > 0 && n%2 == 0 {
+			p = p.Parent() // the previous member value is complete
+		}
+		return p
+	default:
+		return p
+	}
+}
+
+// newSemanticErrorWithPosition annotates err with the provided position
+// if it is a SemanticError lacking any position information.
+// Errors returned by user-provided methods and functions are wrapped
+// in a SemanticError unless operating under legacy error semantics.
+func newSemanticErrorWithPosition(c coder, t reflect.Type, prevDepth int, prevLength int64, err error) error {
+	serr, ok := err.(*SemanticError)
+	if !ok {
+		return err
+	}
+	// Only annotate the error if it is positioned at the value
+	// that the method or function was called upon.
+	if depth, length := c.StackDepth(), stackLength(c); depth == prevDepth && (length == prevLength || length == prevLength+1) {
+		if serr.ByteOffset == 0 && serr.JSONPointer == "" {
+			switch c := c.(type) {
+			case *jsontext.Encoder:
+				serr.ByteOffset = c.OutputOffset()
+				serr.JSONPointer = nextPointer(c.StackPointer(), depth, c.StackIndex)
+			case *jsontext.Decoder:
+				serr.ByteOffset = c.InputOffset()
+				serr.JSONPointer = c.StackPointer()
+			}
+		}
+	}
+	if serr.GoType == nil {
+		serr.GoType = t
+	}
+	return serr
+}
+
+// coder is implemented by [jsontext.Encoder] or [jsontext.Decoder].
+type coder interface {
+	StackDepth() int
+	StackIndex(int) (jsontext.Kind, int64)
+	StackPointer() jsontext.Pointer
+	Options() Options
+}
+
+func stackLength(c coder) int64 {
+	_, n := c.StackIndex(c.StackDepth())
+	return n
+}
+
+// wrapMethodError wraps an error returned by a user-provided method
+// (e.g., MarshalJSON) or function named by funcName.
+func wrapMethodError(c coder, action string, t reflect.Type, val any, funcName string, err error, flags jsonflags.Flags) error {
+	if flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+		if action == "marshal" && internal.NewMarshalerError != nil {
+			return internal.NewMarshalerError(val, err, funcName)
+		}
+		return err
+	}
+	if _, ok := err.(*SemanticError); ok {
+		return err
+	}
+	if _, ok := err.(*jsontext.SyntacticError); ok {
+		return err
+	}
+	serr := &SemanticError{action: action, GoType: t, Err: err}
+	switch c := c.(type) {
+	case *jsontext.Encoder:
+		serr.ByteOffset = c.OutputOffset()
+		serr.JSONPointer = nextPointer(c.StackPointer(), c.StackDepth(), c.StackIndex)
+	case *jsontext.Decoder:
+		serr.ByteOffset = c.InputOffset()
+		serr.JSONPointer = c.StackPointer()
+	}
+	return serr
+}
+
+func (e *SemanticError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(errorPrefix)
+	sb.WriteString("cannot ")
+	switch e.action {
+	case "":
+		sb.WriteString("handle")
+	default:
+		sb.WriteString(e.action)
+	}
+
+	// Format the JSON kind.
+	var preposition string
+	switch e.action {
+	case "marshal":
+		preposition = " from"
+	case "unmarshal":
+		preposition = " into"
+	default:
+		preposition = " with"
+	}
+	if e.JSONKind != 0 {
+		sb.WriteString(" JSON ")
+		sb.WriteString(kindName(e.JSONKind))
+		if len(e.JSONValue) > 0 && len(e.JSONValue) <= 100 {
+			sb.WriteString(" ")
+			sb.Write(e.JSONValue)
+		}
+		if e.GoType != nil {
+			sb.WriteString(preposition)
+		}
+	}
+
+	// Format the Go type.
+	if e.GoType != nil {
+		sb.WriteString(" Go ")
+		sb.WriteString(e.GoType.String())
+	}
+
+	// Format where.
+	if e.JSONPointer != "" {
+		sb.WriteString(" within ")
+		sb.WriteString(strconv.Quote(jsonwire.TruncatePointer(string(e.JSONPointer), 100)))
+	}
+
+	// Format the underlying error.
+	switch err := e.Err; {
+	case err == nil:
+		if e.GoType != nil && e.JSONKind == 0 {
+			sb.WriteString(": unsupported type")
+		}
+	case err == ErrUnknownName:
+		sb.Reset()
+		sb.WriteString(errorPrefix)
+		sb.WriteString(err.Error())
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Quote(e.JSONPointer.LastToken()))
+		if parent := e.JSONPointer.Parent(); parent != "" {
+			sb.WriteString(" within ")
+			sb.WriteString(strconv.Quote(jsonwire.TruncatePointer(string(parent), 100)))
+		}
+	default:
+		sb.WriteString(": ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+func (e *SemanticError) Unwrap() error {
+	return e.Err
+}
+
+func kindName(k jsontext.Kind) string {
+	switch k {
+	case 'n':
+		return "null"
+	case 'f', 't':
+		return "boolean"
+	case '"':
+		return "string"
+	case '0':
+		return "number"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	default:
+		return "value"
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json_test
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+)
+
+// By default, JSON object names for Go struct fields are derived from
+// the Go field name, but may be specified in the `json` tag.
+// Options such as omitzero and format control how each field is handled.
+func Example_fieldNames() {
+	type Event struct {
+		Name     string        `json:"name"`
+		Start    time.Time     `json:"start,format:DateOnly"`
+		Duration time.Duration `json:"duration,format:sec"`
+		Tags     []string      `json:"tags,omitzero"`
+	}
+	in := Event{
+		Name:     "launch",
+		Start:    time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		Duration: 90 * time.Minute,
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+
+	var out Event
+	if err := json.Unmarshal(b, &out); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(out.Duration)
+
+	// Output:
+	// {"name":"launch","start":"2025-08-01","duration":5400}
+	// 1h30m0s
+}
+
+// Unlike v1, duplicate object names are rejected by default.
+func Example_duplicateNames() {
+	var v map[string]int
+	err := json.Unmarshal([]byte(`{"a":1,"a":2}`), &v)
+	fmt.Println(err)
+
+	err = json.Unmarshal([]byte(`{"a":1,"a":2}`), &v, jsontext.AllowDuplicateNames(true))
+	fmt.Println(v, err)
+
+	// Output:
+	// jsontext: duplicate object member name "a"
+	// map[a:2] <nil>
+}
+
+// Custom marshalers may be provided for any type without
+// declaring methods on the type itself.
+func ExampleWithMarshalers() {
+	m := json.JoinMarshalers(
+		// Encode booleans as "yes" or "no".
+		json.MarshalFunc(func(b bool) ([]byte, error) {
+			if b {
+				return []byte(`"yes"`), nil
+			}
+			return []byte(`"no"`), nil
+		}),
+		// Encode even integers as strings, and fall back to
+		// the default representation for odd integers.
+		json.MarshalToFunc(func(enc *jsontext.Encoder, n int) error {
+			if n%2 != 0 {
+				return json.SkipFunc
+			}
+			return enc.WriteToken(jsontext.String(strconv.Itoa(n)))
+		}),
+	)
+	b, err := json.Marshal([]any{true, false, 1, 2}, json.WithMarshalers(m))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+
+	// Output:
+	// ["yes","no",1,"2"]
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonopts"
+	jsonv2 "encoding/json/v2"
+)
+
+var (
+	// marshalersV1 and unmarshalersV1 provide the historical
+	// representation of types declared in this package.
+	marshalersV1   = jsonv2.MarshalToFunc(marshalNumber)
+	unmarshalersV1 = jsonv2.UnmarshalFromFunc(unmarshalNumber)
+
+	// unmarshalersUseNumberV1 additionally decodes JSON numbers
+	// within an empty interface as a [Number].
+	unmarshalersUseNumberV1 = jsonv2.JoinUnmarshalers(unmarshalersV1, jsonv2.UnmarshalFromFunc(unmarshalAnyNumber))
+
+	// optionsV1 is the set of options that reproduce the
+	// historical behavior of this package on top of v2.
+	optionsV1 = jsonv2.JoinOptions(
+		&jsonopts.DefaultOptionsV1,
+		jsonv2.WithMarshalers(marshalersV1),
+		jsonv2.WithUnmarshalers(unmarshalersV1),
+	)
+)
+
+func init() {
+	internal.NewMarshalerError = func(val any, err error, funcName string) error {
+		return &MarshalerError{reflect.TypeOf(val), err, funcName}
+	}
+}
+
+// transformMarshalError converts an error reported by [jsonv2.Marshal]
+// into the equivalent error historically reported by [Marshal].
+func transformMarshalError(err error) error {
+	var merr *MarshalerError
+	if errors.As(err, &merr) {
+		if merr.Type == numberType {
+			return merr.Err // Number was historically not a Marshaler
+		}
+		merr.Err = transformSyntacticError(merr.Err)
+		return merr
+	}
+	if serr, ok := err.(*jsonv2.SemanticError); ok {
+		switch {
+		case serr.Err == nil:
+			// Historically, this was only reported for unserializable types
+			// like complex numbers, channels, functions, and unsafe.Pointers.
+			return &UnsupportedTypeError{Type: serr.GoType}
+		case errors.Is(serr.Err, internal.ErrCycle):
+			return &UnsupportedValueError{Str: fmt.Sprintf("encountered a cycle via %v", serr.GoType)}
+		case errors.Is(serr.Err, internal.ErrNonFiniteNumber):
+			return &UnsupportedValueError{Str: strings.TrimPrefix(serr.Err.Error(), internal.ErrNonFiniteNumber.Error()+": ")}
+		}
+	}
+	return transformSyntacticError(err)
+}
+
+// transformUnmarshalError converts an error reported by [jsonv2.Unmarshal]
+// into the equivalent error historically reported by [Unmarshal].
+func transformUnmarshalError(root any, err error) error {
+	serr, ok := err.(*jsonv2.SemanticError)
+	if !ok {
+		return transformSyntacticError(err)
+	}
+	switch {
+	case serr.Err == internal.ErrNonNilReference:
+		return &InvalidUnmarshalError{serr.GoType}
+	case serr.Err == jsonv2.ErrUnknownName:
+		return fmt.Errorf("json: unknown field %q", serr.JSONPointer.LastToken())
+	case serr.Err != nil && strings.HasPrefix(serr.Err.Error(), "invalid use of ,string struct tag"):
+		return errors.New("json: " + serr.Err.Error())
+	}
+
+	var value string
+	switch serr.JSONKind {
+	case 'n':
+		value = "null"
+	case 'f', 't':
+		value = "bool"
+	case '"':
+		value = "string"
+	case '0':
+		value = "number"
+		// Historically, the number itself was only reported
+		// if it could not be represented by a Go numeric type.
+		if isNumericKind(serr.GoType) && len(serr.JSONValue) > 0 {
+			value += " " + string(serr.JSONValue)
+		}
+	case '[', ']':
+		value = "array"
+	case '{', '}':
+		value = "object"
+	}
+
+	// Historically, the location of a type error was reported as a
+	// '.'-delimited path of struct fields relative to the root type,
+	// which is approximated here from the JSON pointer.
+	var structName, field string
+	if serr.JSONPointer != "" {
+		if t := reflect.TypeOf(root); t != nil {
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			structName = t.Name()
+		}
+		field = strings.ReplaceAll(strings.TrimPrefix(string(serr.JSONPointer), "/"), "/", ".")
+	}
+	return &UnmarshalTypeError{
+		Value:  value,
+		Type:   serr.GoType,
+		Offset: serr.ByteOffset + int64(len(serr.JSONValue)),
+		Struct: structName,
+		Field:  field,
+	}
+}
+
+// isNumericKind reports whether t is a Go integer or floating-point type.
+func isNumericKind(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2
+
+package json
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"encoding/json/internal"
+	"encoding/json/jsontext"
+)
+
+// export exposes internal functionality of the "jsontext" package.
+var export = jsontext.Internal.Export(&internal.AllowInternalUse)
+
+// Valid reports whether data is a valid JSON encoding.
+func Valid(data []byte) bool {
+	return checkValid(data) == nil
+}
+
+// checkValid verifies that data is a single valid JSON value
+// and reports a [SyntaxError] if it is not.
+func checkValid(data []byte) error {
+	d := export.GetBufferedDecoder(data, jsontext.AllowDuplicateNames(true), jsontext.AllowInvalidUTF8(true))
+	defer export.PutBufferedDecoder(d)
+	if _, err := d.ReadValue(); err != nil {
+		if err == io.EOF {
+			err = &jsontext.SyntacticError{ByteOffset: int64(len(data)), Err: io.ErrUnexpectedEOF}
+		}
+		return transformSyntacticError(err)
+	}
+	if err := export.Decoder(d).CheckEOF(); err != nil {
+		return transformSyntacticError(err)
+	}
+	return nil
+}
+
+// A SyntaxError is a description of a JSON syntax error.
+// [Unmarshal] will return a SyntaxError if the JSON can't be parsed.
+type SyntaxError struct {
+	msg    string // description of error
+	Offset int64  // error occurred after reading Offset bytes
+}
+
+func (e *SyntaxError) Error() string { return e.msg }
+
+var errUnexpectedEnd = &SyntaxError{msg: "unexpected end of JSON input"}
+
+// transformSyntacticError converts a [jsontext.SyntacticError]
+// into the equivalent [SyntaxError] historically reported by this package.
+// I/O errors are returned unwrapped. All other errors are returned as is.
+func transformSyntacticError(err error) error {
+	serr, ok := err.(*jsontext.SyntacticError)
+	if !ok {
+		if export.IsIOError(err) {
+			return errors.Unwrap(err) // v1 historically did not wrap I/O errors
+		}
+		return err
+	}
+	if serr.Err == io.ErrUnexpectedEOF {
+		return errUnexpectedEnd
+	}
+	msg := serr.Err.Error()
+	if i := strings.Index(msg, " (expecting"); i >= 0 && !strings.Contains(msg, " in literal") {
+		msg = msg[:i]
+	}
+	offset := serr.ByteOffset
+	if strings.HasPrefix(msg, "invalid character ") {
+		offset++ // historically, the offending character was counted as read
+	}
+	return &SyntaxError{msg: syntaxErrorReplacer.Replace(msg), Offset: offset}
+}
+
+// syntaxErrorReplacer replaces certain phrases in jsontext errors
+// to better match the historical rendering of syntax errors.
+// In particular, jsontext uses the terminology "object name" to match RFC 8259,
+// while v1 uses "object key", which is not a term found in JSON literature.
+var syntaxErrorReplacer = strings.NewReplacer(
+	"object name", "object key",
+	"at start of value", "looking for beginning of value",
+	"at start of string", "looking for beginning of object key string",
+	"after object value", "after object key:value pair",
+	"in number", "in numeric literal",
+)
@@ -0,0 +1,228 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The tests in this file verify that the v1 API implemented on top of
+// encoding/json/v2 preserves the historical behavior of this package.
+
+type v1Struct struct {
+	Name   string  `json:"name"`
+	Count  int     `json:"count,omitempty"`
+	Score  float64 `json:",string"`
+	Nested *v1Struct
+	hidden int
+}
+
+func TestV1Marshal(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, `null`},
+		{[]int(nil), `null`},
+		{map[string]int(nil), `null`},
+		{[]byte("hello"), `"aGVsbG8="`},
+		{"<&>", `"\u003c\u0026\u003e"`},
+		{"\u2028", `"\u2028"`},
+		{"\b\f", `"\b\f"`},
+		{map[string]int{"b": 2, "a": 1}, `{"a":1,"b":2}`},
+		{map[int]string{2: "b", 1: "a"}, `{"1":"a","2":"b"}`},
+		{v1Struct{Name: "x", Score: 1.5}, `{"name":"x","Score":"1.5","Nested":null}`},
+		{Number("12.5"), `12.5`},
+		{map[Number]int{"1": 1}, `{"1":1}`},
+		{RawMessage(`{ "a" : "\u0041" }`), `{"a":"\u0041"}`},
+	}
+	for _, tt := range tests {
+		got, err := Marshal(tt.in)
+		if err != nil {
+			t.Errorf("Marshal(%#v) error: %v", tt.in, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal(%#v):\n\tgot:  %s\n\twant: %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestV1Unmarshal(t *testing.T) {
+	var s v1Struct
+	in := `{"NAME":"x","count":3,"Score":"2.5","name":"y","Nested":{"name":"z"}}`
+	if err := Unmarshal([]byte(in), &s); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	want := v1Struct{Name: "y", Count: 3, Score: 2.5, Nested: &v1Struct{Name: "z"}}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Unmarshal:\n\tgot:  %+v\n\twant: %+v", s, want)
+	}
+
+	var v any
+	if err := Unmarshal([]byte(`{"a":[1,"b",true,null]}`), &v); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	wantAny := map[string]any{"a": []any{1.0, "b", true, nil}}
+	if !reflect.DeepEqual(v, wantAny) {
+		t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", v, wantAny)
+	}
+
+	// Historically, an interface containing a pointer to itself
+	// was replaced rather than followed.
+	var self any
+	self = &self
+	if err := Unmarshal([]byte(`{"a":"b"}`), self); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+}
+
+func TestV1UnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		in      string
+		v       any
+		wantErr error
+	}{
+		{`{"a":1}`, nil, &InvalidUnmarshalError{}},
+		{`{"a":1}`, map[string]int{}, &InvalidUnmarshalError{reflect.TypeFor[map[string]int]()}},
+		{`{`, new(any), &SyntaxError{"unexpected end of JSON input", 0}},
+		{`[1,]`, new(any), &SyntaxError{"invalid character ']' looking for beginning of value", 4}},
+		{`{"a" 1}`, new(any), &SyntaxError{"invalid character '1' after object key", 6}},
+		{`"x"`, new(int), &UnmarshalTypeError{Value: "string", Type: reflect.TypeFor[int](), Offset: 3}},
+		{`300`, new(int8), &UnmarshalTypeError{Value: "number 300", Type: reflect.TypeFor[int8](), Offset: 3}},
+		{`{"name":1}`, new(v1Struct), &UnmarshalTypeError{Value: "number", Type: reflect.TypeFor[string](), Offset: 9, Struct: "v1Struct", Field: "name"}},
+	}
+	for _, tt := range tests {
+		err := Unmarshal([]byte(tt.in), tt.v)
+		if se, ok := tt.wantErr.(*SyntaxError); ok {
+			got, ok := err.(*SyntaxError)
+			if !ok || got.Error() != se.Error() || (se.Offset != 0 && got.Offset != se.Offset) {
+				t.Errorf("Unmarshal(%s) error:\n\tgot:  %#v\n\twant: %#v", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(err, tt.wantErr) {
+			t.Errorf("Unmarshal(%s) error:\n\tgot:  %#v\n\twant: %#v", tt.in, err, tt.wantErr)
+		}
+	}
+}
+
+type v1BadMarshaler struct{}
+
+func (v1BadMarshaler) MarshalJSON() ([]byte, error) { return nil, errors.New("boom") }
+
+func TestV1MarshalErrors(t *testing.T) {
+	_, err := Marshal(make(chan int))
+	if _, ok := err.(*UnsupportedTypeError); !ok {
+		t.Errorf("Marshal(chan) error = %T, want *UnsupportedTypeError", err)
+	}
+
+	_, err = Marshal([]float64{1, math.NaN()})
+	if _, ok := err.(*UnsupportedValueError); !ok {
+		t.Errorf("Marshal(NaN) error = %T, want *UnsupportedValueError", err)
+	}
+
+	_, err = Marshal(v1BadMarshaler{})
+	var merr *MarshalerError
+	if !errors.As(err, &merr) || merr.Err.Error() != "boom" {
+		t.Errorf("Marshal(v1BadMarshaler) error = %v, want *MarshalerError", err)
+	}
+
+	_, err = Marshal(Number("1.2.3"))
+	if err == nil || !strings.Contains(err.Error(), "invalid number literal") {
+		t.Errorf("Marshal(Number) error = %v, want invalid number literal", err)
+	}
+}
+
+func TestV1Decoder(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`{"a":[1,2.5]} {"b":"c"}`))
+	var toks []Token
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Token error: %v", err)
+		}
+		toks = append(toks, tok)
+	}
+	want := []Token{Delim('{'), "a", Delim('['), 1.0, 2.5, Delim(']'), Delim('}'), Delim('{'), "b", "c", Delim('}')}
+	if !reflect.DeepEqual(toks, want) {
+		t.Errorf("Token:\n\tgot:  %v\n\twant: %v", toks, want)
+	}
+
+	dec = NewDecoder(strings.NewReader(`{"a":1} {"a":2.5} {"b":1}`))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil || m["a"] != Number("1") {
+		t.Errorf("Decode = %v, %v; want a=1 as Number", m, err)
+	}
+	var s struct{ A float64 }
+	if err := dec.Decode(&s); err != nil || s.A != 2.5 {
+		t.Errorf("Decode = %v, %v; want A=2.5", s, err)
+	}
+	if err := dec.Decode(&s); err == nil || err.Error() != `json: unknown field "b"` {
+		t.Errorf("Decode error = %v, want unknown field", err)
+	}
+
+	dec = NewDecoder(strings.NewReader(`[1,2`))
+	if err := dec.Decode(new(any)); err != io.ErrUnexpectedEOF {
+		t.Errorf("Decode error = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestV1Encoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(">", "\t")
+	if err := enc.Encode(map[string]any{"a": "<b>", "c": []int{1}}); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	want := "{\n>\t\"a\": \"<b>\",\n>\t\"c\": [\n>\t\t1\n>\t]\n>}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Encode:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+}
+
+func TestV1Formatting(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Compact(&buf, []byte(" { \"a\" : [ 1 , \"\\u0041\" ] } ")); err != nil {
+		t.Fatalf("Compact error: %v", err)
+	}
+	if got, want := buf.String(), `{"a":[1,"\u0041"]}`; got != want {
+		t.Errorf("Compact = %s, want %s", got, want)
+	}
+
+	buf.Reset()
+	if err := Indent(&buf, []byte(`{"a":[]}`+"\n"), "", "  "); err != nil {
+		t.Fatalf("Indent error: %v", err)
+	}
+	if got, want := buf.String(), "{\n  \"a\": []\n}\n"; got != want {
+		t.Errorf("Indent = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	HTMLEscape(&buf, []byte(`{"<":"&"}`))
+	if got, want := buf.String(), `{"\u003c":"\u0026"}`; got != want {
+		t.Errorf("HTMLEscape = %s, want %s", got, want)
+	}
+
+	for in, want := range map[string]bool{`{}`: true, `[1,]`: false, ` 1 `: true, `1 2`: false, "\"\xff\"": true} {
+		if got := Valid([]byte(in)); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -3,11 +3,11 @@ module std
 go 1.24
 
 require (
-	golang.org/x/crypto v0.29.0
-	golang.org/x/net v0.31.1-0.20241122011411-9a518991035b
+	golang.org/x/crypto v0.35.0
+	golang.org/x/net v0.36.0
 )
 
 require (
-	golang.org/x/sys v0.27.1-0.20241118193836-0a57dbcf35b2 // indirect
-	golang.org/x/text v0.20.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
 )
@@ -40,6 +40,7 @@ var depsRules = `
 	NONE
 	< unsafe
 	< cmp,
+	  container/heap/internal/sift,
 	  container/list,
 	  container/ring,
 	  internal/byteorder,
@@ -69,6 +70,8 @@ var depsRules = `
 	internal/goarch < internal/abi;
 	internal/byteorder, internal/goarch < internal/chacha8rand;
 
+	internal/cpu < simd/archsimd;
+
 	# RUNTIME is the core runtime group of packages, all of them very light-weight.
 	internal/abi,
 	internal/chacha8rand,
@@ -94,6 +97,7 @@ var depsRules = `
 	< internal/runtime/exithook
 	< internal/runtime/math
 	< internal/runtime/maps
+	< internal/runtime/cgroup
 	< runtime
 	< sync/atomic
 	< internal/sync
@@ -109,11 +113,19 @@ var depsRules = `
 	< iter
 	< maps, slices;
 
+	iter, container/heap/internal/sift
+	< container/heap/v2;
+
+	iter
+	< container/list/v2;
+
 	internal/oserror, maps, slices
 	< RUNTIME;
 
 	RUNTIME
-	< sort
+	< sort;
+
+	sort, container/heap/internal/sift
 	< container/heap
 	< unique;
 
@@ -132,8 +144,7 @@ var depsRules = `
 	unicode !< path;
 
 	RUNTIME
-	< internal/synctest
-	< testing/synctest;
+	< internal/synctest;
 
 	# SYSCALL is RUNTIME plus the packages necessary for basic system calls.
 	RUNTIME, unicode/utf8, unicode/utf16, internal/synctest
@@ -256,7 +267,26 @@ var depsRules = `
 
 	FMT, encoding/base32, encoding/base64, internal/saferio
 	< encoding/ascii85, encoding/csv, encoding/gob, encoding/hex,
-	  encoding/json, encoding/pem, encoding/xml, mime;
+	  encoding/pem, encoding/xml, mime;
+
+	STR, errors
+	< encoding/json/internal
+	< encoding/json/internal/jsonflags
+	< encoding/json/internal/jsonopts
+	< encoding/json/internal/jsonwire
+	< encoding/json/jsontext;
+
+	FMT,
+	encoding/hex,
+	encoding/base32,
+	encoding/base64,
+	encoding/json/jsontext,
+	encoding/json/internal,
+	encoding/json/internal/jsonflags,
+	encoding/json/internal/jsonopts,
+	encoding/json/internal/jsonwire
+	< encoding/json/v2
+	< encoding/json;
 
 	# hashes
 	io
@@ -269,7 +299,7 @@ var depsRules = `
 
 	# compression
 	FMT, encoding/binary, hash/adler32, hash/crc32, sort
-	< compress/bzip2, compress/flate, compress/lzw, internal/zstd
+	< compress/bzip2, compress/flate, compress/lzw, compress/zstd
 	< archive/zip, compress/gzip, compress/zlib;
 
 	# templates
@@ -294,7 +324,7 @@ var depsRules = `
 	< index/suffixarray;
 
 	# executable parsing
-	FMT, encoding/binary, compress/zlib, internal/saferio, internal/zstd, sort
+	FMT, encoding/binary, compress/zlib, compress/zstd, internal/saferio, sort
 	< runtime/debug
 	< debug/dwarf
 	< debug/elf, debug/gosym, debug/macho, debug/pe, debug/plan9obj, internal/xcoff
@@ -358,6 +388,15 @@ var depsRules = `
 	< image/draw
 	< image/gif, image/jpeg, image/png;
 
+	FMT
+	< image/webp/internal/riff;
+
+	image
+	< image/webp/internal/vp8, image/webp/internal/vp8l;
+
+	bytes, image/webp/internal/riff, image/webp/internal/vp8, image/webp/internal/vp8l
+	< image/webp;
+
 	# cgo, delayed as long as possible.
 	# If you add a dependency on CGO, you must add the package
 	# to cgoPackages in cmd/dist/test.go as well.
@@ -477,6 +516,7 @@ var depsRules = `
 	< crypto/internal/fips140/aes/gcm
 	< crypto/internal/fips140/hkdf
 	< crypto/internal/fips140/mlkem
+	< crypto/internal/fips140/mldsa
 	< crypto/internal/fips140/ssh
 	< crypto/internal/fips140/tls12
 	< crypto/internal/fips140/tls13
@@ -527,12 +567,16 @@ var depsRules = `
 	crypto/hmac < crypto/pbkdf2;
 
 	crypto/internal/fips140/mlkem < crypto/mlkem;
+	crypto, crypto/internal/fips140/mldsa < crypto/mldsa;
 
 	crypto/aes,
 	crypto/des,
 	crypto/ecdh,
 	crypto/hmac,
 	crypto/md5,
+	crypto/mldsa,
+	crypto/mlkem,
+	crypto/pbkdf2,
 	crypto/rc4,
 	crypto/sha1,
 	crypto/sha256,
@@ -564,7 +608,7 @@ var depsRules = `
 	< golang.org/x/crypto/chacha20
 	< golang.org/x/crypto/internal/poly1305
 	< golang.org/x/crypto/chacha20poly1305
-	< crypto/internal/hpke
+	< crypto/hpke
 	< crypto/x509/internal/macos
 	< crypto/x509/pkix;
 
@@ -605,16 +649,36 @@ var depsRules = `
 	NET, crypto/tls
 	< net/http/httptrace;
 
+	# QUIC, for HTTP/3.
+	# golang.org/x/sys/unix imports C only on ports Go does not support.
+	C, FMT, encoding/binary, reflect, regexp
+	< golang.org/x/sys/unix;
+
+	crypto/hmac
+	< golang.org/x/crypto/hkdf;
+
+	encoding/binary
+	< golang.org/x/net/internal/quic/quicwire, net/http/internal/quicwire;
+
+	crypto/tls, log/slog,
+	golang.org/x/crypto/hkdf,
+	golang.org/x/net/internal/quic/quicwire,
+	golang.org/x/sys/unix
+	< golang.org/x/net/quic;
+
 	compress/gzip,
 	golang.org/x/net/http/httpguts,
 	golang.org/x/net/http/httpproxy,
 	golang.org/x/net/http2/hpack,
+	golang.org/x/net/quic,
 	net/http/internal,
 	net/http/internal/ascii,
+	net/http/internal/quicwire,
 	net/http/internal/testcert,
 	net/http/httptrace,
 	mime/multipart,
 	log
+	< net/http/internal/httpcommon
 	< net/http;
 
 	# HTTP-aware packages
@@ -665,6 +729,9 @@ var depsRules = `
 	log/slog, testing
 	< testing/slogtest;
 
+	NET, testing
+	< testing/synctest;
+
 	FMT, crypto/sha256, encoding/json, go/ast, go/parser, go/token,
 	internal/godebug, math/rand, encoding/hex, crypto/sha256
 	< internal/fuzz;
@@ -767,6 +834,8 @@ var depsRules = `
 	# Test-only packages can have anything they want
 	CGO, internal/syscall/unix < net/internal/cgotest;
 
+	FMT, testing < internal/cgrouptest;
+
 
 `
 
@@ -0,0 +1,636 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vp8l
+
+// This file implements an encoder. It applies the subtract-green and
+// predictor transforms and then codes the residual pixels with LZ77
+// backwards references and a single group of canonical Huffman codes.
+
+import (
+	"cmp"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+	"slices"
+)
+
+// maxDimension is the maximum width and height of a VP8L image.
+const maxDimension = 1 << 14
+
+// predictorBits is the log-2 size of the predictor transform's tiles.
+const predictorBits = 4
+
+// bitWriter writes a VP8L bit-stream, least significant bit first.
+type bitWriter struct {
+	buf   []byte
+	bits  uint64
+	nBits uint32
+}
+
+// write writes the low n bits of u. n must be at most 32.
+func (w *bitWriter) write(u uint32, n uint32) {
+	w.bits |= uint64(u) << w.nBits
+	w.nBits += n
+	for w.nBits >= 8 {
+		w.buf = append(w.buf, uint8(w.bits))
+		w.bits >>= 8
+		w.nBits -= 8
+	}
+}
+
+// flush writes any pending bits, padded with zeroes to a byte boundary.
+func (w *bitWriter) flush() {
+	if w.nBits > 0 {
+		w.buf = append(w.buf, uint8(w.bits))
+		w.bits, w.nBits = 0, 0
+	}
+}
+
+// Encode writes the image m to w in the VP8L lossless format.
+func Encode(w io.Writer, m image.Image) error {
+	b := m.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width < 1 || height < 1 || width > maxDimension || height > maxDimension {
+		return errors.New("vp8l: invalid image dimensions")
+	}
+	pix, hasAlpha := toARGB(m)
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8) // VP8L magic number.
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3) // Version.
+
+	// The decoder inverts the transforms in the reverse of the order in
+	// which they are written, so write them in the order they are applied.
+	subtractGreen(pix)
+	bw.write(1, 1)
+	bw.write(transformTypeSubtractGreen, 2)
+
+	modes, tilesPerRow, tilesPerColumn := predict(pix, width, height)
+	bw.write(1, 1)
+	bw.write(transformTypePredictor, 2)
+	bw.write(predictorBits-2, 3)
+	encodePix(bw, modes, tilesPerRow, tilesPerColumn, false)
+
+	bw.write(0, 1) // No more transforms.
+	encodePix(bw, pix, width, height, true)
+	bw.flush()
+
+	_, err := w.Write(bw.buf)
+	return err
+}
+
+// toARGB returns the pixels of m in ARGB order, one uint32 per pixel,
+// and whether any of them is not fully opaque.
+func toARGB(m image.Image) (pix []uint32, hasAlpha bool) {
+	b := m.Bounds()
+	pix = make([]uint32, 0, b.Dx()*b.Dy())
+	if m, ok := m.(*image.NRGBA); ok {
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			i := m.PixOffset(b.Min.X, y)
+			for s := m.Pix[i : i+4*b.Dx() : i+4*b.Dx()]; len(s) >= 4; s = s[4:] {
+				pix = append(pix, uint32(s[3])<<24|uint32(s[0])<<16|uint32(s[1])<<8|uint32(s[2]))
+				hasAlpha = hasAlpha || s[3] != 0xff
+			}
+		}
+		return pix, hasAlpha
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(m.At(x, y)).(color.NRGBA)
+			pix = append(pix, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+			hasAlpha = hasAlpha || c.A != 0xff
+		}
+	}
+	return pix, hasAlpha
+}
+
+// subtractGreen applies the subtract-green transform, specified in section 4.2.
+func subtractGreen(pix []uint32) {
+	for i, p := range pix {
+		g := p >> 8 & 0xff
+		r := (p>>16 - g) & 0xff
+		b := (p - g) & 0xff
+		pix[i] = p&0xff00ff00 | r<<16 | b
+	}
+}
+
+// subPixels returns the channel-wise difference a-b, modulo 256.
+func subPixels(a, b uint32) uint32 {
+	alphaAndGreen := 0x00ff00ff + a&0xff00ff00 - b&0xff00ff00
+	redAndBlue := 0xff00ff00 + a&0x00ff00ff - b&0x00ff00ff
+	return alphaAndGreen&0xff00ff00 | redAndBlue&0x00ff00ff
+}
+
+// average2 returns the channel-wise average of a and b, rounded down.
+func average2(a, b uint32) uint32 {
+	return (a^b)&0xfefefefe>>1 + a&b
+}
+
+// channel returns the i'th channel of p, counting from blue.
+func channel(p uint32, i int) int32 {
+	return int32(p >> (8 * i) & 0xff)
+}
+
+// predictPixel returns the prediction for a pixel with the given
+// neighbors under the predictor mode, specified in section 4.1. It
+// matches the inverse transform in transform.go.
+func predictPixel(mode uint32, l, t, tr, tl uint32) uint32 {
+	switch mode {
+	case 0:
+		return 0xff000000
+	case 1:
+		return l
+	case 2:
+		return t
+	case 3:
+		return tr
+	case 4:
+		return tl
+	case 5:
+		return average2(average2(l, tr), t)
+	case 6:
+		return average2(l, tl)
+	case 7:
+		return average2(l, t)
+	case 8:
+		return average2(tl, t)
+	case 9:
+		return average2(t, tr)
+	case 10:
+		return average2(average2(l, tl), average2(t, tr))
+	case 11:
+		var pl, pt int32
+		for i := range 4 {
+			pl += abs(channel(tl, i) - channel(t, i))
+			pt += abs(channel(tl, i) - channel(l, i))
+		}
+		if pl < pt {
+			return l
+		}
+		return t
+	case 12:
+		var p uint32
+		for i := range 4 {
+			p |= uint32(clampAddSubtractFull(uint8(channel(l, i)), uint8(channel(t, i)), uint8(channel(tl, i)))) << (8 * i)
+		}
+		return p
+	case 13:
+		a := average2(l, t)
+		var p uint32
+		for i := range 4 {
+			p |= uint32(clampAddSubtractHalf(uint8(channel(a, i)), uint8(channel(tl, i)))) << (8 * i)
+		}
+		return p
+	}
+	panic("unreachable")
+}
+
+// nPredictorModes is the number of predictor modes the encoder considers.
+const nPredictorModes = 14
+
+// predict applies the predictor transform to pix, which holds a w×h image,
+// choosing the mode for each tile that minimizes the magnitude of its
+// residuals. It returns the tiles' modes as an image of the given size.
+func predict(pix []uint32, w, h int) (modes []uint32, tilesPerRow, tilesPerColumn int) {
+	tilesPerRow = int(nTiles(int32(w), predictorBits))
+	tilesPerColumn = int(nTiles(int32(h), predictorBits))
+	modes = make([]uint32, tilesPerRow*tilesPerColumn)
+
+	// The neighbors of a pixel refer to the untransformed image,
+	// so compute the residuals in a separate slice.
+	res := make([]uint32, len(pix))
+	const tileSize = 1 << predictorBits
+	for ty := range tilesPerColumn {
+		for tx := range tilesPerRow {
+			x0, y0 := tx*tileSize, ty*tileSize
+			x1, y1 := min(x0+tileSize, w), min(y0+tileSize, h)
+			best, bestCost := uint32(0), int64(-1)
+			for mode := range uint32(nPredictorModes) {
+				var cost int64
+				for y := max(y0, 1); y < y1; y++ {
+					for x := max(x0, 1); x < x1; x++ {
+						i := y*w + x
+						r := subPixels(pix[i], predictPixel(mode, pix[i-1], pix[i-w], pix[i-w+1], pix[i-w-1]))
+						for c := range 4 {
+							cost += int64(abs(int32(int8(channel(r, c)))))
+						}
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					best, bestCost = mode, cost
+				}
+			}
+			modes[ty*tilesPerRow+tx] = 0xff000000 | best<<8
+		}
+	}
+
+	for y := range h {
+		for x := range w {
+			i := y*w + x
+			var pred uint32
+			switch {
+			case x == 0 && y == 0:
+				pred = 0xff000000
+			case y == 0:
+				pred = pix[i-1]
+			case x == 0:
+				pred = pix[i-w]
+			default:
+				mode := modes[(y>>predictorBits)*tilesPerRow+(x>>predictorBits)] >> 8 & 0xff
+				pred = predictPixel(mode, pix[i-1], pix[i-w], pix[i-w+1], pix[i-w-1])
+			}
+			res[i] = subPixels(pix[i], pred)
+		}
+	}
+	copy(pix, res)
+	return modes, tilesPerRow, tilesPerColumn
+}
+
+// A token is either a literal pixel or an LZ77 backwards reference.
+type token struct {
+	argb     uint32 // The literal pixel, if length is zero.
+	length   uint32
+	distCode uint32
+}
+
+const (
+	minMatchLength = 3
+	maxMatchLength = 4096
+	// maxDistance is the largest distance whose code, the distance
+	// plus the number of two-dimensional distance codes, can be
+	// represented with the 40 distance prefix codes.
+	maxDistance = 1<<20 - len(distanceMapTable)
+	hashBits    = 16
+	maxChain    = 32
+)
+
+// backwardRefs tokenizes pix, a w-pixel-wide image, greedily using
+// backwards references found through a hash chain.
+func backwardRefs(pix []uint32, w int) []token {
+	// Map each distance with a two-dimensional code to its smallest code.
+	distCodes := make(map[int]uint32, len(distanceMapTable))
+	for code := uint32(len(distanceMapTable)); code > 0; code-- {
+		distCodes[int(distanceMap(int32(w), code))] = code
+	}
+	distCode := func(dist int) uint32 {
+		if code, ok := distCodes[dist]; ok {
+			return code
+		}
+		return uint32(dist + len(distanceMapTable))
+	}
+
+	hash := func(i int) uint32 {
+		return (pix[i]*0x1e35a7bd + pix[i+1]*0x9e3779b1) >> (32 - hashBits)
+	}
+	head := make([]int32, 1<<hashBits)
+	for i := range head {
+		head[i] = -1
+	}
+	prev := make([]int32, len(pix))
+	insert := func(i int) {
+		if i+1 < len(pix) {
+			h := hash(i)
+			prev[i] = head[h]
+			head[h] = int32(i)
+		}
+	}
+	matchLength := func(i, j int) int {
+		n := min(len(pix)-i, maxMatchLength)
+		k := 0
+		for k < n && pix[i+k] == pix[j+k] {
+			k++
+		}
+		return k
+	}
+
+	var tokens []token
+	for i := 0; i < len(pix); {
+		bestLength, bestDist := 0, 0
+		if i+minMatchLength <= len(pix) {
+			// The pixels to the left and above have the cheapest codes.
+			for _, dist := range [2]int{1, w} {
+				if dist <= i {
+					if n := matchLength(i, i-dist); n > bestLength {
+						bestLength, bestDist = n, dist
+					}
+				}
+			}
+			j := head[hash(i)]
+			for chain := 0; j >= 0 && chain < maxChain && i-int(j) <= maxDistance; chain++ {
+				if n := matchLength(i, int(j)); n > bestLength {
+					bestLength, bestDist = n, i-int(j)
+				}
+				j = prev[j]
+			}
+		}
+		if bestLength < minMatchLength {
+			tokens = append(tokens, token{argb: pix[i]})
+			insert(i)
+			i++
+			continue
+		}
+		tokens = append(tokens, token{length: uint32(bestLength), distCode: distCode(bestDist)})
+		for end := i + bestLength; i < end; i++ {
+			insert(i)
+		}
+	}
+	return tokens
+}
+
+// prefixEncode returns the prefix symbol and extra bits for an LZ77 length
+// or distance code v, specified in section 4.2.2. It is the inverse of
+// decoder.lz77Param.
+func prefixEncode(v uint32) (symbol, nExtraBits, extraBits uint32) {
+	v--
+	if v < 4 {
+		return v, 0, 0
+	}
+	highestBit := uint32(bits.Len32(v)) - 1
+	secondHighestBit := v >> (highestBit - 1) & 1
+	nExtraBits = highestBit - 1
+	return 2*highestBit + secondHighestBit, nExtraBits, v & (1<<nExtraBits - 1)
+}
+
+// encodePix encodes pixel data, specified in section 5.2.2. It is the
+// inverse of decoder.decodePix.
+func encodePix(bw *bitWriter, pix []uint32, w, h int, topLevel bool) {
+	bw.write(0, 1) // No color cache.
+	if topLevel {
+		bw.write(0, 1) // No meta prefix codes.
+	}
+
+	tokens := backwardRefs(pix, w)
+	var hists [nHuff][]uint32
+	for i, n := range alphabetSizes {
+		hists[i] = make([]uint32, n)
+	}
+	for _, t := range tokens {
+		if t.length == 0 {
+			hists[huffGreen][t.argb>>8&0xff]++
+			hists[huffRed][t.argb>>16&0xff]++
+			hists[huffBlue][t.argb&0xff]++
+			hists[huffAlpha][t.argb>>24]++
+			continue
+		}
+		lengthSymbol, _, _ := prefixEncode(t.length)
+		distSymbol, _, _ := prefixEncode(t.distCode)
+		hists[huffGreen][nLiteralCodes+lengthSymbol]++
+		hists[huffDistance][distSymbol]++
+	}
+	var codes [nHuff]prefixCode
+	for i := range codes {
+		codes[i] = writePrefixCode(bw, hists[i])
+	}
+
+	for _, t := range tokens {
+		if t.length == 0 {
+			codes[huffGreen].write(bw, t.argb>>8&0xff)
+			codes[huffRed].write(bw, t.argb>>16&0xff)
+			codes[huffBlue].write(bw, t.argb&0xff)
+			codes[huffAlpha].write(bw, t.argb>>24)
+			continue
+		}
+		symbol, n, extra := prefixEncode(t.length)
+		codes[huffGreen].write(bw, nLiteralCodes+symbol)
+		bw.write(extra, n)
+		symbol, n, extra = prefixEncode(t.distCode)
+		codes[huffDistance].write(bw, symbol)
+		bw.write(extra, n)
+	}
+}
+
+// A prefixCode maps symbols to their bit-reversed canonical Huffman codes.
+type prefixCode struct {
+	codes   []uint32
+	lengths []uint32
+}
+
+func (c *prefixCode) write(bw *bitWriter, symbol uint32) {
+	bw.write(c.codes[symbol], c.lengths[symbol])
+}
+
+// newPrefixCode returns the prefix code for the given code lengths. A code
+// with a single symbol uses zero bits per symbol.
+func newPrefixCode(lengths []uint32) prefixCode {
+	c := prefixCode{
+		codes:   make([]uint32, len(lengths)),
+		lengths: make([]uint32, len(lengths)),
+	}
+	n := 0
+	for _, l := range lengths {
+		if l != 0 {
+			n++
+		}
+	}
+	if n < 2 {
+		return c
+	}
+	codes, err := codeLengthsToCodes(lengths)
+	if err != nil {
+		panic("vp8l: invalid code lengths: " + err.Error())
+	}
+	for s, l := range lengths {
+		if l != 0 {
+			c.codes[s] = uint32(bits.Reverse16(uint16(codes[s]))) >> (16 - l)
+			c.lengths[s] = l
+		}
+	}
+	return c
+}
+
+// writePrefixCode writes a prefix code for the symbol frequencies in hist,
+// specified in section 5.2.2, and returns it. It is the inverse of
+// decoder.decodeHuffmanTree.
+func writePrefixCode(bw *bitWriter, hist []uint32) prefixCode {
+	var symbols []uint32
+	for s, n := range hist {
+		if n != 0 {
+			symbols = append(symbols, uint32(s))
+			if len(symbols) > 2 {
+				break
+			}
+		}
+	}
+	if len(symbols) == 0 {
+		symbols = append(symbols, 0)
+	}
+
+	// Use a simple code length code for one or two 8-bit symbols.
+	if len(symbols) <= 2 && symbols[len(symbols)-1] < 256 {
+		bw.write(1, 1)
+		bw.write(uint32(len(symbols)-1), 1)
+		if symbols[0] < 2 {
+			bw.write(0, 1)
+			bw.write(symbols[0], 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(symbols[0], 8)
+		}
+		c := prefixCode{
+			codes:   make([]uint32, len(hist)),
+			lengths: make([]uint32, len(hist)),
+		}
+		if len(symbols) == 2 {
+			// The first symbol is coded as 0 and the second as 1.
+			bw.write(symbols[1], 8)
+			c.codes[symbols[1]] = 1
+			c.lengths[symbols[0]] = 1
+			c.lengths[symbols[1]] = 1
+		}
+		return c
+	}
+
+	bw.write(0, 1)
+	lengths := huffmanCodeLengths(hist, 15)
+	writeCodeLengths(bw, lengths)
+	return newPrefixCode(lengths)
+}
+
+// writeCodeLengths writes the code lengths of a normal code length code.
+// It is the inverse of decoder.decodeCodeLengths.
+func writeCodeLengths(bw *bitWriter, lengths []uint32) {
+	type rleToken struct {
+		symbol, extraBits uint32
+	}
+	var tokens []rleToken
+	// The decoder starts off repeating a code length of 8.
+	prevLength := uint32(8)
+	for i := 0; i < len(lengths); {
+		l := lengths[i]
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == l {
+			run++
+		}
+		i += run
+		if l == 0 {
+			for run >= 11 {
+				r := min(run, 138)
+				tokens = append(tokens, rleToken{18, uint32(r - 11)})
+				run -= r
+			}
+			if run >= 3 {
+				tokens = append(tokens, rleToken{17, uint32(run - 3)})
+				run = 0
+			}
+		} else {
+			if l != prevLength {
+				tokens = append(tokens, rleToken{l, 0})
+				prevLength = l
+				run--
+			}
+			for run >= 3 {
+				r := min(run, 6)
+				tokens = append(tokens, rleToken{16, uint32(r - 3)})
+				run -= r
+			}
+		}
+		for ; run > 0; run-- {
+			tokens = append(tokens, rleToken{l, 0})
+		}
+	}
+
+	var hist [len(codeLengthCodeOrder)]uint32
+	for _, t := range tokens {
+		hist[t.symbol]++
+	}
+	codeLengthCodeLengths := huffmanCodeLengths(hist[:], 7)
+	nCodes := 4
+	for i, s := range codeLengthCodeOrder {
+		if codeLengthCodeLengths[s] != 0 {
+			nCodes = max(nCodes, i+1)
+		}
+	}
+	bw.write(uint32(nCodes-4), 4)
+	for _, s := range codeLengthCodeOrder[:nCodes] {
+		bw.write(codeLengthCodeLengths[s], 3)
+	}
+	bw.write(0, 1) // Code lengths for every symbol follow.
+
+	c := newPrefixCode(codeLengthCodeLengths)
+	for _, t := range tokens {
+		c.write(bw, t.symbol)
+		if t.symbol >= repeatsCodeLength {
+			bw.write(t.extraBits, uint32(repeatBits[t.symbol-repeatsCodeLength]))
+		}
+	}
+}
+
+// huffmanCodeLengths returns the lengths of a Huffman code for the symbol
+// frequencies in hist, limited to maxLength bits. A single used symbol is
+// given a length of 1.
+func huffmanCodeLengths(hist []uint32, maxLength uint32) []uint32 {
+	lengths := make([]uint32, len(hist))
+	var symbols []int
+	for s, n := range hist {
+		if n != 0 {
+			symbols = append(symbols, s)
+		}
+	}
+	switch len(symbols) {
+	case 0:
+		return lengths
+	case 1:
+		lengths[symbols[0]] = 1
+		return lengths
+	}
+
+	// Build the Huffman tree with the two-queue method: the leaves, sorted
+	// by weight, are followed by the internal nodes, which are created in
+	// order of non-decreasing weight. If the tree is too deep, raise the
+	// weight of the rarest symbols and try again.
+	n := len(symbols)
+	weights := make([]uint64, 2*n-1)
+	parents := make([]int, 2*n-1)
+	depths := make([]uint32, 2*n-1)
+	for minWeight := uint64(1); ; minWeight *= 2 {
+		weight := func(s int) uint64 {
+			return max(uint64(hist[s]), minWeight)
+		}
+		slices.SortStableFunc(symbols, func(a, b int) int {
+			return cmp.Compare(weight(a), weight(b))
+		})
+		for i, s := range symbols {
+			weights[i] = weight(s)
+		}
+		leaf, node := 0, n
+		for next := n; next < len(weights); next++ {
+			var pair [2]int
+			for k := range pair {
+				if leaf < n && (node == next || weights[leaf] <= weights[node]) {
+					pair[k] = leaf
+					leaf++
+				} else {
+					pair[k] = node
+					node++
+				}
+			}
+			weights[next] = weights[pair[0]] + weights[pair[1]]
+			parents[pair[0]], parents[pair[1]] = next, next
+		}
+		depths[len(depths)-1] = 0
+		maxDepth := uint32(0)
+		for i := len(depths) - 2; i >= 0; i-- {
+			depths[i] = depths[parents[i]] + 1
+			if i < n {
+				maxDepth = max(maxDepth, depths[i])
+			}
+		}
+		if maxDepth <= maxLength {
+			for i, s := range symbols {
+				lengths[s] = depths[i]
+			}
+			return lengths
+		}
+	}
+}
@@ -0,0 +1,504 @@
+// Copyright 2011 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package webp implements a WebP image decoder and encoder.
+//
+// The decoder supports lossy (VP8) and lossless (VP8L) images, with or
+// without an alpha channel, as well as animated images. The encoder
+// produces lossless images.
+//
+// The WebP container specification is at
+// https://developers.google.com/speed/webp/docs/riff_container.
+package webp
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/webp/internal/riff"
+	"image/webp/internal/vp8"
+	"image/webp/internal/vp8l"
+	"io"
+)
+
+var errInvalidFormat = errors.New("webp: invalid format")
+
+var (
+	fccALPH = riff.FourCC{'A', 'L', 'P', 'H'}
+	fccANIM = riff.FourCC{'A', 'N', 'I', 'M'}
+	fccANMF = riff.FourCC{'A', 'N', 'M', 'F'}
+	fccVP8  = riff.FourCC{'V', 'P', '8', ' '}
+	fccVP8L = riff.FourCC{'V', 'P', '8', 'L'}
+	fccVP8X = riff.FourCC{'V', 'P', '8', 'X'}
+	fccWEBP = riff.FourCC{'W', 'E', 'B', 'P'}
+)
+
+// Bits of the VP8X chunk's flags byte.
+const (
+	animationBit    = 1 << 1
+	xmpMetadataBit  = 1 << 2
+	exifMetadataBit = 1 << 3
+	alphaBit        = 1 << 4
+	iccProfileBit   = 1 << 5
+)
+
+// Disposal methods, as stored in an ANMF chunk.
+const (
+	// DisposalNone leaves the canvas as is after the frame is displayed.
+	DisposalNone = 0x00
+	// DisposalBackground fills the frame's rectangle with the background
+	// color after the frame is displayed.
+	DisposalBackground = 0x01
+)
+
+// Blending methods, as stored in an ANMF chunk.
+const (
+	// BlendAlpha alpha-blends the frame onto the canvas.
+	BlendAlpha = 0x00
+	// BlendNone overwrites the canvas with the frame.
+	BlendNone = 0x01
+)
+
+// decoder is the type used to decode a WebP file.
+type decoder struct {
+	configOnly bool
+	all        bool
+
+	config          image.Config
+	image           []image.Image
+	delay           []int
+	disposal        []byte
+	blend           []byte
+	loopCount       int
+	backgroundColor color.NRGBA
+}
+
+// decode reads a WebP image from r. If configOnly is true, only the
+// dimensions and color model are decoded. If all is false, decoding stops
+// after the first frame.
+func (d *decoder) decode(r io.Reader, configOnly, all bool) error {
+	d.configOnly = configOnly
+	d.all = all
+
+	formType, riffReader, err := riff.NewReader(r)
+	if err != nil {
+		return err
+	}
+	if formType != fccWEBP {
+		return errInvalidFormat
+	}
+	for {
+		chunkID, chunkLen, chunkData, err := riffReader.Next()
+		if err == io.EOF {
+			err = errInvalidFormat
+		}
+		if err != nil {
+			return err
+		}
+		switch chunkID {
+		case fccVP8, fccVP8L:
+			// The simple file format holds a single image without a canvas.
+			m, c, err := decodeBitstream(chunkID, chunkLen, chunkData, nil, 0, configOnly)
+			if err != nil {
+				return err
+			}
+			d.config = c
+			if !configOnly {
+				d.config.ColorModel = m.ColorModel()
+				b := m.Bounds()
+				d.config.Width, d.config.Height = b.Dx(), b.Dy()
+				d.addFrame(m, 0, DisposalNone, BlendNone)
+			}
+			return nil
+		case fccVP8X:
+			return d.decodeExtended(riffReader, chunkLen, chunkData)
+		}
+	}
+}
+
+// decodeExtended decodes a file in the extended file format, whose first
+// chunk, the VP8X chunk, is given by chunkLen and chunkData.
+func (d *decoder) decodeExtended(riffReader *riff.Reader, chunkLen uint32, chunkData io.Reader) error {
+	var buf [16]byte
+	if chunkLen != 10 {
+		return errInvalidFormat
+	}
+	if _, err := io.ReadFull(chunkData, buf[:10]); err != nil {
+		return err
+	}
+	flags := buf[0]
+	width := int(u24(buf[4:])) + 1
+	height := int(u24(buf[7:])) + 1
+	d.config.Width, d.config.Height = width, height
+	switch {
+	case flags&animationBit != 0:
+		d.config.ColorModel = color.NRGBAModel
+	case flags&alphaBit != 0:
+		d.config.ColorModel = color.NYCbCrAModel
+	default:
+		d.config.ColorModel = color.YCbCrModel
+	}
+	if d.configOnly {
+		return nil
+	}
+
+	if flags&animationBit == 0 {
+		m, err := decodeFrame(riffReader, width, height, flags&alphaBit != 0)
+		if err != nil {
+			return err
+		}
+		d.addFrame(m, 0, DisposalNone, BlendNone)
+		return nil
+	}
+
+	for {
+		chunkID, chunkLen, chunkData, err := riffReader.Next()
+		if err == io.EOF {
+			if len(d.image) == 0 {
+				return errInvalidFormat
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch chunkID {
+		case fccANIM:
+			if chunkLen != 6 {
+				return errInvalidFormat
+			}
+			if _, err := io.ReadFull(chunkData, buf[:6]); err != nil {
+				return err
+			}
+			// The background color is stored in [Blue, Green, Red, Alpha] order.
+			d.backgroundColor = color.NRGBA{R: buf[2], G: buf[1], B: buf[0], A: buf[3]}
+			d.loopCount = int(buf[4]) | int(buf[5])<<8
+
+		case fccANMF:
+			if chunkLen < 16 {
+				return errInvalidFormat
+			}
+			if _, err := io.ReadFull(chunkData, buf[:16]); err != nil {
+				return err
+			}
+			x := 2 * int(u24(buf[0:]))
+			y := 2 * int(u24(buf[3:]))
+			w := int(u24(buf[6:])) + 1
+			h := int(u24(buf[9:])) + 1
+			duration := int(u24(buf[12:]))
+			if x+w > width || y+h > height {
+				return errInvalidFormat
+			}
+			m, err := decodeFrame(riff.NewChunkReader(chunkLen-16, chunkData), w, h, true)
+			if err != nil {
+				return err
+			}
+			setOrigin(m, image.Pt(x, y))
+			d.addFrame(m, duration, buf[15]&0x01, (buf[15]>>1)&0x01)
+			if !d.all {
+				return nil
+			}
+
+		case fccVP8X:
+			return errInvalidFormat
+		}
+	}
+}
+
+func (d *decoder) addFrame(m image.Image, delay int, disposal, blend byte) {
+	d.image = append(d.image, m)
+	d.delay = append(d.delay, delay)
+	d.disposal = append(d.disposal, disposal)
+	d.blend = append(d.blend, blend)
+}
+
+// decodeFrame decodes the image held by the chunks of z: an optional ALPH
+// chunk followed by a VP8 chunk, or a VP8L chunk. Unknown chunks are
+// skipped. The image must be w pixels wide and h pixels high. An ALPH chunk
+// is only permitted if allowAlpha is true.
+func decodeFrame(z *riff.Reader, w, h int, allowAlpha bool) (image.Image, error) {
+	var (
+		alpha       []byte
+		alphaStride int
+		buf         [1]byte
+	)
+	for {
+		chunkID, chunkLen, chunkData, err := z.Next()
+		if err == io.EOF {
+			err = errInvalidFormat
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch chunkID {
+		case fccALPH:
+			if !allowAlpha || alpha != nil {
+				return nil, errInvalidFormat
+			}
+			// Read the Pre-processing | Filter | Compression byte.
+			if _, err := io.ReadFull(chunkData, buf[:1]); err != nil {
+				if err == io.EOF {
+					err = errInvalidFormat
+				}
+				return nil, err
+			}
+			alpha, alphaStride, err = readAlpha(chunkData, chunkLen-1, w, h, buf[0]&0x03)
+			if err != nil {
+				return nil, err
+			}
+			unfilterAlpha(alpha, alphaStride, (buf[0]>>2)&0x03)
+
+		case fccVP8, fccVP8L:
+			m, _, err := decodeBitstream(chunkID, chunkLen, chunkData, alpha, alphaStride, false)
+			if err != nil {
+				return nil, err
+			}
+			if b := m.Bounds(); b.Dx() != w || b.Dy() != h {
+				return nil, errInvalidFormat
+			}
+			return m, nil
+
+		case fccVP8X:
+			return nil, errInvalidFormat
+		}
+	}
+}
+
+// decodeBitstream decodes a VP8 or VP8L chunk. For a VP8 chunk, alpha and
+// alphaStride hold the image's alpha values, if any. A VP8L chunk must not
+// be accompanied by alpha values.
+func decodeBitstream(chunkID riff.FourCC, chunkLen uint32, chunkData io.Reader, alpha []byte, alphaStride int, configOnly bool) (image.Image, image.Config, error) {
+	switch chunkID {
+	case fccVP8:
+		if int32(chunkLen) < 0 {
+			return nil, image.Config{}, errInvalidFormat
+		}
+		d := vp8.NewDecoder()
+		d.Init(chunkData, int(chunkLen))
+		fh, err := d.DecodeFrameHeader()
+		if err != nil {
+			return nil, image.Config{}, err
+		}
+		if configOnly {
+			return nil, image.Config{
+				ColorModel: color.YCbCrModel,
+				Width:      fh.Width,
+				Height:     fh.Height,
+			}, nil
+		}
+		m, err := d.DecodeFrame()
+		if err != nil {
+			return nil, image.Config{}, err
+		}
+		if alpha != nil {
+			if len(alpha) != fh.Width*fh.Height {
+				return nil, image.Config{}, errInvalidFormat
+			}
+			return &image.NYCbCrA{
+				YCbCr:   *m,
+				A:       alpha,
+				AStride: alphaStride,
+			}, image.Config{}, nil
+		}
+		return m, image.Config{}, nil
+
+	case fccVP8L:
+		if alpha != nil {
+			return nil, image.Config{}, errInvalidFormat
+		}
+		if configOnly {
+			c, err := vp8l.DecodeConfig(chunkData)
+			return nil, c, err
+		}
+		m, err := vp8l.Decode(chunkData)
+		return m, image.Config{}, err
+	}
+	return nil, image.Config{}, errInvalidFormat
+}
+
+// setOrigin translates the bounds of m, as returned by decodeBitstream,
+// so that its top-left corner is p.
+func setOrigin(m image.Image, p image.Point) {
+	switch m := m.(type) {
+	case *image.NRGBA:
+		m.Rect = m.Rect.Add(p)
+	case *image.YCbCr:
+		m.Rect = m.Rect.Add(p)
+	case *image.NYCbCrA:
+		m.Rect = m.Rect.Add(p)
+	}
+}
+
+// u24 decodes the first three bytes of b as a little-endian integer.
+func u24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
+
+// readAlpha reads the n bytes of alpha values for a w×h image.
+func readAlpha(chunkData io.Reader, n uint32, w, h int, compression byte) (alpha []byte, alphaStride int, err error) {
+	switch compression {
+	case 0:
+		if uint64(n) < uint64(w)*uint64(h) {
+			return nil, 0, errInvalidFormat
+		}
+		alpha = make([]byte, w*h)
+		if _, err := io.ReadFull(chunkData, alpha); err != nil {
+			return nil, 0, err
+		}
+		return alpha, w, nil
+
+	case 1:
+		// Read the VP8L-compressed alpha values. First, synthesize a 5-byte VP8L header:
+		// a 1-byte magic number, a 14-bit widthMinusOne, a 14-bit heightMinusOne,
+		// a 1-bit (ignored, zero) alphaIsUsed and a 3-bit (zero) version.
+		widthMinusOne, heightMinusOne := uint32(w-1), uint32(h-1)
+		if widthMinusOne > 0x3fff || heightMinusOne > 0x3fff {
+			return nil, 0, errInvalidFormat
+		}
+		alphaImage, err := vp8l.Decode(io.MultiReader(
+			bytes.NewReader([]byte{
+				0x2f, // VP8L magic number.
+				uint8(widthMinusOne),
+				uint8(widthMinusOne>>8) | uint8(heightMinusOne<<6),
+				uint8(heightMinusOne >> 2),
+				uint8(heightMinusOne >> 10),
+			}),
+			chunkData,
+		))
+		if err != nil {
+			return nil, 0, err
+		}
+		// The green values of the inner NRGBA image are the alpha values of the
+		// outer NYCbCrA image.
+		pix := alphaImage.(*image.NRGBA).Pix
+		alpha = make([]byte, len(pix)/4)
+		for i := range alpha {
+			alpha[i] = pix[4*i+1]
+		}
+		return alpha, w, nil
+	}
+	return nil, 0, errInvalidFormat
+}
+
+func unfilterAlpha(alpha []byte, alphaStride int, filter byte) {
+	if len(alpha) == 0 || alphaStride == 0 {
+		return
+	}
+	switch filter {
+	case 1: // Horizontal filter.
+		for i := 1; i < alphaStride; i++ {
+			alpha[i] += alpha[i-1]
+		}
+		for i := alphaStride; i < len(alpha); i += alphaStride {
+			// The first column is equivalent to the vertical filter.
+			alpha[i] += alpha[i-alphaStride]
+
+			for j := 1; j < alphaStride; j++ {
+				alpha[i+j] += alpha[i+j-1]
+			}
+		}
+
+	case 2: // Vertical filter.
+		// The first row is equivalent to the horizontal filter.
+		for i := 1; i < alphaStride; i++ {
+			alpha[i] += alpha[i-1]
+		}
+
+		for i := alphaStride; i < len(alpha); i++ {
+			alpha[i] += alpha[i-alphaStride]
+		}
+
+	case 3: // Gradient filter.
+		// The first row is equivalent to the horizontal filter.
+		for i := 1; i < alphaStride; i++ {
+			alpha[i] += alpha[i-1]
+		}
+
+		for i := alphaStride; i < len(alpha); i += alphaStride {
+			// The first column is equivalent to the vertical filter.
+			alpha[i] += alpha[i-alphaStride]
+
+			// The interior is predicted on the three top/left pixels.
+			for j := 1; j < alphaStride; j++ {
+				c := int(alpha[i+j-alphaStride-1])
+				b := int(alpha[i+j-alphaStride])
+				a := int(alpha[i+j-1])
+				x := a + b - c
+				if x < 0 {
+					x = 0
+				} else if x > 255 {
+					x = 255
+				}
+				alpha[i+j] += uint8(x)
+			}
+		}
+	}
+}
+
+// Decode reads a WebP image from r and returns it as an [image.Image].
+// For an animated image, Decode returns the first frame, whose bounds
+// give its position on the canvas.
+func Decode(r io.Reader) (image.Image, error) {
+	var d decoder
+	if err := d.decode(r, false, false); err != nil {
+		return nil, err
+	}
+	return d.image[0], nil
+}
+
+// WEBP represents the possibly multiple images stored in a WebP file.
+type WEBP struct {
+	// Image is the successive images. Each image's bounds give its
+	// position on the canvas. A still image has a single frame.
+	Image []image.Image
+	// Delay is the successive display durations, one per frame, in
+	// milliseconds.
+	Delay []int
+	// Disposal is the successive disposal methods, one per frame.
+	Disposal []byte
+	// Blend is the successive blending methods, one per frame.
+	Blend []byte
+	// LoopCount controls the number of times an animation will be
+	// played. A LoopCount of 0 means to loop forever.
+	LoopCount int
+	// BackgroundColor is the suggested canvas color, for use with the
+	// DisposalBackground disposal method.
+	BackgroundColor color.NRGBA
+	// Config is the color model and the dimensions of the canvas.
+	Config image.Config
+}
+
+// DecodeAll reads a WebP image from r and returns the sequential frames
+// and timing information.
+func DecodeAll(r io.Reader) (*WEBP, error) {
+	var d decoder
+	if err := d.decode(r, false, true); err != nil {
+		return nil, err
+	}
+	return &WEBP{
+		Image:           d.image,
+		Delay:           d.delay,
+		Disposal:        d.disposal,
+		Blend:           d.blend,
+		LoopCount:       d.loopCount,
+		BackgroundColor: d.backgroundColor,
+		Config:          d.config,
+	}, nil
+}
+
+// DecodeConfig returns the color model and dimensions of a WebP image
+// without decoding the entire image. For an animated image, these are
+// the dimensions of the canvas.
+func DecodeConfig(r io.Reader) (image.Config, error) {
+	var d decoder
+	if err := d.decode(r, true, false); err != nil {
+		return image.Config{}, err
+	}
+	return d.config, nil
+}
+
+func init() {
+	image.RegisterFormat("webp", "RIFF????WEBPVP8", Decode, DecodeConfig)
+}
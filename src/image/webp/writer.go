@@ -0,0 +1,46 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webp
+
+import (
+	"bytes"
+	"image"
+	"image/webp/internal/vp8l"
+	"io"
+)
+
+// Encode writes the Image m to w in WebP format. The image is compressed
+// losslessly, using the VP8L format. Its width and height must each be
+// between 1 and 16384 pixels.
+func Encode(w io.Writer, m image.Image) error {
+	var data bytes.Buffer
+	if err := vp8l.Encode(&data, m); err != nil {
+		return err
+	}
+	n := uint32(data.Len())
+	padded := n + n&1
+
+	var header [20]byte
+	copy(header[0:], "RIFF")
+	putU32(header[4:], 4+8+padded)
+	copy(header[8:], "WEBPVP8L")
+	putU32(header[16:], n)
+	if n&1 != 0 {
+		data.WriteByte(0)
+	}
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data.Bytes())
+	return err
+}
+
+// putU32 encodes u as a little-endian integer in the first four bytes of b.
+func putU32(b []byte, u uint32) {
+	b[0] = uint8(u)
+	b[1] = uint8(u >> 8)
+	b[2] = uint8(u >> 16)
+	b[3] = uint8(u >> 24)
+}
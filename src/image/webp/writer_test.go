@@ -0,0 +1,178 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webp
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math/rand/v2"
+	"os"
+	"testing"
+)
+
+// compareImages reports whether m0 and m1 have the same size and the same
+// non-premultiplied colors, ignoring the color of fully transparent pixels.
+// The images may have different origins.
+func compareImages(m0, m1 image.Image) error {
+	b0, b1 := m0.Bounds(), m1.Bounds()
+	if b0.Size() != b1.Size() {
+		return fmt.Errorf("wrong image size: want %s, got %s", b0, b1)
+	}
+	d := b1.Min.Sub(b0.Min)
+	for y := b0.Min.Y; y < b0.Max.Y; y++ {
+		for x := b0.Min.X; x < b0.Max.X; x++ {
+			c0 := color.NRGBAModel.Convert(m0.At(x, y)).(color.NRGBA)
+			c1 := color.NRGBAModel.Convert(m1.At(x+d.X, y+d.Y)).(color.NRGBA)
+			if c0.A == 0 && c1.A == 0 {
+				continue
+			}
+			if c0 != c1 {
+				return fmt.Errorf("colors differ at (%d, %d): %v vs %v", x, y, c0, c1)
+			}
+		}
+	}
+	return nil
+}
+
+func encodeDecode(m image.Image) (image.Image, error) {
+	var b bytes.Buffer
+	if err := Encode(&b, m); err != nil {
+		return nil, err
+	}
+	return Decode(&b)
+}
+
+func TestWriter(t *testing.T) {
+	names := []string{
+		"blue-purple-pink",
+		"gopher-doc.1bpp",
+		"gopher-doc.8bpp",
+		"tux",
+		"yellow_rose",
+	}
+	for _, name := range names {
+		f, err := os.Open("../testdata/" + name + ".png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m0, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m1, err := encodeDecode(m0)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if err := compareImages(m0, m1); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestWriterLossyInput(t *testing.T) {
+	f, err := os.Open("../testdata/yellow_rose.lossy-with-alpha.webp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	m0, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m1, err := encodeDecode(m0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := compareImages(m0, m1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriterImageTypes(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	rect := image.Rect(3, -2, 40, 31)
+	nrgba := image.NewNRGBA(rect)
+	rgba := image.NewRGBA(rect)
+	gray := image.NewGray(rect)
+	gray16 := image.NewGray16(rect)
+	paletted := image.NewPaletted(rect, color.Palette{
+		color.NRGBA{0, 0, 0, 0},
+		color.NRGBA{0xff, 0, 0, 0xff},
+		color.NRGBA{0, 0x80, 0xff, 0x40},
+	})
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			v := r.Uint32()
+			nrgba.SetNRGBA(x, y, color.NRGBA{uint8(v), uint8(v >> 8), uint8(v >> 16), uint8(v >> 24)})
+			rgba.SetRGBA(x, y, color.RGBA{uint8(x * 4), uint8(y * 4), uint8(x + y), 0xff})
+			gray.SetGray(x, y, color.Gray{uint8(x * y)})
+			gray16.SetGray16(x, y, color.Gray16{uint16(v)})
+			paletted.SetColorIndex(x, y, uint8(v%3))
+		}
+	}
+	for _, m0 := range []image.Image{nrgba, rgba, gray, gray16, paletted} {
+		m1, err := encodeDecode(m0)
+		if err != nil {
+			t.Errorf("%T: %v", m0, err)
+			continue
+		}
+		if err := compareImages(m0, m1); err != nil {
+			t.Errorf("%T: %v", m0, err)
+		}
+	}
+
+}
+
+func TestWriterSizes(t *testing.T) {
+	for _, size := range []image.Point{{1, 1}, {1, 7}, {7, 1}, {16, 16}, {17, 33}, {300, 2}} {
+		m0 := image.NewNRGBA(image.Rect(0, 0, size.X, size.Y))
+		for i := range m0.Pix {
+			m0.Pix[i] = uint8(i * 7 / 5)
+		}
+		m1, err := encodeDecode(m0)
+		if err != nil {
+			t.Errorf("%v: %v", size, err)
+			continue
+		}
+		if err := compareImages(m0, m1); err != nil {
+			t.Errorf("%v: %v", size, err)
+		}
+	}
+}
+
+func TestWriterInvalidSize(t *testing.T) {
+	for _, r := range []image.Rectangle{
+		image.Rect(0, 0, 0, 0),
+		image.Rect(0, 0, 0, 10),
+		image.Rect(0, 0, 16385, 1),
+	} {
+		if err := Encode(new(bytes.Buffer), image.NewNRGBA(r)); err == nil {
+			t.Errorf("%v: got nil error, want non-nil", r)
+		}
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	f, err := os.Open("../testdata/yellow_rose.png")
+	if err != nil {
+		b.Fatal(err)
+	}
+	m, err := png.Decode(f)
+	f.Close()
+	if err != nil {
+		b.Fatal(err)
+	}
+	bounds := m.Bounds()
+	b.SetBytes(int64(bounds.Dx() * bounds.Dy() * 4))
+	for b.Loop() {
+		Encode(io.Discard, m)
+	}
+}
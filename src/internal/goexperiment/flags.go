@@ -128,4 +128,15 @@ type Flags struct {
 
 	// Synctest enables the testing/synctest package.
 	Synctest bool
+
+	// JSONv2 reimplements the encoding/json package on top of
+	// the encoding/json/v2 and encoding/json/jsontext packages.
+	JSONv2 bool
+
+	// SIMD enables the simd/archsimd package and the compiler's SIMD intrinsics.
+	SIMD bool
+
+	// GoroutineLeakProfile enables the goroutineleak profile in
+	// runtime/pprof and the garbage collector's goroutine leak detection.
+	GoroutineLeakProfile bool
 }
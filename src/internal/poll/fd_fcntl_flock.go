@@ -0,0 +1,20 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || (solaris && !illumos)
+
+package poll
+
+import "syscall"
+
+// FcntlFlock wraps syscall.FcntlFlock.
+func (fd *FD) FcntlFlock(cmd int, lk *syscall.Flock_t) error {
+	if err := fd.incref(); err != nil {
+		return err
+	}
+	defer fd.decref()
+	return ignoringEINTR(func() error {
+		return syscall.FcntlFlock(uintptr(fd.Sysfd), cmd, lk)
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"internal/syscall/windows"
+	"syscall"
+)
+
+const allBytes = ^uint32(0)
+
+// LockFileEx locks the whole file by calling windows.LockFileEx.
+func (fd *FD) LockFileEx(flags uint32) error {
+	if err := fd.incref(); err != nil {
+		return err
+	}
+	defer fd.decref()
+	// LockFileEx requires an OVERLAPPED structure, which contains
+	// the file offset of the beginning of the lock range.
+	// We want to lock the entire file, so we leave the offset as zero.
+	ol := new(syscall.Overlapped)
+	return windows.LockFileEx(fd.Sysfd, flags, 0, allBytes, allBytes, ol)
+}
+
+// UnlockFileEx unlocks the whole file by calling windows.UnlockFileEx.
+func (fd *FD) UnlockFileEx() error {
+	if err := fd.incref(); err != nil {
+		return err
+	}
+	defer fd.decref()
+	ol := new(syscall.Overlapped)
+	return windows.UnlockFileEx(fd.Sysfd, 0, allBytes, allBytes, ol)
+}
@@ -5,6 +5,10 @@
 package syscall
 
 const (
+	AT_FDCWD = -0x64
+
+	ENOENT = 0x2
+
 	EPOLLIN       = 0x1
 	EPOLLOUT      = 0x4
 	EPOLLERR      = 0x8
@@ -16,4 +20,7 @@ const (
 	EPOLL_CTL_DEL = 0x2
 	EPOLL_CTL_MOD = 0x3
 	EFD_CLOEXEC   = 0x80000
+
+	O_RDONLY  = 0x0
+	O_CLOEXEC = 0x80000
 )
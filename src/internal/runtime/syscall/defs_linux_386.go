@@ -12,8 +12,13 @@ const (
 	SYS_EPOLL_CREATE1 = 329
 	SYS_EPOLL_PWAIT2  = 441
 	SYS_EVENTFD2      = 328
+	SYS_CLOSE         = 6
+	SYS_OPENAT        = 295
+	SYS_PREAD64       = 180
+	SYS_READ          = 3
 
 	EFD_NONBLOCK = 0x800
+	O_LARGEFILE  = 0x8000
 )
 
 type EpollEvent struct {
@@ -12,8 +12,13 @@ const (
 	SYS_EPOLL_CREATE1 = 291
 	SYS_EPOLL_PWAIT2  = 441
 	SYS_EVENTFD2      = 290
+	SYS_CLOSE         = 3
+	SYS_OPENAT        = 257
+	SYS_PREAD64       = 17
+	SYS_READ          = 0
 
 	EFD_NONBLOCK = 0x800
+	O_LARGEFILE  = 0x0
 )
 
 type EpollEvent struct {
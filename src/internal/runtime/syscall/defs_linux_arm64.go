@@ -12,8 +12,13 @@ const (
 	SYS_MPROTECT      = 226
 	SYS_EPOLL_PWAIT2  = 441
 	SYS_EVENTFD2      = 19
+	SYS_CLOSE         = 57
+	SYS_OPENAT        = 56
+	SYS_PREAD64       = 67
+	SYS_READ          = 63
 
 	EFD_NONBLOCK = 0x800
+	O_LARGEFILE  = 0x0
 )
 
 type EpollEvent struct {
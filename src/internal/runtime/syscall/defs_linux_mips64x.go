@@ -14,8 +14,13 @@ const (
 	SYS_EPOLL_CREATE1 = 5285
 	SYS_EPOLL_PWAIT2  = 5441
 	SYS_EVENTFD2      = 5284
+	SYS_CLOSE         = 5003
+	SYS_OPENAT        = 5247
+	SYS_PREAD64       = 5016
+	SYS_READ          = 5000
 
 	EFD_NONBLOCK = 0x80
+	O_LARGEFILE  = 0x0
 )
 
 type EpollEvent struct {
@@ -14,8 +14,13 @@ const (
 	SYS_EPOLL_CREATE1 = 4326
 	SYS_EPOLL_PWAIT2  = 4441
 	SYS_EVENTFD2      = 4325
+	SYS_CLOSE         = 4006
+	SYS_OPENAT        = 4288
+	SYS_PREAD64       = 4200
+	SYS_READ          = 4003
 
 	EFD_NONBLOCK = 0x80
+	O_LARGEFILE  = 0x2000
 )
 
 type EpollEvent struct {
@@ -12,6 +12,10 @@ const (
 	fstatatTrap    uintptr = syscall.SYS_FSTATAT
 	readlinkatTrap uintptr = syscall.SYS_READLINKAT
 	mkdiratTrap    uintptr = syscall.SYS_MKDIRAT
+	fchownatTrap   uintptr = syscall.SYS_FCHOWNAT
+	renameatTrap   uintptr = syscall.SYS_RENAMEAT
+	linkatTrap     uintptr = syscall.SYS_LINKAT
+	symlinkatTrap  uintptr = syscall.SYS_SYMLINKAT
 
 	AT_EACCESS          = 0x4
 	AT_FDCWD            = 0xfffafdcd
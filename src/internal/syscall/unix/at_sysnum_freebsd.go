@@ -19,4 +19,8 @@ const (
 	posixFallocateTrap uintptr = syscall.SYS_POSIX_FALLOCATE
 	readlinkatTrap     uintptr = syscall.SYS_READLINKAT
 	mkdiratTrap        uintptr = syscall.SYS_MKDIRAT
+	fchownatTrap       uintptr = syscall.SYS_FCHOWNAT
+	renameatTrap       uintptr = syscall.SYS_RENAMEAT
+	linkatTrap         uintptr = syscall.SYS_LINKAT
+	symlinkatTrap      uintptr = syscall.SYS_SYMLINKAT
 )
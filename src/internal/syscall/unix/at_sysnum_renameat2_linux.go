@@ -0,0 +1,13 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build loong64 || riscv64
+
+package unix
+
+import "syscall"
+
+// These architectures do not provide renameat,
+// only renameat2, which accepts an additional flags argument.
+const renameatTrap uintptr = syscall.SYS_RENAMEAT2
@@ -105,6 +105,101 @@ func Mkdirat(dirfd int, path string, mode uint32) error {
 //go:noescape
 func path_create_directory(fd int32, path *byte, pathLen size) syscall.Errno
 
+func Fchmodat(dirfd int, path string, mode uint32, flags int) error {
+	// WASI preview 1 doesn't support changing file modes.
+	return syscall.ENOSYS
+}
+
+func Fchownat(dirfd int, path string, uid, gid int, flags int) error {
+	// WASI preview 1 doesn't support changing file ownership.
+	return syscall.ENOSYS
+}
+
+func Renameat(olddirfd int, oldpath string, newdirfd int, newpath string) error {
+	if oldpath == "" || newpath == "" {
+		return syscall.EINVAL
+	}
+	return errnoErr(path_rename(
+		int32(olddirfd),
+		unsafe.StringData(oldpath),
+		size(len(oldpath)),
+		int32(newdirfd),
+		unsafe.StringData(newpath),
+		size(len(newpath)),
+	))
+}
+
+//go:wasmimport wasi_snapshot_preview1 path_rename
+//go:noescape
+func path_rename(oldFd int32, oldPath *byte, oldPathLen size, newFd int32, newPath *byte, newPathLen size) syscall.Errno
+
+func Linkat(olddirfd int, oldpath string, newdirfd int, newpath string, flags int) error {
+	if oldpath == "" || newpath == "" {
+		return syscall.EINVAL
+	}
+	return errnoErr(path_link(
+		int32(olddirfd),
+		0,
+		unsafe.StringData(oldpath),
+		size(len(oldpath)),
+		int32(newdirfd),
+		unsafe.StringData(newpath),
+		size(len(newpath)),
+	))
+}
+
+//go:wasmimport wasi_snapshot_preview1 path_link
+//go:noescape
+func path_link(oldFd int32, oldFlags uint32, oldPath *byte, oldPathLen size, newFd int32, newPath *byte, newPathLen size) syscall.Errno
+
+func Symlinkat(oldpath string, newdirfd int, newpath string) error {
+	if oldpath == "" || newpath == "" {
+		return syscall.EINVAL
+	}
+	return errnoErr(path_symlink(
+		unsafe.StringData(oldpath),
+		size(len(oldpath)),
+		int32(newdirfd),
+		unsafe.StringData(newpath),
+		size(len(newpath)),
+	))
+}
+
+//go:wasmimport wasi_snapshot_preview1 path_symlink
+//go:noescape
+func path_symlink(oldPath *byte, oldPathLen size, fd int32, newPath *byte, newPathLen size) syscall.Errno
+
+func Utimensat(dirfd int, path string, times *[2]syscall.Timespec, flags int) error {
+	const (
+		FILESTAT_SET_ATIM = 0x0001
+		FILESTAT_SET_MTIM = 0x0004
+	)
+	var lookupFlags uint32
+	if flags&AT_SYMLINK_NOFOLLOW == 0 {
+		lookupFlags |= syscall.LOOKUP_SYMLINK_FOLLOW
+	}
+	var fstFlags uint32
+	if times[0].Nsec != UTIME_OMIT {
+		fstFlags |= FILESTAT_SET_ATIM
+	}
+	if times[1].Nsec != UTIME_OMIT {
+		fstFlags |= FILESTAT_SET_MTIM
+	}
+	return errnoErr(path_filestat_set_times(
+		int32(dirfd),
+		lookupFlags,
+		unsafe.StringData(path),
+		size(len(path)),
+		uint64(syscall.TimespecToNsec(times[0])),
+		uint64(syscall.TimespecToNsec(times[1])),
+		fstFlags,
+	))
+}
+
+//go:wasmimport wasi_snapshot_preview1 path_filestat_set_times
+//go:noescape
+func path_filestat_set_times(fd int32, flags uint32, path *byte, pathLen size, atim uint64, mtim uint64, fstflags uint32) syscall.Errno
+
 func errnoErr(errno syscall.Errno) error {
 	if errno == 0 {
 		return nil
@@ -0,0 +1,16 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "syscall"
+
+// Fchmodat is syscall.Fchmodat.
+//
+// Flags are only supported on kernels which provide fchmodat2.
+// On older kernels, Fchmodat returns EOPNOTSUPP when passed
+// AT_SYMLINK_NOFOLLOW.
+func Fchmodat(dirfd int, path string, mode uint32, flags int) error {
+	return syscall.Fchmodat(dirfd, path, mode, flags)
+}
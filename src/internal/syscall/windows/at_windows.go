@@ -9,6 +9,12 @@ import (
 	"unsafe"
 )
 
+// Information classes used with NtSetInformationFile.
+const (
+	FileDispositionInformation   = 13
+	FileDispositionInformationEx = 64
+)
+
 // Openat flags not supported by syscall.Open.
 //
 // These are invented values.
@@ -18,6 +24,7 @@ import (
 // to avoid overlap.
 const (
 	O_DIRECTORY    = 0x100000   // target must be a directory
+	O_WRITE_ATTRS  = 0x10000000 // FILE_WRITE_ATTRIBUTES, used by Chmod and Chtimes
 	O_NOFOLLOW_ANY = 0x20000000 // disallow symlinks anywhere in the path
 	O_OPEN_REPARSE = 0x40000000 // FILE_OPEN_REPARSE_POINT, used by Lstat
 )
@@ -58,6 +65,9 @@ func Openat(dirfd syscall.Handle, name string, flag int, perm uint32) (_ syscall
 		options |= FILE_DIRECTORY_FILE
 		access |= FILE_LIST_DIRECTORY
 	}
+	if flag&O_WRITE_ATTRS != 0 {
+		access |= FILE_WRITE_ATTRIBUTES
+	}
 	if flag&syscall.O_SYNC != 0 {
 		options |= FILE_WRITE_THROUGH
 	}
@@ -201,11 +211,6 @@ func Deleteat(dirfd syscall.Handle, name string) error {
 	}
 	defer syscall.CloseHandle(h)
 
-	const (
-		FileDispositionInformation   = 13
-		FileDispositionInformationEx = 64
-	)
-
 	// First, attempt to delete the file using POSIX semantics
 	// (which permit a file to be deleted while it is still open).
 	// This matches the behavior of DeleteFileW.
@@ -250,3 +255,233 @@ func Deleteat(dirfd syscall.Handle, name string) error {
 	}
 	return err
 }
+
+// Renameat renames oldpath in olddirfd to newpath in newdirfd.
+// If newpath exists and is not a directory, it is replaced.
+func Renameat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle, newpath string) error {
+	objAttrs := &OBJECT_ATTRIBUTES{}
+	if err := objAttrs.init(olddirfd, oldpath); err != nil {
+		return err
+	}
+	var h syscall.Handle
+	err := NtOpenFile(
+		&h,
+		SYNCHRONIZE|DELETE,
+		objAttrs,
+		&IO_STATUS_BLOCK{},
+		FILE_SHARE_DELETE|FILE_SHARE_READ|FILE_SHARE_WRITE,
+		FILE_OPEN_REPARSE_POINT|FILE_OPEN_FOR_BACKUP_INTENT|FILE_SYNCHRONOUS_IO_NONALERT,
+	)
+	if err != nil {
+		return ntCreateFileError(err, 0)
+	}
+	defer syscall.CloseHandle(h)
+
+	const (
+		FileRenameInformation   = 10
+		FileRenameInformationEx = 65
+	)
+
+	renameInfo := FILE_RENAME_INFORMATION{
+		RootDirectory: newdirfd,
+	}
+	if err := setFileName(&renameInfo.FileName, &renameInfo.FileNameLength, newpath); err != nil {
+		return err
+	}
+
+	// First, attempt to rename the file using POSIX semantics
+	// (which permit replacing a file which is still open).
+	// This matches the behavior of os.Rename.
+	renameInfo.Flags = FILE_RENAME_REPLACE_IF_EXISTS | FILE_RENAME_POSIX_SEMANTICS
+	err = NtSetInformationFile(
+		h,
+		&IO_STATUS_BLOCK{},
+		uintptr(unsafe.Pointer(&renameInfo)),
+		uint32(unsafe.Sizeof(FILE_RENAME_INFORMATION{})),
+		FileRenameInformationEx,
+	)
+	if err == nil {
+		return nil
+	}
+	if st, ok := err.(NTStatus); ok && !isUnsupportedInfoClass(st) {
+		return st.Errno()
+	}
+
+	// The filesystem doesn't support FileRenameInformationEx
+	// (for example, FAT). Try again.
+	renameInfo.Flags = 1 // ReplaceIfExists
+	err = NtSetInformationFile(
+		h,
+		&IO_STATUS_BLOCK{},
+		uintptr(unsafe.Pointer(&renameInfo)),
+		uint32(unsafe.Sizeof(FILE_RENAME_INFORMATION{})),
+		FileRenameInformation,
+	)
+	if st, ok := err.(NTStatus); ok {
+		return st.Errno()
+	}
+	return err
+}
+
+// Linkat creates newpath in newdirfd as a hard link to oldpath in olddirfd.
+// It does not follow a symlink in the final component of oldpath.
+func Linkat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle, newpath string) error {
+	objAttrs := &OBJECT_ATTRIBUTES{}
+	if err := objAttrs.init(olddirfd, oldpath); err != nil {
+		return err
+	}
+	var h syscall.Handle
+	err := NtOpenFile(
+		&h,
+		SYNCHRONIZE|FILE_WRITE_ATTRIBUTES,
+		objAttrs,
+		&IO_STATUS_BLOCK{},
+		FILE_SHARE_DELETE|FILE_SHARE_READ|FILE_SHARE_WRITE,
+		FILE_OPEN_REPARSE_POINT|FILE_OPEN_FOR_BACKUP_INTENT|FILE_SYNCHRONOUS_IO_NONALERT,
+	)
+	if err != nil {
+		return ntCreateFileError(err, 0)
+	}
+	defer syscall.CloseHandle(h)
+
+	const FileLinkInformation = 11
+
+	linkInfo := FILE_LINK_INFORMATION{
+		RootDirectory: newdirfd,
+	}
+	if err := setFileName(&linkInfo.FileName, &linkInfo.FileNameLength, newpath); err != nil {
+		return err
+	}
+	err = NtSetInformationFile(
+		h,
+		&IO_STATUS_BLOCK{},
+		uintptr(unsafe.Pointer(&linkInfo)),
+		uint32(unsafe.Sizeof(FILE_LINK_INFORMATION{})),
+		FileLinkInformation,
+	)
+	if st, ok := err.(NTStatus); ok {
+		return st.Errno()
+	}
+	return err
+}
+
+// SYMLINK_FLAG_DIRECTORY is an invented flag for Symlinkat
+// indicating that the link should be a directory link.
+const SYMLINK_FLAG_DIRECTORY = 0x1
+
+// Symlinkat creates newpath in newdirfd as a symbolic link to oldpath.
+//
+// Unlike CreateSymbolicLink, Symlinkat requires the SeCreateSymbolicLinkPrivilege
+// privilege even when developer mode is enabled.
+func Symlinkat(oldpath string, newdirfd syscall.Handle, newpath string, flags uint32) error {
+	// Construct the reparse data buffer before creating the link,
+	// so we don't need to clean up after an invalid target.
+	target, err := syscall.UTF16FromString(oldpath)
+	if err != nil {
+		return err
+	}
+	target = target[:len(target)-1] // trim trailing NUL
+	var linkFlags uint32
+	if !isAbs(oldpath) {
+		linkFlags |= SYMLINK_FLAG_RELATIVE
+	}
+	// The substitute name and print name are both the link target.
+	nameLen := len(target) * 2
+	hdrSize := unsafe.Sizeof(REPARSE_DATA_BUFFER_HEADER{})
+	rbSize := unsafe.Offsetof(SymbolicLinkReparseBuffer{}.PathBuffer)
+	buf := make([]byte, int(hdrSize)+int(rbSize)+2*nameLen)
+	if len(buf) > syscall.MAXIMUM_REPARSE_DATA_BUFFER_SIZE {
+		return ERROR_FILENAME_EXCED_RANGE
+	}
+	hdr := (*REPARSE_DATA_BUFFER_HEADER)(unsafe.Pointer(&buf[0]))
+	hdr.ReparseTag = syscall.IO_REPARSE_TAG_SYMLINK
+	hdr.ReparseDataLength = uint16(len(buf) - int(hdrSize))
+	rb := (*SymbolicLinkReparseBuffer)(unsafe.Pointer(&buf[hdrSize]))
+	rb.SubstituteNameOffset = 0
+	rb.SubstituteNameLength = uint16(nameLen)
+	rb.PrintNameOffset = uint16(nameLen)
+	rb.PrintNameLength = uint16(nameLen)
+	rb.Flags = linkFlags
+	pathBuf := unsafe.Slice((*uint16)(unsafe.Pointer(&rb.PathBuffer[0])), 2*len(target))
+	copy(pathBuf, target)
+	copy(pathBuf[len(target):], target)
+
+	options := uint32(FILE_NON_DIRECTORY_FILE)
+	if flags&SYMLINK_FLAG_DIRECTORY != 0 {
+		options = FILE_DIRECTORY_FILE
+	}
+	objAttrs := &OBJECT_ATTRIBUTES{}
+	if err := objAttrs.init(newdirfd, newpath); err != nil {
+		return err
+	}
+	var h syscall.Handle
+	err = NtCreateFile(
+		&h,
+		SYNCHRONIZE|FILE_WRITE_ATTRIBUTES|DELETE,
+		objAttrs,
+		&IO_STATUS_BLOCK{},
+		nil,
+		syscall.FILE_ATTRIBUTE_NORMAL,
+		0,
+		FILE_CREATE,
+		FILE_OPEN_REPARSE_POINT|FILE_OPEN_FOR_BACKUP_INTENT|FILE_SYNCHRONOUS_IO_NONALERT|options,
+		0,
+		0,
+	)
+	if err != nil {
+		return ntCreateFileError(err, 0)
+	}
+	defer syscall.CloseHandle(h)
+
+	var bytesReturned uint32
+	err = syscall.DeviceIoControl(h, FSCTL_SET_REPARSE_POINT, &buf[0], uint32(len(buf)), nil, 0, &bytesReturned, nil)
+	if err != nil {
+		// Remove the file we just created.
+		NtSetInformationFile(
+			h,
+			&IO_STATUS_BLOCK{},
+			uintptr(unsafe.Pointer(&FILE_DISPOSITION_INFORMATION{
+				DeleteFile: true,
+			})),
+			uint32(unsafe.Sizeof(FILE_DISPOSITION_INFORMATION{})),
+			FileDispositionInformation,
+		)
+		return err
+	}
+	return nil
+}
+
+// setFileName sets the FileName and FileNameLength fields of a
+// FILE_RENAME_INFORMATION or FILE_LINK_INFORMATION to name.
+func setFileName(buf *[syscall.MAX_PATH]uint16, length *uint32, name string) error {
+	name16, err := syscall.UTF16FromString(name)
+	if err != nil {
+		return err
+	}
+	name16 = name16[:len(name16)-1] // trim trailing NUL
+	if len(name16) > len(buf) {
+		return ERROR_FILENAME_EXCED_RANGE
+	}
+	copy(buf[:], name16)
+	*length = uint32(len(name16) * 2)
+	return nil
+}
+
+// isUnsupportedInfoClass reports whether st indicates that
+// an information class is not supported by the filesystem.
+func isUnsupportedInfoClass(st NTStatus) bool {
+	switch st {
+	case STATUS_INVALID_INFO_CLASS, STATUS_INVALID_PARAMETER, STATUS_NOT_SUPPORTED:
+		return true
+	}
+	return false
+}
+
+// isAbs reports whether path is absolute or volume-relative,
+// and so cannot be stored as a relative symlink.
+func isAbs(path string) bool {
+	if len(path) >= 2 && path[1] == ':' {
+		return true
+	}
+	return len(path) > 0 && (path[0] == '\\' || path[0] == '/')
+}
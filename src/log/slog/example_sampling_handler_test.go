@@ -0,0 +1,34 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"log/slog"
+	"log/slog/internal/slogtest"
+	"os"
+	"time"
+)
+
+// This example shows how to use a SamplingHandler to limit the number
+// of repetitive records that reach a handler.
+func ExampleSamplingHandler() {
+	th := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: slogtest.RemoveTime})
+	logger := slog.New(slog.NewSamplingHandler(th, &slog.SamplingOptions{
+		Interval:   time.Minute,
+		First:      2,
+		Thereafter: 5,
+	}))
+
+	for i := range 10 {
+		logger.Warn("queue full", "attempt", i)
+	}
+	logger.Error("giving up")
+
+	// Output:
+	// level=WARN msg="queue full" attempt=0
+	// level=WARN msg="queue full" attempt=1
+	// level=WARN msg="queue full" attempt=6
+	// level=ERROR msg="giving up"
+}
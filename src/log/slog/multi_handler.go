@@ -0,0 +1,70 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"context"
+	"errors"
+)
+
+// NewMultiHandler creates a [MultiHandler] with the given Handlers.
+func NewMultiHandler(handlers ...Handler) *MultiHandler {
+	h := make([]Handler, len(handlers))
+	copy(h, handlers)
+	return &MultiHandler{multi: h}
+}
+
+// MultiHandler is a [Handler] that invokes all the given Handlers.
+// Its Enabled method reports whether any of the handlers' Enabled methods return true.
+// Its Handle method calls Handle on each of the handlers that is enabled for the record's level.
+// Its WithAttrs and WithGroup methods call the corresponding method on every handler.
+type MultiHandler struct {
+	multi []Handler
+}
+
+// Enabled reports whether any of h's handlers is enabled at the given level.
+func (h *MultiHandler) Enabled(ctx context.Context, l Level) bool {
+	for i := range h.multi {
+		if h.multi[i].Enabled(ctx, l) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes a clone of r to each of h's handlers that is enabled
+// at the level of r. It returns the errors from those handlers,
+// combined with [errors.Join].
+func (h *MultiHandler) Handle(ctx context.Context, r Record) error {
+	var errs []error
+	for i := range h.multi {
+		if h.multi[i].Enabled(ctx, r.Level) {
+			if err := h.multi[i].Handle(ctx, r.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new [MultiHandler] whose handlers are the results
+// of calling WithAttrs on each of h's handlers.
+func (h *MultiHandler) WithAttrs(attrs []Attr) Handler {
+	handlers := make([]Handler, 0, len(h.multi))
+	for i := range h.multi {
+		handlers = append(handlers, h.multi[i].WithAttrs(attrs))
+	}
+	return &MultiHandler{multi: handlers}
+}
+
+// WithGroup returns a new [MultiHandler] whose handlers are the results
+// of calling WithGroup on each of h's handlers.
+func (h *MultiHandler) WithGroup(name string) Handler {
+	handlers := make([]Handler, 0, len(h.multi))
+	for i := range h.multi {
+		handlers = append(handlers, h.multi[i].WithGroup(name))
+	}
+	return &MultiHandler{multi: handlers}
+}
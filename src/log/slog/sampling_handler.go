@@ -0,0 +1,135 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SamplingOptions are options for a [SamplingHandler].
+// A zero SamplingOptions passes the first record with each level
+// and message in every second, and drops the rest.
+type SamplingOptions struct {
+	// Interval is the length of each sampling period.
+	// If Interval is zero, it defaults to one second.
+	Interval time.Duration
+
+	// First is the number of records with the same level and message
+	// that are passed on in each interval.
+	// If First is zero, it defaults to one.
+	First int
+
+	// Thereafter, if positive, passes on every Thereafter-th record
+	// with the same level and message after the first First records
+	// in an interval. If Thereafter is zero, those records are dropped.
+	Thereafter int
+}
+
+// A SamplingHandler is a [Handler] that limits the rate of records
+// passed to another Handler. It counts the records with each level and
+// message during an interval, and drops those that exceed the limits
+// given by its [SamplingOptions].
+// Attributes are not taken into account when counting records.
+//
+// The interval of a record is determined by its time. Records with a
+// zero time are counted in the interval of the current time, and records
+// whose time is before the start of the current interval are counted in it.
+// Counts are reset at the start of each interval, so the memory used
+// by a SamplingHandler is proportional to the number of distinct
+// messages logged in one interval.
+//
+// Handlers returned by [SamplingHandler.WithAttrs] and
+// [SamplingHandler.WithGroup] share their counts with the original.
+type SamplingHandler struct {
+	handler Handler
+	s       *sampler
+}
+
+// NewSamplingHandler returns a [SamplingHandler] that passes the records
+// that are not dropped to h.
+// If opts is nil, the default options are used.
+func NewSamplingHandler(h Handler, opts *SamplingOptions) *SamplingHandler {
+	if opts == nil {
+		opts = &SamplingOptions{}
+	}
+	s := &sampler{opts: *opts}
+	if s.opts.Interval <= 0 {
+		s.opts.Interval = time.Second
+	}
+	if s.opts.First <= 0 {
+		s.opts.First = 1
+	}
+	return &SamplingHandler{handler: h, s: s}
+}
+
+// Enabled reports whether the wrapped handler is enabled at the given level.
+func (h *SamplingHandler) Enabled(ctx context.Context, level Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle passes r to the wrapped handler unless the number of records
+// with the same level and message in the current interval exceeds
+// the limits of h. Dropped records are not reported as errors.
+func (h *SamplingHandler) Handle(ctx context.Context, r Record) error {
+	if !h.s.sample(r) {
+		return nil
+	}
+	return h.handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new [SamplingHandler] that wraps the result of
+// calling WithAttrs on h's handler, and shares h's counts.
+func (h *SamplingHandler) WithAttrs(attrs []Attr) Handler {
+	return &SamplingHandler{handler: h.handler.WithAttrs(attrs), s: h.s}
+}
+
+// WithGroup returns a new [SamplingHandler] that wraps the result of
+// calling WithGroup on h's handler, and shares h's counts.
+func (h *SamplingHandler) WithGroup(name string) Handler {
+	return &SamplingHandler{handler: h.handler.WithGroup(name), s: h.s}
+}
+
+// A sampler holds the counts of records in the current interval.
+type sampler struct {
+	opts SamplingOptions
+
+	mu     sync.Mutex
+	start  time.Time // start of the current interval
+	counts map[sampleKey]int
+}
+
+type sampleKey struct {
+	level Level
+	msg   string
+}
+
+// sample counts r and reports whether it should be passed on.
+func (s *sampler) sample(r Record) bool {
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts == nil || t.Sub(s.start) >= s.opts.Interval {
+		// Start a new interval. Records from concurrent calls may arrive
+		// slightly out of order; earlier ones count in the current interval.
+		clear(s.counts)
+		if s.counts == nil {
+			s.counts = make(map[sampleKey]int)
+		}
+		s.start = t
+	}
+	k := sampleKey{r.Level, r.Message}
+	n := s.counts[k] + 1
+	s.counts[k] = n
+	if n <= s.opts.First {
+		return true
+	}
+	return s.opts.Thereafter > 0 && (n-s.opts.First)%s.opts.Thereafter == 0
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		name string
+		opts *SamplingOptions
+		// Each record is logged at start plus the given offset.
+		offsets []time.Duration
+		want    []int // indexes of records passed on
+	}{
+		{
+			name:    "default",
+			opts:    nil,
+			offsets: []time.Duration{0, 1, 2, time.Second, time.Second + 1},
+			want:    []int{0, 3},
+		},
+		{
+			name:    "first",
+			opts:    &SamplingOptions{First: 2},
+			offsets: []time.Duration{0, 1, 2, 3},
+			want:    []int{0, 1},
+		},
+		{
+			name:    "thereafter",
+			opts:    &SamplingOptions{First: 2, Thereafter: 3},
+			offsets: []time.Duration{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			want:    []int{0, 1, 4, 7},
+		},
+		{
+			name:    "interval",
+			opts:    &SamplingOptions{Interval: time.Minute},
+			offsets: []time.Duration{0, 30 * time.Second, time.Minute, 90 * time.Second, 2*time.Minute + 1},
+			want:    []int{0, 2, 4},
+		},
+		{
+			name:    "out of order",
+			opts:    &SamplingOptions{Interval: time.Minute},
+			offsets: []time.Duration{time.Minute, 0, 2 * time.Minute},
+			want:    []int{0, 2},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var got []int
+			h := NewSamplingHandler(&funcHandler{func(r Record) {
+				got = append(got, slices.Index(test.offsets, r.Time.Sub(start)))
+			}}, test.opts)
+			for _, off := range test.offsets {
+				r := NewRecord(start.Add(off), LevelInfo, "msg", 0)
+				if err := h.Handle(context.Background(), r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("passed records %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// funcHandler is a Handler that calls f for each record.
+type funcHandler struct {
+	f func(Record)
+}
+
+func (h *funcHandler) Enabled(context.Context, Level) bool { return true }
+func (h *funcHandler) WithAttrs([]Attr) Handler            { return h }
+func (h *funcHandler) WithGroup(string) Handler            { return h }
+func (h *funcHandler) Handle(_ context.Context, r Record) error {
+	h.f(r)
+	return nil
+}
+
+func TestSamplingHandlerKeys(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewSamplingHandler(NewTextHandler(&buf, &HandlerOptions{Level: LevelDebug}), nil)
+	l := New(h)
+	for range 3 {
+		l.Info("a")
+		l.Info("b")
+		l.Warn("a")
+		// A derived logger shares the counts, and attributes are ignored.
+		l.With("k", "v").WithGroup("g").Info("a", "x", 1)
+	}
+	got := strings.Count(buf.String(), "\n")
+	if got != 3 {
+		t.Errorf("got %d lines, want 3:\n%s", got, buf.String())
+	}
+	checkLogOutput(t, strings.Split(buf.String(), "\n")[2], "time="+textTimeRE+` level=WARN msg=a`)
+}
+
+func TestSamplingHandlerEnabled(t *testing.T) {
+	h := NewSamplingHandler(NewTextHandler(&bytes.Buffer{}, &HandlerOptions{Level: LevelWarn}), nil)
+	ctx := context.Background()
+	if h.Enabled(ctx, LevelInfo) {
+		t.Error("Enabled(LevelInfo) = true, want false")
+	}
+	if !h.Enabled(ctx, LevelError) {
+		t.Error("Enabled(LevelError) = false, want true")
+	}
+}
+
+func TestSamplingHandlerConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	n := 0
+	h := NewSamplingHandler(&funcHandler{func(Record) {
+		mu.Lock()
+		n++
+		mu.Unlock()
+	}}, &SamplingOptions{Interval: time.Hour, First: 10})
+	l := New(h)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				l.Info("msg")
+			}
+		}()
+	}
+	wg.Wait()
+	if n != 10 {
+		t.Errorf("got %d records, want 10", n)
+	}
+}
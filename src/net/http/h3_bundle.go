@@ -0,0 +1,2015 @@
+// Code generated by golang.org/x/tools/cmd/bundle. DO NOT EDIT.
+//go:generate bundle -o=h3_bundle.go -prefix=http3 -import=golang.org/x/net/internal/httpcommon=net/http/internal/httpcommon -import=golang.org/x/net/internal/quic/quicwire=net/http/internal/quicwire golang.org/x/net/internal/http3
+
+// Package http3 implements the HTTP/3 protocol.
+//
+// This package is a work in progress.
+// It is not ready for production usage.
+// Its API is subject to change without notice.
+//
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"net/http/internal/httpcommon"
+	"net/http/internal/quicwire"
+	"strconv"
+	"sync"
+
+	"golang.org/x/net/http2/hpack"
+	"golang.org/x/net/quic"
+)
+
+// A bodyWriter writes a request or response body to a stream
+// as a series of DATA frames.
+type http3bodyWriter struct {
+	st     *http3stream
+	remain int64  // -1 when content-length is not known
+	flush  bool   // flush the stream after every write
+	name   string // "request" or "response"
+}
+
+func (w *http3bodyWriter) Write(p []byte) (n int, err error) {
+	if w.remain >= 0 && int64(len(p)) > w.remain {
+		return 0, &http3streamError{
+			code:    http3errH3InternalError,
+			message: w.name + " body longer than specified content length",
+		}
+	}
+	w.st.writeVarint(int64(http3frameTypeData))
+	w.st.writeVarint(int64(len(p)))
+	n, err = w.st.Write(p)
+	if w.remain >= 0 {
+		w.remain -= int64(n)
+	}
+	if w.flush && err == nil {
+		err = w.st.Flush()
+	}
+	if err != nil {
+		err = fmt.Errorf("writing %v body: %w", w.name, err)
+	}
+	return n, err
+}
+
+func (w *http3bodyWriter) Close() error {
+	if w.remain > 0 {
+		return errors.New(w.name + " body shorter than specified content length")
+	}
+	return nil
+}
+
+// A bodyReader reads a request or response body from a stream.
+type http3bodyReader struct {
+	st *http3stream
+
+	mu     sync.Mutex
+	remain int64
+	err    error
+}
+
+func (r *http3bodyReader) Read(p []byte) (n int, err error) {
+	// The HTTP/1 and HTTP/2 implementations both permit concurrent reads from a body,
+	// in the sense that the race detector won't complain.
+	// Use a mutex here to provide the same behavior.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return 0, r.err
+	}
+	defer func() {
+		if err != nil {
+			r.err = err
+		}
+	}()
+	if r.st.lim == 0 {
+		// We've finished reading the previous DATA frame, so end it.
+		if err := r.st.endFrame(); err != nil {
+			return 0, err
+		}
+	}
+	// Read the next DATA frame header,
+	// if we aren't already in the middle of one.
+	for r.st.lim < 0 {
+		ftype, err := r.st.readFrameHeader()
+		if err == io.EOF && r.remain > 0 {
+			return 0, &http3streamError{
+				code:    http3errH3MessageError,
+				message: "body shorter than content-length",
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch ftype {
+		case http3frameTypeData:
+			if r.remain >= 0 && r.st.lim > r.remain {
+				return 0, &http3streamError{
+					code:    http3errH3MessageError,
+					message: "body longer than content-length",
+				}
+			}
+			// Fall out of the loop and process the frame body below.
+		case http3frameTypeHeaders:
+			// This HEADERS frame contains the message trailers.
+			if r.remain > 0 {
+				return 0, &http3streamError{
+					code:    http3errH3MessageError,
+					message: "body shorter than content-length",
+				}
+			}
+			// TODO: Fill in Request.Trailer.
+			if err := r.st.discardFrame(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		default:
+			if err := r.st.discardUnknownFrame(ftype); err != nil {
+				return 0, err
+			}
+		}
+	}
+	// We are now reading the content of a DATA frame.
+	// Fill the read buffer or read to the end of the frame,
+	// whichever comes first.
+	if int64(len(p)) > r.st.lim {
+		p = p[:r.st.lim]
+	}
+	n, err = r.st.Read(p)
+	if r.remain > 0 {
+		r.remain -= int64(n)
+	}
+	return n, err
+}
+
+func (r *http3bodyReader) Close() error {
+	// Unlike the HTTP/1 and HTTP/2 body readers (at the time of this comment being written),
+	// calling Close concurrently with Read will interrupt the read.
+	r.st.stream.CloseRead()
+	return nil
+}
+
+type http3streamHandler interface {
+	handleControlStream(*http3stream) error
+	handlePushStream(*http3stream) error
+	handleEncoderStream(*http3stream) error
+	handleDecoderStream(*http3stream) error
+	handleRequestStream(*http3stream) error
+	abort(error)
+}
+
+type http3genericConn struct {
+	mu sync.Mutex
+
+	// The peer may create exactly one control, encoder, and decoder stream.
+	// streamsCreated is a bitset of streams created so far.
+	// Bits are 1 << streamType.
+	streamsCreated uint8
+}
+
+func (c *http3genericConn) acceptStreams(qconn *quic.Conn, h http3streamHandler) {
+	for {
+		// Use context.Background: This blocks until a stream is accepted
+		// or the connection closes.
+		st, err := qconn.AcceptStream(context.Background())
+		if err != nil {
+			return // connection closed
+		}
+		if st.IsReadOnly() {
+			go c.handleUnidirectionalStream(http3newStream(st), h)
+		} else {
+			go c.handleRequestStream(http3newStream(st), h)
+		}
+	}
+}
+
+func (c *http3genericConn) handleUnidirectionalStream(st *http3stream, h http3streamHandler) {
+	// Unidirectional stream header: One varint with the stream type.
+	v, err := st.readVarint()
+	if err != nil {
+		h.abort(&http3connectionError{
+			code:    http3errH3StreamCreationError,
+			message: "error reading unidirectional stream header",
+		})
+		return
+	}
+	stype := http3streamType(v)
+	if err := c.checkStreamCreation(stype); err != nil {
+		h.abort(err)
+		return
+	}
+	switch stype {
+	case http3streamTypeControl:
+		err = h.handleControlStream(st)
+	case http3streamTypePush:
+		err = h.handlePushStream(st)
+	case http3streamTypeEncoder:
+		err = h.handleEncoderStream(st)
+	case http3streamTypeDecoder:
+		err = h.handleDecoderStream(st)
+	default:
+		// "Recipients of unknown stream types MUST either abort reading
+		// of the stream or discard incoming data without further processing."
+		// https://www.rfc-editor.org/rfc/rfc9114.html#section-6.2-7
+		//
+		// We should send the H3_STREAM_CREATION_ERROR error code,
+		// but the quic package currently doesn't allow setting error codes
+		// for STOP_SENDING frames.
+		// TODO: Should CloseRead take an error code?
+		err = nil
+	}
+	if err == io.EOF {
+		err = &http3connectionError{
+			code:    http3errH3ClosedCriticalStream,
+			message: http3streamType(stype).String() + " stream closed",
+		}
+	}
+	c.handleStreamError(st, h, err)
+}
+
+func (c *http3genericConn) handleRequestStream(st *http3stream, h http3streamHandler) {
+	c.handleStreamError(st, h, h.handleRequestStream(st))
+}
+
+func (c *http3genericConn) handleStreamError(st *http3stream, h http3streamHandler, err error) {
+	switch err := err.(type) {
+	case *http3connectionError:
+		h.abort(err)
+	case nil:
+		st.stream.CloseRead()
+		st.stream.CloseWrite()
+	case *http3streamError:
+		st.stream.CloseRead()
+		st.stream.Reset(uint64(err.code))
+	default:
+		st.stream.CloseRead()
+		st.stream.Reset(uint64(http3errH3InternalError))
+	}
+}
+
+func (c *http3genericConn) checkStreamCreation(stype http3streamType) error {
+	switch stype {
+	case http3streamTypeControl, http3streamTypeEncoder, http3streamTypeDecoder:
+		// The peer may create exactly one control, encoder, and decoder stream.
+	default:
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	bit := uint8(1) << stype
+	if c.streamsCreated&bit != 0 {
+		return &http3connectionError{
+			code:    http3errH3StreamCreationError,
+			message: "multiple " + stype.String() + " streams created",
+		}
+	}
+	c.streamsCreated |= bit
+	return nil
+}
+
+// http3Error is an HTTP/3 error code.
+type http3http3Error int
+
+const (
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-8.1
+	http3errH3NoError              = http3http3Error(0x0100)
+	http3errH3GeneralProtocolError = http3http3Error(0x0101)
+	http3errH3InternalError        = http3http3Error(0x0102)
+	http3errH3StreamCreationError  = http3http3Error(0x0103)
+	http3errH3ClosedCriticalStream = http3http3Error(0x0104)
+	http3errH3FrameUnexpected      = http3http3Error(0x0105)
+	http3errH3FrameError           = http3http3Error(0x0106)
+	http3errH3ExcessiveLoad        = http3http3Error(0x0107)
+	http3errH3IDError              = http3http3Error(0x0108)
+	http3errH3SettingsError        = http3http3Error(0x0109)
+	http3errH3MissingSettings      = http3http3Error(0x010a)
+	http3errH3RequestRejected      = http3http3Error(0x010b)
+	http3errH3RequestCancelled     = http3http3Error(0x010c)
+	http3errH3RequestIncomplete    = http3http3Error(0x010d)
+	http3errH3MessageError         = http3http3Error(0x010e)
+	http3errH3ConnectError         = http3http3Error(0x010f)
+	http3errH3VersionFallback      = http3http3Error(0x0110)
+
+	// https://www.rfc-editor.org/rfc/rfc9204.html#section-8.3
+	http3errQPACKDecompressionFailed = http3http3Error(0x0200)
+	http3errQPACKEncoderStreamError  = http3http3Error(0x0201)
+	http3errQPACKDecoderStreamError  = http3http3Error(0x0202)
+)
+
+func (e http3http3Error) Error() string {
+	switch e {
+	case http3errH3NoError:
+		return "H3_NO_ERROR"
+	case http3errH3GeneralProtocolError:
+		return "H3_GENERAL_PROTOCOL_ERROR"
+	case http3errH3InternalError:
+		return "H3_INTERNAL_ERROR"
+	case http3errH3StreamCreationError:
+		return "H3_STREAM_CREATION_ERROR"
+	case http3errH3ClosedCriticalStream:
+		return "H3_CLOSED_CRITICAL_STREAM"
+	case http3errH3FrameUnexpected:
+		return "H3_FRAME_UNEXPECTED"
+	case http3errH3FrameError:
+		return "H3_FRAME_ERROR"
+	case http3errH3ExcessiveLoad:
+		return "H3_EXCESSIVE_LOAD"
+	case http3errH3IDError:
+		return "H3_ID_ERROR"
+	case http3errH3SettingsError:
+		return "H3_SETTINGS_ERROR"
+	case http3errH3MissingSettings:
+		return "H3_MISSING_SETTINGS"
+	case http3errH3RequestRejected:
+		return "H3_REQUEST_REJECTED"
+	case http3errH3RequestCancelled:
+		return "H3_REQUEST_CANCELLED"
+	case http3errH3RequestIncomplete:
+		return "H3_REQUEST_INCOMPLETE"
+	case http3errH3MessageError:
+		return "H3_MESSAGE_ERROR"
+	case http3errH3ConnectError:
+		return "H3_CONNECT_ERROR"
+	case http3errH3VersionFallback:
+		return "H3_VERSION_FALLBACK"
+	case http3errQPACKDecompressionFailed:
+		return "QPACK_DECOMPRESSION_FAILED"
+	case http3errQPACKEncoderStreamError:
+		return "QPACK_ENCODER_STREAM_ERROR"
+	case http3errQPACKDecoderStreamError:
+		return "QPACK_DECODER_STREAM_ERROR"
+	}
+	return fmt.Sprintf("H3_ERROR_%v", int(e))
+}
+
+// A streamError is an error which terminates a stream, but not the connection.
+// https://www.rfc-editor.org/rfc/rfc9114.html#section-8-1
+type http3streamError struct {
+	code    http3http3Error
+	message string
+}
+
+func (e *http3streamError) Error() string { return e.message }
+
+func (e *http3streamError) Unwrap() error { return e.code }
+
+// A connectionError is an error which results in the entire connection closing.
+// https://www.rfc-editor.org/rfc/rfc9114.html#section-8-2
+type http3connectionError struct {
+	code    http3http3Error
+	message string
+}
+
+func (e *http3connectionError) Error() string { return e.message }
+
+func (e *http3connectionError) Unwrap() error { return e.code }
+
+// Stream types.
+//
+// For unidirectional streams, the value is the stream type sent over the wire.
+//
+// For bidirectional streams (which are always request streams),
+// the value is arbitrary and never sent on the wire.
+type http3streamType int64
+
+const (
+	// Bidirectional request stream.
+	// All bidirectional streams are request streams.
+	// This stream type is never sent over the wire.
+	//
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-6.1
+	http3streamTypeRequest = http3streamType(-1)
+
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-6.2
+	http3streamTypeControl = http3streamType(0x00)
+	http3streamTypePush    = http3streamType(0x01)
+
+	// https://www.rfc-editor.org/rfc/rfc9204.html#section-4.2
+	http3streamTypeEncoder = http3streamType(0x02)
+	http3streamTypeDecoder = http3streamType(0x03)
+)
+
+func (stype http3streamType) String() string {
+	switch stype {
+	case http3streamTypeRequest:
+		return "request"
+	case http3streamTypeControl:
+		return "control"
+	case http3streamTypePush:
+		return "push"
+	case http3streamTypeEncoder:
+		return "encoder"
+	case http3streamTypeDecoder:
+		return "decoder"
+	default:
+		return "unknown"
+	}
+}
+
+// Frame types.
+type http3frameType int64
+
+const (
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2
+	http3frameTypeData        = http3frameType(0x00)
+	http3frameTypeHeaders     = http3frameType(0x01)
+	http3frameTypeCancelPush  = http3frameType(0x03)
+	http3frameTypeSettings    = http3frameType(0x04)
+	http3frameTypePushPromise = http3frameType(0x05)
+	http3frameTypeGoaway      = http3frameType(0x07)
+	http3frameTypeMaxPushID   = http3frameType(0x0d)
+)
+
+func (ftype http3frameType) String() string {
+	switch ftype {
+	case http3frameTypeData:
+		return "DATA"
+	case http3frameTypeHeaders:
+		return "HEADERS"
+	case http3frameTypeCancelPush:
+		return "CANCEL_PUSH"
+	case http3frameTypeSettings:
+		return "SETTINGS"
+	case http3frameTypePushPromise:
+		return "PUSH_PROMISE"
+	case http3frameTypeGoaway:
+		return "GOAWAY"
+	case http3frameTypeMaxPushID:
+		return "MAX_PUSH_ID"
+	default:
+		return fmt.Sprintf("UNKNOWN_%d", int64(ftype))
+	}
+}
+
+// QPACK (RFC 9204) header compression wire encoding.
+// https://www.rfc-editor.org/rfc/rfc9204.html
+
+// tableType is the static or dynamic table.
+//
+// The T bit in QPACK instructions indicates whether a table index refers to
+// the dynamic (T=0) or static (T=1) table. tableTypeForTBit and tableType.tbit
+// convert a T bit from the wire encoding to/from a tableType.
+type http3tableType byte
+
+const (
+	http3dynamicTable = 0x00 // T=0, dynamic table
+	http3staticTable  = 0xff // T=1, static table
+)
+
+// tableTypeForTbit returns the table type corresponding to a T bit value.
+// The input parameter contains a byte masked to contain only the T bit.
+func http3tableTypeForTbit(bit byte) http3tableType {
+	if bit == 0 {
+		return http3dynamicTable
+	}
+	return http3staticTable
+}
+
+// tbit produces the T bit corresponding to the table type.
+// The input parameter contains a byte with the T bit set to 1,
+// and the return is either the input or 0 depending on the table type.
+func (t http3tableType) tbit(bit byte) byte {
+	return bit & byte(t)
+}
+
+// indexType indicates a literal's indexing status.
+//
+// The N bit in QPACK instructions indicates whether a literal is "never-indexed".
+// A never-indexed literal (N=1) must not be encoded as an indexed literal if it
+// forwarded on another connection.
+//
+// (See https://www.rfc-editor.org/rfc/rfc9204.html#section-7.1 for details on the
+// security reasons for never-indexed literals.)
+type http3indexType byte
+
+const (
+	http3mayIndex   = 0x00 // N=0, not a never-indexed literal
+	http3neverIndex = 0xff // N=1, never-indexed literal
+)
+
+// indexTypeForNBit returns the index type corresponding to a N bit value.
+// The input parameter contains a byte masked to contain only the N bit.
+func http3indexTypeForNBit(bit byte) http3indexType {
+	if bit == 0 {
+		return http3mayIndex
+	}
+	return http3neverIndex
+}
+
+// nbit produces the N bit corresponding to the table type.
+// The input parameter contains a byte with the N bit set to 1,
+// and the return is either the input or 0 depending on the table type.
+func (t http3indexType) nbit(bit byte) byte {
+	return bit & byte(t)
+}
+
+// Indexed Field Line:
+//
+//       0   1   2   3   4   5   6   7
+//     +---+---+---+---+---+---+---+---+
+//     | 1 | T |      Index (6+)       |
+//     +---+---+-----------------------+
+//
+// https://www.rfc-editor.org/rfc/rfc9204.html#section-4.5.2
+
+func http3appendIndexedFieldLine(b []byte, ttype http3tableType, index int) []byte {
+	const tbit = 0b_01000000
+	return http3appendPrefixedInt(b, 0b_1000_0000|ttype.tbit(tbit), 6, int64(index))
+}
+
+func (st *http3stream) decodeIndexedFieldLine(b byte) (itype http3indexType, name, value string, err error) {
+	index, err := st.readPrefixedIntWithByte(b, 6)
+	if err != nil {
+		return 0, "", "", err
+	}
+	const tbit = 0b_0100_0000
+	if http3tableTypeForTbit(b&tbit) == http3staticTable {
+		ent, err := http3staticTableEntry(index)
+		if err != nil {
+			return 0, "", "", err
+		}
+		return http3mayIndex, ent.name, ent.value, nil
+	} else {
+		return 0, "", "", errors.New("dynamic table is not supported yet")
+	}
+}
+
+// Literal Field Line With Name Reference:
+//
+//      0   1   2   3   4   5   6   7
+//     +---+---+---+---+---+---+---+---+
+//     | 0 | 1 | N | T |Name Index (4+)|
+//     +---+---+---+---+---------------+
+//     | H |     Value Length (7+)     |
+//     +---+---------------------------+
+//     |  Value String (Length bytes)  |
+//     +-------------------------------+
+//
+// https://www.rfc-editor.org/rfc/rfc9204.html#section-4.5.4
+
+func http3appendLiteralFieldLineWithNameReference(b []byte, ttype http3tableType, itype http3indexType, nameIndex int, value string) []byte {
+	const tbit = 0b_0001_0000
+	const nbit = 0b_0010_0000
+	b = http3appendPrefixedInt(b, 0b_0100_0000|itype.nbit(nbit)|ttype.tbit(tbit), 4, int64(nameIndex))
+	b = http3appendPrefixedString(b, 0, 7, value)
+	return b
+}
+
+func (st *http3stream) decodeLiteralFieldLineWithNameReference(b byte) (itype http3indexType, name, value string, err error) {
+	nameIndex, err := st.readPrefixedIntWithByte(b, 4)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	const tbit = 0b_0001_0000
+	if http3tableTypeForTbit(b&tbit) == http3staticTable {
+		ent, err := http3staticTableEntry(nameIndex)
+		if err != nil {
+			return 0, "", "", err
+		}
+		name = ent.name
+	} else {
+		return 0, "", "", errors.New("dynamic table is not supported yet")
+	}
+
+	_, value, err = st.readPrefixedString(7)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	const nbit = 0b_0010_0000
+	itype = http3indexTypeForNBit(b & nbit)
+
+	return itype, name, value, nil
+}
+
+// Literal Field Line with Literal Name:
+//
+//       0   1   2   3   4   5   6   7
+//     +---+---+---+---+---+---+---+---+
+//     | 0 | 0 | 1 | N | H |NameLen(3+)|
+//     +---+---+---+---+---+-----------+
+//     |  Name String (Length bytes)   |
+//     +---+---------------------------+
+//     | H |     Value Length (7+)     |
+//     +---+---------------------------+
+//     |  Value String (Length bytes)  |
+//     +-------------------------------+
+//
+// https://www.rfc-editor.org/rfc/rfc9204.html#section-4.5.6
+
+func http3appendLiteralFieldLineWithLiteralName(b []byte, itype http3indexType, name, value string) []byte {
+	const nbit = 0b_0001_0000
+	b = http3appendPrefixedString(b, 0b_0010_0000|itype.nbit(nbit), 3, name)
+	b = http3appendPrefixedString(b, 0, 7, value)
+	return b
+}
+
+func (st *http3stream) decodeLiteralFieldLineWithLiteralName(b byte) (itype http3indexType, name, value string, err error) {
+	name, err = st.readPrefixedStringWithByte(b, 3)
+	if err != nil {
+		return 0, "", "", err
+	}
+	_, value, err = st.readPrefixedString(7)
+	if err != nil {
+		return 0, "", "", err
+	}
+	const nbit = 0b_0001_0000
+	itype = http3indexTypeForNBit(b & nbit)
+	return itype, name, value, nil
+}
+
+// Prefixed-integer encoding from RFC 7541, section 5.1
+//
+// Prefixed integers consist of some number of bits of data,
+// N bits of encoded integer, and 0 or more additional bytes of
+// encoded integer.
+//
+// The RFCs represent this as, for example:
+//
+//       0   1   2   3   4   5   6   7
+//     +---+---+---+---+---+---+---+---+
+//     | 0 | 0 | 1 |   Capacity (5+)   |
+//     +---+---+---+-------------------+
+//
+// "Capacity" is an integer with a 5-bit prefix.
+//
+// In the following functions, a "prefixLen" parameter is the number
+// of integer bits in the first byte (5 in the above example), and
+// a "firstByte" parameter is a byte containing the first byte of
+// the encoded value (0x001x_xxxx in the above example).
+//
+// https://www.rfc-editor.org/rfc/rfc9204.html#section-4.1.1
+// https://www.rfc-editor.org/rfc/rfc7541#section-5.1
+
+// readPrefixedInt reads an RFC 7541 prefixed integer from st.
+func (st *http3stream) readPrefixedInt(prefixLen uint8) (firstByte byte, v int64, err error) {
+	firstByte, err = st.ReadByte()
+	if err != nil {
+		return 0, 0, http3errQPACKDecompressionFailed
+	}
+	v, err = st.readPrefixedIntWithByte(firstByte, prefixLen)
+	return firstByte, v, err
+}
+
+// readPrefixedInt reads an RFC 7541 prefixed integer from st.
+// The first byte has already been read from the stream.
+func (st *http3stream) readPrefixedIntWithByte(firstByte byte, prefixLen uint8) (v int64, err error) {
+	prefixMask := (byte(1) << prefixLen) - 1
+	v = int64(firstByte & prefixMask)
+	if v != int64(prefixMask) {
+		return v, nil
+	}
+	m := 0
+	for {
+		b, err := st.ReadByte()
+		if err != nil {
+			return 0, http3errQPACKDecompressionFailed
+		}
+		v += int64(b&127) << m
+		m += 7
+		if b&128 == 0 {
+			break
+		}
+	}
+	return v, err
+}
+
+// appendPrefixedInt appends an RFC 7541 prefixed integer to b.
+//
+// The firstByte parameter includes the non-integer bits of the first byte.
+// The other bits must be zero.
+func http3appendPrefixedInt(b []byte, firstByte byte, prefixLen uint8, i int64) []byte {
+	u := uint64(i)
+	prefixMask := (uint64(1) << prefixLen) - 1
+	if u < prefixMask {
+		return append(b, firstByte|byte(u))
+	}
+	b = append(b, firstByte|byte(prefixMask))
+	u -= prefixMask
+	for u >= 128 {
+		b = append(b, 0x80|byte(u&0x7f))
+		u >>= 7
+	}
+	return append(b, byte(u))
+}
+
+// String literal encoding from RFC 7541, section 5.2
+//
+// String literals consist of a single bit flag indicating
+// whether the string is Huffman-encoded, a prefixed integer (see above),
+// and the string.
+//
+// https://www.rfc-editor.org/rfc/rfc9204.html#section-4.1.2
+// https://www.rfc-editor.org/rfc/rfc7541#section-5.2
+
+// readPrefixedString reads an RFC 7541 string from st.
+func (st *http3stream) readPrefixedString(prefixLen uint8) (firstByte byte, s string, err error) {
+	firstByte, err = st.ReadByte()
+	if err != nil {
+		return 0, "", http3errQPACKDecompressionFailed
+	}
+	s, err = st.readPrefixedStringWithByte(firstByte, prefixLen)
+	return firstByte, s, err
+}
+
+// readPrefixedString reads an RFC 7541 string from st.
+// The first byte has already been read from the stream.
+func (st *http3stream) readPrefixedStringWithByte(firstByte byte, prefixLen uint8) (s string, err error) {
+	size, err := st.readPrefixedIntWithByte(firstByte, prefixLen)
+	if err != nil {
+		return "", http3errQPACKDecompressionFailed
+	}
+
+	hbit := byte(1) << prefixLen
+	isHuffman := firstByte&hbit != 0
+
+	// TODO: Avoid allocating here.
+	data := make([]byte, size)
+	if _, err := io.ReadFull(st, data); err != nil {
+		return "", http3errQPACKDecompressionFailed
+	}
+	if isHuffman {
+		// TODO: Move Huffman functions into a new package that hpack (HTTP/2)
+		// and this package can both import. Most of the hpack package isn't
+		// relevant to HTTP/3.
+		s, err := hpack.HuffmanDecodeToString(data)
+		if err != nil {
+			return "", http3errQPACKDecompressionFailed
+		}
+		return s, nil
+	}
+	return string(data), nil
+}
+
+// appendPrefixedString appends an RFC 7541 string to st,
+// applying Huffman encoding and setting the H bit (indicating Huffman encoding)
+// when appropriate.
+//
+// The firstByte parameter includes the non-integer bits of the first byte.
+// The other bits must be zero.
+func http3appendPrefixedString(b []byte, firstByte byte, prefixLen uint8, s string) []byte {
+	huffmanLen := hpack.HuffmanEncodeLength(s)
+	if huffmanLen < uint64(len(s)) {
+		hbit := byte(1) << prefixLen
+		b = http3appendPrefixedInt(b, firstByte|hbit, prefixLen, int64(huffmanLen))
+		b = hpack.AppendHuffmanString(b, s)
+	} else {
+		b = http3appendPrefixedInt(b, firstByte, prefixLen, int64(len(s)))
+		b = append(b, s...)
+	}
+	return b
+}
+
+type http3qpackDecoder struct {
+	// The decoder has no state for now,
+	// but that'll change once we add dynamic table support.
+	//
+	// TODO: dynamic table support.
+}
+
+func (qd *http3qpackDecoder) decode(st *http3stream, f func(itype http3indexType, name, value string) error) error {
+	// Encoded Field Section prefix.
+
+	// We set SETTINGS_QPACK_MAX_TABLE_CAPACITY to 0,
+	// so the Required Insert Count must be 0.
+	_, requiredInsertCount, err := st.readPrefixedInt(8)
+	if err != nil {
+		return err
+	}
+	if requiredInsertCount != 0 {
+		return http3errQPACKDecompressionFailed
+	}
+
+	// Delta Base. We don't use the dynamic table yet, so this may be ignored.
+	_, _, err = st.readPrefixedInt(7)
+	if err != nil {
+		return err
+	}
+
+	sawNonPseudo := false
+	for st.lim > 0 {
+		firstByte, err := st.ReadByte()
+		if err != nil {
+			return err
+		}
+		var name, value string
+		var itype http3indexType
+		switch bits.LeadingZeros8(firstByte) {
+		case 0:
+			// Indexed Field Line
+			itype, name, value, err = st.decodeIndexedFieldLine(firstByte)
+		case 1:
+			// Literal Field Line With Name Reference
+			itype, name, value, err = st.decodeLiteralFieldLineWithNameReference(firstByte)
+		case 2:
+			// Literal Field Line with Literal Name
+			itype, name, value, err = st.decodeLiteralFieldLineWithLiteralName(firstByte)
+		case 3:
+			// Indexed Field Line With Post-Base Index
+			err = errors.New("dynamic table is not supported yet")
+		case 4:
+			// Indexed Field Line With Post-Base Name Reference
+			err = errors.New("dynamic table is not supported yet")
+		}
+		if err != nil {
+			return err
+		}
+		if len(name) == 0 {
+			return http3errH3MessageError
+		}
+		if name[0] == ':' {
+			if sawNonPseudo {
+				return http3errH3MessageError
+			}
+		} else {
+			sawNonPseudo = true
+		}
+		if err := f(itype, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type http3qpackEncoder struct {
+	// The encoder has no state for now,
+	// but that'll change once we add dynamic table support.
+	//
+	// TODO: dynamic table support.
+}
+
+func (qe *http3qpackEncoder) init() {
+	http3staticTableOnce.Do(http3initStaticTableMaps)
+}
+
+// encode encodes a list of headers into a QPACK encoded field section.
+//
+// The headers func must produce the same headers on repeated calls,
+// although the order may vary.
+func (qe *http3qpackEncoder) encode(headers func(func(itype http3indexType, name, value string))) []byte {
+	// Encoded Field Section prefix.
+	//
+	// We don't yet use the dynamic table, so both values here are zero.
+	var b []byte
+	b = http3appendPrefixedInt(b, 0, 8, 0) // Required Insert Count
+	b = http3appendPrefixedInt(b, 0, 7, 0) // Delta Base
+
+	headers(func(itype http3indexType, name, value string) {
+		if itype == http3mayIndex {
+			if i, ok := http3staticTableByNameValue[http3tableEntry{name, value}]; ok {
+				b = http3appendIndexedFieldLine(b, http3staticTable, i)
+				return
+			}
+		}
+		if i, ok := http3staticTableByName[name]; ok {
+			b = http3appendLiteralFieldLineWithNameReference(b, http3staticTable, itype, i, value)
+		} else {
+			b = http3appendLiteralFieldLineWithLiteralName(b, itype, name, value)
+		}
+	})
+
+	return b
+}
+
+type http3tableEntry struct {
+	name  string
+	value string
+}
+
+// staticTableEntry returns the static table entry with the given index.
+func http3staticTableEntry(index int64) (http3tableEntry, error) {
+	if index >= int64(len(http3staticTableEntries)) {
+		return http3tableEntry{}, http3errQPACKDecompressionFailed
+	}
+	return http3staticTableEntries[index], nil
+}
+
+func http3initStaticTableMaps() {
+	http3staticTableByName = make(map[string]int)
+	http3staticTableByNameValue = make(map[http3tableEntry]int)
+	for i, ent := range http3staticTableEntries {
+		if _, ok := http3staticTableByName[ent.name]; !ok {
+			http3staticTableByName[ent.name] = i
+		}
+		http3staticTableByNameValue[ent] = i
+	}
+}
+
+var (
+	http3staticTableOnce        sync.Once
+	http3staticTableByName      map[string]int
+	http3staticTableByNameValue map[http3tableEntry]int
+)
+
+// https://www.rfc-editor.org/rfc/rfc9204.html#appendix-A
+//
+// Note that this is different from the HTTP/2 static table.
+var http3staticTableEntries = [...]http3tableEntry{
+	0:  {":authority", ""},
+	1:  {":path", "/"},
+	2:  {"age", "0"},
+	3:  {"content-disposition", ""},
+	4:  {"content-length", "0"},
+	5:  {"cookie", ""},
+	6:  {"date", ""},
+	7:  {"etag", ""},
+	8:  {"if-modified-since", ""},
+	9:  {"if-none-match", ""},
+	10: {"last-modified", ""},
+	11: {"link", ""},
+	12: {"location", ""},
+	13: {"referer", ""},
+	14: {"set-cookie", ""},
+	15: {":method", "CONNECT"},
+	16: {":method", "DELETE"},
+	17: {":method", "GET"},
+	18: {":method", "HEAD"},
+	19: {":method", "OPTIONS"},
+	20: {":method", "POST"},
+	21: {":method", "PUT"},
+	22: {":scheme", "http"},
+	23: {":scheme", "https"},
+	24: {":status", "103"},
+	25: {":status", "200"},
+	26: {":status", "304"},
+	27: {":status", "404"},
+	28: {":status", "503"},
+	29: {"accept", "*/*"},
+	30: {"accept", "application/dns-message"},
+	31: {"accept-encoding", "gzip, deflate, br"},
+	32: {"accept-ranges", "bytes"},
+	33: {"access-control-allow-headers", "cache-control"},
+	34: {"access-control-allow-headers", "content-type"},
+	35: {"access-control-allow-origin", "*"},
+	36: {"cache-control", "max-age=0"},
+	37: {"cache-control", "max-age=2592000"},
+	38: {"cache-control", "max-age=604800"},
+	39: {"cache-control", "no-cache"},
+	40: {"cache-control", "no-store"},
+	41: {"cache-control", "public, max-age=31536000"},
+	42: {"content-encoding", "br"},
+	43: {"content-encoding", "gzip"},
+	44: {"content-type", "application/dns-message"},
+	45: {"content-type", "application/javascript"},
+	46: {"content-type", "application/json"},
+	47: {"content-type", "application/x-www-form-urlencoded"},
+	48: {"content-type", "image/gif"},
+	49: {"content-type", "image/jpeg"},
+	50: {"content-type", "image/png"},
+	51: {"content-type", "text/css"},
+	52: {"content-type", "text/html; charset=utf-8"},
+	53: {"content-type", "text/plain"},
+	54: {"content-type", "text/plain;charset=utf-8"},
+	55: {"range", "bytes=0-"},
+	56: {"strict-transport-security", "max-age=31536000"},
+	57: {"strict-transport-security", "max-age=31536000; includesubdomains"},
+	58: {"strict-transport-security", "max-age=31536000; includesubdomains; preload"},
+	59: {"vary", "accept-encoding"},
+	60: {"vary", "origin"},
+	61: {"x-content-type-options", "nosniff"},
+	62: {"x-xss-protection", "1; mode=block"},
+	63: {":status", "100"},
+	64: {":status", "204"},
+	65: {":status", "206"},
+	66: {":status", "302"},
+	67: {":status", "400"},
+	68: {":status", "403"},
+	69: {":status", "421"},
+	70: {":status", "425"},
+	71: {":status", "500"},
+	72: {"accept-language", ""},
+	73: {"access-control-allow-credentials", "FALSE"},
+	74: {"access-control-allow-credentials", "TRUE"},
+	75: {"access-control-allow-headers", "*"},
+	76: {"access-control-allow-methods", "get"},
+	77: {"access-control-allow-methods", "get, post, options"},
+	78: {"access-control-allow-methods", "options"},
+	79: {"access-control-expose-headers", "content-length"},
+	80: {"access-control-request-headers", "content-type"},
+	81: {"access-control-request-method", "get"},
+	82: {"access-control-request-method", "post"},
+	83: {"alt-svc", "clear"},
+	84: {"authorization", ""},
+	85: {"content-security-policy", "script-src 'none'; object-src 'none'; base-uri 'none'"},
+	86: {"early-data", "1"},
+	87: {"expect-ct", ""},
+	88: {"forwarded", ""},
+	89: {"if-range", ""},
+	90: {"origin", ""},
+	91: {"purpose", "prefetch"},
+	92: {"server", ""},
+	93: {"timing-allow-origin", "*"},
+	94: {"upgrade-insecure-requests", "1"},
+	95: {"user-agent", ""},
+	96: {"x-forwarded-for", ""},
+	97: {"x-frame-options", "deny"},
+	98: {"x-frame-options", "sameorigin"},
+}
+
+func http3initConfig(config *quic.Config) *quic.Config {
+	if config == nil {
+		config = &quic.Config{}
+	}
+
+	// maybeCloneTLSConfig clones the user-provided tls.Config (but only once)
+	// prior to us modifying it.
+	needCloneTLSConfig := true
+	maybeCloneTLSConfig := func() *tls.Config {
+		if needCloneTLSConfig {
+			config.TLSConfig = config.TLSConfig.Clone()
+			needCloneTLSConfig = false
+		}
+		return config.TLSConfig
+	}
+
+	if config.TLSConfig == nil {
+		config.TLSConfig = &tls.Config{}
+		needCloneTLSConfig = false
+	}
+	if config.TLSConfig.MinVersion == 0 {
+		maybeCloneTLSConfig().MinVersion = tls.VersionTLS13
+	}
+	if config.TLSConfig.NextProtos == nil {
+		maybeCloneTLSConfig().NextProtos = []string{"h3"}
+	}
+	return config
+}
+
+type http3roundTripState struct {
+	cc *http3ClientConn
+	st *http3stream
+
+	// Request body, provided by the caller.
+	onceCloseReqBody sync.Once
+	reqBody          io.ReadCloser
+
+	reqBodyWriter http3bodyWriter
+
+	// Response.Body, provided to the caller.
+	respBody http3bodyReader
+
+	errOnce sync.Once
+	err     error
+}
+
+// abort terminates the RoundTrip.
+// It returns the first fatal error encountered by the RoundTrip call.
+func (rt *http3roundTripState) abort(err error) error {
+	rt.errOnce.Do(func() {
+		rt.err = err
+		switch e := err.(type) {
+		case *http3connectionError:
+			rt.cc.abort(e)
+		case *http3streamError:
+			rt.st.stream.CloseRead()
+			rt.st.stream.Reset(uint64(e.code))
+		default:
+			rt.st.stream.CloseRead()
+			rt.st.stream.Reset(uint64(http3errH3NoError))
+		}
+	})
+	return rt.err
+}
+
+// closeReqBody closes the Request.Body, at most once.
+func (rt *http3roundTripState) closeReqBody() {
+	if rt.reqBody != nil {
+		rt.onceCloseReqBody.Do(func() {
+			rt.reqBody.Close()
+		})
+	}
+}
+
+// RoundTrip sends a request on the connection.
+func (cc *http3ClientConn) RoundTrip(req *Request) (_ *Response, err error) {
+	// Each request gets its own QUIC stream.
+	st, err := http3newConnStream(req.Context(), cc.qconn, http3streamTypeRequest)
+	if err != nil {
+		return nil, err
+	}
+	rt := &http3roundTripState{
+		cc: cc,
+		st: st,
+	}
+	defer func() {
+		if err != nil {
+			err = rt.abort(err)
+		}
+	}()
+
+	// Cancel reads/writes on the stream when the request expires.
+	st.stream.SetReadContext(req.Context())
+	st.stream.SetWriteContext(req.Context())
+
+	contentLength := http3actualContentLength(req)
+
+	var encr httpcommon.EncodeHeadersResult
+	headers := cc.enc.encode(func(yield func(itype http3indexType, name, value string)) {
+		encr, err = httpcommon.EncodeHeaders(req.Context(), httpcommon.EncodeHeadersParam{
+			Request: httpcommon.Request{
+				URL:                 req.URL,
+				Method:              req.Method,
+				Host:                req.Host,
+				Header:              req.Header,
+				Trailer:             req.Trailer,
+				ActualContentLength: contentLength,
+			},
+			AddGzipHeader:         false, // TODO: add when appropriate
+			PeerMaxHeaderListSize: 0,
+			DefaultUserAgent:      "Go-http-client/3",
+		}, func(name, value string) {
+			// Issue #71374: Consider supporting never-indexed fields.
+			yield(http3mayIndex, name, value)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the HEADERS frame.
+	st.writeVarint(int64(http3frameTypeHeaders))
+	st.writeVarint(int64(len(headers)))
+	st.Write(headers)
+	if err := st.Flush(); err != nil {
+		return nil, err
+	}
+
+	if encr.HasBody {
+		// TODO: Defer sending the request body when "Expect: 100-continue" is set.
+		rt.reqBody = req.Body
+		rt.reqBodyWriter.st = st
+		rt.reqBodyWriter.remain = contentLength
+		rt.reqBodyWriter.flush = true
+		rt.reqBodyWriter.name = "request"
+		go http3copyRequestBody(rt)
+	}
+
+	// Read the response headers.
+	for {
+		ftype, err := st.readFrameHeader()
+		if err != nil {
+			return nil, err
+		}
+		switch ftype {
+		case http3frameTypeHeaders:
+			statusCode, h, err := cc.handleHeaders(st)
+			if err != nil {
+				return nil, err
+			}
+
+			if statusCode >= 100 && statusCode < 199 {
+				// TODO: Handle 1xx responses.
+				continue
+			}
+
+			// We have the response headers.
+			// Set up the response and return it to the caller.
+			contentLength, err := http3parseResponseContentLength(req.Method, statusCode, h)
+			if err != nil {
+				return nil, err
+			}
+			rt.respBody.st = st
+			rt.respBody.remain = contentLength
+			resp := &Response{
+				Proto:         "HTTP/3.0",
+				ProtoMajor:    3,
+				Header:        h,
+				StatusCode:    statusCode,
+				Status:        strconv.Itoa(statusCode) + " " + StatusText(statusCode),
+				ContentLength: contentLength,
+				Body:          (*http3transportResponseBody)(rt),
+			}
+			// TODO: Automatic Content-Type: gzip decoding.
+			return resp, nil
+		case http3frameTypePushPromise:
+			if err := cc.handlePushPromise(st); err != nil {
+				return nil, err
+			}
+		default:
+			if err := st.discardUnknownFrame(ftype); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// actualContentLength returns a sanitized version of req.ContentLength,
+// where 0 actually means zero (not unknown) and -1 means unknown.
+func http3actualContentLength(req *Request) int64 {
+	if req.Body == nil || req.Body == NoBody {
+		return 0
+	}
+	if req.ContentLength != 0 {
+		return req.ContentLength
+	}
+	return -1
+}
+
+func http3copyRequestBody(rt *http3roundTripState) {
+	defer rt.closeReqBody()
+	_, err := io.Copy(&rt.reqBodyWriter, rt.reqBody)
+	if closeErr := rt.reqBodyWriter.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Something went wrong writing the body.
+		rt.abort(err)
+	} else {
+		// We wrote the whole body.
+		rt.st.stream.CloseWrite()
+	}
+}
+
+// transportResponseBody is the Response.Body returned by RoundTrip.
+type http3transportResponseBody http3roundTripState
+
+// Read is Response.Body.Read.
+
+// Read is Response.Body.Read.
+func (b *http3transportResponseBody) Read(p []byte) (n int, err error) {
+	return b.respBody.Read(p)
+}
+
+var http3errRespBodyClosed = errors.New("response body closed")
+
+// Close is Response.Body.Close.
+// Closing the response body is how the caller signals that they're done with a request.
+func (b *http3transportResponseBody) Close() error {
+	rt := (*http3roundTripState)(b)
+	// Close the request body, which should wake up copyRequestBody if it's
+	// currently blocked reading the body.
+	rt.closeReqBody()
+	// Close the request stream, since we're done with the request.
+	// Reset closes the sending half of the stream.
+	rt.st.stream.Reset(uint64(http3errH3NoError))
+	// respBody.Close is responsible for closing the receiving half.
+	err := rt.respBody.Close()
+	if err == nil {
+		err = http3errRespBodyClosed
+	}
+	err = rt.abort(err)
+	if err == http3errRespBodyClosed {
+		// No other errors occurred before closing Response.Body,
+		// so consider this a successful request.
+		return nil
+	}
+	return err
+}
+
+func http3parseResponseContentLength(method string, statusCode int, h Header) (int64, error) {
+	clens := h["Content-Length"]
+	if len(clens) == 0 {
+		return -1, nil
+	}
+
+	// We allow duplicate Content-Length headers,
+	// but only if they all have the same value.
+	for _, v := range clens[1:] {
+		if clens[0] != v {
+			return -1, &http3streamError{http3errH3MessageError, "mismatching Content-Length headers"}
+		}
+	}
+
+	// "A server MUST NOT send a Content-Length header field in any response
+	// with a status code of 1xx (Informational) or 204 (No Content).
+	// A server MUST NOT send a Content-Length header field in any 2xx (Successful)
+	// response to a CONNECT request [...]"
+	// https://www.rfc-editor.org/rfc/rfc9110#section-8.6-8
+	if (statusCode >= 100 && statusCode < 200) ||
+		statusCode == 204 ||
+		(method == "CONNECT" && statusCode >= 200 && statusCode < 300) {
+		// This is a protocol violation, but a fairly harmless one.
+		// Just ignore the header.
+		return -1, nil
+	}
+
+	contentLen, err := strconv.ParseUint(clens[0], 10, 63)
+	if err != nil {
+		return -1, &http3streamError{http3errH3MessageError, "invalid Content-Length header"}
+	}
+	return int64(contentLen), nil
+}
+
+func (cc *http3ClientConn) handleHeaders(st *http3stream) (statusCode int, h Header, err error) {
+	haveStatus := false
+	cookie := ""
+	// Issue #71374: Consider tracking the never-indexed status of headers
+	// with the N bit set in their QPACK encoding.
+	err = cc.dec.decode(st, func(_ http3indexType, name, value string) error {
+		switch {
+		case name == ":status":
+			if haveStatus {
+				return &http3streamError{http3errH3MessageError, "duplicate :status"}
+			}
+			haveStatus = true
+			statusCode, err = strconv.Atoi(value)
+			if err != nil {
+				return &http3streamError{http3errH3MessageError, "invalid :status"}
+			}
+		case name[0] == ':':
+			// "Endpoints MUST treat a request or response
+			// that contains undefined or invalid
+			// pseudo-header fields as malformed."
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.3-3
+			return &http3streamError{http3errH3MessageError, "undefined pseudo-header"}
+		case name == "cookie":
+			// "If a decompressed field section contains multiple cookie field lines,
+			// these MUST be concatenated into a single byte string [...]"
+			// using the two-byte delimiter of "; "''
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.2.1-2
+			if cookie == "" {
+				cookie = value
+			} else {
+				cookie += "; " + value
+			}
+		default:
+			if h == nil {
+				h = make(Header)
+			}
+			// TODO: Use a per-connection canonicalization cache as we do in HTTP/2.
+			// Maybe we could put this in the QPACK decoder and have it deliver
+			// pre-canonicalized headers to us here?
+			cname := httpcommon.CanonicalHeader(name)
+			// TODO: Consider using a single []string slice for all headers,
+			// as we do in the HTTP/1 and HTTP/2 cases.
+			// This is a bit tricky, since we don't know the number of headers
+			// at the start of decoding. Perhaps it's worth doing a two-pass decode,
+			// or perhaps we should just allocate header value slices in
+			// reasonably-sized chunks.
+			h[cname] = append(h[cname], value)
+		}
+		return nil
+	})
+	if !haveStatus {
+		// "[The :status] pseudo-header field MUST be included in all responses [...]"
+		// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.3.2-1
+		err = http3errH3MessageError
+	}
+	if cookie != "" {
+		if h == nil {
+			h = make(Header)
+		}
+		h["Cookie"] = []string{cookie}
+	}
+	if err := st.endFrame(); err != nil {
+		return 0, nil, err
+	}
+	return statusCode, h, err
+}
+
+func (cc *http3ClientConn) handlePushPromise(st *http3stream) error {
+	// "A client MUST treat receipt of a PUSH_PROMISE frame that contains a
+	// larger push ID than the client has advertised as a connection error of H3_ID_ERROR."
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.5-5
+	return &http3connectionError{
+		code:    http3errH3IDError,
+		message: "PUSH_PROMISE received when no MAX_PUSH_ID has been sent",
+	}
+}
+
+// A Server is an HTTP/3 server.
+// The zero value for Server is a valid server.
+type http3Server struct {
+	// Handler to invoke for requests, http.DefaultServeMux if nil.
+	Handler Handler
+
+	// Config is the QUIC configuration used by the server.
+	// The Config may be nil.
+	//
+	// ListenAndServe may clone and modify the Config.
+	// The Config must not be modified after calling ListenAndServe.
+	Config *quic.Config
+
+	initOnce sync.Once
+}
+
+func (s *http3Server) init() {
+	s.initOnce.Do(func() {
+		s.Config = http3initConfig(s.Config)
+		if s.Handler == nil {
+			s.Handler = DefaultServeMux
+		}
+	})
+}
+
+// ListenAndServe listens on the UDP network address addr
+// and then calls Serve to handle requests on incoming connections.
+func (s *http3Server) ListenAndServe(addr string) error {
+	s.init()
+	e, err := quic.Listen("udp", addr, s.Config)
+	if err != nil {
+		return err
+	}
+	return s.Serve(e)
+}
+
+// Serve accepts incoming connections on the QUIC endpoint e,
+// and handles requests from those connections.
+func (s *http3Server) Serve(e *quic.Endpoint) error {
+	s.init()
+	for {
+		qconn, err := e.Accept(context.Background())
+		if err != nil {
+			return err
+		}
+		go http3newServerConn(qconn)
+	}
+}
+
+type http3serverConn struct {
+	qconn *quic.Conn
+
+	http3genericConn // for handleUnidirectionalStream
+	enc              http3qpackEncoder
+	dec              http3qpackDecoder
+}
+
+func http3newServerConn(qconn *quic.Conn) {
+	sc := &http3serverConn{
+		qconn: qconn,
+	}
+	sc.enc.init()
+
+	// Create control stream and send SETTINGS frame.
+	// TODO: Time out on creating stream.
+	controlStream, err := http3newConnStream(context.Background(), sc.qconn, http3streamTypeControl)
+	if err != nil {
+		return
+	}
+	controlStream.writeSettings()
+	controlStream.Flush()
+
+	sc.acceptStreams(sc.qconn, sc)
+}
+
+func (sc *http3serverConn) handleControlStream(st *http3stream) error {
+	// "A SETTINGS frame MUST be sent as the first frame of each control stream [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.4-2
+	if err := st.readSettings(func(settingsType, settingsValue int64) error {
+		switch settingsType {
+		case http3settingsMaxFieldSectionSize:
+			_ = settingsValue // TODO
+		case http3settingsQPACKMaxTableCapacity:
+			_ = settingsValue // TODO
+		case http3settingsQPACKBlockedStreams:
+			_ = settingsValue // TODO
+		default:
+			// Unknown settings types are ignored.
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for {
+		ftype, err := st.readFrameHeader()
+		if err != nil {
+			return err
+		}
+		switch ftype {
+		case http3frameTypeCancelPush:
+			// "If a server receives a CANCEL_PUSH frame for a push ID
+			// that has not yet been mentioned by a PUSH_PROMISE frame,
+			// this MUST be treated as a connection error of type H3_ID_ERROR."
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.3-8
+			return &http3connectionError{
+				code:    http3errH3IDError,
+				message: "CANCEL_PUSH for unsent push ID",
+			}
+		case http3frameTypeGoaway:
+			return http3errH3NoError
+		default:
+			// Unknown frames are ignored.
+			if err := st.discardUnknownFrame(ftype); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (sc *http3serverConn) handleEncoderStream(*http3stream) error {
+	// TODO
+	return nil
+}
+
+func (sc *http3serverConn) handleDecoderStream(*http3stream) error {
+	// TODO
+	return nil
+}
+
+func (sc *http3serverConn) handlePushStream(*http3stream) error {
+	// "[...] if a server receives a client-initiated push stream,
+	// this MUST be treated as a connection error of type H3_STREAM_CREATION_ERROR."
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-6.2.2-3
+	return &http3connectionError{
+		code:    http3errH3StreamCreationError,
+		message: "client created push stream",
+	}
+}
+
+func (sc *http3serverConn) handleRequestStream(st *http3stream) error {
+	// TODO
+	return nil
+}
+
+// abort closes the connection with an error.
+func (sc *http3serverConn) abort(err error) {
+	if e, ok := err.(*http3connectionError); ok {
+		sc.qconn.Abort(&quic.ApplicationError{
+			Code:   uint64(e.code),
+			Reason: e.message,
+		})
+	} else {
+		sc.qconn.Abort(err)
+	}
+}
+
+const (
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.4.1
+	http3settingsMaxFieldSectionSize = 0x06
+
+	// https://www.rfc-editor.org/rfc/rfc9204.html#section-5
+	http3settingsQPACKMaxTableCapacity = 0x01
+	http3settingsQPACKBlockedStreams   = 0x07
+)
+
+// writeSettings writes a complete SETTINGS frame.
+// Its parameter is a list of alternating setting types and values.
+func (st *http3stream) writeSettings(settings ...int64) {
+	var size int64
+	for _, s := range settings {
+		// Settings values that don't fit in a QUIC varint ([0,2^62)) will panic here.
+		size += int64(quicwire.SizeVarint(uint64(s)))
+	}
+	st.writeVarint(int64(http3frameTypeSettings))
+	st.writeVarint(size)
+	for _, s := range settings {
+		st.writeVarint(s)
+	}
+}
+
+// readSettings reads a complete SETTINGS frame, including the frame header.
+func (st *http3stream) readSettings(f func(settingType, value int64) error) error {
+	frameType, err := st.readFrameHeader()
+	if err != nil || frameType != http3frameTypeSettings {
+		return &http3connectionError{
+			code:    http3errH3MissingSettings,
+			message: "settings not sent on control stream",
+		}
+	}
+	for st.lim > 0 {
+		settingsType, err := st.readVarint()
+		if err != nil {
+			return err
+		}
+		settingsValue, err := st.readVarint()
+		if err != nil {
+			return err
+		}
+
+		// Use of HTTP/2 settings where there is no corresponding HTTP/3 setting
+		// is an error.
+		// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.4.1-5
+		switch settingsType {
+		case 0x02, 0x03, 0x04, 0x05:
+			return &http3connectionError{
+				code:    http3errH3SettingsError,
+				message: "use of reserved setting",
+			}
+		}
+
+		if err := f(settingsType, settingsValue); err != nil {
+			return err
+		}
+	}
+	return st.endFrame()
+}
+
+// A stream wraps a QUIC stream, providing methods to read/write various values.
+type http3stream struct {
+	stream *quic.Stream
+
+	// lim is the current read limit.
+	// Reading a frame header sets the limit to the end of the frame.
+	// Reading past the limit or reading less than the limit and ending the frame
+	// results in an error.
+	// -1 indicates no limit.
+	lim int64
+}
+
+// newConnStream creates a new stream on a connection.
+// It writes the stream header for unidirectional streams.
+//
+// The stream returned by newStream is not flushed,
+// and will not be sent to the peer until the caller calls
+// Flush or writes enough data to the stream.
+func http3newConnStream(ctx context.Context, qconn *quic.Conn, stype http3streamType) (*http3stream, error) {
+	var qs *quic.Stream
+	var err error
+	if stype == http3streamTypeRequest {
+		// Request streams are bidirectional.
+		qs, err = qconn.NewStream(ctx)
+	} else {
+		// All other streams are unidirectional.
+		qs, err = qconn.NewSendOnlyStream(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	st := &http3stream{
+		stream: qs,
+		lim:    -1, // no limit
+	}
+	if stype != http3streamTypeRequest {
+		// Unidirectional stream header.
+		st.writeVarint(int64(stype))
+	}
+	return st, err
+}
+
+func http3newStream(qs *quic.Stream) *http3stream {
+	return &http3stream{
+		stream: qs,
+		lim:    -1, // no limit
+	}
+}
+
+// readFrameHeader reads the type and length fields of an HTTP/3 frame.
+// It sets the read limit to the end of the frame.
+//
+// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.1
+func (st *http3stream) readFrameHeader() (ftype http3frameType, err error) {
+	if st.lim >= 0 {
+		// We shoudn't call readFrameHeader before ending the previous frame.
+		return 0, http3errH3FrameError
+	}
+	ftype, err = http3readVarint[http3frameType](st)
+	if err != nil {
+		return 0, err
+	}
+	size, err := st.readVarint()
+	if err != nil {
+		return 0, err
+	}
+	st.lim = size
+	return ftype, nil
+}
+
+// endFrame is called after reading a frame to reset the read limit.
+// It returns an error if the entire contents of a frame have not been read.
+func (st *http3stream) endFrame() error {
+	if st.lim != 0 {
+		return &http3connectionError{
+			code:    http3errH3FrameError,
+			message: "invalid HTTP/3 frame",
+		}
+	}
+	st.lim = -1
+	return nil
+}
+
+// readFrameData returns the remaining data in the current frame.
+func (st *http3stream) readFrameData() ([]byte, error) {
+	if st.lim < 0 {
+		return nil, http3errH3FrameError
+	}
+	// TODO: Pool buffers to avoid allocation here.
+	b := make([]byte, st.lim)
+	_, err := io.ReadFull(st, b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ReadByte reads one byte from the stream.
+func (st *http3stream) ReadByte() (b byte, err error) {
+	if err := st.recordBytesRead(1); err != nil {
+		return 0, err
+	}
+	b, err = st.stream.ReadByte()
+	if err != nil {
+		if err == io.EOF && st.lim < 0 {
+			return 0, io.EOF
+		}
+		return 0, http3errH3FrameError
+	}
+	return b, nil
+}
+
+// Read reads from the stream.
+func (st *http3stream) Read(b []byte) (int, error) {
+	n, err := st.stream.Read(b)
+	if e2 := st.recordBytesRead(n); e2 != nil {
+		return 0, e2
+	}
+	if err == io.EOF {
+		if st.lim == 0 {
+			// EOF at end of frame, ignore.
+			return n, nil
+		} else if st.lim > 0 {
+			// EOF inside frame, error.
+			return 0, http3errH3FrameError
+		} else {
+			// EOF outside of frame, surface to caller.
+			return n, io.EOF
+		}
+	}
+	if err != nil {
+		return 0, http3errH3FrameError
+	}
+	return n, nil
+}
+
+// discardUnknownFrame discards an unknown frame.
+//
+// HTTP/3 requires that unknown frames be ignored on all streams.
+// However, a known frame appearing in an unexpected place is a fatal error,
+// so this returns an error if the frame is one we know.
+func (st *http3stream) discardUnknownFrame(ftype http3frameType) error {
+	switch ftype {
+	case http3frameTypeData,
+		http3frameTypeHeaders,
+		http3frameTypeCancelPush,
+		http3frameTypeSettings,
+		http3frameTypePushPromise,
+		http3frameTypeGoaway,
+		http3frameTypeMaxPushID:
+		return &http3connectionError{
+			code:    http3errH3FrameUnexpected,
+			message: "unexpected " + ftype.String() + " frame",
+		}
+	}
+	return st.discardFrame()
+}
+
+// discardFrame discards any remaining data in the current frame and resets the read limit.
+func (st *http3stream) discardFrame() error {
+	// TODO: Consider adding a *quic.Stream method to discard some amount of data.
+	for range st.lim {
+		_, err := st.stream.ReadByte()
+		if err != nil {
+			return &http3streamError{http3errH3FrameError, err.Error()}
+		}
+	}
+	st.lim = -1
+	return nil
+}
+
+// Write writes to the stream.
+func (st *http3stream) Write(b []byte) (int, error) { return st.stream.Write(b) }
+
+// Flush commits data written to the stream.
+func (st *http3stream) Flush() error { return st.stream.Flush() }
+
+// readVarint reads a QUIC variable-length integer from the stream.
+func (st *http3stream) readVarint() (v int64, err error) {
+	b, err := st.stream.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	v = int64(b & 0x3f)
+	n := 1 << (b >> 6)
+	for i := 1; i < n; i++ {
+		b, err := st.stream.ReadByte()
+		if err != nil {
+			return 0, http3errH3FrameError
+		}
+		v = (v << 8) | int64(b)
+	}
+	if err := st.recordBytesRead(n); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+// readVarint reads a varint of a particular type.
+func http3readVarint[T ~int64 | ~uint64](st *http3stream) (T, error) {
+	v, err := st.readVarint()
+	return T(v), err
+}
+
+// writeVarint writes a QUIC variable-length integer to the stream.
+func (st *http3stream) writeVarint(v int64) {
+	switch {
+	case v <= (1<<6)-1:
+		st.stream.WriteByte(byte(v))
+	case v <= (1<<14)-1:
+		st.stream.WriteByte((1 << 6) | byte(v>>8))
+		st.stream.WriteByte(byte(v))
+	case v <= (1<<30)-1:
+		st.stream.WriteByte((2 << 6) | byte(v>>24))
+		st.stream.WriteByte(byte(v >> 16))
+		st.stream.WriteByte(byte(v >> 8))
+		st.stream.WriteByte(byte(v))
+	case v <= (1<<62)-1:
+		st.stream.WriteByte((3 << 6) | byte(v>>56))
+		st.stream.WriteByte(byte(v >> 48))
+		st.stream.WriteByte(byte(v >> 40))
+		st.stream.WriteByte(byte(v >> 32))
+		st.stream.WriteByte(byte(v >> 24))
+		st.stream.WriteByte(byte(v >> 16))
+		st.stream.WriteByte(byte(v >> 8))
+		st.stream.WriteByte(byte(v))
+	default:
+		panic("varint too large")
+	}
+}
+
+// recordBytesRead records that n bytes have been read.
+// It returns an error if the read passes the current limit.
+func (st *http3stream) recordBytesRead(n int) error {
+	if st.lim < 0 {
+		return nil
+	}
+	st.lim -= int64(n)
+	if st.lim < 0 {
+		st.stream = nil // panic if we try to read again
+		return &http3connectionError{
+			code:    http3errH3FrameError,
+			message: "invalid HTTP/3 frame",
+		}
+	}
+	return nil
+}
+
+// A Transport is an HTTP/3 transport.
+//
+// It does not manage a pool of connections,
+// and therefore does not implement net/http.RoundTripper.
+//
+// TODO: Provide a way to register an HTTP/3 transport with a net/http.Transport's
+// connection pool.
+type http3Transport struct {
+	// Endpoint is the QUIC endpoint used by connections created by the transport.
+	// If unset, it is initialized by the first call to Dial.
+	Endpoint *quic.Endpoint
+
+	// Config is the QUIC configuration used for client connections.
+	// The Config may be nil.
+	//
+	// Dial may clone and modify the Config.
+	// The Config must not be modified after calling Dial.
+	Config *quic.Config
+
+	initOnce sync.Once
+	initErr  error
+}
+
+func (tr *http3Transport) init() error {
+	tr.initOnce.Do(func() {
+		tr.Config = http3initConfig(tr.Config)
+		if tr.Endpoint == nil {
+			tr.Endpoint, tr.initErr = quic.Listen("udp", ":0", nil)
+		}
+	})
+	return tr.initErr
+}
+
+// Dial creates a new HTTP/3 client connection.
+func (tr *http3Transport) Dial(ctx context.Context, target string) (*http3ClientConn, error) {
+	if err := tr.init(); err != nil {
+		return nil, err
+	}
+	qconn, err := tr.Endpoint.Dial(ctx, "udp", target, tr.Config)
+	if err != nil {
+		return nil, err
+	}
+	return http3newClientConn(ctx, qconn)
+}
+
+// A ClientConn is a client HTTP/3 connection.
+//
+// Multiple goroutines may invoke methods on a ClientConn simultaneously.
+type http3ClientConn struct {
+	qconn *quic.Conn
+	http3genericConn
+
+	enc http3qpackEncoder
+	dec http3qpackDecoder
+}
+
+func http3newClientConn(ctx context.Context, qconn *quic.Conn) (*http3ClientConn, error) {
+	cc := &http3ClientConn{
+		qconn: qconn,
+	}
+	cc.enc.init()
+
+	// Create control stream and send SETTINGS frame.
+	controlStream, err := http3newConnStream(ctx, cc.qconn, http3streamTypeControl)
+	if err != nil {
+		return nil, fmt.Errorf("http3: cannot create control stream: %v", err)
+	}
+	controlStream.writeSettings()
+	controlStream.Flush()
+
+	go cc.acceptStreams(qconn, cc)
+	return cc, nil
+}
+
+// Close closes the connection.
+// Any in-flight requests are canceled.
+// Close does not wait for the peer to acknowledge the connection closing.
+func (cc *http3ClientConn) Close() error {
+	// Close the QUIC connection immediately with a status of NO_ERROR.
+	cc.qconn.Abort(nil)
+
+	// Return any existing error from the peer, but don't wait for it.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return cc.qconn.Wait(ctx)
+}
+
+func (cc *http3ClientConn) handleControlStream(st *http3stream) error {
+	// "A SETTINGS frame MUST be sent as the first frame of each control stream [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.4-2
+	if err := st.readSettings(func(settingsType, settingsValue int64) error {
+		switch settingsType {
+		case http3settingsMaxFieldSectionSize:
+			_ = settingsValue // TODO
+		case http3settingsQPACKMaxTableCapacity:
+			_ = settingsValue // TODO
+		case http3settingsQPACKBlockedStreams:
+			_ = settingsValue // TODO
+		default:
+			// Unknown settings types are ignored.
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for {
+		ftype, err := st.readFrameHeader()
+		if err != nil {
+			return err
+		}
+		switch ftype {
+		case http3frameTypeCancelPush:
+			// "If a CANCEL_PUSH frame is received that references a push ID
+			// greater than currently allowed on the connection,
+			// this MUST be treated as a connection error of type H3_ID_ERROR."
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.3-7
+			return &http3connectionError{
+				code:    http3errH3IDError,
+				message: "CANCEL_PUSH received when no MAX_PUSH_ID has been sent",
+			}
+		case http3frameTypeGoaway:
+			// TODO: Wait for requests to complete before closing connection.
+			return http3errH3NoError
+		default:
+			// Unknown frames are ignored.
+			if err := st.discardUnknownFrame(ftype); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (cc *http3ClientConn) handleEncoderStream(*http3stream) error {
+	// TODO
+	return nil
+}
+
+func (cc *http3ClientConn) handleDecoderStream(*http3stream) error {
+	// TODO
+	return nil
+}
+
+func (cc *http3ClientConn) handlePushStream(*http3stream) error {
+	// "A client MUST treat receipt of a push stream as a connection error
+	// of type H3_ID_ERROR when no MAX_PUSH_ID frame has been sent [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.6-3
+	return &http3connectionError{
+		code:    http3errH3IDError,
+		message: "push stream created when no MAX_PUSH_ID has been sent",
+	}
+}
+
+func (cc *http3ClientConn) handleRequestStream(st *http3stream) error {
+	// "Clients MUST treat receipt of a server-initiated bidirectional
+	// stream as a connection error of type H3_STREAM_CREATION_ERROR [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-6.1-3
+	return &http3connectionError{
+		code:    http3errH3StreamCreationError,
+		message: "server created bidirectional stream",
+	}
+}
+
+// abort closes the connection with an error.
+func (cc *http3ClientConn) abort(err error) {
+	if e, ok := err.(*http3connectionError); ok {
+		cc.qconn.Abort(&quic.ApplicationError{
+			Code:   uint64(e.code),
+			Reason: e.message,
+		})
+	} else {
+		cc.qconn.Abort(err)
+	}
+}
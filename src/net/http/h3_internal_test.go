@@ -0,0 +1,41 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// White-box tests for the HTTP/3 client.
+
+package http
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseH3AltSvc(t *testing.T) {
+	now := time.Now()
+	day := now.Add(24 * time.Hour)
+	for _, tt := range []struct {
+		origin string
+		vv     []string
+		want   *h3AltSvc
+	}{
+		{"example.com:443", []string{`h3=":443"`}, &h3AltSvc{"example.com:443", day}},
+		{"example.com:443", []string{`h3=":8443"; ma=60`}, &h3AltSvc{"example.com:8443", now.Add(60 * time.Second)}},
+		{"example.com:443", []string{`h3="EXAMPLE.com:8443";persist=1`}, &h3AltSvc{"example.com:8443", day}},
+		{"example.com:443", []string{`h2=":443", h3=":444"`}, &h3AltSvc{"example.com:444", day}},
+		{"example.com:443", []string{`h2=":443"`, `h3=":444"`}, &h3AltSvc{"example.com:444", day}},
+		{"[::1]:443", []string{`h3=":443"`}, &h3AltSvc{"[::1]:443", day}},
+		{"example.com:443", []string{`clear`}, nil},
+		{"example.com:443", []string{`h3-29=":443"`}, nil},
+		{"example.com:443", []string{`h3="other.example.com:443"`}, nil},
+		{"example.com:443", []string{`h3=:443`}, nil},
+		{"example.com:443", []string{`h3=":0"`}, nil},
+		{"example.com:443", []string{`h3=":99999"`}, nil},
+		{"example.com:443", []string{`h3="443"`}, nil},
+	} {
+		got := parseH3AltSvc(tt.origin, tt.vv, now)
+		if (got == nil) != (tt.want == nil) || (got != nil && (got.addr != tt.want.addr || !got.expires.Equal(tt.want.expires))) {
+			t.Errorf("parseH3AltSvc(%q, %q) = %+v, want %+v", tt.origin, tt.vv, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,890 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// HTTP/3 server.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http/internal/quicwire"
+	"net/textproto"
+	"net/url"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/quic"
+)
+
+// ServeQUIC accepts incoming HTTP/3 connections on the UDP socket conn,
+// creating a new service goroutine for each. The service goroutines
+// read requests and then call s.Handler to reply to them.
+//
+// Files containing a certificate and matching private key for the
+// server must be provided if neither the [Server]'s
+// TLSConfig.Certificates, TLSConfig.GetCertificate nor
+// config.GetConfigForClient are populated.
+//
+// While ServeQUIC is running, responses to HTTP/1 and HTTP/2 requests
+// received by s over TLS include an Alt-Svc header advertising HTTP/3
+// on the port of conn.
+//
+// ServeQUIC always returns a non-nil error and closes conn.
+// After [Server.Shutdown] or [Server.Close], the returned error is [ErrServerClosed].
+func (s *Server) ServeQUIC(conn net.PacketConn, certFile, keyFile string) error {
+	config, err := s.setupTLSConfig(certFile, keyFile, []string{"h3"})
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	config.MinVersion = tls.VersionTLS13
+	qconfig := &quic.Config{
+		TLSConfig: config,
+	}
+	if d := s.idleTimeout(); d > 0 {
+		qconfig.MaxIdleTimeout = d
+	}
+	e, err := quic.NewEndpoint(conn, qconfig)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	acceptCtx, stopAccept := context.WithCancel(context.Background())
+	defer stopAccept()
+	if !s.trackQUICEndpoint(e, stopAccept) {
+		e.Close(canceledContext)
+		return ErrServerClosed
+	}
+	defer s.listenerGroup.Done()
+
+	ctx := context.WithValue(context.Background(), ServerContextKey, s)
+	for {
+		qconn, err := e.Accept(acceptCtx)
+		if err != nil {
+			if s.shuttingDown() {
+				// The endpoint is closed once the connections it
+				// carries are done. See Server.closeIdleConns.
+				return ErrServerClosed
+			}
+			s.untrackQUICEndpoint(e)
+			return err
+		}
+		c := &h3ServerConn{
+			srv:   s,
+			qconn: qconn,
+		}
+		s.trackH3Conn(c, true)
+		go c.serve(ctx)
+	}
+}
+
+// canceledContext is a canceled Context.
+// It is used for non-blocking QUIC operations.
+var canceledContext = func() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}()
+
+// trackQUICEndpoint adds a QUIC endpoint to the set of tracked endpoints.
+// It reports whether the server is still up (not Shutdown or Closed).
+func (s *Server) trackQUICEndpoint(e *quic.Endpoint, stopAccept context.CancelFunc) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shuttingDown() {
+		return false
+	}
+	if s.quicEndpoints == nil {
+		s.quicEndpoints = make(map[*quic.Endpoint]context.CancelFunc)
+	}
+	s.quicEndpoints[e] = stopAccept
+	s.listenerGroup.Add(1)
+	s.updateAltSvcLocked()
+	return true
+}
+
+// untrackQUICEndpoint removes a QUIC endpoint from the set of tracked
+// endpoints and closes it.
+func (s *Server) untrackQUICEndpoint(e *quic.Endpoint) {
+	s.mu.Lock()
+	delete(s.quicEndpoints, e)
+	s.updateAltSvcLocked()
+	s.mu.Unlock()
+	e.Close(canceledContext)
+}
+
+// closeQUICEndpointsLocked closes all QUIC endpoints,
+// aborting any connections they still carry.
+func (s *Server) closeQUICEndpointsLocked() {
+	for e := range s.quicEndpoints {
+		e.Close(canceledContext)
+		delete(s.quicEndpoints, e)
+	}
+	clear(s.activeH3Conn)
+	s.updateAltSvcLocked()
+}
+
+// updateAltSvcLocked updates the Alt-Svc header value advertising
+// the ports on which the server accepts HTTP/3 connections.
+func (s *Server) updateAltSvcLocked() {
+	var ports []int
+	for e := range s.quicEndpoints {
+		ports = append(ports, int(e.LocalAddr().Port()))
+	}
+	if len(ports) == 0 {
+		s.altSvc.Store(nil)
+		return
+	}
+	slices.Sort(ports)
+	var altSvc []string
+	for _, port := range slices.Compact(ports) {
+		altSvc = append(altSvc, fmt.Sprintf(`h3=":%d"`, port))
+	}
+	v := strings.Join(altSvc, ", ")
+	s.altSvc.Store(&v)
+}
+
+func (s *Server) trackH3Conn(c *h3ServerConn, add bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.activeH3Conn == nil {
+		s.activeH3Conn = make(map[*h3ServerConn]struct{})
+	}
+	if add {
+		s.activeH3Conn[c] = struct{}{}
+	} else {
+		delete(s.activeH3Conn, c)
+	}
+}
+
+// An h3ServerConn is the server side of an HTTP/3 connection.
+type h3ServerConn struct {
+	srv   *Server
+	qconn *quic.Conn
+	enc   http3qpackEncoder
+	gc    http3genericConn
+
+	mu                 sync.Mutex // guards the fields below
+	controlStream      *http3stream
+	activeRequests     int
+	maxRequestStreamID int64
+	goawaySent         bool
+}
+
+func (c *h3ServerConn) serve(ctx context.Context) {
+	defer c.srv.trackH3Conn(c, false)
+
+	// Create the control stream and send the SETTINGS frame.
+	st, err := http3newConnStream(ctx, c.qconn, http3streamTypeControl)
+	if err != nil {
+		c.qconn.Abort(err)
+		return
+	}
+	st.writeSettings()
+	st.Flush()
+	c.mu.Lock()
+	c.controlStream = st
+	c.mu.Unlock()
+
+	c.acceptStreams(ctx)
+}
+
+// acceptStreams accepts the streams created by the client,
+// handling each of them in a new goroutine.
+// It returns when the connection closes.
+func (c *h3ServerConn) acceptStreams(ctx context.Context) {
+	// The quic package does not expose stream IDs, but it returns the
+	// streams created by the peer in order, so the IDs of request streams
+	// are known: client-initiated bidirectional streams are numbered
+	// 0, 4, 8, and so on.
+	// https://www.rfc-editor.org/rfc/rfc9000.html#section-2.1
+	var id int64
+	for {
+		// This blocks until a stream is accepted or the connection closes.
+		qs, err := c.qconn.AcceptStream(context.Background())
+		if err != nil {
+			return // connection closed
+		}
+		if qs.IsReadOnly() {
+			go c.gc.handleUnidirectionalStream(http3newStream(qs), c)
+			continue
+		}
+		st := newH3Stream(context.Background(), http3newStream(qs))
+		if !c.startRequest(id) {
+			finishH3Stream(st, c, &http3streamError{
+				code:    http3errH3RequestRejected,
+				message: "request received after GOAWAY",
+			})
+		} else {
+			go func() {
+				defer c.endRequest()
+				finishH3Stream(st, c, c.serveRequest(ctx, st))
+			}()
+		}
+		id += 4
+	}
+}
+
+// idle reports whether the connection has no requests in progress.
+func (c *h3ServerConn) idle() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.activeRequests == 0
+}
+
+// sendGoaway tells the client to stop sending requests on the connection.
+func (c *h3ServerConn) sendGoaway() {
+	c.mu.Lock()
+	if c.goawaySent || c.controlStream == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.goawaySent = true
+	// Requests on streams with lower IDs may still be processed.
+	id := c.maxRequestStreamID + 4
+	c.mu.Unlock()
+
+	st := c.controlStream
+	st.writeVarint(int64(http3frameTypeGoaway))
+	st.writeVarint(int64(quicwire.SizeVarint(uint64(id))))
+	st.writeVarint(id)
+	st.Flush()
+}
+
+// startRequest records the start of a request on the stream with the given ID.
+// It reports false if the request arrived after a GOAWAY frame excluding it.
+func (c *h3ServerConn) startRequest(id int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.goawaySent && id > c.maxRequestStreamID {
+		return false
+	}
+	c.maxRequestStreamID = max(c.maxRequestStreamID, id)
+	c.activeRequests++
+	return true
+}
+
+func (c *h3ServerConn) endRequest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.activeRequests--
+}
+
+func (c *h3ServerConn) handleControlStream(st *http3stream) error {
+	// "A SETTINGS frame MUST be sent as the first frame of each control stream [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.4-2
+	if err := st.readSettings(func(settingsType, settingsValue int64) error {
+		// No settings sent by clients currently affect the server.
+		return nil
+	}); err != nil {
+		return err
+	}
+	for {
+		ftype, err := st.readFrameHeader()
+		if err != nil {
+			return err
+		}
+		switch ftype {
+		case http3frameTypeCancelPush:
+			// "If a server receives a CANCEL_PUSH frame for a push ID
+			// that has not yet been mentioned by a PUSH_PROMISE frame,
+			// this MUST be treated as a connection error of type H3_ID_ERROR."
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.3-8
+			return &http3connectionError{
+				code:    http3errH3IDError,
+				message: "CANCEL_PUSH for unsent push ID",
+			}
+		default:
+			// GOAWAY frames from the client concern server push,
+			// which is not supported, and unknown frames are ignored.
+			if err := st.discardUnknownFrame(ftype); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *h3ServerConn) handleEncoderStream(*http3stream) error {
+	// The dynamic table is not supported.
+	return nil
+}
+
+func (c *h3ServerConn) handleDecoderStream(*http3stream) error {
+	// The dynamic table is not supported.
+	return nil
+}
+
+func (c *h3ServerConn) handlePushStream(*http3stream) error {
+	// "[...] if a server receives a client-initiated push stream,
+	// this MUST be treated as a connection error of type H3_STREAM_CREATION_ERROR."
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-6.2.2-3
+	return &http3connectionError{
+		code:    http3errH3StreamCreationError,
+		message: "client created push stream",
+	}
+}
+
+// handleRequestStream is never called: acceptStreams serves request
+// streams itself, since it knows their IDs.
+func (c *h3ServerConn) handleRequestStream(*http3stream) error {
+	panic("unreachable")
+}
+
+func (c *h3ServerConn) abort(err error) {
+	if e, ok := err.(*http3connectionError); ok {
+		c.qconn.Abort(&quic.ApplicationError{
+			Code:   uint64(e.code),
+			Reason: e.message,
+		})
+	} else {
+		c.qconn.Abort(err)
+	}
+}
+
+// h3PseudoHeader holds the pseudo-header fields of a request.
+type h3PseudoHeader struct {
+	method    string
+	scheme    string
+	path      string
+	authority string
+}
+
+// serveRequest reads a request from st and serves it.
+func (c *h3ServerConn) serveRequest(ctx context.Context, st *h3Stream) error {
+	header, ph, err := readHTTP3RequestHeader(st)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(st, header, ph)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	rw := &h3ResponseWriter{
+		st:             st,
+		header:         make(Header),
+		trailer:        make(Header),
+		bb:             make(h3BodyBuffer, 0, h3DefaultBodyBufferCap),
+		cannotHaveBody: req.Method == "HEAD",
+		bw: &h3BodyWriter{
+			st:     st,
+			remain: -1,
+			name:   "response",
+			enc:    &c.enc,
+		},
+	}
+	if r, ok := req.Body.(*h3BodyReader); ok && req.expectsContinue() {
+		r.send100Continue = func() {
+			rw.WriteHeader(StatusContinue)
+		}
+	}
+	if !c.runHandler(rw, req) {
+		st.CloseRead()
+		st.Reset(uint64(http3errH3InternalError))
+		return nil
+	}
+	req.Body.Close()
+	rw.close()
+	return nil
+}
+
+// runHandler calls the server's handler for req.
+// It reports false if the handler panicked.
+func (c *h3ServerConn) runHandler(rw *h3ResponseWriter, req *Request) (ok bool) {
+	defer func() {
+		if req.MultipartForm != nil {
+			req.MultipartForm.RemoveAll()
+		}
+		if ok {
+			return
+		}
+		// Same as net/http:
+		if e := recover(); e != nil && e != ErrAbortHandler {
+			const size = 64 << 10
+			buf := make([]byte, size)
+			buf = buf[:runtime.Stack(buf, false)]
+			c.srv.logf("http: panic serving %v: %v\n%s", req.RemoteAddr, e, buf)
+		}
+	}()
+	serverHandler{c.srv}.ServeHTTP(rw, req)
+	return true
+}
+
+// readHTTP3RequestHeader reads the HEADERS frame that starts a request.
+func readHTTP3RequestHeader(st *h3Stream) (Header, h3PseudoHeader, error) {
+	ftype, err := st.readFrameHeader()
+	if err != nil {
+		return nil, h3PseudoHeader{}, err
+	}
+	if ftype != http3frameTypeHeaders {
+		return nil, h3PseudoHeader{}, &http3streamError{
+			code:    http3errH3FrameUnexpected,
+			message: "received " + ftype.String() + " frame when expecting HEADERS",
+		}
+	}
+	header := make(Header)
+	var ph h3PseudoHeader
+	var hasMethod, hasScheme, hasPath, hasAuthority bool
+	if err := h3DecodeHeaders(st, func(name, value string) error {
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return &http3streamError{code: http3errH3MessageError, message: "invalid field value"}
+		}
+		var seen *bool
+		switch name {
+		case ":method":
+			seen, ph.method = &hasMethod, value
+		case ":scheme":
+			seen, ph.scheme = &hasScheme, value
+		case ":path":
+			seen, ph.path = &hasPath, value
+		case ":authority":
+			seen, ph.authority = &hasAuthority, value
+		default:
+			if !h3ValidWireHeaderFieldName(name) {
+				return &http3streamError{code: http3errH3MessageError, message: "invalid field name"}
+			}
+			header.Add(name, value)
+			return nil
+		}
+		if *seen {
+			return &http3streamError{code: http3errH3MessageError, message: "duplicate " + name}
+		}
+		*seen = true
+		return nil
+	}); err != nil {
+		return nil, h3PseudoHeader{}, err
+	}
+	if err := st.endFrame(); err != nil {
+		return nil, h3PseudoHeader{}, err
+	}
+	if h3HasConnectionHeader(header) {
+		return nil, h3PseudoHeader{}, &http3streamError{code: http3errH3MessageError, message: "invalid connection-specific header"}
+	}
+
+	// "All HTTP/3 requests MUST include exactly one value for the :method,
+	// :scheme, and :path pseudo-header fields, unless the request is a CONNECT
+	// request"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.3.1-6
+	switch {
+	case !hasMethod:
+		return nil, h3PseudoHeader{}, &http3streamError{code: http3errH3MessageError, message: "missing :method"}
+	case ph.method == "CONNECT" && (hasScheme || hasPath || !hasAuthority):
+		return nil, h3PseudoHeader{}, &http3streamError{code: http3errH3MessageError, message: "CONNECT request must only have :method and :authority pseudo-headers"}
+	case ph.method != "CONNECT" && (!hasScheme || !hasPath):
+		return nil, h3PseudoHeader{}, &http3streamError{code: http3errH3MessageError, message: "missing :scheme or :path"}
+	}
+	return header, ph, nil
+}
+
+// h3HasConnectionHeader reports whether h contains connection-specific
+// fields, which are not allowed in HTTP/3:
+//
+// "An endpoint MUST NOT generate an HTTP/3 field section containing
+// connection-specific fields; any message containing connection-specific
+// fields MUST be treated as malformed."
+//
+// "The only exception to this is the TE header field, which MAY be present in
+// an HTTP/3 request header; when it is, it MUST NOT contain any value other
+// than "trailers"."
+//
+// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.2-3
+func h3HasConnectionHeader(h Header) bool {
+	for _, k := range []string{"Connection", "Keep-Alive", "Proxy-Connection", "Transfer-Encoding", "Upgrade"} {
+		if _, ok := h[k]; ok {
+			return true
+		}
+	}
+	if te, ok := h["Te"]; ok && (len(te) != 1 || te[0] != "trailers") {
+		return true
+	}
+	return false
+}
+
+// newRequest returns the Request for the request header read from st.
+func (c *h3ServerConn) newRequest(st *h3Stream, header Header, ph h3PseudoHeader) (*Request, error) {
+	badRequest := func(msg string) error {
+		return &http3streamError{code: http3errH3MessageError, message: msg}
+	}
+
+	// Merge Cookie headers into one "; "-delimited value.
+	if cookies := header["Cookie"]; len(cookies) > 1 {
+		header.Set("Cookie", strings.Join(cookies, "; "))
+	}
+
+	// Setup Trailers
+	var trailer Header
+	for _, v := range header["Trailer"] {
+		for _, key := range strings.Split(v, ",") {
+			key = CanonicalHeaderKey(textproto.TrimString(key))
+			switch key {
+			case "Transfer-Encoding", "Trailer", "Content-Length":
+				// Bogus. (copy of http1 rules)
+				// Ignore.
+			default:
+				if trailer == nil {
+					trailer = make(Header)
+				}
+				trailer[key] = nil
+			}
+		}
+	}
+	delete(header, "Trailer")
+
+	// "The :authority pseudo-header field MUST NOT include the deprecated
+	// userinfo subcomponent for URIs of scheme "http" or "https"."
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.3.1-2.3.1
+	if strings.IndexByte(ph.authority, '@') >= 0 && (ph.scheme == "http" || ph.scheme == "https") {
+		return nil, badRequest("userinfo in :authority")
+	}
+
+	var u *url.URL
+	var requestURI string
+	if ph.method == "CONNECT" {
+		u = &url.URL{Host: ph.authority}
+		requestURI = ph.authority // mimic HTTP/1 server behavior
+	} else {
+		if ph.path == "" || (ph.path[0] != '/' && ph.path != "*") {
+			return nil, badRequest("invalid :path")
+		}
+		var err error
+		u, err = url.ParseRequestURI(ph.path)
+		if err != nil {
+			return nil, badRequest("invalid :path")
+		}
+		requestURI = ph.path
+	}
+
+	contentLength := int64(-1)
+	if clens := header["Content-Length"]; len(clens) > 0 {
+		n, err := parseContentLength(clens)
+		if err != nil {
+			return nil, badRequest("invalid Content-Length")
+		}
+		contentLength = n
+	}
+	var body io.ReadCloser = NoBody
+	if contentLength != 0 || trailer != nil {
+		body = &h3BodyReader{
+			st:            st,
+			remain:        contentLength,
+			trailer:       trailer,
+			filterTrailer: true,
+		}
+	}
+
+	tlsState := c.qconn.ConnectionState()
+	req := &Request{
+		Method:        ph.method,
+		URL:           u,
+		RemoteAddr:    c.qconn.RemoteAddr().String(),
+		Header:        header,
+		RequestURI:    requestURI,
+		Proto:         "HTTP/3.0",
+		ProtoMajor:    3,
+		ProtoMinor:    0,
+		TLS:           &tlsState,
+		Host:          ph.authority,
+		Body:          body,
+		ContentLength: contentLength,
+		Trailer:       trailer,
+	}
+	if req.Host == "" {
+		req.Host = header.Get("Host")
+	}
+	return req, nil
+}
+
+// h3ResponseWriter is the ResponseWriter for HTTP/3 requests.
+type h3ResponseWriter struct {
+	st             *h3Stream
+	bw             *h3BodyWriter
+	mu             sync.Mutex
+	header         Header
+	snapHeader     Header // snapshot of header at WriteHeader time
+	trailer        Header
+	bb             h3BodyBuffer
+	wroteHeader    bool  // non-1xx header has been (logically) written
+	statusCode     int   // status of the response that will be sent in HEADERS frame
+	statusCodeSet  bool  // status of the response has been set via a call to WriteHeader
+	cannotHaveBody bool  // response should not have a body (e.g. response to a HEAD request)
+	bodyLenLeft    int64 // how much of the content body is left to be sent, or -1 if unknown
+}
+
+func (rw *h3ResponseWriter) Header() Header {
+	return rw.header
+}
+
+// prepareTrailerForWriteLocked populates any pre-declared trailer
+// with its value, and passes it to the body writer so it can be written
+// after the body.
+// Caller must hold rw.mu.
+func (rw *h3ResponseWriter) prepareTrailerForWriteLocked() {
+	for name := range rw.trailer {
+		if val, ok := rw.header[name]; ok {
+			rw.trailer[name] = val
+		} else {
+			delete(rw.trailer, name)
+		}
+	}
+	for name, vals := range rw.header {
+		if name, found := strings.CutPrefix(name, TrailerPrefix); found {
+			rw.trailer[CanonicalHeaderKey(name)] = vals
+		}
+	}
+	if len(rw.trailer) > 0 {
+		rw.bw.trailer = rw.trailer
+	}
+}
+
+// writeHeaderLockedOnce writes the final response header.
+// If rw.wroteHeader is true, calling this method is a no-op.
+// Caller must hold rw.mu.
+func (rw *h3ResponseWriter) writeHeaderLockedOnce() {
+	if rw.wroteHeader {
+		return
+	}
+	if !bodyAllowedForStatus(rw.statusCode) {
+		rw.cannotHaveBody = true
+	}
+	// If there is any Trailer declared, save them so we know which trailers
+	// have been pre-declared. Also, write back the extracted value, which is
+	// canonicalized, for consistency.
+	if _, ok := rw.snapHeader["Trailer"]; ok {
+		for _, v := range rw.snapHeader["Trailer"] {
+			foreachHeaderElement(v, func(key string) {
+				key = CanonicalHeaderKey(key)
+				if httpguts.ValidTrailerHeader(key) {
+					rw.trailer[key] = nil
+				}
+			})
+		}
+		rw.snapHeader.Set("Trailer", strings.Join(slices.Sorted(maps.Keys(rw.trailer)), ", "))
+	}
+	rw.bb.inferHeader(rw.snapHeader, rw.statusCode)
+	rw.writeHeaderFrameLocked(rw.statusCode, rw.snapHeader)
+	rw.wroteHeader = true
+}
+
+// writeHeaderFrameLocked writes a HEADERS frame with the given status and header.
+// Caller must hold rw.mu.
+func (rw *h3ResponseWriter) writeHeaderFrameLocked(statusCode int, h Header) {
+	encHeaders := h3EncodeHeaders(rw.bw.enc, func(yield func(name, value string)) {
+		yield(":status", strconv.Itoa(statusCode))
+		for name, values := range h {
+			if isInfoStatus(statusCode) && (name == "Content-Length" || name == "Transfer-Encoding") {
+				continue
+			}
+			if !httpguts.ValidHeaderFieldName(name) {
+				continue
+			}
+			for _, val := range values {
+				if !httpguts.ValidHeaderFieldValue(val) {
+					continue
+				}
+				yield(name, val)
+			}
+		}
+	})
+	rw.st.writeFrame(http3frameTypeHeaders, encHeaders)
+}
+
+func isInfoStatus(status int) bool {
+	return status >= 100 && status < 200
+}
+
+func (rw *h3ResponseWriter) WriteHeader(statusCode int) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if rw.statusCodeSet {
+		return
+	}
+	checkWriteHeaderCode(statusCode)
+
+	// Informational headers can be sent multiple times, and should be flushed
+	// immediately.
+	if isInfoStatus(statusCode) {
+		if !rw.wroteHeader {
+			rw.writeHeaderFrameLocked(statusCode, rw.header)
+			rw.st.Flush()
+		}
+		return
+	}
+
+	// Non-informational headers should only be set once, and should be
+	// buffered.
+	rw.statusCodeSet = true
+	rw.statusCode = statusCode
+	rw.snapHeader = rw.header.Clone()
+	rw.bodyLenLeft = -1 // unknown
+	if cl := rw.header.Get("Content-Length"); cl != "" {
+		if n, err := strconv.ParseInt(cl, 10, 64); err == nil && n >= 0 {
+			rw.bodyLenLeft = n
+		}
+	}
+}
+
+// trimWriteLocked trims b such that its length does not exceed
+// rw.bodyLenLeft. It updates rw.bodyLenLeft, and reports whether b
+// was trimmed.
+// Caller must hold rw.mu.
+func (rw *h3ResponseWriter) trimWriteLocked(b []byte) ([]byte, bool) {
+	if rw.bodyLenLeft < 0 {
+		return b, false
+	}
+	n := min(int64(len(b)), rw.bodyLenLeft)
+	rw.bodyLenLeft -= n
+	return b[:n], n != int64(len(b))
+}
+
+func (rw *h3ResponseWriter) Write(b []byte) (n int, err error) {
+	// Calling Write implicitly calls WriteHeader(200) if WriteHeader has not
+	// been called before.
+	rw.WriteHeader(StatusOK)
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if !bodyAllowedForStatus(rw.statusCode) {
+		return 0, ErrBodyNotAllowed
+	}
+
+	b, trimmed := rw.trimWriteLocked(b)
+	if trimmed {
+		defer func() {
+			err = ErrContentLength
+		}()
+	}
+
+	// If b fits entirely in our body buffer, save it to the buffer and return
+	// early so we can coalesce small writes.
+	// As a special case, we always want to save b to the buffer even when b is
+	// big if we had yet to write our header, so we can infer headers like
+	// "Content-Type" with as much information as possible.
+	initialBLen := len(b)
+	initialBufLen := len(rw.bb)
+	if !rw.wroteHeader || len(b) <= cap(rw.bb)-len(rw.bb) {
+		b = rw.bb.write(b)
+		if len(b) == 0 {
+			return initialBLen, nil
+		}
+	}
+
+	// Reaching this point means that our buffer has been sufficiently filled.
+	// Therefore, we now want to:
+	// 1. Infer and write response headers based on our body buffer, if not
+	// done yet.
+	// 2. Write our body buffer and the rest of b (if any).
+	// 3. Reset the current body buffer so it can be used again.
+	rw.writeHeaderLockedOnce()
+	if rw.cannotHaveBody {
+		return initialBLen, nil
+	}
+	if n, err := rw.bw.writeBuffers(rw.bb, b); err != nil {
+		return max(0, n-initialBufLen), err
+	}
+	rw.bb.discard()
+	return initialBLen, nil
+}
+
+func (rw *h3ResponseWriter) SetReadDeadline(deadline time.Time) error {
+	rw.st.SetReadDeadline(deadline)
+	return nil
+}
+
+func (rw *h3ResponseWriter) SetWriteDeadline(deadline time.Time) error {
+	rw.st.SetWriteDeadline(deadline)
+	return nil
+}
+
+func (rw *h3ResponseWriter) EnableFullDuplex() error {
+	return nil
+}
+
+func (rw *h3ResponseWriter) Flush() { rw.FlushError() }
+
+func (rw *h3ResponseWriter) FlushError() error {
+	// Calling Flush implicitly calls WriteHeader(200) if WriteHeader has not
+	// been called before.
+	rw.WriteHeader(StatusOK)
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.writeHeaderLockedOnce()
+	if !rw.cannotHaveBody {
+		_, err := rw.bw.Write(rw.bb)
+		rw.bb.discard()
+		if err != nil {
+			return err
+		}
+	}
+	return rw.st.Flush()
+}
+
+// close finishes the response after the handler returns.
+func (rw *h3ResponseWriter) close() error {
+	retErr := rw.FlushError()
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	rw.prepareTrailerForWriteLocked()
+	if err := rw.bw.Close(); retErr == nil {
+		retErr = err
+	}
+	if rw.st.writeDeadlineExceeded() {
+		rw.st.Reset(uint64(http3errH3RequestCancelled))
+	} else if err := rw.st.Close(); retErr == nil {
+		retErr = err
+	}
+	return retErr
+}
+
+// h3DefaultBodyBufferCap is the number of bytes of body that we are
+// willing to save in a buffer for the sake of inferring headers and coalescing
+// small writes. 512 is consistent with how much DetectContentType is willing
+// to read.
+const h3DefaultBodyBufferCap = 512
+
+// h3BodyBuffer is a buffer used to store body content of a response.
+type h3BodyBuffer []byte
+
+// write writes b to the buffer. It returns the remainder of b that
+// could not be written to the buffer, if any.
+func (bb *h3BodyBuffer) write(b []byte) []byte {
+	n := min(len(b), cap(*bb)-len(*bb))
+	*bb = append(*bb, b[:n]...)
+	return b[n:]
+}
+
+// discard resets the buffer so it can be used again.
+func (bb *h3BodyBuffer) discard() {
+	*bb = (*bb)[:0]
+}
+
+// inferHeader populates h with the header values that we can infer from the
+// buffer content, if not already explicitly set. It must be called once,
+// before the response header is written and before discard is called.
+func (bb *h3BodyBuffer) inferHeader(h Header, status int) {
+	if _, ok := h["Date"]; !ok {
+		h.Set("Date", time.Now().UTC().Format(TimeFormat))
+	}
+	// If the Content-Encoding is non-blank, we shouldn't
+	// sniff the body. See Issue golang.org/issue/31753.
+	_, hasCE := h["Content-Encoding"]
+	_, hasCT := h["Content-Type"]
+	if !hasCE && !hasCT && bodyAllowedForStatus(status) && len(*bb) > 0 {
+		h.Set("Content-Type", DetectContentType(*bb))
+	}
+}
@@ -0,0 +1,506 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// HTTP/3 streams and message bodies.
+//
+// The framing and QPACK primitives used here are bundled from
+// golang.org/x/net/internal/http3 in h3_bundle.go.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http/internal/ascii"
+	"net/textproto"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/quic"
+)
+
+// An h3Stream is an HTTP/3 stream with read and write deadlines.
+type h3Stream struct {
+	*http3stream
+
+	readDeadline  h3Deadline
+	writeDeadline h3Deadline
+}
+
+// newH3ConnStream creates a new stream on a connection.
+// It writes the stream header for unidirectional streams.
+// Reads and writes on the stream are canceled when ctx is done.
+//
+// The stream returned by newH3ConnStream is not flushed,
+// and will not be sent to the peer until the caller calls
+// Flush or writes enough data to the stream.
+func newH3ConnStream(ctx context.Context, qconn *quic.Conn, stype http3streamType) (*h3Stream, error) {
+	st, err := http3newConnStream(ctx, qconn, stype)
+	if err != nil {
+		return nil, err
+	}
+	return newH3Stream(ctx, st), nil
+}
+
+func newH3Stream(ctx context.Context, st *http3stream) *h3Stream {
+	readCtx, readCancel := context.WithCancelCause(ctx)
+	writeCtx, writeCancel := context.WithCancelCause(ctx)
+	st.stream.SetReadContext(readCtx)
+	st.stream.SetWriteContext(writeCtx)
+	return &h3Stream{
+		http3stream: st,
+		readDeadline: h3Deadline{
+			ctx:    readCtx,
+			cancel: readCancel,
+		},
+		writeDeadline: h3Deadline{
+			ctx:    writeCtx,
+			cancel: writeCancel,
+		},
+	}
+}
+
+// SetReadDeadline sets the deadline for reads from st.
+// Once the deadline is exceeded, it can no longer be extended.
+func (st *h3Stream) SetReadDeadline(t time.Time) {
+	st.readDeadline.set(t)
+}
+
+// SetWriteDeadline sets the deadline for writes to st.
+// Once the deadline is exceeded, it can no longer be extended.
+func (st *h3Stream) SetWriteDeadline(t time.Time) {
+	st.writeDeadline.set(t)
+}
+
+// writeDeadlineExceeded reports whether the write deadline of st has passed.
+func (st *h3Stream) writeDeadlineExceeded() bool {
+	return errors.Is(st.writeDeadline.err(), os.ErrDeadlineExceeded)
+}
+
+// Close closes st, waiting for the peer to acknowledge the data written to it.
+func (st *h3Stream) Close() error {
+	st.readDeadline.stop()
+	st.writeDeadline.stop()
+	return st.stream.Close()
+}
+
+// CloseRead aborts reads on st.
+func (st *h3Stream) CloseRead() {
+	st.readDeadline.stop()
+	st.stream.CloseRead()
+}
+
+// CloseWrite ends the data written to st.
+func (st *h3Stream) CloseWrite() {
+	st.writeDeadline.stop()
+	st.stream.CloseWrite()
+}
+
+// Reset aborts writes on st with the given error code.
+func (st *h3Stream) Reset(code uint64) {
+	st.readDeadline.stop()
+	st.writeDeadline.stop()
+	st.stream.Reset(code)
+}
+
+// The I/O methods below check the deadline before calling into the
+// QUIC layer, which allows reads and writes to succeed from its buffers
+// even once their context has been canceled. Errors caused by an
+// exceeded deadline are reported as [os.ErrDeadlineExceeded].
+
+// readFrameHeader reads the type and length fields of an HTTP/3 frame.
+func (st *h3Stream) readFrameHeader() (http3frameType, error) {
+	if err := st.readDeadline.err(); err != nil {
+		return 0, err
+	}
+	ftype, err := st.http3stream.readFrameHeader()
+	return ftype, st.readDeadline.errOf(err)
+}
+
+// ReadByte reads one byte from the stream.
+func (st *h3Stream) ReadByte() (byte, error) {
+	if err := st.readDeadline.err(); err != nil {
+		return 0, err
+	}
+	b, err := st.http3stream.ReadByte()
+	return b, st.readDeadline.errOf(err)
+}
+
+// Read reads from the stream.
+func (st *h3Stream) Read(b []byte) (int, error) {
+	if err := st.readDeadline.err(); err != nil {
+		return 0, err
+	}
+	n, err := st.http3stream.Read(b)
+	return n, st.readDeadline.errOf(err)
+}
+
+// Write writes to the stream.
+func (st *h3Stream) Write(b []byte) (int, error) {
+	if err := st.writeDeadline.err(); err != nil {
+		return 0, err
+	}
+	n, err := st.stream.Write(b)
+	return n, st.writeDeadline.errOf(err)
+}
+
+// Flush commits data written to the stream.
+func (st *h3Stream) Flush() error {
+	if err := st.writeDeadline.err(); err != nil {
+		return err
+	}
+	st.stream.Flush()
+	return st.writeDeadline.err()
+}
+
+// writeFrame writes a frame of type ftype with the payload p.
+func (st *h3Stream) writeFrame(ftype http3frameType, p []byte) {
+	st.writeVarint(int64(ftype))
+	st.writeVarint(int64(len(p)))
+	st.Write(p)
+}
+
+// h3Deadline manages ctx, and cancels it when timer expires, with
+// [os.ErrDeadlineExceeded] as the cause. If the deadline is manually stopped
+// before timer expires, the context will be canceled with [context.Canceled]
+// as the cause. Once a deadline is exceeded, its timer can no longer be
+// extended.
+//
+// This lets HTTP/3 support time-based deadlines using the quic package's
+// support for context-based deadlines.
+type h3Deadline struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	mu    sync.Mutex // guards timer
+	timer *time.Timer
+}
+
+// stopTimerLocked stops the deadline timer and sets it to nil.
+// The caller must hold d.mu.
+func (d *h3Deadline) stopTimerLocked() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+// stop stops the deadline timer and cancels the context with
+// [context.Canceled] as the cause.
+func (d *h3Deadline) stop() {
+	d.mu.Lock()
+	d.stopTimerLocked()
+	d.mu.Unlock()
+	d.cancel(context.Canceled)
+}
+
+// err returns the deadline's context cancelation cause, if any.
+func (d *h3Deadline) err() error {
+	return context.Cause(d.ctx)
+}
+
+// errOf returns the deadline's context cancelation cause if err is non-nil.
+// It reports whether an error returned by the QUIC layer is caused by
+// the deadline.
+func (d *h3Deadline) errOf(err error) error {
+	if dErr := d.err(); err != nil && dErr != nil {
+		return dErr
+	}
+	return err
+}
+
+// set configures a new deadline.
+// Once the deadline is exceeded, it remains in the expired (sticky) state,
+// and subsequent attempts to extend or reset it are ignored.
+func (d *h3Deadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ctx.Err() != nil {
+		// Already expired, sticky error.
+		return
+	}
+	if t.IsZero() {
+		d.stopTimerLocked()
+		return
+	}
+	dur := time.Until(t)
+	if dur <= 0 {
+		d.stopTimerLocked()
+		d.cancel(os.ErrDeadlineExceeded)
+		return
+	}
+	if d.timer == nil {
+		d.timer = time.AfterFunc(dur, func() {
+			d.cancel(os.ErrDeadlineExceeded)
+		})
+	} else {
+		d.timer.Reset(dur)
+	}
+}
+
+// finishH3Stream closes or resets st according to the error returned
+// by the handler of the stream, aborting the connection with h
+// for a connection error.
+func finishH3Stream(st *h3Stream, h http3streamHandler, err error) {
+	switch err := err.(type) {
+	case *http3connectionError:
+		h.abort(err)
+	case nil:
+		st.CloseRead()
+		st.CloseWrite()
+	case *http3streamError:
+		st.CloseRead()
+		st.Reset(uint64(err.code))
+	default:
+		st.CloseRead()
+		st.Reset(uint64(http3errH3InternalError))
+	}
+}
+
+// h3EncodeHeaders encodes the fields produced by headers as a QPACK field
+// section. Field names are lowercased; fields with non-ASCII names are
+// skipped.
+func h3EncodeHeaders(enc *http3qpackEncoder, headers func(yield func(name, value string))) []byte {
+	enc.init()
+	return enc.encode(func(f func(itype http3indexType, name, value string)) {
+		headers(func(name, value string) {
+			if name, ok := ascii.ToLower(name); ok {
+				f(http3mayIndex, name, value)
+			}
+		})
+	})
+}
+
+// h3DecodeHeaders decodes the QPACK field section of the current frame of st,
+// calling f for each field.
+func h3DecodeHeaders(st *h3Stream, f func(name, value string) error) error {
+	var dec http3qpackDecoder
+	err := dec.decode(st.http3stream, func(_ http3indexType, name, value string) error {
+		return f(name, value)
+	})
+	return st.readDeadline.errOf(err)
+}
+
+// h3ValidWireHeaderFieldName reports whether v is a valid header field name
+// as sent on the wire, which must be lowercase.
+func h3ValidWireHeaderFieldName(v string) bool {
+	if len(v) == 0 {
+		return false
+	}
+	for _, r := range v {
+		if !httpguts.IsTokenRune(r) {
+			return false
+		}
+		if 'A' <= r && r <= 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// An h3BodyWriter writes a request or response body to a stream
+// as a series of DATA frames, followed by an optional trailer.
+type h3BodyWriter struct {
+	st      *h3Stream
+	remain  int64               // -1 when the content length is not known
+	flush   bool                // flush the stream after every write
+	name    string              // "request" or "response"
+	trailer map[string][]string // trailer written when the h3BodyWriter is closed
+	enc     *http3qpackEncoder  // QPACK encoder used by the connection
+}
+
+// writeBuffers writes the contents of ps in a single DATA frame.
+func (w *h3BodyWriter) writeBuffers(ps ...[]byte) (n int, err error) {
+	var size int64
+	for _, p := range ps {
+		size += int64(len(p))
+	}
+	if size == 0 {
+		return 0, nil
+	}
+	if w.remain >= 0 && size > w.remain {
+		return 0, &http3streamError{
+			code:    http3errH3InternalError,
+			message: w.name + " body longer than specified content length",
+		}
+	}
+	w.st.writeVarint(int64(http3frameTypeData))
+	w.st.writeVarint(size)
+	for _, p := range ps {
+		var n2 int
+		n2, err = w.st.Write(p)
+		n += n2
+		if w.remain >= 0 {
+			w.remain -= int64(n2)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if w.flush && err == nil {
+		err = w.st.Flush()
+	}
+	if err != nil {
+		err = fmt.Errorf("writing %v body: %w", w.name, err)
+	}
+	return n, err
+}
+
+func (w *h3BodyWriter) Write(p []byte) (n int, err error) {
+	return w.writeBuffers(p)
+}
+
+// Close writes the trailer, if any, and ends the stream.
+func (w *h3BodyWriter) Close() error {
+	if w.remain > 0 {
+		return errors.New(w.name + " body shorter than specified content length")
+	}
+	if len(w.trailer) > 0 {
+		encTrailer := h3EncodeHeaders(w.enc, func(yield func(name, value string)) {
+			for name, values := range w.trailer {
+				if !httpguts.ValidHeaderFieldName(name) {
+					continue
+				}
+				for _, val := range values {
+					if !httpguts.ValidHeaderFieldValue(val) {
+						continue
+					}
+					yield(name, val)
+				}
+			}
+		})
+		w.st.writeFrame(http3frameTypeHeaders, encTrailer)
+	}
+	w.st.CloseWrite()
+	return nil
+}
+
+// An h3BodyReader reads a request or response body from a stream.
+type h3BodyReader struct {
+	st     *h3Stream
+	remain int64 // -1 when the content length is not known
+
+	// send100Continue, if non-nil, is called before the first read.
+	send100Continue func()
+
+	// trailer, if non-nil, receives the fields of a trailer
+	// sent after the body. If filterTrailer is set, only the
+	// fields already present in trailer are kept.
+	trailer       map[string][]string
+	filterTrailer bool
+
+	mu  sync.Mutex
+	err error
+}
+
+func (r *h3BodyReader) Read(p []byte) (n int, err error) {
+	// The HTTP/1 and HTTP/2 implementations both permit concurrent reads from a body,
+	// in the sense that the race detector won't complain.
+	// Use a mutex here to provide the same behavior.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.send100Continue != nil {
+		r.send100Continue()
+		r.send100Continue = nil
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	defer func() {
+		if err != nil {
+			r.err = err
+		}
+	}()
+	st := r.st
+	if st.lim == 0 {
+		// We've finished reading the previous DATA frame, so end it.
+		if err := st.endFrame(); err != nil {
+			return 0, err
+		}
+	}
+	// Read the next DATA frame header,
+	// if we aren't already in the middle of one.
+	for st.lim < 0 {
+		ftype, err := st.readFrameHeader()
+		if err == io.EOF && r.remain > 0 {
+			return 0, &http3streamError{
+				code:    http3errH3MessageError,
+				message: "body shorter than content-length",
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch ftype {
+		case http3frameTypeData:
+			if r.remain >= 0 && st.lim > r.remain {
+				return 0, &http3streamError{
+					code:    http3errH3MessageError,
+					message: "body longer than content-length",
+				}
+			}
+			// Fall out of the loop and process the frame body below.
+		case http3frameTypeHeaders:
+			// This HEADERS frame contains the message trailers.
+			if r.remain > 0 {
+				return 0, &http3streamError{
+					code:    http3errH3MessageError,
+					message: "body shorter than content-length",
+				}
+			}
+			if err := h3DecodeHeaders(st, func(name, value string) error {
+				if r.trailer == nil {
+					return nil
+				}
+				if !h3ValidWireHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+					return nil
+				}
+				name = textproto.CanonicalMIMEHeaderKey(name)
+				if _, ok := r.trailer[name]; ok || !r.filterTrailer {
+					r.trailer[name] = append(r.trailer[name], value)
+				}
+				return nil
+			}); err != nil {
+				return 0, err
+			}
+			if err := st.discardFrame(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		default:
+			if err := st.discardUnknownFrame(ftype); err != nil {
+				return 0, err
+			}
+		}
+	}
+	// We are now reading the content of a DATA frame.
+	// Fill the read buffer or read to the end of the frame,
+	// whichever comes first.
+	if int64(len(p)) > st.lim {
+		p = p[:st.lim]
+	}
+	n, err = st.Read(p)
+	if r.remain > 0 {
+		r.remain -= int64(n)
+	}
+	return n, err
+}
+
+func (r *h3BodyReader) Close() error {
+	// Unlike the HTTP/1 and HTTP/2 body readers (at the time of this comment being written),
+	// calling Close concurrently with Read will interrupt the read.
+	r.st.CloseRead()
+	// Make sure that any data that has already been written to bodyReader
+	// cannot be read after it has been closed.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = net.ErrClosed
+	r.remain = 0
+	return nil
+}
@@ -0,0 +1,347 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	. "net/http"
+	"net/http/httptest"
+	"net/http/internal/testcert"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type h3TestServer struct {
+	*Server
+	URL    string
+	client *Client
+	done   chan error
+}
+
+// newH3TestServer starts a Server serving HTTP/3 on a loopback UDP port,
+// and returns it along with a Client that uses HTTP/3.
+func newH3TestServer(t *testing.T, h Handler) *h3TestServer {
+	t.Helper()
+	cert, err := tls.X509KeyPair(testcert.LocalhostCert, testcert.LocalhostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP loopback: %v", err)
+	}
+	ts := &h3TestServer{
+		Server: &Server{
+			Handler:   h,
+			TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+		},
+		URL:  "https://" + conn.LocalAddr().String(),
+		done: make(chan error, 1),
+	}
+	go func() {
+		ts.done <- ts.ServeQUIC(conn, "", "")
+	}()
+
+	tr := &Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		Protocols:       new(Protocols),
+	}
+	tr.Protocols.SetHTTP3(true)
+	ts.client = &Client{Transport: tr}
+
+	t.Cleanup(func() {
+		tr.CloseIdleConnections()
+		ts.Close()
+		if err := <-ts.done; err != ErrServerClosed {
+			t.Errorf("ServeQUIC = %v, want ErrServerClosed", err)
+		}
+	})
+	return ts
+}
+
+func TestHTTP3Get(t *testing.T) {
+	ts := newH3TestServer(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		if r.ProtoMajor != 3 || r.TLS == nil {
+			t.Errorf("request Proto = %q, TLS = %v; want HTTP/3.0 with TLS", r.Proto, r.TLS)
+		}
+		w.Header().Set("X-Path", r.URL.Path)
+		io.WriteString(w, "hello, "+r.URL.Query().Get("name"))
+	}))
+	resp, err := ts.client.Get(ts.URL + "/greet?name=gopher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 || resp.Proto != "HTTP/3.0" {
+		t.Errorf("got %v %v; want 200 HTTP/3.0", resp.StatusCode, resp.Proto)
+	}
+	if got, want := resp.Header.Get("X-Path"), "/greet"; got != want {
+		t.Errorf("X-Path = %q, want %q", got, want)
+	}
+	if resp.TLS == nil || resp.TLS.NegotiatedProtocol != "h3" {
+		t.Errorf("resp.TLS = %v, want negotiated protocol h3", resp.TLS)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(body), "hello, gopher"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestHTTP3PostWithTrailers(t *testing.T) {
+	ts := newH3TestServer(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request body: %v", err)
+		}
+		w.Header().Set("Trailer", "X-Checksum")
+		fmt.Fprintf(w, "%s %d %q", r.Method, len(body), r.Trailer.Get("X-Req-Trailer"))
+		w.Header().Set("X-Checksum", "abc")
+	}))
+	req, err := NewRequest("POST", ts.URL, io.MultiReader(strings.NewReader("some "), strings.NewReader("data")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Trailer = Header{"X-Req-Trailer": nil}
+	req.Body = &trailerSettingReader{req.Body, req.Trailer}
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(body), `POST 9 "v"`; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+	if got, want := resp.Trailer.Get("X-Checksum"), "abc"; got != want {
+		t.Errorf("trailer X-Checksum = %q, want %q", got, want)
+	}
+}
+
+// trailerSettingReader sets the request trailer when the body reaches EOF.
+type trailerSettingReader struct {
+	io.ReadCloser
+	trailer Header
+}
+
+func (r *trailerSettingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		r.trailer.Set("X-Req-Trailer", "v")
+	}
+	return n, err
+}
+
+func TestHTTP3NotFound(t *testing.T) {
+	ts := newH3TestServer(t, NotFoundHandler())
+	resp, err := ts.client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != StatusNotFound {
+		t.Errorf("status = %v, want %v", resp.StatusCode, StatusNotFound)
+	}
+}
+
+func TestHTTP3ProxyRejected(t *testing.T) {
+	tr := &Transport{
+		Proxy:     func(*Request) (*url.URL, error) { return url.Parse("http://proxy.invalid") },
+		Protocols: new(Protocols),
+	}
+	tr.Protocols.SetHTTP3(true)
+	defer tr.CloseIdleConnections()
+	_, err := (&Client{Transport: tr}).Get("https://example.invalid/")
+	if err == nil {
+		t.Fatal("request through proxy succeeded, want error")
+	}
+}
+
+func TestHTTP3AltSvc(t *testing.T) {
+	h := HandlerFunc(func(w ResponseWriter, r *Request) {})
+	ts := newH3TestServer(t, h)
+
+	// Serve HTTP/1 over TLS with the same Server.
+	st := httptest.NewUnstartedServer(h)
+	st.Config = ts.Server
+	st.StartTLS()
+	defer st.Close()
+
+	resp, err := st.Client().Get(st.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(ts.URL, "https://"))
+	if got, want := resp.Header.Get("Alt-Svc"), fmt.Sprintf(`h3=":%s"`, port); got != want {
+		t.Errorf("Alt-Svc = %q, want %q", got, want)
+	}
+}
+
+// newH3AltSvcTestServer starts an HTTP/3 test server that also serves
+// HTTP/1 over TLS, advertising HTTP/3 with an Alt-Svc header,
+// and returns the URL of the HTTP/1 server.
+func newH3AltSvcTestServer(t *testing.T, h Handler) string {
+	ts := newH3TestServer(t, h)
+	st := httptest.NewUnstartedServer(h)
+	st.Config = ts.Server
+	st.StartTLS()
+	t.Cleanup(st.Close)
+	return st.URL
+}
+
+// newH3FallbackTransport returns a Transport that may use HTTP/1 and HTTP/3.
+func newH3FallbackTransport(t *testing.T) *Transport {
+	tr := &Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		Protocols:       new(Protocols),
+	}
+	tr.Protocols.SetHTTP1(true)
+	tr.Protocols.SetHTTP3(true)
+	t.Cleanup(tr.CloseIdleConnections)
+	return tr
+}
+
+// getProto sends a GET request for u and returns the protocol of the response.
+func getProto(t *testing.T, c *Client, u string) (string, error) {
+	t.Helper()
+	resp, err := c.Get(u)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Proto, nil
+}
+
+func TestHTTP3AltSvcUpgrade(t *testing.T) {
+	tsURL := newH3AltSvcTestServer(t, HandlerFunc(func(w ResponseWriter, r *Request) {}))
+	c := &Client{Transport: newH3FallbackTransport(t)}
+	for i, want := range []string{"HTTP/1.1", "HTTP/3.0", "HTTP/3.0"} {
+		got, err := getProto(t, c, tsURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("request %v: Proto = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestHTTP3AltSvcFallback(t *testing.T) {
+	// Advertise HTTP/3 on a UDP port which never responds.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP loopback: %v", err)
+	}
+	defer conn.Close()
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+	st := httptest.NewTLSServer(HandlerFunc(func(w ResponseWriter, r *Request) {
+		w.Header().Set("Alt-Svc", fmt.Sprintf(`h3=":%s"; ma=3600`, port))
+	}))
+	defer st.Close()
+
+	tr := newH3FallbackTransport(t)
+	tr.TLSHandshakeTimeout = 50 * time.Millisecond
+	c := &Client{Transport: tr}
+	for i := range 3 {
+		got, err := getProto(t, c, st.URL)
+		if err != nil {
+			t.Fatalf("request %v: %v", i, err)
+		}
+		if want := "HTTP/1.1"; got != want {
+			t.Errorf("request %v: Proto = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestHTTP3AltSvcProxy(t *testing.T) {
+	tsURL := newH3AltSvcTestServer(t, HandlerFunc(func(w ResponseWriter, r *Request) {}))
+	gotConnect := make(chan struct{}, 1)
+	proxy := httptest.NewServer(HandlerFunc(func(w ResponseWriter, r *Request) {
+		if r.Method == "CONNECT" {
+			gotConnect <- struct{}{}
+		}
+		w.WriteHeader(StatusForbidden)
+	}))
+	defer proxy.Close()
+
+	tr := newH3FallbackTransport(t)
+	useProxy := false
+	tr.Proxy = func(*Request) (*url.URL, error) {
+		if !useProxy {
+			return nil, nil
+		}
+		return url.Parse(proxy.URL)
+	}
+	c := &Client{Transport: tr}
+	// Learn that the server supports HTTP/3.
+	if _, err := getProto(t, c, tsURL); err != nil {
+		t.Fatal(err)
+	}
+	// Requests through a proxy use TCP.
+	useProxy = true
+	if _, err := getProto(t, c, tsURL); err == nil {
+		t.Errorf("request through proxy rejecting CONNECT succeeded, want error")
+	}
+	select {
+	case <-gotConnect:
+	default:
+		t.Errorf("request was not sent through the proxy")
+	}
+}
+
+func TestHTTP3Shutdown(t *testing.T) {
+	inHandler := make(chan struct{})
+	unblock := make(chan struct{})
+	ts := newH3TestServer(t, HandlerFunc(func(w ResponseWriter, r *Request) {
+		close(inHandler)
+		<-unblock
+		io.WriteString(w, "done")
+	}))
+	respc := make(chan error, 1)
+	go func() {
+		resp, err := ts.client.Get(ts.URL)
+		if err == nil {
+			var body []byte
+			body, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err == nil && string(body) != "done" {
+				err = fmt.Errorf("body = %q, want %q", body, "done")
+			}
+		}
+		respc <- err
+	}()
+	<-inHandler
+
+	shutdownc := make(chan error, 1)
+	go func() {
+		shutdownc <- ts.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-shutdownc:
+		t.Fatalf("Shutdown returned %v with a request in progress", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(unblock)
+	if err := <-respc; err != nil {
+		t.Errorf("request in progress during Shutdown: %v", err)
+	}
+	ts.client.CloseIdleConnections()
+	if err := <-shutdownc; err != nil && !errors.Is(err, context.Canceled) {
+		t.Errorf("Shutdown = %v", err)
+	}
+}
@@ -0,0 +1,962 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// HTTP/3 client.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptrace"
+	"net/http/internal/ascii"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/quic"
+)
+
+// h3Transport is the pool of HTTP/3 connections of a Transport.
+// Requests to the same host and port share a connection.
+type h3Transport struct {
+	mu       sync.Mutex
+	endpoint *quic.Endpoint // created on first use
+	config   *quic.Config
+	conns    map[string]*h3ClientConn // keyed by host:port
+	altSvc   map[string]h3AltSvc      // keyed by origin host:port
+}
+
+// An h3AltSvc is an HTTP/3 alternative service for an origin (RFC 7838).
+type h3AltSvc struct {
+	addr    string // host:port of the QUIC endpoint
+	expires time.Time
+}
+
+// roundTripHTTP3 sends req using HTTP/3.
+//
+// When HTTP/3 is the only protocol the transport may use for https:// URLs,
+// every request is sent using HTTP/3. Otherwise, HTTP/3 is used only for
+// origins that have advertised it in an Alt-Svc header, and never through
+// a proxy. In that case roundTripHTTP3 returns [ErrSkipAltProtocol]
+// without consuming req when it should be sent using HTTP/1 or HTTP/2,
+// including when no QUIC connection to the origin can be established.
+func (t *Transport) roundTripHTTP3(req *Request) (*Response, error) {
+	p := t.protocols()
+	fallback := p.HTTP1() || p.HTTP2()
+	if t.Proxy != nil {
+		proxyURL, err := t.Proxy(req)
+		if fallback && (err != nil || proxyURL != nil) {
+			// Send the request through the proxy over TCP,
+			// or report the error from there.
+			return nil, ErrSkipAltProtocol
+		}
+		if err != nil {
+			req.closeBody()
+			return nil, err
+		}
+		if proxyURL != nil {
+			req.closeBody()
+			return nil, errors.New("net/http: HTTP/3 requests cannot be sent through a proxy")
+		}
+	}
+	if req.Method != "" && !validMethod(req.Method) {
+		req.closeBody()
+		return nil, fmt.Errorf("net/http: invalid method %q", req.Method)
+	}
+	origin := canonicalAddr(req.URL)
+	addr := origin
+	if fallback {
+		var ok bool
+		addr, ok = t.h3.altSvcAddr(origin)
+		if !ok {
+			return nil, ErrSkipAltProtocol
+		}
+	}
+	cc, err := t.h3.getConn(req.Context(), t, addr)
+	if err != nil {
+		if fallback && req.Context().Err() == nil {
+			// The alternative service cannot be reached.
+			// Stop using it, and send the request over TCP.
+			t.h3.setAltSvc(origin, nil)
+			return nil, ErrSkipAltProtocol
+		}
+		req.closeBody()
+		return nil, err
+	}
+	return cc.roundTrip(req)
+}
+
+// altSvcAddr returns the address of the HTTP/3 alternative service
+// for origin, if one is known.
+func (h3 *h3Transport) altSvcAddr(origin string) (addr string, ok bool) {
+	h3.mu.Lock()
+	defer h3.mu.Unlock()
+	as, ok := h3.altSvc[origin]
+	if !ok {
+		return "", false
+	}
+	if !time.Now().Before(as.expires) {
+		delete(h3.altSvc, origin)
+		return "", false
+	}
+	return as.addr, true
+}
+
+// setAltSvc records the HTTP/3 alternative service for origin.
+// A nil as removes it.
+func (h3 *h3Transport) setAltSvc(origin string, as *h3AltSvc) {
+	h3.mu.Lock()
+	defer h3.mu.Unlock()
+	if as == nil {
+		delete(h3.altSvc, origin)
+		return
+	}
+	if h3.altSvc == nil {
+		h3.altSvc = make(map[string]h3AltSvc)
+	}
+	h3.altSvc[origin] = *as
+}
+
+// handleAltSvc processes the Alt-Svc header of a response received from
+// origin over TCP, recording the HTTP/3 alternative service it advertises.
+//
+// "When an Alt-Svc response header field is received from an origin, its
+// value invalidates and replaces all cached alternative services for that
+// origin."
+// https://www.rfc-editor.org/rfc/rfc7838.html#section-3
+func (h3 *h3Transport) handleAltSvc(origin string, h Header) {
+	vv, ok := h["Alt-Svc"]
+	if !ok {
+		return
+	}
+	h3.setAltSvc(origin, parseH3AltSvc(origin, vv, time.Now()))
+}
+
+// parseH3AltSvc returns the first HTTP/3 alternative service for origin in
+// the Alt-Svc header values vv, or nil if there is none.
+//
+// Only alternatives on the same host as origin are used, so that the
+// server's certificate is verified for the origin's host name.
+func parseH3AltSvc(origin string, vv []string, now time.Time) *h3AltSvc {
+	originHost, _, err := net.SplitHostPort(origin)
+	if err != nil {
+		return nil
+	}
+	for _, v := range vv {
+		for _, alt := range strings.Split(v, ",") {
+			// alt-value = alternative *( OWS ";" OWS parameter )
+			// alternative = protocol-id "=" alt-authority
+			params := strings.Split(alt, ";")
+			protocol, authority, ok := strings.Cut(textproto.TrimString(params[0]), "=")
+			if !ok || protocol != "h3" {
+				continue
+			}
+			authority, err := strconv.Unquote(authority)
+			if err != nil {
+				continue
+			}
+			host, port, err := net.SplitHostPort(authority)
+			if err != nil || (host != "" && !ascii.EqualFold(host, originHost)) {
+				continue
+			}
+			if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+				continue
+			}
+			// "ma" is the number of seconds the alternative is fresh for.
+			// The default is 24 hours.
+			maxAge := 24 * time.Hour
+			for _, param := range params[1:] {
+				name, value, _ := strings.Cut(textproto.TrimString(param), "=")
+				if name == "ma" {
+					if n, err := strconv.ParseUint(value, 10, 32); err == nil {
+						maxAge = time.Duration(n) * time.Second
+					}
+				}
+			}
+			return &h3AltSvc{
+				addr:    net.JoinHostPort(originHost, port),
+				expires: now.Add(maxAge),
+			}
+		}
+	}
+	return nil
+}
+
+// getConn returns a connection to addr, dialing one if necessary.
+func (h3 *h3Transport) getConn(ctx context.Context, t *Transport, addr string) (*h3ClientConn, error) {
+	h3.mu.Lock()
+	cc := h3.conns[addr]
+	if cc != nil && !cc.usable() {
+		delete(h3.conns, addr)
+		cc = nil
+	}
+	if cc == nil {
+		if h3.conns == nil {
+			h3.conns = make(map[string]*h3ClientConn)
+		}
+		cc = &h3ClientConn{
+			h3:       h3,
+			addr:     addr,
+			dialDone: make(chan struct{}),
+		}
+		h3.conns[addr] = cc
+		go cc.dial(t)
+	}
+	h3.mu.Unlock()
+
+	select {
+	case <-cc.dialDone:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if cc.dialErr != nil {
+		return nil, cc.dialErr
+	}
+	return cc, nil
+}
+
+// initEndpointLocked creates the QUIC endpoint used by all connections,
+// if it does not already exist.
+// Caller must hold h3.mu.
+func (h3 *h3Transport) initEndpointLocked(t *Transport) error {
+	if h3.endpoint != nil {
+		return nil
+	}
+	config := cloneTLSConfig(t.TLSClientConfig)
+	config.NextProtos = []string{"h3"}
+	config.MinVersion = tls.VersionTLS13
+	h3.config = &quic.Config{
+		TLSConfig: config,
+	}
+	if t.IdleConnTimeout > 0 {
+		h3.config.MaxIdleTimeout = t.IdleConnTimeout
+	}
+	e, err := quic.Listen("udp", ":0", h3.config)
+	if err != nil {
+		return err
+	}
+	h3.endpoint = e
+	return nil
+}
+
+// removeConn removes cc from the pool.
+func (h3 *h3Transport) removeConn(cc *h3ClientConn) {
+	h3.mu.Lock()
+	defer h3.mu.Unlock()
+	if h3.conns[cc.addr] == cc {
+		delete(h3.conns, cc.addr)
+	}
+}
+
+// closeIdleConns closes the connections that have no requests in progress.
+// The QUIC endpoint is closed once no connections remain.
+func (h3 *h3Transport) closeIdleConns() {
+	h3.mu.Lock()
+	defer h3.mu.Unlock()
+	for addr, cc := range h3.conns {
+		if cc.closeIfIdle() {
+			delete(h3.conns, addr)
+		}
+	}
+	if len(h3.conns) == 0 && h3.endpoint != nil {
+		h3.endpoint.Close(canceledContext)
+		h3.endpoint = nil
+	}
+}
+
+// An h3ClientConn is the client side of an HTTP/3 connection.
+type h3ClientConn struct {
+	h3   *h3Transport
+	addr string
+	enc  http3qpackEncoder
+	gc   http3genericConn
+
+	// The connection is dialed asynchronously.
+	// qconn and dialErr are set before dialDone is closed.
+	dialDone chan struct{}
+	qconn    *quic.Conn
+	dialErr  error
+
+	disableCompression    bool
+	expectContinueTimeout time.Duration
+
+	mu             sync.Mutex // guards the fields below
+	closed         bool       // connection closed, or GOAWAY received
+	activeRequests int
+}
+
+func (cc *h3ClientConn) dial(t *Transport) {
+	defer close(cc.dialDone)
+	cc.disableCompression = t.DisableCompression
+	cc.expectContinueTimeout = t.ExpectContinueTimeout
+
+	ctx := context.Background()
+	if d := t.TLSHandshakeTimeout; d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+	cc.h3.mu.Lock()
+	err := cc.h3.initEndpointLocked(t)
+	e, config := cc.h3.endpoint, cc.h3.config
+	cc.h3.mu.Unlock()
+	if err == nil {
+		cc.qconn, err = e.Dial(ctx, "udp", cc.addr, config)
+	}
+	if err == nil {
+		var st *http3stream
+		// Create the control stream and send the SETTINGS frame.
+		st, err = http3newConnStream(context.Background(), cc.qconn, http3streamTypeControl)
+		if err == nil {
+			st.writeSettings()
+			err = st.Flush()
+		}
+		if err != nil {
+			cc.qconn.Abort(err)
+		}
+	}
+	if err != nil {
+		cc.dialErr = err
+		cc.markClosed()
+		return
+	}
+	go func() {
+		cc.gc.acceptStreams(cc.qconn, cc)
+		cc.markClosed()
+	}()
+}
+
+// usable reports whether new requests may be sent on cc.
+func (cc *h3ClientConn) usable() bool {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return !cc.closed
+}
+
+// markClosed stops new requests from being sent on cc.
+func (cc *h3ClientConn) markClosed() {
+	cc.mu.Lock()
+	cc.closed = true
+	cc.mu.Unlock()
+	cc.h3.removeConn(cc)
+}
+
+// closeIfIdle closes cc if it has no requests in progress,
+// and reports whether it did so.
+func (cc *h3ClientConn) closeIfIdle() bool {
+	select {
+	case <-cc.dialDone:
+	default:
+		return false // still dialing
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.activeRequests > 0 {
+		return false
+	}
+	cc.closed = true
+	if cc.qconn != nil {
+		cc.qconn.Abort(nil)
+	}
+	return true
+}
+
+func (cc *h3ClientConn) handleControlStream(st *http3stream) error {
+	// "A SETTINGS frame MUST be sent as the first frame of each control stream [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.4-2
+	if err := st.readSettings(func(settingsType, settingsValue int64) error {
+		// No settings sent by servers currently affect the client.
+		return nil
+	}); err != nil {
+		return err
+	}
+	for {
+		ftype, err := st.readFrameHeader()
+		if err != nil {
+			return err
+		}
+		switch ftype {
+		case http3frameTypeCancelPush:
+			// "If a CANCEL_PUSH frame is received that references a push ID
+			// greater than currently allowed on the connection,
+			// this MUST be treated as a connection error of type H3_ID_ERROR."
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.3-7
+			return &http3connectionError{
+				code:    http3errH3IDError,
+				message: "CANCEL_PUSH received when no MAX_PUSH_ID has been sent",
+			}
+		case http3frameTypeGoaway:
+			// Stop sending new requests on the connection.
+			// Requests in progress are allowed to complete.
+			cc.mu.Lock()
+			cc.closed = true
+			cc.mu.Unlock()
+			cc.h3.removeConn(cc)
+			if err := st.discardFrame(); err != nil {
+				return err
+			}
+		default:
+			// Unknown frames are ignored.
+			if err := st.discardUnknownFrame(ftype); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (cc *h3ClientConn) handleEncoderStream(*http3stream) error {
+	// The dynamic table is not supported.
+	return nil
+}
+
+func (cc *h3ClientConn) handleDecoderStream(*http3stream) error {
+	// The dynamic table is not supported.
+	return nil
+}
+
+func (cc *h3ClientConn) handlePushStream(*http3stream) error {
+	// "A client MUST treat receipt of a push stream as a connection error
+	// of type H3_ID_ERROR when no MAX_PUSH_ID frame has been sent [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.6-3
+	return &http3connectionError{
+		code:    http3errH3IDError,
+		message: "push stream created when no MAX_PUSH_ID has been sent",
+	}
+}
+
+func (cc *h3ClientConn) handleRequestStream(*http3stream) error {
+	// "Clients MUST treat receipt of a server-initiated bidirectional
+	// stream as a connection error of type H3_STREAM_CREATION_ERROR [...]"
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-6.1-3
+	return &http3connectionError{
+		code:    http3errH3StreamCreationError,
+		message: "server created bidirectional stream",
+	}
+}
+
+func (cc *h3ClientConn) abort(err error) {
+	if e, ok := err.(*http3connectionError); ok {
+		cc.qconn.Abort(&quic.ApplicationError{
+			Code:   uint64(e.code),
+			Reason: e.message,
+		})
+	} else {
+		cc.qconn.Abort(err)
+	}
+}
+
+// h3RoundTrip is the state of a request sent on an HTTP/3 connection.
+type h3RoundTrip struct {
+	cc    *h3ClientConn
+	st    *h3Stream
+	trace *httptrace.ClientTrace
+
+	// Request body, provided by the caller.
+	onceCloseReqBody sync.Once
+	reqBody          io.ReadCloser
+
+	// Response.Body, provided to the caller.
+	respBody io.ReadCloser
+
+	errOnce sync.Once
+	err     error
+}
+
+// abort terminates the round trip.
+// It returns the first fatal error encountered by the round trip.
+func (rt *h3RoundTrip) abort(err error) error {
+	rt.errOnce.Do(func() {
+		rt.err = err
+		rt.cc.mu.Lock()
+		rt.cc.activeRequests--
+		rt.cc.mu.Unlock()
+		switch e := err.(type) {
+		case *http3connectionError:
+			rt.cc.abort(e)
+		case *http3streamError:
+			rt.st.CloseRead()
+			rt.st.Reset(uint64(e.code))
+		default:
+			rt.st.CloseRead()
+			rt.st.Reset(uint64(http3errH3NoError))
+		}
+	})
+	return rt.err
+}
+
+// closeReqBody closes the request body, at most once.
+func (rt *h3RoundTrip) closeReqBody() {
+	rt.onceCloseReqBody.Do(func() {
+		rt.reqBody.Close()
+	})
+}
+
+// roundTrip sends a request on the connection.
+func (cc *h3ClientConn) roundTrip(req *Request) (_ *Response, err error) {
+	cc.mu.Lock()
+	if cc.closed {
+		cc.mu.Unlock()
+		req.closeBody()
+		return nil, errors.New("http: HTTP/3 connection closed")
+	}
+	cc.activeRequests++
+	cc.mu.Unlock()
+
+	ctx := req.Context()
+	// Each request gets its own QUIC stream.
+	st, err := newH3ConnStream(ctx, cc.qconn, http3streamTypeRequest)
+	if err != nil {
+		cc.mu.Lock()
+		cc.activeRequests--
+		cc.mu.Unlock()
+		req.closeBody()
+		return nil, err
+	}
+	rt := &h3RoundTrip{
+		cc:      cc,
+		st:      st,
+		trace:   httptrace.ContextClientTrace(ctx),
+		reqBody: req.Body,
+	}
+	if rt.reqBody == nil {
+		rt.reqBody = NoBody
+	}
+	defer func() {
+		if err != nil {
+			rt.closeReqBody()
+			err = rt.abort(err)
+		}
+	}()
+
+	requestedGzip := !cc.disableCompression &&
+		req.Header.Get("Accept-Encoding") == "" &&
+		req.Header.Get("Range") == "" &&
+		req.Method != "HEAD"
+	headers, err := cc.encodeHeaders(req, requestedGzip, rt.trace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the HEADERS frame.
+	st.writeFrame(http3frameTypeHeaders, headers)
+	if err := st.Flush(); err != nil {
+		return nil, err
+	}
+	if rt.trace != nil && rt.trace.WroteHeaders != nil {
+		rt.trace.WroteHeaders()
+	}
+
+	var startBodyOnce sync.Once
+	startBody := func() {
+		startBodyOnce.Do(func() {
+			go rt.writeBodyAndTrailer(req)
+		})
+	}
+	expectContinue := req.expectsContinue() && rt.reqBody != NoBody
+	if expectContinue {
+		if rt.trace != nil && rt.trace.Wait100Continue != nil {
+			rt.trace.Wait100Continue()
+		}
+		if d := cc.expectContinueTimeout; d > 0 {
+			timer := time.AfterFunc(d, startBody)
+			defer timer.Stop()
+		}
+	} else {
+		startBody()
+	}
+
+	// Read the response header.
+	for {
+		ftype, err := st.readFrameHeader()
+		if err != nil {
+			return nil, err
+		}
+		switch ftype {
+		case http3frameTypeHeaders:
+			statusCode, h, err := readHTTP3ResponseHeader(st)
+			if err != nil {
+				return nil, err
+			}
+			if isInfoStatus(statusCode) {
+				if rt.trace != nil && rt.trace.Got1xxResponse != nil {
+					if err := rt.trace.Got1xxResponse(statusCode, textproto.MIMEHeader(h)); err != nil {
+						return nil, err
+					}
+				}
+				if statusCode == StatusContinue {
+					if rt.trace != nil && rt.trace.Got100Continue != nil {
+						rt.trace.Got100Continue()
+					}
+					startBody()
+				}
+				continue
+			}
+			// The response may arrive before the body has been sent,
+			// for example to reject the request. Do not send the body
+			// in that case.
+			startBodyOnce.Do(func() {
+				if expectContinue {
+					rt.closeReqBody()
+					st.CloseWrite()
+				} else {
+					go rt.writeBodyAndTrailer(req)
+				}
+			})
+			return rt.newResponse(req, statusCode, h, requestedGzip)
+		case http3frameTypePushPromise:
+			// "A client MUST treat receipt of a PUSH_PROMISE frame that contains a
+			// larger push ID than the client has advertised as a connection error of H3_ID_ERROR."
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-7.2.5-5
+			return nil, &http3connectionError{
+				code:    http3errH3IDError,
+				message: "PUSH_PROMISE received when no MAX_PUSH_ID has been sent",
+			}
+		default:
+			if err := st.discardUnknownFrame(ftype); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// newResponse returns the Response for the response header read from rt.st.
+func (rt *h3RoundTrip) newResponse(req *Request, statusCode int, h Header, requestedGzip bool) (*Response, error) {
+	contentLength, err := h3ResponseContentLength(req.Method, statusCode, h)
+	if err != nil {
+		return nil, err
+	}
+	var trailer Header
+	for _, v := range h["Trailer"] {
+		foreachHeaderElement(v, func(key string) {
+			key = CanonicalHeaderKey(key)
+			if !httpguts.ValidTrailerHeader(key) {
+				return
+			}
+			if trailer == nil {
+				trailer = make(Header)
+			}
+			trailer[key] = nil
+		})
+	}
+	delete(h, "Trailer")
+
+	if (contentLength != 0 && req.Method != "HEAD") || trailer != nil {
+		rt.respBody = &h3BodyReader{
+			st:      rt.st,
+			remain:  contentLength,
+			trailer: trailer,
+		}
+	} else {
+		rt.respBody = NoBody
+	}
+	tlsState := rt.cc.qconn.ConnectionState()
+	resp := &Response{
+		Status:        strconv.Itoa(statusCode) + " " + StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/3.0",
+		ProtoMajor:    3,
+		Header:        h,
+		Trailer:       trailer,
+		Body:          (*h3ResponseBody)(rt),
+		ContentLength: contentLength,
+		Request:       req,
+		TLS:           &tlsState,
+	}
+	if requestedGzip && ascii.EqualFold(h.Get("Content-Encoding"), "gzip") {
+		resp.Body = &gzipReader{body: &bodyEOFSignal{body: resp.Body}}
+		h.Del("Content-Encoding")
+		h.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	return resp, nil
+}
+
+// writeBodyAndTrailer writes the request body and trailer, if any,
+// and closes the write side of the stream.
+func (rt *h3RoundTrip) writeBodyAndTrailer(req *Request) {
+	defer rt.closeReqBody()
+
+	declaredTrailer := req.Trailer.Clone()
+	bw := &h3BodyWriter{
+		st:     rt.st,
+		remain: req.outgoingLength(),
+		flush:  true,
+		name:   "request",
+		enc:    &rt.cc.enc,
+	}
+	if _, err := io.Copy(bw, rt.reqBody); err != nil {
+		rt.abort(err)
+		return
+	}
+	// Only send the trailers declared before the body was sent.
+	var trailer Header
+	for name, vals := range req.Trailer {
+		if _, ok := declaredTrailer[name]; ok {
+			if trailer == nil {
+				trailer = make(Header)
+			}
+			trailer[name] = vals
+		}
+	}
+	bw.trailer = trailer
+	if err := bw.Close(); err != nil {
+		rt.abort(err)
+	}
+}
+
+// encodeHeaders encodes the header of req as a QPACK field section.
+func (cc *h3ClientConn) encodeHeaders(req *Request, addGzipHeader bool, trace *httptrace.ClientTrace) ([]byte, error) {
+	if err := h3CheckConnHeaders(req.Header); err != nil {
+		return nil, err
+	}
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	host, err := httpguts.PunycodeHostPort(host)
+	if err != nil {
+		return nil, err
+	}
+	if !httpguts.ValidHostHeader(host) {
+		return nil, errors.New("http: invalid Host header")
+	}
+
+	method := valueOrDefault(req.Method, "GET")
+	var path string
+	if method != "CONNECT" {
+		path = req.URL.RequestURI()
+		if !h3ValidPseudoPath(path) {
+			orig := path
+			path = strings.TrimPrefix(path, req.URL.Scheme+"://"+host)
+			if !h3ValidPseudoPath(path) {
+				if req.URL.Opaque != "" {
+					return nil, fmt.Errorf("http: invalid request :path %q from URL.Opaque = %q", orig, req.URL.Opaque)
+				}
+				return nil, fmt.Errorf("http: invalid request :path %q", orig)
+			}
+		}
+	}
+
+	var trailerKeys []string
+	for k := range req.Trailer {
+		k = CanonicalHeaderKey(k)
+		switch k {
+		case "Transfer-Encoding", "Trailer", "Content-Length":
+			return nil, fmt.Errorf("http: invalid Trailer key %q", k)
+		}
+		trailerKeys = append(trailerKeys, k)
+	}
+	slices.Sort(trailerKeys)
+
+	contentLength := req.outgoingLength()
+	return h3EncodeHeaders(&cc.enc, func(f func(name, value string)) {
+		yield := func(name, value string) {
+			f(name, value)
+			if trace != nil && trace.WroteHeaderField != nil {
+				trace.WroteHeaderField(name, []string{value})
+			}
+		}
+		yield(":authority", host)
+		yield(":method", method)
+		if method != "CONNECT" {
+			yield(":path", path)
+			yield(":scheme", req.URL.Scheme)
+		}
+		if len(trailerKeys) > 0 {
+			yield("trailer", strings.Join(trailerKeys, ","))
+		}
+		var didUA bool
+		for k, vv := range req.Header {
+			switch {
+			case ascii.EqualFold(k, "host"), ascii.EqualFold(k, "content-length"):
+				// Host is :authority, already sent.
+				// Content-Length is automatic, set below.
+				continue
+			case ascii.EqualFold(k, "connection"),
+				ascii.EqualFold(k, "proxy-connection"),
+				ascii.EqualFold(k, "transfer-encoding"),
+				ascii.EqualFold(k, "upgrade"),
+				ascii.EqualFold(k, "keep-alive"):
+				// Connection-specific fields are not sent.
+				// We have already checked if any are error-worthy.
+				continue
+			case ascii.EqualFold(k, "user-agent"):
+				// Match Go's http1 behavior: at most one
+				// User-Agent. If set to nil or empty string,
+				// then omit it. Otherwise if not mentioned,
+				// include the default (below).
+				didUA = true
+				if len(vv) < 1 {
+					continue
+				}
+				vv = vv[:1]
+				if vv[0] == "" {
+					continue
+				}
+			}
+			for _, v := range vv {
+				yield(k, v)
+			}
+		}
+		if contentLength > 0 || contentLength == 0 && (method == "POST" || method == "PUT" || method == "PATCH") {
+			yield("content-length", strconv.FormatInt(contentLength, 10))
+		}
+		if addGzipHeader {
+			yield("accept-encoding", "gzip")
+		}
+		if !didUA {
+			yield("user-agent", defaultUserAgent)
+		}
+	}), nil
+}
+
+// h3CheckConnHeaders checks whether h has any invalid connection-specific
+// fields. Some values are allowed, but are not sent.
+//
+// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.2-3
+func h3CheckConnHeaders(h Header) error {
+	if vv := h["Upgrade"]; len(vv) > 0 && (vv[0] != "" && vv[0] != "chunked") {
+		return fmt.Errorf("http: invalid Upgrade request header: %q", vv)
+	}
+	if vv := h["Transfer-Encoding"]; len(vv) > 0 && (len(vv) > 1 || vv[0] != "" && vv[0] != "chunked") {
+		return fmt.Errorf("http: invalid Transfer-Encoding request header: %q", vv)
+	}
+	if vv := h["Connection"]; len(vv) > 0 && (len(vv) > 1 || vv[0] != "" && !ascii.EqualFold(vv[0], "close") && !ascii.EqualFold(vv[0], "keep-alive")) {
+		return fmt.Errorf("http: invalid Connection request header: %q", vv)
+	}
+	return nil
+}
+
+// h3ValidPseudoPath reports whether v is a valid :path pseudo-header value.
+func h3ValidPseudoPath(v string) bool {
+	return (len(v) > 0 && v[0] == '/') || v == "*"
+}
+
+// readHTTP3ResponseHeader reads the field section of a HEADERS frame
+// containing a response header.
+func readHTTP3ResponseHeader(st *h3Stream) (statusCode int, h Header, err error) {
+	haveStatus := false
+	cookie := ""
+	h = make(Header)
+	err = h3DecodeHeaders(st, func(name, value string) error {
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return &http3streamError{code: http3errH3MessageError, message: "invalid field value"}
+		}
+		switch {
+		case name == ":status":
+			if haveStatus {
+				return &http3streamError{code: http3errH3MessageError, message: "duplicate :status"}
+			}
+			haveStatus = true
+			var err error
+			statusCode, err = strconv.Atoi(value)
+			if err != nil || statusCode < 100 || statusCode > 999 {
+				return &http3streamError{code: http3errH3MessageError, message: "invalid :status"}
+			}
+		case name[0] == ':':
+			// "Endpoints MUST treat a request or response
+			// that contains undefined or invalid
+			// pseudo-header fields as malformed."
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.3-3
+			return &http3streamError{code: http3errH3MessageError, message: "undefined pseudo-header"}
+		case name == "cookie":
+			// "If a decompressed field section contains multiple cookie field lines,
+			// these MUST be concatenated into a single byte string [...]"
+			// using the two-byte delimiter of "; "''
+			// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.2.1-2
+			if cookie == "" {
+				cookie = value
+			} else {
+				cookie += "; " + value
+			}
+		default:
+			if !h3ValidWireHeaderFieldName(name) {
+				return &http3streamError{code: http3errH3MessageError, message: "invalid field name"}
+			}
+			h.Add(name, value)
+		}
+		return nil
+	})
+	if err == nil && !haveStatus {
+		// "[The :status] pseudo-header field MUST be included in all responses [...]"
+		// https://www.rfc-editor.org/rfc/rfc9114.html#section-4.3.2-1
+		err = &http3streamError{code: http3errH3MessageError, message: "missing :status"}
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if cookie != "" {
+		h["Cookie"] = []string{cookie}
+	}
+	if err := st.endFrame(); err != nil {
+		return 0, nil, err
+	}
+	return statusCode, h, nil
+}
+
+// h3ResponseContentLength returns the content length of a response,
+// or -1 if it is unknown.
+func h3ResponseContentLength(method string, statusCode int, h Header) (int64, error) {
+	clens := h["Content-Length"]
+	if len(clens) == 0 {
+		return -1, nil
+	}
+	// "A server MUST NOT send a Content-Length header field in any response
+	// with a status code of 1xx (Informational) or 204 (No Content).
+	// A server MUST NOT send a Content-Length header field in any 2xx (Successful)
+	// response to a CONNECT request [...]"
+	// https://www.rfc-editor.org/rfc/rfc9110#section-8.6-8
+	if isInfoStatus(statusCode) ||
+		statusCode == StatusNoContent ||
+		(method == "CONNECT" && statusCode >= 200 && statusCode < 300) {
+		// This is a protocol violation, but a fairly harmless one.
+		// Just ignore the header.
+		return -1, nil
+	}
+	n, err := parseContentLength(clens)
+	if err != nil || n < 0 {
+		return -1, &http3streamError{code: http3errH3MessageError, message: "invalid Content-Length header"}
+	}
+	return n, nil
+}
+
+// h3ResponseBody is the Response.Body of an HTTP/3 response.
+type h3ResponseBody h3RoundTrip
+
+func (b *h3ResponseBody) Read(p []byte) (n int, err error) {
+	return b.respBody.Read(p)
+}
+
+var errH3RespBodyClosed = errors.New("http: response body closed")
+
+// Close ends the request. It stops reading the response, and stops
+// sending the request body if it is still being sent.
+func (b *h3ResponseBody) Close() error {
+	rt := (*h3RoundTrip)(b)
+	// Close the request body, which should wake up writeBodyAndTrailer
+	// if it is currently blocked reading the body.
+	rt.closeReqBody()
+	rt.st.Reset(uint64(http3errH3NoError))
+	err := rt.respBody.Close()
+	if err == nil {
+		err = errH3RespBodyClosed
+	}
+	err = rt.abort(err)
+	if err == errH3RespBodyClosed {
+		// No other errors occurred before closing Response.Body,
+		// so consider this a successful request.
+		return nil
+	}
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"internal/goexperiment"
 	"internal/profile"
 	"internal/testenv"
 	"io"
@@ -35,6 +36,11 @@ func TestDescriptions(t *testing.T) {
 }
 
 func TestHandlers(t *testing.T) {
+	// The goroutineleak profile only exists with GOEXPERIMENT=goroutineleakprofile.
+	leakStatus, leakType, leakDisposition, leakResp := http.StatusNotFound, "text/plain; charset=utf-8", "", []byte("Unknown profile\n")
+	if goexperiment.GoroutineLeakProfile {
+		leakStatus, leakType, leakDisposition, leakResp = http.StatusOK, "application/octet-stream", `attachment; filename="goroutineleak"`, nil
+	}
 	testCases := []struct {
 		path               string
 		handler            http.HandlerFunc
@@ -51,6 +57,7 @@ func TestHandlers(t *testing.T) {
 		{"/debug/pprof/symbol", Symbol, http.StatusOK, "text/plain; charset=utf-8", "", nil},
 		{"/debug/pprof/trace", Trace, http.StatusOK, "application/octet-stream", `attachment; filename="trace"`, nil},
 		{"/debug/pprof/mutex", Index, http.StatusOK, "application/octet-stream", `attachment; filename="mutex"`, nil},
+		{"/debug/pprof/goroutineleak", Index, leakStatus, leakType, leakDisposition, leakResp},
 		{"/debug/pprof/block?seconds=1", Index, http.StatusOK, "application/octet-stream", `attachment; filename="block-delta"`, nil},
 		{"/debug/pprof/goroutine?seconds=1", Index, http.StatusOK, "application/octet-stream", `attachment; filename="goroutine-delta"`, nil},
 		{"/debug/pprof/", Index, http.StatusOK, "text/html; charset=utf-8", "", []byte("Types of profiles available:")},
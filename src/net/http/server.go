@@ -33,6 +33,7 @@ import (
 	_ "unsafe" // for linkname
 
 	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/quic"
 )
 
 // Errors used by the HTTP server.
@@ -3059,6 +3060,12 @@ type Server struct {
 	// unencrypted HTTP/2 connections. The server can serve both
 	// HTTP/1 and unencrypted HTTP/2 on the same address and port.
 	//
+	// If Protocols includes HTTP3, [Server.ListenAndServeTLS] also serves
+	// HTTP/3 on the UDP port with the same address, and responses to
+	// HTTP/1 and HTTP/2 requests received over TLS advertise it with
+	// an Alt-Svc header. HTTP/3 connections can also be served with
+	// [Server.ServeQUIC].
+	//
 	// If Protocols is nil, the default is usually HTTP/1 and HTTP/2.
 	// If TLSNextProto is non-nil and does not contain an "h2" entry,
 	// the default is HTTP/1 only.
@@ -3070,10 +3077,14 @@ type Server struct {
 	nextProtoOnce     sync.Once // guards setupHTTP2_* init
 	nextProtoErr      error     // result of http2.ConfigureServer if used
 
-	mu         sync.Mutex
-	listeners  map[*net.Listener]struct{}
-	activeConn map[*conn]struct{}
-	onShutdown []func()
+	mu            sync.Mutex
+	listeners     map[*net.Listener]struct{}
+	activeConn    map[*conn]struct{}
+	quicEndpoints map[*quic.Endpoint]context.CancelFunc // cancel stops accepting connections
+	activeH3Conn  map[*h3ServerConn]struct{}
+	onShutdown    []func()
+
+	altSvc atomic.Pointer[string] // Alt-Svc value advertising HTTP/3, if served
 
 	listenerGroup sync.WaitGroup
 }
@@ -3105,6 +3116,7 @@ func (s *Server) Close() error {
 		c.rwc.Close()
 		delete(s.activeConn, c)
 	}
+	s.closeQUICEndpointsLocked()
 	return err
 }
 
@@ -3145,6 +3157,9 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	for _, f := range s.onShutdown {
 		go f()
 	}
+	for c := range s.activeH3Conn {
+		go c.sendGoaway()
+	}
 	s.mu.Unlock()
 	s.listenerGroup.Wait()
 
@@ -3209,6 +3224,17 @@ func (s *Server) closeIdleConns() bool {
 		c.rwc.Close()
 		delete(s.activeConn, c)
 	}
+	for c := range s.activeH3Conn {
+		if !c.idle() {
+			quiescent = false
+			continue
+		}
+		c.qconn.Abort(nil)
+		delete(s.activeH3Conn, c)
+	}
+	if len(s.activeH3Conn) == 0 && s.shuttingDown() {
+		s.closeQUICEndpointsLocked()
+	}
 	return quiescent
 }
 
@@ -3219,6 +3245,11 @@ func (s *Server) closeListenersLocked() error {
 			err = cerr
 		}
 	}
+	// Stop accepting HTTP/3 connections. The QUIC endpoints remain open
+	// until the connections they carry are closed.
+	for _, stopAccept := range s.quicEndpoints {
+		stopAccept()
+	}
 	return err
 }
 
@@ -3297,6 +3328,12 @@ func (sh serverHandler) ServeHTTP(rw ResponseWriter, req *Request) {
 	if !sh.srv.DisableGeneralOptionsHandler && req.RequestURI == "*" && req.Method == "OPTIONS" {
 		handler = globalOptionsHandler{}
 	}
+	if req.TLS != nil && req.ProtoMajor < 3 {
+		// Advertise HTTP/3. The handler may replace or remove the header.
+		if altSvc := sh.srv.altSvc.Load(); altSvc != nil {
+			rw.Header().Set("Alt-Svc", *altSvc)
+		}
+	}
 
 	handler.ServeHTTP(rw, req)
 }
@@ -3476,8 +3513,24 @@ func (s *Server) ServeTLS(l net.Listener, certFile, keyFile string) error {
 		return err
 	}
 
+	var nextProtos []string
+	if s.TLSConfig != nil {
+		nextProtos = s.TLSConfig.NextProtos
+	}
+	config, err := s.setupTLSConfig(certFile, keyFile, adjustNextProtos(nextProtos, s.protocols()))
+	if err != nil {
+		return err
+	}
+
+	tlsListener := tls.NewListener(l, config)
+	return s.Serve(tlsListener)
+}
+
+// setupTLSConfig returns a clone of s.TLSConfig with the given NextProtos,
+// loading the certificate from certFile and keyFile if necessary.
+func (s *Server) setupTLSConfig(certFile, keyFile string, nextProtos []string) (*tls.Config, error) {
 	config := cloneTLSConfig(s.TLSConfig)
-	config.NextProtos = adjustNextProtos(config.NextProtos, s.protocols())
+	config.NextProtos = nextProtos
 
 	configHasCert := len(config.Certificates) > 0 || config.GetCertificate != nil || config.GetConfigForClient != nil
 	if !configHasCert || certFile != "" || keyFile != "" {
@@ -3485,12 +3538,10 @@ func (s *Server) ServeTLS(l net.Listener, certFile, keyFile string) error {
 		config.Certificates = make([]tls.Certificate, 1)
 		config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-
-	tlsListener := tls.NewListener(l, config)
-	return s.Serve(tlsListener)
+	return config, nil
 }
 
 func (s *Server) protocols() Protocols {
@@ -3682,6 +3733,11 @@ func ListenAndServeTLS(addr, certFile, keyFile string, handler Handler) error {
 //
 // If s.Addr is blank, ":https" is used.
 //
+// If s.Protocols includes HTTP3, ListenAndServeTLS also listens on the
+// UDP network address with the same host and port, and calls
+// [Server.ServeQUIC] to handle HTTP/3 requests.
+// If HTTP3 is the only protocol, it does not listen on TCP.
+//
 // ListenAndServeTLS always returns a non-nil error. After [Server.Shutdown] or
 // [Server.Close], the returned error is [ErrServerClosed].
 func (s *Server) ListenAndServeTLS(certFile, keyFile string) error {
@@ -3693,13 +3749,41 @@ func (s *Server) ListenAndServeTLS(certFile, keyFile string) error {
 		addr = ":https"
 	}
 
+	p := s.protocols()
+	if !p.HTTP3() {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+
+		defer ln.Close()
+
+		return s.ServeTLS(ln, certFile, keyFile)
+	}
+
+	if !p.HTTP1() && !p.HTTP2() {
+		pc, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return err
+		}
+		return s.ServeQUIC(pc, certFile, keyFile)
+	}
+
 	ln, err := net.Listen("tcp", addr)
 	if err != nil {
 		return err
 	}
-
 	defer ln.Close()
-
+	// Use the port chosen for the TCP listener, in case addr has port 0.
+	pc, err := net.ListenPacket("udp", ln.Addr().String())
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.ServeQUIC(pc, certFile, keyFile); err != ErrServerClosed {
+			s.logf("http: serving HTTP/3: %v", err)
+		}
+	}()
 	return s.ServeTLS(ln, certFile, keyFile)
 }
 
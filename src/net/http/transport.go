@@ -111,6 +111,8 @@ type Transport struct {
 	connsPerHostWait map[connectMethodKey]wantConnQueue // waiting getConns
 	dialsInProgress  wantConnQueue
 
+	h3 h3Transport // HTTP/3 connections, when Protocols includes HTTP3
+
 	// Proxy specifies a function to return a proxy for a given
 	// Request. If the function returns a non-nil error, the
 	// request is aborted with the provided error.
@@ -305,6 +307,16 @@ type Transport struct {
 	// If Protocols includes UnencryptedHTTP2 and does not include HTTP1,
 	// the transport will use unencrypted HTTP/2 for requests for http:// URLs.
 	//
+	// If Protocols includes HTTP3 and neither HTTP1 nor HTTP2, the transport
+	// will use HTTP/3 for all requests for https:// URLs. Such requests
+	// cannot be sent through a proxy.
+	//
+	// If Protocols includes HTTP3 along with HTTP1 or HTTP2, the transport
+	// will use HTTP/3 for requests to servers that advertise it in an
+	// Alt-Svc response header (RFC 7838), falling back to HTTP/1 or HTTP/2
+	// when a QUIC connection to the server cannot be established.
+	// HTTP/3 is not used for requests sent through a proxy.
+	//
 	// If Protocols is nil, the default is usually HTTP/1 only.
 	// If ForceAttemptHTTP2 is true, or if TLSNextProto contains an "h2" entry,
 	// the default is HTTP/1 and HTTP/2.
@@ -633,6 +645,11 @@ func (t *Transport) roundTrip(req *Request) (_ *Response, err error) {
 		req.closeBody()
 		return nil, errors.New("http: no Host in request URL")
 	}
+	if scheme == "https" && t.protocols().HTTP3() {
+		if resp, err := t.roundTripHTTP3(req); err != ErrSkipAltProtocol {
+			return resp, err
+		}
+	}
 
 	// Transport request context.
 	//
@@ -704,6 +721,9 @@ func (t *Transport) roundTrip(req *Request) (_ *Response, err error) {
 				// canceling the context after the response body is read.
 				cancel(errRequestDone)
 			}
+			if scheme == "https" && cm.proxyURL == nil && t.protocols().HTTP3() {
+				t.h3.handleAltSvc(canonicalAddr(req.URL), resp.Header)
+			}
 			resp.Request = origReq
 			return resp, nil
 		}
@@ -897,6 +917,7 @@ func (t *Transport) CloseIdleConnections() {
 			pconn.close(errCloseIdleConns)
 		}
 	}
+	t.h3.closeIdleConns()
 	t.connsPerHostMu.Lock()
 	t.dialsInProgress.all(func(w *wantConn) {
 		if w.cancelCtx != nil && !w.waiting() {
@@ -0,0 +1,157 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "io"
+
+// Lock places an advisory exclusive (write) lock on the whole file,
+// blocking until the lock can be acquired.
+//
+// While the lock is held, no other process, and no other [File]
+// referring to the same file, can acquire a shared or exclusive lock on it.
+// The lock is released by [File.Unlock]. Closing the file may or may not
+// release the lock promptly, so callers should always call Unlock
+// before Close.
+//
+// If f is already locked, the behavior of Lock is unspecified.
+//
+// Locks are advisory: they do not prevent other programs from reading
+// or writing the file unless those programs also lock it.
+// On Windows, however, the lock is mandatory: while it is held, other
+// handles cannot read or write the file.
+//
+// On systems that do not support file locking, such as Plan 9, Lock
+// returns an error for which errors.Is(err, [errors.ErrUnsupported])
+// is true.
+func (f *File) Lock() error {
+	if err := f.checkValid("lock"); err != nil {
+		return err
+	}
+	_, err := f.lock(writeLock, true)
+	return f.wrapErr("lock", err)
+}
+
+// RLock places an advisory shared (read) lock on the whole file,
+// blocking until the lock can be acquired.
+//
+// While the lock is held, no other process, and no other [File]
+// referring to the same file, can acquire an exclusive lock on it,
+// but others may acquire shared locks.
+// On Windows, while a shared lock is held the file cannot be written,
+// even through f.
+//
+// See [File.Lock] for more details.
+func (f *File) RLock() error {
+	if err := f.checkValid("rlock"); err != nil {
+		return err
+	}
+	_, err := f.lock(readLock, true)
+	return f.wrapErr("rlock", err)
+}
+
+// TryLock is like [File.Lock], but it does not block.
+// It reports whether the lock was acquired.
+// If the file is locked by someone else, TryLock returns false, nil.
+func (f *File) TryLock() (bool, error) {
+	if err := f.checkValid("lock"); err != nil {
+		return false, err
+	}
+	ok, err := f.lock(writeLock, false)
+	return ok, f.wrapErr("lock", err)
+}
+
+// TryRLock is like [File.RLock], but it does not block.
+// It reports whether the lock was acquired.
+// If the file is exclusively locked by someone else, TryRLock returns false, nil.
+func (f *File) TryRLock() (bool, error) {
+	if err := f.checkValid("rlock"); err != nil {
+		return false, err
+	}
+	ok, err := f.lock(readLock, false)
+	return ok, f.wrapErr("rlock", err)
+}
+
+// Unlock releases a lock acquired by [File.Lock], [File.RLock],
+// [File.TryLock], or [File.TryRLock].
+func (f *File) Unlock() error {
+	if err := f.checkValid("unlock"); err != nil {
+		return err
+	}
+	return f.wrapErr("unlock", f.unlock())
+}
+
+// UpdateFile atomically replaces the contents of the named file with
+// the result of calling update on its current contents.
+// If the file does not exist, UpdateFile creates it with permissions
+// perm (before umask) and calls update with empty contents.
+//
+// UpdateFile holds an exclusive lock on the file (see [File.Lock])
+// while it reads, updates, and rewrites it, so concurrent calls to
+// UpdateFile for the same file, from this or other processes, are
+// serialized, and readers that hold a shared lock (see [File.RLock])
+// never observe a partially written file.
+//
+// If update returns an error, the file is left unchanged and UpdateFile
+// returns that error. If writing the new contents fails, UpdateFile makes
+// a best effort to restore the original contents before returning.
+func UpdateFile(name string, perm FileMode, update func([]byte) ([]byte, error)) (err error) {
+	f, err := OpenFile(name, O_RDWR|O_CREATE, perm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := f.Lock(); err != nil {
+		return err
+	}
+	defer f.Unlock()
+
+	old, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	new, err := update(old)
+	if err != nil {
+		return err
+	}
+
+	if len(new) > len(old) {
+		// The file is growing, so write the tail first: if we are about
+		// to run out of space, we would rather detect that before
+		// overwriting the original contents.
+		if _, err := f.WriteAt(new[len(old):], int64(len(old))); err != nil {
+			// Make a best effort to remove the incomplete tail.
+			f.Truncate(int64(len(old)))
+			return err
+		}
+	}
+
+	// We are about to overwrite the old contents. In case of failure,
+	// make a best effort to roll back.
+	defer func() {
+		if err != nil {
+			if _, err := f.WriteAt(old, 0); err == nil {
+				f.Truncate(int64(len(old)))
+			}
+		}
+	}()
+
+	if len(new) >= len(old) {
+		if _, err := f.WriteAt(new[:len(old)], 0); err != nil {
+			return err
+		}
+	} else {
+		if _, err := f.WriteAt(new, 0); err != nil {
+			return err
+		}
+		// The file is shrinking, so truncate it after writing, so that
+		// the space for the old contents is still reserved if the
+		// write fails.
+		if err := f.Truncate(int64(len(new))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
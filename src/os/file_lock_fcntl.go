@@ -0,0 +1,163 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || (solaris && !illumos)
+
+// This file implements file locking using POSIX fcntl locks, which attach
+// to an (inode, process) pair rather than a file descriptor. To avoid
+// unlocking files prematurely when the same file is opened through
+// different descriptors, we allow only one File per inode to hold a lock
+// at a time.
+
+package os
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type lockType int16
+
+const (
+	readLock  lockType = syscall.F_RDLCK
+	writeLock lockType = syscall.F_WRLCK
+)
+
+type inode = uint64
+
+type inodeLock struct {
+	owner *File
+	queue []<-chan *File
+}
+
+var (
+	lockMu     sync.Mutex
+	lockInodes = map[*File]inode{}
+	inodeLocks = map[inode]inodeLock{}
+)
+
+func (f *File) lock(lt lockType, block bool) (bool, error) {
+	var st syscall.Stat_t
+	if err := f.pfd.Fstat(&st); err != nil {
+		return false, err
+	}
+	ino := inode(st.Ino)
+
+	lockMu.Lock()
+	if i, dup := lockInodes[f]; dup && i != ino {
+		lockMu.Unlock()
+		return false, errors.New("inode for file changed since last lock")
+	}
+
+	var wait chan *File
+	l := inodeLocks[ino]
+	switch {
+	case l.owner == f:
+		// This file already owns the lock, but the call may change its lock type.
+	case l.owner == nil:
+		// No owner: it's ours now.
+		l.owner = f
+	case !block:
+		// Another File in this process holds the lock.
+		lockMu.Unlock()
+		return false, nil
+	default:
+		// Already owned: add a channel to wait on.
+		wait = make(chan *File)
+		l.queue = append(l.queue, wait)
+	}
+	lockInodes[f] = ino
+	inodeLocks[ino] = l
+	lockMu.Unlock()
+
+	if wait != nil {
+		wait <- f
+	}
+
+	var err error
+	if block {
+		// Spurious EDEADLK errors arise on platforms that compute deadlock
+		// graphs at the process, rather than thread, level (see go.dev/issue/32817).
+		// Treat EDEADLK as always spurious and retry with backoff:
+		// if there really is a lock-ordering bug between the interacting
+		// processes, it becomes a livelock instead.
+		nextSleep := 1 * time.Millisecond
+		const maxSleep = 500 * time.Millisecond
+		for {
+			err = setlk(f, syscall.F_SETLKW, lt)
+			if err != syscall.EDEADLK {
+				break
+			}
+			time.Sleep(nextSleep)
+
+			nextSleep += nextSleep
+			if nextSleep > maxSleep {
+				nextSleep = maxSleep
+			}
+			// Apply 10% jitter to avoid synchronizing collisions when we finally unblock.
+			nextSleep += time.Duration(runtime_rand()%uint64(nextSleep/10+1)) - nextSleep/20
+		}
+	} else {
+		err = setlk(f, syscall.F_SETLK, lt)
+	}
+
+	if err != nil {
+		f.unlock()
+		if !block && (err == syscall.EAGAIN || err == syscall.EACCES) {
+			// Another process holds a conflicting lock.
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (f *File) unlock() error {
+	var owner *File
+
+	lockMu.Lock()
+	ino, ok := lockInodes[f]
+	if ok {
+		owner = inodeLocks[ino].owner
+	}
+	lockMu.Unlock()
+
+	if owner != f {
+		// f is not locked. Unlocking the inode would release
+		// a lock held through another File.
+		return nil
+	}
+
+	err := setlk(f, syscall.F_SETLK, syscall.F_UNLCK)
+
+	lockMu.Lock()
+	l := inodeLocks[ino]
+	if len(l.queue) == 0 {
+		// No waiters: remove the map entry.
+		delete(inodeLocks, ino)
+	} else {
+		// The first waiter is sending us their file now.
+		// Receive it and update the queue.
+		l.owner = <-l.queue[0]
+		l.queue = l.queue[1:]
+		inodeLocks[ino] = l
+	}
+	delete(lockInodes, f)
+	lockMu.Unlock()
+
+	return err
+}
+
+// setlk calls FcntlFlock with cmd for the whole file.
+func setlk(f *File, cmd int, lt lockType) error {
+	return f.pfd.FcntlFlock(cmd, &syscall.Flock_t{
+		Type:   int16(lt),
+		Whence: io.SeekStart,
+		Start:  0,
+		Len:    0, // All bytes.
+	})
+}
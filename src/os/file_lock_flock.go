@@ -0,0 +1,32 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd
+
+package os
+
+import "syscall"
+
+type lockType int
+
+const (
+	readLock  lockType = syscall.LOCK_SH
+	writeLock lockType = syscall.LOCK_EX
+)
+
+func (f *File) lock(lt lockType, block bool) (bool, error) {
+	how := int(lt)
+	if !block {
+		how |= syscall.LOCK_NB
+	}
+	err := f.pfd.Flock(how)
+	if !block && err == syscall.EWOULDBLOCK {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (f *File) unlock() error {
+	return f.pfd.Flock(syscall.LOCK_UN)
+}
@@ -0,0 +1,24 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows
+
+package os
+
+import "errors"
+
+type lockType int
+
+const (
+	readLock lockType = iota + 1
+	writeLock
+)
+
+func (f *File) lock(lt lockType, block bool) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+func (f *File) unlock() error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"bytes"
+	"errors"
+	. "os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// openLockTest opens n independent Files for the same new file,
+// skipping the test if file locking is not supported.
+func openLockTest(t *testing.T, n int) []*File {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "lock")
+	if err := WriteFile(name, nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	fs := make([]*File, n)
+	for i := range fs {
+		f, err := OpenFile(name, O_RDWR, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		fs[i] = f
+	}
+	if ok, err := fs[0].TryLock(); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("file locking not supported: %v", err)
+	} else if err != nil || !ok {
+		t.Fatalf("TryLock = %v, %v; want true, nil", ok, err)
+	}
+	if err := fs[0].Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func mustTryLock(t *testing.T, f *File, read, want bool) {
+	t.Helper()
+	name, try := "TryLock", f.TryLock
+	if read {
+		name, try = "TryRLock", f.TryRLock
+	}
+	if ok, err := try(); err != nil || ok != want {
+		t.Fatalf("%s = %v, %v; want %v, nil", name, ok, err, want)
+	}
+}
+
+func TestFileLockExclusive(t *testing.T) {
+	fs := openLockTest(t, 2)
+	if err := fs[0].Lock(); err != nil {
+		t.Fatal(err)
+	}
+	mustTryLock(t, fs[1], false, false)
+	mustTryLock(t, fs[1], true, false)
+	if err := fs[0].Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	mustTryLock(t, fs[1], false, true)
+	if err := fs[1].Unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileRLockShared(t *testing.T) {
+	fs := openLockTest(t, 3)
+	if err := fs[0].RLock(); err != nil {
+		t.Fatal(err)
+	}
+	mustTryLock(t, fs[1], true, true)
+	mustTryLock(t, fs[2], false, false)
+	for _, f := range fs[:2] {
+		if err := f.Unlock(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustTryLock(t, fs[2], false, true)
+	if err := fs[2].Unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileLockBlocks(t *testing.T) {
+	fs := openLockTest(t, 2)
+	if err := fs[0].Lock(); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- fs[1].RLock()
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("RLock did not block on a locked file: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	if err := fs[0].Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("RLock: %v", err)
+	}
+	if err := fs[1].Unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileLockClosed(t *testing.T) {
+	fs := openLockTest(t, 1)
+	fs[0].Close()
+	if err := fs[0].Lock(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Lock on closed file = %v, want ErrClosed", err)
+	}
+}
+
+func TestUpdateFile(t *testing.T) {
+	openLockTest(t, 1)
+	name := filepath.Join(t.TempDir(), "counter")
+
+	const n = 20
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := UpdateFile(name, 0o666, func(b []byte) ([]byte, error) {
+				i := 0
+				if len(b) > 0 {
+					var err error
+					if i, err = strconv.Atoi(string(b)); err != nil {
+						return nil, err
+					}
+				}
+				return strconv.AppendInt(nil, int64(i+1), 10), nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if b, err := ReadFile(name); err != nil || string(b) != strconv.Itoa(n) {
+		t.Fatalf("ReadFile = %q, %v; want %q", b, err, strconv.Itoa(n))
+	}
+
+	// Shrinking the contents truncates the file.
+	err := UpdateFile(name, 0o666, func(b []byte) ([]byte, error) {
+		return []byte("x"), nil
+	})
+	if b, _ := ReadFile(name); err != nil || string(b) != "x" {
+		t.Fatalf("after shrinking: contents %q, err %v; want %q, nil", b, err, "x")
+	}
+
+	// An error from update leaves the file unchanged.
+	errUpdate := errors.New("update failed")
+	err = UpdateFile(name, 0o666, func(b []byte) ([]byte, error) {
+		return bytes.Repeat(b, 10), errUpdate
+	})
+	if err != errUpdate {
+		t.Errorf("UpdateFile error = %v, want %v", err, errUpdate)
+	}
+	if b, _ := ReadFile(name); string(b) != "x" {
+		t.Errorf("after failed update: contents %q, want %q", b, "x")
+	}
+}
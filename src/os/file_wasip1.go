@@ -20,3 +20,9 @@ import "internal/poll"
 func (f *file) PollFD() *poll.FD {
 	return &f.pfd
 }
+
+// newDirFile returns a new File for the directory fd.
+func newDirFile(fd int, name string) *File {
+	// We use kindNoPoll because we know that this is a directory.
+	return newFile(fd, name, kindNoPoll, false)
+}
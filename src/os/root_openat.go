@@ -11,6 +11,7 @@ import (
 	"slices"
 	"sync"
 	"syscall"
+	"time"
 )
 
 // root implementation for platforms with a function to open a file
@@ -65,7 +66,7 @@ func (r *root) Name() string {
 }
 
 func rootMkdir(r *Root, name string, perm FileMode) error {
-	_, err := doInRoot(r, name, func(parent sysfdType, name string) (struct{}, error) {
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
 		return struct{}{}, mkdirat(parent, name, perm)
 	})
 	if err != nil {
@@ -74,8 +75,61 @@ func rootMkdir(r *Root, name string, perm FileMode) error {
 	return err
 }
 
+func rootMkdirAll(r *Root, fullname string, perm FileMode) error {
+	// openDirFunc opens each directory in the path except the last,
+	// creating it if it does not exist.
+	openDirFunc := func(parent sysfdType, name string) (sysfdType, error) {
+		for try := range 2 {
+			fd, err := rootOpenDir(parent, name)
+			switch err.(type) {
+			case nil, errSymlink:
+				return fd, err
+			}
+			if try > 0 || !IsNotExist(err) {
+				return fd, err
+			}
+			if err := mkdirat(parent, name, perm); err != nil && !IsExist(err) {
+				return fd, err
+			}
+		}
+		panic("unreachable")
+	}
+	_, err := doInRoot(r, fullname, openDirFunc, func(parent sysfdType, name string) (struct{}, error) {
+		err := mkdirat(parent, name, perm)
+		if err == nil || !IsExist(err) {
+			return struct{}{}, err
+		}
+		// The target already exists.
+		// As with MkdirAll, succeed if it is a directory
+		// or a symlink to a directory.
+		mode, e := modeAt(parent, name)
+		switch {
+		case e != nil:
+		case mode.IsDir():
+			err = nil
+		case mode&ModeSymlink != 0:
+			// Don't return errSymlink here: We don't want to create
+			// the target of the link if it doesn't exist.
+			if fi, e := r.Stat(fullname); e == nil {
+				if fi.IsDir() {
+					err = nil
+				} else {
+					err = syscall.ENOTDIR
+				}
+			}
+		default:
+			err = syscall.ENOTDIR
+		}
+		return struct{}{}, err
+	})
+	if err != nil {
+		return &PathError{Op: "mkdirat", Path: fullname, Err: err}
+	}
+	return nil
+}
+
 func rootRemove(r *Root, name string) error {
-	_, err := doInRoot(r, name, func(parent sysfdType, name string) (struct{}, error) {
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
 		return struct{}{}, removeat(parent, name)
 	})
 	if err != nil {
@@ -84,6 +138,114 @@ func rootRemove(r *Root, name string) error {
 	return err
 }
 
+func rootRemoveAll(r *Root, name string) error {
+	// Consistency with RemoveAll: Strip trailing separators from the name,
+	// so RemoveAll("not_a_directory/") succeeds.
+	for len(name) > 0 && IsPathSeparator(name[len(name)-1]) {
+		name = name[:len(name)-1]
+	}
+	if endsWithDot(name) {
+		// Consistency with RemoveAll: Return EINVAL when trying to remove ".".
+		return &PathError{Op: "RemoveAll", Path: name, Err: syscall.EINVAL}
+	}
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+		return struct{}{}, removeAllFrom(parent, name)
+	})
+	if IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return &PathError{Op: "RemoveAll", Path: name, Err: underlyingError(err)}
+	}
+	return nil
+}
+
+func rootChmod(r *Root, name string, mode FileMode) error {
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+		return struct{}{}, chmodat(parent, name, mode)
+	})
+	if err != nil {
+		return &PathError{Op: "chmodat", Path: name, Err: err}
+	}
+	return nil
+}
+
+func rootChown(r *Root, name string, uid, gid int) error {
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+		return struct{}{}, chownat(parent, name, uid, gid)
+	})
+	if err != nil {
+		return &PathError{Op: "chownat", Path: name, Err: err}
+	}
+	return nil
+}
+
+func rootLchown(r *Root, name string, uid, gid int) error {
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+		return struct{}{}, lchownat(parent, name, uid, gid)
+	})
+	if err != nil {
+		return &PathError{Op: "lchownat", Path: name, Err: err}
+	}
+	return nil
+}
+
+func rootChtimes(r *Root, name string, atime time.Time, mtime time.Time) error {
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+		return struct{}{}, chtimesat(parent, name, atime, mtime)
+	})
+	if err != nil {
+		return &PathError{Op: "chtimesat", Path: name, Err: err}
+	}
+	return nil
+}
+
+func rootReadlink(r *Root, name string) (string, error) {
+	target, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (string, error) {
+		return readlinkat(parent, name)
+	})
+	if err != nil {
+		return "", &PathError{Op: "readlinkat", Path: name, Err: err}
+	}
+	return target, nil
+}
+
+func rootRename(r *Root, oldname, newname string) error {
+	_, err := doInRoot(r, oldname, nil, func(oldparent sysfdType, oldname string) (struct{}, error) {
+		_, err := doInRoot(r, newname, nil, func(newparent sysfdType, newname string) (struct{}, error) {
+			return struct{}{}, renameat(oldparent, oldname, newparent, newname)
+		})
+		return struct{}{}, err
+	})
+	if err != nil {
+		return &LinkError{"renameat", oldname, newname, err}
+	}
+	return nil
+}
+
+func rootLink(r *Root, oldname, newname string) error {
+	_, err := doInRoot(r, oldname, nil, func(oldparent sysfdType, oldname string) (struct{}, error) {
+		_, err := doInRoot(r, newname, nil, func(newparent sysfdType, newname string) (struct{}, error) {
+			return struct{}{}, linkat(oldparent, oldname, newparent, newname)
+		})
+		return struct{}{}, err
+	})
+	if err != nil {
+		return &LinkError{"linkat", oldname, newname, err}
+	}
+	return nil
+}
+
+func rootSymlink(r *Root, oldname, newname string) error {
+	_, err := doInRoot(r, newname, nil, func(parent sysfdType, name string) (struct{}, error) {
+		return struct{}{}, symlinkat(oldname, parent, name)
+	})
+	if err != nil {
+		return &LinkError{"symlinkat", oldname, newname, err}
+	}
+	return nil
+}
+
 // doInRoot performs an operation on a path in a Root.
 //
 // It opens the directory containing the final element of the path,
@@ -92,12 +254,20 @@ func rootRemove(r *Root, name string) error {
 // If the path refers to a symlink which should be followed,
 // then f must return errSymlink.
 // doInRoot will follow the symlink and call f again.
-func doInRoot[T any](r *Root, name string, f func(parent sysfdType, name string) (T, error)) (ret T, err error) {
+//
+// openDirFunc is called to open each directory in the path
+// other than the final element.
+// If openDirFunc is nil, rootOpenDir is used.
+func doInRoot[T any](r *Root, name string, openDirFunc func(parent sysfdType, name string) (sysfdType, error), f func(parent sysfdType, name string) (T, error)) (ret T, err error) {
 	if err := r.root.incref(); err != nil {
 		return ret, err
 	}
 	defer r.root.decref()
 
+	if openDirFunc == nil {
+		openDirFunc = rootOpenDir
+	}
+
 	parts, err := splitPathInRoot(name, nil, nil)
 	if err != nil {
 		return ret, err
@@ -165,7 +335,7 @@ func doInRoot[T any](r *Root, name string, f func(parent sysfdType, name string)
 			}
 		} else {
 			var fd sysfdType
-			fd, err = rootOpenDir(dirfd, parts[i])
+			fd, err = openDirFunc(dirfd, parts[i])
 			if err == nil {
 				if dirfd != rootfd {
 					syscall.Close(dirfd)
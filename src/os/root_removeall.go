@@ -0,0 +1,105 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows || wasip1
+
+package os
+
+import (
+	"io"
+	"syscall"
+)
+
+// removeAllFrom removes base and any children it contains
+// from the directory parent.
+//
+// This is the Root.RemoveAll implementation for platforms which
+// implement Root with openat-style operations, but which do not
+// use removeall_at.go for RemoveAll.
+func removeAllFrom(parent sysfdType, base string) error {
+	// Simple case: if removing base works, we're done.
+	err := removeat(parent, base)
+	if err == nil || IsNotExist(err) {
+		return nil
+	}
+	removeErr := err
+
+	// Remove the directory's entries.
+	var recurseErr error
+	for {
+		const reqSize = 1024
+		var respSize int
+
+		// Open the directory to recurse into.
+		fd, err := rootOpenDir(parent, base)
+		if err != nil {
+			if IsNotExist(err) {
+				return nil
+			}
+			if _, ok := err.(errSymlink); ok || err == syscall.ENOTDIR {
+				// Not a directory; return the error from removeat.
+				return &PathError{Op: "removeat", Path: base, Err: removeErr}
+			}
+			recurseErr = &PathError{Op: "openat", Path: base, Err: err}
+			break
+		}
+		file := newDirFile(fd, base)
+
+		for {
+			numErr := 0
+
+			names, readErr := file.Readdirnames(reqSize)
+			// Errors other than EOF should stop us from continuing.
+			if readErr != nil && readErr != io.EOF {
+				file.Close()
+				if IsNotExist(readErr) {
+					return nil
+				}
+				return &PathError{Op: "readdirnames", Path: base, Err: readErr}
+			}
+
+			respSize = len(names)
+			for _, name := range names {
+				err := removeAllFrom(fd, name)
+				if err != nil {
+					if pathErr, ok := err.(*PathError); ok {
+						pathErr.Path = base + string(PathSeparator) + pathErr.Path
+					}
+					numErr++
+					if recurseErr == nil {
+						recurseErr = err
+					}
+				}
+			}
+
+			// If we can delete any entry, break to start new iteration.
+			// Otherwise, we discard current names, get next entries and try deleting them.
+			if numErr != reqSize {
+				break
+			}
+		}
+
+		// Removing files from the directory may have caused
+		// the OS to reshuffle it. Simply calling Readdirnames
+		// again may skip some entries. The only reliable way
+		// to avoid this is to close and re-open the
+		// directory. See issue 20841.
+		file.Close()
+
+		// Finish when the end of the directory is reached
+		if respSize < reqSize {
+			break
+		}
+	}
+
+	// Remove the directory itself.
+	err = removeat(parent, base)
+	if err == nil || IsNotExist(err) {
+		return nil
+	}
+	if recurseErr != nil {
+		return recurseErr
+	}
+	return &PathError{Op: "removeat", Path: base, Err: err}
+}
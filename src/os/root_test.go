@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"internal/testenv"
 	"io"
 	"io/fs"
 	"net"
@@ -578,6 +579,293 @@ func TestRootLstat(t *testing.T) {
 	}
 }
 
+func TestRootChmod(t *testing.T) {
+	if runtime.GOOS == "wasip1" {
+		t.Skip("Chmod not supported on " + runtime.GOOS)
+	}
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			if target != "" {
+				if err := os.WriteFile(target, nil, 0o666); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Chmod follows symlinks, so the target is always the file being modified.
+			err := root.Chmod(test.open, 0o400)
+			if errEndsTest(t, err, test.wantError, "root.Chmod(%q)", test.open) {
+				return
+			}
+			fi, err := os.Stat(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if runtime.GOOS == "windows" {
+				// On Windows, Chmod only controls the read-only attribute.
+				if got := fi.Mode().Perm(); got&0o222 != 0 {
+					t.Errorf("after root.Chmod(%q, 0o400): mode = %v, want read-only", test.open, got)
+				}
+			} else if got, want := fi.Mode().Perm(), os.FileMode(0o400); got != want {
+				t.Errorf("after root.Chmod(%q, %v): mode = %v, want %v", test.open, want, got, want)
+			}
+		})
+	}
+}
+
+func TestRootChtimes(t *testing.T) {
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			if target != "" {
+				if err := os.WriteFile(target, nil, 0o666); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			mtime := time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+			err := root.Chtimes(test.open, time.Time{}, mtime)
+			if errEndsTest(t, err, test.wantError, "root.Chtimes(%q)", test.open) {
+				return
+			}
+			fi, err := os.Stat(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fi.ModTime(); !got.Equal(mtime) {
+				t.Errorf("after root.Chtimes(%q, _, %v): ModTime = %v, want %v", test.open, mtime, got, mtime)
+			}
+		})
+	}
+}
+
+func TestRootMkdirAll(t *testing.T) {
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			wantError := test.wantError
+			if !wantError {
+				fi, err := os.Lstat(filepath.Join(root.Name(), test.open))
+				if err == nil && fi.Mode().Type() == fs.ModeSymlink {
+					// This case is trying to mkdir("some symlink"),
+					// which is an error.
+					wantError = true
+				}
+			}
+			if test.alwaysFails && test.open != "" && test.ltarget == "" {
+				// MkdirAll creates missing parent directories,
+				// so the "directory does not exist" case succeeds.
+				wantError = false
+				target = filepath.Join(root.Name(), test.open)
+			}
+
+			err := root.MkdirAll(test.open, 0o777)
+			if errEndsTest(t, err, wantError, "root.MkdirAll(%q)", test.open) {
+				return
+			}
+			fi, err := os.Lstat(target)
+			if err != nil {
+				t.Fatalf(`stat file created with Root.MkdirAll(%q): %v`, test.open, err)
+			}
+			if !fi.IsDir() {
+				t.Fatalf(`stat file created with Root.MkdirAll(%q): not a directory`, test.open)
+			}
+
+			// MkdirAll of an existing directory succeeds.
+			if err := root.MkdirAll(test.open, 0o777); err != nil {
+				t.Fatalf(`second Root.MkdirAll(%q) = %v; want success`, test.open, err)
+			}
+		})
+	}
+}
+
+func TestRootRemoveAll(t *testing.T) {
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			wantError := test.wantError
+			if test.ltarget != "" {
+				// RemoveAll doesn't follow symlinks in the final path component,
+				// so it will successfully remove ltarget.
+				wantError = false
+				target = filepath.Join(root.Name(), test.ltarget)
+			} else if target != "" {
+				if err := os.MkdirAll(filepath.Join(target, "a/b"), 0o777); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(target, "a/b/f"), nil, 0o666); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if test.alwaysFails && test.open != "" && test.ltarget == "" {
+				// RemoveAll of a nonexistent file succeeds.
+				wantError = false
+			}
+
+			err := root.RemoveAll(test.open)
+			if errEndsTest(t, err, wantError, "root.RemoveAll(%q)", test.open) {
+				return
+			}
+			if target == "" {
+				return
+			}
+			_, err = os.Lstat(target)
+			if !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf(`stat file removed with Root.RemoveAll(%q): %v, want ErrNotExist`, test.open, err)
+			}
+		})
+	}
+}
+
+func TestRootReadlink(t *testing.T) {
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			// Readlink doesn't follow symlinks in the final path component,
+			// so it succeeds only when the final component is a link.
+			wantError := test.ltarget == ""
+			var want string
+			for _, ent := range test.fs {
+				if base, link, ok := strings.Cut(ent, " => "); ok && base == test.ltarget {
+					want = filepath.FromSlash(strings.ReplaceAll(link, "$ABS", root.Name()))
+				}
+			}
+			if target != "" {
+				if err := os.WriteFile(target, nil, 0o666); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := root.Readlink(test.open)
+			if errEndsTest(t, err, wantError, "root.Readlink(%q)", test.open) {
+				return
+			}
+			if got != want {
+				t.Errorf("root.Readlink(%q) = %q, want %q", test.open, got, want)
+			}
+		})
+	}
+}
+
+func TestRootRenameFrom(t *testing.T) {
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			wantError := test.wantError
+			if test.ltarget != "" {
+				// Rename doesn't follow symlinks in the final path component,
+				// so it will successfully rename ltarget.
+				wantError = false
+				target = filepath.Join(root.Name(), test.ltarget)
+			} else if target != "" {
+				if err := os.WriteFile(target, []byte("content"), 0o666); err != nil {
+					t.Fatal(err)
+				}
+			}
+			wantFI, wantErr := os.Lstat(target)
+
+			err := root.Rename(test.open, "new")
+			if errEndsTest(t, err, wantError, "root.Rename(%q, %q)", test.open, "new") {
+				return
+			}
+			if wantErr != nil {
+				t.Fatal(wantErr)
+			}
+			if _, err := os.Lstat(target); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf(`stat file renamed with Root.Rename(%q, "new"): %v, want ErrNotExist`, test.open, err)
+			}
+			gotFI, err := os.Lstat(filepath.Join(root.Name(), "new"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !os.SameFile(gotFI, wantFI) {
+				t.Errorf(`root.Rename(%q, "new") did not rename the expected file`, test.open)
+			}
+		})
+	}
+}
+
+func TestRootRenameTo(t *testing.T) {
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			wantError := test.wantError
+			if test.ltarget != "" {
+				// Rename doesn't follow symlinks in the final path component,
+				// so it will replace ltarget.
+				wantError = false
+				target = filepath.Join(root.Name(), test.ltarget)
+			}
+			const want = "content"
+			if err := os.WriteFile(filepath.Join(root.Name(), "old"), []byte(want), 0o666); err != nil {
+				t.Fatal(err)
+			}
+
+			err := root.Rename("old", test.open)
+			if errEndsTest(t, err, wantError, "root.Rename(%q, %q)", "old", test.open) {
+				return
+			}
+			got, err := os.ReadFile(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != want {
+				t.Errorf(`file renamed with root.Rename("old", %q) contains %q, want %q`, test.open, got, want)
+			}
+		})
+	}
+}
+
+func TestRootLink(t *testing.T) {
+	testenv.MustHaveLink(t)
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			wantError := test.wantError
+			if test.ltarget != "" {
+				// Link doesn't follow symlinks in the final path component,
+				// and fails when the new name already exists.
+				wantError = true
+			}
+			const want = "content"
+			if err := os.WriteFile(filepath.Join(root.Name(), "old"), []byte(want), 0o666); err != nil {
+				t.Fatal(err)
+			}
+
+			err := root.Link("old", test.open)
+			if errEndsTest(t, err, wantError, "root.Link(%q, %q)", "old", test.open) {
+				return
+			}
+			got, err := os.ReadFile(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != want {
+				t.Errorf(`file linked with root.Link("old", %q) contains %q, want %q`, test.open, got, want)
+			}
+		})
+	}
+}
+
+func TestRootSymlink(t *testing.T) {
+	testenv.MustHaveSymlink(t)
+	for _, test := range rootTestCases {
+		test.run(t, func(t *testing.T, target string, root *os.Root) {
+			wantError := test.wantError
+			if test.ltarget != "" {
+				// Symlink doesn't follow symlinks in the final path component,
+				// and fails when the new name already exists.
+				wantError = true
+			}
+
+			const want = "linktarget"
+			err := root.Symlink(want, test.open)
+			if errEndsTest(t, err, wantError, "root.Symlink(%q, %q)", want, test.open) {
+				return
+			}
+			got, err := os.Readlink(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf(`root.Symlink(%q, %q) created link to %q`, want, test.open, got)
+			}
+		})
+	}
+}
+
 // A rootConsistencyTest is a test case comparing os.Root behavior with
 // the corresponding non-Root function.
 //
@@ -944,6 +1232,86 @@ func TestRootConsistencyLstat(t *testing.T) {
 	}
 }
 
+func TestRootConsistencyChmod(t *testing.T) {
+	if runtime.GOOS == "wasip1" {
+		t.Skip("Chmod not supported on " + runtime.GOOS)
+	}
+	for _, test := range rootConsistencyTestCases {
+		test.run(t, func(t *testing.T, path string, r *os.Root) (string, error) {
+			var err error
+			if r == nil {
+				err = os.Chmod(path, 0o555)
+			} else {
+				err = r.Chmod(path, 0o555)
+			}
+			return "", err
+		})
+	}
+}
+
+func TestRootConsistencyChtimes(t *testing.T) {
+	for _, test := range rootConsistencyTestCases {
+		test.run(t, func(t *testing.T, path string, r *os.Root) (string, error) {
+			mtime := time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+			var err error
+			if r == nil {
+				err = os.Chtimes(path, mtime, mtime)
+			} else {
+				err = r.Chtimes(path, mtime, mtime)
+			}
+			return "", err
+		})
+	}
+}
+
+func TestRootConsistencyMkdirAll(t *testing.T) {
+	for _, test := range rootConsistencyTestCases {
+		test.run(t, func(t *testing.T, path string, r *os.Root) (string, error) {
+			var err error
+			if r == nil {
+				err = os.MkdirAll(path, 0o777)
+			} else {
+				err = r.MkdirAll(path, 0o777)
+			}
+			return "", err
+		})
+	}
+}
+
+func TestRootConsistencyRemoveAll(t *testing.T) {
+	for _, test := range rootConsistencyTestCases {
+		if test.open == "." || test.open == "./" {
+			continue // can't remove the root itself
+		}
+		if test.name == "unix domain socket in path" {
+			// Root.RemoveAll reports ENOTDIR,
+			// while RemoveAll reports the error from opening the socket.
+			test.detailedErrorMismatch = func(t *testing.T) bool { return true }
+		}
+		test.run(t, func(t *testing.T, path string, r *os.Root) (string, error) {
+			var err error
+			if r == nil {
+				err = os.RemoveAll(path)
+			} else {
+				err = r.RemoveAll(path)
+			}
+			return "", err
+		})
+	}
+}
+
+func TestRootConsistencyReadlink(t *testing.T) {
+	for _, test := range rootConsistencyTestCases {
+		test.run(t, func(t *testing.T, path string, r *os.Root) (string, error) {
+			if r == nil {
+				return os.Readlink(path)
+			} else {
+				return r.Readlink(path)
+			}
+		})
+	}
+}
+
 func TestRootRenameAfterOpen(t *testing.T) {
 	switch runtime.GOOS {
 	case "windows":
@@ -1048,6 +1416,32 @@ func TestRootUseAfterClose(t *testing.T) {
 		f: func(r *os.Root, filename string) error {
 			return r.Mkdir(filename, 0o777)
 		},
+	}, {
+		name: "MkdirAll",
+		f: func(r *os.Root, filename string) error {
+			return r.MkdirAll(filename, 0o777)
+		},
+	}, {
+		name: "RemoveAll",
+		f: func(r *os.Root, filename string) error {
+			return r.RemoveAll(filename)
+		},
+	}, {
+		name: "Chmod",
+		f: func(r *os.Root, filename string) error {
+			return r.Chmod(filename, 0o666)
+		},
+	}, {
+		name: "Chtimes",
+		f: func(r *os.Root, filename string) error {
+			return r.Chtimes(filename, time.Time{}, time.Time{})
+		},
+	}, {
+		name: "Readlink",
+		f: func(r *os.Root, filename string) error {
+			_, err := r.Readlink(filename)
+			return err
+		},
 	}} {
 		err := test.f(r, "target")
 		pe, ok := err.(*os.PathError)
@@ -11,6 +11,7 @@ import (
 	"internal/syscall/unix"
 	"runtime"
 	"syscall"
+	"time"
 )
 
 type sysfdType = int
@@ -58,7 +59,7 @@ func newRoot(fd int, name string) (*Root, error) {
 
 // openRootInRoot is Root.OpenRoot.
 func openRootInRoot(r *Root, name string) (*Root, error) {
-	fd, err := doInRoot(r, name, func(parent int, name string) (fd int, err error) {
+	fd, err := doInRoot(r, name, nil, func(parent int, name string) (fd int, err error) {
 		ignoringEINTR(func() error {
 			fd, err = unix.Openat(parent, name, syscall.O_NOFOLLOW|syscall.O_CLOEXEC, 0)
 			if isNoFollowErr(err) {
@@ -76,7 +77,7 @@ func openRootInRoot(r *Root, name string) (*Root, error) {
 
 // rootOpenFileNolog is Root.OpenFile.
 func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode) (*File, error) {
-	fd, err := doInRoot(root, name, func(parent int, name string) (fd int, err error) {
+	fd, err := doInRoot(root, name, nil, func(parent int, name string) (fd int, err error) {
 		ignoringEINTR(func() error {
 			fd, err = unix.Openat(parent, name, syscall.O_NOFOLLOW|syscall.O_CLOEXEC|flag, uint32(perm))
 			if isNoFollowErr(err) || err == syscall.ENOTDIR {
@@ -114,7 +115,7 @@ func rootOpenDir(parent int, name string) (int, error) {
 }
 
 func rootStat(r *Root, name string, lstat bool) (FileInfo, error) {
-	fi, err := doInRoot(r, name, func(parent sysfdType, n string) (FileInfo, error) {
+	fi, err := doInRoot(r, name, nil, func(parent sysfdType, n string) (FileInfo, error) {
 		var fs fileStat
 		if err := unix.Fstatat(parent, n, &fs.sys, unix.AT_SYMLINK_NOFOLLOW); err != nil {
 			return nil, err
@@ -159,6 +160,84 @@ func removeat(fd int, name string) error {
 	return e
 }
 
+func chmodat(parent int, name string, mode FileMode) error {
+	return afterResolvingSymlink(parent, name, func() error {
+		return ignoringEINTR(func() error {
+			err := unix.Fchmodat(parent, name, syscallMode(mode), unix.AT_SYMLINK_NOFOLLOW)
+			if err == syscall.EOPNOTSUPP || err == syscall.ENOTSUP {
+				// Some platforms (for example, Linux kernels which do not
+				// provide fchmodat2) don't support AT_SYMLINK_NOFOLLOW.
+				// We've already checked that name is not a symlink.
+				err = unix.Fchmodat(parent, name, syscallMode(mode), 0)
+			}
+			return err
+		})
+	})
+}
+
+func chownat(parent int, name string, uid, gid int) error {
+	return afterResolvingSymlink(parent, name, func() error {
+		return ignoringEINTR(func() error {
+			return unix.Fchownat(parent, name, uid, gid, unix.AT_SYMLINK_NOFOLLOW)
+		})
+	})
+}
+
+func lchownat(parent int, name string, uid, gid int) error {
+	return ignoringEINTR(func() error {
+		return unix.Fchownat(parent, name, uid, gid, unix.AT_SYMLINK_NOFOLLOW)
+	})
+}
+
+func chtimesat(parent int, name string, atime time.Time, mtime time.Time) error {
+	return afterResolvingSymlink(parent, name, func() error {
+		return ignoringEINTR(func() error {
+			utimes := chtimesUtimes(atime, mtime)
+			return unix.Utimensat(parent, name, &utimes, unix.AT_SYMLINK_NOFOLLOW)
+		})
+	})
+}
+
+func renameat(oldfd int, oldname string, newfd int, newname string) error {
+	return ignoringEINTR(func() error {
+		return unix.Renameat(oldfd, oldname, newfd, newname)
+	})
+}
+
+func linkat(oldfd int, oldname string, newfd int, newname string) error {
+	return ignoringEINTR(func() error {
+		return unix.Linkat(oldfd, oldname, newfd, newname, 0)
+	})
+}
+
+func symlinkat(oldname string, newfd int, newname string) error {
+	return ignoringEINTR(func() error {
+		return unix.Symlinkat(oldname, newfd, newname)
+	})
+}
+
+// modeAt returns the mode of the file name in parent.
+// It does not follow symlinks.
+func modeAt(parent int, name string) (FileMode, error) {
+	var fs fileStat
+	if err := unix.Fstatat(parent, name, &fs.sys, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return 0, err
+	}
+	fillFileStatFromSys(&fs, name)
+	return fs.Mode(), nil
+}
+
+// afterResolvingSymlink calls f, responding to symlinks in the final component of name.
+//
+// If the final component is a symlink, it returns errSymlink with the link target.
+// Otherwise it calls f.
+func afterResolvingSymlink(parent int, name string, f func() error) error {
+	if err := checkSymlink(parent, name, nil); err != nil {
+		return err
+	}
+	return f()
+}
+
 // checkSymlink resolves the symlink name in parent,
 // and returns errSymlink with the link contents.
 //
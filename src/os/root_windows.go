@@ -13,6 +13,7 @@ import (
 	"internal/syscall/windows"
 	"runtime"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
@@ -115,7 +116,7 @@ func newRoot(fd syscall.Handle, name string) (*Root, error) {
 
 // openRootInRoot is Root.OpenRoot.
 func openRootInRoot(r *Root, name string) (*Root, error) {
-	fd, err := doInRoot(r, name, rootOpenDir)
+	fd, err := doInRoot(r, name, nil, rootOpenDir)
 	if err != nil {
 		return nil, &PathError{Op: "openat", Path: name, Err: err}
 	}
@@ -124,7 +125,7 @@ func openRootInRoot(r *Root, name string) (*Root, error) {
 
 // rootOpenFileNolog is Root.OpenFile.
 func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode) (*File, error) {
-	fd, err := doInRoot(root, name, func(parent syscall.Handle, name string) (syscall.Handle, error) {
+	fd, err := doInRoot(root, name, nil, func(parent syscall.Handle, name string) (syscall.Handle, error) {
 		return openat(parent, name, flag, perm)
 	})
 	if err != nil {
@@ -207,7 +208,7 @@ func rootStat(r *Root, name string, lstat bool) (FileInfo, error) {
 		// merely the empirical evidence that Lstat behaves this way.
 		lstat = false
 	}
-	fi, err := doInRoot(r, name, func(parent syscall.Handle, n string) (FileInfo, error) {
+	fi, err := doInRoot(r, name, nil, func(parent syscall.Handle, n string) (FileInfo, error) {
 		fd, err := openat(parent, n, windows.O_OPEN_REPARSE, 0)
 		if err != nil {
 			return nil, err
@@ -239,3 +240,103 @@ func mkdirat(dirfd syscall.Handle, name string, perm FileMode) error {
 func removeat(dirfd syscall.Handle, name string) error {
 	return windows.Deleteat(dirfd, name)
 }
+
+func chmodat(parent syscall.Handle, name string, mode FileMode) error {
+	h, err := openat(parent, name, syscall.O_CLOEXEC|windows.O_WRITE_ATTRS, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(h)
+
+	var d syscall.ByHandleFileInformation
+	if err := syscall.GetFileInformationByHandle(h, &d); err != nil {
+		return err
+	}
+	attrs := d.FileAttributes
+
+	if mode&syscall.S_IWRITE != 0 {
+		attrs &^= syscall.FILE_ATTRIBUTE_READONLY
+	} else {
+		attrs |= syscall.FILE_ATTRIBUTE_READONLY
+	}
+	if attrs == d.FileAttributes {
+		return nil
+	}
+
+	var fbi windows.FILE_BASIC_INFO
+	fbi.FileAttributes = attrs
+	return windows.SetFileInformationByHandle(h, windows.FileBasicInfo, unsafe.Pointer(&fbi), uint32(unsafe.Sizeof(fbi)))
+}
+
+func chownat(parent syscall.Handle, name string, uid, gid int) error {
+	return syscall.EWINDOWS // matches syscall.Chown
+}
+
+func lchownat(parent syscall.Handle, name string, uid, gid int) error {
+	return syscall.EWINDOWS // matches syscall.Lchown
+}
+
+func chtimesat(parent syscall.Handle, name string, atime time.Time, mtime time.Time) error {
+	h, err := openat(parent, name, syscall.O_CLOEXEC|windows.O_WRITE_ATTRS, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(h)
+	a := syscall.Filetime{}
+	w := syscall.Filetime{}
+	if !atime.IsZero() {
+		a = syscall.NsecToFiletime(atime.UnixNano())
+	}
+	if !mtime.IsZero() {
+		w = syscall.NsecToFiletime(mtime.UnixNano())
+	}
+	return syscall.SetFileTime(h, nil, &a, &w)
+}
+
+func renameat(oldfd syscall.Handle, oldname string, newfd syscall.Handle, newname string) error {
+	return windows.Renameat(oldfd, oldname, newfd, newname)
+}
+
+func linkat(oldfd syscall.Handle, oldname string, newfd syscall.Handle, newname string) error {
+	return windows.Linkat(oldfd, oldname, newfd, newname)
+}
+
+func symlinkat(oldname string, newfd syscall.Handle, newname string) error {
+	// Create a directory link if oldname references a directory
+	// within the root. Otherwise, create a file link.
+	var flags uint32
+	if filepathlite.IsLocal(oldname) {
+		// We don't follow symlinks when checking for a directory,
+		// so oldname cannot resolve to a location outside the root.
+		h, err := windows.Openat(newfd, oldname, syscall.O_RDONLY|syscall.O_CLOEXEC|windows.O_DIRECTORY|windows.O_NOFOLLOW_ANY, 0)
+		if err == nil {
+			syscall.CloseHandle(h)
+			flags |= windows.SYMLINK_FLAG_DIRECTORY
+		}
+	}
+	return windows.Symlinkat(oldname, newfd, newname, flags)
+}
+
+func readlinkat(dirfd syscall.Handle, name string) (string, error) {
+	return readReparseLinkAt(dirfd, name)
+}
+
+// modeAt returns the mode of the file name in parent.
+// It does not follow symlinks.
+func modeAt(parent syscall.Handle, name string) (FileMode, error) {
+	fd, err := openat(parent, name, windows.O_OPEN_REPARSE, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer syscall.CloseHandle(fd)
+	fi, err := statHandle(name, fd)
+	if err != nil {
+		return 0, err
+	}
+	return fi.Mode(), nil
+}
+
+// newDirFile returns a new File for the directory handle fd.
+func newDirFile(fd syscall.Handle, name string) *File {
+	return newFile(fd, name, "file")
+}
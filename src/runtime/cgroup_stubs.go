@@ -0,0 +1,19 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package runtime
+
+func defaultGOMAXPROCSInit()          {}
+func defaultGOMAXPROCSUpdateGODEBUG() {}
+
+// defaultGOMAXPROCS returns procs, the number of logical CPUs, or
+// if procs is 0, the number of logical CPUs found at startup.
+func defaultGOMAXPROCS(procs int32) int32 {
+	if procs <= 0 {
+		procs = ncpu
+	}
+	return procs
+}
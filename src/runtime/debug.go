@@ -10,9 +10,64 @@ import (
 )
 
 // GOMAXPROCS sets the maximum number of CPUs that can be executing
-// simultaneously and returns the previous setting. It defaults to
-// the value of [runtime.NumCPU]. If n < 1, it does not change the current setting.
-// This call will go away when the scheduler improves.
+// simultaneously and returns the previous setting. If n < 1, it does not change
+// the current setting.
+//
+// # Default
+//
+// If the GOMAXPROCS environment variable is set to a positive whole number,
+// GOMAXPROCS defaults to that value.
+//
+// Otherwise, the Go runtime selects an appropriate default value from a combination of
+//   - the number of logical CPUs usable by the process, as reported by [NumCPU],
+//   - and, on Linux, the process’s average CPU throughput limit based on cgroup CPU
+//     quota, if any.
+//
+// If GODEBUG=containermaxprocs=0 is set and GOMAXPROCS is not set by the
+// environment variable, then GOMAXPROCS instead defaults to the value of
+// [runtime.NumCPU]. Note that GODEBUG=containermaxprocs=0 is [default] for
+// language version 1.23 and below.
+//
+// # Updates
+//
+// The Go runtime periodically updates the default value based on changes to
+// the cgroup quota and, on Linux, to the CPU affinity mask. Setting a custom value with the GOMAXPROCS environment
+// variable or by calling GOMAXPROCS disables automatic updates. The default
+// value and automatic updates can be restored by calling [SetDefaultGOMAXPROCS].
+//
+// If GODEBUG=updatemaxprocs=0 is set, the Go runtime does not perform
+// automatic GOMAXPROCS updating. Note that GODEBUG=updatemaxprocs=0 is
+// [default] for language version 1.23 and below.
+//
+// # Compatibility
+//
+// Note that the default GOMAXPROCS behavior may change as the scheduler
+// improves, especially the implementation detail below.
+//
+// # Implementation details
+//
+// When computing default GOMAXPROCS via cgroups, the Go runtime computes the
+// "average CPU throughput limit" as the cgroup CPU quota / period. In cgroup
+// v2, these values come from the cpu.max file. In cgroup v1, they come from
+// cpu.cfs_quota_us and cpu.cfs_period_us, respectively. In container runtimes
+// that allow configuring CPU limits, this value usually corresponds to the
+// "CPU limit" option, not "CPU request".
+//
+// The Go runtime typically selects the default GOMAXPROCS as the minimum of
+// the logical CPU count and the cgroup CPU throughput limit. However, it will
+// never set GOMAXPROCS less than 2 unless the logical CPU count is below 2.
+//
+// On Linux, the logical CPU count is the number of CPUs in the CPU affinity
+// mask of the process. It is read again for each update, so after the mask
+// changes it may differ from [NumCPU], which is only read at startup.
+//
+// If the cgroup CPU throughput limit is not a whole number, the Go runtime
+// rounds up to the next whole number.
+//
+// GOMAXPROCS updates are performed up to once per second, or less if the
+// application is idle.
+//
+// [default]: https://go.dev/doc/godebug#default
 func GOMAXPROCS(n int) int {
 	if GOARCH == "wasm" && n > 1 {
 		n = 1 // WebAssembly has no threads yet, so only one CPU is possible.
@@ -20,8 +75,22 @@ func GOMAXPROCS(n int) int {
 
 	lock(&sched.lock)
 	ret := int(gomaxprocs)
+	if n <= 0 {
+		unlock(&sched.lock)
+		return ret
+	}
+	// Set early so we can wait for sysmon before STW. See comment on
+	// computeMaxProcsLock.
+	sched.customGOMAXPROCS = true
 	unlock(&sched.lock)
-	if n <= 0 || n == ret {
+
+	// Wait for sysmon to complete running defaultGOMAXPROCS.
+	lock(&computeMaxProcsLock)
+	unlock(&computeMaxProcsLock)
+
+	if n == ret {
+		// sched.customGOMAXPROCS set, but no need to actually STW
+		// since the gomaxprocs itself isn't changing.
 		return ret
 	}
 
@@ -34,6 +103,43 @@ func GOMAXPROCS(n int) int {
 	return ret
 }
 
+// SetDefaultGOMAXPROCS updates the GOMAXPROCS setting to the runtime
+// default, as described by [GOMAXPROCS], ignoring the GOMAXPROCS
+// environment variable.
+//
+// SetDefaultGOMAXPROCS can be used to enable the default automatic updating
+// GOMAXPROCS behavior if it has been disabled by the GOMAXPROCS
+// environment variable or a prior call to [GOMAXPROCS], or to force an immediate
+// update if the caller is aware of a change to the cgroup quota.
+func SetDefaultGOMAXPROCS() {
+	// SetDefaultGOMAXPROCS conceptually means "[re]do what the runtime
+	// would do at startup if the GOMAXPROCS environment variable were
+	// unset." It still respects GODEBUG.
+
+	procs := defaultGOMAXPROCS(0)
+
+	lock(&sched.lock)
+	curr := gomaxprocs
+	custom := sched.customGOMAXPROCS
+	unlock(&sched.lock)
+
+	if !custom && procs == curr {
+		// Nothing to do if we're already using automatic GOMAXPROCS
+		// and the limit is unchanged.
+		return
+	}
+
+	stw := stopTheWorldGC(stwGOMAXPROCS)
+
+	// newprocs will be processed by startTheWorld
+	newprocs = procs
+	lock(&sched.lock)
+	sched.customGOMAXPROCS = false
+	unlock(&sched.lock)
+
+	startTheWorldGC(stw)
+}
+
 // NumCPU returns the number of logical CPUs usable by the current process.
 //
 // The set of available CPUs is checked by querying the operating system
@@ -231,7 +231,7 @@ The GOMAXPROCS variable limits the number of operating system threads that
 can execute user-level Go code simultaneously. There is no limit to the number of threads
 that can be blocked in system calls on behalf of Go code; those do not count against
 the GOMAXPROCS limit. This package's [GOMAXPROCS] function queries and changes
-the limit.
+the limit, and documents how the default is chosen when the variable is not set.
 
 The GORACE variable configures the race detector, for programs built using -race.
 See the [Race Detector article] for details.
@@ -0,0 +1,44 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !goexperiment.goroutineleakprofile
+
+package runtime
+
+import "unsafe"
+
+// maybeTraceablePtr is an ordinary pointer when goroutine leak
+// detection is disabled (the default), so that sudogs stay small.
+// See goroutineleak_on.go.
+type maybeTraceablePtr struct {
+	vp unsafe.Pointer
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) setUntraceable() {
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) setTraceable() {
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) set(v unsafe.Pointer) {
+	p.vp = v
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) get() unsafe.Pointer {
+	return p.vp
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) uintptr() uintptr {
+	return uintptr(p.vp)
+}
+
+// adjust adjusts the pointer for a stack copy.
+func (p *maybeTraceablePtr) adjust(adjinfo *adjustinfo) {
+	adjustpointer(adjinfo, unsafe.Pointer(&p.vp))
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.goroutineleakprofile
+
+package runtime
+
+import "unsafe"
+
+// maybeTraceablePtr is a pointer that can be hidden from the
+// garbage collector. The address in vu is the source of truth;
+// vp holds the same address while the pointer is traceable,
+// and nil while it is hidden.
+//
+// Goroutine leak detection hides the channels and synchronization
+// objects that blocked goroutines wait on, so that they are only
+// marked if they are reachable from elsewhere.
+//
+// Do not set the fields directly. Use the methods instead.
+type maybeTraceablePtr struct {
+	vp unsafe.Pointer // for liveness only
+	vu uintptr
+}
+
+// setUntraceable hides the pointer from the GC.
+//
+//go:nosplit
+func (p *maybeTraceablePtr) setUntraceable() {
+	p.vp = nil
+}
+
+// setTraceable makes the pointer visible to the GC again.
+//
+//go:nosplit
+func (p *maybeTraceablePtr) setTraceable() {
+	p.vp = unsafe.Pointer(p.vu)
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) set(v unsafe.Pointer) {
+	p.vp = v
+	p.vu = uintptr(v)
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) get() unsafe.Pointer {
+	return unsafe.Pointer(p.vu)
+}
+
+//go:nosplit
+func (p *maybeTraceablePtr) uintptr() uintptr {
+	return p.vu
+}
+
+// adjust adjusts the pointer for a stack copy.
+func (p *maybeTraceablePtr) adjust(adjinfo *adjustinfo) {
+	adjustpointer(adjinfo, unsafe.Pointer(&p.vu))
+	adjustpointer(adjinfo, unsafe.Pointer(&p.vp))
+}
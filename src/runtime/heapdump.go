@@ -418,7 +418,8 @@ func dumpgs() {
 			// ok
 		case _Grunnable,
 			_Gsyscall,
-			_Gwaiting:
+			_Gwaiting,
+			_Gleaked:
 			dumpgoroutine(gp)
 		}
 	})
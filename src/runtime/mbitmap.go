@@ -1303,6 +1303,17 @@ func findObject(p, refBase, refOff uintptr) (base uintptr, s *mspan, objIndex ui
 	return
 }
 
+// isMarkedOrNotInHeap reports whether p points to a marked heap
+// object, or does not point into the heap at all.
+func isMarkedOrNotInHeap(p unsafe.Pointer) bool {
+	obj, span, objIndex := findObject(uintptr(p), 0, 0)
+	if obj == 0 {
+		// Pointers to stacks and globals are always live.
+		return true
+	}
+	return span.markBitsForIndex(objIndex).isMarked()
+}
+
 // reflect_verifyNotInHeapPtr reports whether converting the not-in-heap pointer into a unsafe.Pointer is ok.
 //
 //go:linkname reflect_verifyNotInHeapPtr reflect.verifyNotInHeapPtr
@@ -130,6 +130,7 @@ package runtime
 
 import (
 	"internal/cpu"
+	"internal/goexperiment"
 	"internal/runtime/atomic"
 	"unsafe"
 )
@@ -350,8 +351,8 @@ type workType struct {
 	// (and thus 8-byte alignment even on 32-bit architectures).
 	bytesMarked uint64
 
-	markrootNext uint32 // next markroot job
-	markrootJobs uint32 // number of markroot jobs
+	markrootNext atomic.Uint32 // next markroot job
+	markrootJobs atomic.Uint32 // number of markroot jobs
 
 	nproc  uint32
 	tstart int64
@@ -363,6 +364,12 @@ type workType struct {
 	// consistency.
 	nDataRoots, nBSSRoots, nSpanRoots, nStackRoots int
 
+	// nMaybeRunnableStackRoots is the number of stack roots queued
+	// as markroot jobs. It is less than nStackRoots during goroutine
+	// leak detection, when the stacks of goroutines that may be
+	// leaked are only queued once they are found to be reachable.
+	nMaybeRunnableStackRoots int
+
 	// Base indexes of each root type. Set by gcMarkRootPrepare.
 	baseData, baseBSS, baseSpans, baseStacks, baseEnd uint32
 
@@ -372,6 +379,27 @@ type workType struct {
 	// shared with allgs.
 	stackRoots []*g
 
+	// goroutineLeak holds the state of goroutine leak detection.
+	// It is only used with GOEXPERIMENT=goroutineleakprofile.
+	goroutineLeak struct {
+		// pending is set when the next GC cycle should
+		// detect goroutine leaks.
+		pending atomic.Bool
+
+		// enabled is set if the current GC cycle is
+		// detecting goroutine leaks. It is only changed
+		// while the world is stopped.
+		enabled bool
+
+		// done is set once leaked goroutines have been found
+		// in the current cycle.
+		done bool
+
+		// count is the number of goroutines found to be
+		// leaked by the last leak detection cycle.
+		count int
+	}
+
 	// Each type of GC state transition is protected by a lock.
 	// Since multiple threads can simultaneously detect the state
 	// transition condition, any thread that detects a transition
@@ -536,6 +564,29 @@ func GC() {
 	releasem(mp)
 }
 
+// goroutineLeakGC runs a GC cycle that detects leaked goroutines.
+// Goroutines that are found to be leaked are left in the _Gleaked
+// state until the next goroutine leak detection cycle.
+// It does nothing without GOEXPERIMENT=goroutineleakprofile.
+//
+//go:linkname goroutineLeakGC runtime/pprof.runtime_goroutineLeakGC
+func goroutineLeakGC() {
+	if !goexperiment.GoroutineLeakProfile {
+		return
+	}
+
+	// Ask the next GC cycle to detect goroutine leaks.
+	work.goroutineLeak.pending.Store(true)
+
+	// The next cycle clears the pending flag when it starts,
+	// but GC may find that another goroutine already started
+	// the cycle it was going to wait for, before the flag was
+	// set. Keep running cycles until one has picked it up.
+	for work.goroutineLeak.pending.Load() {
+		GC()
+	}
+}
+
 // gcWaitOnMark blocks until GC finishes the Nth mark phase. If GC has
 // already completed this mark phase, it returns immediately.
 func gcWaitOnMark(n uint32) {
@@ -754,6 +805,15 @@ func gcStart(trigger gcTrigger) {
 		schedEnableUser(false)
 	}
 
+	// If goroutine leak detection was requested, this cycle
+	// does it. Hide the objects that blocked goroutines are
+	// waiting on from the GC until the end of the cycle.
+	if goexperiment.GoroutineLeakProfile && work.goroutineLeak.pending.Load() {
+		work.goroutineLeak.enabled = true
+		work.goroutineLeak.pending.Store(false)
+		setSyncObjectsUntraceable()
+	}
+
 	// Enter concurrent mark phase and enable
 	// write barriers.
 	//
@@ -962,8 +1022,16 @@ top:
 			}
 		}
 	})
-	if restart {
-		gcDebugMarkDone.restartedDueTo27993 = true
+	if restart || (goexperiment.GoroutineLeakProfile && work.goroutineLeak.enabled && !work.goroutineLeak.done) {
+		if restart {
+			gcDebugMarkDone.restartedDueTo27993 = true
+		} else {
+			// Marking has reached a fixed point, so the
+			// remaining candidates may be leaked. If more
+			// goroutines turn out to be runnable, their
+			// stacks must be scanned before looking again.
+			work.goroutineLeak.done = findGoroutineLeaks()
+		}
 
 		getg().m.preemptoff = ""
 		systemstack(func() {
@@ -1014,6 +1082,157 @@ top:
 	gcMarkTermination(stw)
 }
 
+// isMaybeRunnable reports whether gp may become runnable again.
+// It returns false for a goroutine that is blocked on channels
+// or synchronization objects that have not been marked (yet).
+func (gp *g) isMaybeRunnable() bool {
+	if readgstatus(gp) != _Gwaiting {
+		return true
+	}
+	switch gp.waitreason {
+	case waitReasonSelectNoCases, waitReasonChanSendNilChan, waitReasonChanReceiveNilChan:
+		// These goroutines can never be woken.
+		return false
+	case waitReasonChanReceive, waitReasonChanSend, waitReasonSelect:
+		for sg := gp.waiting; sg != nil; sg = sg.waitlink {
+			if isMarkedOrNotInHeap(unsafe.Pointer(sg.c.get())) {
+				return true
+			}
+		}
+		return false
+	}
+	if gp.waitreason.isSyncWait() && gp.waiting != nil {
+		return isMarkedOrNotInHeap(gp.waiting.elem.get())
+	}
+	return true
+}
+
+// findMaybeRunnableGoroutines moves the goroutines in
+// work.stackRoots[work.nMaybeRunnableStackRoots:] that may now be
+// runnable to the front of that range, and queues their stacks as
+// markroot jobs. It reports whether it queued any.
+//
+// The world must be stopped.
+func findMaybeRunnableGoroutines() bool {
+	i, j := work.nMaybeRunnableStackRoots, work.nStackRoots
+	for i < j {
+		if work.stackRoots[i].isMaybeRunnable() {
+			i++
+			continue
+		}
+		j--
+		work.stackRoots[i], work.stackRoots[j] = work.stackRoots[j], work.stackRoots[i]
+	}
+	if i == work.nMaybeRunnableStackRoots {
+		return false
+	}
+	work.markrootJobs.Add(int32(i - work.nMaybeRunnableStackRoots))
+	work.nMaybeRunnableStackRoots = i
+	return true
+}
+
+// leakCandidate reports whether goroutine leak detection should
+// check whether gp is leaked.
+func (gp *g) leakCandidate() bool {
+	// Goroutines in a synctest bubble are woken by the bubble
+	// itself, and are covered by its own deadlock detection.
+	return readgstatus(gp) == _Gwaiting && gp.waitreason.canLeak() && gp.syncGroup == nil
+}
+
+// setSyncObjectsUntraceable hides the channels and synchronization
+// objects that leak candidates are blocked on from the GC, so that
+// they are only marked if they are reachable from elsewhere.
+//
+// The world must be stopped.
+func setSyncObjectsUntraceable() {
+	assertWorldStopped()
+
+	forEachGRace(func(gp *g) {
+		// Check goroutines found to be leaked by
+		// a previous cycle again.
+		gp.atomicstatus.CompareAndSwap(_Gleaked, _Gwaiting)
+		if !gp.leakCandidate() {
+			return
+		}
+		switch {
+		case gp.waitreason.isSyncWait():
+			for sg := gp.waiting; sg != nil; sg = sg.waitlink {
+				sg.elem.setUntraceable()
+			}
+		case gp.waitreason.isChanWait():
+			for sg := gp.waiting; sg != nil; sg = sg.waitlink {
+				sg.c.setUntraceable()
+			}
+		}
+	})
+}
+
+// gcRestoreSyncObjects undoes setSyncObjectsUntraceable.
+//
+// The world must be stopped.
+func gcRestoreSyncObjects() {
+	assertWorldStopped()
+
+	forEachGRace(func(gp *g) {
+		for sg := gp.waiting; sg != nil; sg = sg.waitlink {
+			sg.elem.setTraceable()
+			sg.c.setTraceable()
+		}
+	})
+}
+
+// findGoroutineLeaks is called when marking reaches a fixed point
+// during goroutine leak detection. If more goroutines may now be
+// runnable, it queues their stacks and returns false. Otherwise it
+// marks the remaining candidates as leaked, queues their stacks so
+// that what they reference stays live, and returns true.
+//
+// The world must be stopped.
+func findGoroutineLeaks() bool {
+	assertWorldStopped()
+
+	if work.nMaybeRunnableStackRoots == work.nStackRoots {
+		work.goroutineLeak.count = 0
+		return true
+	}
+	if findMaybeRunnableGoroutines() {
+		return false
+	}
+
+	leaked := work.stackRoots[work.nMaybeRunnableStackRoots:work.nStackRoots]
+	work.goroutineLeak.count = len(leaked)
+	for _, gp := range leaked {
+		casgstatus(gp, _Gwaiting, _Gleaked)
+
+		// The objects the goroutine is blocked on should be
+		// reachable from its stack, but shade them directly
+		// since they are about to become traceable again.
+		switch {
+		case gp.waitreason.isChanWait():
+			for sg := gp.waiting; sg != nil; sg = sg.waitlink {
+				shade(sg.c.uintptr())
+			}
+		case gp.waitreason.isSyncWait():
+			for sg := gp.waiting; sg != nil; sg = sg.waitlink {
+				shade(sg.elem.uintptr())
+			}
+		}
+	}
+
+	// A main goroutine blocked in select{} is waiting for other
+	// goroutines to exit the program, so don't report it. It is
+	// still treated as leaked above, so that the objects only it
+	// refers to don't hide leaks of other goroutines.
+	if gp := allgs[0]; gp.goid == 1 && readgstatus(gp) == _Gleaked && gp.waitreason == waitReasonSelectNoCases {
+		casgstatus(gp, _Gleaked, _Gwaiting)
+		work.goroutineLeak.count--
+	}
+
+	work.markrootJobs.Add(int32(len(leaked)))
+	work.nMaybeRunnableStackRoots = work.nStackRoots
+	return true
+}
+
 // World must be stopped and mark assists and background workers must be
 // disabled.
 func gcMarkTermination(stw worldStop) {
@@ -1173,6 +1392,13 @@ func gcMarkTermination(stw worldStop) {
 		throw("non-concurrent sweep failed to drain all sweep queues")
 	}
 
+	leakCheck := goexperiment.GoroutineLeakProfile && work.goroutineLeak.enabled
+	if leakCheck {
+		gcRestoreSyncObjects()
+		work.goroutineLeak.enabled = false
+		work.goroutineLeak.done = false
+	}
+
 	systemstack(func() {
 		// The memstats updated above must be updated with the world
 		// stopped to ensure consistency of some values, such as
@@ -1241,7 +1467,11 @@ func gcMarkTermination(stw worldStop) {
 		printlock()
 		print("gc ", memstats.numgc,
 			" @", string(itoaDiv(sbuf[:], uint64(work.tSweepTerm-runtimeInitTime)/1e6, 3)), "s ",
-			util, "%: ")
+			util, "%")
+		if leakCheck {
+			print(" (checking for goroutine leaks)")
+		}
+		print(": ")
 		prev := work.tSweepTerm
 		for i, ns := range []int64{work.tMark, work.tMarkTerm, work.tEnd} {
 			if i != 0 {
@@ -1571,7 +1801,7 @@ func gcMarkWorkAvailable(p *p) bool {
 	if !work.full.empty() {
 		return true // global work available
 	}
-	if work.markrootNext < work.markrootJobs {
+	if work.markrootNext.Load() < work.markrootJobs.Load() {
 		return true // root scan work available
 	}
 	return false
@@ -1587,8 +1817,8 @@ func gcMark(startTime int64) {
 	work.tstart = startTime
 
 	// Check that there's no marking work remaining.
-	if work.full != 0 || work.markrootNext < work.markrootJobs {
-		print("runtime: full=", hex(work.full), " next=", work.markrootNext, " jobs=", work.markrootJobs, " nDataRoots=", work.nDataRoots, " nBSSRoots=", work.nBSSRoots, " nSpanRoots=", work.nSpanRoots, " nStackRoots=", work.nStackRoots, "\n")
+	if next, jobs := work.markrootNext.Load(), work.markrootJobs.Load(); work.full != 0 || next < jobs {
+		print("runtime: full=", hex(work.full), " next=", next, " jobs=", jobs, " nDataRoots=", work.nDataRoots, " nBSSRoots=", work.nBSSRoots, " nSpanRoots=", work.nSpanRoots, " nStackRoots=", work.nStackRoots, "\n")
 		panic("non-empty mark queue after concurrent mark")
 	}
 
@@ -9,6 +9,7 @@ package runtime
 import (
 	"internal/abi"
 	"internal/goarch"
+	"internal/goexperiment"
 	"internal/runtime/atomic"
 	"internal/runtime/sys"
 	"unsafe"
@@ -51,6 +52,29 @@ const (
 	pagesPerSpanRoot = 512
 )
 
+// allGsSnapshotSortedForGC is like allGsSnapshot, but for goroutine
+// leak detection. It returns a copy of allgs with the goroutines that
+// may be runnable first, followed by the goroutines that are blocked
+// in a way that may leak, along with the number of the former.
+//
+// The world must be stopped.
+func allGsSnapshotSortedForGC() ([]*g, int) {
+	assertWorldStopped()
+
+	gs := make([]*g, len(allgs))
+	runnable, blocked := 0, len(gs)
+	for _, gp := range allgs {
+		if gp.leakCandidate() {
+			blocked--
+			gs[blocked] = gp
+		} else {
+			gs[runnable] = gp
+			runnable++
+		}
+	}
+	return gs, runnable
+}
+
 // gcMarkRootPrepare queues root scanning jobs (stacks, globals, and
 // some miscellany) and initializes scanning-related state.
 //
@@ -100,11 +124,19 @@ func gcMarkRootPrepare() {
 	// ignore them because they begin life without any roots, so
 	// there's nothing to scan, and any roots they create during
 	// the concurrent phase will be caught by the write barrier.
-	work.stackRoots = allGsSnapshot()
+	if goexperiment.GoroutineLeakProfile && work.goroutineLeak.enabled {
+		// Goroutine leak detection only scans the stacks of
+		// goroutines that may be runnable to start with.
+		// See findGoroutineLeaks.
+		work.stackRoots, work.nMaybeRunnableStackRoots = allGsSnapshotSortedForGC()
+	} else {
+		work.stackRoots = allGsSnapshot()
+		work.nMaybeRunnableStackRoots = len(work.stackRoots)
+	}
 	work.nStackRoots = len(work.stackRoots)
 
-	work.markrootNext = 0
-	work.markrootJobs = uint32(fixedRootCount + work.nDataRoots + work.nBSSRoots + work.nSpanRoots + work.nStackRoots)
+	work.markrootNext.Store(0)
+	work.markrootJobs.Store(uint32(fixedRootCount + work.nDataRoots + work.nBSSRoots + work.nSpanRoots + work.nMaybeRunnableStackRoots))
 
 	// Calculate base indexes of each root type
 	work.baseData = uint32(fixedRootCount)
@@ -117,8 +149,8 @@ func gcMarkRootPrepare() {
 // gcMarkRootCheck checks that all roots have been scanned. It is
 // purely for debugging.
 func gcMarkRootCheck() {
-	if work.markrootNext < work.markrootJobs {
-		print(work.markrootNext, " of ", work.markrootJobs, " markroot jobs done\n")
+	if next, jobs := work.markrootNext.Load(), work.markrootJobs.Load(); next < jobs {
+		print(next, " of ", jobs, " markroot jobs done\n")
 		throw("left over markroot jobs")
 	}
 
@@ -848,7 +880,7 @@ func scanstack(gp *g, gcw *gcWork) int64 {
 	case _Grunning:
 		print("runtime: gp=", gp, ", goid=", gp.goid, ", gp->atomicstatus=", readgstatus(gp), "\n")
 		throw("scanstack: goroutine not stopped")
-	case _Grunnable, _Gsyscall, _Gwaiting:
+	case _Grunnable, _Gsyscall, _Gwaiting, _Gleaked:
 		// ok
 	}
 
@@ -1116,6 +1148,28 @@ func gcDrainMarkWorkerFractional(gcw *gcWork) {
 	gcDrain(gcw, gcDrainFractional|gcDrainUntilPreempt|gcDrainFlushBgCredit)
 }
 
+// gcNextMarkRoot claims the next root job. It reports false
+// if there are no more root jobs.
+func gcNextMarkRoot() (uint32, bool) {
+	if !goexperiment.GoroutineLeakProfile || !work.goroutineLeak.enabled {
+		job := work.markrootNext.Add(1) - 1
+		return job, job < work.markrootJobs.Load()
+	}
+
+	// During goroutine leak detection, findGoroutineLeaks may
+	// add more stack roots, so markrootNext must not run past
+	// markrootJobs.
+	for {
+		next, jobs := work.markrootNext.Load(), work.markrootJobs.Load()
+		if next >= jobs {
+			return 0, false
+		}
+		if work.markrootNext.CompareAndSwap(next, next+1) {
+			return next, true
+		}
+	}
+}
+
 // gcDrain scans roots and objects in work buffers, blackening grey
 // objects until it is unable to get more work. It may return before
 // GC is done; it's the caller's responsibility to balance work from
@@ -1175,12 +1229,12 @@ func gcDrain(gcw *gcWork, flags gcDrainFlags) {
 	}
 
 	// Drain root marking jobs.
-	if work.markrootNext < work.markrootJobs {
+	if work.markrootNext.Load() < work.markrootJobs.Load() {
 		// Stop if we're preemptible, if someone wants to STW, or if
 		// someone is calling forEachP.
 		for !(gp.preempt && (preemptible || sched.gcwaiting.Load() || pp.runSafePointFn != 0)) {
-			job := atomic.Xadd(&work.markrootNext, +1) - 1
-			if job >= work.markrootJobs {
+			job, ok := gcNextMarkRoot()
+			if !ok {
 				break
 			}
 			markroot(gcw, job, flushBgCredit)
@@ -1303,9 +1357,8 @@ func gcDrainN(gcw *gcWork, scanWork int64) int64 {
 
 		if b == 0 {
 			// Try to do a root job.
-			if work.markrootNext < work.markrootJobs {
-				job := atomic.Xadd(&work.markrootNext, +1) - 1
-				if job < work.markrootJobs {
+			if work.markrootNext.Load() < work.markrootJobs.Load() {
+				if job, ok := gcNextMarkRoot(); ok {
 					workFlushed += markroot(gcw, job, false)
 					continue
 				}
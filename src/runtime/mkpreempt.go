@@ -250,6 +250,14 @@ func genAMD64() {
 			lSSE.add("MOVUPS", reg, 16)
 		}
 	}
+	// With GOEXPERIMENT=simd, the compiler may keep 256-bit vectors
+	// in the Y registers, so save them in full if the CPU has AVX.
+	lAVX := layout{stack: l.stack, sp: "SP"}
+	for _, reg := range regNamesAMD64 {
+		if strings.HasPrefix(reg, "X") {
+			lAVX.add("VMOVDQU", "Y"+reg[1:], 32)
+		}
+	}
 
 	// TODO: MXCSR register?
 
@@ -258,17 +266,41 @@ func genAMD64() {
 	p("// Save flags before clobbering them")
 	p("PUSHFQ")
 	p("// obj doesn't understand ADD/SUB on SP, but does understand ADJSP")
+	p("#ifdef GOEXPERIMENT_simd")
+	p("ADJSP $%d", lAVX.stack)
+	p("#else")
 	p("ADJSP $%d", lSSE.stack)
+	p("#endif")
 	p("// But vet doesn't know ADJSP, so suppress vet stack checking")
 	p("NOP SP")
 
 	l.save()
 
+	p("#ifdef GOEXPERIMENT_simd")
+	p("CMPB internal∕cpu·X86+const_offsetX86HasAVX(SB), $1")
+	p("JNE saveSSE")
+	lAVX.save()
+	p("JMP preempt")
+	p("#endif")
+	label("saveSSE:")
 	lSSE.save()
+	label("preempt:")
 	p("CALL ·asyncPreempt2(SB)")
+	p("#ifdef GOEXPERIMENT_simd")
+	p("CMPB internal∕cpu·X86+const_offsetX86HasAVX(SB), $1")
+	p("JNE restoreSSE")
+	lAVX.restore()
+	p("JMP restoreGPs")
+	p("#endif")
+	label("restoreSSE:")
 	lSSE.restore()
+	label("restoreGPs:")
 	l.restore()
+	p("#ifdef GOEXPERIMENT_simd")
+	p("ADJSP $%d", -lAVX.stack)
+	p("#else")
 	p("ADJSP $%d", -lSSE.stack)
+	p("#endif")
 	p("POPFQ")
 	p("POPQ BP")
 	p("RET")
@@ -319,6 +351,16 @@ func genARM() {
 }
 
 func genARM64() {
+	// With GOEXPERIMENT=simd, the compiler may keep 128-bit vectors
+	// in V0-V15, so save those registers in full.
+	p("#ifdef GOEXPERIMENT_simd")
+	genARM64Body(true)
+	p("#else")
+	genARM64Body(false)
+	p("#endif")
+}
+
+func genARM64Body(simd bool) {
 	// Add integer registers R0-R26
 	// R27 (REGTMP), R28 (g), R29 (FP), R30 (LR), R31 (SP) are special
 	// and not saved here.
@@ -342,7 +384,18 @@ func genARM64() {
 		8)
 	// TODO: FPCR? I don't think we'll change it, so no need to save.
 	// Add floating point registers F0-F31.
-	for i := 0; i < 31; i += 2 {
+	i := 0
+	if simd {
+		// Save all 128 bits of V0-V15.
+		if l.stack%16 != 0 {
+			l.stack += 8 // FSTPQ needs 16-byte alignment
+		}
+		for ; i < 16; i += 2 {
+			reg := fmt.Sprintf("(F%d, F%d)", i, i+1)
+			l.add2("FSTPQ", "FLDPQ", reg, 32)
+		}
+	}
+	for ; i < 31; i += 2 {
 		reg := fmt.Sprintf("(F%d, F%d)", i, i+1)
 		l.add2("FSTPD", "FLDPD", reg, 16)
 	}
@@ -1323,6 +1323,57 @@ func goroutineProfileWithLabels(p []profilerecord.StackRecord, labels []unsafe.P
 	return goroutineProfileWithLabelsConcurrent(p, labels)
 }
 
+//go:linkname pprof_goroutineLeakProfileWithLabels
+func pprof_goroutineLeakProfileWithLabels(p []profilerecord.StackRecord, labels []unsafe.Pointer) (n int, ok bool) {
+	return goroutineLeakProfileWithLabels(p, labels)
+}
+
+// goroutineLeakProfileWithLabels records the stacks of the goroutines
+// found to be leaked by the last goroutine leak detection cycle.
+// labels may be nil. If labels is non-nil, it must have the same length as p.
+func goroutineLeakProfileWithLabels(p []profilerecord.StackRecord, labels []unsafe.Pointer) (n int, ok bool) {
+	if labels != nil && len(labels) != len(p) {
+		labels = nil
+	}
+	if len(p) == 0 {
+		// Return an estimate without bothering to STW.
+		return work.goroutineLeak.count, false
+	}
+
+	pcbuf := makeProfStack() // see saveg() for explanation
+	stw := stopTheWorld(stwGoroutineProfile)
+
+	// World is stopped, no locking required.
+	forEachGRace(func(gp1 *g) {
+		if readgstatus(gp1) == _Gleaked {
+			n++
+		}
+	})
+
+	if n <= len(p) {
+		ok = true
+		i := 0
+		forEachGRace(func(gp1 *g) {
+			if readgstatus(gp1) != _Gleaked {
+				return
+			}
+			// See goroutineProfileWithLabelsSync.
+			systemstack(func() { saveg(^uintptr(0), ^uintptr(0), gp1, &p[i], pcbuf) })
+			if labels != nil {
+				labels[i] = gp1.labels
+			}
+			i++
+		})
+	}
+
+	if raceenabled {
+		raceacquire(unsafe.Pointer(&labelSync))
+	}
+
+	startTheWorld(stw)
+	return n, ok
+}
+
 var goroutineProfile = struct {
 	sema    uint32
 	active  bool
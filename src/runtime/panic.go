@@ -801,10 +801,13 @@ func gopanic(e any) {
 	// Because it is unsafe to call arbitrary user code after freezing
 	// the world, we call preprintpanics to invoke all necessary Error
 	// and String methods to prepare the panic strings before startpanic.
+	// Record any deadlocked synctest bubble first, since preprintpanics
+	// replaces the panic values.
+	sg := synctestDeadlockGroup(&p)
 	preprintpanics(&p)
 
-	fatalpanic(&p)   // should not return
-	*(*int)(nil) = 0 // not reached
+	fatalpanic(&p, sg) // should not return
+	*(*int)(nil) = 0   // not reached
 }
 
 // start initializes a panic to start unwinding the stack.
@@ -1063,6 +1066,11 @@ func internal_sync_fatal(s string) {
 	fatal(s)
 }
 
+//go:linkname cgroup_throw internal/runtime/cgroup.throw
+func cgroup_throw(s string) {
+	throw(s)
+}
+
 // throw triggers a fatal error that dumps a stack trace and exits.
 //
 // throw should be used for runtime-internal fatal errors where Go itself,
@@ -1276,7 +1284,7 @@ func fatalthrow(t throwType) {
 
 		startpanic_m()
 
-		if dopanic_m(gp, pc, sp) {
+		if dopanic_m(gp, pc, sp, nil) {
 			// crash uses a decent amount of nosplit stack and we're already
 			// low on stack in throw, so crash on the system stack (unlike
 			// fatalpanic).
@@ -1292,9 +1300,11 @@ func fatalthrow(t throwType) {
 // fatalpanic implements an unrecoverable panic. It is like fatalthrow, except
 // that if msgs != nil, fatalpanic also prints panic messages and decrements
 // runningPanicDefers once main is blocked from exiting.
+// If sg != nil, the panic was caused by a deadlock in the synctest group sg,
+// and fatalpanic also prints the stacks of the goroutines in sg.
 //
 //go:nosplit
-func fatalpanic(msgs *_panic) {
+func fatalpanic(msgs *_panic, sg *synctestGroup) {
 	pc := sys.GetCallerPC()
 	sp := sys.GetCallerSP()
 	gp := getg()
@@ -1314,7 +1324,7 @@ func fatalpanic(msgs *_panic) {
 			printpanics(msgs)
 		}
 
-		docrash = dopanic_m(gp, pc, sp)
+		docrash = dopanic_m(gp, pc, sp, sg)
 	})
 
 	if docrash {
@@ -1396,7 +1406,7 @@ var deadlock mutex
 
 // gp is the crashing g running on this M, but may be a user G, while getg() is
 // always g0.
-func dopanic_m(gp *g, pc, sp uintptr) bool {
+func dopanic_m(gp *g, pc, sp uintptr, sg *synctestGroup) bool {
 	if gp.sig != 0 {
 		signame := signame(gp.sig)
 		if signame != "" {
@@ -1423,6 +1433,8 @@ func dopanic_m(gp *g, pc, sp uintptr) bool {
 		if !didothers && all {
 			didothers = true
 			tracebackothers(gp)
+		} else if sg != nil {
+			tracebacksynctest(gp, sg)
 		}
 	}
 	unlock(&paniclk)
@@ -80,6 +80,7 @@ import (
 	"cmp"
 	"fmt"
 	"internal/abi"
+	"internal/goexperiment"
 	"internal/profilerecord"
 	"io"
 	"runtime"
@@ -105,12 +106,13 @@ import (
 //
 // Each Profile has a unique name. A few profiles are predefined:
 //
-//	goroutine    - stack traces of all current goroutines
-//	heap         - a sampling of memory allocations of live objects
-//	allocs       - a sampling of all past memory allocations
-//	threadcreate - stack traces that led to the creation of new OS threads
-//	block        - stack traces that led to blocking on synchronization primitives
-//	mutex        - stack traces of holders of contended mutexes
+//	goroutine     - stack traces of all current goroutines
+//	goroutineleak - stack traces of all leaked goroutines (experimental, see below)
+//	heap          - a sampling of memory allocations of live objects
+//	allocs        - a sampling of all past memory allocations
+//	threadcreate  - stack traces that led to the creation of new OS threads
+//	block         - stack traces that led to blocking on synchronization primitives
+//	mutex         - stack traces of holders of contended mutexes
 //
 // These predefined profiles maintain themselves and panic on an explicit
 // [Profile.Add] or [Profile.Remove] method call.
@@ -119,6 +121,19 @@ import (
 // the [StartCPUProfile] and [StopCPUProfile] functions, because it streams
 // output to a writer during profiling.
 //
+// # Goroutine leak profile
+//
+// The goroutine leak profile reports goroutines that are blocked forever.
+// Writing the profile runs a garbage collection that looks for goroutines
+// blocked on channel operations, or on a [sync.Mutex], [sync.RWMutex],
+// [sync.WaitGroup] or [sync.Cond], where the channels or synchronization
+// objects are unreachable from any goroutine that could wake them.
+// Goroutines blocked forever in other ways, such as on I/O or on a channel
+// that a global variable refers to, are not reported.
+//
+// The goroutine leak profile is experimental, and is only available
+// in programs built with GOEXPERIMENT=goroutineleakprofile.
+//
 // # Heap profile
 //
 // The heap profile reports statistics as of the most recently completed
@@ -225,6 +240,18 @@ var mutexProfile = &Profile{
 	write: writeMutex,
 }
 
+var goroutineLeakProfile = &Profile{
+	name:  "goroutineleak",
+	count: runtime_goroutineleakcount,
+	write: writeGoroutineLeak,
+}
+
+// goroutineLeakProfileLock serializes writes of the goroutine leak
+// profile. Each leak detection cycle starts by clearing the leaked
+// state of the goroutines found by the previous one, so a concurrent
+// write could otherwise record an incomplete profile.
+var goroutineLeakProfileLock sync.Mutex
+
 func lockProfiles() {
 	profiles.mu.Lock()
 	if profiles.m == nil {
@@ -237,6 +264,9 @@ func lockProfiles() {
 			"block":        blockProfile,
 			"mutex":        mutexProfile,
 		}
+		if goexperiment.GoroutineLeakProfile {
+			profiles.m["goroutineleak"] = goroutineLeakProfile
+		}
 	}
 }
 
@@ -755,6 +785,22 @@ func writeGoroutine(w io.Writer, debug int) error {
 	return writeRuntimeProfile(w, debug, "goroutine", pprof_goroutineProfileWithLabels)
 }
 
+// writeGoroutineLeak runs a goroutine leak detection cycle,
+// then writes the stacks of the leaked goroutines to w.
+func writeGoroutineLeak(w io.Writer, debug int) error {
+	goroutineLeakProfileLock.Lock()
+	defer goroutineLeakProfileLock.Unlock()
+
+	runtime_goroutineLeakGC()
+
+	if debug >= 2 {
+		// Leaked goroutines are marked as such in the
+		// stacks of all goroutines.
+		return writeGoroutineStacks(w)
+	}
+	return writeRuntimeProfile(w, debug, "goroutineleak", pprof_goroutineLeakProfileWithLabels)
+}
+
 func writeGoroutineStacks(w io.Writer) error {
 	// We don't know how big the buffer needs to be to collect
 	// all the goroutines. Start with 1 MB and try a few times, doubling each time.
@@ -977,6 +1023,9 @@ func writeProfileInternal(w io.Writer, debug int, name string, runtimeProfile fu
 //go:linkname pprof_goroutineProfileWithLabels runtime.pprof_goroutineProfileWithLabels
 func pprof_goroutineProfileWithLabels(p []profilerecord.StackRecord, labels []unsafe.Pointer) (n int, ok bool)
 
+//go:linkname pprof_goroutineLeakProfileWithLabels runtime.pprof_goroutineLeakProfileWithLabels
+func pprof_goroutineLeakProfileWithLabels(p []profilerecord.StackRecord, labels []unsafe.Pointer) (n int, ok bool)
+
 //go:linkname pprof_cyclesPerSecond runtime/pprof.runtime_cyclesPerSecond
 func pprof_cyclesPerSecond() int64
 
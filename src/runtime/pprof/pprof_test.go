@@ -11,6 +11,7 @@ import (
 	"context"
 	"fmt"
 	"internal/abi"
+	"internal/goexperiment"
 	"internal/profile"
 	"internal/syscall/unix"
 	"internal/testenv"
@@ -1589,6 +1590,98 @@ func containsCountsLabels(prof *profile.Profile, countLabels map[int64]map[strin
 	return true
 }
 
+func leakedChanReceive(c chan int) { <-c }
+func leakedChanSend(c chan int)    { c <- 1 }
+func liveChanReceive(c chan int)   { <-c }
+
+func leakedSelect(c, d chan int) {
+	select {
+	case <-c:
+	case d <- 1:
+	}
+}
+
+func leakedMutex(mu *sync.Mutex) {
+	mu.Lock()
+	mu.Lock()
+}
+
+func leakedWaitGroup(wg *sync.WaitGroup) {
+	wg.Add(1)
+	wg.Wait()
+}
+
+func TestGoroutineLeakProfile(t *testing.T) {
+	if !goexperiment.GoroutineLeakProfile {
+		if p := Lookup("goroutineleak"); p != nil {
+			t.Errorf("Lookup(%q) = %v, want nil without GOEXPERIMENT=goroutineleakprofile", "goroutineleak", p)
+		}
+		t.Skip("requires GOEXPERIMENT=goroutineleakprofile")
+	}
+
+	// The channels and locks are only reachable from
+	// the goroutines blocked on them.
+	go leakedChanReceive(make(chan int))
+	go leakedChanSend(make(chan int))
+	go leakedSelect(make(chan int), make(chan int))
+	go leakedMutex(new(sync.Mutex))
+	go leakedWaitGroup(new(sync.WaitGroup))
+
+	// This goroutine is blocked, but this test
+	// can still wake it up.
+	live := make(chan int)
+	go liveChanReceive(live)
+	defer close(live)
+
+	leaked := []string{
+		"runtime/pprof.leakedChanReceive",
+		"runtime/pprof.leakedChanSend",
+		"runtime/pprof.leakedSelect",
+		"runtime/pprof.leakedMutex",
+		"runtime/pprof.leakedWaitGroup",
+	}
+	var prof string
+	for i := 0; ; i++ {
+		var w strings.Builder
+		if err := Lookup("goroutineleak").WriteTo(&w, 1); err != nil {
+			t.Fatal(err)
+		}
+		prof = w.String()
+		found := true
+		for _, fn := range leaked {
+			if !strings.Contains(prof, fn) {
+				found = false
+			}
+		}
+		if found {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("goroutineleak profile does not contain all of %v:\n%s", leaked, prof)
+		}
+		// Let the goroutines block.
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !strings.HasPrefix(prof, "goroutineleak profile: total ") {
+		t.Errorf("unexpected goroutineleak profile header:\n%s", prof)
+	}
+	if strings.Contains(prof, "runtime/pprof.liveChanReceive") {
+		t.Errorf("goroutineleak profile contains a goroutine that is not leaked:\n%s", prof)
+	}
+	if n := Lookup("goroutineleak").Count(); n < len(leaked) {
+		t.Errorf("goroutineleak profile count = %d, want at least %d", n, len(leaked))
+	}
+
+	// Leaked goroutines are marked in full goroutine dumps.
+	var w strings.Builder
+	if err := Lookup("goroutineleak").WriteTo(&w, 2); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(w.String(), "[sync.Mutex.Lock (leaked)]:") {
+		t.Errorf("goroutine dump does not mark leaked goroutines:\n%s", w.String())
+	}
+}
+
 func TestGoroutineProfileConcurrency(t *testing.T) {
 	testenv.MustHaveParallelism(t)
 
@@ -10,7 +10,11 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	// Save flags before clobbering them
 	PUSHFQ
 	// obj doesn't understand ADD/SUB on SP, but does understand ADJSP
+	#ifdef GOEXPERIMENT_simd
+	ADJSP $624
+	#else
 	ADJSP $368
+	#endif
 	// But vet doesn't know ADJSP, so suppress vet stack checking
 	NOP SP
 	MOVQ AX, 0(SP)
@@ -27,6 +31,28 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOVQ R13, 88(SP)
 	MOVQ R14, 96(SP)
 	MOVQ R15, 104(SP)
+	#ifdef GOEXPERIMENT_simd
+	CMPB internal∕cpu·X86+const_offsetX86HasAVX(SB), $1
+	JNE saveSSE
+	VMOVDQU Y0, 112(SP)
+	VMOVDQU Y1, 144(SP)
+	VMOVDQU Y2, 176(SP)
+	VMOVDQU Y3, 208(SP)
+	VMOVDQU Y4, 240(SP)
+	VMOVDQU Y5, 272(SP)
+	VMOVDQU Y6, 304(SP)
+	VMOVDQU Y7, 336(SP)
+	VMOVDQU Y8, 368(SP)
+	VMOVDQU Y9, 400(SP)
+	VMOVDQU Y10, 432(SP)
+	VMOVDQU Y11, 464(SP)
+	VMOVDQU Y12, 496(SP)
+	VMOVDQU Y13, 528(SP)
+	VMOVDQU Y14, 560(SP)
+	VMOVDQU Y15, 592(SP)
+	JMP preempt
+	#endif
+saveSSE:
 	MOVUPS X0, 112(SP)
 	MOVUPS X1, 128(SP)
 	MOVUPS X2, 144(SP)
@@ -43,7 +69,30 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOVUPS X13, 320(SP)
 	MOVUPS X14, 336(SP)
 	MOVUPS X15, 352(SP)
+preempt:
 	CALL ·asyncPreempt2(SB)
+	#ifdef GOEXPERIMENT_simd
+	CMPB internal∕cpu·X86+const_offsetX86HasAVX(SB), $1
+	JNE restoreSSE
+	VMOVDQU 592(SP), Y15
+	VMOVDQU 560(SP), Y14
+	VMOVDQU 528(SP), Y13
+	VMOVDQU 496(SP), Y12
+	VMOVDQU 464(SP), Y11
+	VMOVDQU 432(SP), Y10
+	VMOVDQU 400(SP), Y9
+	VMOVDQU 368(SP), Y8
+	VMOVDQU 336(SP), Y7
+	VMOVDQU 304(SP), Y6
+	VMOVDQU 272(SP), Y5
+	VMOVDQU 240(SP), Y4
+	VMOVDQU 208(SP), Y3
+	VMOVDQU 176(SP), Y2
+	VMOVDQU 144(SP), Y1
+	VMOVDQU 112(SP), Y0
+	JMP restoreGPs
+	#endif
+restoreSSE:
 	MOVUPS 352(SP), X15
 	MOVUPS 336(SP), X14
 	MOVUPS 320(SP), X13
@@ -60,6 +109,7 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOVUPS 144(SP), X2
 	MOVUPS 128(SP), X1
 	MOVUPS 112(SP), X0
+restoreGPs:
 	MOVQ 104(SP), R15
 	MOVQ 96(SP), R14
 	MOVQ 88(SP), R13
@@ -74,7 +124,11 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOVQ 16(SP), DX
 	MOVQ 8(SP), CX
 	MOVQ 0(SP), AX
+	#ifdef GOEXPERIMENT_simd
+	ADJSP $-624
+	#else
 	ADJSP $-368
+	#endif
 	POPFQ
 	POPQ BP
 	RET
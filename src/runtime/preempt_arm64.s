@@ -4,6 +4,87 @@
 #include "textflag.h"
 
 TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
+	#ifdef GOEXPERIMENT_simd
+	MOVD R30, -624(RSP)
+	SUB $624, RSP
+	MOVD R29, -8(RSP)
+	SUB $8, RSP, R29
+	#ifdef GOOS_ios
+	MOVD R30, (RSP)
+	#endif
+	STP (R0, R1), 8(RSP)
+	STP (R2, R3), 24(RSP)
+	STP (R4, R5), 40(RSP)
+	STP (R6, R7), 56(RSP)
+	STP (R8, R9), 72(RSP)
+	STP (R10, R11), 88(RSP)
+	STP (R12, R13), 104(RSP)
+	STP (R14, R15), 120(RSP)
+	STP (R16, R17), 136(RSP)
+	STP (R19, R20), 152(RSP)
+	STP (R21, R22), 168(RSP)
+	STP (R23, R24), 184(RSP)
+	STP (R25, R26), 200(RSP)
+	MOVD NZCV, R0
+	MOVD R0, 216(RSP)
+	MOVD FPSR, R0
+	MOVD R0, 224(RSP)
+	FSTPQ (F0, F1), 240(RSP)
+	FSTPQ (F2, F3), 272(RSP)
+	FSTPQ (F4, F5), 304(RSP)
+	FSTPQ (F6, F7), 336(RSP)
+	FSTPQ (F8, F9), 368(RSP)
+	FSTPQ (F10, F11), 400(RSP)
+	FSTPQ (F12, F13), 432(RSP)
+	FSTPQ (F14, F15), 464(RSP)
+	FSTPD (F16, F17), 496(RSP)
+	FSTPD (F18, F19), 512(RSP)
+	FSTPD (F20, F21), 528(RSP)
+	FSTPD (F22, F23), 544(RSP)
+	FSTPD (F24, F25), 560(RSP)
+	FSTPD (F26, F27), 576(RSP)
+	FSTPD (F28, F29), 592(RSP)
+	FSTPD (F30, F31), 608(RSP)
+	CALL ·asyncPreempt2(SB)
+	FLDPD 608(RSP), (F30, F31)
+	FLDPD 592(RSP), (F28, F29)
+	FLDPD 576(RSP), (F26, F27)
+	FLDPD 560(RSP), (F24, F25)
+	FLDPD 544(RSP), (F22, F23)
+	FLDPD 528(RSP), (F20, F21)
+	FLDPD 512(RSP), (F18, F19)
+	FLDPD 496(RSP), (F16, F17)
+	FLDPQ 464(RSP), (F14, F15)
+	FLDPQ 432(RSP), (F12, F13)
+	FLDPQ 400(RSP), (F10, F11)
+	FLDPQ 368(RSP), (F8, F9)
+	FLDPQ 336(RSP), (F6, F7)
+	FLDPQ 304(RSP), (F4, F5)
+	FLDPQ 272(RSP), (F2, F3)
+	FLDPQ 240(RSP), (F0, F1)
+	MOVD 224(RSP), R0
+	MOVD R0, FPSR
+	MOVD 216(RSP), R0
+	MOVD R0, NZCV
+	LDP 200(RSP), (R25, R26)
+	LDP 184(RSP), (R23, R24)
+	LDP 168(RSP), (R21, R22)
+	LDP 152(RSP), (R19, R20)
+	LDP 136(RSP), (R16, R17)
+	LDP 120(RSP), (R14, R15)
+	LDP 104(RSP), (R12, R13)
+	LDP 88(RSP), (R10, R11)
+	LDP 72(RSP), (R8, R9)
+	LDP 56(RSP), (R6, R7)
+	LDP 40(RSP), (R4, R5)
+	LDP 24(RSP), (R2, R3)
+	LDP 8(RSP), (R0, R1)
+	MOVD 624(RSP), R30
+	MOVD -8(RSP), R29
+	MOVD (RSP), R27
+	ADD $640, RSP
+	JMP (R27)
+	#else
 	MOVD R30, -496(RSP)
 	SUB $496, RSP
 	MOVD R29, -8(RSP)
@@ -83,3 +164,4 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOVD (RSP), R27
 	ADD $512, RSP
 	JMP (R27)
+	#endif
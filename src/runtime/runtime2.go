@@ -88,6 +88,16 @@ const (
 	// ready()ing this G.
 	_Gpreempted // 9
 
+	// _Gleaked means this goroutine is blocked on a channel or
+	// synchronization object that the garbage collector found to
+	// be unreachable, so it can never be ready()d. It is otherwise
+	// like _Gwaiting. Goroutines are only moved to _Gleaked by a
+	// goroutine leak detection GC cycle, and move back to
+	// _Gwaiting at the start of the next one. Goroutine leak
+	// detection requires GOEXPERIMENT=goroutineleakprofile, so
+	// goroutines never enter this state without it.
+	_Gleaked // 10
+
 	// _Gscan combined with one of the above states other than
 	// _Grunning indicates that GC is scanning the stack. The
 	// goroutine is not executing user code and the stack is owned
@@ -105,6 +115,7 @@ const (
 	_Gscansyscall   = _Gscan + _Gsyscall   // 0x1003
 	_Gscanwaiting   = _Gscan + _Gwaiting   // 0x1004
 	_Gscanpreempted = _Gscan + _Gpreempted // 0x1009
+	_Gscanleaked    = _Gscan + _Gleaked    // 0x100a
 )
 
 const (
@@ -317,6 +328,21 @@ type gobuf struct {
 	bp   uintptr // for framepointer-enabled architectures
 }
 
+// maybeTraceableChan is a maybeTraceablePtr to an hchan.
+type maybeTraceableChan struct {
+	maybeTraceablePtr
+}
+
+//go:nosplit
+func (p *maybeTraceableChan) set(c *hchan) {
+	p.maybeTraceablePtr.set(unsafe.Pointer(c))
+}
+
+//go:nosplit
+func (p *maybeTraceableChan) get() *hchan {
+	return (*hchan)(p.maybeTraceablePtr.get())
+}
+
 // sudog (pseudo-g) represents a g in a wait list, such as for sending/receiving
 // on a channel.
 //
@@ -336,7 +362,7 @@ type sudog struct {
 
 	next *sudog
 	prev *sudog
-	elem unsafe.Pointer // data element (may point to stack)
+	elem maybeTraceablePtr // data element (may point to stack)
 
 	// The following fields are never accessed concurrently.
 	// For channels, waitlink is only accessed by g.
@@ -364,10 +390,10 @@ type sudog struct {
 	// in the second entry in the list.)
 	waiters uint16
 
-	parent   *sudog // semaRoot binary tree
-	waitlink *sudog // g.waiting list or semaRoot
-	waittail *sudog // semaRoot
-	c        *hchan // channel
+	parent   *sudog             // semaRoot binary tree
+	waitlink *sudog             // g.waiting list or semaRoot
+	waittail *sudog             // semaRoot
+	c        maybeTraceableChan // channel
 }
 
 type libcall struct {
@@ -833,6 +859,8 @@ type schedt struct {
 	procresizetime int64 // nanotime() of last change to gomaxprocs
 	totaltime      int64 // ∫gomaxprocs dt up to procresizetime
 
+	customGOMAXPROCS bool // GOMAXPROCS was manually set from the environment or runtime.GOMAXPROCS
+
 	// sysmonlock protects sysmon's actions on the runtime.
 	//
 	// Acquire and hold this mutex to block sysmon from interacting
@@ -1085,6 +1113,7 @@ const (
 	waitReasonSynctestChanReceive                     // "chan receive (synctest)"
 	waitReasonSynctestChanSend                        // "chan send (synctest)"
 	waitReasonSynctestSelect                          // "select (synctest)"
+	waitReasonUpdateGOMAXPROCSIdle                    // "GOMAXPROCS updater (idle)"
 )
 
 var waitReasonStrings = [...]string{
@@ -1132,6 +1161,7 @@ var waitReasonStrings = [...]string{
 	waitReasonSynctestChanReceive:   "chan receive (synctest)",
 	waitReasonSynctestChanSend:      "chan send (synctest)",
 	waitReasonSynctestSelect:        "select (synctest)",
+	waitReasonUpdateGOMAXPROCSIdle:  "GOMAXPROCS updater (idle)",
 }
 
 func (w waitReason) String() string {
@@ -1147,6 +1177,42 @@ func (w waitReason) isMutexWait() bool {
 		w == waitReasonSyncRWMutexLock
 }
 
+// isSyncWait reports whether a goroutine is blocked on a
+// sync package primitive, whose address is in its sudog's elem.
+func (w waitReason) isSyncWait() bool {
+	switch w {
+	case waitReasonSyncCondWait,
+		waitReasonSyncMutexLock,
+		waitReasonSyncRWMutexRLock,
+		waitReasonSyncRWMutexLock,
+		waitReasonSyncWaitGroupWait:
+		return true
+	}
+	return false
+}
+
+// isChanWait reports whether a goroutine is blocked on non-nil
+// channels, which are in its sudogs' c.
+func (w waitReason) isChanWait() bool {
+	return w == waitReasonChanReceive ||
+		w == waitReasonChanSend ||
+		w == waitReasonSelect
+}
+
+// canLeak reports whether a goroutine blocked for this reason
+// can only be woken by another goroutine that can reach the
+// object it is blocked on, so that it is a candidate for
+// goroutine leak detection.
+func (w waitReason) canLeak() bool {
+	switch w {
+	case waitReasonChanReceiveNilChan,
+		waitReasonChanSendNilChan,
+		waitReasonSelectNoCases:
+		return true
+	}
+	return w.isChanWait() || w.isSyncWait()
+}
+
 func (w waitReason) isWaitingForGC() bool {
 	return isWaitingForGC[w]
 }
@@ -294,7 +294,10 @@ func cansemacquire(addr *uint32) bool {
 // queue adds s to the blocked goroutines in semaRoot.
 func (root *semaRoot) queue(addr *uint32, s *sudog, lifo bool) {
 	s.g = getg()
-	s.elem = unsafe.Pointer(addr)
+	s.elem.set(unsafe.Pointer(addr))
+	// Record the sudog so that goroutine leak detection
+	// can find the semaphore the goroutine is blocked on.
+	s.g.waiting = s
 	s.next = nil
 	s.prev = nil
 	s.waiters = 0
@@ -302,7 +305,7 @@ func (root *semaRoot) queue(addr *uint32, s *sudog, lifo bool) {
 	var last *sudog
 	pt := &root.treap
 	for t := *pt; t != nil; t = *pt {
-		if t.elem == unsafe.Pointer(addr) {
+		if uintptr(unsafe.Pointer(addr)) == t.elem.uintptr() {
 			// Already have addr in list.
 			if lifo {
 				// Substitute s in t's place in treap.
@@ -348,7 +351,7 @@ func (root *semaRoot) queue(addr *uint32, s *sudog, lifo bool) {
 			return
 		}
 		last = t
-		if uintptr(unsafe.Pointer(addr)) < uintptr(t.elem) {
+		if uintptr(unsafe.Pointer(addr)) < t.elem.uintptr() {
 			pt = &t.prev
 		} else {
 			pt = &t.next
@@ -394,10 +397,10 @@ func (root *semaRoot) dequeue(addr *uint32) (found *sudog, now, tailtime int64)
 	ps := &root.treap
 	s := *ps
 	for ; s != nil; s = *ps {
-		if s.elem == unsafe.Pointer(addr) {
+		if uintptr(unsafe.Pointer(addr)) == s.elem.uintptr() {
 			goto Found
 		}
-		if uintptr(unsafe.Pointer(addr)) < uintptr(s.elem) {
+		if uintptr(unsafe.Pointer(addr)) < s.elem.uintptr() {
 			ps = &s.prev
 		} else {
 			ps = &s.next
@@ -462,7 +465,9 @@ Found:
 		tailtime = s.acquiretime
 	}
 	s.parent = nil
-	s.elem = nil
+	// The goroutine is no longer blocked.
+	s.g.waiting = nil
+	s.elem.set(nil)
 	s.next = nil
 	s.prev = nil
 	s.ticket = 0
@@ -581,6 +586,10 @@ func notifyListWait(l *notifyList, t uint32) {
 	// Enqueue itself.
 	s := acquireSudog()
 	s.g = getg()
+	// Record the notify list so that goroutine leak detection
+	// can find the sync.Cond the goroutine is blocked on.
+	s.elem.set(unsafe.Pointer(l))
+	s.g.waiting = s
 	s.ticket = t
 	s.releasetime = 0
 	t0 := int64(0)
@@ -598,6 +607,8 @@ func notifyListWait(l *notifyList, t uint32) {
 	if t0 != 0 {
 		blockevent(s.releasetime-t0, 2)
 	}
+	s.g.waiting = nil
+	s.elem.set(nil)
 	releaseSudog(s)
 }
 
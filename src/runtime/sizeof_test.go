@@ -5,6 +5,7 @@
 package runtime_test
 
 import (
+	"internal/goexperiment"
 	"reflect"
 	"runtime"
 	"testing"
@@ -15,13 +16,19 @@ import (
 
 func TestSizeof(t *testing.T) {
 	const _64bit = unsafe.Sizeof(uintptr(0)) == 8
+	sudog32, sudog64 := uintptr(56), uintptr(88)
+	if goexperiment.GoroutineLeakProfile {
+		// Goroutine leak detection adds a uintptr to each of
+		// the elem and c fields.
+		sudog32, sudog64 = 64, 104
+	}
 	var tests = []struct {
 		val    any     // type as a value
 		_32bit uintptr // size on 32bit platforms
 		_64bit uintptr // size on 64bit platforms
 	}{
-		{runtime.G{}, 280, 440},   // g, but exported for testing
-		{runtime.Sudog{}, 56, 88}, // sudog, but exported for testing
+		{runtime.G{}, 280, 440},             // g, but exported for testing
+		{runtime.Sudog{}, sudog32, sudog64}, // sudog, but exported for testing
 	}
 
 	for _, tt := range tests {
@@ -205,8 +205,13 @@ func synctestRun(f func()) {
 
 	total := sg.total
 	unlock(&sg.mu)
+	if raceenabled {
+		// Establish a happens-before relationship between the exit
+		// of the goroutines in the group and Run returning.
+		raceacquireg(gp, sg.raceaddr())
+	}
 	if total != 1 {
-		panic("deadlock: all goroutines in bubble are blocked")
+		panic(synctestDeadlockError{sg})
 	}
 	if gp.timer != nil && gp.timer.isFake {
 		// Verify that we haven't marked this goroutine's sleep timer as fake.
@@ -215,6 +220,31 @@ func synctestRun(f func()) {
 	}
 }
 
+// A synctestDeadlockError is the panic value used by synctest.Run
+// when every goroutine in the bubble is durably blocked.
+//
+// If the panic is not recovered, the stacks of the blocked goroutines
+// are printed along with the panicking goroutine's stack.
+type synctestDeadlockError struct {
+	sg *synctestGroup
+}
+
+func (synctestDeadlockError) Error() string {
+	return "deadlock: all goroutines in bubble are blocked"
+}
+
+// synctestDeadlockGroup returns the deadlocked synctest group
+// associated with any of the active panics, or nil if there is none.
+// It must be called before preprintpanics replaces the panic values.
+func synctestDeadlockGroup(p *_panic) *synctestGroup {
+	for ; p != nil; p = p.link {
+		if err, ok := p.arg.(synctestDeadlockError); ok {
+			return err.sg
+		}
+	}
+	return nil
+}
+
 func synctestidle_c(gp *g, _ unsafe.Pointer) bool {
 	lock(&gp.syncGroup.mu)
 	defer unlock(&gp.syncGroup.mu)
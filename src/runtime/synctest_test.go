@@ -5,6 +5,7 @@
 package runtime_test
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -15,3 +16,17 @@ func TestSynctest(t *testing.T) {
 		t.Fatalf("output:\n%s\n\nwanted:\n%s", output, want)
 	}
 }
+
+func TestSynctestDeadlockTraceback(t *testing.T) {
+	output := runTestProg(t, "testprog", "SynctestDeadlock")
+	for _, want := range []string{
+		"panic: deadlock: all goroutines in bubble are blocked",
+		// Stacks of the blocked goroutines in the bubble.
+		"main.SynctestDeadlock.func1()",
+		"main.synctestDeadlockBlocked(",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+func init() {
+	register("UpdateGOMAXPROCSSchedAffinity", UpdateGOMAXPROCSSchedAffinity)
+}
+
+const maxCPUs = 64 * 1024
+
+type cpuMask [maxCPUs / 8]byte
+
+func (m *cpuMask) count() int {
+	n := 0
+	for _, b := range m {
+		for ; b != 0; b >>= 1 {
+			n += int(b & 1)
+		}
+	}
+	return n
+}
+
+func mustGetAffinity() *cpuMask {
+	var m cpuMask
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_GETAFFINITY, 0, unsafe.Sizeof(m), uintptr(unsafe.Pointer(&m[0])))
+	if errno != 0 {
+		panic(fmt.Sprintf("error getting CPU affinity: %v", errno))
+	}
+	return &m
+}
+
+// mustSetProcessAffinity sets the CPU affinity mask of every thread of
+// the process to m. Affinity is a per-thread attribute, and the runtime
+// reads it from whichever thread computes the default GOMAXPROCS.
+func mustSetProcessAffinity(m *cpuMask) {
+	tasks, err := os.ReadDir("/proc/self/task")
+	if err != nil {
+		panic(err)
+	}
+	for _, task := range tasks {
+		tid, err := strconv.Atoi(task.Name())
+		if err != nil {
+			panic(err)
+		}
+		_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, uintptr(tid), unsafe.Sizeof(*m), uintptr(unsafe.Pointer(&m[0])))
+		if errno != 0 && errno != syscall.ESRCH {
+			panic(fmt.Sprintf("error setting CPU affinity of thread %d: %v", tid, errno))
+		}
+	}
+}
+
+// UpdateGOMAXPROCSSchedAffinity checks that GOMAXPROCS follows changes
+// to the CPU affinity mask of the process.
+func UpdateGOMAXPROCSSchedAffinity() {
+	orig := mustGetAffinity()
+	procs := runtime.GOMAXPROCS(0)
+	println("GOMAXPROCS:", procs)
+	if procs < 2 {
+		panic(fmt.Sprintf("GOMAXPROCS got %d want at least 2", procs))
+	}
+
+	// Restrict the process to the first CPU it may run on.
+	var one cpuMask
+	for i, b := range orig {
+		if b != 0 {
+			one[i] = b & -b
+			break
+		}
+	}
+	mustSetProcessAffinity(&one)
+	waitForMaxProcsChange(procs, 1)
+
+	// SetDefaultGOMAXPROCS reads the mask too.
+	runtime.GOMAXPROCS(procs)
+	runtime.SetDefaultGOMAXPROCS()
+	if got := runtime.GOMAXPROCS(0); got != 1 {
+		panic(fmt.Sprintf("GOMAXPROCS after SetDefaultGOMAXPROCS got %d want 1", got))
+	}
+
+	// Undo the restriction.
+	mustSetProcessAffinity(orig)
+	waitForMaxProcsChange(1, procs)
+
+	// NumCPU still reports the CPUs available at startup.
+	if got, want := runtime.NumCPU(), orig.count(); got != want {
+		panic(fmt.Sprintf("NumCPU got %d want %d", got, want))
+	}
+
+	println("OK")
+}
@@ -0,0 +1,26 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"internal/synctest"
+)
+
+func init() {
+	register("SynctestDeadlock", SynctestDeadlock)
+}
+
+func SynctestDeadlock() {
+	synctest.Run(func() {
+		ch := make(chan int)
+		go synctestDeadlockBlocked(ch)
+		<-ch
+	})
+}
+
+//go:noinline
+func synctestDeadlockBlocked(ch chan int) {
+	ch <- <-ch
+}
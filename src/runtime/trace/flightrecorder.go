@@ -0,0 +1,352 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FlightRecorder represents a single consumer of a Go execution trace.
+// It tracks a moving window over the execution trace produced by the
+// runtime, always containing the most recent trace data.
+//
+// The window is kept in memory and may be written out with
+// [FlightRecorder.WriteTo] at any time, for example when a request
+// exceeds its latency objective. The result is a complete trace in the
+// same format as the one written by [Start], which may be analyzed with
+// "go tool trace".
+//
+// At most one flight recorder may be active at any given time,
+// though flight recording is allowed to be concurrently active
+// with a trace consumer using [Start].
+type FlightRecorder struct {
+	minAge   time.Duration
+	maxBytes uint64
+
+	mu      sync.Mutex // gate mutators (Start, Stop)
+	enabled atomic.Bool
+	writing sync.Mutex // serializes WriteTo calls
+	rec     *recorder
+}
+
+// FlightRecorderConfig configures a [FlightRecorder].
+type FlightRecorderConfig struct {
+	// MinAge is a lower bound on the age of an event in the flight
+	// recorder's window.
+	//
+	// The flight recorder will strive to promptly discard events older
+	// than the minimum age, but older events may appear in the window
+	// snapshot. The age setting will always be overridden by MaxBytes.
+	//
+	// If this is 0, the minimum age is implementation defined, but can
+	// be assumed to be on the order of seconds.
+	MinAge time.Duration
+
+	// MaxBytes is an upper bound on the size of the window in bytes.
+	//
+	// This setting takes precedence over MinAge. However, it does not
+	// make any guarantees on the size of the data WriteTo will write,
+	// nor does it guarantee memory overheads will always stay below
+	// MaxBytes. Treat it as a hint.
+	//
+	// If this is 0, the maximum size is implementation defined.
+	MaxBytes uint64
+}
+
+// NewFlightRecorder creates a new flight recorder from the provided configuration.
+func NewFlightRecorder(cfg FlightRecorderConfig) *FlightRecorder {
+	fr := &FlightRecorder{
+		minAge:   cfg.MinAge,
+		maxBytes: cfg.MaxBytes,
+	}
+	if fr.minAge == 0 {
+		fr.minAge = 10 * time.Second
+	}
+	if fr.maxBytes == 0 {
+		fr.maxBytes = 10 << 20 // 10 MiB
+	}
+	return fr
+}
+
+// Start activates the flight recorder and begins recording trace data.
+// It returns an error if the flight recorder is already active or if
+// another flight recorder is active in the program.
+func (fr *FlightRecorder) Start() error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	if fr.enabled.Load() {
+		return errors.New("trace: flight recorder already enabled")
+	}
+	rec := &recorder{
+		minAge:   fr.minAge,
+		maxBytes: fr.maxBytes,
+		freq:     1e9 / float64(clockUnitsPerSecond()),
+	}
+	if err := tracing.subscribeFlightRecorder(rec); err != nil {
+		return err
+	}
+	fr.rec = rec
+	fr.enabled.Store(true)
+	return nil
+}
+
+// Stop ends recording of trace data and discards the window.
+// It blocks until any concurrent WriteTo calls complete.
+func (fr *FlightRecorder) Stop() {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	if !fr.enabled.Load() {
+		return
+	}
+	fr.writing.Lock()
+	fr.enabled.Store(false)
+	fr.writing.Unlock()
+
+	tracing.unsubscribeFlightRecorder()
+	fr.rec = nil
+}
+
+// Enabled reports whether the flight recorder is active, that is,
+// whether Start returned without error and Stop has not been called since.
+// It is safe to call from multiple goroutines simultaneously.
+func (fr *FlightRecorder) Enabled() bool {
+	return fr.enabled.Load()
+}
+
+// WriteTo writes a snapshot of the moving window tracked by the flight
+// recorder to w. The snapshot contains the trace data produced up to
+// approximately the time of the call.
+//
+// Only one goroutine may execute WriteTo at a time. WriteTo returns an
+// error if the flight recorder is inactive, if another WriteTo call is
+// already in progress, or if writing to w fails.
+func (fr *FlightRecorder) WriteTo(w io.Writer) (n int64, err error) {
+	if !fr.writing.TryLock() {
+		// The caller should use the result of the other call instead.
+		return 0, errors.New("trace: call to FlightRecorder.WriteTo already in progress")
+	}
+	defer fr.writing.Unlock()
+	if !fr.enabled.Load() {
+		return 0, errors.New("trace: cannot snapshot a disabled flight recorder")
+	}
+
+	// Flush the generation in progress into the window. Once advance
+	// returns, all the data of the generation it ended has been handed to
+	// the recorder, but that generation only moves into the window when the
+	// recorder sees the start of the following one. Every generation has at
+	// least one batch, so ending a second generation guarantees it.
+	advance()
+	advance()
+
+	header, gens, err := fr.rec.snapshot()
+	if err != nil {
+		return 0, err
+	}
+	nw, err := w.Write(header[:])
+	n += int64(nw)
+	if err != nil {
+		return n, err
+	}
+	for _, gen := range gens {
+		for _, data := range gen.batches {
+			nw, err = w.Write(data)
+			n += int64(nw)
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// A recorder receives the trace data from the trace reader goroutine
+// and maintains the window of a [FlightRecorder].
+type recorder struct {
+	minAge   time.Duration
+	maxBytes uint64
+	freq     float64 // nanoseconds per trace clock unit
+
+	// Only accessed by the trace reader goroutine.
+	headerReceived bool
+	active         rawGeneration
+
+	mu     sync.Mutex // protects the fields below
+	header [16]byte
+	ring   []rawGeneration
+	err    error
+}
+
+// A rawGeneration holds the unparsed batches of a single trace generation.
+type rawGeneration struct {
+	gen     uint64
+	size    int
+	minTime int64 // earliest batch timestamp, in nanoseconds
+	batches [][]byte
+}
+
+// Write receives a chunk of trace data from the runtime. The first chunk
+// is the trace header; every subsequent chunk is exactly one batch.
+func (r *recorder) Write(b []byte) (int, error) {
+	if !r.headerReceived {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if len(b) != len(r.header) {
+			r.err = fmt.Errorf("trace: expected %d byte header, got %d bytes", len(r.header), len(b))
+			return 0, r.err
+		}
+		copy(r.header[:], b)
+		r.headerReceived = true
+		return len(b), nil
+	}
+	h, err := readBatchHeader(b)
+	if err != nil {
+		r.mu.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		r.mu.Unlock()
+		return 0, err
+	}
+	if len(r.active.batches) == 0 {
+		r.active.gen = h.gen
+	}
+	if t := r.nanotime(h.time); r.active.minTime == 0 || t < r.active.minTime {
+		r.active.minTime = t
+	}
+	r.active.size += len(b)
+	r.active.batches = append(r.active.batches, slices.Clone(b))
+	return len(b), nil
+}
+
+// endGeneration moves the active generation into the window,
+// dropping old generations that fall outside of it.
+func (r *recorder) endGeneration() {
+	now := r.nanotime(clockNow())
+
+	// Always keep the most recent complete generation, then add older
+	// generations until one of them crosses either threshold. This keeps
+	// the generation that crosses a threshold, but none that lie entirely
+	// outside of it.
+	//
+	// The ring is replaced rather than modified in place, so that a
+	// snapshot never aliases memory the reader goroutine may change.
+	r.mu.Lock()
+	ring := []rawGeneration{r.active}
+	size := r.active.size
+	for i := len(r.ring) - 1; i >= 0; i-- {
+		if uint64(size) > r.maxBytes || time.Duration(now-ring[len(ring)-1].minTime) > r.minAge {
+			break
+		}
+		size += r.ring[i].size
+		ring = append(ring, r.ring[i])
+	}
+	slices.Reverse(ring)
+	r.ring = ring
+	r.mu.Unlock()
+
+	r.active = rawGeneration{}
+}
+
+// snapshot returns the trace header and the generations in the window.
+func (r *recorder) snapshot() ([16]byte, []rawGeneration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.header, r.ring, r.err
+}
+
+// nanotime converts a trace clock timestamp to nanoseconds.
+func (r *recorder) nanotime(ts uint64) int64 {
+	return int64(float64(ts) * r.freq)
+}
+
+// Batch event types and the maximum batch size of the trace format.
+// These must match runtime/traceevent.go and internal/trace/event/go122,
+// which cannot be imported here: package testing depends on this package.
+const (
+	evEventBatch        = 1
+	evExperimentalBatch = 49
+	maxBatchSize        = 64 << 10
+)
+
+// A batchHeader is the parsed header of a trace batch.
+type batchHeader struct {
+	gen  uint64
+	time uint64
+}
+
+// readBatchHeader parses the header of the batch b, checking that b holds
+// exactly one complete batch.
+func readBatchHeader(b []byte) (batchHeader, error) {
+	if len(b) == 0 {
+		return batchHeader{}, errors.New("trace: empty batch")
+	}
+	switch typ := b[0]; typ {
+	case evEventBatch:
+		b = b[1:]
+	case evExperimentalBatch:
+		if len(b) < 2 {
+			return batchHeader{}, errors.New("trace: truncated batch header")
+		}
+		b = b[2:] // Skip the experiment ID.
+	default:
+		return batchHeader{}, fmt.Errorf("trace: expected batch event, got event %d", typ)
+	}
+
+	// Read the generation, M ID, timestamp, and batch length.
+	var fields [4]uint64
+	for i := range fields {
+		v, n := uvarint(b)
+		if n <= 0 {
+			return batchHeader{}, errors.New("trace: malformed batch header")
+		}
+		fields[i] = v
+		b = b[n:]
+	}
+	if size := fields[3]; size > maxBatchSize || size != uint64(len(b)) {
+		return batchHeader{}, fmt.Errorf("trace: invalid batch size %d", size)
+	}
+	return batchHeader{gen: fields[0], time: fields[2]}, nil
+}
+
+// uvarint decodes a uint64 from b, like [encoding/binary.Uvarint].
+func uvarint(b []byte) (uint64, int) {
+	var x uint64
+	var s uint
+	for i, c := range b {
+		if i == 10 {
+			return 0, -(i + 1) // overflow
+		}
+		if c < 0x80 {
+			if i == 9 && c > 1 {
+				return 0, -(i + 1) // overflow
+			}
+			return x | uint64(c)<<s, i + 1
+		}
+		x |= uint64(c&0x7f) << s
+		s += 7
+	}
+	return 0, 0
+}
+
+//
+// Function bodies are defined in runtime/traceruntime.go
+//
+
+// ends the current trace generation and waits for its data to be read.
+func advance()
+
+// returns the current trace clock timestamp.
+func clockNow() uint64
+
+// returns the number of trace clock units per second.
+func clockUnitsPerSecond() uint64
@@ -0,0 +1,208 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trace_test
+
+import (
+	"bytes"
+	"context"
+	inttrace "internal/trace"
+	"internal/trace/testtrace"
+	"io"
+	. "runtime/trace"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlightRecorderDoubleStart(t *testing.T) {
+	fr := NewFlightRecorder(FlightRecorderConfig{})
+	if err := fr.Start(); err != nil {
+		t.Fatalf("unexpected error on Start: %v", err)
+	}
+	if err := fr.Start(); err == nil {
+		t.Fatalf("succeeded to start flight recorder second time")
+	}
+	other := NewFlightRecorder(FlightRecorderConfig{})
+	if err := other.Start(); err == nil {
+		t.Fatalf("succeeded to start second flight recorder")
+	}
+	fr.Stop()
+	fr.Stop()
+}
+
+func TestFlightRecorderEnabled(t *testing.T) {
+	fr := NewFlightRecorder(FlightRecorderConfig{})
+	if fr.Enabled() {
+		t.Fatal("flight recorder is enabled, but never started")
+	}
+	if err := fr.Start(); err != nil {
+		t.Fatalf("unexpected error on Start: %v", err)
+	}
+	if !fr.Enabled() {
+		t.Fatal("flight recorder is not enabled, but started")
+	}
+	fr.Stop()
+	if fr.Enabled() {
+		t.Fatal("flight recorder is enabled, but stopped")
+	}
+}
+
+func TestFlightRecorderWriteToDisabled(t *testing.T) {
+	fr := NewFlightRecorder(FlightRecorderConfig{})
+	if n, err := fr.WriteTo(io.Discard); err == nil {
+		t.Fatalf("successfully wrote %d bytes from disabled flight recorder", n)
+	}
+	if err := fr.Start(); err != nil {
+		t.Fatalf("unexpected error on Start: %v", err)
+	}
+	fr.Stop()
+	if n, err := fr.WriteTo(io.Discard); err == nil {
+		t.Fatalf("successfully wrote %d bytes from disabled flight recorder", n)
+	}
+}
+
+func TestFlightRecorder(t *testing.T) {
+	if IsEnabled() {
+		t.Skip("skipping because -test.trace is set")
+	}
+	fr := NewFlightRecorder(FlightRecorderConfig{})
+	if err := fr.Start(); err != nil {
+		t.Fatalf("unexpected error on Start: %v", err)
+	}
+	defer fr.Stop()
+
+	logUntilStopped(t, fr, "flight recorder")
+}
+
+func TestFlightRecorderConcurrentWithStart(t *testing.T) {
+	if IsEnabled() {
+		t.Skip("skipping because -test.trace is set")
+	}
+	var full bytes.Buffer
+	if err := Start(&full); err != nil {
+		t.Fatalf("failed to start tracing: %v", err)
+	}
+	fr := NewFlightRecorder(FlightRecorderConfig{})
+	if err := fr.Start(); err != nil {
+		Stop()
+		t.Fatalf("unexpected error on Start: %v", err)
+	}
+	if !IsEnabled() {
+		t.Error("tracing is not enabled with both consumers active")
+	}
+	logUntilStopped(t, fr, "concurrent")
+
+	// Stopping the regular trace must leave the flight recorder working.
+	Stop()
+	size := full.Len()
+	checkTrace(t, full.Bytes(), "")
+	logUntilStopped(t, fr, "after Stop")
+	fr.Stop()
+
+	if IsEnabled() {
+		t.Error("tracing is enabled after both consumers stopped")
+	}
+	if full.Len() != size {
+		t.Fatalf("trace writes after Stop: %d -> %d", size, full.Len())
+	}
+}
+
+func TestFlightRecorderWindow(t *testing.T) {
+	if IsEnabled() {
+		t.Skip("skipping because -test.trace is set")
+	}
+	fr := NewFlightRecorder(FlightRecorderConfig{MinAge: time.Nanosecond, MaxBytes: 1})
+	if err := fr.Start(); err != nil {
+		t.Fatalf("unexpected error on Start: %v", err)
+	}
+	defer fr.Stop()
+
+	// Log a message and then let several generations pass. With such a
+	// small window, the message must have been discarded.
+	Log(context.Background(), "flight recorder", "old")
+	var buf bytes.Buffer
+	for range 3 {
+		buf.Reset()
+		if _, err := fr.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error on WriteTo: %v", err)
+		}
+	}
+	if logs := checkTrace(t, buf.Bytes(), "flight recorder"); len(logs) != 0 {
+		t.Errorf("found logs %q outside of the window", logs)
+	}
+}
+
+// logUntilStopped emits user log events under category while
+// snapshotting fr, and checks that the snapshot is a valid trace
+// containing the most recent events.
+func logUntilStopped(t *testing.T, fr *FlightRecorder, category string) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			Log(context.Background(), category, "spin")
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	Log(context.Background(), category, "marker")
+
+	var buf bytes.Buffer
+	_, err := fr.WriteTo(&buf)
+	close(done)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error on WriteTo: %v", err)
+	}
+
+	logs := checkTrace(t, buf.Bytes(), category)
+	found := false
+	for _, msg := range logs {
+		if msg == "marker" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("%s: snapshot does not contain the most recent log event", category)
+	}
+}
+
+// checkTrace parses and validates the trace in data, returning the
+// messages of all user log events with the given category.
+func checkTrace(t *testing.T, data []byte, category string) []string {
+	t.Helper()
+
+	r, err := inttrace.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error creating trace reader: %v", err)
+	}
+	v := testtrace.NewValidator()
+	var logs []string
+	for {
+		ev, err := r.ReadEvent()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error reading trace: %v", err)
+		}
+		if err := v.Event(ev); err != nil {
+			t.Fatalf("invalid trace: %v", err)
+		}
+		if ev.Kind() == inttrace.EventLog && ev.Log().Category == category {
+			logs = append(logs, ev.Log().Message)
+		}
+	}
+	return logs
+}
@@ -0,0 +1,215 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var tracing traceMultiplexer
+
+// traceMultiplexer distributes the single execution trace produced by
+// the runtime to its subscribers: the writer passed to [Start] and
+// the active [FlightRecorder], if any.
+//
+// The runtime trace stays enabled as long as there is at least one
+// subscriber. Subscribers only ever observe whole generations: changes
+// to the set of subscribers take effect at the next generation boundary
+// seen by the trace reader goroutine.
+type traceMultiplexer struct {
+	sync.Mutex  // gate mutators (subscribe, unsubscribe)
+	enabled     atomic.Bool
+	subscribers int
+
+	subscribersMu    sync.Mutex
+	traceStartWriter io.Writer
+	flightRecorder   *recorder
+}
+
+func (t *traceMultiplexer) subscribeFlightRecorder(r *recorder) error {
+	t.Lock()
+	defer t.Unlock()
+
+	t.subscribersMu.Lock()
+	if t.flightRecorder != nil {
+		t.subscribersMu.Unlock()
+		return errors.New("trace: flight recorder already enabled")
+	}
+	t.flightRecorder = r
+	t.subscribersMu.Unlock()
+
+	if err := t.addedSubscriber(); err != nil {
+		t.subscribersMu.Lock()
+		t.flightRecorder = nil
+		t.subscribersMu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (t *traceMultiplexer) unsubscribeFlightRecorder() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.subscribersMu.Lock()
+	if t.flightRecorder == nil {
+		t.subscribersMu.Unlock()
+		return
+	}
+	t.subscribersMu.Unlock()
+
+	t.removingSubscriber()
+
+	t.subscribersMu.Lock()
+	t.flightRecorder = nil
+	t.subscribersMu.Unlock()
+
+	t.removedSubscriber()
+}
+
+func (t *traceMultiplexer) subscribeTraceStartWriter(w io.Writer) error {
+	t.Lock()
+	defer t.Unlock()
+
+	t.subscribersMu.Lock()
+	if t.traceStartWriter != nil {
+		t.subscribersMu.Unlock()
+		return errors.New("tracing is already enabled")
+	}
+	t.traceStartWriter = w
+	t.subscribersMu.Unlock()
+
+	if err := t.addedSubscriber(); err != nil {
+		t.subscribersMu.Lock()
+		t.traceStartWriter = nil
+		t.subscribersMu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (t *traceMultiplexer) unsubscribeTraceStartWriter() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.subscribersMu.Lock()
+	if t.traceStartWriter == nil {
+		t.subscribersMu.Unlock()
+		return
+	}
+	t.subscribersMu.Unlock()
+
+	t.removingSubscriber()
+
+	t.subscribersMu.Lock()
+	t.traceStartWriter = nil
+	t.subscribersMu.Unlock()
+
+	t.removedSubscriber()
+}
+
+// addedSubscriber starts the runtime trace, or, if it is already running,
+// ends the current generation so the reader goroutine notices the new subscriber.
+func (t *traceMultiplexer) addedSubscriber() error {
+	if t.enabled.Load() {
+		advance()
+	} else if err := t.startLocked(); err != nil {
+		return err
+	}
+	t.subscribers++
+	return nil
+}
+
+// removingSubscriber stops the runtime trace if the subscriber being removed
+// is the last one. Otherwise it ends the current generation, so that the
+// departing subscriber receives all the data produced up to this point.
+func (t *traceMultiplexer) removingSubscriber() {
+	t.subscribers--
+	if t.subscribers == 0 {
+		runtime.StopTrace()
+		t.enabled.Store(false)
+	} else {
+		advance()
+	}
+}
+
+// removedSubscriber ends the current generation so the reader goroutine
+// notices the departed subscriber. Once it returns, the departed subscriber
+// is never written to again.
+func (t *traceMultiplexer) removedSubscriber() {
+	if t.subscribers > 0 {
+		advance()
+	}
+}
+
+func (t *traceMultiplexer) startLocked() error {
+	if err := runtime.StartTrace(); err != nil {
+		return err
+	}
+
+	// Grab the subscribers for the trace reader goroutine. Changes made
+	// after this point are only observed at a generation boundary, so
+	// subscribing or unsubscribing must end the current generation.
+	t.subscribersMu.Lock()
+	flightRecorder := t.flightRecorder
+	traceStartWriter := t.traceStartWriter
+	t.subscribersMu.Unlock()
+
+	go func() {
+		header := runtime.ReadTrace()
+		if traceStartWriter != nil {
+			traceStartWriter.Write(header)
+		}
+		if flightRecorder != nil {
+			flightRecorder.Write(header)
+		}
+
+		var gen uint64
+		for {
+			data := runtime.ReadTrace()
+			if data == nil {
+				break
+			}
+			// The runtime hands out every batch of a generation before any
+			// batch of the next one, so the first batch of a new generation
+			// marks the end of the previous one.
+			if h, err := readBatchHeader(data); err == nil && h.gen != gen {
+				if gen != 0 {
+					if flightRecorder != nil {
+						flightRecorder.endGeneration()
+					}
+
+					// Pick up any changes.
+					t.subscribersMu.Lock()
+					frIsNew := flightRecorder != t.flightRecorder && t.flightRecorder != nil
+					trIsNew := traceStartWriter != t.traceStartWriter && t.traceStartWriter != nil
+					flightRecorder = t.flightRecorder
+					traceStartWriter = t.traceStartWriter
+					t.subscribersMu.Unlock()
+
+					if trIsNew {
+						traceStartWriter.Write(header)
+					}
+					if frIsNew {
+						flightRecorder.Write(header)
+					}
+				}
+				gen = h.gen
+			}
+			if traceStartWriter != nil {
+				traceStartWriter.Write(data)
+			}
+			if flightRecorder != nil {
+				flightRecorder.Write(data)
+			}
+		}
+	}()
+	t.enabled.Store(true)
+	return nil
+}
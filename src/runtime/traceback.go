@@ -1191,6 +1191,7 @@ var gStatusStrings = [...]string{
 	_Gdead:      "dead",
 	_Gcopystack: "copystack",
 	_Gpreempted: "preempted",
+	_Gleaked:    "leaked",
 }
 
 func goroutineheader(gp *g) {
@@ -1210,13 +1211,13 @@ func goroutineheader(gp *g) {
 	}
 
 	// Override.
-	if gpstatus == _Gwaiting && gp.waitreason != waitReasonZero {
+	if (gpstatus == _Gwaiting || gpstatus == _Gleaked) && gp.waitreason != waitReasonZero {
 		status = gp.waitreason.String()
 	}
 
 	// approx time the G is blocked, in minutes
 	var waitfor int64
-	if (gpstatus == _Gwaiting || gpstatus == _Gsyscall) && gp.waitsince != 0 {
+	if (gpstatus == _Gwaiting || gpstatus == _Gleaked || gpstatus == _Gsyscall) && gp.waitsince != 0 {
 		waitfor = (nanotime() - gp.waitsince) / 60e9
 	}
 	print("goroutine ", gp.goid)
@@ -1229,6 +1230,9 @@ func goroutineheader(gp *g) {
 		}
 	}
 	print(" [", status)
+	if gpstatus == _Gleaked {
+		print(" (leaked)")
+	}
 	if isScan {
 		print(" (scan)")
 	}
@@ -1281,6 +1285,21 @@ func tracebackothers(me *g) {
 	})
 }
 
+// tracebacksynctest prints the stacks of the goroutines in the
+// synctest group sg, other than me.
+// It is used to report the goroutines involved in a bubble deadlock,
+// which are otherwise omitted unless GOTRACEBACK=all.
+func tracebacksynctest(me *g, sg *synctestGroup) {
+	forEachGRace(func(gp *g) {
+		if gp == me || gp.syncGroup != sg || readgstatus(gp) == _Gdead {
+			return
+		}
+		print("\n")
+		goroutineheader(gp)
+		traceback(^uintptr(0), ^uintptr(0), 0, gp)
+	})
+}
+
 // tracebackHexdump hexdumps part of stk around frame.sp and frame.fp
 // for debugging purposes. If the address bad is included in the
 // hexdumped range, it will mark it as well.
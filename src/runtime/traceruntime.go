@@ -672,6 +672,28 @@ func trace_userLog(id uint64, category, message string) {
 	traceRelease(tl)
 }
 
+// trace_advance ends the current trace generation and waits for its data
+// to be read. See runtime/trace/flightrecorder.go.
+//
+//go:linkname trace_advance runtime/trace.advance
+func trace_advance() {
+	traceAdvance(false)
+}
+
+// trace_clockNow returns the current trace clock timestamp.
+//
+//go:linkname trace_clockNow runtime/trace.clockNow
+func trace_clockNow() uint64 {
+	return uint64(traceClockNow())
+}
+
+// trace_clockUnitsPerSecond returns the number of trace clock units per second.
+//
+//go:linkname trace_clockUnitsPerSecond runtime/trace.clockUnitsPerSecond
+func trace_clockUnitsPerSecond() uint64 {
+	return traceClockUnitsPerSecond()
+}
+
 // traceThreadDestroy is called when a thread is removed from
 // sched.freem.
 //
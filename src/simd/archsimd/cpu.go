@@ -0,0 +1,36 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.simd
+
+package archsimd
+
+import "internal/cpu"
+
+// X86Features reports which x86 CPU features used by this package
+// are available on the current CPU.
+// On architectures other than 386 and amd64, all methods report false.
+type X86Features struct{}
+
+// X86 reports the x86 CPU features available on the current CPU.
+var X86 X86Features
+
+// AVX reports whether the CPU supports AVX, which is required
+// for operations on 128-bit vectors.
+func (X86Features) AVX() bool {
+	return cpu.X86.HasAVX
+}
+
+// AVX2 reports whether the CPU supports AVX2, which is required
+// for integer operations on 256-bit vectors.
+func (X86Features) AVX2() bool {
+	return cpu.X86.HasAVX2
+}
+
+// AVX512 reports whether the CPU supports the AVX-512 Foundation,
+// Byte and Word, and Vector Length extensions, which are required
+// for operations on 64-bit elements such as [Int64x4.Max].
+func (X86Features) AVX512() bool {
+	return cpu.X86.HasAVX512F && cpu.X86.HasAVX512BW && cpu.X86.HasAVX512VL
+}
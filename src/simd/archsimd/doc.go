@@ -0,0 +1,41 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.simd
+
+//go:generate go run mkops.go
+
+// Package archsimd provides access to architecture-specific SIMD
+// (single instruction, multiple data) vector operations.
+//
+// This package is experimental. It is only available when building with
+// GOEXPERIMENT=simd, and its API may change or be removed in future
+// releases without notice.
+//
+// The package defines fixed-size vector types such as [Int32x8] and
+// [Float64x4], named after their element type and number of elements.
+// Methods on these types are compiler intrinsics: each method compiles
+// directly to the machine instruction named in its documentation, and
+// vector values are kept in vector registers where possible.
+//
+// The set of types and operations depends on the target architecture.
+// On amd64, 128-bit and 256-bit vectors of all integer and floating-point
+// element types are supported, using AVX and AVX2 instructions, and a few
+// operations on 64-bit elements that require AVX-512.
+// On arm64, 128-bit vectors of integer element types are supported,
+// using NEON (Advanced SIMD) instructions, which are always available.
+//
+// Each method documents the CPU feature it requires. Calling a method
+// on a CPU that lacks the required feature results in an illegal
+// instruction fault, so programs should check for the feature first,
+// using [X86] on amd64. For example:
+//
+//	if archsimd.X86.AVX2() {
+//		x := archsimd.LoadInt32x8Slice(a)
+//		y := archsimd.LoadInt32x8Slice(b)
+//		x.Add(y).StoreSlice(c)
+//	} else {
+//		// fall back to scalar code
+//	}
+package archsimd
@@ -0,0 +1,474 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// mkops generates the vector types and operations of package archsimd,
+// together with the compiler support for them:
+//
+//   - types_GOARCH.go and ops_GOARCH.go in this directory,
+//   - cmd/compile/internal/ssa/_gen/simdGOARCHops.go (the SSA ops),
+//   - cmd/compile/internal/GOARCH/simdssa.go (code generation), and
+//   - cmd/compile/internal/ssagen/simdintrinsics.go (the intrinsics).
+//
+// After running mkops, run the SSA generator in
+// cmd/compile/internal/ssa/_gen to regenerate opGen.go.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// An elem is a vector element type.
+type elem struct {
+	name string // exported name prefix, e.g. "Int32"
+	typ  string // Go type, e.g. "int32"
+	bits int
+	kind byte // 'i' for signed, 'u' for unsigned, 'f' for float
+}
+
+var elems = []elem{
+	{"Int8", "int8", 8, 'i'},
+	{"Int16", "int16", 16, 'i'},
+	{"Int32", "int32", 32, 'i'},
+	{"Int64", "int64", 64, 'i'},
+	{"Uint8", "uint8", 8, 'u'},
+	{"Uint16", "uint16", 16, 'u'},
+	{"Uint32", "uint32", 32, 'u'},
+	{"Uint64", "uint64", 64, 'u'},
+	{"Float32", "float32", 32, 'f'},
+	{"Float64", "float64", 64, 'f'},
+}
+
+// A vec is a vector type.
+type vec struct {
+	elem
+	bits int // vector width
+}
+
+func (v vec) lanes() int    { return v.bits / v.elem.bits }
+func (v vec) name() string  { return fmt.Sprintf("%sx%d", v.elem.name, v.lanes()) }
+func (v vec) array() string { return fmt.Sprintf("[%d]%s", v.lanes(), v.typ) }
+
+// An op is a vector operation, implemented as a method.
+type op struct {
+	name        string
+	doc         string
+	args        int  // number of vector operands, including the receiver
+	commutative bool // whether the operands can be swapped
+	swap        bool // whether the instruction takes its operands in reverse order
+}
+
+var ops = []op{
+	{name: "Abs", doc: "computes the absolute value of each element.", args: 1},
+	{name: "Add", doc: "adds corresponding elements of two vectors.", args: 2, commutative: true},
+	{name: "And", doc: "performs a bitwise AND operation between two vectors.", args: 2, commutative: true},
+	{name: "AndNot", doc: "performs a bitwise x &^ y.", args: 2, swap: true},
+	{name: "Div", doc: "divides elements of two vectors.", args: 2},
+	{name: "Equal", doc: "compares for equality, setting all bits of each\n// element of the result if the corresponding elements are equal,\n// and clearing them otherwise.", args: 2, commutative: true},
+	{name: "Greater", doc: "compares for x > y, setting all bits of each\n// element of the result if the comparison holds, and clearing them\n// otherwise.", args: 2},
+	{name: "Max", doc: "computes the maximum of corresponding elements.", args: 2},
+	{name: "Min", doc: "computes the minimum of corresponding elements.", args: 2},
+	{name: "Mul", doc: "multiplies corresponding elements of two vectors,\n// keeping the low bits of each product.", args: 2, commutative: true},
+	{name: "Or", doc: "performs a bitwise OR operation between two vectors.", args: 2, commutative: true},
+	{name: "Sqrt", doc: "computes the square root of each element.", args: 1},
+	{name: "Sub", doc: "subtracts corresponding elements of two vectors.", args: 2},
+	{name: "Xor", doc: "performs a bitwise XOR operation between two vectors.", args: 2, commutative: true},
+}
+
+// An impl is the implementation of an op for a particular vector type.
+type impl struct {
+	asm     string // assembler mnemonic
+	ssaName string // SSA op name, without the architecture prefix
+	arng    string // arm64 arrangement, e.g. "4S"
+	feature string // required CPU feature
+}
+
+// An arch describes a target architecture.
+type arch struct {
+	goarch string
+	name   string // SSA architecture name
+	family string // sys.ArchFamily
+	widths []int
+	elems  func(e elem) bool
+	impl   func(o op, v vec) (impl, bool)
+}
+
+var arches = []arch{
+	{
+		goarch: "amd64",
+		name:   "AMD64",
+		family: "AMD64",
+		widths: []int{128, 256},
+		elems:  func(e elem) bool { return true },
+		impl:   amd64Impl,
+	},
+	{
+		goarch: "arm64",
+		name:   "ARM64",
+		family: "ARM64",
+		widths: []int{128},
+		// The arm64 assembler does not yet support the
+		// floating-point vector instructions.
+		elems: func(e elem) bool { return e.kind != 'f' },
+		impl:  arm64Impl,
+	},
+}
+
+var x86Suffix = map[int]string{8: "B", 16: "W", 32: "D", 64: "Q"}
+
+func amd64Impl(o op, v vec) (impl, bool) {
+	e := v.elem
+	s := x86Suffix[e.bits]
+	ps := "PS"
+	if e.bits == 64 {
+		ps = "PD"
+	}
+	var asm string
+	avx512 := false
+	switch o.name {
+	case "Abs":
+		if e.kind == 'i' {
+			asm = "VPABS" + s
+			avx512 = e.bits == 64
+		}
+	case "Add", "Sub":
+		if e.kind == 'f' {
+			asm = "V" + strings.ToUpper(o.name) + ps
+		} else {
+			asm = "VP" + strings.ToUpper(o.name) + s
+		}
+	case "And", "AndNot", "Or", "Xor":
+		if e.kind != 'f' {
+			asm = map[string]string{"And": "VPAND", "AndNot": "VPANDN", "Or": "VPOR", "Xor": "VPXOR"}[o.name]
+		}
+	case "Div", "Sqrt":
+		if e.kind == 'f' {
+			asm = "V" + strings.ToUpper(o.name) + ps
+		}
+	case "Equal":
+		if e.kind != 'f' {
+			asm = "VPCMPEQ" + s
+		}
+	case "Greater":
+		if e.kind == 'i' {
+			asm = "VPCMPGT" + s
+		}
+	case "Max", "Min":
+		switch e.kind {
+		case 'f':
+			asm = "V" + strings.ToUpper(o.name) + ps
+		case 'i':
+			asm = "VP" + strings.ToUpper(o.name) + "S" + s
+		case 'u':
+			asm = "VP" + strings.ToUpper(o.name) + "U" + s
+		}
+		avx512 = e.bits == 64 && e.kind != 'f'
+	case "Mul":
+		switch {
+		case e.kind == 'f':
+			asm = "VMUL" + ps
+		case e.bits == 16 || e.bits == 32:
+			asm = "VPMULL" + s
+		}
+	}
+	if asm == "" {
+		return impl{}, false
+	}
+	feature := "AVX"
+	switch {
+	case avx512:
+		feature = "AVX512"
+	case v.bits == 256 && e.kind != 'f':
+		feature = "AVX2"
+	}
+	return impl{asm: asm, ssaName: asm, feature: feature}, true
+}
+
+func arm64Impl(o op, v vec) (impl, bool) {
+	e := v.elem
+	arng := fmt.Sprintf("%d%s", v.lanes(), map[int]string{8: "B", 16: "H", 32: "S", 64: "D"}[e.bits])
+	var asm string
+	switch o.name {
+	case "Add", "Sub":
+		asm = "V" + strings.ToUpper(o.name)
+	case "And":
+		asm, arng = "VAND", "16B"
+	case "Or":
+		asm, arng = "VORR", "16B"
+	case "Xor":
+		asm, arng = "VEOR", "16B"
+	case "Equal":
+		asm = "VCMEQ"
+	case "Max", "Min":
+		if e.kind == 'u' && e.bits < 64 {
+			asm = "VU" + strings.ToUpper(o.name)
+		}
+	}
+	if asm == "" {
+		return impl{}, false
+	}
+	return impl{asm: asm, ssaName: asm + arng, arng: arng, feature: "NEON"}, true
+}
+
+func (a arch) vecs() []vec {
+	var vs []vec
+	for _, w := range a.widths {
+		for _, e := range elems {
+			if a.elems(e) {
+				vs = append(vs, vec{e, w})
+			}
+		}
+	}
+	return vs
+}
+
+func main() {
+	// mkops runs in src/simd/archsimd.
+	compile := filepath.Join("..", "..", "cmd", "compile", "internal")
+
+	intrinsics := new(bytes.Buffer)
+	fmt.Fprintf(intrinsics, "%s\npackage ssagen\n\n", header)
+	fmt.Fprintf(intrinsics, "import (\n\t\"cmd/compile/internal/ssa\"\n\t\"cmd/internal/sys\"\n)\n\n")
+	fmt.Fprintf(intrinsics, "const simdPackage = \"simd/archsimd\"\n\n")
+	fmt.Fprintf(intrinsics, "func simdIntrinsics(addF func(pkg, fn string, b intrinsicBuilder, archFamilies ...sys.ArchFamily)) {\n")
+
+	for _, a := range arches {
+		writeFile("types_"+a.goarch+".go", genTypes(a))
+		writeFile("ops_"+a.goarch+".go", genOps(a))
+		writeFile(filepath.Join(compile, "ssa", "_gen", "simd"+a.name+"ops.go"), genSSAOps(a))
+		writeFile(filepath.Join(compile, a.goarch, "simdssa.go"), genSSAGen(a))
+		genIntrinsics(intrinsics, a)
+	}
+
+	fmt.Fprintf(intrinsics, "}\n")
+	writeFile(filepath.Join(compile, "ssagen", "simdintrinsics.go"), intrinsics)
+}
+
+const header = "// Code generated by mkops.go; DO NOT EDIT.\n"
+
+func writeFile(path string, b *bytes.Buffer) {
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("formatting %s: %v\n%s", path, err, b.Bytes())
+	}
+	if err := os.WriteFile(path, src, 0666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func genTypes(a arch) *bytes.Buffer {
+	w := new(bytes.Buffer)
+	fmt.Fprintf(w, "%s\n//go:build goexperiment.simd\n\npackage archsimd\n", header)
+	for _, width := range a.widths {
+		fmt.Fprintf(w, `
+// v%[1]d is a tag type that tells the compiler that a struct
+// is really a %[1]d-bit vector.
+type v%[1]d struct {
+	_%[1]d [0]func() // uncomparable
+}
+`, width)
+	}
+	for _, v := range a.vecs() {
+		n, lanes, arr := v.name(), v.lanes(), v.array()
+		fmt.Fprintf(w, `
+// %[1]s is a %[2]d-bit SIMD vector of %[3]d %[4]ss.
+type %[1]s struct {
+	%[5]s v%[2]d
+	vals %[6]s
+}
+
+// Len returns the number of elements in %[7]s %[1]s.
+func (x %[1]s) Len() int { return %[3]d }
+
+// Load%[1]s loads %[7]s %[1]s from an array.
+//
+//go:noescape
+func Load%[1]s(y *%[6]s) %[1]s
+
+// Store stores %[7]s %[1]s to an array.
+//
+//go:noescape
+func (x %[1]s) Store(y *%[6]s)
+
+// Load%[1]sSlice loads %[7]s %[1]s from a slice of at least %[3]d elements.
+// It panics if s has fewer than %[3]d elements.
+func Load%[1]sSlice(s []%[4]s) %[1]s {
+	return Load%[1]s((*%[6]s)(s))
+}
+
+// StoreSlice stores x into a slice of at least %[3]d elements.
+// It panics if s has fewer than %[3]d elements.
+func (x %[1]s) StoreSlice(s []%[4]s) {
+	x.Store((*%[6]s)(s))
+}
+
+// Broadcast%[1]s returns %[7]s %[1]s with all elements set to v.
+func Broadcast%[1]s(v %[4]s) %[1]s {
+	var a %[6]s
+	for i := range a {
+		a[i] = v
+	}
+	return Load%[1]s(&a)
+}
+`, n, v.bits, lanes, v.typ, strings.ToLower(n), arr, article(n))
+	}
+	return w
+}
+
+// article returns the indefinite article to use before name.
+func article(name string) string {
+	if strings.HasPrefix(name, "I") {
+		return "an"
+	}
+	return "a"
+}
+
+func genOps(a arch) *bytes.Buffer {
+	w := new(bytes.Buffer)
+	fmt.Fprintf(w, "%s\n//go:build goexperiment.simd\n\npackage archsimd\n", header)
+	for _, o := range ops {
+		first := true
+		for _, v := range a.vecs() {
+			im, ok := a.impl(o, v)
+			if !ok {
+				continue
+			}
+			if first {
+				fmt.Fprintf(w, "\n/* %s */\n", o.name)
+				first = false
+			}
+			n := v.name()
+			fmt.Fprintf(w, "\n// %s %s\n//\n// Asm: %s, CPU Feature: %s\n", o.name, o.doc, im.asm, im.feature)
+			if o.args == 1 {
+				fmt.Fprintf(w, "func (x %s) %s() %s\n", n, o.name, n)
+			} else {
+				fmt.Fprintf(w, "func (x %s) %s(y %s) %s\n", n, o.name, n, n)
+			}
+		}
+	}
+	return w
+}
+
+// ssaOps returns the distinct SSA ops needed for a, in sorted order.
+func ssaOps(a arch) []struct {
+	op
+	impl
+} {
+	seen := map[string]bool{}
+	var res []struct {
+		op
+		impl
+	}
+	for _, o := range ops {
+		for _, v := range a.vecs() {
+			im, ok := a.impl(o, v)
+			if !ok || seen[im.ssaName] {
+				continue
+			}
+			seen[im.ssaName] = true
+			res = append(res, struct {
+				op
+				impl
+			}{o, im})
+		}
+	}
+	slices.SortFunc(res, func(x, y struct {
+		op
+		impl
+	}) int {
+		return strings.Compare(x.ssaName, y.ssaName)
+	})
+	return res
+}
+
+func genSSAOps(a arch) *bytes.Buffer {
+	w := new(bytes.Buffer)
+	fmt.Fprintf(w, "%s\npackage main\n\n", header)
+	fmt.Fprintf(w, "func simd%sOps(v11, v21 regInfo) []opData {\n\treturn []opData{\n", a.name)
+	for _, o := range ssaOps(a) {
+		reg := "v21"
+		if o.args == 1 {
+			reg = "v11"
+		}
+		comm := ""
+		if o.commutative {
+			comm = ", commutative: true"
+		}
+		fmt.Fprintf(w, "\t\t{name: %q, argLength: %d, reg: %s, asm: %q%s},\n", o.ssaName, o.args, reg, o.asm, comm)
+	}
+	fmt.Fprintf(w, "\t}\n}\n")
+	return w
+}
+
+func genSSAGen(a arch) *bytes.Buffer {
+	w := new(bytes.Buffer)
+	fmt.Fprintf(w, "%s\npackage %s\n\n", header, a.goarch)
+	switch a.goarch {
+	case "amd64":
+		fmt.Fprintf(w, "import (\n\t\"cmd/compile/internal/ssa\"\n\t\"cmd/compile/internal/ssagen\"\n)\n\n")
+	case "arm64":
+		fmt.Fprintf(w, "import (\n\t\"cmd/compile/internal/ssa\"\n\t\"cmd/compile/internal/ssagen\"\n\t\"cmd/internal/obj/arm64\"\n)\n\n")
+	}
+	fmt.Fprintf(w, "// ssaGenSIMDValue generates code for v if it is a SIMD operation,\n")
+	fmt.Fprintf(w, "// and reports whether it did so.\n")
+	fmt.Fprintf(w, "func ssaGenSIMDValue(s *ssagen.State, v *ssa.Value) bool {\n\tswitch v.Op {\n")
+
+	// Group the ops by how their code is generated.
+	type group struct {
+		key  string
+		call string
+		ops  []string
+	}
+	var groups []*group
+	byKey := map[string]*group{}
+	for _, o := range ssaOps(a) {
+		key := fmt.Sprintf("simdV%d1", o.args)
+		call := key + "(s, v)"
+		if a.goarch == "arm64" {
+			key += "/" + o.arng
+			call = fmt.Sprintf("simdV%d1(s, v, arm64.ARNG_%s)", o.args, o.arng)
+		}
+		g := byKey[key]
+		if g == nil {
+			g = &group{key: key, call: call}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.ops = append(g.ops, fmt.Sprintf("ssa.Op%s%s", a.name, o.ssaName))
+	}
+	slices.SortFunc(groups, func(x, y *group) int { return strings.Compare(x.key, y.key) })
+	for _, g := range groups {
+		fmt.Fprintf(w, "\tcase %s:\n\t\t%s\n", strings.Join(g.ops, ",\n\t\t"), g.call)
+	}
+	fmt.Fprintf(w, "\tdefault:\n\t\treturn false\n\t}\n\treturn true\n}\n")
+	return w
+}
+
+func genIntrinsics(w *bytes.Buffer, a arch) {
+	fmt.Fprintf(w, "\n\t/******** %s ********/\n", a.goarch)
+	for _, v := range a.vecs() {
+		n := v.name()
+		fmt.Fprintf(w, "\taddF(simdPackage, %q, simdLoad, sys.%s)\n", "Load"+n, a.family)
+		fmt.Fprintf(w, "\taddF(simdPackage, %q, simdStore, sys.%s)\n", n+".Store", a.family)
+		for _, o := range ops {
+			im, ok := a.impl(o, v)
+			if !ok {
+				continue
+			}
+			b := fmt.Sprintf("simdOp%d(ssa.Op%s%s)", o.args, a.name, im.ssaName)
+			if o.swap {
+				b = fmt.Sprintf("simdOp2Swapped(ssa.Op%s%s)", a.name, im.ssaName)
+			}
+			fmt.Fprintf(w, "\taddF(simdPackage, %q, %s, sys.%s)\n", n+"."+o.name, b, a.family)
+		}
+	}
+}
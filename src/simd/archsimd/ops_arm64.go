@@ -0,0 +1,307 @@
+// Code generated by mkops.go; DO NOT EDIT.
+
+//go:build goexperiment.simd
+
+package archsimd
+
+/* Add */
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Int8x16) Add(y Int8x16) Int8x16
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Int16x8) Add(y Int16x8) Int16x8
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Int32x4) Add(y Int32x4) Int32x4
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Int64x2) Add(y Int64x2) Int64x2
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Uint8x16) Add(y Uint8x16) Uint8x16
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Uint16x8) Add(y Uint16x8) Uint16x8
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Uint32x4) Add(y Uint32x4) Uint32x4
+
+// Add adds corresponding elements of two vectors.
+//
+// Asm: VADD, CPU Feature: NEON
+func (x Uint64x2) Add(y Uint64x2) Uint64x2
+
+/* And */
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Int8x16) And(y Int8x16) Int8x16
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Int16x8) And(y Int16x8) Int16x8
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Int32x4) And(y Int32x4) Int32x4
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Int64x2) And(y Int64x2) Int64x2
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Uint8x16) And(y Uint8x16) Uint8x16
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Uint16x8) And(y Uint16x8) Uint16x8
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Uint32x4) And(y Uint32x4) Uint32x4
+
+// And performs a bitwise AND operation between two vectors.
+//
+// Asm: VAND, CPU Feature: NEON
+func (x Uint64x2) And(y Uint64x2) Uint64x2
+
+/* Equal */
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Int8x16) Equal(y Int8x16) Int8x16
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Int16x8) Equal(y Int16x8) Int16x8
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Int32x4) Equal(y Int32x4) Int32x4
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Int64x2) Equal(y Int64x2) Int64x2
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Uint8x16) Equal(y Uint8x16) Uint8x16
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Uint16x8) Equal(y Uint16x8) Uint16x8
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Uint32x4) Equal(y Uint32x4) Uint32x4
+
+// Equal compares for equality, setting all bits of each
+// element of the result if the corresponding elements are equal,
+// and clearing them otherwise.
+//
+// Asm: VCMEQ, CPU Feature: NEON
+func (x Uint64x2) Equal(y Uint64x2) Uint64x2
+
+/* Max */
+
+// Max computes the maximum of corresponding elements.
+//
+// Asm: VUMAX, CPU Feature: NEON
+func (x Uint8x16) Max(y Uint8x16) Uint8x16
+
+// Max computes the maximum of corresponding elements.
+//
+// Asm: VUMAX, CPU Feature: NEON
+func (x Uint16x8) Max(y Uint16x8) Uint16x8
+
+// Max computes the maximum of corresponding elements.
+//
+// Asm: VUMAX, CPU Feature: NEON
+func (x Uint32x4) Max(y Uint32x4) Uint32x4
+
+/* Min */
+
+// Min computes the minimum of corresponding elements.
+//
+// Asm: VUMIN, CPU Feature: NEON
+func (x Uint8x16) Min(y Uint8x16) Uint8x16
+
+// Min computes the minimum of corresponding elements.
+//
+// Asm: VUMIN, CPU Feature: NEON
+func (x Uint16x8) Min(y Uint16x8) Uint16x8
+
+// Min computes the minimum of corresponding elements.
+//
+// Asm: VUMIN, CPU Feature: NEON
+func (x Uint32x4) Min(y Uint32x4) Uint32x4
+
+/* Or */
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Int8x16) Or(y Int8x16) Int8x16
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Int16x8) Or(y Int16x8) Int16x8
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Int32x4) Or(y Int32x4) Int32x4
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Int64x2) Or(y Int64x2) Int64x2
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Uint8x16) Or(y Uint8x16) Uint8x16
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Uint16x8) Or(y Uint16x8) Uint16x8
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Uint32x4) Or(y Uint32x4) Uint32x4
+
+// Or performs a bitwise OR operation between two vectors.
+//
+// Asm: VORR, CPU Feature: NEON
+func (x Uint64x2) Or(y Uint64x2) Uint64x2
+
+/* Sub */
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Int8x16) Sub(y Int8x16) Int8x16
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Int16x8) Sub(y Int16x8) Int16x8
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Int32x4) Sub(y Int32x4) Int32x4
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Int64x2) Sub(y Int64x2) Int64x2
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Uint8x16) Sub(y Uint8x16) Uint8x16
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Uint16x8) Sub(y Uint16x8) Uint16x8
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Uint32x4) Sub(y Uint32x4) Uint32x4
+
+// Sub subtracts corresponding elements of two vectors.
+//
+// Asm: VSUB, CPU Feature: NEON
+func (x Uint64x2) Sub(y Uint64x2) Uint64x2
+
+/* Xor */
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Int8x16) Xor(y Int8x16) Int8x16
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Int16x8) Xor(y Int16x8) Int16x8
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Int32x4) Xor(y Int32x4) Int32x4
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Int64x2) Xor(y Int64x2) Int64x2
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Uint8x16) Xor(y Uint8x16) Uint8x16
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Uint16x8) Xor(y Uint16x8) Uint16x8
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Uint32x4) Xor(y Uint32x4) Uint32x4
+
+// Xor performs a bitwise XOR operation between two vectors.
+//
+// Asm: VEOR, CPU Feature: NEON
+func (x Uint64x2) Xor(y Uint64x2) Uint64x2
@@ -0,0 +1,150 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.simd
+
+package archsimd_test
+
+import (
+	"math"
+	"simd/archsimd"
+	"sync/atomic"
+	"testing"
+)
+
+func TestInt32x8(t *testing.T) {
+	if !archsimd.X86.AVX2() {
+		t.Skip("AVX2 not supported")
+	}
+	a := [8]int32{1, -2, 3, -4, 5, -6, 7, -8}
+	b := [8]int32{8, 7, 6, 5, 4, 3, 2, 1}
+	x := archsimd.LoadInt32x8(&a)
+	y := archsimd.LoadInt32x8(&b)
+
+	for _, tt := range []struct {
+		name string
+		got  archsimd.Int32x8
+		op   func(a, b int32) int32
+	}{
+		{"Add", x.Add(y), func(a, b int32) int32 { return a + b }},
+		{"Sub", x.Sub(y), func(a, b int32) int32 { return a - b }},
+		{"Mul", x.Mul(y), func(a, b int32) int32 { return a * b }},
+		{"Min", x.Min(y), func(a, b int32) int32 { return min(a, b) }},
+		{"Max", x.Max(y), func(a, b int32) int32 { return max(a, b) }},
+		{"AndNot", x.AndNot(y), func(a, b int32) int32 { return a &^ b }},
+		{"Greater", x.Greater(y), func(a, b int32) int32 {
+			if a > b {
+				return -1
+			}
+			return 0
+		}},
+		{"Abs", x.Abs(), func(a, _ int32) int32 { return max(a, -a) }},
+	} {
+		var got [8]int32
+		tt.got.Store(&got)
+		for i := range got {
+			if want := tt.op(a[i], b[i]); got[i] != want {
+				t.Errorf("%s: element %d = %d, want %d", tt.name, i, got[i], want)
+			}
+		}
+	}
+}
+
+func TestFloat64x4(t *testing.T) {
+	if !archsimd.X86.AVX() {
+		t.Skip("AVX not supported")
+	}
+	a := [4]float64{1, 4, -9, 16}
+	b := [4]float64{2, 0.5, 3, -4}
+	x := archsimd.LoadFloat64x4(&a)
+	y := archsimd.LoadFloat64x4(&b)
+
+	for _, tt := range []struct {
+		name string
+		got  archsimd.Float64x4
+		op   func(a, b float64) float64
+	}{
+		{"Add", x.Add(y), func(a, b float64) float64 { return a + b }},
+		{"Sub", x.Sub(y), func(a, b float64) float64 { return a - b }},
+		{"Mul", x.Mul(y), func(a, b float64) float64 { return a * b }},
+		{"Div", x.Div(y), func(a, b float64) float64 { return a / b }},
+		{"Min", x.Min(y), func(a, b float64) float64 { return min(a, b) }},
+		{"Max", x.Max(y), func(a, b float64) float64 { return max(a, b) }},
+	} {
+		var got [4]float64
+		tt.got.Store(&got)
+		for i := range got {
+			if want := tt.op(a[i], b[i]); got[i] != want {
+				t.Errorf("%s: element %d = %v, want %v", tt.name, i, got[i], want)
+			}
+		}
+	}
+
+	var got [4]float64
+	x.Sqrt().Store(&got)
+	for i := range got {
+		if want := math.Sqrt(a[i]); got[i] != want && !(math.IsNaN(got[i]) && math.IsNaN(want)) {
+			t.Errorf("Sqrt: element %d = %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+func TestFloat32x4(t *testing.T) {
+	if !archsimd.X86.AVX() {
+		t.Skip("AVX not supported")
+	}
+	x := archsimd.BroadcastFloat32x4(1)
+	y := archsimd.LoadFloat32x4(&[4]float32{1, 2, 4, 8})
+	var got [4]float32
+	x.Div(y).Store(&got)
+	if want := [4]float32{1, 0.5, 0.25, 0.125}; got != want {
+		t.Errorf("Div = %v, want %v", got, want)
+	}
+}
+
+func TestInt64x4AVX512(t *testing.T) {
+	if !archsimd.X86.AVX512() {
+		t.Skip("AVX-512 not supported")
+	}
+	x := archsimd.LoadInt64x4(&[4]int64{-1, 2, math.MinInt64, 4})
+	y := archsimd.LoadInt64x4(&[4]int64{1, -2, 3, 4})
+	var got [4]int64
+	x.Max(y).Store(&got)
+	if want := [4]int64{1, 2, 3, 4}; got != want {
+		t.Errorf("Max = %v, want %v", got, want)
+	}
+	x.Min(y).Store(&got)
+	if want := [4]int64{-1, -2, math.MinInt64, 4}; got != want {
+		t.Errorf("Min = %v, want %v", got, want)
+	}
+	y.Abs().Store(&got)
+	if want := [4]int64{1, 2, 3, 4}; got != want {
+		t.Errorf("Abs = %v, want %v", got, want)
+	}
+}
+
+func TestAsyncPreempt256(t *testing.T) {
+	if !archsimd.X86.AVX2() {
+		t.Skip("AVX2 not supported")
+	}
+	var stop atomic.Bool
+	defer stop.Store(true)
+	go preempt(&stop)
+
+	// acc stays in a Y register for the whole loop, so all 256 bits
+	// must survive asynchronous preemption.
+	one := archsimd.LoadInt32x8(&[8]int32{1, 2, 3, 4, 5, 6, 7, 8})
+	var acc archsimd.Int32x8
+	const n = 100_000_000
+	for range n {
+		acc = acc.Add(one)
+	}
+	var got [8]int32
+	acc.Store(&got)
+	for i := range got {
+		if want := int32(n * (i + 1)); got[i] != want {
+			t.Errorf("element %d = %d, want %d", i, got[i], want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.simd && (amd64 || arm64)
+
+package archsimd_test
+
+import (
+	"runtime"
+	"simd/archsimd"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// require128 skips the test if 128-bit vector operations
+// are not supported on the current CPU.
+func require128(t *testing.T) {
+	t.Helper()
+	if runtime.GOARCH == "amd64" && !archsimd.X86.AVX() {
+		t.Skip("AVX not supported")
+	}
+}
+
+func TestAddSub(t *testing.T) {
+	require128(t)
+	a := [4]int32{1, 2, 3, 4}
+	b := [4]int32{10, -20, 30, -1 << 31}
+	x := archsimd.LoadInt32x4(&a)
+	y := archsimd.LoadInt32x4(&b)
+
+	var got [4]int32
+	x.Add(y).Store(&got)
+	if want := [4]int32{11, -18, 33, -1<<31 + 4}; got != want {
+		t.Errorf("Add = %v, want %v", got, want)
+	}
+	x.Sub(y).Store(&got)
+	if want := [4]int32{-9, 22, -27, -1<<31 + 4}; got != want {
+		t.Errorf("Sub = %v, want %v", got, want)
+	}
+	y.Sub(x).Store(&got)
+	if want := [4]int32{9, -22, 27, 1<<31 - 4}; got != want {
+		t.Errorf("reversed Sub = %v, want %v", got, want)
+	}
+}
+
+func TestBitwise(t *testing.T) {
+	require128(t)
+	a := make([]uint8, 16)
+	b := make([]uint8, 16)
+	for i := range a {
+		a[i] = uint8(i * 17)
+		b[i] = uint8(0xf0 ^ i)
+	}
+	x := archsimd.LoadUint8x16Slice(a)
+	y := archsimd.LoadUint8x16Slice(b)
+
+	for _, tt := range []struct {
+		name string
+		got  archsimd.Uint8x16
+		op   func(a, b uint8) uint8
+	}{
+		{"And", x.And(y), func(a, b uint8) uint8 { return a & b }},
+		{"Or", x.Or(y), func(a, b uint8) uint8 { return a | b }},
+		{"Xor", x.Xor(y), func(a, b uint8) uint8 { return a ^ b }},
+	} {
+		got := make([]uint8, 16)
+		tt.got.StoreSlice(got)
+		for i := range got {
+			if want := tt.op(a[i], b[i]); got[i] != want {
+				t.Errorf("%s: element %d = %#x, want %#x", tt.name, i, got[i], want)
+			}
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	require128(t)
+	x := archsimd.LoadInt64x2(&[2]int64{5, 6})
+	y := archsimd.LoadInt64x2(&[2]int64{5, 7})
+	var got [2]int64
+	x.Equal(y).Store(&got)
+	if want := [2]int64{-1, 0}; got != want {
+		t.Errorf("Equal = %v, want %v", got, want)
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	require128(t)
+	var zero archsimd.Uint16x8
+	y := archsimd.BroadcastUint16x8(7)
+	var got [8]uint16
+	zero.Add(y).Store(&got)
+	if want := [8]uint16{7, 7, 7, 7, 7, 7, 7, 7}; got != want {
+		t.Errorf("zero.Add(y) = %v, want %v", got, want)
+	}
+	zero.Store(&got)
+	if want := [8]uint16{}; got != want {
+		t.Errorf("zero value = %v, want %v", got, want)
+	}
+}
+
+func TestMethodValue(t *testing.T) {
+	require128(t)
+	// Using the methods as function values requires the compiler
+	// to generate bodies for them.
+	ops := []func(archsimd.Int32x4, archsimd.Int32x4) archsimd.Int32x4{
+		archsimd.Int32x4.Add,
+		archsimd.Int32x4.Sub,
+	}
+	x := archsimd.BroadcastInt32x4(5)
+	y := archsimd.BroadcastInt32x4(3)
+	var got [4]int32
+	ops[0](x, y).Store(&got)
+	if want := [4]int32{8, 8, 8, 8}; got != want {
+		t.Errorf("Add via func value = %v, want %v", got, want)
+	}
+	ops[1](x, y).Store(&got)
+	if want := [4]int32{2, 2, 2, 2}; got != want {
+		t.Errorf("Sub via func value = %v, want %v", got, want)
+	}
+}
+
+//go:noinline
+func clobber(n int) float64 {
+	var s float64
+	for i := range n {
+		s += float64(i) * 1.5
+	}
+	return s
+}
+
+func TestLiveAcrossCall(t *testing.T) {
+	require128(t)
+	a := []int16{1, 2, 3, 4, 5, 6, 7, 8}
+	x := archsimd.LoadInt16x8Slice(a)
+	y := x.Add(x)
+	// x and y must survive a call that uses the floating-point registers.
+	if clobber(100) == 0 {
+		t.Fatal("unexpected result from clobber")
+	}
+	got := make([]int16, 8)
+	y.Add(x).StoreSlice(got)
+	want := []int16{3, 6, 9, 12, 15, 18, 21, 24}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestShortSlice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("LoadInt32x4Slice of a short slice did not panic")
+		}
+	}()
+	archsimd.LoadInt32x4Slice(make([]int32, 3))
+}
+
+// preempt repeatedly forces goroutines to be preempted
+// until stop is set.
+func preempt(stop *atomic.Bool) {
+	for !stop.Load() {
+		runtime.GC()
+	}
+}
+
+func TestAsyncPreempt(t *testing.T) {
+	require128(t)
+	var stop atomic.Bool
+	defer stop.Store(true)
+	go preempt(&stop)
+
+	// acc stays in a vector register for the whole loop,
+	// so it must survive asynchronous preemption.
+	one := archsimd.LoadInt64x2(&[2]int64{1, 1 << 32})
+	var acc archsimd.Int64x2
+	const n = 100_000_000
+	for range n {
+		acc = acc.Add(one)
+	}
+	var got [2]int64
+	acc.Store(&got)
+	if want := [2]int64{n, n << 32}; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,331 @@
+// Code generated by mkops.go; DO NOT EDIT.
+
+//go:build goexperiment.simd
+
+package archsimd
+
+// v128 is a tag type that tells the compiler that a struct
+// is really a 128-bit vector.
+type v128 struct {
+	_128 [0]func() // uncomparable
+}
+
+// Int8x16 is a 128-bit SIMD vector of 16 int8s.
+type Int8x16 struct {
+	int8x16 v128
+	vals    [16]int8
+}
+
+// Len returns the number of elements in an Int8x16.
+func (x Int8x16) Len() int { return 16 }
+
+// LoadInt8x16 loads an Int8x16 from an array.
+//
+//go:noescape
+func LoadInt8x16(y *[16]int8) Int8x16
+
+// Store stores an Int8x16 to an array.
+//
+//go:noescape
+func (x Int8x16) Store(y *[16]int8)
+
+// LoadInt8x16Slice loads an Int8x16 from a slice of at least 16 elements.
+// It panics if s has fewer than 16 elements.
+func LoadInt8x16Slice(s []int8) Int8x16 {
+	return LoadInt8x16((*[16]int8)(s))
+}
+
+// StoreSlice stores x into a slice of at least 16 elements.
+// It panics if s has fewer than 16 elements.
+func (x Int8x16) StoreSlice(s []int8) {
+	x.Store((*[16]int8)(s))
+}
+
+// BroadcastInt8x16 returns an Int8x16 with all elements set to v.
+func BroadcastInt8x16(v int8) Int8x16 {
+	var a [16]int8
+	for i := range a {
+		a[i] = v
+	}
+	return LoadInt8x16(&a)
+}
+
+// Int16x8 is a 128-bit SIMD vector of 8 int16s.
+type Int16x8 struct {
+	int16x8 v128
+	vals    [8]int16
+}
+
+// Len returns the number of elements in an Int16x8.
+func (x Int16x8) Len() int { return 8 }
+
+// LoadInt16x8 loads an Int16x8 from an array.
+//
+//go:noescape
+func LoadInt16x8(y *[8]int16) Int16x8
+
+// Store stores an Int16x8 to an array.
+//
+//go:noescape
+func (x Int16x8) Store(y *[8]int16)
+
+// LoadInt16x8Slice loads an Int16x8 from a slice of at least 8 elements.
+// It panics if s has fewer than 8 elements.
+func LoadInt16x8Slice(s []int16) Int16x8 {
+	return LoadInt16x8((*[8]int16)(s))
+}
+
+// StoreSlice stores x into a slice of at least 8 elements.
+// It panics if s has fewer than 8 elements.
+func (x Int16x8) StoreSlice(s []int16) {
+	x.Store((*[8]int16)(s))
+}
+
+// BroadcastInt16x8 returns an Int16x8 with all elements set to v.
+func BroadcastInt16x8(v int16) Int16x8 {
+	var a [8]int16
+	for i := range a {
+		a[i] = v
+	}
+	return LoadInt16x8(&a)
+}
+
+// Int32x4 is a 128-bit SIMD vector of 4 int32s.
+type Int32x4 struct {
+	int32x4 v128
+	vals    [4]int32
+}
+
+// Len returns the number of elements in an Int32x4.
+func (x Int32x4) Len() int { return 4 }
+
+// LoadInt32x4 loads an Int32x4 from an array.
+//
+//go:noescape
+func LoadInt32x4(y *[4]int32) Int32x4
+
+// Store stores an Int32x4 to an array.
+//
+//go:noescape
+func (x Int32x4) Store(y *[4]int32)
+
+// LoadInt32x4Slice loads an Int32x4 from a slice of at least 4 elements.
+// It panics if s has fewer than 4 elements.
+func LoadInt32x4Slice(s []int32) Int32x4 {
+	return LoadInt32x4((*[4]int32)(s))
+}
+
+// StoreSlice stores x into a slice of at least 4 elements.
+// It panics if s has fewer than 4 elements.
+func (x Int32x4) StoreSlice(s []int32) {
+	x.Store((*[4]int32)(s))
+}
+
+// BroadcastInt32x4 returns an Int32x4 with all elements set to v.
+func BroadcastInt32x4(v int32) Int32x4 {
+	var a [4]int32
+	for i := range a {
+		a[i] = v
+	}
+	return LoadInt32x4(&a)
+}
+
+// Int64x2 is a 128-bit SIMD vector of 2 int64s.
+type Int64x2 struct {
+	int64x2 v128
+	vals    [2]int64
+}
+
+// Len returns the number of elements in an Int64x2.
+func (x Int64x2) Len() int { return 2 }
+
+// LoadInt64x2 loads an Int64x2 from an array.
+//
+//go:noescape
+func LoadInt64x2(y *[2]int64) Int64x2
+
+// Store stores an Int64x2 to an array.
+//
+//go:noescape
+func (x Int64x2) Store(y *[2]int64)
+
+// LoadInt64x2Slice loads an Int64x2 from a slice of at least 2 elements.
+// It panics if s has fewer than 2 elements.
+func LoadInt64x2Slice(s []int64) Int64x2 {
+	return LoadInt64x2((*[2]int64)(s))
+}
+
+// StoreSlice stores x into a slice of at least 2 elements.
+// It panics if s has fewer than 2 elements.
+func (x Int64x2) StoreSlice(s []int64) {
+	x.Store((*[2]int64)(s))
+}
+
+// BroadcastInt64x2 returns an Int64x2 with all elements set to v.
+func BroadcastInt64x2(v int64) Int64x2 {
+	var a [2]int64
+	for i := range a {
+		a[i] = v
+	}
+	return LoadInt64x2(&a)
+}
+
+// Uint8x16 is a 128-bit SIMD vector of 16 uint8s.
+type Uint8x16 struct {
+	uint8x16 v128
+	vals     [16]uint8
+}
+
+// Len returns the number of elements in a Uint8x16.
+func (x Uint8x16) Len() int { return 16 }
+
+// LoadUint8x16 loads a Uint8x16 from an array.
+//
+//go:noescape
+func LoadUint8x16(y *[16]uint8) Uint8x16
+
+// Store stores a Uint8x16 to an array.
+//
+//go:noescape
+func (x Uint8x16) Store(y *[16]uint8)
+
+// LoadUint8x16Slice loads a Uint8x16 from a slice of at least 16 elements.
+// It panics if s has fewer than 16 elements.
+func LoadUint8x16Slice(s []uint8) Uint8x16 {
+	return LoadUint8x16((*[16]uint8)(s))
+}
+
+// StoreSlice stores x into a slice of at least 16 elements.
+// It panics if s has fewer than 16 elements.
+func (x Uint8x16) StoreSlice(s []uint8) {
+	x.Store((*[16]uint8)(s))
+}
+
+// BroadcastUint8x16 returns a Uint8x16 with all elements set to v.
+func BroadcastUint8x16(v uint8) Uint8x16 {
+	var a [16]uint8
+	for i := range a {
+		a[i] = v
+	}
+	return LoadUint8x16(&a)
+}
+
+// Uint16x8 is a 128-bit SIMD vector of 8 uint16s.
+type Uint16x8 struct {
+	uint16x8 v128
+	vals     [8]uint16
+}
+
+// Len returns the number of elements in a Uint16x8.
+func (x Uint16x8) Len() int { return 8 }
+
+// LoadUint16x8 loads a Uint16x8 from an array.
+//
+//go:noescape
+func LoadUint16x8(y *[8]uint16) Uint16x8
+
+// Store stores a Uint16x8 to an array.
+//
+//go:noescape
+func (x Uint16x8) Store(y *[8]uint16)
+
+// LoadUint16x8Slice loads a Uint16x8 from a slice of at least 8 elements.
+// It panics if s has fewer than 8 elements.
+func LoadUint16x8Slice(s []uint16) Uint16x8 {
+	return LoadUint16x8((*[8]uint16)(s))
+}
+
+// StoreSlice stores x into a slice of at least 8 elements.
+// It panics if s has fewer than 8 elements.
+func (x Uint16x8) StoreSlice(s []uint16) {
+	x.Store((*[8]uint16)(s))
+}
+
+// BroadcastUint16x8 returns a Uint16x8 with all elements set to v.
+func BroadcastUint16x8(v uint16) Uint16x8 {
+	var a [8]uint16
+	for i := range a {
+		a[i] = v
+	}
+	return LoadUint16x8(&a)
+}
+
+// Uint32x4 is a 128-bit SIMD vector of 4 uint32s.
+type Uint32x4 struct {
+	uint32x4 v128
+	vals     [4]uint32
+}
+
+// Len returns the number of elements in a Uint32x4.
+func (x Uint32x4) Len() int { return 4 }
+
+// LoadUint32x4 loads a Uint32x4 from an array.
+//
+//go:noescape
+func LoadUint32x4(y *[4]uint32) Uint32x4
+
+// Store stores a Uint32x4 to an array.
+//
+//go:noescape
+func (x Uint32x4) Store(y *[4]uint32)
+
+// LoadUint32x4Slice loads a Uint32x4 from a slice of at least 4 elements.
+// It panics if s has fewer than 4 elements.
+func LoadUint32x4Slice(s []uint32) Uint32x4 {
+	return LoadUint32x4((*[4]uint32)(s))
+}
+
+// StoreSlice stores x into a slice of at least 4 elements.
+// It panics if s has fewer than 4 elements.
+func (x Uint32x4) StoreSlice(s []uint32) {
+	x.Store((*[4]uint32)(s))
+}
+
+// BroadcastUint32x4 returns a Uint32x4 with all elements set to v.
+func BroadcastUint32x4(v uint32) Uint32x4 {
+	var a [4]uint32
+	for i := range a {
+		a[i] = v
+	}
+	return LoadUint32x4(&a)
+}
+
+// Uint64x2 is a 128-bit SIMD vector of 2 uint64s.
+type Uint64x2 struct {
+	uint64x2 v128
+	vals     [2]uint64
+}
+
+// Len returns the number of elements in a Uint64x2.
+func (x Uint64x2) Len() int { return 2 }
+
+// LoadUint64x2 loads a Uint64x2 from an array.
+//
+//go:noescape
+func LoadUint64x2(y *[2]uint64) Uint64x2
+
+// Store stores a Uint64x2 to an array.
+//
+//go:noescape
+func (x Uint64x2) Store(y *[2]uint64)
+
+// LoadUint64x2Slice loads a Uint64x2 from a slice of at least 2 elements.
+// It panics if s has fewer than 2 elements.
+func LoadUint64x2Slice(s []uint64) Uint64x2 {
+	return LoadUint64x2((*[2]uint64)(s))
+}
+
+// StoreSlice stores x into a slice of at least 2 elements.
+// It panics if s has fewer than 2 elements.
+func (x Uint64x2) StoreSlice(s []uint64) {
+	x.Store((*[2]uint64)(s))
+}
+
+// BroadcastUint64x2 returns a Uint64x2 with all elements set to v.
+func BroadcastUint64x2(v uint64) Uint64x2 {
+	var a [2]uint64
+	for i := range a {
+		a[i] = v
+	}
+	return LoadUint64x2(&a)
+}
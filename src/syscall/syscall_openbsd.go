@@ -208,4 +208,7 @@ func Getfsstat(buf []Statfs_t, flags int) (n int, err error) {
 //sys	mmap(addr uintptr, length uintptr, prot int, flag int, fd int, pos int64) (ret uintptr, err error)
 //sys	munmap(addr uintptr, length uintptr) (err error)
 //sys	getfsstat(stat *Statfs_t, bufsize uintptr, flags int) (n int, err error)
+// used by internal/syscall/unix
+//go:linkname utimensat
+
 //sys	utimensat(dirfd int, path string, times *[2]Timespec, flag int) (err error)
@@ -47,6 +47,7 @@ const (
 	O_ASYNC        = 0x02000
 	O_CLOEXEC      = 0x80000
 	o_DIRECTORY    = 0x100000   // used by internal/syscall/windows
+	o_WRITE_ATTRS  = 0x10000000 // used by internal/syscall/windows
 	o_NOFOLLOW_ANY = 0x20000000 // used by internal/syscall/windows
 	o_OPEN_REPARSE = 0x40000000 // used by internal/syscall/windows
 )
@@ -11,3 +11,8 @@ type HighPrecisionTime = highPrecisionTime
 var HighPrecisionTimeNow = highPrecisionTimeNow
 
 const ParallelConflict = parallelConflict
+
+var (
+	ParseShard = parseShard
+	InShard    = inShard
+)
@@ -300,13 +300,15 @@ func (f *F) Fuzz(ff any) {
 		n := runtime.Callers(2, pc[:])
 		t := &T{
 			common: common{
-				barrier: make(chan bool),
-				signal:  make(chan bool),
-				name:    testName,
-				parent:  &f.common,
-				level:   f.level + 1,
-				creator: pc[:n],
-				chatty:  f.chatty,
+				barrier:    make(chan bool),
+				signal:     make(chan bool),
+				name:       testName,
+				parent:     &f.common,
+				level:      f.level + 1,
+				creator:    pc[:n],
+				chatty:     f.chatty,
+				modulePath: f.modulePath,
+				importPath: f.importPath,
 			},
 			tstate: f.tstate,
 		}
@@ -489,7 +491,11 @@ func runFuzzTests(deps testDeps, fuzzTests []InternalFuzzTarget, deadline time.T
 			tstate := newTestState(*parallel, m)
 			tstate.deadline = deadline
 			fstate := &fuzzState{deps: deps, mode: seedCorpusOnly}
-			root := common{w: os.Stdout} // gather output in one place
+			root := common{
+				w:          os.Stdout, // gather output in one place
+				modulePath: deps.ModulePath(),
+				importPath: deps.ImportPath(),
+			}
 			if Verbose() {
 				root.chatty = newChattyPrinter(root.w)
 			}
@@ -510,12 +516,14 @@ func runFuzzTests(deps testDeps, fuzzTests []InternalFuzzTarget, deadline time.T
 				}
 				f := &F{
 					common: common{
-						signal:  make(chan bool),
-						barrier: make(chan bool),
-						name:    testName,
-						parent:  &root,
-						level:   root.level + 1,
-						chatty:  root.chatty,
+						signal:     make(chan bool),
+						barrier:    make(chan bool),
+						name:       testName,
+						parent:     &root,
+						level:      root.level + 1,
+						chatty:     root.chatty,
+						modulePath: root.modulePath,
+						importPath: root.importPath,
 					},
 					tstate: tstate,
 					fstate: fstate,
@@ -559,7 +567,11 @@ func runFuzzing(deps testDeps, fuzzTests []InternalFuzzTarget) (ok bool) {
 	fstate := &fuzzState{
 		deps: deps,
 	}
-	root := common{w: os.Stdout}
+	root := common{
+		w:          os.Stdout,
+		modulePath: deps.ModulePath(),
+		importPath: deps.ImportPath(),
+	}
 	if *isFuzzWorker {
 		root.w = io.Discard
 		fstate.mode = fuzzWorker
@@ -592,12 +604,14 @@ func runFuzzing(deps testDeps, fuzzTests []InternalFuzzTarget) (ok bool) {
 
 	f := &F{
 		common: common{
-			signal:  make(chan bool),
-			barrier: nil, // T.Parallel has no effect when fuzzing.
-			name:    testName,
-			parent:  &root,
-			level:   root.level + 1,
-			chatty:  root.chatty,
+			signal:     make(chan bool),
+			barrier:    nil, // T.Parallel has no effect when fuzzing.
+			name:       testName,
+			parent:     &root,
+			level:      root.level + 1,
+			chatty:     root.chatty,
+			modulePath: root.modulePath,
+			importPath: root.importPath,
 		},
 		fstate: fstate,
 		tstate: tstate,
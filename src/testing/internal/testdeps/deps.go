@@ -66,6 +66,14 @@ func (TestDeps) ImportPath() string {
 	return ImportPath
 }
 
+// ModulePath is the path of the module containing the package under test,
+// set by the generated main function.
+var ModulePath string
+
+func (TestDeps) ModulePath() string {
+	return ModulePath
+}
+
 // testLog implements testlog.Interface, logging actions by package os.
 type testLog struct {
 	mu  sync.Mutex
@@ -0,0 +1,346 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package synctest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// NetPipe creates an in-memory, full duplex network connection.
+// Data written to one end of the connection may be read from the other.
+//
+// Unlike [net.Pipe], the connection is not synchronous.
+// Writes are made to an unbounded buffer and return immediately.
+//
+// When NetPipe is called from within a bubble, the connection is
+// associated with the bubble, and a goroutine blocked reading from
+// either end of the connection is durably blocked.
+// The connection must not be used from outside the bubble.
+func NetPipe() (net.Conn, net.Conn) {
+	c1, c2 := newNetPipe(netAddr("pipe"), netAddr("pipe"))
+	return c1, c2
+}
+
+// A NetListener is an in-memory [net.Listener].
+// Connections to the listener are made with [NetListener.DialContext].
+//
+// When a NetListener is created from within a bubble, the listener and
+// the connections it creates are associated with the bubble,
+// and a goroutine blocked in Accept is durably blocked.
+// The listener must not be used from outside the bubble.
+type NetListener struct {
+	// setc and unsetc act as a lock.
+	// When the listener is unlocked, exactly one channel contains a value:
+	// setc if a connection is queued or the listener is closed,
+	// and unsetc otherwise.
+	setc, unsetc chan struct{}
+	queue        []net.Conn
+	closed       bool
+}
+
+// NetListen returns a new in-memory listener.
+func NetListen() *NetListener {
+	li := &NetListener{
+		setc:   make(chan struct{}, 1),
+		unsetc: make(chan struct{}, 1),
+	}
+	li.unsetc <- struct{}{}
+	return li
+}
+
+func (li *NetListener) lock() {
+	select {
+	case <-li.setc:
+	case <-li.unsetc:
+	}
+}
+
+func (li *NetListener) unlock() {
+	if li.closed || len(li.queue) > 0 {
+		li.setc <- struct{}{}
+	} else {
+		li.unsetc <- struct{}{}
+	}
+}
+
+// Accept waits for and returns the next connection to the listener.
+func (li *NetListener) Accept() (net.Conn, error) {
+	<-li.setc
+	defer li.unlock()
+	if li.closed {
+		return nil, net.ErrClosed
+	}
+	c := li.queue[0]
+	li.queue = li.queue[1:]
+	return c, nil
+}
+
+// Close closes the listener.
+// Any blocked Accept operations are unblocked and return errors,
+// and future calls to DialContext fail.
+// Connections which have been queued but not accepted are closed.
+func (li *NetListener) Close() error {
+	li.lock()
+	defer li.unlock()
+	li.closed = true
+	for _, c := range li.queue {
+		c.Close()
+	}
+	li.queue = nil
+	return nil
+}
+
+// Addr returns the listener's network address.
+func (li *NetListener) Addr() net.Addr {
+	return netAddr("listener")
+}
+
+// DialContext creates a new connection to the listener.
+// The network and address are ignored.
+//
+// DialContext does not wait for the connection to be accepted.
+// Its signature matches the DialContext field of [net/http.Transport].
+func (li *NetListener) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Addr: li.Addr(), Err: err}
+	}
+	li.lock()
+	defer li.unlock()
+	if li.closed {
+		return nil, &net.OpError{Op: "dial", Net: network, Addr: li.Addr(), Err: net.ErrClosed}
+	}
+	server, client := newNetPipe(li.Addr(), netAddr("client"))
+	li.queue = append(li.queue, server)
+	return client, nil
+}
+
+// netAddr is the address of an in-memory connection.
+type netAddr string
+
+func (netAddr) Network() string  { return "synctest" }
+func (a netAddr) String() string { return string(a) }
+
+func newNetPipe(addr1, addr2 net.Addr) (*netConn, *netConn) {
+	h1 := newNetConnHalf(addr1)
+	h2 := newNetConnHalf(addr2)
+	return &netConn{loc: h1, rem: h2}, &netConn{loc: h2, rem: h1}
+}
+
+// A netConn is one endpoint of an in-memory connection.
+type netConn struct {
+	// local and remote connection halves.
+	// Each half contains a buffer.
+	// Reads pull from the local buffer, and writes push to the remote buffer.
+	loc, rem *netConnHalf
+}
+
+// Read reads data from the connection.
+func (c *netConn) Read(b []byte) (n int, err error) {
+	return c.loc.read(b)
+}
+
+// Write writes data to the connection.
+func (c *netConn) Write(b []byte) (n int, err error) {
+	return c.rem.write(b)
+}
+
+// Close closes the connection.
+func (c *netConn) Close() error {
+	// Local half of the conn is now closed.
+	c.loc.lock()
+	c.loc.writeErr = net.ErrClosed
+	c.loc.readErr = net.ErrClosed
+	c.loc.buf.Reset()
+	c.loc.unlock()
+	// Remote half of the connection reads EOF after reading any remaining data.
+	c.rem.lock()
+	if c.rem.readErr == nil {
+		c.rem.readErr = io.EOF
+	}
+	c.rem.unlock()
+	return nil
+}
+
+// LocalAddr returns the local network address.
+func (c *netConn) LocalAddr() net.Addr {
+	return c.loc.addr
+}
+
+// RemoteAddr returns the remote network address.
+func (c *netConn) RemoteAddr() net.Addr {
+	return c.rem.addr
+}
+
+// SetDeadline sets the read and write deadlines for the connection.
+func (c *netConn) SetDeadline(t time.Time) error {
+	c.SetReadDeadline(t)
+	c.SetWriteDeadline(t)
+	return nil
+}
+
+// SetReadDeadline sets the read deadline for the connection.
+func (c *netConn) SetReadDeadline(t time.Time) error {
+	c.loc.rctx.setDeadline(t)
+	return nil
+}
+
+// SetWriteDeadline sets the write deadline for the connection.
+func (c *netConn) SetWriteDeadline(t time.Time) error {
+	c.rem.wctx.setDeadline(t)
+	return nil
+}
+
+// netConnHalf is one data flow in an in-memory connection.
+// Each half contains a buffer. Writes to the half push to the buffer, and reads pull from it.
+type netConnHalf struct {
+	addr net.Addr
+
+	// Read and write timeouts.
+	rctx, wctx deadlineContext
+
+	// These two channels act as a lock,
+	// and allow waiting for readability.
+	// When the half is unlocked, exactly one channel contains a value.
+	// When the half is locked, both channels are empty.
+	lockr chan struct{} // readable
+	lockn chan struct{} // not readable
+
+	buf      bytes.Buffer
+	readErr  error // error returned by reads
+	writeErr error // error returned by writes
+}
+
+func newNetConnHalf(addr net.Addr) *netConnHalf {
+	h := &netConnHalf{
+		addr:  addr,
+		lockr: make(chan struct{}, 1),
+		lockn: make(chan struct{}, 1),
+	}
+	h.unlock()
+	return h
+}
+
+// lock locks h.
+func (h *netConnHalf) lock() {
+	select {
+	case <-h.lockr: // readable
+	case <-h.lockn: // not readable
+	}
+}
+
+// unlock unlocks h.
+func (h *netConnHalf) unlock() {
+	if h.readErr != nil || h.buf.Len() > 0 {
+		h.lockr <- struct{}{}
+	} else {
+		h.lockn <- struct{}{}
+	}
+}
+
+// waitAndLockForRead waits until h is readable and locks it.
+func (h *netConnHalf) waitAndLockForRead() error {
+	// First a non-blocking select to see if we can make immediate progress.
+	// This permits reading available data after the deadline has expired.
+	select {
+	case <-h.lockr:
+		return nil
+	default:
+	}
+	ctx := h.rctx.context()
+	select {
+	case <-h.lockr:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+func (h *netConnHalf) read(b []byte) (n int, err error) {
+	if err := h.waitAndLockForRead(); err != nil {
+		return 0, err
+	}
+	defer h.unlock()
+	if h.buf.Len() == 0 && h.readErr != nil {
+		return 0, h.readErr
+	}
+	return h.buf.Read(b)
+}
+
+func (h *netConnHalf) write(b []byte) (n int, err error) {
+	// Writes never block, but fail once the write deadline has expired.
+	if ctx := h.wctx.context(); ctx.Err() != nil {
+		return 0, context.Cause(ctx)
+	}
+	h.lock()
+	defer h.unlock()
+	if h.writeErr != nil {
+		return 0, h.writeErr
+	}
+	return h.buf.Write(b)
+}
+
+// deadlineContext converts a changeable deadline (as in net.Conn.SetDeadline) into a Context.
+type deadlineContext struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	timer  *time.Timer
+}
+
+// context returns a Context which expires when the deadline does.
+func (t *deadlineContext) context() context.Context {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ctx == nil {
+		t.ctx, t.cancel = context.WithCancelCause(context.Background())
+	}
+	return t.ctx
+}
+
+// setDeadline sets the current deadline.
+func (t *deadlineContext) setDeadline(deadline time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// If t.ctx is non-nil and t.cancel is nil, then t.ctx was canceled
+	// and we should create a new one.
+	if t.ctx == nil || t.cancel == nil {
+		t.ctx, t.cancel = context.WithCancelCause(context.Background())
+	}
+	// Stop any existing deadline from expiring.
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	if deadline.IsZero() {
+		// No deadline.
+		return
+	}
+	now := time.Now()
+	if !deadline.After(now) {
+		// Deadline has already expired.
+		t.cancel(os.ErrDeadlineExceeded)
+		t.cancel = nil
+		return
+	}
+	if t.timer != nil {
+		// Reuse existing deadline timer.
+		t.timer.Reset(deadline.Sub(now))
+		return
+	}
+	// Create a new timer to cancel the context at the deadline.
+	t.timer = time.AfterFunc(deadline.Sub(now), func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.cancel != nil {
+			t.cancel(os.ErrDeadlineExceeded)
+			t.cancel = nil
+		}
+	})
+}
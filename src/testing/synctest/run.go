@@ -0,0 +1,25 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.synctest
+
+package synctest
+
+import "internal/synctest"
+
+// Run executes f in a new goroutine.
+//
+// The new goroutine and any goroutines transitively started by it form
+// an isolated "bubble".
+// Run waits for all goroutines in the bubble to exit before returning.
+//
+// If every goroutine is blocked and there are no timers scheduled,
+// Run panics.
+//
+// Run is only available when using GOEXPERIMENT=synctest,
+// and is not subject to the Go 1 compatibility promise.
+// New code should use [Test], which integrates with the testing package.
+func Run(f func()) {
+	synctest.Run(f)
+}
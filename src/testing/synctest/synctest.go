@@ -2,42 +2,82 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build goexperiment.synctest
-
 // Package synctest provides support for testing concurrent code.
 //
-// This package only exists when using Go compiled with GOEXPERIMENT=synctest.
-// It is experimental, and not subject to the Go 1 compatibility promise.
+// The [Test] function runs a function in an isolated "bubble".
+// Any goroutines started within the bubble are also part of the bubble.
+//
+// Within a bubble, the [time] package uses a fake clock.
+// Each bubble has its own clock.
+// The initial time is midnight UTC 2000-01-01.
+//
+// Time in a bubble only advances when every goroutine in the
+// bubble is durably blocked.
+// See [Wait] for the specific definition of durably blocked.
+//
+// For example, this test runs immediately rather than taking
+// two seconds:
+//
+//	func TestTime(t *testing.T) {
+//		synctest.Test(t, func(t *testing.T) {
+//			start := time.Now() // always midnight UTC 2000-01-01
+//			go func() {
+//				time.Sleep(1 * time.Second)
+//				t.Log(time.Since(start)) // always logs "1s"
+//			}()
+//			time.Sleep(2 * time.Second) // the goroutine above will run before this Sleep returns
+//			t.Log(time.Since(start))    // always logs "2s"
+//		})
+//	}
+//
+// Channels, time.Timers, and time.Tickers created within the bubble
+// are associated with it. Operating on a bubbled channel, timer, or ticker
+// from outside the bubble panics.
+//
+// Network connections are not durably blocking, since data may arrive
+// from outside the bubble at any time. [NetPipe] and [NetListener] provide
+// in-memory connections for use within a bubble which block durably.
 package synctest
 
 import (
 	"internal/synctest"
+	"testing"
+	_ "unsafe" // for go:linkname
 )
 
-// Run executes f in a new goroutine.
+// Test executes f in a new bubble.
 //
-// The new goroutine and any goroutines transitively started by it form
-// an isolated "bubble".
-// Run waits for all goroutines in the bubble to exit before returning.
+// Test waits for all goroutines in the bubble to exit before returning.
+// If the goroutines in the bubble become deadlocked, the test fails,
+// and the stacks of the blocked goroutines are printed.
 //
-// Goroutines in the bubble use a synthetic time implementation.
-// The initial time is midnight UTC 2000-01-01.
+// Test must not be called from within another bubble.
 //
-// Time advances when every goroutine in the bubble is blocked.
-// For example, a call to time.Sleep will block until all other
-// goroutines are blocked and return after the bubble's clock has
-// advanced. See [Wait] for the specific definition of blocked.
+// The [*testing.T] provided to f has the following properties:
 //
-// If every goroutine is blocked and there are no timers scheduled,
-// Run panics.
+//   - T.Cleanup functions run inside the bubble,
+//     immediately before Test returns.
+//   - T.Context returns a [context.Context] with a Done channel
+//     associated with the bubble.
+//   - T.Run and T.Parallel must not be called.
 //
-// Channels, time.Timers, and time.Tickers created within the bubble
-// are associated with it. Operating on a bubbled channel, timer, or ticker
-// from outside the bubble panics.
-func Run(f func()) {
-	synctest.Run(f)
+// If f fails, the test calling Test fails as well,
+// and Test calls t.FailNow after the bubble has exited.
+func Test(t *testing.T, f func(*testing.T)) {
+	var ok bool
+	synctest.Run(func() {
+		ok = testingSynctestTest(t, f)
+	})
+	if !ok {
+		// Fail the test outside the bubble,
+		// so test durations get set using real time.
+		t.FailNow()
+	}
 }
 
+//go:linkname testingSynctestTest
+func testingSynctestTest(t *testing.T, f func(*testing.T)) bool
+
 // Wait blocks until every goroutine within the current bubble,
 // other than the current goroutine, is durably blocked.
 // It panics if called from a non-bubbled goroutine,
@@ -58,6 +98,9 @@ func Run(f func()) {
 // connection, because it may be unblocked by data written from outside
 // the bubble or may be in the process of receiving data from a kernel
 // network buffer.
+// Connections created by [NetPipe] and [NetListener] within the bubble
+// are an exception: they are implemented entirely with bubbled channels,
+// so operations on them block durably.
 //
 // A goroutine is not durably blocked when blocked on a send or receive
 // on a channel that was not created within its bubble, because it may
@@ -0,0 +1,242 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package synctest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"internal/testenv"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestTestTime(t *testing.T) {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	synctest.Test(t, func(t *testing.T) {
+		if got := time.Now(); !got.Equal(start) {
+			t.Errorf("at start: time.Now = %v, want %v", got, start)
+		}
+		time.Sleep(1 * time.Second)
+		if got, want := time.Now(), start.Add(1*time.Second); !got.Equal(want) {
+			t.Errorf("after sleep: time.Now = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestTestContext(t *testing.T) {
+	done := false
+	synctest.Test(t, func(t *testing.T) {
+		ctx := t.Context()
+		go func() {
+			<-ctx.Done()
+			done = true
+		}()
+		t.Cleanup(func() {
+			// The context is canceled before Cleanup functions run.
+			// Wait for the goroutine waiting on it to exit.
+			synctest.Wait()
+			if !done {
+				t.Errorf("goroutine waiting on t.Context().Done() has not exited")
+			}
+		})
+	})
+	if !done {
+		t.Errorf("goroutine waiting on t.Context().Done() did not run in bubble")
+	}
+}
+
+func TestTestCleanupInBubble(t *testing.T) {
+	var cleanupTime time.Time
+	synctest.Test(t, func(t *testing.T) {
+		t.Cleanup(func() {
+			cleanupTime = time.Now()
+		})
+		time.Sleep(1 * time.Hour)
+	})
+	if want := time.Date(2000, 1, 1, 1, 0, 0, 0, time.UTC); !cleanupTime.Equal(want) {
+		t.Errorf("time.Now in Cleanup = %v, want %v", cleanupTime, want)
+	}
+}
+
+func TestTestHelper(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		switch os.Getenv("SYNCTEST_HELPER") {
+		case "fail":
+			synctest.Test(t, func(t *testing.T) {
+				t.Log("log from bubble")
+				t.Error("error from bubble")
+			})
+			t.Error("not reached")
+		case "skip":
+			synctest.Test(t, func(t *testing.T) {
+				t.Skip("skip from bubble")
+			})
+		case "parallel":
+			synctest.Test(t, func(t *testing.T) {
+				t.Parallel()
+			})
+		case "deadlock":
+			synctest.Test(t, func(t *testing.T) {
+				go blockForever()
+				select {}
+			})
+		}
+		return
+	}
+
+	for _, test := range []struct {
+		name string
+		want string
+	}{{
+		name: "fail",
+		want: `(?s)^--- FAIL: TestTestHelper \([^)]+\)
+    synctest_test.go:\d+: log from bubble
+    synctest_test.go:\d+: error from bubble
+FAIL
+`,
+	}, {
+		name: "skip",
+		want: `(?s)synctest_test.go:\d+: skip from bubble
+--- SKIP: TestTestHelper \([^)]+\)
+PASS
+`,
+	}, {
+		name: "parallel",
+		want: `t.Parallel called inside synctest bubble`,
+	}, {
+		name: "deadlock",
+		want: `(?s)panic: deadlock: all goroutines in bubble are blocked.*testing/synctest_test.blockForever`,
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			// Use -test.v=true so that the skipped test is reported.
+			cmd := testenv.Command(t, testenv.Executable(t), "-test.run=^TestTestHelper$", "-test.v="+fmt.Sprint(test.name == "skip"))
+			cmd = testenv.CleanCmdEnv(cmd)
+			cmd.Env = append(cmd.Env, "GO_WANT_HELPER_PROCESS=1", "SYNCTEST_HELPER="+test.name)
+			out, _ := cmd.CombinedOutput()
+			if !regexp.MustCompile(test.want).Match(out) {
+				t.Errorf("got output:\n\n%s\nwant matching:\n\n%s", out, test.want)
+			}
+		})
+	}
+}
+
+func blockForever() {
+	select {}
+}
+
+func TestNetPipe(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c1, c2 := synctest.NetPipe()
+		defer c1.Close()
+		defer c2.Close()
+
+		var got []byte
+		go func() {
+			got, _ = io.ReadAll(c2)
+		}()
+		// The reading goroutine is durably blocked.
+		synctest.Wait()
+		if got != nil {
+			t.Fatalf("read %q before write", got)
+		}
+
+		const want = "hello"
+		if _, err := c1.Write([]byte(want)); err != nil {
+			t.Fatal(err)
+		}
+		c1.Close()
+		synctest.Wait()
+		if string(got) != want {
+			t.Errorf("read %q, want %q", got, want)
+		}
+	})
+}
+
+func TestNetPipeReadDeadline(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c1, c2 := synctest.NetPipe()
+		defer c1.Close()
+		defer c2.Close()
+
+		start := time.Now()
+		c1.SetReadDeadline(start.Add(1 * time.Second))
+		_, err := c1.Read(make([]byte, 1))
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("Read after deadline: %v, want ErrDeadlineExceeded", err)
+		}
+		if got, want := time.Since(start), 1*time.Second; got != want {
+			t.Errorf("Read returned after %v, want %v", got, want)
+		}
+	})
+}
+
+func TestNetPipeCloseUnblocksRead(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c1, c2 := synctest.NetPipe()
+		defer c2.Close()
+
+		var err error
+		go func() {
+			_, err = c1.Read(make([]byte, 1))
+		}()
+		synctest.Wait()
+		c1.Close()
+		synctest.Wait()
+		if !errors.Is(err, net.ErrClosed) {
+			t.Errorf("Read after Close: %v, want net.ErrClosed", err)
+		}
+		if _, err := c2.Read(make([]byte, 1)); err != io.EOF {
+			t.Errorf("Read from peer after Close: %v, want io.EOF", err)
+		}
+	})
+}
+
+func TestNetListener(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		li := synctest.NetListen()
+		defer li.Close()
+
+		go func() {
+			for {
+				c, err := li.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer c.Close()
+					io.Copy(c, c)
+				}()
+			}
+		}()
+
+		c, err := li.DialContext(context.Background(), "tcp", "ignored")
+		if err != nil {
+			t.Fatal(err)
+		}
+		const want = "echo"
+		if _, err := c.Write([]byte(want)); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, len(want))
+		if _, err := io.ReadFull(c, got); err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("read %q, want %q", got, want)
+		}
+		c.Close()
+
+		li.Close()
+		if _, err := li.DialContext(context.Background(), "tcp", "ignored"); err == nil {
+			t.Errorf("DialContext after Close succeeded, want error")
+		}
+	})
+}
@@ -393,6 +393,7 @@ import (
 	"time"
 	"unicode"
 	"unicode/utf8"
+	_ "unsafe" // for go:linkname
 )
 
 var initRan bool
@@ -422,6 +423,7 @@ func Init() {
 	// this flag lets "go test" tell the binary to write the files in the directory where
 	// the "go test" command is run.
 	outputDir = flag.String("test.outputdir", "", "write profiles to `dir`")
+	artifacts = flag.Bool("test.artifacts", false, "store test artifacts in test.outputdir")
 	// Report as tests are run; default is silent for success.
 	flag.Var(&chatty, "test.v", "verbose: print additional output")
 	count = flag.Uint("test.count", 1, "run tests and benchmarks `n` times")
@@ -440,10 +442,12 @@ func Init() {
 	panicOnExit0 = flag.Bool("test.paniconexit0", false, "panic on call to os.Exit(0)")
 	traceFile = flag.String("test.trace", "", "write an execution trace to `file`")
 	timeout = flag.Duration("test.timeout", 0, "panic test binary after duration `d` (default 0, timeout disabled)")
+	testTimeout = flag.Duration("test.testtimeout", 0, "fail each top-level test that runs longer than duration `d` (default 0, timeout disabled)")
 	cpuListStr = flag.String("test.cpu", "", "comma-separated `list` of cpu counts to run each test with")
 	parallel = flag.Int("test.parallel", runtime.GOMAXPROCS(0), "run at most `n` tests in parallel")
 	testlog = flag.String("test.testlogfile", "", "write test action log to `file` (for use only by cmd/go)")
 	shuffle = flag.String("test.shuffle", "off", "randomize the execution order of tests and benchmarks")
+	shard = flag.String("test.shard", "", "run only the tests, examples, benchmarks, and fuzz tests in shard `i/n`")
 	fullPath = flag.Bool("test.fullpath", false, "show full file names in error messages")
 
 	initBenchmarkFlags()
@@ -455,6 +459,7 @@ var (
 	short                *bool
 	failFast             *bool
 	outputDir            *string
+	artifacts            *bool
 	chatty               chattyFlag
 	count                *uint
 	coverProfile         *string
@@ -472,9 +477,11 @@ var (
 	panicOnExit0         *bool
 	traceFile            *string
 	timeout              *time.Duration
+	testTimeout          *time.Duration
 	cpuListStr           *string
 	parallel             *int
 	shuffle              *string
+	shard                *string
 	testlog              *string
 	fullPath             *bool
 
@@ -482,6 +489,7 @@ var (
 
 	cpuList     []int
 	testlogFile *os.File
+	artifactDir string // absolute path of the -test.artifacts directory
 
 	numFailed atomic.Uint32 // number of test failures
 
@@ -616,16 +624,19 @@ type common struct {
 	cleanupStarted atomic.Bool    // Registered cleanup callbacks have started to execute
 	runner         string         // Function name of tRunner running the test.
 	isParallel     bool           // Whether the test is parallel.
-
-	parent   *common
-	level    int               // Nesting depth of test or benchmark.
-	creator  []uintptr         // If level > 0, the stack trace at the point where the parent called t.Run.
-	name     string            // Name of test or benchmark.
-	start    highPrecisionTime // Time test or benchmark started
-	duration time.Duration
-	barrier  chan bool // To signal parallel subtests they may start. Nil when T.Parallel is not present (B) or not usable (when fuzzing).
-	signal   chan bool // To signal a test is done.
-	sub      []*T      // Queue of subtests to be run in parallel.
+	isSynctest     bool           // Whether the test is the bubbled T created by synctest.Test.
+
+	parent     *common
+	level      int               // Nesting depth of test or benchmark.
+	creator    []uintptr         // If level > 0, the stack trace at the point where the parent called t.Run.
+	modulePath string            // Path of the module containing the test, if known.
+	importPath string            // Import path of the package containing the test, if known.
+	name       string            // Name of test or benchmark.
+	start      highPrecisionTime // Time test or benchmark started
+	duration   time.Duration
+	barrier    chan bool // To signal parallel subtests they may start. Nil when T.Parallel is not present (B) or not usable (when fuzzing).
+	signal     chan bool // To signal a test is done.
+	sub        []*T      // Queue of subtests to be run in parallel.
 
 	lastRaceErrors  atomic.Int64 // Max value of race.Errors seen during the test or its subtests.
 	raceErrorLogged atomic.Bool
@@ -635,6 +646,10 @@ type common struct {
 	tempDirErr error
 	tempDirSeq int32
 
+	artifactDirOnce sync.Once
+	artifactDir     string
+	artifactDirErr  error
+
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 }
@@ -848,6 +863,21 @@ func (c *common) flushToParent(testName, format string, args ...any) {
 	}
 }
 
+// flushSynctestToParent moves the output of the T created by synctest.Test
+// to its parent. The synctest T is an implementation detail of synctest.Test
+// sharing its parent's name, so it has no result line of its own.
+func (c *common) flushSynctestToParent() {
+	p := c.parent
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p.output = append(p.output, c.output...)
+	c.output = c.output[:0]
+}
+
 type indenter struct {
 	c *common
 }
@@ -883,6 +913,8 @@ func fmtDuration(d time.Duration) string {
 
 // TB is the interface common to T, B, and F.
 type TB interface {
+	ArtifactDir() string
+	Attr(key, value string)
 	Cleanup(func())
 	Error(args ...any)
 	Errorf(format string, args ...any)
@@ -926,6 +958,13 @@ type T struct {
 	common
 	denyParallel bool
 	tstate       *testState // For running tests and subtests.
+	goid         uint64     // ID of the goroutine running the test function, or 0 if unknown.
+
+	timeoutMu       sync.Mutex    // guards this group of fields; held while reporting a timeout
+	timeout         time.Duration // per-test timeout set by -test.testtimeout or SetTimeout
+	timeoutTimer    *time.Timer   // fires when the test times out; nil if no timeout is pending
+	timeoutDeadline time.Time     // when timeoutTimer fires
+	timeoutStopped  bool          // test function has returned; don't start new timers
 }
 
 func (c *common) private() {}
@@ -1195,6 +1234,14 @@ func (c *common) Cleanup(f func()) {
 // if the directory creation fails, TempDir terminates the test by calling Fatal.
 func (c *common) TempDir() string {
 	c.checkFuzzFn("TempDir")
+	dir, err := c.makeTempDir()
+	if err != nil {
+		c.Fatalf("TempDir: %v", err)
+	}
+	return dir
+}
+
+func (c *common) makeTempDir() (string, error) {
 	// Use a single parent directory for all the temporary directories
 	// created by a test, each numbered sequentially.
 	c.tempDirMu.Lock()
@@ -1205,33 +1252,23 @@ func (c *common) TempDir() string {
 		_, err := os.Stat(c.tempDir)
 		nonExistent = os.IsNotExist(err)
 		if err != nil && !nonExistent {
-			c.Fatalf("TempDir: %v", err)
+			c.tempDirMu.Unlock()
+			return "", err
 		}
 	}
 
 	if nonExistent {
 		c.Helper()
 
+		pattern := c.Name()
+		// Limit length of file names on disk.
+		// Invalid runes from slicing are dropped by removeSymbolsExcept.
+		pattern = pattern[:min(len(pattern), 64)]
+
 		// Drop unusual characters (such as path separators or
 		// characters interacting with globs) from the directory name to
 		// avoid surprising os.MkdirTemp behavior.
-		mapper := func(r rune) rune {
-			if r < utf8.RuneSelf {
-				const allowed = "!#$%&()+,-.=@^_{}~ "
-				if '0' <= r && r <= '9' ||
-					'a' <= r && r <= 'z' ||
-					'A' <= r && r <= 'Z' {
-					return r
-				}
-				if strings.ContainsRune(allowed, r) {
-					return r
-				}
-			} else if unicode.IsLetter(r) || unicode.IsNumber(r) {
-				return r
-			}
-			return -1
-		}
-		pattern := strings.Map(mapper, c.Name())
+		pattern = removeSymbolsExcept(pattern, "!#$%&()+,-.=@^_{}~ ")
 		c.tempDir, c.tempDirErr = os.MkdirTemp("", pattern)
 		if c.tempDirErr == nil {
 			c.Cleanup(func() {
@@ -1249,14 +1286,125 @@ func (c *common) TempDir() string {
 	c.tempDirMu.Unlock()
 
 	if c.tempDirErr != nil {
-		c.Fatalf("TempDir: %v", c.tempDirErr)
+		return "", c.tempDirErr
 	}
 
 	dir := fmt.Sprintf("%s%c%03d", c.tempDir, os.PathSeparator, seq)
 	if err := os.Mkdir(dir, 0777); err != nil {
-		c.Fatalf("TempDir: %v", err)
+		return "", err
 	}
-	return dir
+	return dir, nil
+}
+
+// removeSymbolsExcept returns s with all characters removed
+// except letters, digits, and the ASCII characters in allowed.
+func removeSymbolsExcept(s, allowed string) string {
+	mapper := func(r rune) rune {
+		if r < utf8.RuneSelf {
+			if '0' <= r && r <= '9' ||
+				'a' <= r && r <= 'z' ||
+				'A' <= r && r <= 'Z' {
+				return r
+			}
+			if strings.ContainsRune(allowed, r) {
+				return r
+			}
+		} else if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return r
+		}
+		return -1
+	}
+	return strings.Map(mapper, s)
+}
+
+// ArtifactDir returns a directory in which the test should store output files,
+// such as logs or images, for later inspection.
+//
+// When the -artifacts flag is provided, the directory is located under the
+// output directory and is kept after the test completes, and its path is
+// reported in the test output (and as an "artifacts" event by test2json).
+// Otherwise, ArtifactDir returns a temporary directory that is removed
+// after the test completes.
+//
+// Each test or subtest has its own artifact directory.
+// Repeated calls to ArtifactDir in the same test or subtest return the same directory.
+// If the directory cannot be created, ArtifactDir terminates the test by calling Fatal.
+func (c *common) ArtifactDir() string {
+	c.checkFuzzFn("ArtifactDir")
+	c.artifactDirOnce.Do(func() {
+		c.artifactDir, c.artifactDirErr = c.makeArtifactDir()
+	})
+	if c.artifactDirErr != nil {
+		c.Fatalf("ArtifactDir: %v", c.artifactDirErr)
+	}
+	return c.artifactDir
+}
+
+// makeArtifactDir creates the artifact directory for a test.
+// With -test.artifacts, the directory is
+//
+//	<output dir>/_artifacts/<package>/<test name>/<random>
+//
+// where <package> is the import path of the package with
+// the module path removed.
+func (c *common) makeArtifactDir() (string, error) {
+	if !*artifacts {
+		return c.makeTempDir()
+	}
+	base := filepath.Join(artifactDir, c.relativeArtifactBase())
+	if err := os.MkdirAll(base, 0777); err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp(base, "")
+	if err != nil {
+		return "", err
+	}
+	if c.chatty != nil {
+		c.chatty.Updatef(c.name, "=== ARTIFACTS %s %s\n", c.name, dir)
+	}
+	return dir, nil
+}
+
+// relativeArtifactBase returns the directory, relative to the
+// -test.artifacts directory, under which the test's artifact
+// directory is created.
+func (c *common) relativeArtifactBase() string {
+	// Keep directory names short. If the test name is too long,
+	// truncate it and replace its end with a hash of the full name.
+	const maxNameSize = 64
+	name := strings.ReplaceAll(c.name, "/", "__")
+	if len(name) > maxNameSize {
+		h := fmt.Sprintf("%x", fnv64(name))
+		name = name[:maxNameSize-len(h)] + h
+	}
+
+	pkg := strings.TrimPrefix(c.importPath, c.modulePath)
+	pkg = strings.TrimPrefix(pkg, "/")
+	base := name
+	if pkg != "" {
+		// The import path is slash-separated; filepath.Localize
+		// converts it to a local path below.
+		base = pkg + "/" + name
+	}
+	base = removeSymbolsExcept(base, "!#$%&()+,-.=@^_{}~ /")
+	base, err := filepath.Localize(base)
+	if err != nil {
+		// The name can't be safely used as a local path.
+		// Put the directory directly under _artifacts.
+		return ""
+	}
+	return base
+}
+
+// fnv64 returns the 64-bit FNV-1a hash of s.
+// It is used instead of hash/fnv to avoid the dependency.
+func fnv64(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
 }
 
 // removeAll is like os.RemoveAll, but retries Windows "Access is denied."
@@ -1366,6 +1514,33 @@ func (c *common) Context() context.Context {
 	return c.ctx
 }
 
+// Attr emits a test attribute associated with this test.
+//
+// The key must not contain whitespace.
+// The value must not contain newlines or carriage returns.
+//
+// The meaning of different attribute keys is left up to continuous
+// integration systems and test frameworks. For example, an attribute
+// might record the issue tracked by a test, or mark a test as flaky.
+//
+// Attributes are reported in verbose test output, and by test2json
+// as "attr" events. They are emitted immediately in the test log,
+// but are intended to be treated as unordered.
+func (c *common) Attr(key, value string) {
+	if key == "" || strings.ContainsFunc(key, unicode.IsSpace) {
+		c.Errorf("invalid attribute key %q: must be non-empty and not contain whitespace", key)
+		return
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		c.Errorf("invalid attribute value %q: must not contain newlines", value)
+		return
+	}
+	if c.chatty == nil {
+		return
+	}
+	c.chatty.Updatef(c.name, "=== ATTR  %s %s %s\n", c.name, key, value)
+}
+
 // panicHandling controls the panic handling used by runCleanup.
 type panicHandling int
 
@@ -1512,6 +1687,11 @@ func (t *T) Parallel() {
 	if t.denyParallel {
 		panic(parallelConflict)
 	}
+	for c := &t.common; c != nil; c = c.parent {
+		if c.isSynctest {
+			panic("testing: t.Parallel called inside synctest bubble")
+		}
+	}
 	t.isParallel = true
 	if t.parent.barrier == nil {
 		// T.Parallel has no effect when fuzzing.
@@ -1545,6 +1725,10 @@ func (t *T) Parallel() {
 	}
 	running.Delete(t.name)
 
+	// Like the test duration, the test's timeout
+	// does not include the time spent paused.
+	remaining, hasTimeout := t.pauseTimeout()
+
 	t.signal <- true   // Release calling test.
 	<-t.parent.barrier // Wait for the parent test to complete.
 	t.tstate.waitParallel()
@@ -1554,6 +1738,11 @@ func (t *T) Parallel() {
 	}
 	running.Store(t.name, highPrecisionTimeNow())
 	t.start = highPrecisionTimeNow()
+	if hasTimeout {
+		t.timeoutMu.Lock()
+		t.startTimeoutLocked(remaining)
+		t.timeoutMu.Unlock()
+	}
 
 	// Reset the local race counter to ignore any races that happened while this
 	// goroutine was blocked, such as in the parent test or in other parallel
@@ -1622,7 +1811,8 @@ func tRunner(t *T, fn func(t *T)) {
 		t.checkRaces()
 
 		// TODO(#61034): This is the wrong place for this check.
-		if t.Failed() {
+		// A synctest T's failure is counted by its parent.
+		if t.Failed() && !t.isSynctest {
 			numFailed.Add(1)
 		}
 
@@ -1682,7 +1872,11 @@ func tRunner(t *T, fn func(t *T)) {
 			if err != nil {
 				panic(err)
 			}
-			running.Delete(t.name)
+			if !t.isSynctest {
+				// A synctest T shares its parent's name,
+				// and the parent is still running.
+				running.Delete(t.name)
+			}
 			t.signal <- signal
 		}()
 
@@ -1693,6 +1887,10 @@ func tRunner(t *T, fn func(t *T)) {
 			}
 			// Flush the output log up to the root before dying.
 			for root := &t.common; root.parent != nil; root = root.parent {
+				if root.isSynctest {
+					root.flushSynctestToParent()
+					continue
+				}
 				root.mu.Lock()
 				root.duration += highPrecisionTimeSince(root.start)
 				d := root.duration
@@ -1754,6 +1952,8 @@ func tRunner(t *T, fn func(t *T)) {
 		}
 	}()
 	defer func() {
+		// The timeout does not cover cleanup functions.
+		t.stopTimeout()
 		if len(t.sub) == 0 {
 			t.runCleanup(normalPanic)
 		}
@@ -1761,6 +1961,12 @@ func tRunner(t *T, fn func(t *T)) {
 
 	t.start = highPrecisionTimeNow()
 	t.resetRaces()
+	if !t.tstate.isFuzzing {
+		t.goid = goroutineID()
+		if t.level == 1 && *testTimeout > 0 {
+			t.SetTimeout(*testTimeout)
+		}
+	}
 	fn(t)
 
 	// code beyond here will not be executed when FailNow is invoked
@@ -1791,20 +1997,29 @@ func (t *T) Run(name string, f func(t *T)) bool {
 	var pc [maxStackLen]uintptr
 	n := runtime.Callers(2, pc[:])
 
-	// There's no reason to inherit this context from parent. The user's code can't observe
-	// the difference between the background context and the one from the parent test.
-	ctx, cancelCtx := context.WithCancel(context.Background())
+	// The parent's context is normally canceled only after all its subtests
+	// have completed, so the user's code can't observe the difference between
+	// the background context and the one from the parent test, except that
+	// a parent that times out also cancels the contexts of its subtests.
+	parentCtx := t.ctx
+	if parentCtx == nil {
+		// Tests run by fuzz targets have no context.
+		parentCtx = context.Background()
+	}
+	ctx, cancelCtx := context.WithCancel(parentCtx)
 	t = &T{
 		common: common{
-			barrier:   make(chan bool),
-			signal:    make(chan bool, 1),
-			name:      testName,
-			parent:    &t.common,
-			level:     t.level + 1,
-			creator:   pc[:n],
-			chatty:    t.chatty,
-			ctx:       ctx,
-			cancelCtx: cancelCtx,
+			barrier:    make(chan bool),
+			signal:     make(chan bool, 1),
+			name:       testName,
+			parent:     &t.common,
+			level:      t.level + 1,
+			creator:    pc[:n],
+			modulePath: t.modulePath,
+			importPath: t.importPath,
+			chatty:     t.chatty,
+			ctx:        ctx,
+			cancelCtx:  cancelCtx,
 		},
 		tstate: t.tstate,
 	}
@@ -1840,15 +2055,217 @@ func (t *T) Run(name string, f func(t *T)) bool {
 	return !t.failed
 }
 
+// testingSynctestTest runs f with a new T within the current synctest bubble.
+// It is called by synctest.Test from within the bubble, so the new T's
+// Context and Cleanup functions are associated with the bubble.
+// It reports whether f succeeded.
+//
+//go:linkname testingSynctestTest testing/synctest.testingSynctestTest
+func testingSynctestTest(t *T, f func(*T)) (ok bool) {
+	if t.cleanupStarted.Load() {
+		panic("testing: synctest.Test called during t.Cleanup")
+	}
+
+	var pc [maxStackLen]uintptr
+	n := runtime.Callers(2, pc[:])
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	t2 := &T{
+		common: common{
+			barrier:    make(chan bool),
+			signal:     make(chan bool, 1),
+			name:       t.name,
+			parent:     &t.common,
+			level:      t.level + 1,
+			creator:    pc[:n],
+			modulePath: t.modulePath,
+			importPath: t.importPath,
+			chatty:     t.chatty,
+			ctx:        ctx,
+			cancelCtx:  cancelCtx,
+			isSynctest: true,
+		},
+		tstate: t.tstate,
+	}
+	t2.w = indenter{&t2.common}
+
+	go tRunner(t2, f)
+	if !<-t2.signal {
+		// At this point, it is likely that FailNow was called on one of the
+		// parent tests by one of the subtests. Continue aborting up the chain.
+		runtime.Goexit()
+	}
+	if t2.Skipped() && !t2.Failed() {
+		t.mu.Lock()
+		t.skipped = true
+		t.mu.Unlock()
+	}
+	return !t2.Failed()
+}
+
 // Deadline reports the time at which the test binary will have
-// exceeded the timeout specified by the -timeout flag.
+// exceeded the timeout specified by the -timeout flag or, if it is earlier,
+// the time at which the test will exceed its own timeout, set by the
+// -testtimeout flag or [T.SetTimeout].
 //
-// The ok result is false if the -timeout flag indicates “no timeout” (0).
+// The ok result is false if neither timeout is set.
 func (t *T) Deadline() (deadline time.Time, ok bool) {
 	deadline = t.tstate.deadline
+	t.timeoutMu.Lock()
+	if !t.timeoutDeadline.IsZero() && (deadline.IsZero() || t.timeoutDeadline.Before(deadline)) {
+		deadline = t.timeoutDeadline
+	}
+	t.timeoutMu.Unlock()
 	return deadline, !deadline.IsZero()
 }
 
+// SetTimeout sets a timeout of d for the test, measured from the time
+// SetTimeout is called. It replaces the timeout set for a top-level test
+// by the -testtimeout flag and any earlier call to SetTimeout.
+// If d <= 0, the test has no timeout.
+//
+// The timeout covers the test function and the subtests it runs, excluding
+// the time the test spends paused in [T.Parallel], the parallel subtests
+// that run after the test function returns, and the functions registered
+// with [T.Cleanup].
+//
+// When the test exceeds its timeout, it is marked as failed, the stack
+// traces of its goroutines are logged, and the context returned by
+// [T.Context] is canceled. The test function is not stopped: if it returns
+// once its context is canceled, the remaining tests continue to run.
+// Otherwise the test binary keeps waiting for it until the -timeout flag
+// stops the whole binary.
+func (t *T) SetTimeout(d time.Duration) {
+	t.timeoutMu.Lock()
+	defer t.timeoutMu.Unlock()
+	if d <= 0 {
+		t.stopTimeoutLocked()
+		t.timeout = 0
+		return
+	}
+	t.timeout = d
+	t.startTimeoutLocked(d)
+}
+
+// startTimeoutLocked starts a timer to report that the test
+// timed out after d, replacing any earlier timer.
+// t.timeoutMu must be held.
+func (t *T) startTimeoutLocked(d time.Duration) {
+	t.stopTimeoutLocked()
+	if t.timeoutStopped {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		t.timeoutMu.Lock()
+		defer t.timeoutMu.Unlock()
+		if t.timeoutTimer != timer {
+			// The timer was stopped or replaced while it fired.
+			return
+		}
+		t.timeoutTimer = nil
+		t.Errorf("test timed out after %v\n%s", t.timeout, testStacks(t.goid))
+		if t.cancelCtx != nil {
+			t.cancelCtx()
+		}
+	})
+	t.timeoutTimer = timer
+	t.timeoutDeadline = time.Now().Add(d)
+}
+
+// stopTimeoutLocked stops the pending timeout timer, if any.
+// t.timeoutMu must be held.
+func (t *T) stopTimeoutLocked() {
+	if t.timeoutTimer != nil {
+		t.timeoutTimer.Stop()
+		t.timeoutTimer = nil
+	}
+	t.timeoutDeadline = time.Time{}
+}
+
+// pauseTimeout stops the pending timeout timer, if any, and
+// returns the time that was left before it would have fired.
+// The ok result is false if no timeout was pending.
+func (t *T) pauseTimeout() (remaining time.Duration, ok bool) {
+	t.timeoutMu.Lock()
+	defer t.timeoutMu.Unlock()
+	if t.timeoutTimer == nil {
+		return 0, false
+	}
+	remaining = max(time.Until(t.timeoutDeadline), 0)
+	t.stopTimeoutLocked()
+	return remaining, true
+}
+
+// stopTimeout stops the pending timeout timer, if any, and prevents
+// new ones from starting. It is called when the test function returns,
+// and waits for a timeout that is being reported to finish.
+func (t *T) stopTimeout() {
+	t.timeoutMu.Lock()
+	defer t.timeoutMu.Unlock()
+	t.stopTimeoutLocked()
+	t.timeoutStopped = true
+}
+
+// goroutineID returns the ID of the calling goroutine.
+func goroutineID() uint64 {
+	var buf [64]byte
+	// The stack trace begins with "goroutine 123 [running]:".
+	s, _ := bytes.CutPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	s, _, _ = bytes.Cut(s, []byte(" "))
+	id, _ := strconv.ParseUint(string(s), 10, 64)
+	return id
+}
+
+// testStacks returns the stack traces of the goroutine with the given ID
+// and of the goroutines it started, directly or through goroutines that
+// are still running. If goid is 0, testStacks returns the stack traces of
+// all goroutines.
+func testStacks(goid uint64) string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	if goid == 0 {
+		return string(buf)
+	}
+
+	// Each goroutine's trace begins with "goroutine N [status]:" and,
+	// unless it is a system goroutine, ends with "created by F in goroutine M".
+	traces := strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n\n")
+	ids := make([]uint64, len(traces))
+	creators := make([]uint64, len(traces))
+	for i, trace := range traces {
+		fmt.Sscanf(trace, "goroutine %d", &ids[i])
+		if j := strings.LastIndex(trace, " in goroutine "); j >= 0 {
+			fmt.Sscanf(trace[j:], " in goroutine %d", &creators[i])
+		}
+	}
+	inTest := map[uint64]bool{goid: true}
+	for added := true; added; {
+		added = false
+		for i := range traces {
+			if !inTest[ids[i]] && inTest[creators[i]] {
+				inTest[ids[i]] = true
+				added = true
+			}
+		}
+	}
+	var b strings.Builder
+	for i, trace := range traces {
+		if inTest[ids[i]] {
+			b.WriteString(trace)
+			b.WriteString("\n\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
 // testState holds all fields that are common to all tests. This includes
 // synchronization primitives to run at most *parallel tests.
 type testState struct {
@@ -1921,6 +2338,7 @@ func (f matchStringOnly) StartCPUProfile(w io.Writer) error           { return e
 func (f matchStringOnly) StopCPUProfile()                             {}
 func (f matchStringOnly) WriteProfileTo(string, io.Writer, int) error { return errMain }
 func (f matchStringOnly) ImportPath() string                          { return "" }
+func (f matchStringOnly) ModulePath() string                          { return "" }
 func (f matchStringOnly) StartTestLog(io.Writer)                      {}
 func (f matchStringOnly) StopTestLog() error                          { return errMain }
 func (f matchStringOnly) SetPanicOnExit0(bool)                        {}
@@ -1973,6 +2391,7 @@ type M struct {
 // testing/internal/testdeps's TestDeps.
 type testDeps interface {
 	ImportPath() string
+	ModulePath() string
 	MatchString(pat, str string) (bool, error)
 	SetPanicOnExit0(bool)
 	StartCPUProfile(io.Writer) error
@@ -2075,6 +2494,17 @@ func (m *M) Run() (code int) {
 		return
 	}
 
+	if *shard != "" {
+		index, n, err := parseShard(*shard)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "testing:", err)
+			flag.Usage()
+			m.exitCode = 2
+			return
+		}
+		m.selectShard(index, n)
+	}
+
 	if *matchList != "" {
 		listTests(m.deps.MatchString, m.tests, m.benchmarks, m.fuzzTargets, m.examples)
 		m.exitCode = 0
@@ -2111,7 +2541,7 @@ func (m *M) Run() (code int) {
 	if !*isFuzzWorker {
 		deadline := m.startAlarm()
 		haveExamples = len(m.examples) > 0
-		testRan, testOk := runTests(m.deps.MatchString, m.tests, deadline)
+		testRan, testOk := runTests(m.deps.ModulePath(), m.deps.ImportPath(), m.deps.MatchString, m.tests, deadline)
 		fuzzTargetsRan, fuzzTargetsOk := runFuzzTests(m.deps, m.fuzzTargets, deadline)
 		exampleRan, exampleOk := runExamples(m.deps.MatchString, m.examples)
 		m.stopAlarm()
@@ -2161,6 +2591,10 @@ func (t *T) report() {
 	if t.parent == nil {
 		return
 	}
+	if t.isSynctest {
+		t.flushSynctestToParent()
+		return
+	}
 	dstr := fmtDuration(t.duration)
 	format := "--- %s: %s (%s)\n"
 	if t.Failed() {
@@ -2174,6 +2608,71 @@ func (t *T) report() {
 	}
 }
 
+// parseShard parses the -test.shard flag value "i/n",
+// returning the 1-based shard index i and the shard count n.
+func parseShard(s string) (index, n int, err error) {
+	is, ns, ok := strings.Cut(s, "/")
+	if ok {
+		index, err = strconv.Atoi(is)
+		if err == nil {
+			n, err = strconv.Atoi(ns)
+		}
+	}
+	if !ok || err != nil || n < 1 || index < 1 || index > n {
+		return 0, 0, fmt.Errorf("invalid -test.shard %q: must be i/n with 1 <= i <= n", s)
+	}
+	return index, n, nil
+}
+
+// inShard reports whether the top-level test, example, benchmark,
+// or fuzz test with the given name belongs to shard index of n.
+// The assignment is a hash of the package import path and the name,
+// so that it does not depend on which other tests exist in the binary
+// and tests of different packages spread evenly across shards.
+// The go command makes the same assignment to skip packages with
+// no tests in the shard, so the two must be kept in sync.
+func inShard(importPath, name string, index, n int) bool {
+	// FNV-1a, inlined to avoid a dependency on hash/fnv.
+	h := uint64(14695981039346656037)
+	for _, s := range [...]string{importPath, "\x00", name} {
+		for i := 0; i < len(s); i++ {
+			h ^= uint64(s[i])
+			h *= 1099511628211
+		}
+	}
+	// The low bits of FNV-1a depend only on the low bits of the input
+	// bytes, so mix the high bits down before reducing modulo n.
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return int(h%uint64(n)) == index-1
+}
+
+// selectShard removes from m the tests, examples, benchmarks,
+// and fuzz tests that do not belong to shard index of n.
+func (m *M) selectShard(index, n int) {
+	importPath := m.deps.ImportPath()
+	keep := func(name string) bool { return inShard(importPath, name, index, n) }
+	m.tests = filterShard(m.tests, func(t InternalTest) bool { return keep(t.Name) })
+	m.benchmarks = filterShard(m.benchmarks, func(b InternalBenchmark) bool { return keep(b.Name) })
+	m.fuzzTargets = filterShard(m.fuzzTargets, func(f InternalFuzzTarget) bool { return keep(f.Name) })
+	m.examples = filterShard(m.examples, func(e InternalExample) bool { return keep(e.Name) })
+}
+
+// filterShard returns a new slice holding the elements of s for which keep returns true.
+// It does not modify s, which is owned by the caller of MainStart.
+func filterShard[T any](s []T, keep func(T) bool) []T {
+	var out []T
+	for _, x := range s {
+		if keep(x) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
 func listTests(matchString func(pat, str string) (bool, error), tests []InternalTest, benchmarks []InternalBenchmark, fuzzTargets []InternalFuzzTarget, examples []InternalExample) {
 	if _, err := matchString(*matchList, "non-empty"); err != nil {
 		fmt.Fprintf(os.Stderr, "testing: invalid regexp in -test.list (%q): %s\n", *matchList, err)
@@ -2209,14 +2708,14 @@ func RunTests(matchString func(pat, str string) (bool, error), tests []InternalT
 	if *timeout > 0 {
 		deadline = time.Now().Add(*timeout)
 	}
-	ran, ok := runTests(matchString, tests, deadline)
+	ran, ok := runTests("", "", matchString, tests, deadline)
 	if !ran && !haveExamples {
 		fmt.Fprintln(os.Stderr, "testing: warning: no tests to run")
 	}
 	return ok
 }
 
-func runTests(matchString func(pat, str string) (bool, error), tests []InternalTest, deadline time.Time) (ran, ok bool) {
+func runTests(modulePath, importPath string, matchString func(pat, str string) (bool, error), tests []InternalTest, deadline time.Time) (ran, ok bool) {
 	ok = true
 	for _, procs := range cpuList {
 		runtime.GOMAXPROCS(procs)
@@ -2235,11 +2734,13 @@ func runTests(matchString func(pat, str string) (bool, error), tests []InternalT
 			tstate.deadline = deadline
 			t := &T{
 				common: common{
-					signal:    make(chan bool, 1),
-					barrier:   make(chan bool),
-					w:         os.Stdout,
-					ctx:       ctx,
-					cancelCtx: cancelCtx,
+					signal:     make(chan bool, 1),
+					barrier:    make(chan bool),
+					w:          os.Stdout,
+					ctx:        ctx,
+					cancelCtx:  cancelCtx,
+					modulePath: modulePath,
+					importPath: importPath,
 				},
 				tstate: tstate,
 			}
@@ -2308,6 +2809,18 @@ func (m *M) before() {
 		fmt.Fprintf(os.Stderr, "testing: cannot use -test.gocoverdir because test binary was not built with coverage enabled\n")
 		os.Exit(2)
 	}
+	if *artifacts {
+		var err error
+		artifactDir, err = filepath.Abs(toOutputDir("_artifacts"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "testing: cannot make -test.outputdir absolute: %v\n", err)
+			os.Exit(2)
+		}
+		if err := os.MkdirAll(artifactDir, 0777); err != nil {
+			fmt.Fprintf(os.Stderr, "testing: %v\n", err)
+			os.Exit(2)
+		}
+	}
 	if *testlog != "" {
 		// Note: Not using toOutputDir.
 		// This file is for use by cmd/go, not users.
@@ -435,12 +435,13 @@ func TestTesting(t *testing.T) {
 
 // runTest runs a helper test with -test.v, ignoring its exit status.
 // runTest both logs and returns the test output.
-func runTest(t *testing.T, test string) []byte {
+func runTest(t *testing.T, test string, args ...string) []byte {
 	t.Helper()
 
 	testenv.MustHaveExec(t)
 
 	cmd := testenv.Command(t, testenv.Executable(t), "-test.run=^"+test+"$", "-test.bench="+test, "-test.v", "-test.parallel=2", "-test.benchtime=2x")
+	cmd.Args = append(cmd.Args, args...)
 	cmd = testenv.CleanCmdEnv(cmd)
 	cmd.Env = append(cmd.Env, "GO_WANT_HELPER_PROCESS=1")
 	out, err := cmd.CombinedOutput()
@@ -974,6 +975,152 @@ func TestContext(t *testing.T) {
 	})
 }
 
+// TestAttrExample is used by TestAttrSet,
+// and also serves as a convenient test to run that sets an attribute.
+func TestAttrExample(t *testing.T) {
+	t.Attr("key", "value")
+}
+
+func TestAttrSet(t *testing.T) {
+	out := string(runTest(t, "TestAttrExample"))
+
+	want := "=== ATTR  TestAttrExample key value\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected output containing %q, got:\n%q", want, out)
+	}
+}
+
+func TestAttrInvalid(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"k ey", "value"},
+		{"k\tey", "value"},
+		{"k\rey", "value"},
+		{"k\ney", "value"},
+		{"key", "val\rue"},
+		{"key", "val\nue"},
+	}
+
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		for i, test := range tests {
+			t.Run(fmt.Sprint(i), func(t *testing.T) {
+				t.Attr(test.key, test.value)
+			})
+		}
+		return
+	}
+
+	out := string(runTest(t, "TestAttrInvalid"))
+
+	for i := range tests {
+		want := fmt.Sprintf("--- FAIL: TestAttrInvalid/%v ", i)
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output containing %q, got:\n%q", want, out)
+		}
+	}
+}
+
+const artifactContent = "It belongs in a museum.\n"
+
+func TestArtifactDirExample(t *testing.T) {
+	os.WriteFile(filepath.Join(t.ArtifactDir(), "artifact"), []byte(artifactContent), 0o666)
+}
+
+func TestArtifactDirDefault(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	out := runTest(t, "TestArtifactDirExample", "-test.artifacts")
+	checkArtifactDir(t, out, "TestArtifactDirExample", tempDir)
+}
+
+func TestArtifactDirSpecified(t *testing.T) {
+	tempDir := t.TempDir()
+	out := runTest(t, "TestArtifactDirExample", "-test.artifacts", "-test.outputdir="+tempDir)
+	checkArtifactDir(t, out, "TestArtifactDirExample", tempDir)
+}
+
+func TestArtifactDirNoArtifacts(t *testing.T) {
+	t.Chdir(t.TempDir())
+	out := string(runTest(t, "TestArtifactDirExample"))
+	if strings.Contains(out, "=== ARTIFACTS") {
+		t.Errorf("expected output with no === ARTIFACTS, got\n%q", out)
+	}
+	ents, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range ents {
+		t.Errorf("unexpected file in current directory after test: %v", e.Name())
+	}
+}
+
+func TestArtifactDirSubtestExample(t *testing.T) {
+	t.Run("Subtest", func(t *testing.T) {
+		os.WriteFile(filepath.Join(t.ArtifactDir(), "artifact"), []byte(artifactContent), 0o666)
+	})
+}
+
+func TestArtifactDirInSubtest(t *testing.T) {
+	tempDir := t.TempDir()
+	out := runTest(t, "TestArtifactDirSubtestExample/Subtest", "-test.artifacts", "-test.outputdir="+tempDir)
+	checkArtifactDir(t, out, "TestArtifactDirSubtestExample/Subtest", tempDir)
+}
+
+func TestArtifactDirLongTestNameExample(t *testing.T) {
+	name := strings.Repeat("x", 256)
+	t.Run(name, func(t *testing.T) {
+		os.WriteFile(filepath.Join(t.ArtifactDir(), "artifact"), []byte(artifactContent), 0o666)
+	})
+}
+
+func TestArtifactDirWithLongTestName(t *testing.T) {
+	tempDir := t.TempDir()
+	out := runTest(t, "TestArtifactDirLongTestNameExample", "-test.artifacts", "-test.outputdir="+tempDir)
+	checkArtifactDir(t, out, `TestArtifactDirLongTestNameExample/\w+`, tempDir)
+}
+
+func TestArtifactDirConsistent(t *testing.T) {
+	a := t.ArtifactDir()
+	b := t.ArtifactDir()
+	if a != b {
+		t.Errorf("t.ArtifactDir is not consistent between calls: %q, %q", a, b)
+	}
+}
+
+func checkArtifactDir(t *testing.T, out []byte, testName, outputDir string) {
+	t.Helper()
+
+	re := regexp.MustCompile(`=== ARTIFACTS ` + testName + ` ([^\n]+)`)
+	match := re.FindSubmatch(out)
+	if match == nil {
+		t.Fatalf("expected output matching %q, got\n%q", re, out)
+	}
+	artifactDir := string(match[1])
+
+	// Verify that the artifact directory is contained in the expected output directory.
+	relDir, err := filepath.Rel(outputDir, artifactDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filepath.IsLocal(relDir) {
+		t.Fatalf("want artifact directory contained in %q, got %q", outputDir, artifactDir)
+	}
+
+	for _, part := range strings.Split(relDir, string(os.PathSeparator)) {
+		const maxSize = 64
+		if len(part) > maxSize {
+			t.Errorf("artifact directory %q contains component >%v characters long: %q", relDir, maxSize, part)
+		}
+	}
+
+	got, err := os.ReadFile(filepath.Join(artifactDir, "artifact"))
+	if err != nil || string(got) != artifactContent {
+		t.Errorf("reading artifact in %q: got %q, %v; want %q", artifactDir, got, err, artifactContent)
+	}
+}
+
 func TestBenchmarkBLoopIterationCorrect(t *testing.T) {
 	out := runTest(t, "BenchmarkBLoopPrint")
 	c := bytes.Count(out, []byte("Printing from BenchmarkBLoopPrint"))
@@ -1029,3 +1176,157 @@ func BenchmarkBNPrint(b *testing.B) {
 		b.Logf("Printing from BenchmarkBNPrint")
 	}
 }
+
+func TestParseShard(t *testing.T) {
+	for _, s := range []string{"1/1", "3/16", "16/16"} {
+		if _, _, err := testing.ParseShard(s); err != nil {
+			t.Errorf("ParseShard(%q): %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "1", "0/4", "5/4", "1/0", "-1/4", "a/b", "1/2/3"} {
+		if _, _, err := testing.ParseShard(s); err == nil {
+			t.Errorf("ParseShard(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestInShard(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 7, 16} {
+		counts := make([]int, n)
+		for i := range 1000 {
+			name := fmt.Sprintf("Test%d", i)
+			var shards []int
+			for index := 1; index <= n; index++ {
+				if testing.InShard("example.com/pkg", name, index, n) {
+					shards = append(shards, index)
+				}
+			}
+			if len(shards) != 1 {
+				t.Fatalf("%s is in shards %v of %d, want exactly one", name, shards, n)
+			}
+			counts[shards[0]-1]++
+		}
+		for i, c := range counts {
+			if want := 1000 / n; c < want/2 {
+				t.Errorf("shard %d/%d has %d of 1000 tests, want about %d", i+1, n, c, want)
+			}
+		}
+	}
+}
+
+func TestShardList(t *testing.T) {
+	testenv.MustHaveExec(t)
+
+	list := func(args ...string) []string {
+		cmd := testenv.Command(t, testenv.Executable(t), append([]string{"-test.list=^TestSetenv"}, args...)...)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%v: %v\n%s", cmd, err, out)
+		}
+		return strings.Fields(string(out))
+	}
+
+	all := list()
+	var sharded []string
+	for index := 1; index <= 3; index++ {
+		sharded = append(sharded, list(fmt.Sprintf("-test.shard=%d/3", index))...)
+	}
+	slices.Sort(all)
+	slices.Sort(sharded)
+	if !slices.Equal(all, sharded) {
+		t.Errorf("union of shards = %v, want %v", sharded, all)
+	}
+
+	cmd := testenv.Command(t, testenv.Executable(t), "-test.run=^$", "-test.shard=4/3")
+	if out, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(out), "invalid -test.shard") {
+		t.Errorf("%v: %v\n%s; want invalid -test.shard error", cmd, err, out)
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		stop := make(chan struct{})
+		defer close(stop)
+		go unrelatedGoroutine(stop)
+
+		t.Run("Hung", func(t *testing.T) {
+			t.SetTimeout(10 * time.Millisecond)
+			if deadline, ok := t.Deadline(); !ok || time.Until(deadline) > time.Second {
+				t.Errorf("Deadline() = %v, %v; want deadline within 10ms", deadline, ok)
+			}
+			done := make(chan struct{})
+			go timedOutGoroutine(t.Context(), done)
+			<-done
+		})
+		t.Run("Reset", func(t *testing.T) {
+			t.SetTimeout(10 * time.Millisecond)
+			t.SetTimeout(0)
+			time.Sleep(50 * time.Millisecond)
+		})
+		t.Run("Fast", func(t *testing.T) {
+			t.SetTimeout(time.Minute)
+		})
+		t.Run("Cleanup", func(t *testing.T) {
+			t.SetTimeout(10 * time.Millisecond)
+			t.Cleanup(func() {
+				time.Sleep(50 * time.Millisecond)
+			})
+		})
+		return
+	}
+
+	out := string(runTest(t, "TestSetTimeout"))
+	for _, want := range []string{
+		"--- FAIL: TestSetTimeout/Hung ",
+		"test timed out after 10ms",
+		"testing_test.timedOutGoroutine(",
+		"--- PASS: TestSetTimeout/Reset ",
+		"--- PASS: TestSetTimeout/Fast ",
+		"--- PASS: TestSetTimeout/Cleanup ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+	// Only the goroutines of the test that timed out are reported.
+	if strings.Contains(out, "unrelatedGoroutine") {
+		t.Errorf("output contains stack of goroutine not started by the test")
+	}
+	if strings.Contains(out, "Deadline() =") {
+		t.Errorf("T.Deadline did not report the test timeout")
+	}
+}
+
+func TestTestTimeoutFlag(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		t.Run("Sub", func(t *testing.T) {
+			<-t.Context().Done()
+		})
+		return
+	}
+
+	// The timeout applies to the top-level test as a whole:
+	// the subtest blocks until the test's context is canceled,
+	// and is reported as part of the test's goroutines.
+	out := string(runTest(t, "TestTestTimeoutFlag", "-test.testtimeout=10ms"))
+	for _, want := range []string{
+		"--- FAIL: TestTestTimeoutFlag ",
+		"test timed out after 10ms",
+		"testing.(*T).Run",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+}
+
+// timedOutGoroutine closes done once ctx is canceled.
+func timedOutGoroutine(ctx context.Context, done chan<- struct{}) {
+	<-ctx.Done()
+	close(done)
+}
+
+// unrelatedGoroutine blocks until stop is closed.
+func unrelatedGoroutine(stop <-chan struct{}) {
+	<-stop
+}